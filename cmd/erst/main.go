@@ -7,20 +7,17 @@ import (
 	"os"
 
 	"github.com/dotandev/hintents/internal/cmd"
-	"github.com/dotandev/hintents/internal/updater"
 )
 
 var Version = "dev"
 
 func main() {
-	// Set version in cmd package
-	cmd.Version = Version
-
-	// Start update checker in background (non-blocking)
-	checker := updater.NewChecker(Version)
-	go checker.CheckForUpdates()
+	// Set version in cmd package, unless ldflags already stamped it there
+	if Version != "dev" {
+		cmd.Version = Version
+	}
 
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
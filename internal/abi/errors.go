@@ -0,0 +1,41 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+// ContractError is one case of a contract's error enum: the name a contract
+// gives a numeric error code, and its doc comment.
+type ContractError struct {
+	Enum string `json:"enum,omitempty"`
+	Name string `json:"name"`
+	Doc  string `json:"doc,omitempty"`
+}
+
+// ErrorCodes maps the numeric codes of every error enum in the spec to their
+// cases. Contracts normally define one error enum; should two define the
+// same code, the first wins.
+func (s *ContractSpec) ErrorCodes() map[uint32]ContractError {
+	codes := make(map[uint32]ContractError)
+	for _, enum := range s.ErrorEnums {
+		for _, c := range enum.Cases {
+			if _, ok := codes[uint32(c.Value)]; !ok {
+				codes[uint32(c.Value)] = ContractError{Enum: enum.Name, Name: c.Name, Doc: c.Doc}
+			}
+		}
+	}
+	return codes
+}
+
+// WasmErrorCodes reads the error codes from the contract spec embedded in a
+// WASM binary. It returns (nil, nil) when the WASM carries no spec.
+func WasmErrorCodes(wasm []byte) (map[uint32]ContractError, error) {
+	specBytes, err := ExtractCustomSection(wasm, "contractspecv0")
+	if err != nil || specBytes == nil {
+		return nil, err
+	}
+	spec, err := DecodeContractSpec(specBytes)
+	if err != nil {
+		return nil, err
+	}
+	return spec.ErrorCodes(), nil
+}
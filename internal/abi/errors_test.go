@@ -0,0 +1,45 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWasmErrorCodes(t *testing.T) {
+	spec := marshalEntries(t, xdr.ScSpecEntry{
+		Kind: xdr.ScSpecEntryKindScSpecEntryUdtErrorEnumV0,
+		UdtErrorEnumV0: &xdr.ScSpecUdtErrorEnumV0{
+			Name: "TokenError",
+			Cases: []xdr.ScSpecUdtErrorEnumCaseV0{
+				{Name: "NotInitialized", Value: 1},
+				{Name: "InsufficientBalance", Value: 5, Doc: "The sender cannot cover the amount."},
+			},
+		},
+	})
+	wasm := buildWasm(struct {
+		name    string
+		payload []byte
+	}{"contractspecv0", spec})
+
+	codes, err := WasmErrorCodes(wasm)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32]ContractError{
+		1: {Enum: "TokenError", Name: "NotInitialized"},
+		5: {Enum: "TokenError", Name: "InsufficientBalance", Doc: "The sender cannot cover the amount."},
+	}, codes)
+}
+
+func TestWasmErrorCodes_NoSpec(t *testing.T) {
+	codes, err := WasmErrorCodes(buildWasm())
+	require.NoError(t, err)
+	assert.Nil(t, codes)
+
+	_, err = WasmErrorCodes([]byte("not wasm"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package authtrace
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Credentials types of a SorobanAuthorizationEntry.
+const (
+	CredentialsSourceAccount = "source_account"
+	CredentialsAddress       = "address"
+)
+
+// sorobanAuthMarkers are lower-cased fragments of host errors and diagnostic
+// messages that indicate a failed require_auth check.
+var sorobanAuthMarkers = []string{
+	"error(auth,",
+	"auth(invalidaction)",
+	"auth(invalidinput)",
+	"nonce already exists",
+	"signature has expired",
+	"failed account authentication",
+	"unauthorized function call",
+}
+
+// SorobanAuthFailure describes the SorobanAuthorizationEntry most likely
+// responsible for a failed Soroban authorization check.
+type SorobanAuthFailure struct {
+	Reason           AuthFailureReason `json:"reason"`
+	OperationIndex   int               `json:"operation_index"`
+	InvokingContract string            `json:"invoking_contract,omitempty"`
+	Function         string            `json:"function,omitempty"`
+
+	// EntryIndex is the position of the failing entry in the operation's
+	// auth list, or -1 when no entry could be matched (e.g. the entry is
+	// missing altogether).
+	EntryIndex                int    `json:"entry_index"`
+	CredentialsType           string `json:"credentials_type,omitempty"`
+	Address                   string `json:"address,omitempty"`
+	ProvidedNonce             *int64 `json:"provided_nonce,omitempty"`
+	ExpectedNonce             string `json:"expected_nonce,omitempty"`
+	SignatureExpirationLedger uint32 `json:"signature_expiration_ledger,omitempty"`
+
+	HostMessage string `json:"host_message,omitempty"`
+}
+
+// Hint returns a one-line suggestion for fixing the failure.
+func (f *SorobanAuthFailure) Hint() string {
+	switch f.Reason {
+	case ReasonNonceReused:
+		return "Re-sign the authorization entry with a fresh random nonce."
+	case ReasonSignatureExpired:
+		return "Re-sign the authorization entry with a later signature expiration ledger."
+	case ReasonInvalidSignature:
+		return "Check that the entry was signed by the address's key over the correct network passphrase."
+	case ReasonMissingAuthEntry:
+		return "Add an authorization entry for the address, e.g. by re-simulating the transaction before signing."
+	default:
+		return "Inspect the host message and diagnostic events for the failing require_auth call."
+	}
+}
+
+type sorobanAuthEntry struct {
+	opIndex    int
+	entryIndex int
+	entry      xdr.SorobanAuthorizationEntry
+}
+
+// DetectSorobanAuthFailure inspects a simulation outcome for a failed Soroban
+// authorization check. simErr is the simulator error and diagnostics holds
+// the raw events, diagnostic events and logs as text. When an auth failure is
+// found, the transaction's authorization entries are matched against the
+// diagnostics to identify the failing one. It returns nil for non-auth
+// failures.
+func DetectSorobanAuthFailure(envelopeXdr, simErr string, diagnostics []string) *SorobanAuthFailure {
+	message := authMessage(simErr, diagnostics)
+	if message == "" {
+		return nil
+	}
+	combined := strings.ToLower(simErr + " " + strings.Join(diagnostics, " "))
+
+	failure := &SorobanAuthFailure{
+		Reason:      classifyAuthFailure(combined),
+		EntryIndex:  -1,
+		HostMessage: message,
+	}
+
+	var envelope xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &envelope); err != nil {
+		return failure
+	}
+
+	var entries []sorobanAuthEntry
+	for i, op := range envelope.Operations() {
+		invoke, ok := op.Body.GetInvokeHostFunctionOp()
+		if !ok {
+			continue
+		}
+		if failure.InvokingContract == "" {
+			failure.OperationIndex = i
+			failure.InvokingContract, failure.Function = invokedContract(invoke.HostFunction.InvokeContract)
+		}
+		for j, entry := range invoke.Auth {
+			entries = append(entries, sorobanAuthEntry{opIndex: i, entryIndex: j, entry: entry})
+		}
+	}
+
+	match := matchAuthEntry(entries, combined, failure.Reason)
+	if match == nil {
+		if failure.Reason == ReasonUnknown {
+			failure.Reason = ReasonMissingAuthEntry
+		}
+		return failure
+	}
+
+	failure.OperationIndex = match.opIndex
+	failure.EntryIndex = match.entryIndex
+	if fn := match.entry.RootInvocation.Function; fn.ContractFn != nil {
+		failure.InvokingContract, failure.Function = invokedContract(fn.ContractFn)
+	}
+
+	creds := match.entry.Credentials
+	if creds.Type == xdr.SorobanCredentialsTypeSorobanCredentialsSourceAccount {
+		failure.CredentialsType = CredentialsSourceAccount
+		return failure
+	}
+	failure.CredentialsType = CredentialsAddress
+	if creds.Address != nil {
+		failure.Address, _ = creds.Address.Address.String()
+		nonce := int64(creds.Address.Nonce)
+		failure.ProvidedNonce = &nonce
+		failure.SignatureExpirationLedger = uint32(creds.Address.SignatureExpirationLedger)
+		if failure.Reason == ReasonNonceReused {
+			// Soroban nonces are not sequential; the host only requires one
+			// that the address has not consumed yet.
+			failure.ExpectedNonce = "a nonce not yet consumed by " + failure.Address
+		}
+	}
+	return failure
+}
+
+// authMessage returns the first diagnostic line, or failing that the host
+// error, that mentions an authorization failure, or "" when there is none.
+func authMessage(simErr string, diagnostics []string) string {
+	for _, line := range append(append([]string(nil), diagnostics...), simErr) {
+		lc := strings.ToLower(line)
+		for _, marker := range sorobanAuthMarkers {
+			if strings.Contains(lc, marker) {
+				return strings.TrimSpace(line)
+			}
+		}
+	}
+	return ""
+}
+
+func classifyAuthFailure(combined string) AuthFailureReason {
+	switch {
+	case strings.Contains(combined, "nonce already exists"):
+		return ReasonNonceReused
+	case strings.Contains(combined, "signature has expired"):
+		return ReasonSignatureExpired
+	case strings.Contains(combined, "failed account authentication"),
+		strings.Contains(combined, "signature verification failed"),
+		strings.Contains(combined, "invalid signature"):
+		return ReasonInvalidSignature
+	case strings.Contains(combined, "unauthorized function call"):
+		return ReasonMissingAuthEntry
+	}
+	return ReasonUnknown
+}
+
+// matchAuthEntry picks the entry whose address (and preferably nonce) appears
+// in the diagnostics. Signature, nonce and expiry failures can only come from
+// address credentials, so with no textual match the first such entry is used.
+func matchAuthEntry(entries []sorobanAuthEntry, combined string, reason AuthFailureReason) *sorobanAuthEntry {
+	var byAddress *sorobanAuthEntry
+	for i := range entries {
+		creds := entries[i].entry.Credentials.Address
+		if creds == nil || !mentionsAddress(combined, creds.Address) {
+			continue
+		}
+		if strings.Contains(combined, strconv.FormatInt(int64(creds.Nonce), 10)) {
+			return &entries[i]
+		}
+		if byAddress == nil {
+			byAddress = &entries[i]
+		}
+	}
+	if byAddress != nil {
+		return byAddress
+	}
+
+	switch reason {
+	case ReasonNonceReused, ReasonSignatureExpired, ReasonInvalidSignature:
+		for i := range entries {
+			if entries[i].entry.Credentials.Address != nil {
+				return &entries[i]
+			}
+		}
+	}
+	return nil
+}
+
+// mentionsAddress reports whether text contains the address as a strkey or,
+// as the host's debug output prints it, as hex-encoded key bytes.
+func mentionsAddress(text string, addr xdr.ScAddress) bool {
+	if s, err := addr.String(); err == nil && strings.Contains(text, strings.ToLower(s)) {
+		return true
+	}
+
+	var raw []byte
+	switch {
+	case addr.AccountId != nil && addr.AccountId.Ed25519 != nil:
+		raw = addr.AccountId.Ed25519[:]
+	case addr.ContractId != nil:
+		raw = addr.ContractId[:]
+	}
+	return raw != nil && strings.Contains(text, hex.EncodeToString(raw))
+}
+
+func invokedContract(args *xdr.InvokeContractArgs) (contract, function string) {
+	if args == nil {
+		return "", ""
+	}
+	contract, err := args.ContractAddress.String()
+	if err != nil {
+		contract = fmt.Sprintf("<invalid address: %v>", err)
+	}
+	return contract, string(args.FunctionName)
+}
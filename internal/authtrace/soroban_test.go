@@ -0,0 +1,100 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package authtrace
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func authTestEnvelope(t *testing.T, entries ...xdr.SorobanAuthorizationEntry) string {
+	t.Helper()
+
+	contractID := xdr.ContractId{0xaa}
+	invoke := xdr.InvokeContractArgs{
+		ContractAddress: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractID},
+		FunctionName:    "transfer",
+	}
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: xdr.MustMuxedAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"),
+			Operations: []xdr.Operation{{Body: xdr.OperationBody{
+				Type: xdr.OperationTypeInvokeHostFunction,
+				InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+					HostFunction: xdr.HostFunction{Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract, InvokeContract: &invoke},
+					Auth:         entries,
+				},
+			}}},
+		}},
+	}
+	encoded, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return encoded
+}
+
+func addressEntry(key byte, nonce int64) xdr.SorobanAuthorizationEntry {
+	ed := xdr.Uint256{key}
+	contractID := xdr.ContractId{0xaa}
+	return xdr.SorobanAuthorizationEntry{
+		Credentials: xdr.SorobanCredentials{
+			Type: xdr.SorobanCredentialsTypeSorobanCredentialsAddress,
+			Address: &xdr.SorobanAddressCredentials{
+				Address: xdr.ScAddress{
+					Type:      xdr.ScAddressTypeScAddressTypeAccount,
+					AccountId: &xdr.AccountId{Type: xdr.PublicKeyTypePublicKeyTypeEd25519, Ed25519: &ed},
+				},
+				Nonce:                     xdr.Int64(nonce),
+				SignatureExpirationLedger: 5000,
+				Signature:                 xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+			},
+		},
+		RootInvocation: xdr.SorobanAuthorizedInvocation{Function: xdr.SorobanAuthorizedFunction{
+			Type: xdr.SorobanAuthorizedFunctionTypeSorobanAuthorizedFunctionTypeContractFn,
+			ContractFn: &xdr.InvokeContractArgs{
+				ContractAddress: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractID},
+				FunctionName:    "transfer",
+			},
+		}},
+	}
+}
+
+func TestDetectSorobanAuthFailure_NonceReusedPicksMatchingEntry(t *testing.T) {
+	envelope := authTestEnvelope(t, addressEntry(0x01, 7), addressEntry(0x02, 42))
+	key := hex.EncodeToString(append([]byte{0x02}, make([]byte, 31)...))
+
+	failure := DetectSorobanAuthFailure(envelope, "HostError: Error(Auth, ExistingValue)", []string{
+		`Symbol(error) Vec([String("nonce already exists for address"), Address(Account(` + key + `)), I64(42)])`,
+	})
+
+	require.NotNil(t, failure)
+	assert.Equal(t, ReasonNonceReused, failure.Reason)
+	assert.Equal(t, 1, failure.EntryIndex)
+	assert.Equal(t, CredentialsAddress, failure.CredentialsType)
+	require.NotNil(t, failure.ProvidedNonce)
+	assert.Equal(t, int64(42), *failure.ProvidedNonce)
+	assert.Contains(t, failure.ExpectedNonce, failure.Address)
+	assert.Equal(t, "transfer", failure.Function)
+	assert.NotEmpty(t, failure.InvokingContract)
+	assert.Contains(t, failure.HostMessage, "nonce already exists")
+}
+
+func TestDetectSorobanAuthFailure_MissingEntry(t *testing.T) {
+	envelope := authTestEnvelope(t)
+
+	failure := DetectSorobanAuthFailure(envelope, "HostError: Error(Auth, InvalidAction)", nil)
+
+	require.NotNil(t, failure)
+	assert.Equal(t, ReasonMissingAuthEntry, failure.Reason)
+	assert.Equal(t, -1, failure.EntryIndex)
+	assert.Equal(t, "transfer", failure.Function)
+}
+
+func TestDetectSorobanAuthFailure_IgnoresOtherErrors(t *testing.T) {
+	assert.Nil(t, DetectSorobanAuthFailure("", "HostError: Error(Budget, ExceededLimit)", []string{"cpu limit exceeded"}))
+}
@@ -22,6 +22,9 @@ const (
 	ReasonInvalidPublicKey     AuthFailureReason = "invalid_public_key"
 	ReasonExpiredPreAuth       AuthFailureReason = "expired_pre_auth"
 	ReasonCustomContractFailed AuthFailureReason = "custom_contract_failed"
+	ReasonNonceReused          AuthFailureReason = "nonce_reused"
+	ReasonSignatureExpired     AuthFailureReason = "signature_expired"
+	ReasonMissingAuthEntry     AuthFailureReason = "missing_auth_entry"
 	ReasonUnknown              AuthFailureReason = "unknown"
 )
 
@@ -0,0 +1,411 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bundle saves and loads self-contained debug sessions: the
+// transaction XDR, footprint keys and ledger entries needed to replay a
+// transaction offline. A bundle is either a directory of files or a single
+// .erst.tar.gz / .zip archive holding the same files.
+package bundle
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/snapshot"
+)
+
+// FormatVersion is the bundle layout written by this build. Bundles with a
+// newer version are rejected rather than misread.
+const FormatVersion = 1
+
+// Files contained in every bundle.
+const (
+	manifestFile = "manifest.json"
+	envelopeFile = "envelope.xdr"
+	resultFile   = "result.xdr"
+	metaFile     = "meta.xdr"
+	keysFile     = "keys.json"
+	entriesFile  = "entries.json"
+
+	// simulationFile is optional; redacted bundles carry their simulation
+	// result so they can be diffed without being simulated again.
+	simulationFile = "simulation.json"
+)
+
+// maxFileSize caps how much of any single archive member is read.
+const maxFileSize = 256 << 20
+
+// Manifest describes a bundle's origin and layout version.
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	TxHash        string    `json:"tx_hash"`
+	Network       string    `json:"network"`
+	ErstVersion   string    `json:"erst_version,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// ReplayHash identifies the bundle's simulation inputs; see ReplayHash.
+	// Bundles written before it was added leave it empty.
+	ReplayHash string `json:"replay_hash,omitempty"`
+
+	// Redacted is set when account addresses (and, with RedactedContracts,
+	// contract IDs) were replaced by pseudonyms. See Redact.
+	Redacted          bool `json:"redacted,omitempty"`
+	RedactedContracts bool `json:"redacted_contracts,omitempty"`
+}
+
+// Bundle is everything required to replay a transaction without network access.
+type Bundle struct {
+	Manifest      Manifest
+	EnvelopeXdr   string
+	ResultXdr     string
+	ResultMetaXdr string
+	Keys          []string
+	Entries       map[string]string
+
+	// Simulation is the stored simulation result, if one was saved.
+	Simulation *simulator.SimulationResponse
+}
+
+// New creates a bundle for the given transaction, stamped with the current
+// format version and time.
+func New(txHash, network, erstVersion string, tx *rpc.TransactionResponse, keys []string, entries map[string]string) *Bundle {
+	b := &Bundle{
+		Manifest: Manifest{
+			FormatVersion: FormatVersion,
+			TxHash:        txHash,
+			Network:       network,
+			ErstVersion:   erstVersion,
+			CreatedAt:     time.Now().UTC(),
+		},
+		EnvelopeXdr:   tx.EnvelopeXdr,
+		ResultXdr:     tx.ResultXdr,
+		ResultMetaXdr: tx.ResultMetaXdr,
+		Keys:          keys,
+		Entries:       entries,
+	}
+	b.Manifest.ReplayHash = b.replayHash()
+	return b
+}
+
+// Transaction returns the bundled transaction in the shape the RPC client
+// would have returned it.
+func (b *Bundle) Transaction() *rpc.TransactionResponse {
+	return &rpc.TransactionResponse{
+		EnvelopeXdr:   b.EnvelopeXdr,
+		ResultXdr:     b.ResultXdr,
+		ResultMetaXdr: b.ResultMetaXdr,
+	}
+}
+
+type archiveKind int
+
+const (
+	kindDir archiveKind = iota
+	kindTarGz
+	kindZip
+)
+
+func kindForPath(path string) archiveKind {
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return kindTarGz
+	case strings.HasSuffix(lower, ".zip"):
+		return kindZip
+	default:
+		return kindDir
+	}
+}
+
+// Save writes the bundle to path. Paths ending in .tar.gz/.tgz or .zip are
+// written as a single archive; any other path is treated as a directory.
+func Save(path string, b *Bundle) error {
+	files, err := b.encode()
+	if err != nil {
+		return err
+	}
+
+	switch kindForPath(path) {
+	case kindTarGz:
+		return writeTarGz(path, files)
+	case kindZip:
+		return writeZip(path, files)
+	default:
+		return writeDir(path, files)
+	}
+}
+
+// Load reads a bundle from a directory or archive.
+func Load(path string) (*Bundle, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+
+	var files map[string][]byte
+	switch {
+	case info.IsDir():
+		files, err = readDir(path)
+	case kindForPath(path) == kindZip:
+		files, err = readZip(path)
+	case kindForPath(path) == kindTarGz:
+		files, err = readTarGz(path)
+	default:
+		return nil, fmt.Errorf("unrecognised bundle %s: expected a directory, .tar.gz or .zip", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decode(files)
+}
+
+// fileOrder fixes the order files are written in, so archives are stable.
+var fileOrder = []string{manifestFile, envelopeFile, resultFile, metaFile, keysFile, entriesFile}
+
+// allFiles adds the optional files, which are skipped when absent.
+var allFiles = append(append([]string{}, fileOrder...), simulationFile)
+
+func (b *Bundle) encode() (map[string][]byte, error) {
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	keys, err := json.MarshalIndent(b.Keys, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal keys: %w", err)
+	}
+	entries, err := json.MarshalIndent(snapshot.FromMap(b.Entries), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal entries: %w", err)
+	}
+
+	files := map[string][]byte{
+		manifestFile: manifest,
+		envelopeFile: []byte(b.EnvelopeXdr),
+		resultFile:   []byte(b.ResultXdr),
+		metaFile:     []byte(b.ResultMetaXdr),
+		keysFile:     keys,
+		entriesFile:  entries,
+	}
+	if b.Simulation != nil {
+		sim, err := json.MarshalIndent(b.Simulation, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal simulation result: %w", err)
+		}
+		files[simulationFile] = sim
+	}
+	return files, nil
+}
+
+func decode(files map[string][]byte) (*Bundle, error) {
+	raw, ok := files[manifestFile]
+	if !ok {
+		return nil, fmt.Errorf("bundle is missing %s", manifestFile)
+	}
+	var b Bundle
+	if err := json.Unmarshal(raw, &b.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if v := b.Manifest.FormatVersion; v < 1 || v > FormatVersion {
+		return nil, fmt.Errorf("unsupported bundle format version %d (this build reads up to %d)", v, FormatVersion)
+	}
+
+	envelope, ok := files[envelopeFile]
+	if !ok || len(envelope) == 0 {
+		return nil, fmt.Errorf("bundle is missing %s", envelopeFile)
+	}
+	b.EnvelopeXdr = strings.TrimSpace(string(envelope))
+	b.ResultXdr = strings.TrimSpace(string(files[resultFile]))
+	b.ResultMetaXdr = strings.TrimSpace(string(files[metaFile]))
+
+	if raw, ok := files[keysFile]; ok {
+		if err := json.Unmarshal(raw, &b.Keys); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", keysFile, err)
+		}
+	}
+
+	b.Entries = map[string]string{}
+	if raw, ok := files[entriesFile]; ok {
+		var snap snapshot.Snapshot
+		if err := json.Unmarshal(raw, &snap); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", entriesFile, err)
+		}
+		b.Entries = snap.ToMap()
+	}
+
+	if raw, ok := files[simulationFile]; ok {
+		if err := json.Unmarshal(raw, &b.Simulation); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", simulationFile, err)
+		}
+	}
+
+	return &b, nil
+}
+
+func writeDir(dir string, files map[string][]byte) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+	for _, name := range allFiles {
+		data, ok := files[name]
+		if !ok {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func readDir(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, name := range allFiles {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+func writeTarGz(path string, files map[string][]byte) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range allFiles {
+		data, ok := files[name]
+		if !ok {
+			continue
+		}
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Now()}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write archive header for %s: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s to archive: %w", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalise archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finalise archive: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
+
+func readTarGz(path string) (map[string][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := readLimited(tr, hdr.Name)
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.Base(hdr.Name)] = data
+	}
+	return files, nil
+}
+
+func writeZip(path string, files map[string][]byte) error {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range allFiles {
+		data, ok := files[name]
+		if !ok {
+			continue
+		}
+		w, err := zw.Create(name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", name, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s to archive: %w", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalise archive: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
+
+func readZip(path string) (map[string][]byte, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip bundle: %w", err)
+	}
+	defer zr.Close()
+
+	files := make(map[string][]byte)
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s in archive: %w", zf.Name, err)
+		}
+		data, err := readLimited(rc, zf.Name)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.Base(zf.Name)] = data
+	}
+	return files, nil
+}
+
+func readLimited(r io.Reader, name string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from archive: %w", name, err)
+	}
+	if len(data) > maxFileSize {
+		return nil, fmt.Errorf("%s in archive exceeds %d bytes", name, maxFileSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleBundle() *Bundle {
+	tx := &rpc.TransactionResponse{EnvelopeXdr: "AAAAenv", ResultXdr: "AAAAres", ResultMetaXdr: "AAAAmeta"}
+	return New("abc123", "testnet", "v1.0.0", tx,
+		[]string{"key1", "key2"},
+		map[string]string{"key1": "val1", "key2": "val2"})
+}
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	for _, name := range []string{"session", "session.erst.tar.gz", "session.zip"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			want := sampleBundle()
+			require.NoError(t, Save(path, want))
+
+			got, err := Load(path)
+			require.NoError(t, err)
+
+			assert.Equal(t, FormatVersion, got.Manifest.FormatVersion)
+			assert.Equal(t, "abc123", got.Manifest.TxHash)
+			assert.Equal(t, "testnet", got.Manifest.Network)
+			assert.Equal(t, want.Transaction(), got.Transaction())
+			assert.Equal(t, want.Keys, got.Keys)
+			assert.Equal(t, want.Entries, got.Entries)
+		})
+	}
+}
+
+func TestLoad_RejectsNewerFormatVersion(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "session")
+	b := sampleBundle()
+	b.Manifest.FormatVersion = FormatVersion + 1
+	require.NoError(t, Save(dir, b))
+
+	_, err := Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported bundle format version")
+}
+
+func TestLoad_MissingManifest(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, envelopeFile), []byte("AAAA"), 0644))
+
+	_, err := Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), manifestFile)
+}
+
+func TestSave_DirectoryLayout(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "session")
+	require.NoError(t, Save(dir, sampleBundle()))
+
+	for _, name := range fileOrder {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	require.NoError(t, err)
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &m))
+	assert.EqualValues(t, FormatVersion, m["format_version"])
+}
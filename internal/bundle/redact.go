@@ -0,0 +1,230 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Pseudonym domains, so an account and a contract with the same bytes do not
+// share a pseudonym.
+const (
+	redactAccountDomain  = "erst-redact:account:"
+	redactContractDomain = "erst-redact:contract:"
+	redactTxDomain       = "erst-redact:tx:"
+)
+
+// Redact replaces every account address in the bundle, and every contract ID
+// when contracts is set, with a pseudonym derived from it by hashing. The same
+// address always maps to the same pseudonym, so references stay consistent
+// within the bundle and across bundles redacted by any build; the transaction
+// hash is replaced the same way. Pseudonyms hide addresses from casual reading,
+// but anyone who already suspects an address can confirm it.
+//
+// The XDR is decoded, rewritten and re-encoded, so it stays well formed, and
+// ledger keys are rewritten to match their entries. Signatures are zeroed and
+// no longer verify, and bytes inside contract values are left as they are, so
+// a redacted bundle usually cannot be simulated again; the stored simulation
+// result is redacted too and is what erst diff compares.
+func (b *Bundle) Redact(contracts bool) error {
+	r := newRedactor(true, contracts)
+
+	var err error
+	if b.EnvelopeXdr, err = redactXDR(r, b.EnvelopeXdr, &xdr.TransactionEnvelope{}); err != nil {
+		return fmt.Errorf("failed to redact envelope: %w", err)
+	}
+	if b.ResultXdr, err = redactXDR(r, b.ResultXdr, &xdr.TransactionResult{}); err != nil {
+		return fmt.Errorf("failed to redact result: %w", err)
+	}
+	if b.ResultMetaXdr, err = redactXDR(r, b.ResultMetaXdr, &xdr.TransactionResultMeta{}); err != nil {
+		return fmt.Errorf("failed to redact result meta: %w", err)
+	}
+
+	keys := make([]string, len(b.Keys))
+	for i, k := range b.Keys {
+		if keys[i], err = redactXDR(r, k, &xdr.LedgerKey{}); err != nil {
+			return fmt.Errorf("failed to redact ledger key %s: %w", k, err)
+		}
+	}
+	entries := make(map[string]string, len(b.Entries))
+	for k, v := range b.Entries {
+		key, err := redactXDR(r, k, &xdr.LedgerKey{})
+		if err != nil {
+			return fmt.Errorf("failed to redact ledger key %s: %w", k, err)
+		}
+		if entries[key], err = redactXDR(r, v, &xdr.LedgerEntry{}); err != nil {
+			return fmt.Errorf("failed to redact ledger entry for key %s: %w", k, err)
+		}
+	}
+	b.Keys, b.Entries = keys, entries
+
+	if b.Simulation != nil {
+		raw, err := json.Marshal(b.Simulation)
+		if err != nil {
+			return fmt.Errorf("failed to redact simulation result: %w", err)
+		}
+		raw = r.redactText(raw)
+		b.Simulation = nil
+		if err := json.Unmarshal(raw, &b.Simulation); err != nil {
+			return fmt.Errorf("failed to redact simulation result: %w", err)
+		}
+	}
+
+	if b.Manifest.TxHash != "" {
+		b.Manifest.TxHash = hex.EncodeToString(pseudonym(redactTxDomain, []byte(b.Manifest.TxHash)))
+	}
+	b.Manifest.ReplayHash = b.replayHash()
+	b.Manifest.Redacted = true
+	b.Manifest.RedactedContracts = contracts
+	return nil
+}
+
+// RedactAddresses replaces the account strkeys in text when accounts is set,
+// and the contract strkeys when contracts is set, with the pseudonyms Redact
+// gives them, so redacted output and redacted bundles agree.
+func RedactAddresses(text []byte, accounts, contracts bool) []byte {
+	return newRedactor(accounts, contracts).redactText(text)
+}
+
+// RedactLedgerKey redacts the base64 LedgerKey key like RedactAddresses does.
+func RedactLedgerKey(key string, accounts, contracts bool) (string, error) {
+	return redactXDR(newRedactor(accounts, contracts), key, &xdr.LedgerKey{})
+}
+
+type redactor struct {
+	accounts  bool
+	contracts bool
+	// hints maps the signature hint of each redacted account to the hint of
+	// its pseudonym.
+	hints map[xdr.SignatureHint]xdr.SignatureHint
+}
+
+func newRedactor(accounts, contracts bool) *redactor {
+	return &redactor{accounts: accounts, contracts: contracts, hints: make(map[xdr.SignatureHint]xdr.SignatureHint)}
+}
+
+func pseudonym(domain string, raw []byte) []byte {
+	sum := sha256.Sum256(append([]byte(domain), raw...))
+	return sum[:]
+}
+
+func (r *redactor) account(key *xdr.Uint256) {
+	if !r.accounts {
+		return
+	}
+	var hint xdr.SignatureHint
+	copy(hint[:], key[28:])
+	copy(key[:], pseudonym(redactAccountDomain, key[:]))
+	var replaced xdr.SignatureHint
+	copy(replaced[:], key[28:])
+	r.hints[hint] = replaced
+}
+
+func (r *redactor) contract(id *xdr.ContractId) {
+	if r.contracts {
+		copy(id[:], pseudonym(redactContractDomain, id[:]))
+	}
+}
+
+// redactXDR decodes b64 into v, redacts it and re-encodes it. Empty input is
+// returned unchanged.
+func redactXDR(r *redactor, b64 string, v interface{}) (string, error) {
+	if b64 == "" {
+		return "", nil
+	}
+	if err := xdr.SafeUnmarshalBase64(b64, v); err != nil {
+		return "", err
+	}
+	r.walk(reflect.ValueOf(v).Elem())
+	return xdr.MarshalBase64(v)
+}
+
+var (
+	uint256Type            = reflect.TypeOf(xdr.Uint256{})
+	contractIDType         = reflect.TypeOf(xdr.ContractId{})
+	decoratedSignatureType = reflect.TypeOf(xdr.DecoratedSignature{})
+
+	// accountKeyTypes hold an account's ed25519 key in a field named Ed25519.
+	accountKeyTypes = map[reflect.Type]bool{
+		reflect.TypeOf(xdr.AccountId{}):                     true,
+		reflect.TypeOf(xdr.PublicKey{}):                     true,
+		reflect.TypeOf(xdr.MuxedAccount{}):                  true,
+		reflect.TypeOf(xdr.MuxedAccountMed25519{}):          true,
+		reflect.TypeOf(xdr.MuxedEd25519Account{}):           true,
+		reflect.TypeOf(xdr.SignerKey{}):                     true,
+		reflect.TypeOf(xdr.SignerKeyEd25519SignedPayload{}): true,
+	}
+)
+
+// walk redacts every account key, contract ID and signature reachable from v.
+func (r *redactor) walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			r.walk(v.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			r.walk(v.Index(i))
+		}
+	case reflect.Array:
+		if v.Type() == contractIDType {
+			r.contract(v.Addr().Interface().(*xdr.ContractId))
+		}
+	case reflect.Struct:
+		if v.Type() == decoratedSignatureType {
+			sig := v.Addr().Interface().(*xdr.DecoratedSignature)
+			sig.Hint = r.hints[sig.Hint]
+			sig.Signature = make(xdr.Signature, len(sig.Signature))
+			return
+		}
+		if accountKeyTypes[v.Type()] {
+			switch f := v.FieldByName("Ed25519"); {
+			case f.Type() == uint256Type:
+				r.account(f.Addr().Interface().(*xdr.Uint256))
+			case f.Kind() == reflect.Ptr && !f.IsNil():
+				r.account(f.Interface().(*xdr.Uint256))
+			}
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Name != "Ed25519" || !accountKeyTypes[v.Type()] {
+				r.walk(v.Field(i))
+			}
+		}
+	}
+}
+
+// strkeyPattern matches G... account, M... muxed account and C... contract
+// addresses.
+var strkeyPattern = regexp.MustCompile(`\b(?:[GC][A-Z2-7]{55}|M[A-Z2-7]{68})\b`)
+
+// redactText replaces account and contract addresses written out as strkeys,
+// as they appear in simulation output. A muxed account keeps its ID and has
+// its underlying account replaced.
+func (r *redactor) redactText(text []byte) []byte {
+	return strkeyPattern.ReplaceAllFunc(text, func(m []byte) []byte {
+		version, raw, err := strkey.DecodeAny(string(m))
+		if err != nil || len(raw) < 32 {
+			return m
+		}
+		switch {
+		case (version == strkey.VersionByteAccountID || version == strkey.VersionByteMuxedAccount) && r.accounts:
+			var key xdr.Uint256
+			copy(key[:], raw)
+			r.account(&key)
+			return []byte(strkey.MustEncode(version, append(key[:], raw[32:]...)))
+		case version == strkey.VersionByteContract && r.contracts:
+			return []byte(strkey.MustEncode(version, pseudonym(redactContractDomain, raw)))
+		}
+		return m
+	})
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	redactSource   = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+	redactReceiver = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+)
+
+var redactContractID = xdr.ContractId{1, 2, 3, 4}
+
+func redactContract(t *testing.T) string {
+	t.Helper()
+	c, err := strkey.Encode(strkey.VersionByteContract, redactContractID[:])
+	require.NoError(t, err)
+	return c
+}
+
+func accountKeyAndEntry(t *testing.T, address string) (string, string) {
+	t.Helper()
+	entry := xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type:    xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{AccountId: xdr.MustAddress(address), Balance: 100},
+		},
+	}
+	key, err := entry.LedgerKey()
+	require.NoError(t, err)
+	k, err := xdr.MarshalBase64(key)
+	require.NoError(t, err)
+	e, err := xdr.MarshalBase64(entry)
+	require.NoError(t, err)
+	return k, e
+}
+
+func redactableBundle(t *testing.T) *Bundle {
+	t.Helper()
+	source := xdr.MustAddress(redactSource)
+	receiver := xdr.MustAddress(redactReceiver)
+	contract := redactContractID
+	var hint xdr.SignatureHint
+	copy(hint[:], source.Ed25519[28:])
+
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: xdr.MustMuxedAddress(redactSource),
+				Fee:           100,
+				SeqNum:        1,
+				Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+				Operations: []xdr.Operation{{
+					Body: xdr.OperationBody{
+						Type: xdr.OperationTypeInvokeHostFunction,
+						InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+							HostFunction: xdr.HostFunction{
+								Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+								InvokeContract: &xdr.InvokeContractArgs{
+									ContractAddress: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contract},
+									FunctionName:    "transfer",
+									Args: []xdr.ScVal{{
+										Type:    xdr.ScValTypeScvAddress,
+										Address: &xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeAccount, AccountId: &receiver},
+									}},
+								},
+							},
+						},
+					},
+				}},
+			},
+			Signatures: []xdr.DecoratedSignature{{Hint: hint, Signature: make(xdr.Signature, 64)}},
+		},
+	}
+	env.V1.Signatures[0].Signature[0] = 0xff
+	envXdr, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	srcKey, srcEntry := accountKeyAndEntry(t, redactSource)
+	rcvKey, rcvEntry := accountKeyAndEntry(t, redactReceiver)
+	b := New("deadbeef", "testnet", "v1", &rpc.TransactionResponse{EnvelopeXdr: envXdr},
+		[]string{srcKey, rcvKey}, map[string]string{srcKey: srcEntry, rcvKey: rcvEntry})
+	contractID := redactContract(t)
+	b.Simulation = &simulator.SimulationResponse{
+		Status: "success",
+		DiagnosticEvents: []simulator.DiagnosticEvent{{
+			EventType:  "contract",
+			ContractID: &contractID,
+			Topics:     []string{"transfer", redactSource, redactReceiver},
+			Data:       "100",
+		}},
+	}
+	return b
+}
+
+// bundleText joins everything in b that could carry an address.
+func bundleText(t *testing.T, b *Bundle) string {
+	t.Helper()
+	files, err := b.encode()
+	require.NoError(t, err)
+	var parts []string
+	for _, data := range files {
+		parts = append(parts, string(data))
+	}
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(b.EnvelopeXdr, &env))
+	source := env.SourceAccount().ToAccountId()
+	parts = append(parts, source.Address())
+	for _, e := range b.Entries {
+		var entry xdr.LedgerEntry
+		require.NoError(t, xdr.SafeUnmarshalBase64(e, &entry))
+		parts = append(parts, entry.Data.Account.AccountId.Address())
+	}
+	return strings.Join(parts, "\n")
+}
+
+func TestRedact_ReplacesAccounts(t *testing.T) {
+	b := redactableBundle(t)
+	require.NoError(t, b.Redact(false))
+
+	text := bundleText(t, b)
+	assert.NotContains(t, text, redactSource)
+	assert.NotContains(t, text, redactReceiver)
+	assert.NotContains(t, text, "deadbeef")
+	assert.Contains(t, text, redactContract(t), "contract IDs are kept unless requested")
+	assert.True(t, b.Manifest.Redacted)
+	assert.False(t, b.Manifest.RedactedContracts)
+}
+
+func TestRedact_Contracts(t *testing.T) {
+	b := redactableBundle(t)
+	require.NoError(t, b.Redact(true))
+
+	assert.NotContains(t, bundleText(t, b), redactContract(t))
+	assert.True(t, b.Manifest.RedactedContracts)
+
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(b.EnvelopeXdr, &env))
+	addr := env.V1.Tx.Operations[0].Body.InvokeHostFunctionOp.HostFunction.InvokeContract.ContractAddress
+	id, err := strkey.Encode(strkey.VersionByteContract, addr.ContractId[:])
+	require.NoError(t, err)
+	assert.Equal(t, id, *b.Simulation.DiagnosticEvents[0].ContractID, "XDR and simulation output use the same pseudonym")
+}
+
+func TestRedact_ConsistentAndDeterministic(t *testing.T) {
+	a, b := redactableBundle(t), redactableBundle(t)
+	require.NoError(t, a.Redact(false))
+	require.NoError(t, b.Redact(false))
+	assert.Equal(t, a.EnvelopeXdr, b.EnvelopeXdr)
+	assert.Equal(t, a.Entries, b.Entries)
+	assert.Equal(t, a.Manifest.TxHash, b.Manifest.TxHash)
+
+	// Every key still belongs to its entry.
+	require.Len(t, a.Keys, 2)
+	for _, k := range a.Keys {
+		var entry xdr.LedgerEntry
+		require.NoError(t, xdr.SafeUnmarshalBase64(a.Entries[k], &entry))
+		key, err := entry.LedgerKey()
+		require.NoError(t, err)
+		own, err := xdr.MarshalBase64(key)
+		require.NoError(t, err)
+		assert.Equal(t, k, own)
+	}
+
+	// The source account has one pseudonym across the envelope, its ledger
+	// entry, the signature hint and the simulation output.
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(a.EnvelopeXdr, &env))
+	source := env.SourceAccount().ToAccountId()
+	assert.Equal(t, source.Address(), a.Simulation.DiagnosticEvents[0].Topics[1])
+	assert.Contains(t, bundleText(t, a), source.Address())
+	assert.Equal(t, source.Ed25519[28:], env.V1.Signatures[0].Hint[:])
+	assert.Equal(t, make(xdr.Signature, 64), env.V1.Signatures[0].Signature)
+}
+
+func TestRedact_SaveLoad(t *testing.T) {
+	b := redactableBundle(t)
+	require.NoError(t, b.Redact(false))
+	path := filepath.Join(t.TempDir(), "redacted.zip")
+	require.NoError(t, Save(path, b))
+
+	got, err := Load(path)
+	require.NoError(t, err)
+	assert.True(t, got.Manifest.Redacted)
+	assert.Equal(t, b.Simulation, got.Simulation)
+	assert.Equal(t, b.Entries, got.Entries)
+}
+
+func TestRedactAddresses_MatchesBundlePseudonyms(t *testing.T) {
+	b := redactableBundle(t)
+	require.NoError(t, b.Redact(true))
+	contractID := redactContract(t)
+
+	text := string(RedactAddresses([]byte(`{"a":"`+redactSource+`","c":"`+contractID+`"}`), true, true))
+	assert.NotContains(t, text, redactSource)
+	assert.NotContains(t, text, contractID)
+	assert.Contains(t, text, b.Simulation.DiagnosticEvents[0].Topics[1], "same pseudonym as a redacted bundle")
+	assert.Contains(t, text, *b.Simulation.DiagnosticEvents[0].ContractID)
+
+	text = string(RedactAddresses([]byte(redactSource+" "+contractID), false, true))
+	assert.Contains(t, text, redactSource, "accounts are kept unless requested")
+	assert.NotContains(t, text, contractID)
+
+	key, _ := accountKeyAndEntry(t, redactSource)
+	redacted, err := RedactLedgerKey(key, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, b.Keys[0], redacted)
+	kept, err := RedactLedgerKey(key, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, key, kept)
+}
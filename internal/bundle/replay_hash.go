@@ -0,0 +1,92 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"sort"
+	"strings"
+)
+
+// replayHashVersion prefixes every replay hash, so a change to what is hashed
+// never makes an old hash match new inputs.
+const replayHashVersion = "erst-replay-v1"
+
+// ReplayHash returns a stable SHA-256 over everything a replay simulates:
+// the envelope, the result meta, the footprint keys and the ledger entries.
+// The XDR is hashed decoded, so differences in base64 padding or whitespace
+// do not count, and keys and entries are hashed sorted, so their order does
+// not either. Two runs with the same replay hash simulate identical inputs.
+func ReplayHash(envelopeXdr, resultMetaXdr string, keys []string, entries map[string]string) string {
+	h := sha256.New()
+	writeField(h, []byte(replayHashVersion))
+	writeField(h, normalizeXDR(envelopeXdr))
+	writeField(h, normalizeXDR(resultMetaXdr))
+
+	sortedKeys := make([]string, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		n := string(normalizeXDR(k))
+		if !seen[n] {
+			seen[n] = true
+			sortedKeys = append(sortedKeys, n)
+		}
+	}
+	sort.Strings(sortedKeys)
+	writeCount(h, len(sortedKeys))
+	for _, k := range sortedKeys {
+		writeField(h, []byte(k))
+	}
+
+	type entry struct{ key, value []byte }
+	sortedEntries := make([]entry, 0, len(entries))
+	for k, v := range entries {
+		sortedEntries = append(sortedEntries, entry{normalizeXDR(k), normalizeXDR(v)})
+	}
+	sort.Slice(sortedEntries, func(i, j int) bool {
+		return string(sortedEntries[i].key) < string(sortedEntries[j].key)
+	})
+	writeCount(h, len(sortedEntries))
+	for _, e := range sortedEntries {
+		writeField(h, e.key)
+		writeField(h, e.value)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replayHash is the replay hash of the bundle's contents.
+func (b *Bundle) replayHash() string {
+	return ReplayHash(b.EnvelopeXdr, b.ResultMetaXdr, b.Keys, b.Entries)
+}
+
+// normalizeXDR decodes base64 XDR to its bytes. Anything that is not base64
+// is hashed as trimmed text.
+func normalizeXDR(s string) []byte {
+	s = strings.TrimSpace(s)
+	if raw, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return raw
+	}
+	if raw, err := base64.RawStdEncoding.DecodeString(s); err == nil {
+		return raw
+	}
+	return []byte(s)
+}
+
+// writeField length-prefixes each field, so adjacent fields cannot run
+// together into the same bytes.
+func writeField(h hash.Hash, b []byte) {
+	writeCount(h, len(b))
+	h.Write(b)
+}
+
+func writeCount(h hash.Hash, n int) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	h.Write(buf[:])
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayHash_Stable(t *testing.T) {
+	keys := []string{"AAAAAQ==", "AAAAAg=="}
+	entries := map[string]string{"AAAAAQ==": "AAAAAw==", "AAAAAg==": "AAAABA=="}
+
+	first := ReplayHash("AAAAenv=", "AAAAmeta", keys, entries)
+	assert.Len(t, first, 64)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, ReplayHash("AAAAenv=", "AAAAmeta", keys, entries))
+	}
+}
+
+func TestReplayHash_IgnoresOrderAndEncoding(t *testing.T) {
+	want := ReplayHash("AAAAenv=", "AAAAmeta", []string{"AAAAAQ==", "AAAAAg=="},
+		map[string]string{"AAAAAQ==": "AAAAAw==", "AAAAAg==": "AAAABA=="})
+
+	assert.Equal(t, want, ReplayHash(" AAAAenv=\n", "AAAAmeta", []string{"AAAAAg==", "AAAAAQ==", "AAAAAg=="},
+		map[string]string{"AAAAAg==": "AAAABA==", "AAAAAQ==": "AAAAAw=="}))
+}
+
+func TestReplayHash_ChangesWithInputs(t *testing.T) {
+	keys := []string{"AAAAAQ=="}
+	entries := map[string]string{"AAAAAQ==": "AAAAAw=="}
+	base := ReplayHash("AAAAenv=", "AAAAmeta", keys, entries)
+
+	assert.NotEqual(t, base, ReplayHash("AAAAenw=", "AAAAmeta", keys, entries))
+	assert.NotEqual(t, base, ReplayHash("AAAAenv=", "AAAAmetb", keys, entries))
+	assert.NotEqual(t, base, ReplayHash("AAAAenv=", "AAAAmeta", []string{"AAAAAg=="}, entries))
+	assert.NotEqual(t, base, ReplayHash("AAAAenv=", "AAAAmeta", keys, map[string]string{"AAAAAQ==": "AAAABA=="}))
+	// Moving bytes between fields must change the hash.
+	assert.NotEqual(t, ReplayHash("ab", "c", nil, nil), ReplayHash("a", "bc", nil, nil))
+}
+
+func TestNew_RecordsReplayHash(t *testing.T) {
+	b := sampleBundle()
+	assert.Equal(t, ReplayHash(b.EnvelopeXdr, b.ResultMetaXdr, b.Keys, b.Entries), b.Manifest.ReplayHash)
+
+	path := filepath.Join(t.TempDir(), "session.zip")
+	require.NoError(t, Save(path, b))
+	got, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, b.Manifest.ReplayHash, got.Manifest.ReplayHash)
+	assert.Equal(t, got.Manifest.ReplayHash, got.replayHash())
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/report"
+)
+
+// budgetCriticalPercent matches the CRITICAL threshold used when printing
+// resource usage in the debug command.
+const budgetCriticalPercent = 95.0
+
+func init() {
+	Register("success", CheckerFunc(checkSuccess))
+	Register("no-budget-critical", CheckerFunc(checkBudgetCritical))
+	Register("no-compare-divergence", CheckerFunc(checkNoDivergence))
+}
+
+// checkSuccess requires the primary simulation to have succeeded.
+func checkSuccess(r *report.DebugReport) []Finding {
+	if r.Status() == "success" {
+		return nil
+	}
+	msg := fmt.Sprintf("simulation status is %q", r.Status())
+	if r.Result != nil && r.Result.Error != "" {
+		msg += ": " + r.Result.Error
+	}
+	return []Finding{{Check: "success", Message: msg}}
+}
+
+// checkBudgetCritical flags CPU or memory usage at or above the critical threshold.
+func checkBudgetCritical(r *report.DebugReport) []Finding {
+	if r.Result == nil || r.Result.BudgetUsage == nil {
+		return nil
+	}
+	usage := r.Result.BudgetUsage
+	var findings []Finding
+	if usage.CPUUsagePercent >= budgetCriticalPercent {
+		findings = append(findings, Finding{
+			Check:   "no-budget-critical",
+			Message: fmt.Sprintf("CPU usage %.2f%% is at or above %.0f%%", usage.CPUUsagePercent, budgetCriticalPercent),
+		})
+	}
+	if usage.MemoryUsagePercent >= budgetCriticalPercent {
+		findings = append(findings, Finding{
+			Check:   "no-budget-critical",
+			Message: fmt.Sprintf("memory usage %.2f%% is at or above %.0f%%", usage.MemoryUsagePercent, budgetCriticalPercent),
+		})
+	}
+	return findings
+}
+
+// checkNoDivergence fails when a cross-network comparison found differences.
+func checkNoDivergence(r *report.DebugReport) []Finding {
+	if r.Diff == nil || !r.Diff.HasDivergence {
+		return nil
+	}
+	return []Finding{{
+		Check:   "no-compare-divergence",
+		Message: fmt.Sprintf("%s and %s diverged (%d divergent events)", r.Network, r.CompareNetwork, r.Diff.DivergentEvents),
+	}}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package checks runs post-simulation assertions against a DebugReport.
+// Checkers are small, composable rules ("did event X fire", "did the run stay
+// within budget") that emit findings; any finding marks the run as failed so
+// CI jobs can gate on it.
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dotandev/hintents/internal/report"
+)
+
+// Finding is a single failed check.
+type Finding struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("[%s] %s", f.Check, f.Message)
+}
+
+// Checker inspects a debug report and returns a finding for every violated
+// expectation. An empty result means the check passed.
+type Checker interface {
+	Check(*report.DebugReport) []Finding
+}
+
+// CheckerFunc adapts an ordinary function to the Checker interface.
+type CheckerFunc func(*report.DebugReport) []Finding
+
+func (f CheckerFunc) Check(r *report.DebugReport) []Finding {
+	return f(r)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Checker)
+)
+
+// Register makes a checker available by name to rule files (`builtin <name>`).
+// Registering a name twice replaces the previous checker.
+func Register(name string, c Checker) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = c
+}
+
+// Lookup returns the checker registered under name.
+func Lookup(name string) (Checker, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	c, ok := registry[name]
+	return c, ok
+}
+
+// Names returns the sorted names of all registered checkers.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run executes every checker against the report and collects their findings.
+func Run(r *report.DebugReport, checkers []Checker) []Finding {
+	var findings []Finding
+	for _, c := range checkers {
+		findings = append(findings, c.Check(r)...)
+	}
+	return findings
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// EventExpectation requires the primary simulation to have emitted a decoded
+// event matching every field that is set: the emitting contract, each topic
+// (exactly, as --filter-topic matches them) and text contained in the data.
+// Unset fields match anything, so "topic=transfer" alone is a valid
+// expectation.
+type EventExpectation struct {
+	Spec     string
+	Contract string
+	Topics   []string
+	Data     string
+}
+
+// ParseEventExpectation parses an expectation of the form
+//
+//	contract=<id>,topic=<symbol>,data=<text>
+//
+// in any order and with any field left out. topic may be given more than
+// once; the event must carry all of them.
+func ParseEventExpectation(spec string) (*EventExpectation, error) {
+	e := &EventExpectation{Spec: spec}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%q is not of the form <field>=<value>", part)
+		}
+		switch key {
+		case "contract":
+			e.Contract = value
+		case "topic":
+			e.Topics = append(e.Topics, value)
+		case "data":
+			e.Data = value
+		default:
+			return nil, fmt.Errorf("unknown field %q (expected contract, topic or data)", key)
+		}
+	}
+	if e.Contract == "" && len(e.Topics) == 0 && e.Data == "" {
+		return nil, fmt.Errorf("expectation %q sets no field", spec)
+	}
+	return e, nil
+}
+
+// Match reports whether ev satisfies the expectation.
+func (e *EventExpectation) Match(ev simulator.DiagnosticEvent) bool {
+	if e.Contract != "" && (ev.ContractID == nil || *ev.ContractID != e.Contract) {
+		return false
+	}
+	for _, topic := range e.Topics {
+		if !(simulator.EventFilter{Topics: []string{topic}}).MatchDiagnostic(ev) {
+			return false
+		}
+	}
+	return e.Data == "" || strings.Contains(ev.Data, e.Data)
+}
+
+func (e *EventExpectation) Check(r *report.DebugReport) []Finding {
+	check := "expect-event " + e.Spec
+	if r.Result == nil {
+		return []Finding{{Check: check, Message: "no simulation result"}}
+	}
+	events := r.Result.DiagnosticEvents
+	for _, ev := range events {
+		if e.Match(ev) {
+			return nil
+		}
+	}
+	return []Finding{{Check: check, Message: fmt.Sprintf("none of the %d decoded events matched", len(events))}}
+}
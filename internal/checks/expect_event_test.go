@@ -0,0 +1,75 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+func eventReport() *report.DebugReport {
+	token := "CTOKEN"
+	r := report.NewDebugReport("abc", "testnet")
+	r.Result = &simulator.SimulationResponse{
+		Status: "success",
+		DiagnosticEvents: []simulator.DiagnosticEvent{
+			{EventType: "contract", ContractID: &token, Topics: []string{`Symbol("transfer")`, "GALICE", "GBOB"}, Data: "I128(1000)"},
+		},
+	}
+	return r
+}
+
+func TestParseEventExpectation(t *testing.T) {
+	e, err := ParseEventExpectation("contract=CTOKEN, topic=transfer,topic=GBOB,data=1000")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if e.Contract != "CTOKEN" || len(e.Topics) != 2 || e.Data != "1000" {
+		t.Errorf("unexpected expectation: %+v", e)
+	}
+
+	for _, spec := range []string{"", "topic", "topic=", "kind=transfer"} {
+		if _, err := ParseEventExpectation(spec); err == nil {
+			t.Errorf("expected an error for %q", spec)
+		}
+	}
+}
+
+func TestEventExpectation_Check(t *testing.T) {
+	tests := []struct {
+		spec string
+		pass bool
+	}{
+		{"topic=transfer", true},
+		{"contract=CTOKEN,topic=transfer,data=1000", true},
+		{"topic=transfer,topic=GBOB", true},
+		{"topic=trans", false},
+		{"topic=mint", false},
+		{"contract=COTHER,topic=transfer", false},
+		{"topic=transfer,data=2000", false},
+	}
+	for _, tt := range tests {
+		e, err := ParseEventExpectation(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error: %v", tt.spec, err)
+		}
+		findings := e.Check(eventReport())
+		if (len(findings) == 0) != tt.pass {
+			t.Errorf("%s: expected pass=%v, got findings %v", tt.spec, tt.pass, findings)
+		}
+		if !tt.pass && len(findings) == 1 && !strings.Contains(findings[0].String(), tt.spec) {
+			t.Errorf("%s: finding should name the expectation, got %q", tt.spec, findings[0])
+		}
+	}
+}
+
+func TestEventExpectation_NoResult(t *testing.T) {
+	e, _ := ParseEventExpectation("topic=transfer")
+	if findings := e.Check(report.NewDebugReport("abc", "testnet")); len(findings) != 1 {
+		t.Errorf("expected a finding without a result, got %v", findings)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// Rule files contain one rule per line; blank lines and lines starting with
+// '#' are ignored. Supported rules:
+//
+//	builtin <name>              run a registered checker (see Names)
+//	status == <value>           primary status must equal value (also !=)
+//	event <text>                some event must contain text
+//	no_event <text>             no event may contain text
+//	<metric> <op> <number>      compare a numeric metric
+//
+// Metrics: cpu_instructions, memory_bytes, cpu_percent, memory_percent,
+// events, diagnostic_events, logs. Operators: == != < <= > >=.
+
+// LoadRuleFile parses a rule file into checkers.
+func LoadRuleFile(path string) ([]Checker, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to open rule file: %v", err))
+	}
+	defer f.Close()
+
+	checkers, err := ParseRules(f)
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("%s: %v", path, err))
+	}
+	return checkers, nil
+}
+
+// ParseRules parses rules from r.
+func ParseRules(r io.Reader) ([]Checker, error) {
+	var checkers []Checker
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		c, err := parseRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		checkers = append(checkers, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return checkers, nil
+}
+
+func parseRule(line string) (Checker, error) {
+	fields := strings.Fields(line)
+	keyword := fields[0]
+
+	switch keyword {
+	case "builtin":
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("usage: builtin <name>")
+		}
+		c, ok := Lookup(fields[1])
+		if !ok {
+			return nil, fmt.Errorf("unknown builtin check %q (available: %s)", fields[1], strings.Join(Names(), ", "))
+		}
+		return c, nil
+
+	case "event", "no_event":
+		text := strings.TrimSpace(strings.TrimPrefix(line, keyword))
+		if text == "" {
+			return nil, fmt.Errorf("usage: %s <text>", keyword)
+		}
+		return eventRule{rule: line, text: text, want: keyword == "event"}, nil
+
+	case "status":
+		if len(fields) != 3 || (fields[1] != "==" && fields[1] != "!=") {
+			return nil, fmt.Errorf("usage: status ==|!= <value>")
+		}
+		return statusRule{rule: line, negate: fields[1] == "!=", value: fields[2]}, nil
+	}
+
+	if _, ok := metrics[keyword]; ok {
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("usage: %s <op> <number>", keyword)
+		}
+		if _, ok := comparators[fields[1]]; !ok {
+			return nil, fmt.Errorf("unknown operator %q", fields[1])
+		}
+		n, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", fields[2])
+		}
+		return metricRule{rule: line, metric: keyword, op: fields[1], value: n}, nil
+	}
+
+	return nil, fmt.Errorf("unknown rule %q", keyword)
+}
+
+type statusRule struct {
+	rule   string
+	negate bool
+	value  string
+}
+
+func (s statusRule) Check(r *report.DebugReport) []Finding {
+	if (r.Status() == s.value) != s.negate {
+		return nil
+	}
+	return []Finding{{Check: s.rule, Message: fmt.Sprintf("status is %q", r.Status())}}
+}
+
+type eventRule struct {
+	rule string
+	text string
+	want bool
+}
+
+func (e eventRule) Check(r *report.DebugReport) []Finding {
+	found := r.Result != nil && responseHasEvent(r.Result, e.text)
+	switch {
+	case e.want && !found:
+		return []Finding{{Check: e.rule, Message: fmt.Sprintf("no event containing %q was emitted", e.text)}}
+	case !e.want && found:
+		return []Finding{{Check: e.rule, Message: fmt.Sprintf("an event containing %q was emitted", e.text)}}
+	}
+	return nil
+}
+
+func responseHasEvent(res *simulator.SimulationResponse, text string) bool {
+	for _, ev := range res.Events {
+		if strings.Contains(ev, text) {
+			return true
+		}
+	}
+	for _, ev := range res.DiagnosticEvents {
+		if strings.Contains(ev.Data, text) || strings.Contains(strings.Join(ev.Topics, " "), text) {
+			return true
+		}
+	}
+	return false
+}
+
+var metrics = map[string]func(*simulator.SimulationResponse) float64{
+	"cpu_instructions": func(r *simulator.SimulationResponse) float64 {
+		if r.BudgetUsage == nil {
+			return 0
+		}
+		return float64(r.BudgetUsage.CPUInstructions)
+	},
+	"memory_bytes": func(r *simulator.SimulationResponse) float64 {
+		if r.BudgetUsage == nil {
+			return 0
+		}
+		return float64(r.BudgetUsage.MemoryBytes)
+	},
+	"cpu_percent": func(r *simulator.SimulationResponse) float64 {
+		if r.BudgetUsage == nil {
+			return 0
+		}
+		return r.BudgetUsage.CPUUsagePercent
+	},
+	"memory_percent": func(r *simulator.SimulationResponse) float64 {
+		if r.BudgetUsage == nil {
+			return 0
+		}
+		return r.BudgetUsage.MemoryUsagePercent
+	},
+	"events":            func(r *simulator.SimulationResponse) float64 { return float64(len(r.Events)) },
+	"diagnostic_events": func(r *simulator.SimulationResponse) float64 { return float64(len(r.DiagnosticEvents)) },
+	"logs":              func(r *simulator.SimulationResponse) float64 { return float64(len(r.Logs)) },
+}
+
+var comparators = map[string]func(a, b float64) bool{
+	"==": func(a, b float64) bool { return a == b },
+	"!=": func(a, b float64) bool { return a != b },
+	"<":  func(a, b float64) bool { return a < b },
+	"<=": func(a, b float64) bool { return a <= b },
+	">":  func(a, b float64) bool { return a > b },
+	">=": func(a, b float64) bool { return a >= b },
+}
+
+type metricRule struct {
+	rule   string
+	metric string
+	op     string
+	value  float64
+}
+
+func (m metricRule) Check(r *report.DebugReport) []Finding {
+	if r.Result == nil {
+		return []Finding{{Check: m.rule, Message: "no simulation result"}}
+	}
+	actual := metrics[m.metric](r.Result)
+	if comparators[m.op](actual, m.value) {
+		return nil
+	}
+	return []Finding{{Check: m.rule, Message: fmt.Sprintf("%s is %s", m.metric, strconv.FormatFloat(actual, 'f', -1, 64))}}
+}
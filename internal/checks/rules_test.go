@@ -0,0 +1,92 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+func testReport() *report.DebugReport {
+	r := report.NewDebugReport("abc", "testnet")
+	r.Result = &simulator.SimulationResponse{
+		Status: "success",
+		Events: []string{"transfer alice bob 10"},
+		BudgetUsage: &simulator.BudgetUsage{
+			CPUInstructions: 5000,
+			CPUUsagePercent: 50,
+		},
+	}
+	return r
+}
+
+func TestParseRules_AllPass(t *testing.T) {
+	rules := `
+# everything here should hold
+builtin success
+status == success
+event transfer
+no_event burn
+cpu_instructions < 10000
+events >= 1
+`
+	checkers, err := ParseRules(strings.NewReader(rules))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if len(checkers) != 6 {
+		t.Fatalf("expected 6 checkers, got %d", len(checkers))
+	}
+
+	if findings := Run(testReport(), checkers); len(findings) != 0 {
+		t.Errorf("expected no findings, got %v", findings)
+	}
+}
+
+func TestParseRules_Failures(t *testing.T) {
+	rules := "status != success\nevent mint\ncpu_percent <= 10\n"
+	checkers, err := ParseRules(strings.NewReader(rules))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	findings := Run(testReport(), checkers)
+	if len(findings) != 3 {
+		t.Fatalf("expected 3 findings, got %v", findings)
+	}
+	if !strings.Contains(findings[2].Message, "cpu_percent is 50") {
+		t.Errorf("unexpected metric message: %q", findings[2].Message)
+	}
+}
+
+func TestParseRules_Errors(t *testing.T) {
+	cases := []string{
+		"builtin does-not-exist",
+		"status = success",
+		"cpu_percent ~ 10",
+		"events > many",
+		"frobnicate",
+	}
+	for _, rule := range cases {
+		if _, err := ParseRules(strings.NewReader(rule)); err == nil {
+			t.Errorf("expected error for rule %q", rule)
+		}
+	}
+}
+
+func TestBuiltinBudgetCritical(t *testing.T) {
+	r := testReport()
+	r.Result.BudgetUsage.MemoryUsagePercent = 99
+
+	c, ok := Lookup("no-budget-critical")
+	if !ok {
+		t.Fatal("expected no-budget-critical to be registered")
+	}
+	if findings := c.Check(r); len(findings) != 1 {
+		t.Errorf("expected 1 finding, got %v", findings)
+	}
+}
@@ -31,12 +31,7 @@ Examples:
   erst auth-debug --json <tx-hash>`,
 	Args: cobra.ExactArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		switch rpc.Network(authNetworkFlag) {
-		case rpc.Testnet, rpc.Mainnet, rpc.Futurenet:
-		default:
-			return errors.WrapInvalidNetwork(authNetworkFlag)
-		}
-		return nil
+		return parseNetworkFlag(&authNetworkFlag)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		txHash := args[0]
@@ -0,0 +1,327 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+// benchLedgerKeyLimit caps how many ledger keys each getLedgerEntries call
+// asks for, so the benchmark measures a typical request rather than the
+// largest footprint it happened to pick.
+const benchLedgerKeyLimit = 10
+
+// RPC methods timed by erst bench, in report order.
+const (
+	benchMethodGetTransaction   = "getTransaction"
+	benchMethodGetLedgerEntries = "getLedgerEntries"
+	benchMethodGetHealth        = "getHealth"
+)
+
+var (
+	benchNetworkFlag    string
+	benchIterationsFlag int
+	benchRPCURLsFlag    []string
+	benchTxFlag         string
+	benchOutputFlag     string
+)
+
+// LatencyStats summarises the latencies observed for one RPC method on one
+// endpoint. Durations only cover successful calls.
+type LatencyStats struct {
+	Method   string  `json:"method"`
+	Samples  int     `json:"samples"`
+	Errors   int     `json:"errors"`
+	MinMs    float64 `json:"min_ms"`
+	MedianMs float64 `json:"median_ms"`
+	P95Ms    float64 `json:"p95_ms"`
+	MaxMs    float64 `json:"max_ms"`
+}
+
+// EndpointBenchmark holds the per-method results for one endpoint.
+type EndpointBenchmark struct {
+	URL     string         `json:"url"`
+	Methods []LatencyStats `json:"methods"`
+}
+
+// BenchReport is the output of erst bench.
+type BenchReport struct {
+	Network     string              `json:"network"`
+	Transaction string              `json:"transaction"`
+	Iterations  int                 `json:"iterations"`
+	LedgerKeys  int                 `json:"ledger_keys"`
+	Endpoints   []EndpointBenchmark `json:"endpoints"`
+}
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Measure RPC latency for the calls erst makes",
+	Long: `Repeatedly fetch a known transaction and the ledger entries it touched,
+and report min, median, p95 and max latency for each RPC method.
+
+Pass --rpc-url more than once to compare providers side by side. Each URL is
+used for both Horizon and Soroban RPC calls, as single-URL providers serve
+both; without --rpc-url the network's default endpoints are measured.
+
+The transaction defaults to the latest one on the network. Caching is
+disabled so every iteration reaches the endpoint.`,
+	Example: `  # Benchmark the default mainnet endpoints
+  erst bench --network mainnet --iterations 20
+
+  # Compare two providers on the same transaction
+  erst bench -n mainnet --rpc-url https://rpc-a.example --rpc-url https://rpc-b.example --tx <hash>`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		switch benchOutputFlag {
+		case outputFormatText, outputFormatJSON:
+		default:
+			return errors.WrapValidationError(fmt.Sprintf("unsupported output format %q (expected text or json)", benchOutputFlag))
+		}
+		if benchIterationsFlag < 1 {
+			return errors.WrapValidationError("--iterations must be at least 1")
+		}
+		if OfflineFlag {
+			return errors.WrapValidationError("erst bench needs network access and cannot run with --offline")
+		}
+		if benchTxFlag != "" {
+			if err := rpc.ValidateTransactionHash(benchTxFlag); err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("invalid --tx: %v", err))
+			}
+		}
+		return parseNetworkFlag(&benchNetworkFlag)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		progress := progressWriter(cmd)
+
+		clients, urls, err := benchClients(rpc.Network(benchNetworkFlag), benchURLs())
+		if err != nil {
+			return err
+		}
+
+		hash := benchTxFlag
+		if hash == "" {
+			if hash, err = clients[0].LatestTransactionHash(ctx); err != nil {
+				return err
+			}
+			fmt.Fprintf(progress, "Using latest transaction %s\n", hash)
+		}
+
+		tx, err := clients[0].GetTransaction(ctx, hash)
+		if err != nil {
+			return err
+		}
+		keys, err := extractLedgerKeys(tx.ResultMetaXdr)
+		if err != nil {
+			return errors.WrapUnmarshalFailed(err, "result meta")
+		}
+		if len(keys) > benchLedgerKeyLimit {
+			keys = keys[:benchLedgerKeyLimit]
+		}
+
+		report := BenchReport{
+			Network:     benchNetworkFlag,
+			Transaction: hash,
+			Iterations:  benchIterationsFlag,
+			LedgerKeys:  len(keys),
+		}
+		for i, client := range clients {
+			fmt.Fprintf(progress, "Benchmarking %s (%d iterations)...\n", urls[i], benchIterationsFlag)
+			report.Endpoints = append(report.Endpoints, EndpointBenchmark{
+				URL:     urls[i],
+				Methods: benchEndpoint(ctx, client, hash, keys, benchIterationsFlag),
+			})
+		}
+
+		if benchOutputFlag == outputFormatJSON {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return errors.WrapMarshalFailed(err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+
+		printBenchReport(cmd.OutOrStdout(), report)
+		return nil
+	},
+}
+
+// benchURLs returns the --rpc-url endpoints, which may be repeated or
+// comma-separated, with blanks dropped.
+func benchURLs() []string {
+	var urls []string
+	for _, url := range benchRPCURLsFlag {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// benchClients builds one uncached client per endpoint. With no URLs it
+// returns a single client on the network's default endpoints.
+func benchClients(network rpc.Network, urls []string) ([]*rpc.Client, []string, error) {
+	base := []rpc.ClientOption{
+		rpc.WithNetwork(network),
+		rpc.WithToken(os.Getenv("ERST_RPC_TOKEN")),
+		rpc.WithCacheEnabled(false),
+	}
+
+	if len(urls) == 0 {
+		client, err := rpc.NewClient(base...)
+		if err != nil {
+			return nil, nil, errors.WrapValidationError(fmt.Sprintf("failed to create client for %s: %v", network, err))
+		}
+		return []*rpc.Client{client}, []string{client.HorizonURL}, nil
+	}
+
+	clients := make([]*rpc.Client, 0, len(urls))
+	for _, url := range urls {
+		opts := append(append([]rpc.ClientOption{}, base...), rpc.WithHorizonURL(url), rpc.WithSorobanURL(url))
+		client, err := rpc.NewClient(opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		clients = append(clients, client)
+	}
+	return clients, urls, nil
+}
+
+// benchEndpoint times each RPC method iterations times against client.
+func benchEndpoint(ctx context.Context, client *rpc.Client, hash string, keys []string, iterations int) []LatencyStats {
+	type call struct {
+		method string
+		fn     func() error
+	}
+	calls := []call{
+		{benchMethodGetTransaction, func() error {
+			_, err := client.GetTransaction(ctx, hash)
+			return err
+		}},
+	}
+	if len(keys) > 0 {
+		calls = append(calls, call{benchMethodGetLedgerEntries, func() error {
+			_, err := client.GetLedgerEntries(ctx, keys)
+			return err
+		}})
+	}
+	calls = append(calls, call{benchMethodGetHealth, func() error {
+		_, err := client.GetHealth(ctx)
+		return err
+	}})
+
+	stats := make([]LatencyStats, 0, len(calls))
+	for _, c := range calls {
+		var samples []time.Duration
+		failed := 0
+		for i := 0; i < iterations; i++ {
+			start := time.Now()
+			if err := c.fn(); err != nil {
+				failed++
+				continue
+			}
+			samples = append(samples, time.Since(start))
+		}
+		s := summarizeLatencies(samples)
+		s.Method = c.method
+		s.Errors = failed
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// summarizeLatencies computes min, median, p95 and max over samples. The
+// median averages the two middle samples for even counts; p95 uses the
+// nearest-rank method.
+func summarizeLatencies(samples []time.Duration) LatencyStats {
+	s := LatencyStats{Samples: len(samples)}
+	if len(samples) == 0 {
+		return s
+	}
+
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	n := len(sorted)
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	rank := int(math.Ceil(0.95*float64(n))) - 1
+
+	s.MinMs = durationMs(sorted[0])
+	s.MedianMs = durationMs(median)
+	s.P95Ms = durationMs(sorted[rank])
+	s.MaxMs = durationMs(sorted[n-1])
+	return s
+}
+
+func durationMs(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*10) / 10
+}
+
+// printBenchReport prints one table per method with a row per endpoint, so
+// providers can be compared side by side.
+func printBenchReport(out io.Writer, report BenchReport) {
+	fmt.Fprintf(out, "Network: %s\n", report.Network)
+	fmt.Fprintf(out, "Transaction: %s\n", report.Transaction)
+	fmt.Fprintf(out, "Iterations: %d, ledger keys per request: %d\n", report.Iterations, report.LedgerKeys)
+
+	width := len("endpoint")
+	var methods []string
+	seen := make(map[string]bool)
+	for _, e := range report.Endpoints {
+		if len(e.URL) > width {
+			width = len(e.URL)
+		}
+		for _, m := range e.Methods {
+			if !seen[m.Method] {
+				seen[m.Method] = true
+				methods = append(methods, m.Method)
+			}
+		}
+	}
+
+	for _, method := range methods {
+		fmt.Fprintf(out, "\n%s\n", method)
+		fmt.Fprintf(out, "  %-*s %9s %9s %9s %9s %7s\n", width, "endpoint", "min", "median", "p95", "max", "errors")
+		fmt.Fprintf(out, "  %s\n", strings.Repeat("-", width+48))
+		for _, e := range report.Endpoints {
+			for _, m := range e.Methods {
+				if m.Method != method {
+					continue
+				}
+				if m.Samples == 0 {
+					fmt.Fprintf(out, "  %-*s %9s %9s %9s %9s %7d\n", width, e.URL, "-", "-", "-", "-", m.Errors)
+					continue
+				}
+				fmt.Fprintf(out, "  %-*s %7.1fms %7.1fms %7.1fms %7.1fms %7d\n",
+					width, e.URL, m.MinMs, m.MedianMs, m.P95Ms, m.MaxMs, m.Errors)
+			}
+		}
+	}
+}
+
+func init() {
+	benchCmd.Flags().StringVarP(&benchNetworkFlag, "network", "n", string(rpc.Mainnet), "Stellar network to benchmark (testnet, mainnet, futurenet)")
+	benchCmd.Flags().IntVar(&benchIterationsFlag, "iterations", 20, "Number of times to call each RPC method")
+	benchCmd.Flags().StringSliceVar(&benchRPCURLsFlag, "rpc-url", nil, "RPC endpoint to benchmark; repeat to compare endpoints side by side")
+	benchCmd.Flags().StringVar(&benchTxFlag, "tx", "", "Transaction hash to fetch (default: latest transaction on the network)")
+	benchCmd.Flags().StringVar(&benchOutputFlag, "output", outputFormatText, "Output format: text or json")
+
+	rootCmd.AddCommand(benchCmd)
+}
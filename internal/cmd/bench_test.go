@@ -0,0 +1,65 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeLatencies(t *testing.T) {
+	var samples []time.Duration
+	for i := 20; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	s := summarizeLatencies(samples)
+	assert.Equal(t, 20, s.Samples)
+	assert.Equal(t, 1.0, s.MinMs)
+	assert.Equal(t, 10.5, s.MedianMs)
+	assert.Equal(t, 19.0, s.P95Ms)
+	assert.Equal(t, 20.0, s.MaxMs)
+}
+
+func TestSummarizeLatencies_Empty(t *testing.T) {
+	assert.Equal(t, LatencyStats{}, summarizeLatencies(nil))
+}
+
+func TestPrintBenchReport(t *testing.T) {
+	var buf bytes.Buffer
+	printBenchReport(&buf, BenchReport{
+		Network:     "mainnet",
+		Transaction: "abc",
+		Iterations:  5,
+		LedgerKeys:  2,
+		Endpoints: []EndpointBenchmark{
+			{URL: "https://a", Methods: []LatencyStats{{Method: benchMethodGetTransaction, Samples: 5, MinMs: 10, MedianMs: 12, P95Ms: 20, MaxMs: 21}}},
+			{URL: "https://b", Methods: []LatencyStats{{Method: benchMethodGetTransaction, Errors: 5}}},
+		},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "getTransaction\n")
+	assert.Contains(t, out, "https://a    10.0ms    12.0ms    20.0ms    21.0ms       0")
+	assert.Contains(t, out, "https://b         -         -         -         -       5")
+}
+
+func TestBenchPreRunE_Validation(t *testing.T) {
+	prevNet, prevIter, prevTx, prevOut := benchNetworkFlag, benchIterationsFlag, benchTxFlag, benchOutputFlag
+	t.Cleanup(func() {
+		benchNetworkFlag, benchIterationsFlag, benchTxFlag, benchOutputFlag = prevNet, prevIter, prevTx, prevOut
+	})
+
+	benchNetworkFlag, benchIterationsFlag, benchTxFlag, benchOutputFlag = "mainnet", 20, "", outputFormatJSON
+	assert.NoError(t, benchCmd.PreRunE(benchCmd, nil))
+
+	benchIterationsFlag = 0
+	assert.Error(t, benchCmd.PreRunE(benchCmd, nil))
+
+	benchIterationsFlag, benchTxFlag = 20, "not-a-hash"
+	assert.Error(t, benchCmd.PreRunE(benchCmd, nil))
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/config"
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changesNetworkFlag string
+	changesRPCURLFlag  string
+	changesRPCToken    string
+	changesOutputFlag  string
+)
+
+// changesOutput is the JSON form of `erst changes`.
+type changesOutput struct {
+	TxHash  string                 `json:"tx_hash"`
+	Network string                 `json:"network"`
+	Changes []decoder.LedgerChange `json:"changes"`
+}
+
+var changesCmd = &cobra.Command{
+	Use:   "changes <transaction-hash>",
+	Short: "List the ledger entries a transaction changed, without simulating it",
+	Long: `Fetch a transaction and list every ledger entry its result meta records as
+created, updated, removed or restored, with the decoded key and value.
+
+Nothing is simulated, so this is a quick way to answer "what did this
+transaction do?".
+
+Examples:
+  erst changes 5c0a1234567890abcdef1234567890abcdef1234567890abcdef1234567890ab
+  erst changes --network testnet --output json <tx-hash>`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := rpc.ValidateTransactionHash(args[0]); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("invalid transaction hash: %v", err))
+		}
+		if changesOutputFlag != outputFormatText && changesOutputFlag != outputFormatJSON {
+			return errors.WrapValidationError(fmt.Sprintf("unsupported output format %q (expected text or json)", changesOutputFlag))
+		}
+		return parseNetworkFlag(&changesNetworkFlag)
+	},
+	RunE: runChanges,
+}
+
+func runChanges(cmd *cobra.Command, args []string) error {
+	txHash := args[0]
+	token := changesRPCToken
+	if token == "" {
+		token = os.Getenv("ERST_RPC_TOKEN")
+	}
+	if token == "" {
+		if cfg, err := config.LoadConfig(); err == nil && cfg.RPCToken != "" {
+			token = cfg.RPCToken
+		}
+	}
+
+	opts := []rpc.ClientOption{
+		rpc.WithNetwork(rpc.Network(changesNetworkFlag)),
+		rpc.WithToken(token),
+	}
+	if changesRPCURLFlag != "" {
+		opts = append(opts, rpc.WithHorizonURL(changesRPCURLFlag))
+	}
+	client, err := rpc.NewClient(opts...)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to create client: %v", err))
+	}
+
+	resp, err := client.GetTransaction(cmd.Context(), txHash)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(resp.ResultMetaXdr) == "" {
+		return errors.WrapValidationError("the endpoint returned no result meta for this transaction, so its changes are unknown; try another --rpc-url")
+	}
+	changes, err := decoder.LedgerChanges(resp.ResultMetaXdr)
+	if err != nil {
+		return errors.WrapUnmarshalFailed(err, "result meta")
+	}
+
+	if changesOutputFlag == outputFormatJSON {
+		if err := report.WriteJSON(cmd.OutOrStdout(), changesOutput{TxHash: txHash, Network: changesNetworkFlag, Changes: changes}); err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+		return nil
+	}
+	printLedgerChanges(cmd.OutOrStdout(), changes)
+	return nil
+}
+
+// printLedgerChanges lists each change on one line, with the entry's value
+// before and after it on the lines below.
+func printLedgerChanges(out io.Writer, changes []decoder.LedgerChange) {
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "The transaction changed no ledger entries")
+		return
+	}
+	fmt.Fprintf(out, "%d ledger entry changes:\n", len(changes))
+	for i, c := range changes {
+		fmt.Fprintf(out, "\n%d. %-8s %s %s\n", i+1, c.Kind, c.KeyType, c.Description)
+		if c.Before != "" {
+			fmt.Fprintf(out, "   before: %s\n", c.Before)
+		}
+		if c.After != "" {
+			fmt.Fprintf(out, "   after:  %s\n", c.After)
+		}
+	}
+}
+
+func init() {
+	changesCmd.Flags().StringVarP(&changesNetworkFlag, "network", "n", string(rpc.Mainnet), "Stellar network to use (testnet, mainnet, futurenet)")
+	changesCmd.Flags().StringVar(&changesRPCURLFlag, "rpc-url", "", "Custom Horizon RPC URL to use")
+	changesCmd.Flags().StringVar(&changesRPCToken, "rpc-token", "", "RPC authentication token (can also use ERST_RPC_TOKEN env var)")
+	changesCmd.Flags().StringVarP(&changesOutputFlag, "output", "o", outputFormatText, "Output format: text or json")
+
+	rootCmd.AddCommand(changesCmd)
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintLedgerChanges(t *testing.T) {
+	var out bytes.Buffer
+	printLedgerChanges(&out, []decoder.LedgerChange{
+		{Kind: decoder.ChangeUpdated, KeyType: "Account", Description: "account GABC", Before: "balance 100, sequence 7", After: "balance 60, sequence 7"},
+		{Kind: decoder.ChangeRemoved, KeyType: "ContractData", Description: "CABC persistent gone"},
+	})
+	assert.Contains(t, out.String(), "2 ledger entry changes:")
+	assert.Contains(t, out.String(), "1. updated  Account account GABC\n   before: balance 100, sequence 7\n   after:  balance 60, sequence 7\n")
+	assert.Contains(t, out.String(), "2. removed  ContractData CABC persistent gone\n")
+
+	out.Reset()
+	printLedgerChanges(&out, nil)
+	assert.Equal(t, "The transaction changed no ledger entries\n", out.String())
+}
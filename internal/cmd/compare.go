@@ -73,13 +73,10 @@ Examples:
 		if err := rpc.ValidateTransactionHash(args[0]); err != nil {
 			return errors.WrapValidationError(fmt.Sprintf("invalid transaction hash: %v", err))
 		}
-		switch rpc.Network(cmpNetworkFlag) {
-		case rpc.Testnet, rpc.Mainnet, rpc.Futurenet:
-			// valid
-		default:
-			return errors.WrapInvalidNetwork(cmpNetworkFlag)
+		if err := checkProtocolSupported("protocol-version", cmpProtoFlag); err != nil {
+			return err
 		}
-		return nil
+		return parseNetworkFlag(&cmpNetworkFlag)
 	},
 	RunE: runCompare,
 }
@@ -175,7 +172,7 @@ func runCompare(cmd *cobra.Command, cmdArgs []string) error {
 	fmt.Printf("%s Fetched (envelope: %d bytes)\n\n", visualizer.Success(), len(txResp.EnvelopeXdr))
 
 	// ── Extract ledger keys & entries ───────────────────────────────────────
-	keys, err := extractLedgerKeys(txResp.ResultMetaXdr)
+	keys, err := transactionLedgerKeys(ctx, os.Stdout, client, txResp)
 	if err != nil {
 		return errors.WrapUnmarshalFailed(err, "result meta")
 	}
@@ -220,7 +217,7 @@ func runCompare(cmd *cobra.Command, cmdArgs []string) error {
 // runBothPasses executes the local and on-chain simulation concurrently.
 func runBothPasses(
 	ctx context.Context,
-	runner *simulator.Runner,
+	runner simulator.RunnerInterface,
 	txResp *rpc.TransactionResponse,
 	ledgerEntries map[string]string,
 ) (localResult, onChainResult *simulator.SimulationResponse, err error) {
@@ -233,14 +230,14 @@ func runBothPasses(
 	go func() {
 		defer wg.Done()
 		req := buildSimRequest(txResp, ledgerEntries, &cmpLocalWasmFlag, cmpArgsFlag)
-		localResult, localErr = runner.Run(req)
+		localResult, localErr = simulator.RunWithContext(ctx, runner, req)
 	}()
 
 	// Pass B – on-chain (no --wasm flag, uses whatever is in the ledger)
 	go func() {
 		defer wg.Done()
 		req := buildSimRequest(txResp, ledgerEntries, nil, nil)
-		onChainResult, onChainErr = runner.Run(req)
+		onChainResult, onChainErr = simulator.RunWithContext(ctx, runner, req)
 	}()
 
 	wg.Wait()
@@ -273,9 +270,7 @@ func buildSimRequest(
 		req.MockArgs = &mockArgs
 	}
 	if cmpProtoFlag > 0 {
-		if err := simulator.Validate(cmpProtoFlag); err == nil {
-			req.ProtocolVersion = &cmpProtoFlag
-		}
+		req.ProtocolVersion = &cmpProtoFlag
 	}
 	return req
 }
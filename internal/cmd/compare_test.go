@@ -0,0 +1,48 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/simulator/simtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBothPasses(t *testing.T) {
+	prevWasm, prevArgs := cmpLocalWasmFlag, cmpArgsFlag
+	t.Cleanup(func() { cmpLocalWasmFlag, cmpArgsFlag = prevWasm, prevArgs })
+	cmpLocalWasmFlag, cmpArgsFlag = "contract.wasm", nil
+
+	runner := simtest.NewMockRunner(func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		if req.WasmPath != nil {
+			return &simulator.SimulationResponse{Status: "success", Events: []string{"local"}}, nil
+		}
+		return &simulator.SimulationResponse{Status: "success", Events: []string{"on-chain"}}, nil
+	})
+	tx := &rpc.TransactionResponse{EnvelopeXdr: "ENV", ResultMetaXdr: "META"}
+
+	local, onChain, err := runBothPasses(context.Background(), runner, tx, map[string]string{"k": "v"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"local"}, local.Events)
+	assert.Equal(t, []string{"on-chain"}, onChain.Events)
+
+	reqs := runner.Requests()
+	require.Len(t, reqs, 2)
+	for _, req := range reqs {
+		assert.Equal(t, "ENV", req.EnvelopeXdr)
+		assert.Equal(t, map[string]string{"k": "v"}, req.LedgerEntries)
+	}
+}
+
+func TestRunBothPasses_Error(t *testing.T) {
+	runner := simtest.Returning(nil, errors.New("sim crashed"))
+	_, _, err := runBothPasses(context.Background(), runner, &rpc.TransactionResponse{}, nil)
+	assert.ErrorContains(t, err, "sim crashed")
+}
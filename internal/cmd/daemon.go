@@ -56,11 +56,8 @@ Example:
 			defer cleanup()
 		}
 
-		// Validate network
-		switch rpc.Network(daemonNetwork) {
-		case rpc.Testnet, rpc.Mainnet, rpc.Futurenet:
-		default:
-			return errors.WrapInvalidNetwork(daemonNetwork)
+		if err := parseNetworkFlag(&daemonNetwork); err != nil {
+			return err
 		}
 
 		// Create server
@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -15,17 +16,23 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dotandev/hintents/internal/authtrace"
+	"github.com/dotandev/hintents/internal/bundle"
+	"github.com/dotandev/hintents/internal/checks"
+	"github.com/dotandev/hintents/internal/compare"
 	"github.com/dotandev/hintents/internal/config"
 	"github.com/dotandev/hintents/internal/decoder"
 	"github.com/dotandev/hintents/internal/errors"
 	"github.com/dotandev/hintents/internal/logger"
 	"github.com/dotandev/hintents/internal/lto"
+	"github.com/dotandev/hintents/internal/report"
 	"github.com/dotandev/hintents/internal/rpc"
 	"github.com/dotandev/hintents/internal/security"
 	"github.com/dotandev/hintents/internal/session"
 	"github.com/dotandev/hintents/internal/simulator"
 	"github.com/dotandev/hintents/internal/snapshot"
 	"github.com/dotandev/hintents/internal/telemetry"
+	"github.com/dotandev/hintents/internal/terminal"
 	"github.com/dotandev/hintents/internal/tokenflow"
 	"github.com/dotandev/hintents/internal/visualizer"
 	"github.com/dotandev/hintents/internal/wat"
@@ -35,27 +42,61 @@ import (
 	"github.com/stellar/go-stellar-sdk/xdr"
 	"go.opentelemetry.io/otel/attribute"
 )
+
 var (
-	networkFlag        string
-	rpcURLFlag         string
-	rpcTokenFlag       string
-	tracingEnabled     bool
-	otlpExporterURL    string
-	generateTrace      bool
-	traceOutputFile    string
-	snapshotFlag       string
-	compareNetworkFlag string
-	verbose            bool
-	wasmPath           string
-	args               []string
-	noCacheFlag        bool
-	demoMode           bool
-	watchFlag          bool
-	watchTimeoutFlag   int
-	mockBaseFeeFlag    uint32
-	mockGasPriceFlag   uint64
+	networkFlag         string
+	rpcURLFlag          string
+	rpcTokenFlag        string
+	tracingEnabled      bool
+	otlpExporterURL     string
+	generateTrace       bool
+	traceOutputFile     string
+	snapshotFlag        string
+	compareNetworksFlag []string
+	failOnMismatchFlag  bool
+	compareRPCURLFlag   string
+	compareHorizonURL   string
+	compareSorobanURL   string
+	compareModeFlag     string
+	verbose             bool
+	wasmPath            string
+	args                []string
+	noCacheFlag         bool
+	demoMode            bool
+	watchFlag           bool
+	watchTimeoutFlag    int
+	mockBaseFeeFlag     uint32
+	mockGasPriceFlag    uint64
+	themeFlag           string
+	mockTimeFlag        int64
+	protocolVersionFlag uint32
+	outputFormatFlag    string
+	reportFileFlag      string
+	templateFileFlag    string
+	outputFileFlag      string
+	filterContractFlag  []string
+	filterTopicFlag     []string
+	showTTLFlag         bool
+	showStorageFlag     bool
+	showResourcesFlag   bool
+	includeRawFlag      bool
+	checkRuleFiles      []string
+	expectEventFlags    []string
+	saveBundleFlag      string
+	replayBundleFlag    string
+	eventsFormatFlag    string
+	fullEventsFlag      bool
+	hostLogsFlag        bool
+	summaryFlag         bool
+	autoNetworkFlag     bool
+	explainFlag         bool
+	sourceAccountFlag   string
 )
 
+// ttlWarnLedgers is the remaining-TTL threshold (roughly one day of ledgers)
+// below which --show-ttl flags a footprint entry as close to expiring.
+const ttlWarnLedgers = 17280
+
 // DebugCommand holds dependencies for the debug command
 type DebugCommand struct {
 	Runner simulator.RunnerInterface
@@ -78,13 +119,7 @@ Example:
   erst debug --network testnet <tx-hash>`,
 		Args: cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			// Validate network flag
-			switch rpc.Network(networkFlag) {
-			case rpc.Testnet, rpc.Mainnet, rpc.Futurenet:
-				return nil
-			default:
-				return errors.WrapInvalidNetwork(networkFlag)
-			}
+			return parseNetworkFlag(&networkFlag)
 		},
 		RunE: d.runDebug,
 	}
@@ -149,7 +184,7 @@ func (d *DebugCommand) runDebug(cmd *cobra.Command, args []string) error {
 }
 
 var debugCmd = &cobra.Command{
-	Use:   "debug <transaction-hash>",
+	Use:   "debug <transaction-hash | ->",
 	Short: "Debug a failed Soroban transaction",
 	Long: `Fetch and simulate a Soroban transaction to debug failures and analyze execution.
 
@@ -173,33 +208,205 @@ Local WASM Replay Mode:
   # Debug and compare results between networks
   erst debug --network mainnet --compare-network testnet abc123...def789
 
+  # Only show events from one contract
+  erst debug abc123...def789 --filter-contract CABC...XYZ --filter-topic transfer
+
+  # Attach a Markdown report to a bug report
+  erst debug abc123...def789 --report report.md
+
   # Debug and save the session
   erst debug abc123...def789 && erst session save
 
   # Compare execution across networks
   erst debug --network testnet --compare-network mainnet <tx-hash>
 
+  # Find the odd one out across three networks
+  erst debug --network mainnet --compare-network testnet --compare-network futurenet <tx-hash>
+
+  # Reproduce behaviour before and after a protocol upgrade
+  erst debug --compare-tx <other-tx-hash> --protocol-version 21 --compare-protocol-version 22 <tx-hash>
+
+  # Simulate against ledger state captured from a local stellar-core
+  erst debug <tx-hash> --snapshot ledger-snapshot.json
+
   # Local WASM replay (no network required)
   erst debug --wasm ./contract.wasm --args "arg1" --args "arg2"
 
   # Demo mode (test color output, no network required)
-  erst debug --demo`,
-	Args: cobra.MaximumNArgs(1),
+  erst debug --demo
+
+  # Save everything needed to replay offline, then replay it later
+  erst debug <tx-hash> --save bug-report.erst.tar.gz
+  erst debug --replay bug-report.erst.tar.gz
+
+  # Share a bundle without revealing addresses (diffable, not replayable)
+  erst debug <tx-hash> --save shared.erst.tar.gz --redact
+
+  # Aggregate outcomes of a batch of transactions
+  erst debug --summary --output json <tx-hash> <tx-hash> <tx-hash>
+
+  # Debug every hash piped in on stdin, one per line
+  cat failed.txt | erst debug -
+  cat failed.txt | erst debug --summary -`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if summaryFlag {
+			return cobra.ArbitraryArgs(cmd, args)
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	},
 	PreRunE: func(cmd *cobra.Command, args []string) error {
+		// Ahead of the checks below, which see it as a --compare-network
+		if err := validateCompareNetworkJSON(); err != nil {
+			return err
+		}
+		if err := validateOutputFormat(outputFormatFlag); err != nil {
+			return err
+		}
+		if err := validateEventsFormat(eventsFormatFlag); err != nil {
+			return err
+		}
+		if err := validateEventSeverity(); err != nil {
+			return err
+		}
+		if _, err := newLedgerKeyFilter(onlyKeyFlags, excludeKeyFlags); err != nil {
+			return err
+		}
+		if err := validateEventSource(); err != nil {
+			return err
+		}
+		if err := validateTxSource(); err != nil {
+			return err
+		}
+		if err := validateDiffFormat(); err != nil {
+			return err
+		}
+		if err := validateRedact(); err != nil {
+			return err
+		}
+		if err := validateProtocolVersions(); err != nil {
+			return err
+		}
+		if err := validateIncludeRaw(); err != nil {
+			return err
+		}
+		if err := validateRedactFields(); err != nil {
+			return err
+		}
+		if err := validateAutoRestore(); err != nil {
+			return err
+		}
+		if err := validateOverrideWasm(); err != nil {
+			return err
+		}
+		if err := validateArchivalRPCURL(); err != nil {
+			return err
+		}
+		if err := validateRawHorizon(); err != nil {
+			return err
+		}
+		if err := validateRebuild(); err != nil {
+			return err
+		}
+		if err := validateSummaryJSON(); err != nil {
+			return err
+		}
+		if err := validateBumpTTL(); err != nil {
+			return err
+		}
+		if err := validateTemplateOutput(); err != nil {
+			return err
+		}
+		if maxKeysFlag < 0 {
+			return errors.WrapValidationError("--max-keys must not be negative")
+		}
+		if err := validateConfirmKeys(); err != nil {
+			return err
+		}
+		if err := loadStdinHashes(cmd, args); err != nil {
+			return err
+		}
+		args = debugHashArgs(args)
+		if err := validateCompareTx(args); err != nil {
+			return err
+		}
+		if err := validateCompareLedger(args); err != nil {
+			return err
+		}
+		if err := validateKeysOnly(args); err != nil {
+			return err
+		}
+		if err := validateNetworkJSON(cmd); err != nil {
+			return err
+		}
+
+		// Local envelopes take the place of the transaction hash
+		if err := validateEnvelopesFile(args); err != nil || envelopesFileFlag != "" {
+			return err
+		}
+
+		// Summary mode replays many hashes and only prints aggregates
+		if summaryFlag {
+			return validateSummaryMode(cmd, args)
+		}
+
 		// Demo mode or local WASM replay don't need transaction hash
 		if demoMode || wasmPath != "" {
+			return validateReportModes()
+		}
+
+		// A replay bundle carries its own transaction and network
+		if replayBundleFlag != "" {
+			if len(args) > 0 || saveBundleFlag != "" || snapshotFlag != "" || entriesFileFlag != "" || len(compareNetworksFlag) > 0 || watchFlag || sourceAccountFlag != "" {
+				return errors.WrapValidationError("--replay cannot be combined with a transaction hash, --save, --snapshot, --entries-file, --compare-network, --watch or --source-account")
+			}
 			return nil
 		}
 
 		if len(args) == 0 {
-			return errors.WrapValidationError("transaction hash is required when not using --wasm or --demo flag")
+			return errors.WrapValidationError("transaction hash is required when not using --wasm, --demo or --replay flag")
+		}
+		if len(args) > 1 {
+			if err := validateMultiHash(); err != nil {
+				return err
+			}
+		}
+
+		if entriesFileFlag != "" && snapshotFlag != "" {
+			return errors.WrapValidationError("--entries-file and --snapshot both supply ledger entries; use one")
+		}
+
+		if saveBundleFlag != "" && len(compareNetworksFlag) > 0 {
+			return errors.WrapValidationError("--save is not supported with --compare-network")
+		}
+
+		if failOnMismatchFlag && len(compareNetworksFlag) == 0 && compareTxFlag == "" && compareLedgerFlag == "" {
+			return errors.WrapValidationError("--fail-on-mismatch requires --compare-network, --compare-tx or --compare-ledger")
+		}
+
+		if sourceAccountFlag != "" {
+			if saveBundleFlag != "" {
+				return errors.WrapValidationError("--save is not supported with --source-account")
+			}
+			if _, err := decoder.ParseAccount(sourceAccountFlag); err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("invalid --source-account %q: expected a G... or M... account address", sourceAccountFlag))
+			}
 		}
 
 		if err := rpc.ValidateTransactionHash(args[0]); err != nil {
 			return errors.WrapValidationError(fmt.Sprintf("invalid transaction hash format: %v", err))
 		}
 
-		if !cmd.Flags().Changed("network") {
+		networkSource := networkFromDefault
+		if customNetwork != nil {
+			networkSource = networkFromFlag
+		} else if cmd.Flags().Changed("network") {
+			networkSource = networkFromFlag
+		} else if inferred, _, ok := rpcURLNetwork(); ok && !autoNetworkFlag {
+			// A well-known --rpc-url already says which network it serves
+			networkFlag, networkSource = string(inferred), networkFromURL
+			fmt.Fprintf(progressWriter(cmd), "Inferred network from --rpc-url: %s\n", networkFlag)
+		}
+		if networkSource == networkFromDefault || autoNetworkFlag {
 			token := rpcTokenFlag
 			if token == "" {
 				token = os.Getenv("ERST_RPC_TOKEN")
@@ -207,36 +414,40 @@ Local WASM Replay Mode:
 			probeCtx, probeCancel := context.WithTimeout(cmd.Context(), 5*time.Second)
 			defer probeCancel()
 			if resolved, err := rpc.ResolveNetwork(probeCtx, args[0], token); err == nil {
-				networkFlag = string(resolved)
-				fmt.Printf("Resolved network: %s\n", networkFlag)
+				networkFlag, networkSource = string(resolved), networkFromProbe
+				fmt.Fprintf(progressWriter(cmd), "Resolved network: %s\n", networkFlag)
 			}
 		}
 
-		// Validate network flag
-		switch rpc.Network(networkFlag) {
-		case rpc.Testnet, rpc.Mainnet, rpc.Futurenet:
-			// valid
-		default:
-			return errors.WrapInvalidNetwork(networkFlag)
+		if err := parsePrimaryNetwork(); err != nil {
+			return err
 		}
-
-		// Validate compare network flag if present
-		if compareNetworkFlag != "" {
-			switch rpc.Network(compareNetworkFlag) {
-			case rpc.Testnet, rpc.Mainnet, rpc.Futurenet:
-				// valid
-			default:
-				return errors.WrapInvalidNetwork(compareNetworkFlag)
-			}
+		warnRPCURLNetwork(progressWriter(cmd), networkSource)
+		if err := parseCompareNetworks(); err != nil {
+			return err
+		}
+		if err := validateCompareURLFlags(); err != nil {
+			return err
 		}
+		mode, err := compare.ParseMode(compareModeFlag)
+		if err != nil {
+			return errors.WrapValidationError(err.Error())
+		}
+		compareModeFlag = string(mode)
+
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, cmdArgs []string) error {
-		if verbose {
+		switch {
+		case hostLogsFlag:
+			// The host's output is logged at debug level
+			logger.SetLevel(slog.LevelDebug)
+		case verbose:
 			logger.SetLevel(slog.LevelInfo)
-		} else {
+		default:
 			logger.SetLevel(slog.LevelWarn)
 		}
+		simulator.SetHostLogs(hostLogsFlag)
 
 		// Apply theme if specified, otherwise auto-detect
 		if themeFlag != "" {
@@ -245,6 +456,8 @@ Local WASM Replay Mode:
 			visualizer.SetTheme(visualizer.DetectTheme())
 		}
 
+		cmdArgs = debugHashArgs(cmdArgs)
+
 		// Demo mode: print sample output for testing color detection (no network)
 		if demoMode {
 			return runDemoMode(cmdArgs)
@@ -255,9 +468,65 @@ Local WASM Replay Mode:
 			return runLocalWasmReplay()
 		}
 
+		if envelopesFileFlag != "" {
+			return runDebugEnvelopes(cmd)
+		}
+		if summaryFlag {
+			return runDebugSummary(cmd, cmdArgs)
+		}
+		if len(cmdArgs) > 1 {
+			return runDebugEach(cmd, cmdArgs)
+		}
+		if compareTxFlag != "" {
+			return runDebugCompareTx(cmd, cmdArgs[0])
+		}
+		if compareLedgerFlag != "" {
+			return runDebugCompareLedger(cmd, cmdArgs[0])
+		}
+		if keysOnlyFlag {
+			return runDebugKeysOnly(cmd, cmdArgs[0])
+		}
+
 		// Network transaction replay mode
 		ctx := cmd.Context()
-		txHash := cmdArgs[0]
+
+		var replay *bundle.Bundle
+		var txHash string
+		if replayBundleFlag != "" {
+			var err error
+			replay, err = bundle.Load(replayBundleFlag)
+			if err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("failed to load replay bundle: %v", err))
+			}
+			network, err := rpc.ParseNetwork(replay.Manifest.Network)
+			if err != nil {
+				return err
+			}
+			txHash = replay.Manifest.TxHash
+			networkFlag = network.String()
+		} else {
+			txHash = cmdArgs[0]
+		}
+
+		// Structured output owns stdout; progress messages go to stderr.
+		out := progressWriter(cmd)
+		warns := &debugWarnings{}
+
+		var checkers []checks.Checker
+		for _, path := range checkRuleFiles {
+			loaded, err := checks.LoadRuleFile(path)
+			if err != nil {
+				return err
+			}
+			checkers = append(checkers, loaded...)
+		}
+		for _, spec := range expectEventFlags {
+			expectation, err := checks.ParseEventExpectation(spec)
+			if err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("--expect-event: %v", err))
+			}
+			checkers = append(checkers, expectation)
+		}
 
 		// Initialize OpenTelemetry if enabled
 		if tracingEnabled {
@@ -281,43 +550,18 @@ Local WASM Replay Mode:
 		)
 		defer span.End()
 
-		var horizonURL string
-		token := rpcTokenFlag
-		if token == "" {
-			token = os.Getenv("ERST_RPC_TOKEN")
-		}
-		if token == "" {
-			if cfg, err := config.Load(); err == nil && cfg.RPCToken != "" {
-				token = cfg.RPCToken
-			}
-		}
+		requestIDs := newNetworkRequestIDs(networkFlag, compareNetworksFlag)
+		ctx = requestIDs.primary(ctx)
+		timings := &runTimings{}
+		stats := newDebugStats(networkFlag, compareNetworksFlag)
+		runStart := time.Now()
 
-		opts := []rpc.ClientOption{
-			rpc.WithNetwork(rpc.Network(networkFlag)),
-			rpc.WithToken(token),
-		}
+		// Shared by the primary and compare clients so no ledger key is
+		// requested twice within this run.
+		entryMemo := rpc.NewEntryMemo()
 
-		if rpcURLFlag != "" {
-			urls := strings.Split(rpcURLFlag, ",")
-			for i := range urls {
-				urls[i] = strings.TrimSpace(urls[i])
-			}
-			opts = append(opts, rpc.WithAltURLs(urls))
-			horizonURL = urls[0]
-		} else {
-			cfg, err := config.Load()
-			if err == nil {
-				if len(cfg.RpcUrls) > 0 {
-					opts = append(opts, rpc.WithAltURLs(cfg.RpcUrls))
-					horizonURL = cfg.RpcUrls[0]
-				} else if cfg.RpcUrl != "" {
-					opts = append(opts, rpc.WithHorizonURL(cfg.RpcUrl))
-					horizonURL = cfg.RpcUrl
-				}
-			}
-		}
-
-		client, err := rpc.NewClient(opts...)
+		opts, horizonURL := primaryClientOptions(resolveRPCToken(), entryMemo)
+		client, err := rpc.NewClient(append(opts, stats.clientOption(0))...)
 		if err != nil {
 			return errors.WrapValidationError(fmt.Sprintf("failed to create client: %v", err))
 		}
@@ -329,18 +573,21 @@ Local WASM Replay Mode:
 
 		if noCacheFlag {
 			client.CacheEnabled = false
-			fmt.Println("🚫 Cache disabled by --no-cache flag")
+			fmt.Fprintln(out, "🚫 Cache disabled by --no-cache flag")
 		}
 
-		fmt.Printf("Debugging transaction: %s\n", txHash)
-		fmt.Printf("Primary Network: %s\n", networkFlag)
-		if compareNetworkFlag != "" {
-			fmt.Printf("Comparing against Network: %s\n", compareNetworkFlag)
+		fmt.Fprintf(out, "Debugging transaction: %s\n", txHash)
+		fmt.Fprintf(out, "Primary Network: %s\n", networkFlag)
+		if len(compareNetworksFlag) > 0 {
+			fmt.Fprintf(out, "Comparing against Network: %s\n", strings.Join(compareNetworksFlag, ", "))
+		}
+		if verbose {
+			requestIDs.printLegend(out)
 		}
 
 		// Fetch transaction details
 		if watchFlag {
-			spinner := watch.NewSpinner()
+			spinner := watch.NewSpinner().WithRenderer(terminal.NewANSIRendererTo(out))
 			poller := watch.NewPoller(watch.PollerConfig{
 				InitialInterval: 1 * time.Second,
 				MaxInterval:     10 * time.Second,
@@ -370,18 +617,131 @@ Local WASM Replay Mode:
 			spinner.StopWithMessage("Transaction found! Starting debug...")
 		}
 
-		fmt.Printf("Fetching transaction: %s\n", txHash)
-		resp, err := client.GetTransaction(ctx, txHash)
+		var resp *rpc.TransactionResponse
+		var keys []string
+		var feeBump *decoder.FeeBumpResult
+		if replay != nil {
+			fmt.Fprintf(out, "Replaying bundle: %s (format v%d)\n", replayBundleFlag, replay.Manifest.FormatVersion)
+			warnRedactedReplay(out, replay.Manifest)
+			resp = replay.Transaction()
+			keys = replay.Keys
+			if followFeeBumpInnerFlag {
+				feeBump, resp = followFeeBumpInner(ctx, out, client.GetNetworkPassphrase(), nil, resp)
+			}
+		} else {
+			explain(out, explainFetch)
+			fmt.Fprintf(out, "Fetching transaction: %s\n", txHash)
+			stageStart := time.Now()
+			resp, err = client.GetTransaction(ctx, txHash)
+			if err != nil {
+				// These already explain what to try next
+				if errors.Is(err, errors.ErrTransactionNotFound) || errors.Is(err, errors.ErrRateLimitExceeded) {
+					return err
+				}
+				return errors.WrapRPCConnectionFailed(err)
+			}
+
+			fmt.Fprintf(out, "Transaction fetched successfully. Envelope size: %d bytes\n", len(resp.EnvelopeXdr))
+			printRawHorizon(cmd.ErrOrStderr(), resp)
+			timings.record("fetch transaction", stageStart)
+			if followFeeBumpInnerFlag {
+				feeBump, resp = followFeeBumpInner(ctx, out, client.GetNetworkPassphrase(), client, resp)
+			}
+
+			// Extract ledger keys for replay
+			stageStart = time.Now()
+			keys, err = transactionLedgerKeys(ctx, out, client, resp)
+			if err != nil {
+				return errors.WrapUnmarshalFailed(err, "result meta")
+			}
+			timings.record("extract keys", stageStart)
+			fmt.Fprintf(out, "Result meta size: %d bytes, %d ledger keys\n", len(resp.ResultMetaXdr), len(keys))
+			if err := checkKeyLimit(len(keys)); err != nil {
+				return err
+			}
+			if err := confirmMainnetFetch(cmd, networkFlag, len(keys)); err != nil {
+				return err
+			}
+		}
+		decodedEnv := printOperations(out, resp.EnvelopeXdr)
+		printFeeBump(out, feeBump)
+
+		var storageChanges []decoder.StorageChange
+		if showStorageFlag {
+			storageChanges = printStorageChanges(out, resp.ResultMetaXdr)
+		}
+		var resources *decoder.SorobanResources
+		if showResourcesFlag {
+			resources = printSorobanResources(out, warns, resp.EnvelopeXdr, resp.ResultMetaXdr)
+		}
+		var accountClient *rpc.Client
+		if replay == nil {
+			accountClient = client
+		}
+		sequenceFailure := detectSequenceFailure(ctx, accountClient, resp)
+		printSequenceFailure(out, sequenceFailure)
+
+		var sourceOverride *sourceAccountOverride
+		if sourceAccountFlag != "" {
+			rewritten, override, err := newSourceAccountOverride(resp.EnvelopeXdr, sourceAccountFlag)
+			if err != nil {
+				return errors.WrapValidationError(err.Error())
+			}
+			overridden := *resp
+			overridden.EnvelopeXdr = rewritten
+			resp = &overridden
+			keys = override.rewriteKeys(keys)
+			sourceOverride = override
+			fmt.Fprintf(out, "%s Simulating as source account %s instead of %s. The envelope's signatures no longer match, but simulation does not verify signatures.\n",
+				visualizer.Warning(), override.toAccount, override.fromAccount)
+		}
+
+		wasmOverrides, err := parseWasmOverrides(overrideWasmFlags)
 		if err != nil {
-			return errors.WrapRPCConnectionFailed(err)
+			return err
+		}
+		if contractErrors, err = parseErrorSpecs(errorSpecFlags); err != nil {
+			return err
+		}
+		if len(wasmOverrides) > 0 {
+			rewritten, err := rewriteEnvelopeForWasms(resp.EnvelopeXdr, wasmOverrides)
+			if err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("--override-wasm: %v", err))
+			}
+			overridden := *resp
+			overridden.EnvelopeXdr = rewritten
+			resp = &overridden
 		}
 
-		fmt.Printf("Transaction fetched successfully. Envelope size: %d bytes\n", len(resp.EnvelopeXdr))
+		ttlBumps, err := parseTTLBumps(bumpTTLFlags)
+		if err != nil {
+			return err
+		}
 
-		// Extract ledger keys for replay
-		keys, err := extractLedgerKeys(resp.ResultMetaXdr)
+		keyFilter, err := newLedgerKeyFilter(onlyKeyFlags, excludeKeyFlags)
 		if err != nil {
-			return errors.WrapUnmarshalFailed(err, "result meta")
+			return err
+		}
+		keys = keyFilter.filterKeys(out, keys)
+		keyTypes := countLedgerKeyTypes(keys)
+		printLedgerKeyTypes(out, keyTypes)
+
+		// Loading the primary entries is the slowest step of setup, so it
+		// starts now and overlaps everything up to the first simulation
+		var prefetch *entryPrefetch
+		if replay == nil && snapshotFlag == "" && entriesFileFlag == "" {
+			prefetch = startEntryPrefetch(ctx, client, resp.ResultMetaXdr, keys)
+			timings.prefetch = prefetch
+		}
+		setupStart := time.Now()
+
+		explain(out, explainKeys)
+
+		// Restore runs ahead of the invoke, so its results come first
+		var restore *report.RestoreStep
+		var restoredEntries map[string]string
+		if autoRestoreFlag && replay == nil {
+			restore, restoredEntries = simulateRestore(ctx, out, client, resp.EnvelopeXdr)
 		}
 
 		// Initialize Simulator Runner
@@ -400,145 +760,280 @@ Local WASM Replay Mode:
 			}
 		}
 
+		compareClients := make([]*rpc.Client, len(compareNetworksFlag))
+		for i, n := range compareNetworksFlag {
+			compareOpts := append([]rpc.ClientOption{
+				rpc.WithNetwork(rpc.Network(n)),
+				rpc.WithToken(rpcTokenFlag),
+				rpc.WithEntryMemo(entryMemo),
+				rpc.WithTransactionSource(rpc.TransactionSource(txSourceFlag)),
+				stats.clientOption(i + 1),
+			}, compareURLOptions()...)
+			compareClients[i], err = rpc.NewClient(compareOpts...)
+			if err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("failed to create compare client for %s: %v", n, err))
+			}
+			if noCacheFlag {
+				compareClients[i].CacheEnabled = false
+			}
+		}
+
+		var ttls []rpc.EntryTTL
+		compareTTLs := make([][]rpc.EntryTTL, len(compareClients))
+		if showTTLFlag {
+			ttls = fetchEntryTTLs(ctx, out, warns, networkFlag, client, keys)
+			for i, c := range compareClients {
+				compareTTLs[i] = fetchEntryTTLs(requestIDs.compare(ctx, i), out, warns, compareNetworksFlag[i], c, keys)
+			}
+		}
+
+		var snapshotEntries map[string]string
+		entriesSource := "snapshot"
+		if snapshotFlag != "" {
+			// The snapshot replaces the network as the state source: the
+			// footprint is looked up in it rather than with getLedgerEntries
+			source, err := snapshot.LoadSource(snapshotFlag)
+			if err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("failed to load snapshot: %v", err))
+			}
+			if snapshotEntries, err = ledgerSourceEntries(ctx, out, warns, source, "snapshot", keys); err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("failed to look up entries in snapshot: %v", err))
+			}
+			entryMemo.Seed(rpc.Network(networkFlag), snapshotEntries)
+		}
+		if entriesFileFlag != "" {
+			// Used as-is in place of metadata extraction and getLedgerEntries
+			if snapshotEntries, err = loadEntriesFile(entriesFileFlag); err != nil {
+				return err
+			}
+			entriesSource = "entries file"
+			warnMissingEntries(out, warns, entriesSource, keys, snapshotEntries)
+			entryMemo.Seed(rpc.Network(networkFlag), snapshotEntries)
+		}
+
 		var lastSimResp *simulator.SimulationResponse
+		var chainCheck *compare.ChainCheck
+		var lastCompareResps []*simulator.SimulationResponse
+		var lastEntries map[string]string
+		var wasms networkWasms
+		var protocols networkProtocols
+		bundleSaved := false
+
+		explain(out, explainEntries)
+		explain(out, explainSimulate)
+
+		primaryProtocol, protocolNote := simulationProtocol(ctx, client, resp.Ledger, protocolVersionFlag)
+		if protocolNote != "" {
+			fmt.Fprintln(out, protocolNote)
+		}
+		timings.record("setup", setupStart)
+		simulateStart := time.Now()
 
 		for _, ts := range timestamps {
 			if len(timestamps) > 1 {
-				fmt.Printf("\n--- Simulating at Timestamp: %d ---\n", ts)
+				fmt.Fprintf(out, "\n--- Simulating at Timestamp: %d ---\n", ts)
 			}
 
 			var simResp *simulator.SimulationResponse
+			var compareSimResps []*simulator.SimulationResponse
 			var ledgerEntries map[string]string
 
-			if compareNetworkFlag == "" {
+			if len(compareNetworksFlag) == 0 {
 				// Single Network Run
-				if snapshotFlag != "" {
-					snap, err := snapshot.Load(snapshotFlag)
+				if replay != nil {
+					ledgerEntries = replay.Entries
+					fmt.Fprintf(out, "Loaded %d ledger entries from bundle\n", len(ledgerEntries))
+				} else if snapshotEntries != nil {
+					ledgerEntries = snapshotEntries
+					fmt.Fprintf(out, "Loaded %d ledger entries from %s\n", len(ledgerEntries), entriesSource)
+				} else {
+					// From the metadata when it has them, else fetched
+					ledgerEntries, err = prefetch.wait()
 					if err != nil {
-						return errors.WrapValidationError(fmt.Sprintf("failed to load snapshot: %v", err))
+						return errors.WrapRPCConnectionFailed(err)
 					}
-					ledgerEntries = snap.ToMap()
-					fmt.Printf("Loaded %d ledger entries from snapshot\n", len(ledgerEntries))
-				} else {
-					// Try to extract from metadata first, fall back to fetching
-					ledgerEntries, err = rpc.ExtractLedgerEntriesFromMeta(resp.ResultMetaXdr)
+				}
+				if sourceOverride != nil {
+					ledgerEntries, err = sourceOverride.applyToEntries(ctx, client, ledgerEntries)
 					if err != nil {
-						logger.Logger.Warn("Failed to extract ledger entries from metadata, fetching from network", "error", err)
-						ledgerEntries, err = client.GetLedgerEntries(ctx, keys)
-						if err != nil {
-							return errors.WrapRPCConnectionFailed(err)
-						}
-					} else {
-						logger.Logger.Info("Extracted ledger entries for simulation", "count", len(ledgerEntries))
+						return errors.WrapRPCConnectionFailed(err)
 					}
 				}
-
-				fmt.Printf("Running simulation on %s...\n", networkFlag)
-				simReq := &simulator.SimulationRequest{
-					EnvelopeXdr:     resp.EnvelopeXdr,
-					ResultMetaXdr:   resp.ResultMetaXdr,
-					LedgerEntries:   ledgerEntries,
-					Timestamp:       ts,
-					ProtocolVersion: nil,
+				ledgerEntries = keyFilter.filterEntries(withRestoredEntries(ledgerEntries, restoredEntries))
+				if len(wasmOverrides) > 0 {
+					if ledgerEntries, err = applyWasmOverrides(ctx, client, wasmOverrides, ledgerEntries); err != nil {
+						return errors.WrapValidationError(fmt.Sprintf("--override-wasm: %v", err))
+					}
+					if ts == timestamps[0] {
+						printWasmOverrides(out, wasmOverrides)
+					}
 				}
-
-				// Apply protocol version override if specified
-				if protocolVersionFlag > 0 {
-					if err := simulator.Validate(protocolVersionFlag); err != nil {
-						return fmt.Errorf("invalid protocol version %d: %w", protocolVersionFlag, err)
+				if len(ttlBumps) > 0 {
+					if ledgerEntries, err = applyTTLBumps(ttlBumps, ledgerEntries); err != nil {
+						return errors.WrapValidationError(fmt.Sprintf("--bump-ttl: %v", err))
+					}
+					if ts == timestamps[0] {
+						printTTLBumps(out, ttlBumps, ledgerEntries, resp.Ledger)
 					}
-					simReq.ProtocolVersion = &protocolVersionFlag
-					fmt.Printf("Using protocol version override: %d\n", protocolVersionFlag)
 				}
-				applySimulationFeeMocks(simReq)
 
-				simResp, err = runner.Run(simReq)
-				if err != nil {
-					return errors.WrapSimulationFailed(err, "")
+				replayHash := bundle.ReplayHash(resp.EnvelopeXdr, resp.ResultMetaXdr, keys, ledgerEntries)
+				if stored := reusableSimulation(replay, replayHash, len(timestamps)); stored != nil {
+					fmt.Fprintf(out, "Replay hash matches the bundle; reusing its stored simulation result\n")
+					simResp = stored
+				} else {
+					fmt.Fprintf(out, "Running simulation on %s...\n", networkFlag)
+					simReq := &simulator.SimulationRequest{
+						EnvelopeXdr:     resp.EnvelopeXdr,
+						ResultMetaXdr:   resp.ResultMetaXdr,
+						LedgerEntries:   ledgerEntries,
+						Timestamp:       ts,
+						ProtocolVersion: primaryProtocol,
+					}
+					applySimulationFeeMocks(simReq)
+
+					simStart := time.Now()
+					simResp, err = simulator.RunWithContext(ctx, runner, simReq)
+					stats.simulated(0, simStart)
+					if err != nil {
+						// Still save what was fetched, so it can be replayed
+						// once the simulator is fixed.
+						if saveBundleFlag != "" && !bundleSaved && !redactFlag && !redactContractsFlag {
+							_ = saveReplayBundle(out, txHash, resp, keys, ledgerEntries, nil)
+						}
+						return errors.WrapSimulationFailed(err, "")
+					}
 				}
-				printSimulationResult(networkFlag, simResp)
+				// Checked before --source chain swaps in the recorded events
+				chainCheck = checkAgainstChain(resp, simResp)
+				if eventSourceFlag == eventSourceChain {
+					chainEvents, err := fetchChainEvents(ctx, client, txHash, resp.Ledger)
+					if err != nil {
+						return err
+					}
+					printChainEventComparison(out, simResp, chainEvents)
+					simResp = withChainEvents(simResp, chainEvents)
+				}
+				// The bundle stores the simulation result, so it is saved
+				// once the simulation has run.
+				if saveBundleFlag != "" && !bundleSaved {
+					if err := saveReplayBundle(out, txHash, resp, keys, ledgerEntries, simResp); err != nil {
+						return err
+					}
+					bundleSaved = true
+				}
+				contractErrors.learn(ledgerEntries)
+				printSimulationResult(out, networkFlag, filterEventsForDisplay(out, networkFlag, simResp))
 				// Fetch contract bytecode on demand for any contract calls in the trace; cache via RPC client
-				if client != nil && simResp != nil && len(simResp.DiagnosticEvents) > 0 {
+				if client != nil && replay == nil && simResp != nil && len(simResp.DiagnosticEvents) > 0 {
 					contractIDs := collectContractIDsFromDiagnosticEvents(simResp.DiagnosticEvents)
 					if len(contractIDs) > 0 {
 						_, _ = rpc.FetchBytecodeForTraceContractCalls(ctx, client, contractIDs, nil)
 					}
 				}
 			} else {
-				// Comparison Run
+				// Comparison Run: primary and every compare network concurrently
 				var wg sync.WaitGroup
-				var primaryResult, compareResult *simulator.SimulationResponse
-				var primaryErr, compareErr error
+				var primaryResult *simulator.SimulationResponse
+				var primaryErr error
+				compareResults := make([]*simulator.SimulationResponse, len(compareClients))
+				compareErrs := make([]error, len(compareClients))
+				compareProtocolNotes := make([]string, len(compareClients))
 
-				wg.Add(2)
+				wg.Add(1 + len(compareClients))
 				go func() {
 					defer wg.Done()
-					var entries map[string]string
-					var extractErr error
-					entries, extractErr = rpc.ExtractLedgerEntriesFromMeta(resp.ResultMetaXdr)
-					if extractErr != nil {
-						entries, extractErr = client.GetLedgerEntries(ctx, keys)
-						if extractErr != nil {
-							primaryErr = extractErr
+					entries := snapshotEntries
+					if entries == nil {
+						var fetchErr error
+						if entries, fetchErr = prefetch.wait(); fetchErr != nil {
+							primaryErr = fetchErr
 							return
 						}
 					}
+					if sourceOverride != nil {
+						var overrideErr error
+						if entries, overrideErr = sourceOverride.applyToEntries(ctx, client, entries); overrideErr != nil {
+							primaryErr = overrideErr
+							return
+						}
+					}
+					entries = keyFilter.filterEntries(entries)
 					primaryReq := &simulator.SimulationRequest{
-						EnvelopeXdr:   resp.EnvelopeXdr,
-						ResultMetaXdr: resp.ResultMetaXdr,
-						LedgerEntries: entries,
-						Timestamp:     ts,
+						EnvelopeXdr:     resp.EnvelopeXdr,
+						ResultMetaXdr:   resp.ResultMetaXdr,
+						LedgerEntries:   entries,
+						Timestamp:       ts,
+						ProtocolVersion: primaryProtocol,
 					}
 					applySimulationFeeMocks(primaryReq)
-					primaryResult, primaryErr = runner.Run(primaryReq)
+					ledgerEntries = entries
+					simStart := time.Now()
+					primaryResult, primaryErr = simulator.RunWithContext(ctx, runner, primaryReq)
+					stats.simulated(0, simStart)
 				}()
 
-				go func() {
-					defer wg.Done()
-					compareOpts := []rpc.ClientOption{
-						rpc.WithNetwork(rpc.Network(compareNetworkFlag)),
-						rpc.WithToken(rpcTokenFlag),
-					}
-					compareClient, clientErr := rpc.NewClient(compareOpts...)
-					if clientErr != nil {
-						compareErr = errors.WrapValidationError(fmt.Sprintf("failed to create compare client: %v", clientErr))
-						return
-					}
-					if noCacheFlag {
-						compareClient.CacheEnabled = false
-					}
-
-					compareResp, txErr := compareClient.GetTransaction(ctx, txHash)
-					if txErr != nil {
-						compareErr = errors.WrapRPCConnectionFailed(txErr)
-						return
-					}
+				for i, compareClient := range compareClients {
+					go func(i int, compareClient *rpc.Client) {
+						defer wg.Done()
+						ctx := requestIDs.compare(ctx, i)
+						compareResp, txErr := compareClient.GetTransaction(ctx, txHash)
+						if txErr != nil {
+							compareErrs[i] = errors.WrapRPCConnectionFailed(txErr)
+							return
+						}
 
-					entries, extractErr := rpc.ExtractLedgerEntriesFromMeta(compareResp.ResultMetaXdr)
-					if extractErr != nil {
-						entries, extractErr = compareClient.GetLedgerEntries(ctx, keys)
+						// As on the primary network, so both sides simulate
+						// the entries the transaction only read
+						entries, extractErr := loadLedgerEntries(ctx, compareClient, compareResp.ResultMetaXdr, keys)
 						if extractErr != nil {
-							compareErr = extractErr
+							compareErrs[i] = extractErr
 							return
 						}
-					}
+						if sourceOverride != nil {
+							if entries, extractErr = sourceOverride.applyToEntries(ctx, compareClient, entries); extractErr != nil {
+								compareErrs[i] = extractErr
+								return
+							}
+						}
+						entries = keyFilter.filterEntries(entries)
 
-					compareReq := &simulator.SimulationRequest{
-						EnvelopeXdr:   resp.EnvelopeXdr,
-						ResultMetaXdr: compareResp.ResultMetaXdr,
-						LedgerEntries: entries,
-						Timestamp:     ts,
-					}
-					applySimulationFeeMocks(compareReq)
-					compareResult, compareErr = runner.Run(compareReq)
-				}()
+						compareReq := &simulator.SimulationRequest{
+							EnvelopeXdr:   resp.EnvelopeXdr,
+							ResultMetaXdr: compareResp.ResultMetaXdr,
+							LedgerEntries: entries,
+							Timestamp:     ts,
+						}
+						compareReq.ProtocolVersion, compareProtocolNotes[i] = simulationProtocol(ctx, compareClient, compareResp.Ledger, compareProtocolVersionFlag)
+						applySimulationFeeMocks(compareReq)
+						simStart := time.Now()
+						compareResults[i], compareErrs[i] = simulator.RunWithContext(ctx, runner, compareReq)
+						stats.simulated(i+1, simStart)
+					}(i, compareClient)
+				}
 
 				wg.Wait()
 				if primaryErr != nil {
 					return errors.WrapRPCConnectionFailed(primaryErr)
 				}
-				if compareErr != nil {
-					return errors.WrapRPCConnectionFailed(compareErr)
+				for _, compareErr := range compareErrs {
+					if compareErr != nil {
+						return errors.WrapRPCConnectionFailed(compareErr)
+					}
+				}
+				for i, note := range compareProtocolNotes {
+					if note != "" {
+						fmt.Fprintf(out, "[%s] %s\n", compareNetworksFlag[i], note)
+					}
+				}
+				if protocols == nil {
+					protocols = fetchNetworkProtocols(ctx, client, compareClients, requestIDs)
+				}
+				if wasms == nil {
+					wasms = networkWasms{networkFlag: fetchContractWasms(ctx, client, keys)}
+					for i, compareClient := range compareClients {
+						wasms[compareNetworksFlag[i]] = fetchContractWasms(requestIDs.compare(ctx, i), compareClient, keys)
+					}
 				}
 				// Fetch contract bytecode on demand for contract calls in the trace; cache via RPC client
 				if client != nil && primaryResult != nil && len(primaryResult.DiagnosticEvents) > 0 {
@@ -549,37 +1044,70 @@ Local WASM Replay Mode:
 				}
 
 				simResp = primaryResult // Use primary for further analysis
-				printSimulationResult(networkFlag, primaryResult)
-				printSimulationResult(compareNetworkFlag, compareResult)
-				diffResults(primaryResult, compareResult, networkFlag, compareNetworkFlag)
+				contractErrors.learn(ledgerEntries)
+				chainCheck = checkAgainstChain(resp, primaryResult)
+				compareSimResps = compareResults
+				named := []compare.NamedResult{{Network: networkFlag, Result: filterEventsForDisplay(out, networkFlag, primaryResult)}}
+				for i, res := range compareResults {
+					named = append(named, compare.NamedResult{Network: compareNetworksFlag[i], Result: filterEventsForDisplay(out, compareNetworksFlag[i], res)})
+				}
+				for _, nr := range named {
+					printSimulationResult(out, nr.Network, nr.Result)
+				}
+				diffOutcomes(out, warns, named, wasms, protocols)
 			}
 			lastSimResp = simResp
+			lastCompareResps = compareSimResps
+			lastEntries = ledgerEntries
 		}
+		timings.record("simulate", simulateStart)
 
 		if lastSimResp == nil {
 			return errors.WrapSimulationLogicError("no simulation results generated")
 		}
+		explainOutcome(out, lastSimResp)
+		printChainCheck(out, chainCheck)
+		replayHash := bundle.ReplayHash(resp.EnvelopeXdr, resp.ResultMetaXdr, keys, lastEntries)
+		printReplayHash(out, replayHash)
+		contractInstances := printContractInstances(out, keys, lastEntries)
+		if err := writeRebuild(out, resp.EnvelopeXdr, lastEntries); err != nil {
+			return err
+		}
+		if len(compareNetworksFlag) > 0 {
+			explain(out, explainCompare)
+		}
+		if saved := entryMemo.Saved(); saved > 0 {
+			logger.Logger.Info("Reused ledger entries fetched earlier in this run", "requests_saved", saved)
+		}
+		if verbose {
+			timings.print(out)
+		}
+		stats.print(out, time.Since(runStart))
 
 		// Analysis: Error Suggestions (Heuristic-based)
 		if len(lastSimResp.Events) > 0 {
 			suggestionEngine := decoder.NewSuggestionEngine()
-			
+
 			// Decode events for analysis
 			callTree, err := decoder.DecodeEvents(lastSimResp.Events)
 			if err == nil && callTree != nil {
 				suggestions := suggestionEngine.AnalyzeCallTree(callTree)
 				if len(suggestions) > 0 {
-					fmt.Print(decoder.FormatSuggestions(suggestions))
+					fmt.Fprint(out, decoder.FormatSuggestions(suggestions))
 				}
 			}
 		}
 
+		// Analysis: Soroban authorization failures
+		authFailure := detectAuthFailure(resp.EnvelopeXdr, lastSimResp)
+		printAuthFailure(out, authFailure)
+
 		// Analysis: Security
-		fmt.Printf("\n=== Security Analysis ===\n")
+		fmt.Fprintf(out, "\n=== Security Analysis ===\n")
 		secDetector := security.NewDetector()
 		findings := secDetector.Analyze(resp.EnvelopeXdr, resp.ResultMetaXdr, lastSimResp.Events, lastSimResp.Logs)
 		if len(findings) == 0 {
-			fmt.Printf("%s No security issues detected\n", visualizer.Success())
+			fmt.Fprintf(out, "%s No security issues detected\n", visualizer.Success())
 		} else {
 			verifiedCount := 0
 			heuristicCount := 0
@@ -593,34 +1121,34 @@ Local WASM Replay Mode:
 			}
 
 			if verifiedCount > 0 {
-				fmt.Printf("\n[!]  VERIFIED SECURITY RISKS: %d\n", verifiedCount)
+				fmt.Fprintf(out, "\n[!]  VERIFIED SECURITY RISKS: %d\n", verifiedCount)
 			}
 			if heuristicCount > 0 {
-				fmt.Printf("* HEURISTIC WARNINGS: %d\n", heuristicCount)
+				fmt.Fprintf(out, "* HEURISTIC WARNINGS: %d\n", heuristicCount)
 			}
 
-			fmt.Printf("\nFindings:\n")
+			fmt.Fprintf(out, "\nFindings:\n")
 			for i, finding := range findings {
 				icon := "*"
 				if finding.Type == security.FindingVerifiedRisk {
 					icon = "[!]"
 				}
-				fmt.Printf("%d. %s [%s] %s - %s\n", i+1, icon, finding.Type, finding.Severity, finding.Title)
-				fmt.Printf("   %s\n", finding.Description)
+				fmt.Fprintf(out, "%d. %s [%s] %s - %s\n", i+1, icon, finding.Type, finding.Severity, finding.Title)
+				fmt.Fprintf(out, "   %s\n", finding.Description)
 				if finding.Evidence != "" {
-					fmt.Printf("   Evidence: %s\n", finding.Evidence)
+					fmt.Fprintf(out, "   Evidence: %s\n", finding.Evidence)
 				}
 			}
 		}
 
 		// Analysis: Token Flows
 		if report, err := tokenflow.BuildReport(resp.EnvelopeXdr, resp.ResultMetaXdr); err == nil && len(report.Agg) > 0 {
-			fmt.Printf("\nToken Flow Summary:\n")
+			fmt.Fprintf(out, "\nToken Flow Summary:\n")
 			for _, line := range report.SummaryLines() {
-				fmt.Printf("  %s\n", line)
+				fmt.Fprintf(out, "  %s\n", line)
 			}
-			fmt.Printf("\nToken Flow Chart (Mermaid):\n")
-			fmt.Println(report.MermaidFlowchart())
+			fmt.Fprintf(out, "\nToken Flow Chart (Mermaid):\n")
+			fmt.Fprintln(out, report.MermaidFlowchart())
 		}
 
 		// Session Management
@@ -631,11 +1159,11 @@ Local WASM Replay Mode:
 		applySimulationFeeMocks(simReq)
 		simReqJSON, err := json.Marshal(simReq)
 		if err != nil {
-			fmt.Printf("Warning: failed to serialize simulation data: %v\n", err)
+			fmt.Fprintf(out, "Warning: failed to serialize simulation data: %v\n", err)
 		}
 		simRespJSON, err := json.Marshal(lastSimResp)
 		if err != nil {
-			fmt.Printf("Warning: failed to serialize simulation results: %v\n", err)
+			fmt.Fprintf(out, "Warning: failed to serialize simulation results: %v\n", err)
 		}
 
 		sessionData := &session.SessionData{
@@ -655,8 +1183,72 @@ Local WASM Replay Mode:
 			SchemaVersion:   session.SchemaVersion,
 		}
 		SetCurrentSession(sessionData)
-		fmt.Printf("\nSession created: %s\n", sessionData.ID)
-		fmt.Printf("Run 'erst session save' to persist this session.\n")
+		fmt.Fprintf(out, "\nSession created: %s\n", sessionData.ID)
+		fmt.Fprintf(out, "Run 'erst session save' to persist this session.\n")
+
+		debugReport := report.NewDebugReport(txHash, networkFlag)
+		debugReport.Tool = toolInfo()
+		debugReport.EnvelopeSize = len(resp.EnvelopeXdr)
+		debugReport.DurationMs = time.Since(runStart).Milliseconds()
+		setReportAccounts(debugReport, decodedEnv)
+		debugReport.Footprint = keys
+		debugReport.FootprintTypes = keyTypes
+		debugReport.TTLs = ttls
+		debugReport.StorageChanges = storageChanges
+		debugReport.ContractInstances = contractInstances
+		debugReport.ReplayHash = replayHash
+		debugReport.Resources = resources
+		debugReport.AuthFailure = authFailure
+		debugReport.SequenceFailure = sequenceFailure
+		debugReport.FeeBump = feeBump
+		debugReport.Restore = restore
+		debugReport.ChainCheck = chainCheck
+		debugReport.Warnings = warns.all(lastSimResp)
+		debugReport.Failure = decodeFailure(lastSimResp)
+		lastSimResp.FailureCategory = failureCategory(lastSimResp)
+		for _, res := range lastCompareResps {
+			if res != nil {
+				res.FailureCategory = failureCategory(res)
+			}
+		}
+		debugReport.Result = lastSimResp
+		if includeRawFlag {
+			debugReport.Raw = &report.RawTransaction{
+				EnvelopeXdr:   resp.EnvelopeXdr,
+				ResultXdr:     resp.ResultXdr,
+				ResultMetaXdr: resp.ResultMetaXdr,
+				Keys:          keys,
+				LedgerEntries: lastEntries,
+			}
+		}
+		attachComparisons(debugReport, compareNetworksFlag, lastCompareResps, compareTTLs)
+		if len(compareNetworksFlag) > 0 {
+			debugReport.WasmDiffs = wasms.diff(networkFlag, compareNetworksFlag[0])
+			if len(protocols) > 0 {
+				debugReport.NetworkProtocols = protocols
+			}
+		}
+
+		// Checks see every event; --filter-* only narrows what is rendered.
+		checkFindings := checks.Run(debugReport, checkers)
+		printCheckFindings(out, len(checkers), checkFindings)
+		printWarnings(out, debugReport.Warnings)
+
+		if err := emitDebugReport(cmd.OutOrStdout(), out, applyEventsFormat(filterDebugReport(classifyDebugReport(debugReport), currentEventFilter()), eventsFormatFlag)); err != nil {
+			return err
+		}
+		if err := writeSummaryJSON(cmd.OutOrStdout(), debugReport, len(checkFindings)); err != nil {
+			return err
+		}
+		if len(checkFindings) > 0 {
+			return errors.WrapChecksFailed(len(checkFindings))
+		}
+		if failOnMismatchFlag && comparisonDiverged(debugReport) {
+			return errors.WrapResultsMismatch("results differ across networks")
+		}
+		if lastSimResp.Status == "error" {
+			return errors.WrapSimulationFailure(lastSimResp.FailureCategory.Reason(), lastSimResp.Error)
+		}
 		return nil
 	},
 }
@@ -778,13 +1370,8 @@ func runLocalWasmReplay() error {
 }
 
 func extractLedgerKeys(metaXdr string) ([]string, error) {
-	data, err := base64.StdEncoding.DecodeString(metaXdr)
-	if err != nil {
-		return nil, err
-	}
-
 	var meta xdr.TransactionResultMeta
-	if err := xdr.SafeUnmarshal(data, &meta); err != nil {
+	if err := decoder.UnmarshalBase64RoundTrip(metaXdr, &meta, "transaction result meta"); err != nil {
 		return nil, err
 	}
 
@@ -796,65 +1383,13 @@ func extractLedgerKeys(metaXdr string) ([]string, error) {
 
 	collectChanges := func(changes xdr.LedgerEntryChanges) {
 		for _, c := range changes {
-			switch c.Type {
-			case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
-				k, err := c.Created.LedgerKey()
-				if err == nil {
-					addKey(k)
-				}
-			case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
-				k, err := c.Updated.LedgerKey()
-				if err == nil {
-					addKey(k)
-				}
-			case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
-				if c.Removed != nil {
-					addKey(*c.Removed)
-				}
-			case xdr.LedgerEntryChangeTypeLedgerEntryState:
-				k, err := c.State.LedgerKey()
-				if err == nil {
-					addKey(k)
-				}
+			if k, ok := decoder.ChangedLedgerKey(c); ok {
+				addKey(k)
 			}
 		}
 	}
 
-	// 1. Fee processing changes
-	collectChanges(meta.FeeProcessing)
-
-	// 2. Transaction apply processing changes
-	switch meta.TxApplyProcessing.V {
-	case 0:
-		if meta.TxApplyProcessing.Operations != nil {
-			for _, op := range *meta.TxApplyProcessing.Operations {
-				collectChanges(op.Changes)
-			}
-		}
-	case 1:
-		if v1 := meta.TxApplyProcessing.V1; v1 != nil {
-			collectChanges(v1.TxChanges)
-			for _, op := range v1.Operations {
-				collectChanges(op.Changes)
-			}
-		}
-	case 2:
-		if v2 := meta.TxApplyProcessing.V2; v2 != nil {
-			collectChanges(v2.TxChangesBefore)
-			collectChanges(v2.TxChangesAfter)
-			for _, op := range v2.Operations {
-				collectChanges(op.Changes)
-			}
-		}
-	case 3:
-		if v3 := meta.TxApplyProcessing.V3; v3 != nil {
-			collectChanges(v3.TxChangesBefore)
-			collectChanges(v3.TxChangesAfter)
-			for _, op := range v3.Operations {
-				collectChanges(op.Changes)
-			}
-		}
-	}
+	decoder.WalkLedgerEntryChanges(meta, collectChanges)
 
 	res := make([]string, 0, len(keysMap))
 	for k := range keysMap {
@@ -878,16 +1413,20 @@ func collectContractIDsFromDiagnosticEvents(events []simulator.DiagnosticEvent)
 	return ids
 }
 
-func printSimulationResult(network string, res *simulator.SimulationResponse) {
-	fmt.Printf("\n--- Result for %s ---\n", network)
-	fmt.Printf("Status: %s\n", res.Status)
+func printSimulationResult(out io.Writer, network string, res *simulator.SimulationResponse) {
+	fmt.Fprintf(out, "\n--- Result for %s ---\n", network)
+	fmt.Fprintf(out, "Status: %s\n", res.Status)
 	if res.Error != "" {
-		fmt.Printf("Error: %s\n", res.Error)
+		fmt.Fprintf(out, "Error: %s\n", res.Error)
 	}
+	if c := failureCategory(res); c != "" {
+		fmt.Fprintf(out, "%s Failure category: %s (%s)\n", visualizer.Error(), c, c.Description())
+	}
+	printFailureDiagnostic(out, decodeFailure(res))
 
 	// Display budget usage if available
 	if res.BudgetUsage != nil {
-		fmt.Printf("\nResource Usage:\n")
+		fmt.Fprintf(out, "\nResource Usage:\n")
 
 		// CPU usage with percentage and warning indicator
 		cpuIndicator := ""
@@ -896,7 +1435,7 @@ func printSimulationResult(network string, res *simulator.SimulationResponse) {
 		} else if res.BudgetUsage.CPUUsagePercent >= 80.0 {
 			cpuIndicator = " [!]  WARNING"
 		}
-		fmt.Printf("  CPU Instructions: %d / %d (%.2f%%)%s\n",
+		fmt.Fprintf(out, "  CPU Instructions: %d / %d (%.2f%%)%s\n",
 			res.BudgetUsage.CPUInstructions,
 			res.BudgetUsage.CPULimit,
 			res.BudgetUsage.CPUUsagePercent,
@@ -909,115 +1448,436 @@ func printSimulationResult(network string, res *simulator.SimulationResponse) {
 		} else if res.BudgetUsage.MemoryUsagePercent >= 80.0 {
 			memIndicator = " [!]  WARNING"
 		}
-		fmt.Printf("  Memory Bytes: %d / %d (%.2f%%)%s\n",
+		fmt.Fprintf(out, "  Memory Bytes: %d / %d (%.2f%%)%s\n",
 			res.BudgetUsage.MemoryBytes,
 			res.BudgetUsage.MemoryLimit,
 			res.BudgetUsage.MemoryUsagePercent,
 			memIndicator)
 
-		fmt.Printf("  Operations: %d\n", res.BudgetUsage.OperationsCount)
+		fmt.Fprintf(out, "  Operations: %d\n", res.BudgetUsage.OperationsCount)
 	}
 
-	// Display diagnostic events with details
-	if len(res.DiagnosticEvents) > 0 {
-		fmt.Printf("\nDiagnostic Events: %d\n", len(res.DiagnosticEvents))
-		for i, event := range res.DiagnosticEvents {
-			if i < 10 { // Show first 10 events
-				fmt.Printf("  [%d] Type: %s", i+1, event.EventType)
-				if event.ContractID != nil {
-					fmt.Printf(", Contract: %s", *event.ContractID)
-				}
-				if deprecatedFn, ok := deprecatedHostFunctionInDiagnosticEvent(event); ok {
-					fmt.Printf(" %s %s", visualizer.Warning(), visualizer.Colorize("deprecated host fn: "+deprecatedFn, "yellow"))
-				}
-				fmt.Printf("\n")
-				if len(event.Topics) > 0 {
-					fmt.Printf("      Topics: %v\n", event.Topics)
-				}
-				if event.Data != "" && len(event.Data) < 100 {
-					fmt.Printf("      Data: %s\n", event.Data)
-				}
-			}
-		}
-		if len(res.DiagnosticEvents) > 10 {
-			fmt.Printf("  ... and %d more events\n", len(res.DiagnosticEvents)-10)
-		}
-	} else {
-		fmt.Printf("\nEvents: %d\n", len(res.Events))
+	switch eventsFormatFlag {
+	case eventsFormatRaw:
+		printRawEvents(out, res.Events)
+	case eventsFormatBoth:
+		printDecodedEvents(out, res)
+		printRawEvents(out, res.Events)
+	default:
+		printDecodedEvents(out, res)
 	}
 
 	// Display logs
 	if len(res.Logs) > 0 {
-		fmt.Printf("\nLogs: %d\n", len(res.Logs))
+		fmt.Fprintf(out, "\nLogs: %d\n", len(res.Logs))
 		for i, log := range res.Logs {
 			if i < 5 { // Show first 5 logs
-				fmt.Printf("  - %s\n", log)
+				fmt.Fprintf(out, "  - %s\n", log)
 			}
 		}
 		if len(res.Logs) > 5 {
-			fmt.Printf("  ... and %d more logs\n", len(res.Logs)-5)
+			fmt.Fprintf(out, "  ... and %d more logs\n", len(res.Logs)-5)
+		}
+	}
+	fmt.Fprintf(out, "Events: %d, Logs: %d\n", len(res.Events), len(res.Logs))
+}
+
+// printDecodedEvents lists the structured events of a result: contract and
+// system events first, then host diagnostics. Diagnostics of a successful run
+// are mostly call tracing noise, so they are only listed with --verbose.
+func printDecodedEvents(out io.Writer, res *simulator.SimulationResponse) {
+	if len(res.DiagnosticEvents) == 0 {
+		fmt.Fprintf(out, "\nEvents: %d\n", len(res.Events))
+		return
+	}
+
+	contractEvents, diagnostics := simulator.SplitEvents(res.DiagnosticEvents)
+	printEventList(out, "Contract Events", contractEvents)
+	if len(diagnostics) == 0 {
+		return
+	}
+	if !verbose && res.Status != "error" {
+		fmt.Fprintf(out, "\nDiagnostic Events: %d (use --verbose to show)\n", len(diagnostics))
+		// Errors raised and recovered from are still worth seeing
+		printEventList(out, "Error Diagnostics", errorEvents(diagnostics))
+		return
+	}
+	printEventList(out, "Diagnostic Events", diagnostics)
+}
+
+// eventDataLimit is the length beyond which event data is shown as its size
+// and hash, or zero with --full-events.
+func eventDataLimit() int {
+	if fullEventsFlag {
+		return 0
+	}
+	return simulator.DefaultEventDataLimit
+}
+
+func printEventList(out io.Writer, title string, events []simulator.DiagnosticEvent) {
+	if len(events) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\n%s: %d\n", title, len(events))
+	for i, event := range events {
+		if i >= 10 { // Show first 10 events
+			fmt.Fprintf(out, "  ... and %d more events\n", len(events)-10)
+			break
+		}
+		event = simulator.AbbreviateEvent(event, eventDataLimit())
+		fmt.Fprintf(out, "  [%d] Type: %s", i+1, event.EventType)
+		if event.ContractID != nil {
+			fmt.Fprintf(out, ", Contract: %s", *event.ContractID)
+		}
+		fmt.Fprint(out, severityLabel(event.Severity))
+		if deprecatedFn, ok := deprecatedHostFunctionInDiagnosticEvent(event); ok {
+			fmt.Fprintf(out, " %s %s", visualizer.Warning(), visualizer.Colorize("deprecated host fn: "+deprecatedFn, "yellow"))
+		}
+		fmt.Fprintf(out, "\n")
+		if len(event.Topics) > 0 {
+			fmt.Fprintf(out, "      Topics: %v\n", event.Topics)
+		}
+		if event.Data != "" {
+			fmt.Fprintf(out, "      Data: %s\n", event.Data)
+		}
+	}
+}
+
+// printFailureDiagnostic shows the decoded error diagnostic of a failed run,
+// the most direct answer to why a contract reverted.
+func printFailureDiagnostic(out io.Writer, f *simulator.FailureDiagnostic) {
+	if f == nil {
+		return
+	}
+	if f.ErrorName != "" {
+		fmt.Fprintf(out, "\n%s Contract failure: %s (%s)\n", visualizer.Error(), f.ErrorName, f.Error)
+	} else {
+		fmt.Fprintf(out, "\n%s Contract failure: %s\n", visualizer.Error(), f.Error)
+	}
+	if f.ErrorDoc != "" {
+		fmt.Fprintf(out, "  %s\n", f.ErrorDoc)
+	}
+	if f.ContractID != "" {
+		fmt.Fprintf(out, "  Contract: %s\n", f.ContractID)
+	}
+	if f.Message != "" {
+		fmt.Fprintf(out, "  Message:  %s\n", f.Message)
+	}
+	if f.Data != "" && f.Data != f.Message {
+		fmt.Fprintf(out, "  Data:     %s\n", f.Data)
+	}
+	if len(f.CallStack) > 0 {
+		fmt.Fprintf(out, "  Call stack (innermost last):\n")
+		for i, frame := range f.CallStack {
+			fmt.Fprintf(out, "    %d: %s", i, frame.Function)
+			if frame.ContractID != "" {
+				fmt.Fprintf(out, " (%s)", frame.ContractID)
+			}
+			fmt.Fprintf(out, "\n")
+		}
+	}
+}
+
+// failureCategory returns the category of a failed result, classifying it
+// when the runner that produced it did not.
+func failureCategory(res *simulator.SimulationResponse) simulator.FailureCategory {
+	if res == nil {
+		return ""
+	}
+	if res.FailureCategory != "" {
+		return res.FailureCategory
+	}
+	return simulator.ClassifyFailure(res)
+}
+
+// decodeFailure returns the failure diagnostic of a failed result, or nil.
+func decodeFailure(res *simulator.SimulationResponse) *simulator.FailureDiagnostic {
+	if res == nil || res.Status != "error" {
+		return nil
+	}
+	f := simulator.DecodeFailure(res.DiagnosticEvents)
+	contractErrors.name(f)
+	return f
+}
+
+// printRawEvents lists events in the raw form emitted by the simulator.
+func printRawEvents(out io.Writer, events []string) {
+	if len(events) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\nRaw Events: %d\n", len(events))
+	for i, ev := range events {
+		if i >= 10 {
+			fmt.Fprintf(out, "  ... and %d more events\n", len(events)-10)
+			break
+		}
+		fmt.Fprintf(out, "  [%d] %s\n", i+1, simulator.AbbreviateEventData(ev, eventDataLimit()))
+	}
+}
+
+// resolveRPCToken returns the RPC token from --rpc-token, ERST_RPC_TOKEN or
+// the config file, in that order of precedence.
+func resolveRPCToken() string {
+	if rpcTokenFlag != "" {
+		return rpcTokenFlag
+	}
+	if token := os.Getenv("ERST_RPC_TOKEN"); token != "" {
+		return token
+	}
+	if cfg, err := config.Load(); err == nil {
+		return cfg.RPCToken
+	}
+	return ""
+}
+
+// primaryClientOptions builds the client options for --network, taking
+// endpoints from --rpc-url or the config file. It also returns the Horizon
+// URL those endpoints imply, or "" to use the network default. A
+// --network-json network brings its own endpoints and passphrase.
+func primaryClientOptions(token string, memo *rpc.EntryMemo) ([]rpc.ClientOption, string) {
+	opts := append([]rpc.ClientOption{
+		rpc.WithNetwork(rpc.Network(networkFlag)),
+		rpc.WithToken(token),
+		rpc.WithEntryMemo(memo),
+	}, txSourceOptions()...)
+	opts = append(opts, archivalOptions()...)
+	opts = append(opts, rawHorizonOptions()...)
+	if customNetwork != nil {
+		return append(opts, rpc.WithNetworkConfig(*customNetwork)), customNetwork.HorizonURL
+	}
+
+	if rpcURLFlag != "" {
+		urls := splitURLList(rpcURLFlag)
+		return append(opts, rpc.WithAltURLs(urls)), urls[0]
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return opts, ""
+	}
+	if len(cfg.RpcUrls) > 0 {
+		return append(opts, rpc.WithAltURLs(cfg.RpcUrls)), cfg.RpcUrls[0]
+	}
+	if cfg.RpcUrl != "" {
+		return append(opts, rpc.WithHorizonURL(cfg.RpcUrl)), cfg.RpcUrl
+	}
+	return opts, ""
+}
+
+// detectAuthFailure looks for a failed Soroban authorization check in a
+// simulation result and matches it to the transaction's auth entries.
+func detectAuthFailure(envelopeXdr string, res *simulator.SimulationResponse) *authtrace.SorobanAuthFailure {
+	if res == nil || res.Status != "error" {
+		return nil
+	}
+	diagnostics := make([]string, 0, len(res.DiagnosticEvents)+len(res.Events)+len(res.Logs))
+	for _, ev := range res.DiagnosticEvents {
+		diagnostics = append(diagnostics, strings.Join(ev.Topics, " ")+" "+ev.Data)
+	}
+	diagnostics = append(diagnostics, res.Events...)
+	diagnostics = append(diagnostics, res.Logs...)
+	return authtrace.DetectSorobanAuthFailure(envelopeXdr, res.Error, diagnostics)
+}
+
+func printAuthFailure(out io.Writer, f *authtrace.SorobanAuthFailure) {
+	if f == nil {
+		return
+	}
+	fmt.Fprintf(out, "\n=== Authorization Failure ===\n")
+	fmt.Fprintf(out, "%s Reason: %s\n", visualizer.Error(), f.Reason)
+	if f.InvokingContract != "" {
+		fmt.Fprintf(out, "  Invoking contract: %s", f.InvokingContract)
+		if f.Function != "" {
+			fmt.Fprintf(out, " (%s)", f.Function)
+		}
+		fmt.Fprintln(out)
+	}
+	if f.EntryIndex < 0 {
+		fmt.Fprintf(out, "  Auth entry: none of the transaction's entries matched\n")
+	} else {
+		fmt.Fprintf(out, "  Auth entry: operation %d, entry %d (%s credentials)\n", f.OperationIndex, f.EntryIndex, f.CredentialsType)
+	}
+	if f.Address != "" {
+		fmt.Fprintf(out, "  Address: %s\n", f.Address)
+	}
+	if f.ProvidedNonce != nil {
+		fmt.Fprintf(out, "  Provided nonce: %d\n", *f.ProvidedNonce)
+	}
+	if f.ExpectedNonce != "" {
+		fmt.Fprintf(out, "  Expected nonce: %s\n", f.ExpectedNonce)
+	}
+	if f.SignatureExpirationLedger > 0 {
+		fmt.Fprintf(out, "  Signature expires at ledger: %d\n", f.SignatureExpirationLedger)
+	}
+	if f.HostMessage != "" {
+		fmt.Fprintf(out, "  Host message: %s\n", f.HostMessage)
+	}
+	fmt.Fprintf(out, "  Hint: %s\n", f.Hint())
+}
+
+// validateCompareURLFlags checks the compare-side endpoint overrides. They
+// describe a single endpoint, so they require exactly one --compare-network.
+func validateCompareURLFlags() error {
+	if compareRPCURLFlag == "" && compareHorizonURL == "" && compareSorobanURL == "" {
+		return nil
+	}
+	if len(compareNetworksFlag) != 1 {
+		return errors.WrapValidationError("--compare-rpc-url, --compare-horizon-url and --compare-soroban-rpc-url require exactly one --compare-network")
+	}
+
+	var urls []string
+	if compareRPCURLFlag != "" {
+		urls = append(urls, splitURLList(compareRPCURLFlag)...)
+	}
+	for _, u := range []string{compareHorizonURL, compareSorobanURL} {
+		if u != "" {
+			urls = append(urls, u)
 		}
 	}
-	fmt.Printf("Events: %d, Logs: %d\n", len(res.Events), len(res.Logs))
+	for _, u := range urls {
+		if err := rpc.ValidateURL(u); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("invalid compare URL %q: %v", u, err))
+		}
+	}
+	return nil
 }
 
-func diffResults(res1, res2 *simulator.SimulationResponse, net1, net2 string) {
-	fmt.Printf("\n=== Comparison: %s vs %s ===\n", net1, net2)
+// compareURLOptions turns the compare-side endpoint overrides into client
+// options. --compare-rpc-url mirrors --rpc-url: a comma-separated failover
+// list whose first entry is also used as the Horizon URL. A
+// --compare-network-json network brings its own endpoints and passphrase.
+func compareURLOptions() []rpc.ClientOption {
+	if customCompareNetwork != nil {
+		return []rpc.ClientOption{rpc.WithNetworkConfig(*customCompareNetwork)}
+	}
+	var opts []rpc.ClientOption
+	if compareRPCURLFlag != "" {
+		opts = append(opts, rpc.WithAltURLs(splitURLList(compareRPCURLFlag)))
+	}
+	if compareHorizonURL != "" {
+		opts = append(opts, rpc.WithHorizonURL(compareHorizonURL))
+	}
+	if compareSorobanURL != "" {
+		opts = append(opts, rpc.WithSorobanURL(compareSorobanURL))
+	}
+	return opts
+}
+
+func splitURLList(list string) []string {
+	urls := strings.Split(list, ",")
+	for i := range urls {
+		urls[i] = strings.TrimSpace(urls[i])
+	}
+	return urls
+}
+
+// diffOutcomes compares the results of every network in a run. Two networks
+// get the detailed two-way diff; more are grouped by identical outcome, and
+// each divergent group is diffed against the majority. wasms, when known,
+// adds the contracts whose code differs to each diff, and protocols labels
+// each network with its protocol version and warns when they differ.
+func diffOutcomes(out io.Writer, warns *debugWarnings, results []compare.NamedResult, wasms networkWasms, protocols networkProtocols) {
+	names := make([]string, len(results))
+	labels := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.Network
+		labels[i] = protocols.label(r.Network)
+	}
+	warnProtocolMismatch(out, warns, names, protocols)
+
+	if len(results) == 2 {
+		diffResults(out, results[0].Result, results[1].Result, results[0].Network, results[1].Network,
+			wasms.diff(results[0].Network, results[1].Network), protocols)
+		return
+	}
+
+	fmt.Fprintf(out, "\n=== Comparison: %s ===\n", strings.Join(labels, " vs "))
+
+	groups := compare.GroupOutcomes(results, compare.Mode(compareModeFlag))
+	if len(groups) == 1 {
+		fmt.Fprintf(out, "%s All %d networks produced the same outcome (%s)\n", visualizer.Success(), len(results), groups[0].Status)
+		return
+	}
+
+	majority := compare.HasMajority(groups)
+	fmt.Fprintf(out, "Outcome groups: %d\n", len(groups))
+	for i, g := range groups {
+		label := g.Status
+		if g.Error != "" {
+			label += ": " + g.Error
+		}
+		marker := ""
+		if majority && i > 0 {
+			marker = " " + visualizer.Warning() + " DIVERGENT"
+		}
+		fmt.Fprintf(out, "  [%d] %s (%s)%s\n", i+1, strings.Join(g.Networks, ", "), label, marker)
+	}
+	if !majority {
+		fmt.Fprintf(out, "No majority outcome; differences are shown against group 1\n")
+	}
+
+	base := groups[0].Representative()
+	for _, g := range groups[1:] {
+		other := g.Representative()
+		diffResults(out, base.Result, other.Result, base.Network, other.Network, wasms.diff(base.Network, other.Network), protocols)
+	}
+}
+
+func diffResults(out io.Writer, res1, res2 *simulator.SimulationResponse, net1, net2 string, wasmDiffs []decoder.WasmDiff, protocols networkProtocols) {
+	fmt.Fprintf(out, "\n=== Comparison: %s vs %s ===\n", protocols.label(net1), protocols.label(net2))
+	if diffFormatFlag == diffFormatUnified {
+		printWasmDiffs(out, wasmDiffs, net1, net2)
+		writeUnifiedDiff(out, res1, res2, net1, net2)
+		return
+	}
 
 	if res1.Status != res2.Status {
-		fmt.Printf("Status Mismatch: %s (%s) vs %s (%s)\n", res1.Status, net1, res2.Status, net2)
+		fmt.Fprintf(out, "Status Mismatch: %s (%s) vs %s (%s)\n", res1.Status, net1, res2.Status, net2)
 	} else {
-		fmt.Printf("Status Match: %s\n", res1.Status)
+		fmt.Fprintf(out, "Status Match: %s\n", res1.Status)
 	}
+	printWasmDiffs(out, wasmDiffs, net1, net2)
 
 	// Compare diagnostic events if available
 	if len(res1.DiagnosticEvents) > 0 && len(res2.DiagnosticEvents) > 0 {
 		if len(res1.DiagnosticEvents) != len(res2.DiagnosticEvents) {
-			fmt.Printf("[DIFF] Diagnostic events count mismatch: %d vs %d\n",
+			fmt.Fprintf(out, "[DIFF] Diagnostic events count mismatch: %d vs %d\n",
 				len(res1.DiagnosticEvents), len(res2.DiagnosticEvents))
 		}
 	} else if len(res1.Events) != len(res2.Events) {
-		fmt.Printf("[DIFF] Events count mismatch: %d vs %d\n", len(res1.Events), len(res2.Events))
+		fmt.Fprintf(out, "[DIFF] Events count mismatch: %d vs %d\n", len(res1.Events), len(res2.Events))
 	}
 
 	// Compare budget usage if available
 	if res1.BudgetUsage != nil && res2.BudgetUsage != nil {
 		if res1.BudgetUsage.CPUInstructions != res2.BudgetUsage.CPUInstructions {
-			fmt.Printf("[DIFF] CPU instructions: %d vs %d\n",
+			fmt.Fprintf(out, "[DIFF] CPU instructions: %d vs %d\n",
 				res1.BudgetUsage.CPUInstructions, res2.BudgetUsage.CPUInstructions)
 		}
 		if res1.BudgetUsage.MemoryBytes != res2.BudgetUsage.MemoryBytes {
-			fmt.Printf("[DIFF] Memory bytes: %d vs %d\n",
+			fmt.Fprintf(out, "[DIFF] Memory bytes: %d vs %d\n",
 				res1.BudgetUsage.MemoryBytes, res2.BudgetUsage.MemoryBytes)
 		}
 	}
 
 	// Compare Events
-	fmt.Println("\nEvent Diff:")
-	maxEvents := len(res1.Events)
-	if len(res2.Events) > maxEvents {
-		maxEvents = len(res2.Events)
-	}
-
-	for i := 0; i < maxEvents; i++ {
-		var ev1, ev2 string
-		if i < len(res1.Events) {
-			ev1 = res1.Events[i]
-		} else {
+	diff := compare.DiffWithMode(res1, res2, compare.Mode(compareModeFlag))
+	printSeverityChanges(out, diff.DiagnosticDiffs, net1, net2)
+	fmt.Fprintln(out, "\nEvent Diff:")
+	for _, d := range diff.EventDiffs {
+		if !d.Divergent {
+			continue
+		}
+		ev1 := simulator.AbbreviateEventData(d.LocalEvent, eventDataLimit())
+		ev2 := simulator.AbbreviateEventData(d.OnChainEvent, eventDataLimit())
+		if d.Index >= len(res1.Events) {
 			ev1 = "<missing>"
 		}
-
-		if i < len(res2.Events) {
-			ev2 = res2.Events[i]
-		} else {
+		if d.Index >= len(res2.Events) {
 			ev2 = "<missing>"
 		}
-
-		if ev1 != ev2 {
-			fmt.Printf("  [%d] MISMATCH:\n", i)
-			fmt.Printf("    %s: %s\n", net1, ev1)
-			fmt.Printf("    %s: %s\n", net2, ev2)
-		}
+		fmt.Fprintf(out, "  [%d] MISMATCH:\n", d.Index)
+		fmt.Fprintf(out, "    %s: %s\n", net1, ev1)
+		fmt.Fprintf(out, "    %s: %s\n", net2, ev2)
 	}
 }
 
@@ -1036,6 +1896,164 @@ func applySimulationFeeMocks(req *simulator.SimulationRequest) {
 	}
 }
 
+// fetchEntryTTLs reports the remaining TTL of every contract-data and
+// contract-code entry in the footprint on the given network, warning about
+// entries that are missing, expired, or close to expiring. The fetched TTLs
+// are returned so they can be attached to the debug report.
+func fetchEntryTTLs(ctx context.Context, out io.Writer, warns *debugWarnings, network string, client *rpc.Client, keys []string) []rpc.EntryTTL {
+	ttls, err := client.GetEntryTTLs(ctx, keys)
+	if err != nil {
+		fmt.Fprintf(out, "%s Failed to fetch entry TTLs on %s: %v\n", visualizer.Warning(), network, err)
+		return nil
+	}
+	if len(ttls) == 0 {
+		fmt.Fprintf(out, "\nEntry TTLs (%s): no contract entries in footprint\n", network)
+		return nil
+	}
+
+	fmt.Fprintf(out, "\nEntry TTLs (%s, latest ledger %d):\n", network, ttls[0].LatestLedger)
+	var missing, expiring []string
+	for _, ttl := range ttls {
+		marker := " "
+		if ttl.Missing || ttl.Expired() || ttl.ExpiresWithin(ttlWarnLedgers) {
+			marker = visualizer.Warning()
+		}
+		if ttl.Missing {
+			missing = append(missing, ttl.Key)
+		} else if ttl.Expired() || ttl.ExpiresWithin(ttlWarnLedgers) {
+			expiring = append(expiring, ttl.Key)
+		}
+		fmt.Fprintf(out, "  %s %s\n", marker, ttl)
+		fmt.Fprintf(out, "      key: %s\n", ttl.Key)
+	}
+	if len(missing) > 0 {
+		warns.add(out, simulator.Warning{
+			Code:    simulator.WarningArchivedEntries,
+			Message: fmt.Sprintf("%d footprint entries not found on %s (archived or never created)", len(missing), network),
+			Keys:    missing,
+		})
+	}
+	if len(expiring) > 0 {
+		warns.add(out, simulator.Warning{
+			Code:    simulator.WarningTTLExpiring,
+			Message: fmt.Sprintf("%d footprint entries on %s have expired or expire within %d ledgers", len(expiring), network, ttlWarnLedgers),
+			Keys:    expiring,
+		})
+	}
+	return ttls
+}
+
+// printStorageChanges shows the before and after value of every
+// contract-data entry the on-chain transaction updated. The changes are
+// returned so they can be attached to the debug report.
+func printStorageChanges(out io.Writer, resultMetaXdr string) []decoder.StorageChange {
+	changes, err := decoder.StorageChanges(resultMetaXdr)
+	if err != nil {
+		fmt.Fprintf(out, "%s Failed to decode storage changes: %v\n", visualizer.Warning(), err)
+		return nil
+	}
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "\nStorage changes: no contract data was updated\n")
+		return nil
+	}
+
+	fmt.Fprintf(out, "\nStorage changes (%d):\n", len(changes))
+	for _, c := range changes {
+		fmt.Fprintf(out, "  %s [%s] %s\n", c.Contract, c.Durability, c.Key)
+		fmt.Fprintf(out, "      %s\n", c.Diff)
+	}
+	return changes
+}
+
+// printSorobanResources shows the resources and fee the transaction
+// declared, the fees it was charged, and any entries it changed without
+// declaring them in its footprint.
+func printSorobanResources(out io.Writer, warns *debugWarnings, envelopeXdr, resultMetaXdr string) *decoder.SorobanResources {
+	res, err := decoder.DecodeSorobanResources(envelopeXdr, resultMetaXdr)
+	if err != nil {
+		fmt.Fprintf(out, "%s Failed to decode Soroban resources: %v\n", visualizer.Warning(), err)
+		return nil
+	}
+	if res == nil {
+		fmt.Fprintf(out, "\nSoroban resources: not a Soroban transaction\n")
+		return nil
+	}
+
+	fmt.Fprintf(out, "\nDeclared Soroban resources:\n")
+	fmt.Fprintf(out, "  Instructions:    %d\n", res.Instructions)
+	fmt.Fprintf(out, "  Disk read bytes: %d\n", res.DiskReadBytes)
+	fmt.Fprintf(out, "  Write bytes:     %d\n", res.WriteBytes)
+	fmt.Fprintf(out, "  Resource fee:    %d stroops\n", res.ResourceFee)
+	if res.RefundableFeeCharged != nil {
+		fmt.Fprintf(out, "  Charged:         %d non-refundable + %d refundable (rent %d) stroops\n",
+			*res.NonRefundableFeeCharged, *res.RefundableFeeCharged, *res.RentFeeCharged)
+	}
+	for _, set := range []struct {
+		name string
+		keys []string
+	}{{"Read-only", res.ReadOnly}, {"Read-write", res.ReadWrite}} {
+		fmt.Fprintf(out, "  %s footprint (%d):\n", set.name, len(set.keys))
+		for _, k := range set.keys {
+			fmt.Fprintf(out, "    %s\n", k)
+		}
+	}
+
+	if len(res.Undeclared) > 0 {
+		warns.add(out, simulator.Warning{
+			Code:    simulator.WarningUndeclaredFootprint,
+			Message: fmt.Sprintf("The transaction changed %d ledger entries missing from its declared footprint (under-declared resources)", len(res.Undeclared)),
+			Keys:    res.Undeclared,
+		})
+	}
+	return res
+}
+
+// printCheckFindings summarizes the outcome of --check rules.
+func printCheckFindings(out io.Writer, total int, findings []checks.Finding) {
+	if total == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\n=== Checks ===\n")
+	if len(findings) == 0 {
+		fmt.Fprintf(out, "%s All %d checks passed\n", visualizer.Success(), total)
+		return
+	}
+	fmt.Fprintf(out, "%s %d finding(s):\n", visualizer.Error(), len(findings))
+	for _, f := range findings {
+		fmt.Fprintf(out, "  - %s\n", f)
+	}
+}
+
+// currentEventFilter builds the event filter selected by --filter-contract
+// and --filter-topic.
+func currentEventFilter() simulator.EventFilter {
+	return simulator.EventFilter{
+		ContractIDs: filterContractFlag,
+		Topics:      filterTopicFlag,
+		MinSeverity: simulator.EventSeverity(minSeverityFlag),
+		Classifier:  eventClassifier,
+	}
+}
+
+// filterEventsForDisplay applies the active event filter to a response before
+// it is printed or diffed. Both compare sides go through the same filter so
+// the diff stays aligned. A note is printed when the filter hides every event.
+// Every structured event is classified by severity first.
+func filterEventsForDisplay(out io.Writer, network string, res *simulator.SimulationResponse) *simulator.SimulationResponse {
+	res = eventClassifier.Annotate(res)
+	filter := currentEventFilter()
+	if res == nil || filter.IsEmpty() {
+		return res
+	}
+
+	filtered := filter.Apply(res)
+	total := len(res.Events) + len(res.DiagnosticEvents)
+	if total > 0 && len(filtered.Events)+len(filtered.DiagnosticEvents) == 0 {
+		fmt.Fprintf(out, "Note: event filters excluded all %d events on %s\n", total, network)
+	}
+	return filtered
+}
+
 var deprecatedSorobanHostFunctions = []string{
 	"bytes_copy_from_linear_memory",
 	"bytes_copy_to_linear_memory",
@@ -1066,16 +2084,29 @@ func findDeprecatedHostFunction(input string) (string, bool) {
 }
 
 func init() {
-	debugCmd.Flags().StringVarP(&networkFlag, "network", "n", "mainnet", "Stellar network (auto-detected when omitted; testnet, mainnet, futurenet)")
+	debugCmd.Flags().StringVarP(&networkFlag, "network", "n", "mainnet", "Stellar network (inferred from --rpc-url or auto-detected when omitted; testnet, mainnet, futurenet)")
+	debugCmd.Flags().StringVar(&networkJSONFlag, "network-json", "", `Custom network as JSON, e.g. '{"name":"local","networkPassphrase":"...","sorobanRPCURL":"http://localhost:8000/soroban/rpc"}'; overrides --network and the config file`)
+	debugCmd.Flags().BoolVar(&autoNetworkFlag, "auto-network", false, "Search mainnet, testnet and futurenet for the transaction, even when --network is set")
 	debugCmd.Flags().StringVar(&rpcURLFlag, "rpc-url", "", "Custom RPC URL")
 	debugCmd.Flags().StringVar(&rpcTokenFlag, "rpc-token", "", "RPC authentication token (can also use ERST_RPC_TOKEN env var)")
 	debugCmd.Flags().BoolVar(&tracingEnabled, "tracing", false, "Enable tracing")
 	debugCmd.Flags().StringVar(&otlpExporterURL, "otlp-url", "http://localhost:4318", "OTLP URL")
 	debugCmd.Flags().BoolVar(&generateTrace, "generate-trace", false, "Generate trace file")
 	debugCmd.Flags().StringVar(&traceOutputFile, "trace-output", "", "Trace output file")
-	debugCmd.Flags().StringVar(&snapshotFlag, "snapshot", "", "Load state from JSON snapshot file")
-	debugCmd.Flags().StringVar(&compareNetworkFlag, "compare-network", "", "Network to compare against (testnet, mainnet, futurenet)")
-	debugCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	debugCmd.Flags().StringVar(&snapshotFlag, "snapshot", "", "Simulate against a locally captured ledger snapshot (JSON, as written by erst export --snapshot); footprint entries are looked up in it instead of fetched over RPC")
+	debugCmd.Flags().StringVar(&envelopesFileFlag, "envelopes-file", "", "Simulate the envelopes in this JSON array file in order, instead of fetching a transaction; each is a base64 envelope or {\"envelope_xdr\": ..., \"result_meta_xdr\": ...}")
+	debugCmd.Flags().BoolVar(&accumulateStateFlag, "accumulate-state", false, "With --envelopes-file, apply each envelope's ledger changes, from its result meta, before simulating the next")
+	debugCmd.Flags().StringVar(&entriesFileFlag, "entries-file", "", "Simulate with the ledger entries in this JSON file (key to entry, or a snapshot) instead of fetching them")
+	debugCmd.Flags().StringSliceVar(&compareNetworksFlag, "compare-network", nil, "Network to compare against (testnet, mainnet, futurenet); repeatable for an N-way comparison")
+	debugCmd.Flags().StringVar(&compareTxFlag, "compare-tx", "", "Hash of a different transaction on the same network to simulate and diff against this one")
+	debugCmd.Flags().StringVar(&compareLedgerFlag, "compare-ledger", "", "Simulate the transaction against the state of two ledgers on the same network, as <seqA>:<seqB>, and diff the outcomes")
+	debugCmd.Flags().BoolVar(&failOnMismatchFlag, "fail-on-mismatch", false, "Exit with status 4 when a --compare-network, --compare-tx or --compare-ledger result differs from the primary")
+	debugCmd.Flags().StringVar(&compareRPCURLFlag, "compare-rpc-url", "", "Custom RPC URL(s) for the compare network, comma-separated for failover")
+	debugCmd.Flags().StringVar(&compareHorizonURL, "compare-horizon-url", "", "Custom Horizon URL for the compare network")
+	debugCmd.Flags().StringVar(&compareSorobanURL, "compare-soroban-rpc-url", "", "Custom Soroban RPC URL for the compare network")
+	debugCmd.Flags().StringVar(&compareNetworkJSONFlag, "compare-network-json", "", `Custom network to compare against, as JSON with the --network-json keys, e.g. a local mainnet fork: '{"name":"fork","networkPassphrase":"Public Global Stellar Network ; September 2015","sorobanRPCURL":"http://localhost:8000/soroban/rpc"}'`)
+	debugCmd.Flags().StringVar(&compareModeFlag, "compare-mode", string(compare.ModeStrict), "How events are matched across networks: strict (by position), set (ignore order), normalized (also ignore formatting and core_metrics resource counter events)")
+	debugCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output, including host diagnostic events of successful runs")
 	debugCmd.Flags().StringVar(&wasmPath, "wasm", "", "Path to local WASM file for local replay (no network required)")
 	debugCmd.Flags().StringSliceVar(&args, "args", []string{}, "Mock arguments for local replay (JSON array of strings)")
 	debugCmd.Flags().BoolVar(&noCacheFlag, "no-cache", false, "Disable local ledger state caching")
@@ -1084,6 +2115,55 @@ func init() {
 	debugCmd.Flags().IntVar(&watchTimeoutFlag, "watch-timeout", 30, "Timeout in seconds for watch mode")
 	debugCmd.Flags().Uint32Var(&mockBaseFeeFlag, "mock-base-fee", 0, "Override base fee (stroops) for local fee sufficiency checks")
 	debugCmd.Flags().Uint64Var(&mockGasPriceFlag, "mock-gas-price", 0, "Override gas price multiplier for local fee sufficiency checks")
+	debugCmd.Flags().StringVar(&themeFlag, "theme", "", "Color theme (default, deuteranopia, protanopia, tritanopia, high-contrast)")
+	debugCmd.Flags().Int64Var(&mockTimeFlag, "mock-time", 0, "Override the ledger timestamp (Unix epoch seconds) for every simulation")
+	debugCmd.Flags().Uint32Var(&protocolVersionFlag, "protocol-version", 0, "Protocol version to simulate under (20, 21, 22, ...); defaults to the version the transaction's ledger ran")
+	debugCmd.Flags().Uint32Var(&compareProtocolVersionFlag, "compare-protocol-version", 0, "Protocol version to simulate the --compare-network or --compare-tx side under; defaults to the version its ledger ran")
+	debugCmd.Flags().StringVarP(&outputFormatFlag, "output", "o", outputFormatText, "Output format: text, json, markdown, or line (one logfmt line per transaction: hash, network, status, category, events, duration)")
+	debugCmd.Flags().StringSliceVar(&filterContractFlag, "filter-contract", nil, "Only print and diff events emitted by this contract ID (repeatable)")
+	debugCmd.Flags().StringSliceVar(&filterTopicFlag, "filter-topic", nil, "Only print and diff events with this topic symbol (repeatable)")
+	debugCmd.Flags().StringVar(&minSeverityFlag, "min-severity", "", "Only print and diff events at least this severe: info, warning or error")
+	debugCmd.Flags().StringVar(&severityRulesFlag, "severity-rules", "", "File of event severity rules, one '<severity> topic|contract|data <value>' per line, tried before the built-in patterns")
+	debugCmd.Flags().BoolVar(&showResourcesFlag, "show-resources", false, "Show the transaction's declared Soroban footprint, resource limits and fees, flagging entries it changed without declaring")
+	debugCmd.Flags().BoolVar(&showStorageFlag, "show-storage-changes", false, "Show the before and after value of every contract-data entry the transaction updated")
+	debugCmd.Flags().BoolVar(&showTTLFlag, "show-ttl", false, "Fetch and display the TTL of contract entries in the footprint, warning when close to expiry")
+	debugCmd.Flags().StringVar(&eventsFormatFlag, "events", eventsFormatDecoded, "How events are shown: raw, decoded, or both (JSON output pairs raw and decoded per event)")
+	debugCmd.Flags().BoolVar(&fullEventsFlag, "full-events", false, "Show event data in full; by default data longer than 256 bytes is shown as its length and SHA-256")
+	debugCmd.Flags().BoolVar(&hostLogsFlag, "host-logs", false, "Print the simulator host's own log and diagnostic output to stderr, which often pinpoints a panic inside the contract (enables debug logging)")
+	debugCmd.Flags().StringVar(&saveBundleFlag, "save", "", "Save the transaction and ledger state for offline replay (directory, .erst.tar.gz or .zip)")
+	debugCmd.Flags().BoolVar(&redactFlag, "redact", false, "With --save, replace account addresses with stable pseudonyms; the bundle can be diffed but not faithfully replayed")
+	debugCmd.Flags().BoolVar(&redactContractsFlag, "redact-contracts", false, "With --save, also replace contract IDs with stable pseudonyms (implies --redact)")
+	debugCmd.Flags().StringVar(&replayBundleFlag, "replay", "", "Replay a bundle written by --save instead of fetching from the network")
+	debugCmd.Flags().BoolVar(&printReplayHashFlag, "print-replay-hash", false, "Print the replay hash: a stable hash of the envelope, result meta, footprint keys and ledger entries simulated")
+	debugCmd.Flags().BoolVar(&autoRestoreFlag, "auto-restore", false, "When footprint entries are archived, simulate the restore Soroban RPC calls for and then the invoke with the restored state, reporting the restore's cost separately")
+	debugCmd.Flags().StringArrayVar(&errorSpecFlags, "error-spec", nil, "Name a contract's error codes: <contractID>=<json or file> mapping codes to names, e.g. '{\"5\":\"InsufficientBalance\"}'; repeatable. Contracts whose WASM is simulated are named from its spec")
+	debugCmd.Flags().StringArrayVar(&overrideWasmFlags, "override-wasm", nil, "Run a contract on other code: <contractID>=<wasmHash> (fetched from the network) or <contractID>=<file.wasm>; repeatable")
+	debugCmd.Flags().StringArrayVar(&bumpTTLFlags, "bump-ttl", nil, "Set an entry's TTL before simulating: <ledgerKey>=<extendTo>, where the key is a base64 TTL, ContractData or ContractCode LedgerKey and extendTo the ledger it lives until; repeatable")
+	debugCmd.Flags().BoolVar(&keysOnlyFlag, "keys-only", false, "Print the transaction's footprint, sorted base64 ledger keys, and exit without fetching entries or simulating")
+	debugCmd.Flags().StringSliceVar(&onlyKeyFlags, "only-key", nil, "Only fetch and inject the ledger entry for this base64 XDR LedgerKey (repeatable)")
+	debugCmd.Flags().IntVar(&maxKeysFlag, "max-keys", defaultMaxKeys, "Stop before fetching ledger entries when the footprint has more keys than this (0 for no limit)")
+	debugCmd.Flags().IntVar(&confirmKeysFlag, "confirm-keys", defaultConfirmKeys, "Ask before fetching the ledger entries of a mainnet footprint with more keys than this (0 to never ask)")
+	debugCmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "Fetch without asking, even above --confirm-keys; required for large mainnet footprints in non-interactive runs")
+	debugCmd.Flags().StringSliceVar(&excludeKeyFlags, "exclude-key", nil, "Drop the ledger entry for this base64 XDR LedgerKey from the simulation (repeatable)")
+	debugCmd.Flags().StringVar(&diffFormatFlag, "diff-format", diffFormatCustom, "Layout of the text comparison: custom or unified (standard unified-diff syntax, for diffstat and review tools)")
+	debugCmd.Flags().StringVar(&txSourceFlag, "tx-source", string(rpc.TransactionSourceAuto), "API to fetch the transaction from: horizon, soroban (getTransaction, no Horizon needed) or auto (Horizon unless only a Soroban RPC endpoint is configured)")
+	debugCmd.Flags().StringVar(&archivalRPCURLFlag, "archival-rpc-url", "", "Full-history Soroban RPC to retry ledger-pinned requests (on-chain events, Soroban getTransaction) against when the regular endpoint no longer retains the ledger; defaults to archival_rpc_url from the config")
+	debugCmd.Flags().StringVar(&rebuildFlag, "rebuild", "", "Write the simulated envelope, with --source-account applied, unsigned and with its sequence number updated, to this file ('-' prints it) for re-signing and submission")
+	debugCmd.Flags().BoolVar(&statsFlag, "stats", false, "Print a footer with the wall time, the time spent in RPC requests and in simulation, the number of requests and the bytes fetched (per network when comparing); also shown with --verbose")
+	debugCmd.Flags().BoolVar(&rawHorizonFlag, "raw-horizon", false, "Print Horizon's full transaction JSON (memo, signatures, fee account, ...) to stderr, apart from the report")
+	debugCmd.Flags().StringVar(&eventSourceFlag, "source", eventSourceSimulation, "Where reported events come from: chain (getEvents, compared against the re-simulation) or simulation")
+	debugCmd.Flags().BoolVar(&followFeeBumpInnerFlag, "follow-fee-bump-inner", false, "For a fee bump, report the outer (fee) and inner (logic) results separately, fetching the inner transaction by its hash when the fee bump's result meta is missing")
+	debugCmd.Flags().StringVar(&sourceAccountFlag, "source-account", "", "Simulate as if submitted by this G... or muxed M... account, using its (underlying) ledger entries instead of the original source's")
+	debugCmd.Flags().BoolVar(&explainFlag, "explain", false, "Narrate each debugging step in plain language (for newcomers to Soroban)")
+	debugCmd.Flags().BoolVar(&summaryFlag, "summary", false, "Replay every given transaction hash and print only aggregate counts (works with --output json)")
+	debugCmd.Flags().StringSliceVar(&checkRuleFiles, "check", nil, "Rule file of post-simulation checks; any failed check exits non-zero (repeatable)")
+	debugCmd.Flags().StringArrayVar(&expectEventFlags, "expect-event", nil, "Require a decoded event matching contract=<id>,topic=<symbol>,data=<text>, any field optional; exits with status 4 if none matches (repeatable)")
+	debugCmd.Flags().StringSliceVar(&redactFieldsFlag, "redact-fields", nil, "Pseudonymise these address kinds in JSON output: account, contract (comma-separated)")
+	debugCmd.Flags().BoolVar(&includeRawFlag, "include-raw", false, "Embed the envelope, result meta, footprint keys and ledger entries as base64 XDR in the JSON report")
+	debugCmd.Flags().StringVar(&reportFileFlag, "report", "", "Write a report file (Markdown, or JSON when the path ends in .json)")
+	debugCmd.Flags().StringVar(&templateFileFlag, "template", "", "Render the report through this Go text/template file instead of the text output (see examples/templates)")
+	debugCmd.Flags().StringVar(&outputFileFlag, "output-file", "", "Write the --output json|markdown or --template rendering to this file instead of stdout")
+	debugCmd.Flags().BoolVar(&summaryJSONFlag, "summary-json", false, "After the text output, print a one-line JSON summary (status, category, events, mismatches, duration) as the last line of stdout")
 
 	rootCmd.AddCommand(debugCmd)
 }
@@ -1112,6 +2192,8 @@ func checkLTOWarning(wasmFilePath string) {
 		}
 		dir = parent
 	}
+}
+
 func displaySourceLocation(loc *simulator.SourceLocation) {
 	fmt.Printf("%s Location: %s:%d:%d\n", visualizer.Symbol("location"), loc.File, loc.Line, loc.Column)
 
@@ -0,0 +1,45 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/config"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+)
+
+// archivalRPCURLFlag is --archival-rpc-url: a full-history Soroban RPC the
+// primary network falls back to for ledgers its regular endpoints no longer
+// retain.
+var archivalRPCURLFlag string
+
+// validateArchivalRPCURL checks --archival-rpc-url.
+func validateArchivalRPCURL() error {
+	if archivalRPCURLFlag == "" {
+		return nil
+	}
+	if err := rpc.ValidateURL(archivalRPCURLFlag); err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("invalid --archival-rpc-url: %v", err))
+	}
+	return nil
+}
+
+// archivalOptions returns the client option for the primary network's
+// archival endpoint: --archival-rpc-url, else archival_rpc_url from the
+// config. A --network-json network brings its own archivalRPCURL, which
+// only the flag overrides.
+func archivalOptions() []rpc.ClientOption {
+	url := archivalRPCURLFlag
+	if url == "" && customNetwork == nil {
+		if cfg, err := config.Load(); err == nil {
+			url = cfg.ArchivalRpcUrl
+		}
+	}
+	if url == "" {
+		return nil
+	}
+	return []rpc.ClientOption{rpc.WithArchivalRPCURL(url)}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var bumpTTLFlags []string
+
+// ttlBump extends one entry's TTL: the TTL entry for keyHash is set to live
+// until liveUntil before simulation.
+type ttlBump struct {
+	key       string // the key as given on the command line
+	ttlKey    string // base64 TTL LedgerKey
+	keyHash   xdr.Hash
+	liveUntil uint32
+	// dataKey is the base64 key of the contract data or code entry the TTL
+	// belongs to, empty when a TTL key was given directly.
+	dataKey string
+	// from is the live-until ledger before the bump, set once the bump is
+	// applied; existed is false when there was no TTL entry to bump.
+	from    uint32
+	existed bool
+}
+
+// parseTTLBumps parses --bump-ttl <ledgerKey>=<extendTo> values. The key is a
+// base64 XDR LedgerKey: a TTL key, or a contract data or contract code key,
+// whose TTL key is derived from it. extendTo is the ledger the entry is to
+// live until. Base64 keys may end in '=' padding, so the value is split at
+// the last '='.
+func parseTTLBumps(specs []string) ([]*ttlBump, error) {
+	var bumps []*ttlBump
+	seen := make(map[string]bool)
+	for _, spec := range specs {
+		i := strings.LastIndex(spec, "=")
+		if i <= 0 || i == len(spec)-1 {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid --bump-ttl %q: expected <ledgerKey>=<extendTo>", spec))
+		}
+		keyB64, target := spec[:i], spec[i+1:]
+		liveUntil, err := strconv.ParseUint(target, 10, 32)
+		if err != nil || liveUntil == 0 {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid --bump-ttl ledger %q: expected a ledger sequence greater than 0", target))
+		}
+		var key xdr.LedgerKey
+		if err := xdr.SafeUnmarshalBase64(keyB64, &key); err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid --bump-ttl key %q: not a base64 XDR LedgerKey", keyB64))
+		}
+
+		b := &ttlBump{key: keyB64, liveUntil: uint32(liveUntil)}
+		switch key.Type {
+		case xdr.LedgerEntryTypeTtl:
+			b.keyHash = key.Ttl.KeyHash
+		case xdr.LedgerEntryTypeContractData, xdr.LedgerEntryTypeContractCode:
+			raw, err := key.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			b.keyHash = sha256.Sum256(raw)
+			b.dataKey = keyB64
+		default:
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid --bump-ttl key %q: a %s entry has no TTL; expected a TTL, ContractData or ContractCode key", keyB64, key.Type))
+		}
+		if b.ttlKey, err = xdr.MarshalBase64(xdr.LedgerKey{
+			Type: xdr.LedgerEntryTypeTtl,
+			Ttl:  &xdr.LedgerKeyTtl{KeyHash: b.keyHash},
+		}); err != nil {
+			return nil, err
+		}
+		if seen[b.ttlKey] {
+			return nil, errors.WrapValidationError(fmt.Sprintf("--bump-ttl names the TTL of %s more than once", keyB64))
+		}
+		seen[b.ttlKey] = true
+		bumps = append(bumps, b)
+	}
+	return bumps, nil
+}
+
+// validateBumpTTL checks --bump-ttl, which rewrites the entries of a single
+// simulation, whether fetched, loaded from a snapshot or replayed.
+func validateBumpTTL() error {
+	if len(bumpTTLFlags) == 0 {
+		return nil
+	}
+	if summaryFlag || demoMode || wasmPath != "" || len(compareNetworksFlag) > 0 ||
+		compareTxFlag != "" || compareLedgerFlag != "" || keysOnlyFlag {
+		return errors.WrapValidationError("--bump-ttl cannot be combined with --summary, --demo, --wasm, --compare-network, --compare-tx, --compare-ledger or --keys-only")
+	}
+	_, err := parseTTLBumps(bumpTTLFlags)
+	return err
+}
+
+// applyTTLBumps returns entries with each bumped TTL entry set to its new
+// live-until ledger, adding the TTL entry when entries has none. entries
+// itself is left untouched.
+func applyTTLBumps(bumps []*ttlBump, entries map[string]string) (map[string]string, error) {
+	out := make(map[string]string, len(entries)+len(bumps))
+	for k, v := range entries {
+		out[k] = v
+	}
+	for _, b := range bumps {
+		entry := xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeTtl,
+				Ttl:  &xdr.TtlEntry{KeyHash: b.keyHash},
+			},
+		}
+		b.existed = false
+		if existing, ok := out[b.ttlKey]; ok {
+			if err := xdr.SafeUnmarshalBase64(existing, &entry); err != nil {
+				return nil, fmt.Errorf("failed to decode the TTL entry of %s: %w", b.key, err)
+			}
+			if entry.Data.Ttl == nil {
+				return nil, fmt.Errorf("the ledger entry for %s is not a TTL entry", b.ttlKey)
+			}
+			b.from = uint32(entry.Data.Ttl.LiveUntilLedgerSeq)
+			b.existed = true
+		}
+		entry.Data.Ttl.LiveUntilLedgerSeq = xdr.Uint32(b.liveUntil)
+		var err error
+		if out[b.ttlKey], err = xdr.MarshalBase64(entry); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// printTTLBumps says which TTLs were changed, once the bumps have been
+// applied to entries. txLedger is the ledger the transaction ran in, or 0
+// when unknown; a TTL that still ends before it leaves the entry expired.
+func printTTLBumps(out io.Writer, bumps []*ttlBump, entries map[string]string, txLedger uint32) {
+	for _, b := range bumps {
+		if b.existed {
+			fmt.Fprintf(out, "%s TTL of %s bumped from ledger %d to %d\n", visualizer.Warning(), b.key, b.from, b.liveUntil)
+		} else {
+			fmt.Fprintf(out, "%s TTL of %s set to ledger %d; it had no TTL entry\n", visualizer.Warning(), b.key, b.liveUntil)
+		}
+		if b.dataKey != "" {
+			if _, ok := entries[b.dataKey]; !ok {
+				fmt.Fprintf(out, "%s --bump-ttl: %s is not among the ledger entries, so its TTL has nothing to keep alive\n", visualizer.Warning(), b.dataKey)
+			}
+		}
+		if txLedger != 0 && b.liveUntil < txLedger {
+			fmt.Fprintf(out, "%s --bump-ttl: ledger %d is before the transaction's ledger %d, so %s is still expired\n", visualizer.Warning(), b.liveUntil, txLedger, b.key)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bumpTestDataKey(t *testing.T) (xdr.LedgerKey, string) {
+	t.Helper()
+	id := xdr.ContractId{7}
+	key := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &id},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+			Durability: xdr.ContractDataDurabilityTemporary,
+		},
+	}
+	b64, err := xdr.MarshalBase64(key)
+	require.NoError(t, err)
+	return key, b64
+}
+
+func TestParseTTLBumps(t *testing.T) {
+	key, keyB64 := bumpTestDataKey(t)
+	raw, err := key.MarshalBinary()
+	require.NoError(t, err)
+	hash := xdr.Hash(sha256.Sum256(raw))
+	ttlKey, err := xdr.MarshalBase64(xdr.LedgerKey{Type: xdr.LedgerEntryTypeTtl, Ttl: &xdr.LedgerKeyTtl{KeyHash: hash}})
+	require.NoError(t, err)
+
+	bumps, err := parseTTLBumps([]string{keyB64 + "=5000"})
+	require.NoError(t, err)
+	require.Len(t, bumps, 1)
+	assert.Equal(t, hash, bumps[0].keyHash)
+	assert.Equal(t, ttlKey, bumps[0].ttlKey)
+	assert.Equal(t, keyB64, bumps[0].dataKey)
+	assert.Equal(t, uint32(5000), bumps[0].liveUntil)
+
+	// A TTL key is taken as is
+	bumps, err = parseTTLBumps([]string{ttlKey + "=6000"})
+	require.NoError(t, err)
+	assert.Equal(t, hash, bumps[0].keyHash)
+	assert.Empty(t, bumps[0].dataKey)
+
+	account, err := xdr.MarshalBase64(xdr.LedgerKey{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: xdr.MustAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")},
+	})
+	require.NoError(t, err)
+
+	for name, spec := range map[string]string{
+		"no ledger":      keyB64,
+		"empty ledger":   keyB64 + "=",
+		"zero ledger":    keyB64 + "=0",
+		"bad ledger":     keyB64 + "=soon",
+		"bad key":        "not-xdr=100",
+		"account key":    account + "=100",
+		"duplicate bump": keyB64 + "=100",
+	} {
+		specs := []string{spec}
+		if name == "duplicate bump" {
+			specs = []string{spec, ttlKey + "=200"}
+		}
+		_, err := parseTTLBumps(specs)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestApplyTTLBumps(t *testing.T) {
+	_, keyB64 := bumpTestDataKey(t)
+	bumps, err := parseTTLBumps([]string{keyB64 + "=5000"})
+	require.NoError(t, err)
+	b := bumps[0]
+
+	existing, err := xdr.MarshalBase64(xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 90,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeTtl,
+			Ttl:  &xdr.TtlEntry{KeyHash: b.keyHash, LiveUntilLedgerSeq: 100},
+		},
+	})
+	require.NoError(t, err)
+	entries := map[string]string{b.ttlKey: existing, keyB64: "data"}
+
+	out, err := applyTTLBumps(bumps, entries)
+	require.NoError(t, err)
+	assert.Equal(t, existing, entries[b.ttlKey], "the input entries are left untouched")
+	assert.Equal(t, "data", out[keyB64])
+	assert.True(t, b.existed)
+	assert.Equal(t, uint32(100), b.from)
+
+	var bumped xdr.LedgerEntry
+	require.NoError(t, xdr.SafeUnmarshalBase64(out[b.ttlKey], &bumped))
+	assert.Equal(t, xdr.Uint32(5000), bumped.Data.Ttl.LiveUntilLedgerSeq)
+	assert.Equal(t, xdr.Uint32(90), bumped.LastModifiedLedgerSeq)
+
+	var buf bytes.Buffer
+	printTTLBumps(&buf, bumps, out, 6000)
+	assert.Contains(t, buf.String(), "bumped from ledger 100 to 5000")
+	assert.Contains(t, buf.String(), "still expired")
+
+	// A missing TTL entry is added
+	out, err = applyTTLBumps(bumps, map[string]string{})
+	require.NoError(t, err)
+	assert.False(t, b.existed)
+	require.NoError(t, xdr.SafeUnmarshalBase64(out[b.ttlKey], &bumped))
+	assert.Equal(t, b.keyHash, bumped.Data.Ttl.KeyHash)
+	assert.Equal(t, xdr.Uint32(5000), bumped.Data.Ttl.LiveUntilLedgerSeq)
+
+	buf.Reset()
+	printTTLBumps(&buf, bumps, out, 0)
+	assert.Contains(t, buf.String(), "had no TTL entry")
+	assert.Contains(t, buf.String(), "is not among the ledger entries")
+}
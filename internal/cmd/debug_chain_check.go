@@ -0,0 +1,72 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+// checkAgainstChain compares the re-simulation with the result the network
+// recorded for the transaction. It returns nil when there is nothing to
+// compare, e.g. for a replay without a recorded result.
+func checkAgainstChain(resp *rpc.TransactionResponse, sim *simulator.SimulationResponse) *compare.ChainCheck {
+	if resp == nil || sim == nil {
+		return nil
+	}
+	outcome, err := decoder.DecodeOnChainOutcome(resp.ResultXdr, resp.ResultMetaXdr)
+	if err != nil {
+		logger.Logger.Warn("Could not decode the recorded result; skipping the on-chain comparison", "error", err)
+		return nil
+	}
+	if outcome == nil {
+		return nil
+	}
+	return compare.CheckAgainstChain(sim, outcome)
+}
+
+// printChainCheck reports whether the re-simulation reproduces the on-chain
+// result, and if not, where the two disagree.
+func printChainCheck(out io.Writer, c *compare.ChainCheck) {
+	if c == nil {
+		return
+	}
+	if c.Reproduced {
+		fmt.Fprintf(out, "\n%s The re-simulation reproduces the on-chain result (%s, %s", visualizer.Success(), c.ChainStatus, c.ChainResultCode)
+		if c.EventsCompared > 0 {
+			fmt.Fprintf(out, ", %d events", c.EventsCompared)
+		}
+		fmt.Fprintln(out, ")")
+		return
+	}
+
+	fmt.Fprintf(out, "\n%s The re-simulation does not match the on-chain result. The reconstructed ledger state is probably off, e.g. entries from the wrong ledger:\n", visualizer.Warning())
+	if !c.StatusMatches() {
+		fmt.Fprintf(out, "  status: %s on chain (%s), %s in the simulation\n", c.ChainStatus, c.ChainResultCode, c.SimulatedStatus)
+	}
+	if len(c.EventMismatches) > 0 {
+		fmt.Fprintf(out, "  events: %d of %d differ\n", len(c.EventMismatches), c.EventsCompared)
+		for i, d := range c.EventMismatches {
+			if i >= 5 {
+				fmt.Fprintf(out, "    ... and %d more\n", len(c.EventMismatches)-5)
+				break
+			}
+			fmt.Fprintf(out, "    [%d] chain:      %s\n", d.Index+1, summarizeEvent(d.OnChain))
+			fmt.Fprintf(out, "        simulation: %s\n", summarizeEvent(d.Local))
+		}
+	}
+	if len(c.UnexpectedWrites) > 0 {
+		fmt.Fprintf(out, "  changes: %d entries changed on chain outside the simulated write footprint\n", len(c.UnexpectedWrites))
+		for _, k := range c.UnexpectedWrites {
+			fmt.Fprintf(out, "    %s\n", k)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAgainstChain(t *testing.T) {
+	result, err := xdr.MarshalBase64(xdr.TransactionResult{Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxBadAuth}})
+	require.NoError(t, err)
+	resp := &rpc.TransactionResponse{ResultXdr: result}
+
+	c := checkAgainstChain(resp, &simulator.SimulationResponse{Status: "error"})
+	require.NotNil(t, c)
+	assert.True(t, c.Reproduced)
+
+	assert.Nil(t, checkAgainstChain(&rpc.TransactionResponse{}, &simulator.SimulationResponse{}), "nothing recorded to compare")
+	assert.Nil(t, checkAgainstChain(&rpc.TransactionResponse{ResultXdr: "not-xdr"}, &simulator.SimulationResponse{}))
+}
+
+func TestPrintChainCheck(t *testing.T) {
+	var out bytes.Buffer
+	printChainCheck(&out, &compare.ChainCheck{Reproduced: true, ChainStatus: "failed", ChainResultCode: "tx_failed", SimulatedStatus: "failed"})
+	assert.Contains(t, out.String(), "reproduces the on-chain result (failed, tx_failed)")
+
+	out.Reset()
+	printChainCheck(&out, &compare.ChainCheck{
+		ChainStatus: "failed", ChainResultCode: "tx_failed", SimulatedStatus: "success",
+		UnexpectedWrites: []string{"k2"},
+	})
+	assert.Contains(t, out.String(), "does not match the on-chain result")
+	assert.Contains(t, out.String(), "status: failed on chain (tx_failed), success in the simulation")
+	assert.Contains(t, out.String(), "1 entries changed on chain outside the simulated write footprint")
+
+	out.Reset()
+	printChainCheck(&out, nil)
+	assert.Empty(t, out.String())
+}
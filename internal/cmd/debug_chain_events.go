@@ -0,0 +1,121 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+// Values of --source, which picks where reported events come from.
+const (
+	eventSourceSimulation = "simulation"
+	eventSourceChain      = "chain"
+)
+
+var eventSourceFlag string
+
+// validateEventSource checks --source. On-chain events are fetched for the
+// primary network only, so chain is not combined with --compare-network or
+// with runs that have no live transaction.
+func validateEventSource() error {
+	switch eventSourceFlag {
+	case eventSourceSimulation:
+		return nil
+	case eventSourceChain:
+		if len(compareNetworksFlag) > 0 || replayBundleFlag != "" || demoMode || wasmPath != "" || summaryFlag {
+			return errors.WrapValidationError("--source chain needs a live transaction and is not supported with --compare-network, --replay, --demo, --wasm or --summary")
+		}
+		return nil
+	default:
+		return errors.WrapValidationError(fmt.Sprintf("unsupported event source %q (expected chain or simulation)", eventSourceFlag))
+	}
+}
+
+// fetchChainEvents returns the decoded events txHash emitted on chain.
+func fetchChainEvents(ctx context.Context, client *rpc.Client, txHash string, ledger uint32) ([]simulator.DiagnosticEvent, error) {
+	if ledger == 0 {
+		return nil, errors.WrapValidationError("the transaction's ledger is unknown, so its on-chain events cannot be fetched")
+	}
+	events, err := client.GetEvents(ctx, ledger, nil)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := decoder.DecodeChainEvents(events, txHash)
+	if err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "getEvents")
+	}
+	return decoded, nil
+}
+
+// withChainEvents returns a copy of res whose contract and system events are
+// replaced by the on-chain ones. Host diagnostics only exist in the
+// simulation and are kept, so failures can still be decoded.
+func withChainEvents(res *simulator.SimulationResponse, chain []simulator.DiagnosticEvent) *simulator.SimulationResponse {
+	if res == nil {
+		return nil
+	}
+	_, diagnostics := simulator.SplitEvents(res.DiagnosticEvents)
+	out := *res
+	out.Events = nil
+	out.DiagnosticEvents = append(append([]simulator.DiagnosticEvent{}, chain...), diagnostics...)
+	return &out
+}
+
+// printChainEventComparison reports whether the events recorded on chain
+// match the contract events the re-simulation produced. The two sides are
+// rendered by different encoders, so they are compared in normalized mode.
+func printChainEventComparison(out io.Writer, res *simulator.SimulationResponse, chain []simulator.DiagnosticEvent) {
+	if res == nil {
+		return
+	}
+	simulated, _ := simulator.SplitEvents(res.DiagnosticEvents)
+	diff := compare.DiffWithMode(
+		&simulator.SimulationResponse{DiagnosticEvents: simulated},
+		&simulator.SimulationResponse{DiagnosticEvents: chain},
+		compare.ModeNormalized,
+	)
+
+	var divergent []compare.DiagnosticDiff
+	for _, d := range diff.DiagnosticDiffs {
+		if d.Divergent {
+			divergent = append(divergent, d)
+		}
+	}
+	if len(divergent) == 0 {
+		fmt.Fprintf(out, "\n%s On-chain events match the re-simulation (%d events)\n", visualizer.Success(), len(chain))
+		return
+	}
+
+	fmt.Fprintf(out, "\n%s %d of %d events differ between the chain and the re-simulation\n",
+		visualizer.Warning(), len(divergent), len(diff.DiagnosticDiffs))
+	for i, d := range divergent {
+		if i >= 5 {
+			fmt.Fprintf(out, "  ... and %d more\n", len(divergent)-5)
+			break
+		}
+		fmt.Fprintf(out, "  [%d] chain:      %s\n", d.Index+1, summarizeEvent(d.OnChain))
+		fmt.Fprintf(out, "      simulation: %s\n", summarizeEvent(d.Local))
+	}
+}
+
+func summarizeEvent(e *simulator.DiagnosticEvent) string {
+	if e == nil {
+		return "(none)"
+	}
+	ev := simulator.AbbreviateEvent(*e, eventDataLimit())
+	s := ev.EventType
+	if ev.ContractID != nil {
+		s += " " + *ev.ContractID
+	}
+	return fmt.Sprintf("%s %v %s", s, ev.Topics, ev.Data)
+}
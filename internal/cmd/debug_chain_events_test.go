@@ -0,0 +1,68 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEventSource(t *testing.T) {
+	prev, prevCompare := eventSourceFlag, compareNetworksFlag
+	t.Cleanup(func() { eventSourceFlag, compareNetworksFlag = prev, prevCompare })
+
+	compareNetworksFlag = nil
+	for _, v := range []string{eventSourceSimulation, eventSourceChain} {
+		eventSourceFlag = v
+		assert.NoError(t, validateEventSource(), v)
+	}
+
+	eventSourceFlag = "ledger"
+	assert.Error(t, validateEventSource())
+
+	eventSourceFlag = eventSourceChain
+	compareNetworksFlag = []string{"testnet"}
+	assert.Error(t, validateEventSource())
+}
+
+func TestWithChainEvents_KeepsDiagnostics(t *testing.T) {
+	sim := &simulator.SimulationResponse{
+		Status: "success",
+		Events: []string{"raw"},
+		DiagnosticEvents: []simulator.DiagnosticEvent{
+			{EventType: "contract", Topics: []string{"transfer"}},
+			{EventType: "diagnostic", Topics: []string{"fn_call"}},
+		},
+	}
+	chain := []simulator.DiagnosticEvent{{EventType: "contract", Topics: []string{"mint"}}}
+
+	got := withChainEvents(sim, chain)
+	require.NotNil(t, got)
+	assert.Nil(t, got.Events)
+	require.Len(t, got.DiagnosticEvents, 2)
+	assert.Equal(t, []string{"mint"}, got.DiagnosticEvents[0].Topics)
+	assert.Equal(t, "diagnostic", got.DiagnosticEvents[1].EventType)
+
+	// The simulated response is left untouched
+	assert.Equal(t, []string{"transfer"}, sim.DiagnosticEvents[0].Topics)
+}
+
+func TestPrintChainEventComparison(t *testing.T) {
+	sim := &simulator.SimulationResponse{DiagnosticEvents: []simulator.DiagnosticEvent{
+		{EventType: "contract", Topics: []string{"transfer"}, Data: "10"},
+		{EventType: "diagnostic", Topics: []string{"fn_call"}},
+	}}
+
+	var out bytes.Buffer
+	printChainEventComparison(&out, sim, []simulator.DiagnosticEvent{{EventType: "contract", Topics: []string{"transfer"}, Data: "10"}})
+	assert.Contains(t, out.String(), "On-chain events match the re-simulation (1 events)")
+
+	out.Reset()
+	printChainEventComparison(&out, sim, []simulator.DiagnosticEvent{{EventType: "contract", Topics: []string{"transfer"}, Data: "20"}})
+	assert.Contains(t, out.String(), "1 of 1 events differ between the chain and the re-simulation")
+}
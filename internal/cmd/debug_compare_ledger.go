@@ -0,0 +1,226 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/spf13/cobra"
+)
+
+var compareLedgerFlag string
+
+// ledgerPoint is one of the two ledgers of a --compare-ledger run: A is the
+// first sequence given, B the second.
+type ledgerPoint struct {
+	Label  string `json:"label"`
+	Ledger uint32 `json:"ledger"`
+	// Timestamp is the ledger's close time, simulated as the ledger time.
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// Newer lists the footprint keys whose entry changed after Ledger; their
+	// current value was simulated in place of the one at Ledger.
+	Newer  []string                      `json:"newer_entries,omitempty"`
+	Result *simulator.SimulationResponse `json:"result"`
+}
+
+// compareLedgerOutput is the result of a --compare-ledger run, and its JSON
+// form.
+type compareLedgerOutput struct {
+	Network     string              `json:"network"`
+	TxHash      string              `json:"tx_hash"`
+	A           *ledgerPoint        `json:"a"`
+	B           *ledgerPoint        `json:"b"`
+	CompareMode compare.Mode        `json:"compare_mode"`
+	Diff        *compare.DiffResult `json:"diff"`
+}
+
+// parseCompareLedger parses --compare-ledger's <seqA>:<seqB>.
+func parseCompareLedger(value string) (uint32, uint32, error) {
+	first, second, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected <seqA>:<seqB>, got %q", value)
+	}
+	var seqs [2]uint32
+	for i, s := range []string{first, second} {
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, 32)
+		if err != nil || n == 0 {
+			return 0, 0, fmt.Errorf("invalid ledger sequence %q", s)
+		}
+		seqs[i] = uint32(n)
+	}
+	if seqs[0] == seqs[1] {
+		return 0, 0, fmt.Errorf("the two ledgers are the same (%d)", seqs[0])
+	}
+	return seqs[0], seqs[1], nil
+}
+
+// validateCompareLedger checks --compare-ledger, which diffs one transaction
+// against the state of two ledgers on the same network rather than across
+// networks or against another transaction.
+func validateCompareLedger(args []string) error {
+	if compareLedgerFlag == "" {
+		return nil
+	}
+	if summaryFlag || demoMode || wasmPath != "" || replayBundleFlag != "" || saveBundleFlag != "" ||
+		snapshotFlag != "" || entriesFileFlag != "" || len(compareNetworksFlag) > 0 || compareTxFlag != "" || watchFlag ||
+		sourceAccountFlag != "" || len(checkRuleFiles) > 0 || reportFileFlag != "" || includeRawFlag {
+		return errors.WrapValidationError("--compare-ledger cannot be combined with --summary, --demo, --wasm, --replay, --save, --snapshot, --entries-file, --compare-network, --compare-tx, --watch, --source-account, --check, --report or --include-raw")
+	}
+	if outputFormatFlag == outputFormatMarkdown || outputFormatFlag == outputFormatLine {
+		return errors.WrapValidationError("--compare-ledger supports --output text or json")
+	}
+	if len(args) != 1 {
+		return errors.WrapValidationError("--compare-ledger replays exactly one transaction hash argument")
+	}
+	if _, _, err := parseCompareLedger(compareLedgerFlag); err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("invalid --compare-ledger: %v", err))
+	}
+	return nil
+}
+
+// runDebugCompareLedger replays txHash against the ledger state of both
+// --compare-ledger sequences on --network and diffs the outcomes, isolating
+// the effect of on-chain state changes from network differences.
+func runDebugCompareLedger(cmd *cobra.Command, txHash string) error {
+	ctx := cmd.Context()
+	out := progressWriter(cmd)
+	seqA, seqB, _ := parseCompareLedger(compareLedgerFlag)
+
+	opts, _ := primaryClientOptions(resolveRPCToken(), rpc.NewEntryMemo())
+	client, err := rpc.NewClient(opts...)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to create client: %v", err))
+	}
+	runner, err := simulator.NewRunnerWithMockTime("", tracingEnabled, mockTimeFlag)
+	if err != nil {
+		return errors.WrapSimulatorNotFound(err.Error())
+	}
+
+	resp, err := client.GetTransaction(ctx, txHash)
+	if err != nil {
+		// These already explain what to try next
+		if errors.Is(err, errors.ErrTransactionNotFound) || errors.Is(err, errors.ErrRateLimitExceeded) {
+			return err
+		}
+		return errors.WrapRPCConnectionFailed(err)
+	}
+	keys, err := transactionLedgerKeys(ctx, out, client, resp)
+	if err != nil {
+		return errors.WrapUnmarshalFailed(err, "result meta of "+txHash)
+	}
+	if err := checkKeyLimit(len(keys)); err != nil {
+		return err
+	}
+	if err := confirmMainnetFetch(cmd, networkFlag, len(keys)); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Comparing %s on %s at two ledgers:\n", txHash, networkFlag)
+	fmt.Fprintf(out, "  A  ledger %d\n", seqA)
+	fmt.Fprintf(out, "  B  ledger %d\n", seqB)
+
+	points := []*ledgerPoint{{Label: "A", Ledger: seqA}, {Label: "B", Ledger: seqB}}
+	for _, p := range points {
+		fmt.Fprintf(out, "[%s] Fetching ledger %d state and simulating\n", p.Label, p.Ledger)
+		if err := p.simulate(ctx, out, client, runner, resp, keys); err != nil {
+			return err
+		}
+	}
+
+	result := &compareLedgerOutput{
+		Network:     networkFlag,
+		TxHash:      txHash,
+		A:           points[0],
+		B:           points[1],
+		CompareMode: compare.Mode(compareModeFlag),
+		Diff:        compare.DiffWithMode(points[0].Result, points[1].Result, compare.Mode(compareModeFlag)),
+	}
+	if outputFormatFlag == outputFormatJSON {
+		if err := report.WriteJSON(cmd.OutOrStdout(), result); err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+	} else {
+		printCompareLedger(cmd.OutOrStdout(), result)
+	}
+
+	if failOnMismatchFlag && result.Diff.HasDivergence {
+		return errors.WrapResultsMismatch("the results at the two ledgers differ")
+	}
+	return nil
+}
+
+// simulate replays resp against the footprint's entries pinned to the
+// point's ledger, at that ledger's close time and protocol.
+func (p *ledgerPoint) simulate(ctx context.Context, out io.Writer, client *rpc.Client, runner simulator.RunnerInterface, resp *rpc.TransactionResponse, keys []string) error {
+	protocol := &protocolVersionFlag
+	if protocolVersionFlag == 0 {
+		protocol = nil
+	}
+	header, err := client.GetLedgerHeader(ctx, p.Ledger)
+	if err != nil {
+		logger.Logger.Warn("Could not fetch the ledger header; using the simulator's default time and protocol", "ledger", p.Ledger, "error", err)
+	} else {
+		p.Timestamp = header.CloseTime.Unix()
+		if protocolVersionFlag == 0 {
+			var note string
+			if protocol, note = protocolForLedger(p.Ledger, header.ProtocolVersion); note != "" {
+				fmt.Fprintf(out, "[%s] %s\n", p.Label, note)
+			}
+		}
+	}
+
+	state, err := client.GetLedgerEntriesAt(ctx, keys, p.Ledger)
+	if err != nil {
+		return fmt.Errorf("ledger %d (%s): %w", p.Ledger, p.Label, err)
+	}
+	p.Newer = state.Newer
+
+	timestamp := p.Timestamp
+	if TimestampFlag > 0 {
+		timestamp = TimestampFlag
+	}
+	req := &simulator.SimulationRequest{
+		EnvelopeXdr:     resp.EnvelopeXdr,
+		ResultMetaXdr:   resp.ResultMetaXdr,
+		LedgerEntries:   state.Entries,
+		Timestamp:       timestamp,
+		LedgerSequence:  p.Ledger,
+		ProtocolVersion: protocol,
+	}
+	applySimulationFeeMocks(req)
+	if p.Result, err = simulator.RunWithContext(ctx, runner, req); err != nil {
+		return fmt.Errorf("ledger %d (%s): %w", p.Ledger, p.Label, err)
+	}
+	return nil
+}
+
+// printCompareLedger renders a --compare-ledger run, labelling each side by
+// its ledger and warning where the state at a ledger could only be
+// approximated.
+func printCompareLedger(out io.Writer, r *compareLedgerOutput) {
+	fmt.Fprintf(out, "\nTransaction %s\nA = ledger %d\nB = ledger %d\n", r.TxHash, r.A.Ledger, r.B.Ledger)
+	diffResults(out, r.A.Result, r.B.Result, fmt.Sprintf("ledger %d", r.A.Ledger), fmt.Sprintf("ledger %d", r.B.Ledger), nil, nil)
+
+	for _, p := range []*ledgerPoint{r.A, r.B} {
+		if len(p.Newer) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "\n%s %d footprint entries changed after ledger %d; RPC only serves current state, so their current value was used for %s:\n",
+			visualizer.Warning(), len(p.Newer), p.Ledger, p.Label)
+		for _, k := range p.Newer {
+			fmt.Fprintf(out, "  %s\n", k)
+		}
+	}
+}
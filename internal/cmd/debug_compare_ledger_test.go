@@ -0,0 +1,66 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompareLedger(t *testing.T) {
+	a, b, err := parseCompareLedger("1000:2000")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1000), a)
+	assert.Equal(t, uint32(2000), b)
+
+	for _, bad := range []string{"1000", "1000:", ":2000", "0:2000", "a:b", "1000:1000", "-1:5"} {
+		_, _, err := parseCompareLedger(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestValidateCompareLedger(t *testing.T) {
+	prevLedger, prevTx, prevFormat := compareLedgerFlag, compareTxFlag, outputFormatFlag
+	t.Cleanup(func() {
+		compareLedgerFlag, compareTxFlag, outputFormatFlag = prevLedger, prevTx, prevFormat
+	})
+	hash := strings.Repeat("a", 64)
+
+	compareLedgerFlag, compareTxFlag, outputFormatFlag = "", "", outputFormatText
+	assert.NoError(t, validateCompareLedger([]string{hash}))
+
+	compareLedgerFlag = "100:200"
+	assert.NoError(t, validateCompareLedger([]string{hash}))
+	assert.Error(t, validateCompareLedger(nil))
+
+	compareLedgerFlag = "100"
+	assert.Error(t, validateCompareLedger([]string{hash}))
+
+	compareLedgerFlag, compareTxFlag = "100:200", strings.Repeat("b", 64)
+	assert.Error(t, validateCompareLedger([]string{hash}))
+
+	compareTxFlag, outputFormatFlag = "", outputFormatMarkdown
+	assert.Error(t, validateCompareLedger([]string{hash}))
+}
+
+func TestPrintCompareLedger(t *testing.T) {
+	a := &ledgerPoint{Label: "A", Ledger: 100, Result: &simulator.SimulationResponse{Status: "success"}}
+	b := &ledgerPoint{Label: "B", Ledger: 200, Newer: []string{"changedKey"},
+		Result: &simulator.SimulationResponse{Status: "error", Error: "trapped"}}
+	r := &compareLedgerOutput{TxHash: "tx", A: a, B: b, Diff: compare.DiffWithMode(a.Result, b.Result, compare.ModeStrict)}
+
+	var out bytes.Buffer
+	printCompareLedger(&out, r)
+	text := out.String()
+	assert.Contains(t, text, "A = ledger 100")
+	assert.Contains(t, text, "Status Mismatch: success (ledger 100) vs error (ledger 200)")
+	assert.Contains(t, text, "1 footprint entries changed after ledger 200")
+	assert.Contains(t, text, "  changedKey")
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/spf13/cobra"
+)
+
+var compareTxFlag string
+
+// compareTxSide is one of the two transactions of a --compare-tx run: A is
+// the transaction given as the argument, B the one given to --compare-tx.
+type compareTxSide struct {
+	Label     string                        `json:"label"`
+	TxHash    string                        `json:"tx_hash"`
+	Footprint []string                      `json:"footprint"`
+	Result    *simulator.SimulationResponse `json:"result"`
+}
+
+// footprintDiff lists the ledger keys only one of two transactions touched.
+type footprintDiff struct {
+	OnlyA  []string `json:"only_a"`
+	OnlyB  []string `json:"only_b"`
+	Shared int      `json:"shared"`
+}
+
+// compareTxOutput is the result of a --compare-tx run, and its JSON form.
+type compareTxOutput struct {
+	Network     string              `json:"network"`
+	A           *compareTxSide      `json:"a"`
+	B           *compareTxSide      `json:"b"`
+	CompareMode compare.Mode        `json:"compare_mode"`
+	Diff        *compare.DiffResult `json:"diff"`
+	Footprint   footprintDiff       `json:"footprint_diff"`
+}
+
+// validateCompareTx checks --compare-tx, which diffs the transaction against
+// a different one on the same network rather than the same transaction
+// across networks.
+func validateCompareTx(args []string) error {
+	if compareTxFlag == "" {
+		return nil
+	}
+	if summaryFlag || demoMode || wasmPath != "" || replayBundleFlag != "" || saveBundleFlag != "" ||
+		snapshotFlag != "" || entriesFileFlag != "" || len(compareNetworksFlag) > 0 || watchFlag ||
+		sourceAccountFlag != "" || len(checkRuleFiles) > 0 || reportFileFlag != "" || includeRawFlag {
+		return errors.WrapValidationError("--compare-tx cannot be combined with --summary, --demo, --wasm, --replay, --save, --snapshot, --entries-file, --compare-network, --watch, --source-account, --check, --report or --include-raw")
+	}
+	if outputFormatFlag == outputFormatMarkdown || outputFormatFlag == outputFormatLine {
+		return errors.WrapValidationError("--compare-tx supports --output text or json")
+	}
+	if len(args) != 1 {
+		return errors.WrapValidationError("--compare-tx compares against exactly one transaction hash argument")
+	}
+	if err := rpc.ValidateTransactionHash(compareTxFlag); err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("invalid --compare-tx hash: %v", err))
+	}
+	if compareTxFlag == args[0] {
+		return errors.WrapValidationError("--compare-tx must name a different transaction; use --compare-network to compare one transaction across networks")
+	}
+	return nil
+}
+
+// runDebugCompareTx replays txHash and the --compare-tx transaction on
+// --network and diffs their status, events and footprints.
+func runDebugCompareTx(cmd *cobra.Command, txHash string) error {
+	ctx := cmd.Context()
+	out := progressWriter(cmd)
+
+	opts, _ := primaryClientOptions(resolveRPCToken(), rpc.NewEntryMemo())
+	client, err := rpc.NewClient(opts...)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to create client: %v", err))
+	}
+	if noCacheFlag {
+		client.CacheEnabled = false
+	}
+	runner, err := simulator.NewRunnerWithMockTime("", tracingEnabled, mockTimeFlag)
+	if err != nil {
+		return errors.WrapSimulatorNotFound(err.Error())
+	}
+
+	fmt.Fprintf(out, "Comparing two transactions on %s:\n", networkFlag)
+	fmt.Fprintf(out, "  A  %s\n", txHash)
+	fmt.Fprintf(out, "  B  %s (--compare-tx)\n", compareTxFlag)
+
+	sides := []*compareTxSide{{Label: "A", TxHash: txHash}, {Label: "B", TxHash: compareTxFlag}}
+	protocols := []uint32{protocolVersionFlag, compareProtocolVersionFlag}
+	for i, side := range sides {
+		fmt.Fprintf(out, "[%s] Fetching and simulating %s\n", side.Label, side.TxHash)
+		if err := side.simulate(ctx, out, client, runner, protocols[i]); err != nil {
+			return err
+		}
+	}
+
+	result := newCompareTxOutput(networkFlag, sides[0], sides[1], compare.Mode(compareModeFlag))
+	if outputFormatFlag == outputFormatJSON {
+		if err := report.WriteJSON(cmd.OutOrStdout(), result); err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+	} else {
+		printCompareTx(cmd.OutOrStdout(), result)
+	}
+
+	if failOnMismatchFlag && result.Diff.HasDivergence {
+		return errors.WrapResultsMismatch("the two transactions' results differ")
+	}
+	return nil
+}
+
+// simulate fetches the side's transaction and replays it under the requested
+// protocol, or the one its ledger ran when requested is 0.
+func (s *compareTxSide) simulate(ctx context.Context, out io.Writer, client *rpc.Client, runner simulator.RunnerInterface, requested uint32) error {
+	resp, err := client.GetTransaction(ctx, s.TxHash)
+	if err != nil {
+		// These already explain what to try next
+		if errors.Is(err, errors.ErrTransactionNotFound) || errors.Is(err, errors.ErrRateLimitExceeded) {
+			return err
+		}
+		return errors.WrapRPCConnectionFailed(err)
+	}
+	if s.Footprint, err = transactionLedgerKeys(ctx, out, client, resp); err != nil {
+		return errors.WrapUnmarshalFailed(err, "result meta of "+s.TxHash)
+	}
+	if err := checkKeyLimit(len(s.Footprint)); err != nil {
+		return err
+	}
+	protocol, note := simulationProtocol(ctx, client, resp.Ledger, requested)
+	if note != "" {
+		fmt.Fprintf(out, "[%s] %s\n", s.Label, note)
+	}
+	if s.Result, err = simulateFetched(ctx, client, runner, resp, s.Footprint, protocol); err != nil {
+		return fmt.Errorf("transaction %s (%s): %w", s.Label, s.TxHash, err)
+	}
+	return nil
+}
+
+func newCompareTxOutput(network string, a, b *compareTxSide, mode compare.Mode) *compareTxOutput {
+	return &compareTxOutput{
+		Network:     network,
+		A:           a,
+		B:           b,
+		CompareMode: mode,
+		Diff:        compare.DiffWithMode(a.Result, b.Result, mode),
+		Footprint:   diffFootprints(a.Footprint, b.Footprint),
+	}
+}
+
+// diffFootprints splits two footprints into the keys only each one has,
+// sorted, and counts the keys they share.
+func diffFootprints(a, b []string) footprintDiff {
+	inA := make(map[string]bool, len(a))
+	for _, k := range a {
+		inA[k] = true
+	}
+	d := footprintDiff{OnlyA: []string{}, OnlyB: []string{}}
+	inB := make(map[string]bool, len(b))
+	for _, k := range b {
+		if inB[k] {
+			continue
+		}
+		inB[k] = true
+		if inA[k] {
+			d.Shared++
+		} else {
+			d.OnlyB = append(d.OnlyB, k)
+		}
+	}
+	for k := range inA {
+		if !inB[k] {
+			d.OnlyA = append(d.OnlyA, k)
+		}
+	}
+	sort.Strings(d.OnlyA)
+	sort.Strings(d.OnlyB)
+	return d
+}
+
+// printCompareTx renders a --compare-tx run, labelling the transactions A
+// and B as the run's header introduced them.
+func printCompareTx(out io.Writer, r *compareTxOutput) {
+	fmt.Fprintf(out, "\nA = %s\nB = %s\n", r.A.TxHash, r.B.TxHash)
+	diffResults(out, r.A.Result, r.B.Result, r.A.Label, r.B.Label, nil, nil)
+
+	f := r.Footprint
+	fmt.Fprintf(out, "\nFootprint: %d shared, %d only in A, %d only in B\n", f.Shared, len(f.OnlyA), len(f.OnlyB))
+	for _, k := range f.OnlyA {
+		fmt.Fprintf(out, "  only in A: %s\n", k)
+	}
+	for _, k := range f.OnlyB {
+		fmt.Fprintf(out, "  only in B: %s\n", k)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCompareTx(t *testing.T) {
+	prevTx, prevNetworks, prevFormat := compareTxFlag, compareNetworksFlag, outputFormatFlag
+	t.Cleanup(func() {
+		compareTxFlag, compareNetworksFlag, outputFormatFlag = prevTx, prevNetworks, prevFormat
+	})
+	hashA, hashB := strings.Repeat("a", 64), strings.Repeat("b", 64)
+
+	compareTxFlag, compareNetworksFlag, outputFormatFlag = "", nil, outputFormatText
+	assert.NoError(t, validateCompareTx([]string{hashA}))
+
+	compareTxFlag = hashB
+	assert.NoError(t, validateCompareTx([]string{hashA}))
+	assert.Error(t, validateCompareTx(nil))
+	assert.Error(t, validateCompareTx([]string{hashA, hashB}))
+	assert.Error(t, validateCompareTx([]string{hashB}), "the same transaction twice")
+
+	compareTxFlag = "not-a-hash"
+	assert.Error(t, validateCompareTx([]string{hashA}))
+
+	compareTxFlag, compareNetworksFlag = hashB, []string{"testnet"}
+	assert.Error(t, validateCompareTx([]string{hashA}))
+
+	compareNetworksFlag, outputFormatFlag = nil, outputFormatMarkdown
+	assert.Error(t, validateCompareTx([]string{hashA}))
+}
+
+func TestDiffFootprints(t *testing.T) {
+	d := diffFootprints([]string{"k3", "k1", "k2"}, []string{"k2", "k4", "k1", "k4"})
+	assert.Equal(t, []string{"k3"}, d.OnlyA)
+	assert.Equal(t, []string{"k4"}, d.OnlyB)
+	assert.Equal(t, 2, d.Shared)
+
+	same := diffFootprints([]string{"k1"}, []string{"k1"})
+	assert.Empty(t, same.OnlyA)
+	assert.Empty(t, same.OnlyB)
+}
+
+func TestPrintCompareTx_LabelsHashes(t *testing.T) {
+	hashA, hashB := strings.Repeat("a", 64), strings.Repeat("b", 64)
+	a := &compareTxSide{Label: "A", TxHash: hashA, Footprint: []string{"shared", "onlyA"},
+		Result: &simulator.SimulationResponse{Status: "success"}}
+	b := &compareTxSide{Label: "B", TxHash: hashB, Footprint: []string{"shared"},
+		Result: &simulator.SimulationResponse{Status: "error", Error: "trapped"}}
+
+	result := newCompareTxOutput("testnet", a, b, compare.ModeStrict)
+	assert.True(t, result.Diff.HasDivergence)
+
+	var out bytes.Buffer
+	printCompareTx(&out, result)
+	text := out.String()
+	assert.Contains(t, text, "A = "+hashA)
+	assert.Contains(t, text, "B = "+hashB)
+	assert.Contains(t, text, "Status Mismatch: success (A) vs error (B)")
+	assert.Contains(t, text, "Footprint: 1 shared, 1 only in A, 0 only in B")
+	assert.Contains(t, text, "only in A: onlyA")
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+var (
+	yesFlag         bool
+	confirmKeysFlag int
+)
+
+// defaultConfirmKeys is the mainnet footprint size above which a run asks
+// before fetching its ledger entries.
+const defaultConfirmKeys = 200
+
+// validateConfirmKeys checks --confirm-keys.
+func validateConfirmKeys() error {
+	if confirmKeysFlag < 0 {
+		return errors.WrapValidationError("--confirm-keys must not be negative")
+	}
+	return nil
+}
+
+// confirmMainnetFetch asks before fetching the entries of a footprint of
+// count keys from mainnet when it is larger than --confirm-keys, so a
+// pathological transaction does not run up the bill of a metered RPC plan
+// by accident. --yes, a --confirm-keys of 0 and --offline skip the
+// question. Without a terminal to ask on, the run stops unless --yes is
+// given.
+func confirmMainnetFetch(cmd *cobra.Command, network string, count int) error {
+	if network != string(rpc.Mainnet) || yesFlag || OfflineFlag || confirmKeysFlag == 0 || count <= confirmKeysFlag {
+		return nil
+	}
+	return confirmFetch(cmd.InOrStdin(), cmd.ErrOrStderr(), stdinIsTerminal(cmd), network, count)
+}
+
+func confirmFetch(in io.Reader, out io.Writer, interactive bool, network string, count int) error {
+	requests := (count + rpc.MaxLedgerKeysPerRequest - 1) / rpc.MaxLedgerKeysPerRequest
+	if !interactive {
+		return errors.WrapValidationError(fmt.Sprintf(
+			"the transaction touches %d ledger entries, more than --confirm-keys %d; fetching them from %s takes %d RPC requests. Pass --yes to confirm in a non-interactive run",
+			count, confirmKeysFlag, network, requests))
+	}
+
+	fmt.Fprintf(out, "About to fetch %d ledger entries from %s in %d RPC requests (more than --confirm-keys %d). Continue? [y/N]: ",
+		count, network, requests, confirmKeysFlag)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return errors.WrapValidationError(fmt.Sprintf("fetching %d ledger entries from %s was not confirmed; pass --yes to skip the question", count, network))
+}
+
+// stdinIsTerminal reports whether the command's input is a terminal a
+// question can be asked on.
+func stdinIsTerminal(cmd *cobra.Command) bool {
+	f, ok := cmd.InOrStdin().(*os.File)
+	return ok && isatty.IsTerminal(f.Fd())
+}
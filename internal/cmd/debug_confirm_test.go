@@ -0,0 +1,55 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmFetch(t *testing.T) {
+	prev := confirmKeysFlag
+	t.Cleanup(func() { confirmKeysFlag = prev })
+	confirmKeysFlag = 200
+
+	var out bytes.Buffer
+	require.NoError(t, confirmFetch(strings.NewReader("y\n"), &out, true, "mainnet", 450))
+	assert.Contains(t, out.String(), "About to fetch 450 ledger entries from mainnet in 3 RPC requests")
+
+	err := confirmFetch(strings.NewReader("\n"), &out, true, "mainnet", 450)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrValidationFailed))
+	assert.Contains(t, err.Error(), "--yes")
+
+	// Nobody to ask
+	err = confirmFetch(strings.NewReader("y\n"), &out, false, "mainnet", 450)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Pass --yes")
+}
+
+func TestConfirmMainnetFetch(t *testing.T) {
+	prevKeys, prevYes := confirmKeysFlag, yesFlag
+	t.Cleanup(func() { confirmKeysFlag, yesFlag = prevKeys, prevYes })
+	confirmKeysFlag, yesFlag = 200, false
+
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader(""))
+	cmd.SetErr(&bytes.Buffer{})
+
+	assert.NoError(t, confirmMainnetFetch(cmd, "mainnet", 200), "at the threshold")
+	assert.NoError(t, confirmMainnetFetch(cmd, "testnet", 5000), "only mainnet asks")
+	assert.Error(t, confirmMainnetFetch(cmd, "mainnet", 201), "no terminal, no --yes")
+
+	yesFlag = true
+	assert.NoError(t, confirmMainnetFetch(cmd, "mainnet", 5000))
+
+	yesFlag, confirmKeysFlag = false, 0
+	assert.NoError(t, confirmMainnetFetch(cmd, "mainnet", 5000), "0 never asks")
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+// networkWasms maps each network of a compare run to the code its contracts
+// run, by contract address.
+type networkWasms map[string]map[string]decoder.ContractWasm
+
+// diff lists the contracts whose code differs between two of the networks.
+func (w networkWasms) diff(net1, net2 string) []decoder.WasmDiff {
+	if w == nil {
+		return nil
+	}
+	return decoder.DiffContractWasms(w[net1], w[net2])
+}
+
+// fetchContractWasms looks up the code every contract instance in keys runs
+// on client's network, hashing the WASM it fetches to confirm it. A
+// contract the network does not have is recorded without a hash; one that
+// could not be looked up is left out.
+func fetchContractWasms(ctx context.Context, client *rpc.Client, keys []string) map[string]decoder.ContractWasm {
+	wasms := make(map[string]decoder.ContractWasm)
+	for key, contract := range decoder.ContractInstanceKeys(keys) {
+		entries, err := client.GetLedgerEntries(ctx, []string{key})
+		if err != nil {
+			// A missing entry fails verification: the contract is not deployed
+			if errors.Is(err, errors.ErrValidationFailed) {
+				wasms[contract] = decoder.ContractWasm{Contract: contract}
+			} else {
+				logger.Logger.WarnContext(ctx, "Could not look up the contract's code", "contract", contract, "error", err)
+			}
+			continue
+		}
+		w, codeKey, err := decoder.InstanceWasm(entries[key])
+		if err != nil {
+			logger.Logger.WarnContext(ctx, "Could not read the contract instance", "contract", contract, "error", err)
+			continue
+		}
+		if codeKey != "" {
+			if code, err := client.GetLedgerEntries(ctx, []string{codeKey}); err != nil {
+				logger.Logger.WarnContext(ctx, "Could not fetch the contract's WASM", "contract", contract, "hash", w.Hash, "error", err)
+			} else if w.Size, err = decoder.WasmSize(code[codeKey], w.Hash); err != nil {
+				logger.Logger.WarnContext(ctx, "Fetched WASM does not match its hash", "contract", contract, "error", err)
+			}
+		}
+		wasms[contract] = w
+	}
+	return wasms
+}
+
+// printWasmDiffs flags contracts that run different code on the two
+// networks, which on its own often explains a divergence.
+func printWasmDiffs(out io.Writer, diffs []decoder.WasmDiff, net1, net2 string) {
+	for _, d := range diffs {
+		fmt.Fprintf(out, "%s Contract WASM differs for %s:\n", visualizer.Warning(), d.Contract)
+		fmt.Fprintf(out, "    %s: %s\n", net1, d.A)
+		fmt.Fprintf(out, "    %s: %s\n", net2, d.B)
+	}
+}
+
+// printContractInstances decodes the instance entry, among entries, of every
+// contract instance key in keys and shows each contract's executable and
+// instance storage, where contracts keep their admin and configuration.
+func printContractInstances(out io.Writer, keys []string, entries map[string]string) []decoder.ContractInstance {
+	var instances []decoder.ContractInstance
+	for key := range decoder.ContractInstanceKeys(keys) {
+		entry, ok := entries[key]
+		if !ok {
+			continue
+		}
+		inst, err := decoder.DecodeContractInstance(entry)
+		if err != nil {
+			fmt.Fprintf(out, "%s Failed to decode contract instance: %v\n", visualizer.Warning(), err)
+			continue
+		}
+		instances = append(instances, *inst)
+	}
+	if len(instances) == 0 {
+		return nil
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Contract < instances[j].Contract })
+
+	fmt.Fprintf(out, "\nContract instances (%d):\n", len(instances))
+	for _, inst := range instances {
+		fmt.Fprintf(out, "  %s\n", inst.Contract)
+		fmt.Fprintf(out, "      executable: %s\n", inst.Executable)
+		if len(inst.Storage) == 0 {
+			fmt.Fprintf(out, "      storage: empty\n")
+			continue
+		}
+		for _, e := range inst.Storage {
+			fmt.Fprintf(out, "      %s = %s\n", e.Key, e.Value)
+		}
+	}
+	return instances
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintContractInstances(t *testing.T) {
+	id := xdr.ContractId{9}
+	contract := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &id}
+	admin := xdr.ScSymbol("admin")
+	owner := xdr.ScSymbol("owner")
+	storage := xdr.ScMap{{
+		Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &admin},
+		Val: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &owner},
+	}}
+	hash := xdr.Hash{1}
+	entry := xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.ContractDataEntry{
+			Contract:   contract,
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+			Durability: xdr.ContractDataDurabilityPersistent,
+			Val: xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{
+				Executable: xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableWasm, WasmHash: &hash},
+				Storage:    &storage,
+			}},
+		},
+	}}
+	key, err := entry.LedgerKey()
+	require.NoError(t, err)
+	keyB64, err := xdr.MarshalBase64(key)
+	require.NoError(t, err)
+	entryB64, err := xdr.MarshalBase64(entry)
+	require.NoError(t, err)
+	address, err := contract.String()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	instances := printContractInstances(&out, []string{keyB64}, map[string]string{keyB64: entryB64})
+	require.Len(t, instances, 1)
+	assert.Equal(t, address, instances[0].Contract)
+	assert.Len(t, instances[0].Storage, 1)
+	assert.Contains(t, out.String(), "Contract instances (1):")
+	assert.Contains(t, out.String(), "executable: 01000000")
+
+	// An instance key whose entry was not fetched is skipped
+	out.Reset()
+	assert.Nil(t, printContractInstances(&out, []string{keyB64}, nil))
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// Values of --diff-format, which picks how text comparisons are laid out.
+const (
+	diffFormatCustom  = "custom"
+	diffFormatUnified = "unified"
+)
+
+var diffFormatFlag string
+
+// validateDiffFormat checks --diff-format. The unified layout replaces the
+// text comparison, so it needs one and no structured output.
+func validateDiffFormat() error {
+	switch diffFormatFlag {
+	case diffFormatCustom:
+		return nil
+	case diffFormatUnified:
+		if len(compareNetworksFlag) == 0 && compareTxFlag == "" && compareLedgerFlag == "" {
+			return errors.WrapValidationError("--diff-format unified requires --compare-network, --compare-tx or --compare-ledger")
+		}
+		if structuredOutput() {
+			return errors.WrapValidationError("--diff-format unified applies to text output; JSON and Markdown carry the diff as data")
+		}
+		return nil
+	default:
+		return errors.WrapValidationError(fmt.Sprintf("unsupported diff format %q (expected custom or unified)", diffFormatFlag))
+	}
+}
+
+// writeUnifiedDiff renders the comparison of res1 (net1) and res2 (net2) as
+// a unified diff, with event data abbreviated as in the custom layout.
+func writeUnifiedDiff(out io.Writer, res1, res2 *simulator.SimulationResponse, net1, net2 string) {
+	diff := compare.DiffWithMode(res1, res2, compare.Mode(compareModeFlag))
+	for i := range diff.EventDiffs {
+		d := &diff.EventDiffs[i]
+		if d.Index < len(res1.Events) {
+			d.LocalEvent = simulator.AbbreviateEventData(d.LocalEvent, eventDataLimit())
+		}
+		if d.Index < len(res2.Events) {
+			d.OnChainEvent = simulator.AbbreviateEventData(d.OnChainEvent, eventDataLimit())
+		}
+	}
+	if err := compare.WriteUnified(out, diff, net1, net2); err != nil {
+		fmt.Fprintf(out, "failed to write diff: %v\n", err)
+	}
+}
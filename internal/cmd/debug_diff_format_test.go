@@ -0,0 +1,52 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDiffFormat(t *testing.T) {
+	prevFormat, prevCompare, prevOutput := diffFormatFlag, compareNetworksFlag, outputFormatFlag
+	t.Cleanup(func() {
+		diffFormatFlag, compareNetworksFlag, outputFormatFlag = prevFormat, prevCompare, prevOutput
+	})
+
+	compareNetworksFlag, outputFormatFlag = nil, outputFormatText
+	diffFormatFlag = diffFormatCustom
+	assert.NoError(t, validateDiffFormat())
+
+	diffFormatFlag = diffFormatUnified
+	assert.Error(t, validateDiffFormat(), "unified needs a comparison")
+
+	compareNetworksFlag = []string{"futurenet"}
+	assert.NoError(t, validateDiffFormat())
+
+	outputFormatFlag = outputFormatJSON
+	assert.Error(t, validateDiffFormat())
+
+	diffFormatFlag = "side-by-side"
+	assert.Error(t, validateDiffFormat())
+}
+
+func TestDiffResults_Unified(t *testing.T) {
+	prev := diffFormatFlag
+	t.Cleanup(func() { diffFormatFlag = prev })
+	diffFormatFlag = diffFormatUnified
+
+	var buf bytes.Buffer
+	diffResults(&buf,
+		&simulator.SimulationResponse{Status: "success", Events: []string{"a", "b"}},
+		&simulator.SimulationResponse{Status: "success", Events: []string{"a", "c"}},
+		"testnet", "futurenet", nil, nil)
+
+	out := buf.String()
+	require.Contains(t, out, "--- testnet\n+++ futurenet\n@@ -1,3 +1,3 @@\n status: success\n event: a\n-event: b\n+event: c\n")
+	assert.NotContains(t, out, "MISMATCH")
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/snapshot"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var entriesFileFlag string
+
+// loadEntriesFile reads pre-fetched ledger entries for --entries-file. The
+// file is JSON, either an object mapping base64 LedgerKeys to base64
+// LedgerEntries or a snapshot written by --snapshot ({"ledgerEntries":
+// [[key, entry], ...]}). Keys and entries may also be URL-safe base64 or
+// hex. Every pair must decode, and each entry must belong to the key it is
+// listed under. Keys and entries are returned as standard base64, keys
+// normalised so they match the footprint.
+func loadEntriesFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to read entries file: %v", err))
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to parse entries file %s: expected a JSON object: %v", path, err))
+	}
+
+	pairs := make(map[string]string, len(raw))
+	if tuples, ok := raw["ledgerEntries"]; ok && len(raw) == 1 {
+		var snap snapshot.Snapshot
+		if err := json.Unmarshal(tuples, &snap.LedgerEntries); err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("failed to parse entries file %s: %v", path, err))
+		}
+		for i, t := range snap.LedgerEntries {
+			if len(t) != 2 {
+				return nil, errors.WrapValidationError(fmt.Sprintf("entries file %s: ledgerEntries[%d] must be a [key, entry] pair", path, i))
+			}
+		}
+		pairs = snap.ToMap()
+	} else {
+		for key, value := range raw {
+			var entry string
+			if err := json.Unmarshal(value, &entry); err != nil {
+				return nil, errors.WrapValidationError(fmt.Sprintf("entries file %s: the entry for key %s must be a base64 or hex string", path, key))
+			}
+			pairs[key] = entry
+		}
+	}
+
+	entries := make(map[string]string, len(pairs))
+	for rawKey, rawEntry := range pairs {
+		key, err := normalizeLedgerKey(rawKey)
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("entries file %s: invalid key %q: %v", path, rawKey, err))
+		}
+		var entry xdr.LedgerEntry
+		if err := decoder.DecodeXDRText(rawEntry, decoder.EncodingAuto, &entry, "ledger entry"); err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("entries file %s: entry for key %s: %v", path, key, err))
+		}
+		entryKey, err := entry.LedgerKey()
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("entries file %s: entry for key %s: %v", path, key, err))
+		}
+		if own, err := xdr.MarshalBase64(entryKey); err != nil || own != key {
+			return nil, errors.WrapValidationError(fmt.Sprintf("entries file %s: the entry listed under key %s belongs to a different key", path, key))
+		}
+		if _, dup := entries[key]; dup {
+			return nil, errors.WrapValidationError(fmt.Sprintf("entries file %s: key %s is listed more than once", path, key))
+		}
+		// The simulator takes standard base64, whatever the file used
+		if entries[key], err = xdr.MarshalBase64(entry); err != nil {
+			return nil, errors.WrapMarshalFailed(err)
+		}
+	}
+	return entries, nil
+}
+
+// ledgerSourceEntries looks up the footprint's entries in source, which
+// stands in for the network, and warns about the keys it does not hold.
+func ledgerSourceEntries(ctx context.Context, out io.Writer, warns *debugWarnings, source rpc.LedgerSource, from string, keys []string) (map[string]string, error) {
+	entries, err := source.GetLedgerEntries(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	warnMissingEntries(out, warns, from, keys, entries)
+	return entries, nil
+}
+
+// warnMissingEntries warns about footprint keys the entries file or snapshot
+// named by from does not cover. The simulation still runs, but the host will
+// treat those entries as absent, which usually changes the outcome.
+func warnMissingEntries(out io.Writer, warns *debugWarnings, from string, keys []string, entries map[string]string) {
+	var missing []string
+	for _, k := range keys {
+		if _, ok := entries[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+	sort.Strings(missing)
+	warns.add(out, simulator.Warning{
+		Code:    simulator.WarningMissingEntries,
+		Message: fmt.Sprintf("The %s is missing %d of %d footprint entries; they will be treated as absent", from, len(missing), len(keys)),
+		Keys:    missing,
+	})
+}
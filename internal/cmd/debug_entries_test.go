@@ -0,0 +1,134 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/snapshot"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func accountLedgerEntry(t *testing.T, address string) string {
+	t.Helper()
+	entry := xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 1,
+		Data: xdr.LedgerEntryData{
+			Type:    xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{AccountId: xdr.MustAddress(address), Balance: 100},
+		},
+	}
+	b64, err := xdr.MarshalBase64(entry)
+	require.NoError(t, err)
+	return b64
+}
+
+func writeEntriesFile(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "entries.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return path
+}
+
+func TestLoadEntriesFile(t *testing.T) {
+	const addrA = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+	const addrB = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	keyA, entryA := accountLedgerKey(t, addrA), accountLedgerEntry(t, addrA)
+	keyB, entryB := accountLedgerKey(t, addrB), accountLedgerEntry(t, addrB)
+
+	t.Run("object", func(t *testing.T) {
+		entries, err := loadEntriesFile(writeEntriesFile(t, map[string]string{keyA: entryA, keyB: entryB}))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{keyA: entryA, keyB: entryB}, entries)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		entries, err := loadEntriesFile(writeEntriesFile(t, map[string]interface{}{
+			"ledgerEntries": [][]string{{keyA, entryA}},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{keyA: entryA}, entries)
+	})
+
+	t.Run("hex and URL-safe base64", func(t *testing.T) {
+		rawEntry, err := base64.StdEncoding.DecodeString(entryA)
+		require.NoError(t, err)
+		rawKey, err := base64.StdEncoding.DecodeString(keyA)
+		require.NoError(t, err)
+		entries, err := loadEntriesFile(writeEntriesFile(t, map[string]string{
+			base64.RawURLEncoding.EncodeToString(rawKey): hex.EncodeToString(rawEntry),
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{keyA: entryA}, entries, "returned as standard base64")
+	})
+
+	t.Run("entry under the wrong key", func(t *testing.T) {
+		_, err := loadEntriesFile(writeEntriesFile(t, map[string]string{keyA: entryB}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "belongs to a different key")
+	})
+
+	t.Run("corrupt entry", func(t *testing.T) {
+		_, err := loadEntriesFile(writeEntriesFile(t, map[string]string{keyA: entryA[:len(entryA)-8]}))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := loadEntriesFile(writeEntriesFile(t, map[string]string{"not-a-key": entryA}))
+		assert.Error(t, err)
+	})
+}
+
+func TestWarnMissingEntries(t *testing.T) {
+	keyA := accountLedgerKey(t, "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	keyB := accountLedgerKey(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+
+	var out bytes.Buffer
+	warnMissingEntries(&out, nil, "entries file", []string{keyA, keyB}, map[string]string{keyA: "x"})
+	assert.Contains(t, out.String(), "missing 1 of 2 footprint entries")
+	assert.Contains(t, out.String(), keyB)
+
+	out.Reset()
+	warnMissingEntries(&out, nil, "entries file", []string{keyA}, map[string]string{keyA: "x"})
+	assert.Empty(t, out.String())
+
+	// A collector records the warning instead of printing it
+	warns := &debugWarnings{}
+	warnMissingEntries(&out, warns, "entries file", []string{keyA, keyB}, map[string]string{keyA: "x"})
+	assert.Empty(t, out.String())
+	require.Len(t, warns.list, 1)
+	assert.Equal(t, simulator.WarningMissingEntries, warns.list[0].Code)
+	assert.Equal(t, []string{keyB}, warns.list[0].Keys)
+}
+
+func TestLedgerSourceEntries(t *testing.T) {
+	keyA := accountKey(t, overrideSource)
+	keyB := accountKey(t, originalSource)
+	source, err := snapshot.NewSource(snapshot.FromMap(map[string]string{keyA: "x"}))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	entries, err := ledgerSourceEntries(context.Background(), &out, nil, source, "snapshot", []string{keyA})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{keyA: "x"}, entries)
+	assert.Empty(t, out.String())
+
+	entries, err = ledgerSourceEntries(context.Background(), &out, nil, source, "snapshot", []string{keyA, keyB})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{keyA: "x"}, entries)
+	assert.Contains(t, out.String(), "The snapshot is missing 1 of 2 footprint entries")
+	assert.Contains(t, out.String(), keyB)
+}
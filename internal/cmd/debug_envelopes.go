@@ -0,0 +1,362 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/snapshot"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	envelopesFileFlag   string
+	accumulateStateFlag bool
+)
+
+// placeholderResultMeta stands in for the result meta of an envelope that
+// has none: the simulator requires a non-empty result_meta_xdr, as in
+// dry-run.
+const placeholderResultMeta = "AAAAAQ=="
+
+// envelopeFileEntry is one transaction of an --envelopes-file: a base64
+// TransactionEnvelope and, when it was executed somewhere, the base64
+// TransactionResultMeta of that execution.
+type envelopeFileEntry struct {
+	EnvelopeXdr   string `json:"envelope_xdr"`
+	ResultMetaXdr string `json:"result_meta_xdr,omitempty"`
+}
+
+// UnmarshalJSON accepts either a bare envelope string or an object with
+// envelope_xdr and result_meta_xdr.
+func (e *envelopeFileEntry) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '"' {
+		return json.Unmarshal(trimmed, &e.EnvelopeXdr)
+	}
+	type plain envelopeFileEntry
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+// loadEnvelopesFile reads the JSON array of an --envelopes-file. Every
+// envelope must decode; they may be base64, URL-safe base64 or hex and are
+// returned as standard base64.
+func loadEnvelopesFile(path string) ([]envelopeFileEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to read envelopes file: %v", err))
+	}
+	var entries []envelopeFileEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to parse envelopes file %s: expected a JSON array of envelopes: %v", path, err))
+	}
+	if len(entries) == 0 {
+		return nil, errors.WrapValidationError(fmt.Sprintf("envelopes file %s holds no envelopes", path))
+	}
+	for i := range entries {
+		e := &entries[i]
+		var env xdr.TransactionEnvelope
+		if err := decoder.DecodeXDRText(strings.TrimSpace(e.EnvelopeXdr), decoder.EncodingAuto, &env, "transaction envelope"); err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("envelopes file %s: envelope %d: %v", path, i+1, err))
+		}
+		if e.EnvelopeXdr, err = xdr.MarshalBase64(env); err != nil {
+			return nil, errors.WrapMarshalFailed(err)
+		}
+		e.ResultMetaXdr = strings.TrimSpace(e.ResultMetaXdr)
+		if e.ResultMetaXdr != "" {
+			var meta xdr.TransactionResultMeta
+			if err := decoder.UnmarshalBase64RoundTrip(e.ResultMetaXdr, &meta, "transaction result meta"); err != nil {
+				return nil, errors.WrapValidationError(fmt.Sprintf("envelopes file %s: result meta %d: %v", path, i+1, err))
+			}
+		}
+	}
+	return entries, nil
+}
+
+// validateEnvelopesFile checks the flags of an --envelopes-file run, which
+// simulates local envelopes in order instead of fetching a transaction.
+func validateEnvelopesFile(args []string) error {
+	if envelopesFileFlag == "" {
+		if accumulateStateFlag {
+			return errors.WrapValidationError("--accumulate-state requires --envelopes-file")
+		}
+		return nil
+	}
+	if len(args) > 0 {
+		return errors.WrapValidationError("--envelopes-file replaces the transaction hash argument")
+	}
+	if summaryFlag || demoMode || wasmPath != "" || replayBundleFlag != "" || saveBundleFlag != "" ||
+		len(compareNetworksFlag) > 0 || compareTxFlag != "" || compareLedgerFlag != "" || watchFlag ||
+		keysOnlyFlag || sourceAccountFlag != "" || rebuildFlag != "" || reportFileFlag != "" {
+		return errors.WrapValidationError("--envelopes-file cannot be combined with --summary, --demo, --wasm, --replay, --save, --compare-network, --compare-tx, --compare-ledger, --watch, --keys-only, --source-account, --rebuild or --report")
+	}
+	if outputFormatFlag == outputFormatMarkdown || outputFormatFlag == outputFormatLine {
+		return errors.WrapValidationError("--envelopes-file supports --output text or json")
+	}
+	if entriesFileFlag != "" && snapshotFlag != "" {
+		return errors.WrapValidationError("--entries-file and --snapshot both supply ledger entries; use one")
+	}
+	return parsePrimaryNetwork()
+}
+
+// envelopeRun is the outcome of one envelope of an --envelopes-file run.
+type envelopeRun struct {
+	Index int `json:"index"`
+	// Keys is the footprint the envelope was simulated with and Carried
+	// the number of its entries taken from earlier transactions' changes.
+	Keys    int                           `json:"keys"`
+	Carried int                           `json:"carried_entries,omitempty"`
+	Result  *simulator.SimulationResponse `json:"result,omitempty"`
+	Error   string                        `json:"error,omitempty"`
+}
+
+// envelopesOutput is the result of an --envelopes-file run, and its JSON
+// form.
+type envelopesOutput struct {
+	Network         string        `json:"network"`
+	AccumulateState bool          `json:"accumulate_state"`
+	Transactions    []envelopeRun `json:"transactions"`
+}
+
+// envelopeState supplies the ledger entries of each envelope: from the
+// --entries-file, the --snapshot or the network, overlaid with the changes
+// of earlier envelopes when state accumulates.
+type envelopeState struct {
+	fixed   map[string]string
+	source  rpc.LedgerSource
+	client  *rpc.Client
+	carried map[string]string
+}
+
+// entries returns the entries of keys, preferring those the envelope's own
+// result meta records, and how many came from carried state.
+func (s *envelopeState) entries(ctx context.Context, out io.Writer, warns *debugWarnings, e envelopeFileEntry, keys []string) (map[string]string, int, error) {
+	var entries map[string]string
+	var err error
+	if e.ResultMetaXdr != "" {
+		entries, err = rpc.ExtractLedgerEntriesFromMeta(e.ResultMetaXdr)
+	}
+	if entries == nil || err != nil {
+		switch {
+		case s.fixed != nil:
+			entries = make(map[string]string, len(keys))
+			for _, k := range keys {
+				if v, ok := s.fixed[k]; ok {
+					entries[k] = v
+				}
+			}
+			warnMissingEntries(out, warns, "entries file", keys, entries)
+		case s.source != nil:
+			if entries, err = ledgerSourceEntries(ctx, out, warns, s.source, "snapshot", keys); err != nil {
+				return nil, 0, errors.WrapValidationError(fmt.Sprintf("failed to look up entries in snapshot: %v", err))
+			}
+		default:
+			if err := checkKeyLimit(len(keys)); err != nil {
+				return nil, 0, err
+			}
+			if entries, err = s.client.GetLedgerEntries(ctx, keys); err != nil {
+				return nil, 0, errors.WrapRPCConnectionFailed(err)
+			}
+		}
+	}
+
+	carried := 0
+	for _, k := range keys {
+		v, ok := s.carried[k]
+		if !ok {
+			continue
+		}
+		carried++
+		if v == "" {
+			delete(entries, k)
+		} else {
+			entries[k] = v
+		}
+	}
+	return entries, carried, nil
+}
+
+// carry records the state an envelope's result meta leaves behind, for the
+// envelopes after it.
+func (s *envelopeState) carry(resultMetaXdr string) error {
+	post, err := decoder.PostStateEntries(resultMetaXdr)
+	if err != nil {
+		return err
+	}
+	for k, v := range post {
+		s.carried[k] = v
+	}
+	return nil
+}
+
+// runDebugEnvelopes simulates every envelope of --envelopes-file in order
+// and reports each result. With --accumulate-state, the ledger changes of
+// each envelope's result meta are applied before the next one runs, so later
+// transactions see earlier ones' writes. The simulator does not report the
+// writes it makes, so an envelope without result meta passes nothing on.
+func runDebugEnvelopes(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	out := progressWriter(cmd)
+	warns := &debugWarnings{}
+
+	envelopes, err := loadEnvelopesFile(envelopesFileFlag)
+	if err != nil {
+		return err
+	}
+	if contractErrors, err = parseErrorSpecs(errorSpecFlags); err != nil {
+		return err
+	}
+
+	state := &envelopeState{carried: make(map[string]string)}
+	switch {
+	case entriesFileFlag != "":
+		if state.fixed, err = loadEntriesFile(entriesFileFlag); err != nil {
+			return err
+		}
+	case snapshotFlag != "":
+		if state.source, err = snapshot.LoadSource(snapshotFlag); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("failed to load snapshot: %v", err))
+		}
+	default:
+		opts, _ := primaryClientOptions(resolveRPCToken(), rpc.NewEntryMemo())
+		if state.client, err = rpc.NewClient(opts...); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("failed to create client: %v", err))
+		}
+		if noCacheFlag {
+			state.client.CacheEnabled = false
+		}
+	}
+
+	runner, err := simulator.NewRunnerWithMockTime("", tracingEnabled, mockTimeFlag)
+	if err != nil {
+		return errors.WrapSimulatorNotFound(err.Error())
+	}
+	var protocol *uint32
+	if protocolVersionFlag != 0 {
+		protocol = &protocolVersionFlag
+	}
+
+	result := &envelopesOutput{Network: networkFlag, AccumulateState: accumulateStateFlag}
+	failed := 0
+	for i, e := range envelopes {
+		fmt.Fprintf(out, "[%d/%d] Simulating envelope %d\n", i+1, len(envelopes), i+1)
+		run := envelopeRun{Index: i + 1}
+		run.Result, run.Keys, run.Carried, err = simulateEnvelope(ctx, out, warns, state, runner, e, protocol)
+		if err != nil {
+			run.Error = err.Error()
+			failed++
+		} else if run.Result.Status == "error" {
+			failed++
+		}
+		result.Transactions = append(result.Transactions, run)
+
+		if !accumulateStateFlag {
+			continue
+		}
+		if e.ResultMetaXdr == "" {
+			if i < len(envelopes)-1 {
+				fmt.Fprintf(out, "%s Envelope %d has no result meta, so its changes are not carried to the next envelopes\n", visualizer.Warning(), i+1)
+			}
+			continue
+		}
+		if err := state.carry(e.ResultMetaXdr); err != nil {
+			return errors.WrapUnmarshalFailed(err, fmt.Sprintf("result meta of envelope %d", i+1))
+		}
+	}
+
+	printWarnings(out, warns.list)
+	if outputFormatFlag == outputFormatJSON {
+		if err := report.WriteJSON(cmd.OutOrStdout(), result); err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+	} else {
+		printEnvelopeRuns(cmd.OutOrStdout(), result)
+	}
+
+	if failed > 0 {
+		return errors.WrapBatchFailed(failed, len(envelopes))
+	}
+	return nil
+}
+
+// simulateEnvelope simulates one envelope against the entries of its
+// footprint. The footprint comes from its result meta or, without one, from
+// the footprint the envelope declares or Soroban RPC discovers.
+func simulateEnvelope(ctx context.Context, out io.Writer, warns *debugWarnings, state *envelopeState, runner simulator.RunnerInterface, e envelopeFileEntry, protocol *uint32) (*simulator.SimulationResponse, int, int, error) {
+	var keys []string
+	var err error
+	if e.ResultMetaXdr != "" {
+		keys, err = extractLedgerKeys(e.ResultMetaXdr)
+	} else {
+		keys, _, err = discoverLedgerKeys(ctx, state.client, e.EnvelopeXdr)
+	}
+	if err != nil {
+		return nil, 0, 0, errors.WrapUnmarshalFailed(err, "envelope footprint")
+	}
+	sort.Strings(keys)
+
+	entries, carried, err := state.entries(ctx, out, warns, e, keys)
+	if err != nil {
+		return nil, len(keys), 0, err
+	}
+	contractErrors.learn(entries)
+
+	meta := e.ResultMetaXdr
+	if meta == "" {
+		meta = placeholderResultMeta
+	}
+	req := &simulator.SimulationRequest{
+		EnvelopeXdr:     e.EnvelopeXdr,
+		ResultMetaXdr:   meta,
+		LedgerEntries:   entries,
+		Timestamp:       TimestampFlag,
+		ProtocolVersion: protocol,
+	}
+	applySimulationFeeMocks(req)
+	res, err := simulator.RunWithContext(ctx, runner, req)
+	if err != nil {
+		return nil, len(keys), carried, errors.WrapSimulationFailed(err, "")
+	}
+	return res, len(keys), carried, nil
+}
+
+// printEnvelopeRuns prints each envelope's result in order, then a tally.
+func printEnvelopeRuns(out io.Writer, r *envelopesOutput) {
+	succeeded, failed, errored := 0, 0, 0
+	for _, run := range r.Transactions {
+		label := fmt.Sprintf("envelope %d", run.Index)
+		if run.Error != "" {
+			errored++
+			fmt.Fprintf(out, "\n--- Result for %s ---\n%s %s\n", label, visualizer.Error(), run.Error)
+			continue
+		}
+		if run.Carried > 0 {
+			label += fmt.Sprintf(" (%d of %d entries from earlier envelopes)", run.Carried, run.Keys)
+		}
+		printSimulationResult(out, label, run.Result)
+		if run.Result.Status == "error" {
+			failed++
+		} else {
+			succeeded++
+		}
+	}
+	fmt.Fprintf(out, "\n%d envelopes on %s: %d succeeded, %d failed", len(r.Transactions), r.Network, succeeded, failed)
+	if errored > 0 {
+		fmt.Fprintf(out, ", %d could not be simulated", errored)
+	}
+	fmt.Fprintln(out)
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEnvelopesFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "envelopes.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+// updateMeta is the result meta of a successful transaction that updates
+// an entry to entry.
+func updateMeta(t *testing.T, entry string) string {
+	t.Helper()
+	var updated xdr.LedgerEntry
+	require.NoError(t, xdr.SafeUnmarshalBase64(entry, &updated))
+	meta := xdr.TransactionResultMeta{TxApplyProcessing: xdr.TransactionMeta{
+		V: 3,
+		V3: &xdr.TransactionMetaV3{Operations: []xdr.OperationMeta{{Changes: xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &updated},
+		}}}},
+	}, Result: xdr.TransactionResultPair{Result: xdr.TransactionResult{Result: xdr.TransactionResultResult{
+		Code:    xdr.TransactionResultCodeTxSuccess,
+		Results: &[]xdr.OperationResult{},
+	}}}}
+	encoded, err := xdr.MarshalBase64(meta)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestLoadEnvelopesFile(t *testing.T) {
+	env := sourceTestEnvelope(t)
+	raw, err := base64.StdEncoding.DecodeString(env)
+	require.NoError(t, err)
+	meta := updateMeta(t, accountLedgerEntry(t, overrideSource))
+
+	path := writeEnvelopesFile(t, `["`+env+`", {"envelope_xdr": "`+base64.URLEncoding.EncodeToString(raw)+`", "result_meta_xdr": "`+meta+`"}]`)
+	entries, err := loadEnvelopesFile(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, env, entries[0].EnvelopeXdr)
+	assert.Empty(t, entries[0].ResultMetaXdr)
+	assert.Equal(t, env, entries[1].EnvelopeXdr, "envelopes are re-encoded as standard base64")
+	assert.Equal(t, meta, entries[1].ResultMetaXdr)
+
+	for _, content := range []string{`{}`, `[]`, `["not-xdr"]`, `[{"envelope_xdr": "` + env + `", "result_meta_xdr": "bad"}]`} {
+		_, err := loadEnvelopesFile(writeEnvelopesFile(t, content))
+		assert.Error(t, err, content)
+	}
+}
+
+func TestValidateEnvelopesFile(t *testing.T) {
+	t.Cleanup(func() { envelopesFileFlag, accumulateStateFlag, summaryFlag = "", false, false })
+
+	accumulateStateFlag = true
+	assert.Error(t, validateEnvelopesFile(nil), "--accumulate-state alone")
+
+	envelopesFileFlag = "txs.json"
+	assert.Error(t, validateEnvelopesFile([]string{"abc"}), "a hash argument")
+
+	summaryFlag = true
+	assert.Error(t, validateEnvelopesFile(nil))
+}
+
+func TestEnvelopeStateCarriesChanges(t *testing.T) {
+	key := accountKey(t, overrideSource)
+	original := accountEntryWithSeq(t, overrideSource, 1)
+	updated := accountEntryWithSeq(t, overrideSource, 2)
+
+	state := &envelopeState{fixed: map[string]string{key: original}, carried: make(map[string]string)}
+	var out bytes.Buffer
+	e := envelopeFileEntry{EnvelopeXdr: sourceTestEnvelope(t)}
+
+	entries, carried, err := state.entries(context.Background(), &out, nil, e, []string{key})
+	require.NoError(t, err)
+	assert.Equal(t, original, entries[key])
+	assert.Zero(t, carried)
+
+	require.NoError(t, state.carry(updateMeta(t, updated)))
+	entries, carried, err = state.entries(context.Background(), &out, nil, e, []string{key})
+	require.NoError(t, err)
+	assert.Equal(t, updated, entries[key], "later envelopes see earlier changes")
+	assert.Equal(t, 1, carried)
+	assert.Equal(t, original, state.fixed[key], "the entries file is left alone")
+}
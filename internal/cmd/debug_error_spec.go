@@ -0,0 +1,138 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/abi"
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var errorSpecFlags []string
+
+// contractErrors names the error codes of the contracts a debug run
+// touches, by contract address. It is filled from --error-spec and then
+// from the spec embedded in each contract's WASM, when the WASM is among
+// the ledger entries simulated.
+var contractErrors contractErrorSpecs
+
+type contractErrorSpecs map[string]map[uint32]abi.ContractError
+
+// parseErrorSpecs parses --error-spec <contractID>=<json> values. The JSON,
+// given inline or as a file, maps codes to names or to {"name", "doc"}
+// objects:
+//
+//	{"1": "NotInitialized", "5": {"name": "InsufficientBalance", "doc": "..."}}
+func parseErrorSpecs(specs []string) (contractErrorSpecs, error) {
+	parsed := make(contractErrorSpecs)
+	for _, spec := range specs {
+		contract, value, ok := strings.Cut(spec, "=")
+		if !ok || contract == "" || value == "" {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid --error-spec %q: expected <contractID>=<json or file>", spec))
+		}
+		if _, err := strkey.Decode(strkey.VersionByteContract, contract); err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid --error-spec contract %q: expected a C... contract address", contract))
+		}
+		if _, ok := parsed[contract]; ok {
+			return nil, errors.WrapValidationError(fmt.Sprintf("--error-spec names contract %s more than once", contract))
+		}
+
+		data := []byte(value)
+		if !strings.HasPrefix(strings.TrimSpace(value), "{") {
+			var err error
+			if data, err = os.ReadFile(value); err != nil {
+				return nil, errors.WrapValidationError(fmt.Sprintf("--error-spec %s: %v", contract, err))
+			}
+		}
+		codes, err := parseErrorCodes(data)
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("--error-spec %s: %v", contract, err))
+		}
+		parsed[contract] = codes
+	}
+	return parsed, nil
+}
+
+func parseErrorCodes(data []byte) (map[uint32]abi.ContractError, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	codes := make(map[uint32]abi.ContractError, len(raw))
+	for key, value := range raw {
+		code, err := strconv.ParseUint(strings.TrimPrefix(key, "#"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an error code", key)
+		}
+		var e abi.ContractError
+		if err := json.Unmarshal(value, &e.Name); err != nil {
+			if err := json.Unmarshal(value, &e); err != nil {
+				return nil, fmt.Errorf("code %s: expected a name or an object with name and doc", key)
+			}
+		}
+		if e.Name == "" {
+			return nil, fmt.Errorf("code %s has no name", key)
+		}
+		codes[uint32(code)] = e
+	}
+	return codes, nil
+}
+
+// learn reads the error enums of every contract whose instance and WASM are
+// both among entries, skipping contracts whose codes are already known so
+// that --error-spec takes precedence.
+func (s contractErrorSpecs) learn(entries map[string]string) {
+	if s == nil {
+		return
+	}
+	for key, contract := range decoder.ContractInstanceKeys(slices.Collect(maps.Keys(entries))) {
+		if _, ok := s[contract]; ok {
+			continue
+		}
+		_, codeKey, err := decoder.InstanceWasm(entries[key])
+		if err != nil || codeKey == "" {
+			continue
+		}
+		codeEntry, ok := entries[codeKey]
+		if !ok {
+			continue
+		}
+		var entry xdr.LedgerEntry
+		if err := xdr.SafeUnmarshalBase64(codeEntry, &entry); err != nil || entry.Data.ContractCode == nil {
+			continue
+		}
+		codes, err := abi.WasmErrorCodes(entry.Data.ContractCode.Code)
+		if err != nil {
+			logger.Logger.Warn("Could not read the contract's error spec", "contract", contract, "error", err)
+		}
+		s[contract] = codes
+	}
+}
+
+// name sets the name and doc of f's error when it is a contract error code
+// the raising contract's spec defines.
+func (s contractErrorSpecs) name(f *simulator.FailureDiagnostic) {
+	if f == nil {
+		return
+	}
+	code, ok := f.ContractErrorCode()
+	if !ok {
+		return
+	}
+	if e, ok := s[f.ContractID][code]; ok {
+		f.ErrorName, f.ErrorDoc = e.Name, e.Doc
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/abi"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errorSpecTestWasm is a minimal module whose contractspecv0 section
+// defines one error enum case, InsufficientBalance = 5.
+func errorSpecTestWasm(t *testing.T) []byte {
+	t.Helper()
+	payload, err := xdr.ScSpecEntry{
+		Kind: xdr.ScSpecEntryKindScSpecEntryUdtErrorEnumV0,
+		UdtErrorEnumV0: &xdr.ScSpecUdtErrorEnumV0{
+			Name:  "TokenError",
+			Cases: []xdr.ScSpecUdtErrorEnumCaseV0{{Name: "InsufficientBalance", Value: 5}},
+		},
+	}.MarshalBinary()
+	require.NoError(t, err)
+	name := "contractspecv0"
+	section := append([]byte{byte(len(name))}, name...)
+	section = append(section, payload...)
+	require.Less(t, len(section), 0x80, "the section length is written as one LEB128 byte")
+	return append([]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x00, byte(len(section))}, section...)
+}
+
+func TestParseErrorSpecs(t *testing.T) {
+	contract := strkey.MustEncode(strkey.VersionByteContract, make([]byte, 32))
+
+	specs, err := parseErrorSpecs([]string{contract + `={"1":"NotInitialized","#5":{"name":"InsufficientBalance","doc":"Balance too low"}}`})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32]abi.ContractError{
+		1: {Name: "NotInitialized"},
+		5: {Name: "InsufficientBalance", Doc: "Balance too low"},
+	}, specs[contract])
+
+	file := filepath.Join(t.TempDir(), "errors.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"2": "Unauthorized"}`), 0o600))
+	specs, err = parseErrorSpecs([]string{contract + "=" + file})
+	require.NoError(t, err)
+	assert.Equal(t, "Unauthorized", specs[contract][2].Name)
+
+	for _, bad := range []string{
+		contract,
+		"GABC=" + `{"1":"A"}`,
+		contract + `={"one":"A"}`,
+		contract + `={"1":""}`,
+		contract + `={"1":42}`,
+		contract + "=" + filepath.Join(t.TempDir(), "missing.json"),
+	} {
+		_, err := parseErrorSpecs([]string{bad})
+		assert.True(t, errors.Is(err, errors.ErrValidationFailed), bad)
+	}
+
+	_, err = parseErrorSpecs([]string{contract + `={"1":"A"}`, contract + `={"2":"B"}`})
+	assert.Error(t, err, "a contract named twice")
+}
+
+func TestContractErrorSpecs_LearnAndName(t *testing.T) {
+	code := errorSpecTestWasm(t)
+	hash := xdr.Hash(sha256.Sum256(code))
+	id := xdr.ContractId{7}
+	instanceKey, instanceEntry := overrideInstance(t, id, xdr.ContractExecutable{
+		Type:     xdr.ContractExecutableTypeContractExecutableWasm,
+		WasmHash: &hash,
+	})
+	codeKey, err := xdr.MarshalBase64(xdr.LedgerKey{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.LedgerKeyContractCode{Hash: hash},
+	})
+	require.NoError(t, err)
+	codeEntry, err := xdr.MarshalBase64(xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type:         xdr.LedgerEntryTypeContractCode,
+			ContractCode: &xdr.ContractCodeEntry{Hash: hash, Code: code},
+		},
+	})
+	require.NoError(t, err)
+	contract := strkey.MustEncode(strkey.VersionByteContract, id[:])
+
+	specs := contractErrorSpecs{}
+	specs.learn(map[string]string{instanceKey: instanceEntry, codeKey: codeEntry})
+	assert.Equal(t, "InsufficientBalance", specs[contract][5].Name)
+
+	f := &simulator.FailureDiagnostic{ContractID: contract, Error: "Error(Contract, #5)"}
+	specs.name(f)
+	assert.Equal(t, "InsufficientBalance", f.ErrorName)
+
+	unknown := &simulator.FailureDiagnostic{ContractID: contract, Error: "Error(Contract, #6)"}
+	specs.name(unknown)
+	assert.Empty(t, unknown.ErrorName)
+
+	// --error-spec takes precedence over the WASM's spec.
+	specs = contractErrorSpecs{contract: {5: {Name: "FromFlag"}}}
+	specs.learn(map[string]string{instanceKey: instanceEntry, codeKey: codeEntry})
+	assert.Equal(t, "FromFlag", specs[contract][5].Name)
+}
+
+func TestPrintFailureDiagnostic_ErrorName(t *testing.T) {
+	var out bytes.Buffer
+	printFailureDiagnostic(&out, &simulator.FailureDiagnostic{
+		Error:     "Error(Contract, #5)",
+		ErrorName: "InsufficientBalance",
+		ErrorDoc:  "The sender cannot cover the amount.",
+	})
+	assert.Contains(t, out.String(), "Contract failure: InsufficientBalance (Error(Contract, #5))")
+	assert.Contains(t, out.String(), "The sender cannot cover the amount.")
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+var (
+	minSeverityFlag   string
+	severityRulesFlag string
+
+	// eventClassifier holds the --severity-rules once loaded. A nil
+	// classifier applies the built-in patterns only.
+	eventClassifier *simulator.EventClassifier
+)
+
+// validateEventSeverity parses --min-severity and loads --severity-rules.
+func validateEventSeverity() error {
+	if minSeverityFlag != "" {
+		sev, err := simulator.ParseEventSeverity(minSeverityFlag)
+		if err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("--min-severity: %v", err))
+		}
+		minSeverityFlag = string(sev)
+	}
+	eventClassifier = nil
+	if severityRulesFlag == "" {
+		return nil
+	}
+	f, err := os.Open(severityRulesFlag)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to open severity rule file: %v", err))
+	}
+	defer f.Close()
+	rules, err := simulator.ParseSeverityRules(f)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("%s: %v", severityRulesFlag, err))
+	}
+	eventClassifier = &simulator.EventClassifier{Rules: rules}
+	return nil
+}
+
+// classifyDebugReport returns a shallow copy of r with the severity of every
+// structured event set, in the primary and every compared result.
+func classifyDebugReport(r *report.DebugReport) *report.DebugReport {
+	classified := *r
+	classified.Result = eventClassifier.Annotate(r.Result)
+	classified.CompareResult = eventClassifier.Annotate(r.CompareResult)
+	if len(r.Comparisons) > 0 {
+		classified.Comparisons = make([]report.NetworkResult, len(r.Comparisons))
+		for i, c := range r.Comparisons {
+			c.Result = eventClassifier.Annotate(c.Result)
+			classified.Comparisons[i] = c
+		}
+	}
+	return &classified
+}
+
+// severityLabel marks warning and error events in event listings; info
+// events are left unmarked.
+func severityLabel(sev simulator.EventSeverity) string {
+	switch sev {
+	case simulator.SeverityError:
+		return " " + visualizer.Error() + " " + visualizer.Colorize("ERROR", "red")
+	case simulator.SeverityWarning:
+		return " " + visualizer.Warning() + " " + visualizer.Colorize("WARNING", "yellow")
+	}
+	return ""
+}
+
+// errorEvents returns the events classified as errors.
+func errorEvents(events []simulator.DiagnosticEvent) []simulator.DiagnosticEvent {
+	var errs []simulator.DiagnosticEvent
+	for _, ev := range events {
+		if eventClassifier.Classify(ev) == simulator.SeverityError {
+			errs = append(errs, ev)
+		}
+	}
+	return errs
+}
+
+// severityChange is a logical event, the same in both results, classified
+// differently on each side.
+type severityChange struct {
+	Index int
+	Event simulator.DiagnosticEvent
+	A, B  simulator.EventSeverity
+}
+
+// eventSeverityChanges finds the aligned events of a diff that were emitted
+// by the same contract with the same first topic on both sides but whose
+// severity differs, such as a transfer that goes to the zero address on
+// one side only.
+func eventSeverityChanges(diffs []compare.DiagnosticDiff) []severityChange {
+	var changes []severityChange
+	for _, d := range diffs {
+		if d.Local == nil || d.OnChain == nil || d.DivergentPath || !sameFirstTopic(*d.Local, *d.OnChain) {
+			continue
+		}
+		a, b := eventClassifier.Classify(*d.Local), eventClassifier.Classify(*d.OnChain)
+		if a != b {
+			changes = append(changes, severityChange{Index: d.Index, Event: *d.Local, A: a, B: b})
+		}
+	}
+	return changes
+}
+
+func sameFirstTopic(a, b simulator.DiagnosticEvent) bool {
+	if len(a.Topics) == 0 || len(b.Topics) == 0 {
+		return len(a.Topics) == len(b.Topics)
+	}
+	return a.Topics[0] == b.Topics[0]
+}
+
+// printSeverityChanges flags every event whose severity differs between the
+// compared results.
+func printSeverityChanges(out io.Writer, diffs []compare.DiagnosticDiff, net1, net2 string) {
+	for _, c := range eventSeverityChanges(diffs) {
+		name := c.Event.EventType
+		if len(c.Event.Topics) > 0 {
+			name = c.Event.Topics[0]
+		}
+		if c.Event.ContractID != nil {
+			name += " (" + *c.Event.ContractID + ")"
+		}
+		fmt.Fprintf(out, "[DIFF] Event [%d] %s severity: %s (%s) vs %s (%s)\n", c.Index, name, c.A, net1, c.B, net2)
+	}
+}
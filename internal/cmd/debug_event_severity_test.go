@@ -0,0 +1,99 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setSeverityFlags(t *testing.T, min, rules string) {
+	t.Helper()
+	prevMin, prevRules, prevClassifier := minSeverityFlag, severityRulesFlag, eventClassifier
+	t.Cleanup(func() { minSeverityFlag, severityRulesFlag, eventClassifier = prevMin, prevRules, prevClassifier })
+	minSeverityFlag, severityRulesFlag = min, rules
+}
+
+func TestValidateEventSeverity(t *testing.T) {
+	setSeverityFlags(t, "WARNING", "")
+	require.NoError(t, validateEventSeverity())
+	assert.Equal(t, "warning", minSeverityFlag)
+	assert.Nil(t, eventClassifier)
+
+	setSeverityFlags(t, "fatal", "")
+	assert.Error(t, validateEventSeverity())
+
+	path := filepath.Join(t.TempDir(), "severity.rules")
+	require.NoError(t, os.WriteFile(path, []byte("error topic liquidated\n"), 0o600))
+	setSeverityFlags(t, "", path)
+	require.NoError(t, validateEventSeverity())
+	require.NotNil(t, eventClassifier)
+	assert.Len(t, eventClassifier.Rules, 1)
+
+	require.NoError(t, os.WriteFile(path, []byte("error liquidated\n"), 0o600))
+	assert.Error(t, validateEventSeverity())
+
+	setSeverityFlags(t, "", filepath.Join(t.TempDir(), "missing.rules"))
+	assert.Error(t, validateEventSeverity())
+}
+
+func TestPrintSeverityChanges(t *testing.T) {
+	setSeverityFlags(t, "", "")
+	token := "CTOKEN"
+	a := &simulator.SimulationResponse{DiagnosticEvents: []simulator.DiagnosticEvent{
+		{EventType: "contract", ContractID: &token, Topics: []string{"mint", "GABC"}},
+		{EventType: "contract", ContractID: &token, Topics: []string{"transfer", "GABC", "GDEF"}},
+	}}
+	b := &simulator.SimulationResponse{DiagnosticEvents: []simulator.DiagnosticEvent{
+		{EventType: "contract", ContractID: &token, Topics: []string{"mint", "GABC"}},
+		{EventType: "contract", ContractID: &token, Topics: []string{"transfer", "GABC", "GAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAWHF"}},
+	}}
+
+	var out bytes.Buffer
+	printSeverityChanges(&out, compare.Diff(a, b).DiagnosticDiffs, "testnet", "mainnet")
+	assert.Equal(t, "[DIFF] Event [1] transfer (CTOKEN) severity: info (testnet) vs warning (mainnet)\n", out.String())
+
+	// A different event in the same position is not a severity change
+	c := &simulator.SimulationResponse{DiagnosticEvents: []simulator.DiagnosticEvent{
+		{EventType: "contract", ContractID: &token, Topics: []string{"burn", "GABC"}},
+	}}
+	out.Reset()
+	printSeverityChanges(&out, compare.Diff(a, c).DiagnosticDiffs, "testnet", "mainnet")
+	assert.Empty(t, out.String())
+}
+
+func TestPrintEventList_MarksSeverity(t *testing.T) {
+	setSeverityFlags(t, "", "")
+	res := eventClassifier.Annotate(&simulator.SimulationResponse{DiagnosticEvents: []simulator.DiagnosticEvent{
+		{EventType: "diagnostic", Topics: []string{"error", "Error(Contract(3))"}},
+		{EventType: "contract", Topics: []string{"mint"}},
+	}})
+
+	var out bytes.Buffer
+	printEventList(&out, "Events", res.DiagnosticEvents)
+	assert.Contains(t, out.String(), "[1] Type: diagnostic"+severityLabel(simulator.SeverityError))
+	assert.Contains(t, out.String(), "[2] Type: contract\n")
+}
+
+func TestPrintDecodedEvents_ShowsHiddenErrors(t *testing.T) {
+	setSeverityFlags(t, "", "")
+	prev := verbose
+	t.Cleanup(func() { verbose = prev })
+	verbose = false
+
+	var out bytes.Buffer
+	printDecodedEvents(&out, &simulator.SimulationResponse{Status: "success", DiagnosticEvents: []simulator.DiagnosticEvent{
+		{EventType: "diagnostic", Topics: []string{"fn_call"}},
+		{EventType: "diagnostic", Topics: []string{"error", "Error(Contract(3))"}},
+	}})
+	assert.Contains(t, out.String(), "Diagnostic Events: 2 (use --verbose to show)")
+	assert.Contains(t, out.String(), "Error Diagnostics: 1")
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+// explainStep identifies a point of the debug flow narrated by --explain.
+type explainStep string
+
+const (
+	explainFetch    explainStep = "fetch"
+	explainKeys     explainStep = "keys"
+	explainEntries  explainStep = "entries"
+	explainSimulate explainStep = "simulate"
+	explainSuccess  explainStep = "success"
+	explainFailure  explainStep = "failure"
+	explainCompare  explainStep = "compare"
+)
+
+var explanations = map[explainStep]string{
+	explainFetch: `A transaction hash identifies a transaction that was already applied to the
+ledger. Erst fetches its envelope (what was submitted: operations, signatures
+and authorization entries) and its result meta (what the network recorded
+while applying it).`,
+	explainKeys: `All Soroban state (accounts, contract code, contract storage) lives in ledger
+entries, each addressed by a ledger key. The transaction's footprint declares
+which keys it reads and writes, so those keys tell Erst which state the replay
+needs.`,
+	explainEntries: `To replay faithfully, the simulator needs the values of those entries. Erst
+takes them from the result meta when it can, and otherwise asks the RPC server
+for their current values, which may differ from the state the transaction
+originally saw.`,
+	explainSimulate: `The simulator runs the transaction through the same Soroban host the
+validators use, against the ledger entries gathered above, and records events,
+logs and resource usage. Nothing is submitted to the network.`,
+	explainSuccess: `Status "success" means the host executed every operation without error. The
+events show what the contracts emitted, and resource usage shows how close the
+transaction came to its CPU and memory limits.`,
+	explainFailure: `Status "error" means the host aborted the transaction. The error names the
+failing area (for example Auth, Budget, Storage or WasmVm), and the last
+diagnostic events before it usually show which contract call failed and why.`,
+	explainCompare: `The same envelope was replayed against each network's own state. Differences
+in status, events or budget point at state or protocol differences between the
+networks rather than at the transaction itself.`,
+}
+
+// explain prints the narrative for step when --explain is set. It never
+// changes what the debug command does.
+func explain(out io.Writer, step explainStep) {
+	if !explainFlag {
+		return
+	}
+	fmt.Fprintf(out, "\n%s %s\n\n", visualizer.Info(), explanations[step])
+}
+
+// explainOutcome narrates how to read a simulation result.
+func explainOutcome(out io.Writer, res *simulator.SimulationResponse) {
+	if res != nil && res.Status == "success" {
+		explain(out, explainSuccess)
+		return
+	}
+	explain(out, explainFailure)
+}
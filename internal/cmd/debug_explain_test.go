@@ -0,0 +1,46 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain_OnlyWhenFlagSet(t *testing.T) {
+	prev := explainFlag
+	t.Cleanup(func() { explainFlag = prev })
+
+	var buf bytes.Buffer
+	explainFlag = false
+	explain(&buf, explainKeys)
+	assert.Empty(t, buf.String())
+
+	explainFlag = true
+	explain(&buf, explainKeys)
+	assert.Contains(t, buf.String(), "ledger key")
+}
+
+func TestExplainOutcome(t *testing.T) {
+	prev := explainFlag
+	t.Cleanup(func() { explainFlag = prev })
+	explainFlag = true
+
+	var buf bytes.Buffer
+	explainOutcome(&buf, &simulator.SimulationResponse{Status: "error"})
+	assert.Contains(t, buf.String(), `Status "error"`)
+
+	buf.Reset()
+	explainOutcome(&buf, &simulator.SimulationResponse{Status: "success"})
+	assert.Contains(t, buf.String(), `Status "success"`)
+}
+
+func TestExplanations_CoverEveryStep(t *testing.T) {
+	for _, step := range []explainStep{explainFetch, explainKeys, explainEntries, explainSimulate, explainSuccess, explainFailure, explainCompare} {
+		assert.NotEmpty(t, explanations[step], step)
+	}
+}
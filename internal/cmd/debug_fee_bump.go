@@ -0,0 +1,79 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+var followFeeBumpInnerFlag bool
+
+// followFeeBumpInner decodes the outer and inner results of a fee bump for
+// --follow-fee-bump-inner. When the fee bump's response has no result meta,
+// which leaves no ledger keys to replay, the inner transaction is fetched by
+// its hash with fetch and its meta used instead. fetch is nil when replaying
+// a bundle offline. The result is nil when resp is not a fee bump.
+func followFeeBumpInner(ctx context.Context, out io.Writer, passphrase string, fetch *rpc.Client, resp *rpc.TransactionResponse) (*decoder.FeeBumpResult, *rpc.TransactionResponse) {
+	feeBump, err := decoder.DecodeFeeBumpResult(resp.EnvelopeXdr, resp.ResultXdr, passphrase)
+	if err != nil {
+		logger.Logger.Warn("Failed to decode the fee bump", "error", err)
+		return nil, resp
+	}
+	if feeBump == nil {
+		fmt.Fprintf(out, "--follow-fee-bump-inner: the transaction is not a fee bump\n")
+		return nil, resp
+	}
+	if resp.ResultMetaXdr != "" || fetch == nil || feeBump.InnerHash == "" {
+		return feeBump, resp
+	}
+
+	fmt.Fprintf(out, "The fee bump has no result meta; fetching inner transaction %s\n", feeBump.InnerHash)
+	inner, err := fetch.GetTransaction(ctx, feeBump.InnerHash)
+	if err != nil {
+		logger.Logger.Warn("Failed to fetch the inner transaction", "hash", feeBump.InnerHash, "error", err)
+		return feeBump, resp
+	}
+	if inner.ResultMetaXdr == "" {
+		return feeBump, resp
+	}
+	merged := *resp
+	merged.ResultMetaXdr = inner.ResultMetaXdr
+	if merged.Ledger == 0 {
+		merged.Ledger = inner.Ledger
+	}
+	return feeBump, &merged
+}
+
+// printFeeBump reports who paid for a fee bump and what it was charged
+// separately from whether the inner transaction's logic succeeded.
+func printFeeBump(out io.Writer, f *decoder.FeeBumpResult) {
+	if f == nil {
+		return
+	}
+	fmt.Fprintf(out, "\n=== Fee Bump ===\n")
+	fmt.Fprintf(out, "Outer (fee):\n")
+	fmt.Fprintf(out, "  Fee source: %s\n", f.FeeSource)
+	fmt.Fprintf(out, "  Max fee:    %d stroops\n", f.MaxFee)
+	if f.OuterCode != "" {
+		fmt.Fprintf(out, "  Result:     %s (fee charged: %d stroops)\n", f.OuterCode, f.FeeCharged)
+	}
+	fmt.Fprintf(out, "Inner (logic):\n")
+	fmt.Fprintf(out, "  Hash:       %s\n", f.InnerHash)
+	fmt.Fprintf(out, "  Source:     %s\n", f.InnerSource)
+	switch {
+	case !f.InnerRan():
+		fmt.Fprintf(out, "  %s The fee bump was rejected before its inner transaction ran\n", visualizer.Warning())
+	case f.InnerSuccessful:
+		fmt.Fprintf(out, "  Result:     %s %s\n", visualizer.Success(), f.InnerCode)
+	default:
+		fmt.Fprintf(out, "  Result:     %s %s: %s\n", visualizer.Error(), f.InnerCode, f.InnerExplanation)
+	}
+}
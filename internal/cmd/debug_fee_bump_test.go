@@ -0,0 +1,85 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func feeBumpEnvelopeXDR(t *testing.T) string {
+	t.Helper()
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{Tx: xdr.FeeBumpTransaction{
+			FeeSource: xdr.MustMuxedAddress(overrideSource),
+			Fee:       500,
+			InnerTx: xdr.FeeBumpTransactionInnerTx{Type: xdr.EnvelopeTypeEnvelopeTypeTx, V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+				SourceAccount: xdr.MustMuxedAddress(originalSource),
+				Fee:           100,
+				SeqNum:        1,
+				Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+				Operations: []xdr.Operation{{Body: xdr.OperationBody{
+					Type:           xdr.OperationTypeBumpSequence,
+					BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 2},
+				}}},
+			}}},
+		}},
+	}
+	encoded, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestFollowFeeBumpInner(t *testing.T) {
+	var out bytes.Buffer
+	resp := &rpc.TransactionResponse{EnvelopeXdr: feeBumpEnvelopeXDR(t)}
+
+	feeBump, got := followFeeBumpInner(context.Background(), &out, network.TestNetworkPassphrase, nil, resp)
+	require.NotNil(t, feeBump)
+	assert.Same(t, resp, got, "without a client the response is left as is")
+	assert.Equal(t, overrideSource, feeBump.FeeSource)
+	assert.Equal(t, originalSource, feeBump.InnerSource)
+	assert.Len(t, feeBump.InnerHash, 64)
+
+	result, err := xdr.MarshalBase64(xdr.TransactionResult{Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxBadAuth}})
+	require.NoError(t, err)
+	env, err := xdr.MarshalBase64(xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1:   &xdr.TransactionV1Envelope{Tx: xdr.Transaction{SourceAccount: xdr.MustMuxedAddress(originalSource)}},
+	})
+	require.NoError(t, err)
+	out.Reset()
+	feeBump, _ = followFeeBumpInner(context.Background(), &out, network.TestNetworkPassphrase, nil, &rpc.TransactionResponse{EnvelopeXdr: env, ResultXdr: result})
+	assert.Nil(t, feeBump)
+	assert.Contains(t, out.String(), "not a fee bump")
+}
+
+func TestPrintFeeBump(t *testing.T) {
+	var out bytes.Buffer
+	printFeeBump(&out, &decoder.FeeBumpResult{
+		FeeSource: "GFEE", MaxFee: 500, FeeCharged: 300, OuterCode: "tx_fee_bump_inner_failed",
+		InnerHash: "abc", InnerSource: "GINNER", InnerCode: "tx_failed", InnerExplanation: "One or more operations failed",
+	})
+	assert.Contains(t, out.String(), "Outer (fee):")
+	assert.Contains(t, out.String(), "tx_fee_bump_inner_failed (fee charged: 300 stroops)")
+	assert.Contains(t, out.String(), "Inner (logic):")
+	assert.Contains(t, out.String(), "tx_failed: One or more operations failed")
+
+	out.Reset()
+	printFeeBump(&out, &decoder.FeeBumpResult{OuterCode: "tx_insufficient_fee"})
+	assert.Contains(t, out.String(), "rejected before its inner transaction ran")
+
+	out.Reset()
+	printFeeBump(&out, nil)
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,282 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	onlyKeyFlags    []string
+	excludeKeyFlags []string
+	maxKeysFlag     int
+)
+
+// defaultMaxKeys is the footprint size above which --max-keys stops a run
+// unless raised.
+const defaultMaxKeys = 1000
+
+// checkKeyLimit enforces --max-keys before ledger entries are fetched, so a
+// pathological transaction cannot fire hundreds of RPC requests unnoticed. A
+// limit of 0 disables the check.
+func checkKeyLimit(count int) error {
+	if maxKeysFlag > 0 && count > maxKeysFlag {
+		return errors.WrapValidationError(fmt.Sprintf(
+			"the transaction touches %d ledger entries, more than --max-keys %d; fetching them takes about %d RPC requests. Raise --max-keys (0 for no limit) to continue",
+			count, maxKeysFlag, (count+rpc.MaxLedgerKeysPerRequest-1)/rpc.MaxLedgerKeysPerRequest))
+	}
+	return nil
+}
+
+// ledgerKeyFilter restricts the footprint entries fetched and injected into
+// the simulation, for testing whether a failure depends on particular state.
+// A nil filter keeps everything.
+type ledgerKeyFilter struct {
+	only    map[string]bool
+	exclude map[string]bool
+	// order lists every filter key as given, for stable warnings.
+	order []string
+}
+
+// newLedgerKeyFilter builds the filter for --only-key and --exclude-key. Keys
+// are normalised to the encoding used for footprint keys so differently
+// padded input still matches. It returns nil when neither flag is set.
+func newLedgerKeyFilter(only, exclude []string) (*ledgerKeyFilter, error) {
+	if len(only) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+
+	f := &ledgerKeyFilter{only: make(map[string]bool), exclude: make(map[string]bool)}
+	for _, set := range []struct {
+		flag string
+		keys []string
+		into map[string]bool
+	}{
+		{"--only-key", only, f.only},
+		{"--exclude-key", exclude, f.exclude},
+	} {
+		for _, raw := range set.keys {
+			key, err := normalizeLedgerKey(raw)
+			if err != nil {
+				return nil, errors.WrapValidationError(fmt.Sprintf("invalid %s %q: %v", set.flag, raw, err))
+			}
+			if !set.into[key] {
+				set.into[key] = true
+				f.order = append(f.order, key)
+			}
+		}
+	}
+
+	for key := range f.only {
+		if f.exclude[key] {
+			return nil, errors.WrapValidationError(fmt.Sprintf("ledger key %s is passed to both --only-key and --exclude-key", key))
+		}
+	}
+	return f, nil
+}
+
+// normalizeLedgerKey decodes an XDR LedgerKey written as standard or
+// URL-safe base64 or hex, and re-encodes it as standard base64.
+func normalizeLedgerKey(raw string) (string, error) {
+	var key xdr.LedgerKey
+	if err := decoder.DecodeXDRText(raw, decoder.EncodingAuto, &key, "ledger key"); err != nil {
+		return "", fmt.Errorf("expected a base64 or hex XDR LedgerKey: %w", err)
+	}
+	b, err := key.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// keep reports whether the entry for key should be used.
+func (f *ledgerKeyFilter) keep(key string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.only) > 0 && !f.only[key] {
+		return false
+	}
+	return !f.exclude[key]
+}
+
+// filterKeys returns the footprint keys the filter keeps, warning on out
+// about any filter key that is not part of the footprint.
+func (f *ledgerKeyFilter) filterKeys(out io.Writer, keys []string) []string {
+	if f == nil {
+		return keys
+	}
+
+	inFootprint := make(map[string]bool, len(keys))
+	kept := make([]string, 0, len(keys))
+	for _, key := range keys {
+		inFootprint[key] = true
+		if f.keep(key) {
+			kept = append(kept, key)
+		}
+	}
+	for _, key := range f.order {
+		if !inFootprint[key] {
+			fmt.Fprintf(out, "%s Filter key %s is not in the transaction footprint\n", visualizer.Warning(), key)
+		}
+	}
+	fmt.Fprintf(out, "Ledger key filter: keeping %d of %d footprint entries\n", len(kept), len(keys))
+	return kept
+}
+
+// filterEntries drops the ledger entries the filter excludes.
+func (f *ledgerKeyFilter) filterEntries(entries map[string]string) map[string]string {
+	if f == nil {
+		return entries
+	}
+	kept := make(map[string]string, len(entries))
+	for key, entry := range entries {
+		if f.keep(key) {
+			kept[key] = entry
+		}
+	}
+	return kept
+}
+
+// countLedgerKeyTypes groups footprint keys by their LedgerKey type, for
+// example {"ContractData": 3, "Account": 1}. Keys that do not decode are
+// counted as "Invalid".
+func countLedgerKeyTypes(keys []string) map[string]int {
+	counts := make(map[string]int)
+	for _, raw := range keys {
+		var key xdr.LedgerKey
+		if err := xdr.SafeUnmarshalBase64(raw, &key); err != nil {
+			counts["Invalid"]++
+			continue
+		}
+		counts[strings.TrimPrefix(key.Type.String(), "LedgerEntryType")]++
+	}
+	return counts
+}
+
+// printLedgerKeyTypes prints a one-line breakdown of the footprint by key
+// type, most common first, so an unusual footprint stands out before its
+// entries are fetched.
+func printLedgerKeyTypes(out io.Writer, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if counts[types[i]] != counts[types[j]] {
+			return counts[types[i]] > counts[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = fmt.Sprintf("%d %s", counts[t], t)
+	}
+	fmt.Fprintf(out, "Footprint by type: %s\n", strings.Join(parts, ", "))
+}
+
+// transactionLedgerKeys returns the ledger keys to replay tx against, read
+// from its result meta plus any keys the envelope's Soroban footprint
+// declares that the meta does not show: entries the transaction only read
+// leave no change behind, yet the replay still needs them.
+//
+// Some endpoints return no result meta, notably for very recent
+// transactions; the keys then come from the footprint the envelope declares
+// or, failing that, from Soroban RPC's simulateTransaction, and a warning on
+// out says their entries are current state rather than the state the
+// transaction ran against. With no footprint at all it warns that the
+// simulation runs against empty state instead of silently doing so.
+func transactionLedgerKeys(ctx context.Context, out io.Writer, client *rpc.Client, tx *rpc.TransactionResponse) ([]string, error) {
+	if strings.TrimSpace(tx.ResultMetaXdr) != "" {
+		keys, err := extractLedgerKeys(tx.ResultMetaXdr)
+		if err != nil {
+			return nil, err
+		}
+		keys, added, err := withDeclaredFootprint(keys, tx.EnvelopeXdr)
+		if err != nil {
+			logger.Logger.Warn("Could not read the envelope's declared footprint", "error", err)
+		}
+		if added > 0 {
+			fmt.Fprintf(out, "Added %d ledger keys from the declared footprint that the result meta does not show; their entries are fetched from the current ledger\n", added)
+		}
+		return keys, nil
+	}
+
+	keys, source, err := discoverLedgerKeys(ctx, client, tx.EnvelopeXdr)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		fmt.Fprintf(out, "%s The endpoint returned no result meta for this transaction and no footprint could be found; the simulation runs against empty ledger state and its results may be inaccurate\n", visualizer.Warning())
+		return keys, nil
+	}
+	fmt.Fprintf(out, "%s The endpoint returned no result meta for this transaction; using the %d ledger keys %s. Their entries are fetched from the current ledger, so results may differ from the original execution\n",
+		visualizer.Warning(), len(keys), source)
+	return keys, nil
+}
+
+// withDeclaredFootprint appends to keys the read-only and read-write keys
+// the envelope's Soroban footprint declares that keys lacks, and says how
+// many it added. Classic transactions declare none.
+func withDeclaredFootprint(keys []string, envelopeXdr string) ([]string, int, error) {
+	declared, err := decoder.DecodeSorobanResources(envelopeXdr, "")
+	if err != nil || declared == nil {
+		return keys, 0, err
+	}
+	seen := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		seen[k] = struct{}{}
+	}
+	union := slices.Clone(keys)
+	for _, k := range append(slices.Clone(declared.ReadOnly), declared.ReadWrite...) {
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		union = append(union, k)
+	}
+	return union, len(union) - len(keys), nil
+}
+
+// discoverLedgerKeys finds the footprint of a transaction without its result
+// meta, and says where it was found. Keys are base64 XDR, read-only first.
+func discoverLedgerKeys(ctx context.Context, client *rpc.Client, envelopeXdr string) ([]string, string, error) {
+	declared, err := decoder.DecodeSorobanResources(envelopeXdr, "")
+	if err != nil {
+		return nil, "", err
+	}
+	if declared != nil && len(declared.ReadOnly)+len(declared.ReadWrite) > 0 {
+		return append(declared.ReadOnly, declared.ReadWrite...), "its envelope declares", nil
+	}
+	if client == nil {
+		return nil, "", nil
+	}
+
+	fp, err := client.DiscoverFootprint(ctx, envelopeXdr)
+	if err != nil {
+		logger.Logger.Warn("Soroban RPC footprint discovery failed", "error", err)
+		return nil, "", nil
+	}
+	keys := fp.Keys()
+	if len(keys) == 0 {
+		return nil, "", nil
+	}
+	return keys, "Soroban RPC's simulateTransaction discovered", nil
+}
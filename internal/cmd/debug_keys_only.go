@@ -0,0 +1,95 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+var keysOnlyFlag bool
+
+// keysOnlyOutput is the JSON form of --keys-only.
+type keysOnlyOutput struct {
+	TxHash  string   `json:"tx_hash"`
+	Network string   `json:"network"`
+	Keys    []string `json:"keys"`
+}
+
+// validateKeysOnly checks --keys-only, which stops after extracting the
+// footprint, so every flag that fetches entries or simulates is meaningless
+// with it.
+func validateKeysOnly(args []string) error {
+	if !keysOnlyFlag {
+		return nil
+	}
+	if summaryFlag || demoMode || wasmPath != "" || replayBundleFlag != "" || saveBundleFlag != "" ||
+		snapshotFlag != "" || entriesFileFlag != "" || len(compareNetworksFlag) > 0 || compareTxFlag != "" ||
+		compareLedgerFlag != "" || watchFlag || sourceAccountFlag != "" || len(checkRuleFiles) > 0 ||
+		reportFileFlag != "" || templateFileFlag != "" || includeRawFlag {
+		return errors.WrapValidationError("--keys-only cannot be combined with --summary, --demo, --wasm, --replay, --save, --snapshot, --entries-file, --compare-network, --compare-tx, --compare-ledger, --watch, --source-account, --check, --report, --template or --include-raw")
+	}
+	if outputFormatFlag == outputFormatMarkdown || outputFormatFlag == outputFormatLine {
+		return errors.WrapValidationError("--keys-only supports --output text or json")
+	}
+	if len(args) != 1 {
+		return errors.WrapValidationError("--keys-only takes exactly one transaction hash argument")
+	}
+	return nil
+}
+
+// runDebugKeysOnly prints the sorted footprint of txHash, after --only-key
+// and --exclude-key, without fetching any entry or simulating. Text output
+// is one base64 key per line with nothing else on stdout, so it can feed
+// scripts directly.
+func runDebugKeysOnly(cmd *cobra.Command, txHash string) error {
+	ctx := cmd.Context()
+	// Keep stdout to the keys alone, even in text mode
+	progress := cmd.ErrOrStderr()
+
+	opts, _ := primaryClientOptions(resolveRPCToken(), rpc.NewEntryMemo())
+	client, err := rpc.NewClient(opts...)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to create client: %v", err))
+	}
+	resp, err := client.GetTransaction(ctx, txHash)
+	if err != nil {
+		// These already explain what to try next
+		if errors.Is(err, errors.ErrTransactionNotFound) || errors.Is(err, errors.ErrRateLimitExceeded) {
+			return err
+		}
+		return errors.WrapRPCConnectionFailed(err)
+	}
+	keys, err := transactionLedgerKeys(ctx, progress, client, resp)
+	if err != nil {
+		return errors.WrapUnmarshalFailed(err, "result meta of "+txHash)
+	}
+	filter, err := newLedgerKeyFilter(onlyKeyFlags, excludeKeyFlags)
+	if err != nil {
+		return err
+	}
+	keys = filter.filterKeys(progress, keys)
+	sort.Strings(keys)
+
+	if outputFormatFlag == outputFormatJSON {
+		if err := report.WriteJSON(cmd.OutOrStdout(), keysOnlyOutput{TxHash: txHash, Network: networkFlag, Keys: keys}); err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+		return nil
+	}
+	printKeys(cmd.OutOrStdout(), keys)
+	return nil
+}
+
+func printKeys(out io.Writer, keys []string) {
+	for _, k := range keys {
+		fmt.Fprintln(out, k)
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKeysOnly(t *testing.T) {
+	prevKeys, prevTx, prevFormat := keysOnlyFlag, compareTxFlag, outputFormatFlag
+	t.Cleanup(func() {
+		keysOnlyFlag, compareTxFlag, outputFormatFlag = prevKeys, prevTx, prevFormat
+	})
+	hash := strings.Repeat("a", 64)
+
+	keysOnlyFlag, compareTxFlag, outputFormatFlag = false, "", outputFormatText
+	assert.NoError(t, validateKeysOnly(nil))
+
+	keysOnlyFlag = true
+	assert.NoError(t, validateKeysOnly([]string{hash}))
+	assert.Error(t, validateKeysOnly(nil))
+	assert.Error(t, validateKeysOnly([]string{hash, strings.Repeat("b", 64)}))
+
+	outputFormatFlag = outputFormatJSON
+	assert.NoError(t, validateKeysOnly([]string{hash}))
+	outputFormatFlag = outputFormatMarkdown
+	assert.Error(t, validateKeysOnly([]string{hash}))
+
+	outputFormatFlag, compareTxFlag = outputFormatText, strings.Repeat("b", 64)
+	assert.Error(t, validateKeysOnly([]string{hash}))
+}
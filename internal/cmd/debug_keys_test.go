@@ -0,0 +1,191 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func accountLedgerKey(t *testing.T, address string) string {
+	t.Helper()
+	var key xdr.LedgerKey
+	require.NoError(t, key.SetAccount(xdr.MustAddress(address)))
+	b, err := key.MarshalBinary()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func TestLedgerKeyFilter_Only(t *testing.T) {
+	a := accountLedgerKey(t, "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	b := accountLedgerKey(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	missing := accountLedgerKey(t, "GCEZWKCA5VLDNRLN3RPRJMRZOX3Z6G5CHCGSNFHEYVXM3XOJMDS674JZ")
+
+	f, err := newLedgerKeyFilter([]string{a, missing}, nil)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	assert.Equal(t, []string{a}, f.filterKeys(&out, []string{a, b}))
+	assert.Contains(t, out.String(), "Filter key "+missing+" is not in the transaction footprint")
+	assert.NotContains(t, out.String(), "Filter key "+a)
+	assert.Contains(t, out.String(), "keeping 1 of 2 footprint entries")
+
+	assert.Equal(t, map[string]string{a: "A"}, f.filterEntries(map[string]string{a: "A", b: "B"}))
+}
+
+func TestLedgerKeyFilter_Exclude(t *testing.T) {
+	a := accountLedgerKey(t, "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	b := accountLedgerKey(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+
+	f, err := newLedgerKeyFilter(nil, []string{b})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{a: "A"}, f.filterEntries(map[string]string{a: "A", b: "B"}))
+}
+
+func TestLedgerKeyFilter_Validation(t *testing.T) {
+	f, err := newLedgerKeyFilter(nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+
+	_, err = newLedgerKeyFilter([]string{"not-xdr"}, nil)
+	assert.Error(t, err)
+
+	a := accountLedgerKey(t, "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	_, err = newLedgerKeyFilter([]string{a}, []string{a})
+	assert.Error(t, err)
+}
+
+func TestLedgerKeyFilter_NilKeepsEverything(t *testing.T) {
+	var f *ledgerKeyFilter
+	var out bytes.Buffer
+	assert.Equal(t, []string{"x"}, f.filterKeys(&out, []string{"x"}))
+	assert.Empty(t, out.String())
+	assert.Equal(t, map[string]string{"x": "X"}, f.filterEntries(map[string]string{"x": "X"}))
+}
+
+func TestCountLedgerKeyTypes(t *testing.T) {
+	var code xdr.LedgerKey
+	require.NoError(t, code.SetContractCode(xdr.Hash{1}))
+	codeKey, err := xdr.MarshalBase64(code)
+	require.NoError(t, err)
+	keys := []string{
+		accountLedgerKey(t, "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"),
+		accountLedgerKey(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"),
+		codeKey,
+		"not-a-key",
+	}
+
+	counts := countLedgerKeyTypes(keys)
+	assert.Equal(t, map[string]int{"Account": 2, "ContractCode": 1, "Invalid": 1}, counts)
+
+	var out bytes.Buffer
+	printLedgerKeyTypes(&out, counts)
+	assert.Equal(t, "Footprint by type: 2 Account, 1 ContractCode, 1 Invalid\n", out.String())
+
+	out.Reset()
+	printLedgerKeyTypes(&out, countLedgerKeyTypes(nil))
+	assert.Empty(t, out.String())
+}
+
+func TestCheckKeyLimit(t *testing.T) {
+	prev := maxKeysFlag
+	t.Cleanup(func() { maxKeysFlag = prev })
+
+	maxKeysFlag = 500
+	assert.NoError(t, checkKeyLimit(500))
+	err := checkKeyLimit(501)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "501 ledger entries")
+	assert.Contains(t, err.Error(), "about 3 RPC requests")
+
+	maxKeysFlag = 0
+	assert.NoError(t, checkKeyLimit(100000), "0 disables the limit")
+}
+
+// footprintEnvelope is a Soroban envelope declaring one read-only and one
+// read-write key.
+func footprintEnvelope(t *testing.T, readOnly, readWrite string) string {
+	t.Helper()
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(sourceTestEnvelope(t), &env))
+	var ro, rw xdr.LedgerKey
+	require.NoError(t, xdr.SafeUnmarshalBase64(readOnly, &ro))
+	require.NoError(t, xdr.SafeUnmarshalBase64(readWrite, &rw))
+	env.V1.Tx.Ext = xdr.TransactionExt{V: 1, SorobanData: &xdr.SorobanTransactionData{
+		Resources: xdr.SorobanResources{Footprint: xdr.LedgerFootprint{
+			ReadOnly:  []xdr.LedgerKey{ro},
+			ReadWrite: []xdr.LedgerKey{rw},
+		}},
+	}}
+	envXdr, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return envXdr
+}
+
+func TestTransactionLedgerKeys_MetaUnavailableUsesEnvelopeFootprint(t *testing.T) {
+	readOnly := accountLedgerKey(t, "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	readWrite := accountLedgerKey(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	envXdr := footprintEnvelope(t, readOnly, readWrite)
+
+	var out bytes.Buffer
+	keys, err := transactionLedgerKeys(context.Background(), &out, nil, &rpc.TransactionResponse{EnvelopeXdr: envXdr})
+	require.NoError(t, err)
+	assert.Equal(t, []string{readOnly, readWrite}, keys)
+	assert.Contains(t, out.String(), "no result meta")
+	assert.Contains(t, out.String(), "2 ledger keys its envelope declares")
+}
+
+func TestTransactionLedgerKeys_AddsDeclaredReadOnlyKeys(t *testing.T) {
+	var code xdr.LedgerKey
+	require.NoError(t, code.SetContractCode(xdr.Hash{1}))
+	readOnly, err := xdr.MarshalBase64(code)
+	require.NoError(t, err)
+	readWrite := accountLedgerKey(t, overrideSource)
+
+	// The meta shows only the account the transaction updated; the contract
+	// code it merely read left no change behind.
+	tx := &rpc.TransactionResponse{
+		EnvelopeXdr:   footprintEnvelope(t, readOnly, readWrite),
+		ResultMetaXdr: updateMeta(t, accountLedgerEntry(t, overrideSource)),
+	}
+	metaKeys, err := extractLedgerKeys(tx.ResultMetaXdr)
+	require.NoError(t, err)
+	require.Equal(t, []string{readWrite}, metaKeys)
+
+	var out bytes.Buffer
+	keys, err := transactionLedgerKeys(context.Background(), &out, nil, tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{readWrite, readOnly}, keys)
+	assert.Contains(t, out.String(), "Added 1 ledger keys from the declared footprint")
+
+	// Nothing is added when the meta already covers the footprint.
+	out.Reset()
+	keys, err = transactionLedgerKeys(context.Background(), &out, nil, &rpc.TransactionResponse{
+		EnvelopeXdr:   sourceTestEnvelope(t),
+		ResultMetaXdr: tx.ResultMetaXdr,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{readWrite}, keys)
+	assert.Empty(t, out.String())
+}
+
+func TestTransactionLedgerKeys_MetaUnavailableWithoutFootprintWarns(t *testing.T) {
+	var out bytes.Buffer
+	keys, err := transactionLedgerKeys(context.Background(), &out, nil, &rpc.TransactionResponse{EnvelopeXdr: sourceTestEnvelope(t)})
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+	assert.Contains(t, out.String(), "runs against empty ledger state")
+}
+
+func TestTransactionLedgerKeys_BadEnvelope(t *testing.T) {
+	_, err := transactionLedgerKeys(context.Background(), &bytes.Buffer{}, nil, &rpc.TransactionResponse{EnvelopeXdr: "not-xdr"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+// Where the debug command's network came from.
+const (
+	networkFromFlag    = "flag"
+	networkFromURL     = "rpc-url"
+	networkFromProbe   = "probe"
+	networkFromDefault = "default"
+)
+
+// rpcURLNetwork returns the network the first recognisable --rpc-url
+// endpoint serves, and that endpoint.
+func rpcURLNetwork() (rpc.Network, string, bool) {
+	if rpcURLFlag == "" {
+		return "", "", false
+	}
+	for _, u := range splitURLList(rpcURLFlag) {
+		if n, ok := rpc.InferNetworkFromURL(u); ok {
+			return n, u, true
+		}
+	}
+	return "", "", false
+}
+
+// warnRPCURLNetwork warns when --rpc-url and the network whose passphrase
+// will be used disagree, or when a custom endpoint silently falls back to the
+// mainnet default. A wrong passphrase makes every signature check fail in a
+// way that looks like a contract bug.
+func warnRPCURLNetwork(out io.Writer, source string) {
+	if rpcURLFlag == "" {
+		return
+	}
+	inferred, url, ok := rpcURLNetwork()
+	switch {
+	case ok && inferred.String() != networkFlag:
+		fmt.Fprintf(out, "%s --rpc-url %s looks like a %s endpoint, but the %s network passphrase will be used. Pass --network %s if that is wrong.\n",
+			visualizer.Warning(), url, inferred, networkFlag, inferred)
+	case !ok && source == networkFromDefault:
+		fmt.Fprintf(out, "%s --rpc-url is not a known endpoint and --network was not given, so the mainnet passphrase will be used. Pass --network if the endpoint serves another network.\n",
+			visualizer.Warning())
+	}
+}
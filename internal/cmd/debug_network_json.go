@@ -0,0 +1,142 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkJSONFlag        string
+	compareNetworkJSONFlag string
+
+	// customNetwork is --network-json once parsed, or nil.
+	customNetwork *rpc.NetworkConfig
+	// customCompareNetwork is --compare-network-json once parsed, or nil.
+	customCompareNetwork *rpc.NetworkConfig
+)
+
+// networkJSON is the shape --network-json and --compare-network-json accept. The keys follow the
+// rpc.NetworkConfig field names.
+type networkJSON struct {
+	Name              string  `json:"name"`
+	HorizonURL        string  `json:"horizonURL"`
+	NetworkPassphrase string  `json:"networkPassphrase"`
+	SorobanRPCURL     string  `json:"sorobanRPCURL"`
+	RateLimit         float64 `json:"rateLimit"`
+	ArchivalRPCURL    string  `json:"archivalRPCURL"`
+}
+
+// parseNetworkJSON decodes and validates the value of flag, --network-json
+// or --compare-network-json, with the same checks a custom client gets.
+// Unknown keys are rejected so a typo such as "rpcURL" is not silently
+// ignored.
+func parseNetworkJSON(flag, s string) (*rpc.NetworkConfig, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.DisallowUnknownFields()
+	var v networkJSON
+	if err := dec.Decode(&v); err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("invalid %s: %v", flag, err))
+	}
+	if dec.More() {
+		return nil, errors.WrapValidationError(fmt.Sprintf("invalid %s: trailing data after the JSON object", flag))
+	}
+	cfg := rpc.NetworkConfig{
+		Name:              v.Name,
+		HorizonURL:        v.HorizonURL,
+		NetworkPassphrase: v.NetworkPassphrase,
+		SorobanRPCURL:     v.SorobanRPCURL,
+		RateLimit:         v.RateLimit,
+		ArchivalRPCURL:    v.ArchivalRPCURL,
+	}
+	if err := rpc.ValidateNetworkConfig(cfg); err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("invalid %s: %v", flag, err))
+	}
+	// As with NewCustomClient, a lone Horizon URL serves Soroban RPC too,
+	// rather than the builder falling back to the mainnet endpoint
+	if cfg.SorobanRPCURL == "" {
+		cfg.SorobanRPCURL = cfg.HorizonURL
+	}
+	return &cfg, nil
+}
+
+// validateNetworkJSON parses --network-json and makes its name the network
+// label for the rest of the run. It takes precedence over --network and the
+// config file endpoints for the primary network; --rpc-url and --replay,
+// which pick the endpoints themselves, cannot be combined with it.
+func validateNetworkJSON(cmd *cobra.Command) error {
+	customNetwork = nil
+	if networkJSONFlag == "" {
+		return nil
+	}
+	if rpcURLFlag != "" || replayBundleFlag != "" {
+		return errors.WrapValidationError("--network-json cannot be combined with --rpc-url or --replay")
+	}
+	cfg, err := parseNetworkJSON("--network-json", networkJSONFlag)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("network") {
+		fmt.Fprintf(progressWriter(cmd), "%s --network %s is ignored; --network-json selects the network\n", visualizer.Warning(), networkFlag)
+	}
+	customNetwork = cfg
+	networkFlag = cfg.Name
+	return nil
+}
+
+// validateCompareNetworkJSON parses --compare-network-json, a custom network
+// to compare against, such as a local fork of mainnet that shares its
+// passphrase but not its endpoints. It stands in for a single
+// --compare-network, named after its "name" key, so it cannot be combined
+// with --compare-network or the compare-side URL flags.
+func validateCompareNetworkJSON() error {
+	customCompareNetwork = nil
+	if compareNetworkJSONFlag == "" {
+		return nil
+	}
+	if len(compareNetworksFlag) > 0 || compareRPCURLFlag != "" || compareHorizonURL != "" || compareSorobanURL != "" {
+		return errors.WrapValidationError("--compare-network-json cannot be combined with --compare-network, --compare-rpc-url, --compare-horizon-url or --compare-soroban-rpc-url")
+	}
+	cfg, err := parseNetworkJSON("--compare-network-json", compareNetworkJSONFlag)
+	if err != nil {
+		return err
+	}
+	customCompareNetwork = cfg
+	compareNetworksFlag = []string{cfg.Name}
+	return nil
+}
+
+// parseCompareNetworks normalises each --compare-network, except the name of
+// a --compare-network-json network, which need not be a well-known one but
+// must differ from the primary network's so the two sides can be told apart.
+func parseCompareNetworks() error {
+	if customCompareNetwork != nil {
+		if customCompareNetwork.Name == networkFlag {
+			return errors.WrapValidationError(fmt.Sprintf("--compare-network-json name %q is also the primary network's; give the compared network a different name, e.g. \"%s-fork\"", networkFlag, networkFlag))
+		}
+		return nil
+	}
+	for i := range compareNetworksFlag {
+		if err := parseNetworkFlag(&compareNetworksFlag[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parsePrimaryNetwork normalises --network, unless --network-json already
+// chose a custom network whose name need not be a well-known one.
+func parsePrimaryNetwork() error {
+	if customNetwork != nil {
+		return nil
+	}
+	return parseNetworkFlag(&networkFlag)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetworkJSON(t *testing.T) {
+	cfg, err := parseNetworkJSON("--network-json", `{"name":"local","networkPassphrase":"Standalone Network ; February 2017","sorobanRPCURL":"http://localhost:8000/soroban/rpc"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "local", cfg.Name)
+	assert.Equal(t, "Standalone Network ; February 2017", cfg.NetworkPassphrase)
+	assert.Equal(t, "http://localhost:8000/soroban/rpc", cfg.SorobanRPCURL)
+	assert.Empty(t, cfg.HorizonURL)
+
+	cfg, err = parseNetworkJSON("--network-json", `{"name":"local","networkPassphrase":"p","horizonURL":"http://localhost:8000"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8000", cfg.SorobanRPCURL)
+
+	for name, in := range map[string]string{
+		"not json":        `{"name":`,
+		"no name":         `{"networkPassphrase":"p","horizonURL":"http://h"}`,
+		"no passphrase":   `{"name":"n","horizonURL":"http://h"}`,
+		"no url":          `{"name":"n","networkPassphrase":"p"}`,
+		"bad url":         `{"name":"n","networkPassphrase":"p","horizonURL":"not a url"}`,
+		"unknown key":     `{"name":"n","networkPassphrase":"p","rpcURL":"http://h"}`,
+		"trailing object": `{"name":"n","networkPassphrase":"p","horizonURL":"http://h"} {}`,
+	} {
+		_, err := parseNetworkJSON("--network-json", in)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestPrimaryClientOptions_NetworkJSON(t *testing.T) {
+	prevJSON, prevNetwork, prevCustom := networkJSONFlag, networkFlag, customNetwork
+	t.Cleanup(func() {
+		networkJSONFlag, networkFlag, customNetwork = prevJSON, prevNetwork, prevCustom
+	})
+
+	networkJSONFlag = `{"name":"local","networkPassphrase":"Standalone Network ; February 2017","horizonURL":"http://localhost:8000"}`
+	require.NoError(t, validateNetworkJSON(debugCmd))
+	assert.Equal(t, "local", networkFlag)
+	require.NoError(t, parsePrimaryNetwork())
+
+	opts, horizonURL := primaryClientOptions("", nil)
+	assert.Equal(t, "http://localhost:8000", horizonURL)
+	client, err := rpc.NewClient(opts...)
+	require.NoError(t, err)
+	assert.Equal(t, "Standalone Network ; February 2017", client.GetNetworkPassphrase())
+}
+
+func TestCompareNetworkJSON(t *testing.T) {
+	prevJSON, prevNetwork, prevCompare, prevCustom := compareNetworkJSONFlag, networkFlag, compareNetworksFlag, customCompareNetwork
+	prevRPCURL := compareRPCURLFlag
+	t.Cleanup(func() {
+		compareNetworkJSONFlag, networkFlag, compareNetworksFlag, customCompareNetwork = prevJSON, prevNetwork, prevCompare, prevCustom
+		compareRPCURLFlag = prevRPCURL
+	})
+
+	networkFlag, compareNetworksFlag, compareRPCURLFlag = "mainnet", nil, ""
+	compareNetworkJSONFlag = `{"name":"fork","networkPassphrase":"Public Global Stellar Network ; September 2015","sorobanRPCURL":"http://localhost:8000/soroban/rpc"}`
+	require.NoError(t, validateCompareNetworkJSON())
+	assert.Equal(t, []string{"fork"}, compareNetworksFlag)
+	require.NoError(t, parseCompareNetworks())
+
+	client, err := rpc.NewClient(append([]rpc.ClientOption{rpc.WithNetwork("fork")}, compareURLOptions()...)...)
+	require.NoError(t, err)
+	assert.Equal(t, "Public Global Stellar Network ; September 2015", client.GetNetworkPassphrase())
+	assert.Equal(t, "http://localhost:8000/soroban/rpc", client.SorobanURL)
+
+	// The fork needs a name of its own
+	networkFlag = "fork"
+	assert.Error(t, parseCompareNetworks())
+
+	compareNetworksFlag = []string{"testnet"}
+	assert.Error(t, validateCompareNetworkJSON(), "cannot be combined with --compare-network")
+
+	compareNetworksFlag, compareRPCURLFlag = nil, "http://localhost:9000"
+	assert.Error(t, validateCompareNetworkJSON(), "cannot be combined with --compare-rpc-url")
+}
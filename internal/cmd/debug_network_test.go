@@ -0,0 +1,36 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarnRPCURLNetwork(t *testing.T) {
+	prevURL, prevNetwork := rpcURLFlag, networkFlag
+	t.Cleanup(func() { rpcURLFlag, networkFlag = prevURL, prevNetwork })
+
+	warn := func(url, network, source string) string {
+		rpcURLFlag, networkFlag = url, network
+		var out bytes.Buffer
+		warnRPCURLNetwork(&out, source)
+		return out.String()
+	}
+
+	assert.Empty(t, warn("", "mainnet", networkFromDefault))
+	assert.Empty(t, warn("https://soroban-testnet.stellar.org", "testnet", networkFromFlag))
+	assert.Empty(t, warn("http://localhost:8000", "testnet", networkFromFlag), "an explicit network is trusted for unknown endpoints")
+
+	out := warn("https://soroban-testnet.stellar.org", "mainnet", networkFromFlag)
+	assert.Contains(t, out, "looks like a testnet endpoint")
+	assert.Contains(t, out, "--network testnet")
+
+	assert.Contains(t, warn("http://localhost:8000", "mainnet", networkFromDefault), "mainnet passphrase will be used")
+
+	// Alternates are searched for the first recognisable endpoint.
+	assert.Contains(t, warn("http://localhost:8000,https://horizon-futurenet.stellar.org", "testnet", networkFromFlag), "looks like a futurenet endpoint")
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/report"
+)
+
+// printOperations lists what the transaction does, one line per operation,
+// before any simulation output, after the source account (and fee source of a
+// fee bump), which are shown in both forms when muxed. It returns the decoded
+// envelope, or nil for an envelope that cannot be decoded: that is only
+// logged, since simulation reports the problem in detail.
+func printOperations(out io.Writer, envelopeXdr string) *decoder.DecodedEnvelope {
+	env, err := decoder.AnalyzeEnvelope(envelopeXdr)
+	if err != nil {
+		logger.Logger.Warn("Failed to decode envelope operations", "error", err)
+		return nil
+	}
+	if env.InnerTx != nil {
+		fmt.Fprintf(out, "Fee source: %s\n", env.SourceAccount)
+		fmt.Fprintf(out, "Source account: %s\n", env.InnerTx.SourceAccount)
+	} else {
+		fmt.Fprintf(out, "Source account: %s\n", env.SourceAccount)
+	}
+	ops := env.AllOperations()
+	if len(ops) == 0 {
+		return env
+	}
+	fmt.Fprintf(out, "Operations (%d):\n", len(ops))
+	for i, op := range ops {
+		fmt.Fprintf(out, "  [%d] %s\n", i, decoder.SummarizeOperation(op))
+	}
+	return env
+}
+
+// setReportAccounts records the source account, and the fee source of a fee
+// bump, of env in r.
+func setReportAccounts(r *report.DebugReport, env *decoder.DecodedEnvelope) {
+	if env == nil {
+		return
+	}
+	source := env.SourceAccount
+	if env.InnerTx != nil {
+		feeSource := env.SourceAccount
+		r.FeeSource = &feeSource
+		source = env.InnerTx.SourceAccount
+	}
+	r.SourceAccount = &source
+}
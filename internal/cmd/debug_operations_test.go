@@ -0,0 +1,86 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintOperations(t *testing.T) {
+	contractID := xdr.ContractId([32]byte{0x02})
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: xdr.MustMuxedAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"),
+			Operations: []xdr.Operation{
+				{Body: xdr.OperationBody{
+					Type: xdr.OperationTypeInvokeHostFunction,
+					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{HostFunction: xdr.HostFunction{
+						Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+						InvokeContract: &xdr.InvokeContractArgs{
+							ContractAddress: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractID},
+							FunctionName:    "swap",
+						},
+					}},
+				}},
+				{Body: xdr.OperationBody{Type: xdr.OperationTypeBumpSequence, BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 9}}},
+			},
+		}},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	decoded := printOperations(&out, b64)
+	require.NotNil(t, decoded)
+	assert.Contains(t, out.String(), "Source account: GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7\n")
+	assert.Contains(t, out.String(), "Operations (2):")
+	assert.Contains(t, out.String(), ".swap (0 args)")
+	assert.Contains(t, out.String(), "[1] BumpSequence")
+
+	out.Reset()
+	assert.Nil(t, printOperations(&out, "not-xdr"))
+	assert.Empty(t, out.String())
+}
+
+func TestPrintOperations_MuxedFeeBump(t *testing.T) {
+	var m strkey.MuxedAccount
+	require.NoError(t, m.SetAccountID(originalSource))
+	m.SetID(1234)
+	mAddress, err := m.Address()
+	require.NoError(t, err)
+
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{Tx: xdr.FeeBumpTransaction{
+			FeeSource: xdr.MustMuxedAddress(overrideSource),
+			InnerTx: xdr.FeeBumpTransactionInnerTx{
+				Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+				V1:   &xdr.TransactionV1Envelope{Tx: xdr.Transaction{SourceAccount: xdr.MustMuxedAddress(mAddress)}},
+			},
+		}},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	decoded := printOperations(&out, b64)
+	assert.Contains(t, out.String(), "Fee source: "+overrideSource+"\n")
+	assert.Contains(t, out.String(), "Source account: "+mAddress+" ("+originalSource+", memo ID 1234)\n")
+
+	r := report.NewDebugReport("abc", "testnet")
+	setReportAccounts(r, decoded)
+	require.NotNil(t, r.SourceAccount)
+	assert.Equal(t, mAddress, r.SourceAccount.Address)
+	assert.Equal(t, originalSource, r.SourceAccount.AccountID)
+	assert.Equal(t, uint64(1234), *r.SourceAccount.MuxedID)
+	assert.Equal(t, overrideSource, r.FeeSource.Address)
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+)
+
+// entryPrefetch loads the primary network's ledger entries in the
+// background. Started as soon as the footprint is known, the fetch overlaps
+// the rest of the run's setup: building the simulator runner and compare
+// clients, fetching TTLs and looking up the ledger's protocol version.
+type entryPrefetch struct {
+	done    chan struct{}
+	entries map[string]string
+	err     error
+
+	// elapsed is how long the fetch took, and waited how much of that the
+	// run spent blocked on it.
+	elapsed time.Duration
+	waited  time.Duration
+}
+
+// startEntryPrefetch starts loading the entries for keys: from the result
+// meta when it has them, otherwise with getLedgerEntries.
+func startEntryPrefetch(ctx context.Context, client *rpc.Client, resultMetaXdr string, keys []string) *entryPrefetch {
+	p := &entryPrefetch{done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		start := time.Now()
+		p.entries, p.err = loadLedgerEntries(ctx, client, resultMetaXdr, keys)
+		p.elapsed = time.Since(start)
+	}()
+	return p
+}
+
+// loadLedgerEntries reads the entries from the result meta when it has them,
+// then fetches the keys the meta does not mention, such as those
+// transactionLedgerKeys recovered from the declared footprint. Keys the meta
+// does mention are never fetched, so an entry the transaction created is not
+// replaced by its current state.
+func loadLedgerEntries(ctx context.Context, client *rpc.Client, resultMetaXdr string, keys []string) (map[string]string, error) {
+	entries, err := rpc.ExtractLedgerEntriesFromMeta(resultMetaXdr)
+	if err == nil {
+		logger.Logger.InfoContext(ctx, "Extracted ledger entries for simulation", "count", len(entries))
+		return withUnchangedEntries(ctx, client, resultMetaXdr, keys, entries), nil
+	}
+	logger.Logger.WarnContext(ctx, "Failed to extract ledger entries from metadata, fetching from network", "error", err)
+	return client.GetLedgerEntries(ctx, keys)
+}
+
+// withUnchangedEntries adds to entries, read from the result meta, the
+// current entries for those of keys the meta does not mention. A failed
+// fetch is logged and leaves entries as they are.
+func withUnchangedEntries(ctx context.Context, client *rpc.Client, resultMetaXdr string, keys []string, entries map[string]string) map[string]string {
+	if client == nil {
+		return entries
+	}
+	metaKeys, err := extractLedgerKeys(resultMetaXdr)
+	if err != nil {
+		return entries
+	}
+	inMeta := make(map[string]struct{}, len(metaKeys))
+	for _, k := range metaKeys {
+		inMeta[k] = struct{}{}
+	}
+	var missing []string
+	for _, k := range keys {
+		if _, ok := inMeta[k]; ok {
+			continue
+		}
+		if _, ok := entries[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) == 0 {
+		return entries
+	}
+
+	fetched, err := client.GetLedgerEntries(ctx, missing)
+	if err != nil {
+		logger.Logger.WarnContext(ctx, "Failed to fetch declared footprint entries missing from metadata", "count", len(missing), "error", err)
+		return entries
+	}
+	merged := make(map[string]string, len(entries)+len(fetched))
+	maps.Copy(merged, entries)
+	maps.Copy(merged, fetched)
+	logger.Logger.InfoContext(ctx, "Fetched declared footprint entries missing from metadata", "count", len(fetched))
+	return merged
+}
+
+// wait blocks until the entries are loaded and returns them. Every call
+// returns the same entries, which callers must not modify.
+func (p *entryPrefetch) wait() (map[string]string, error) {
+	start := time.Now()
+	<-p.done
+	p.waited += time.Since(start)
+	return p.entries, p.err
+}
+
+// runTimings records how long each stage of a debug run took, shown with
+// --verbose.
+type runTimings struct {
+	names    []string
+	elapsed  []time.Duration
+	prefetch *entryPrefetch
+}
+
+// record adds a stage that started at start and has just finished.
+func (t *runTimings) record(name string, start time.Time) {
+	t.names = append(t.names, name)
+	t.elapsed = append(t.elapsed, time.Since(start))
+}
+
+func (t *runTimings) print(out io.Writer) {
+	parts := make([]string, len(t.names))
+	for i, name := range t.names {
+		parts[i] = fmt.Sprintf("%s %s", name, t.elapsed[i].Round(time.Millisecond))
+	}
+	fmt.Fprintf(out, "\nTimings: %s\n", strings.Join(parts, ", "))
+	if p := t.prefetch; p != nil {
+		select {
+		case <-p.done:
+			fmt.Fprintf(out, "  Ledger entries loaded in the background in %s; the run waited %s for them\n",
+				p.elapsed.Round(time.Millisecond), p.waited.Round(time.Millisecond))
+		default:
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryPrefetch_FromMeta(t *testing.T) {
+	meta, err := xdr.MarshalBase64(xdr.TransactionResultMeta{
+		Result:            xdr.TransactionResultPair{Result: xdr.TransactionResult{Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxBadAuth}}},
+		TxApplyProcessing: xdr.TransactionMeta{V: 1, V1: &xdr.TransactionMetaV1{}},
+	})
+	require.NoError(t, err)
+
+	// Entries in the meta need no client
+	p := startEntryPrefetch(context.Background(), nil, meta, nil)
+	entries, err := p.wait()
+	require.NoError(t, err)
+	assert.NotNil(t, entries)
+
+	again, err := p.wait()
+	require.NoError(t, err)
+	assert.Equal(t, entries, again, "every wait returns the same entries")
+}
+
+func TestLoadLedgerEntries_FetchesKeysMissingFromMeta(t *testing.T) {
+	const reader = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	updated := accountLedgerKey(t, overrideSource)
+	readOnly := accountLedgerKey(t, reader)
+	readOnlyEntry := accountLedgerEntry(t, reader)
+
+	var requested bytes.Buffer
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = requested.ReadFrom(r.Body)
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": map[string]any{
+			"entries":      []map[string]any{{"key": readOnly, "xdr": readOnlyEntry}},
+			"latestLedger": 10,
+		}})
+	}))
+	t.Cleanup(server.Close)
+	client, err := rpc.NewClient(rpc.WithSorobanURL(server.URL), rpc.WithCacheEnabled(false))
+	require.NoError(t, err)
+
+	meta := updateMeta(t, accountLedgerEntry(t, overrideSource))
+	entries, err := loadLedgerEntries(context.Background(), client, meta, []string{updated, readOnly})
+	require.NoError(t, err)
+	assert.Contains(t, entries, updated)
+	assert.Equal(t, readOnlyEntry, entries[readOnly])
+	assert.Contains(t, requested.String(), readOnly)
+	assert.NotContains(t, requested.String(), updated, "keys the meta records are not fetched")
+}
+
+func TestRunTimings_Print(t *testing.T) {
+	timings := &runTimings{}
+	timings.record("fetch transaction", time.Now())
+	timings.record("setup", time.Now())
+
+	var out bytes.Buffer
+	timings.print(&out)
+	assert.Regexp(t, `Timings: fetch transaction \S+, setup \S+\n`, out.String())
+	assert.NotContains(t, out.String(), "in the background")
+
+	p := &entryPrefetch{done: make(chan struct{}), elapsed: 400 * time.Millisecond, waited: 20 * time.Millisecond}
+	close(p.done)
+	timings.prefetch = p
+	out.Reset()
+	timings.print(&out)
+	assert.Contains(t, out.String(), "loaded in the background in 400ms; the run waited 20ms for them")
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+var compareProtocolVersionFlag uint32
+
+// validateProtocolVersions checks --protocol-version and
+// --compare-protocol-version against the protocols the embedded host
+// supports, so an unsupported version fails before anything is fetched.
+func validateProtocolVersions() error {
+	if compareProtocolVersionFlag > 0 && len(compareNetworksFlag) == 0 && compareTxFlag == "" {
+		return errors.WrapValidationError("--compare-protocol-version requires --compare-network or --compare-tx")
+	}
+	if err := checkProtocolSupported("protocol-version", protocolVersionFlag); err != nil {
+		return err
+	}
+	return checkProtocolSupported("compare-protocol-version", compareProtocolVersionFlag)
+}
+
+// checkProtocolSupported rejects a requested version the embedded host
+// cannot simulate. 0 means no version was requested.
+func checkProtocolSupported(flag string, version uint32) error {
+	if version == 0 {
+		return nil
+	}
+	if err := simulator.Validate(version); err != nil {
+		return fmt.Errorf("--%s: %w; the embedded host supports protocols %s", flag, err, supportedProtocols())
+	}
+	return nil
+}
+
+func supportedProtocols() string {
+	versions := simulator.Supported()
+	names := make([]string, len(versions))
+	for i, v := range versions {
+		names[i] = fmt.Sprint(v)
+	}
+	return strings.Join(names, ", ")
+}
+
+// simulationProtocol returns the protocol to simulate a transaction from
+// ledger under: requested when set, otherwise the version that ledger ran.
+// A nil version leaves the choice to the simulator, which uses its latest
+// protocol. The note explains the choice for the run's output and may be
+// empty.
+func simulationProtocol(ctx context.Context, client *rpc.Client, ledger, requested uint32) (*uint32, string) {
+	if requested > 0 {
+		return &requested, fmt.Sprintf("Using protocol version override: %d", requested)
+	}
+	if client == nil || ledger == 0 {
+		return nil, ""
+	}
+	header, err := client.GetLedgerHeader(ctx, ledger)
+	if err != nil {
+		logger.Logger.Warn("Could not determine the ledger's protocol version; using the simulator default", "ledger", ledger, "error", err)
+		return nil, ""
+	}
+	return protocolForLedger(ledger, header.ProtocolVersion)
+}
+
+// protocolForLedger picks the protocol to replay a transaction from ledger
+// under, given the version that ledger ran.
+func protocolForLedger(ledger, version uint32) (*uint32, string) {
+	if version == 0 {
+		return nil, ""
+	}
+	if simulator.Validate(version) != nil {
+		return nil, fmt.Sprintf("%s Ledger %d ran protocol %d, which the embedded host does not support (it supports %s); simulating under protocol %d",
+			visualizer.Warning(), ledger, version, supportedProtocols(), simulator.LatestVersion())
+	}
+	return &version, fmt.Sprintf("Simulating under protocol %d, as ledger %d ran", version, ledger)
+}
+
+// networkProtocols maps each network of a compare run to the protocol
+// version it currently runs. A network whose version could not be fetched
+// is left out.
+type networkProtocols map[string]uint32
+
+// fetchNetworkProtocols asks the primary and every compare network for its
+// current protocol version with getLatestLedger.
+func fetchNetworkProtocols(ctx context.Context, client *rpc.Client, compareClients []*rpc.Client, requestIDs *networkRequestIDs) networkProtocols {
+	protocols := make(networkProtocols)
+	fetch := func(ctx context.Context, network string, c *rpc.Client) {
+		if c == nil {
+			return
+		}
+		latest, err := c.GetLatestLedger(ctx)
+		if err != nil {
+			logger.Logger.WarnContext(ctx, "Could not determine the network's protocol version", "network", network, "error", err)
+			return
+		}
+		if latest.ProtocolVersion > 0 {
+			protocols[network] = latest.ProtocolVersion
+		}
+	}
+	fetch(ctx, networkFlag, client)
+	for i, c := range compareClients {
+		fetch(requestIDs.compare(ctx, i), compareNetworksFlag[i], c)
+	}
+	return protocols
+}
+
+// label names network in a comparison header, with its protocol version
+// when known.
+func (p networkProtocols) label(network string) string {
+	if v, ok := p[network]; ok {
+		return fmt.Sprintf("%s (protocol %d)", network, v)
+	}
+	return network
+}
+
+// mismatched reports whether the networks are known to run different
+// protocol versions.
+func (p networkProtocols) mismatched() bool {
+	var first uint32
+	for _, v := range p {
+		if first == 0 {
+			first = v
+		} else if v != first {
+			return true
+		}
+	}
+	return false
+}
+
+// warnProtocolMismatch warns that the compared networks run different
+// protocol versions, so a divergence may come from the protocol rather
+// than the contract.
+func warnProtocolMismatch(out io.Writer, warns *debugWarnings, networks []string, protocols networkProtocols) {
+	if !protocols.mismatched() {
+		return
+	}
+	labels := make([]string, len(networks))
+	for i, n := range networks {
+		labels[i] = protocols.label(n)
+	}
+	warns.add(out, simulator.Warning{
+		Code: simulator.WarningProtocolMismatch,
+		Message: fmt.Sprintf("PROTOCOL MISMATCH: %s. The networks run different protocol versions, so differing status, "+
+			"events or costs may be caused by the protocol upgrade rather than by the contract", strings.Join(labels, ", ")),
+	})
+}
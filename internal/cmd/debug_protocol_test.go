@@ -0,0 +1,84 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProtocolVersions(t *testing.T) {
+	prevProto, prevCompareProto := protocolVersionFlag, compareProtocolVersionFlag
+	prevNetworks, prevTx := compareNetworksFlag, compareTxFlag
+	t.Cleanup(func() {
+		protocolVersionFlag, compareProtocolVersionFlag = prevProto, prevCompareProto
+		compareNetworksFlag, compareTxFlag = prevNetworks, prevTx
+	})
+	compareNetworksFlag, compareTxFlag = nil, ""
+
+	protocolVersionFlag, compareProtocolVersionFlag = 0, 0
+	assert.NoError(t, validateProtocolVersions())
+
+	protocolVersionFlag = 21
+	assert.NoError(t, validateProtocolVersions())
+
+	protocolVersionFlag = 99
+	err := validateProtocolVersions()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrProtocolUnsupported))
+	assert.Contains(t, err.Error(), "--protocol-version")
+	assert.Contains(t, err.Error(), supportedProtocols())
+	assert.Equal(t, ExitCodeInvalidInput, ExitCode(err))
+
+	protocolVersionFlag, compareProtocolVersionFlag = 21, 22
+	err = validateProtocolVersions()
+	require.Error(t, err, "a compare version needs a compare side")
+	assert.Contains(t, err.Error(), "--compare-protocol-version requires")
+
+	compareNetworksFlag = []string{"testnet"}
+	assert.NoError(t, validateProtocolVersions())
+
+	compareProtocolVersionFlag = 99
+	err = validateProtocolVersions()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--compare-protocol-version")
+}
+
+func TestSimulationProtocol_Requested(t *testing.T) {
+	version, note := simulationProtocol(context.Background(), nil, 100, 21)
+	require.NotNil(t, version)
+	assert.Equal(t, uint32(21), *version)
+	assert.Equal(t, "Using protocol version override: 21", note)
+
+	version, note = simulationProtocol(context.Background(), nil, 100, 0)
+	assert.Nil(t, version, "without a client the simulator picks")
+	assert.Empty(t, note)
+}
+
+func TestProtocolForLedger(t *testing.T) {
+	version, note := protocolForLedger(500, 21)
+	require.NotNil(t, version)
+	assert.Equal(t, uint32(21), *version)
+	assert.Contains(t, note, "protocol 21")
+	assert.Contains(t, note, "ledger 500")
+
+	version, note = protocolForLedger(500, 99)
+	assert.Nil(t, version)
+	assert.Contains(t, note, "does not support")
+	assert.Contains(t, note, "protocol 99")
+
+	version, note = protocolForLedger(500, 0)
+	assert.Nil(t, version)
+	assert.Empty(t, note)
+
+	latest := simulator.LatestVersion()
+	version, _ = protocolForLedger(1, latest)
+	require.NotNil(t, version)
+	assert.Equal(t, latest, *version)
+}
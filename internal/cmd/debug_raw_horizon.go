@@ -0,0 +1,61 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+var rawHorizonFlag bool
+
+// validateRawHorizon checks --raw-horizon, which needs the transaction
+// fetched from Horizon.
+func validateRawHorizon() error {
+	if !rawHorizonFlag {
+		return nil
+	}
+	if rpc.TransactionSource(txSourceFlag) == rpc.TransactionSourceSoroban {
+		return errors.WrapValidationError("--raw-horizon needs the transaction from Horizon; it cannot be combined with --tx-source soroban")
+	}
+	if replayBundleFlag != "" || demoMode || wasmPath != "" || summaryFlag {
+		return errors.WrapValidationError("--raw-horizon cannot be combined with --replay, --demo, --wasm or --summary, which fetch no transaction from Horizon")
+	}
+	return nil
+}
+
+// rawHorizonOptions returns the client option that keeps Horizon's
+// transaction JSON for --raw-horizon.
+func rawHorizonOptions() []rpc.ClientOption {
+	if !rawHorizonFlag {
+		return nil
+	}
+	return []rpc.ClientOption{rpc.WithRawHorizon(true)}
+}
+
+// printRawHorizon writes the Horizon transaction JSON of tx, indented, to w,
+// which is stderr so it stays out of the report. A transaction that came
+// from Soroban RPC has none, which is said on w instead.
+func printRawHorizon(w io.Writer, tx *rpc.TransactionResponse) {
+	if !rawHorizonFlag || tx == nil {
+		return
+	}
+	if len(tx.RawHorizon) == 0 {
+		fmt.Fprintf(w, "%s --raw-horizon: the transaction was fetched from Soroban RPC, so there is no Horizon JSON to print\n", visualizer.Warning())
+		return
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, tx.RawHorizon, "", "  "); err != nil {
+		buf.Reset()
+		buf.Write(tx.RawHorizon)
+	}
+	buf.WriteByte('\n')
+	_, _ = w.Write(buf.Bytes())
+}
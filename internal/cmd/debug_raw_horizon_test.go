@@ -0,0 +1,46 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintRawHorizon(t *testing.T) {
+	prev := rawHorizonFlag
+	t.Cleanup(func() { rawHorizonFlag = prev })
+
+	tx := &rpc.TransactionResponse{EnvelopeXdr: "ENV", RawHorizon: []byte(`{"memo":"hello","signatures":["c2ln"]}`)}
+
+	var buf bytes.Buffer
+	rawHorizonFlag = false
+	printRawHorizon(&buf, tx)
+	assert.Empty(t, buf.String())
+
+	rawHorizonFlag = true
+	printRawHorizon(&buf, tx)
+	assert.Equal(t, "{\n  \"memo\": \"hello\",\n  \"signatures\": [\n    \"c2ln\"\n  ]\n}\n", buf.String())
+
+	buf.Reset()
+	printRawHorizon(&buf, &rpc.TransactionResponse{EnvelopeXdr: "ENV"})
+	assert.Contains(t, buf.String(), "fetched from Soroban RPC")
+}
+
+func TestValidateRawHorizon(t *testing.T) {
+	prevRaw, prevSource, prevReplay := rawHorizonFlag, txSourceFlag, replayBundleFlag
+	t.Cleanup(func() { rawHorizonFlag, txSourceFlag, replayBundleFlag = prevRaw, prevSource, prevReplay })
+
+	rawHorizonFlag, txSourceFlag, replayBundleFlag = true, "", ""
+	assert.NoError(t, validateRawHorizon())
+
+	txSourceFlag = string(rpc.TransactionSourceSoroban)
+	assert.Error(t, validateRawHorizon())
+
+	txSourceFlag, replayBundleFlag = "", "bundle.json"
+	assert.Error(t, validateRawHorizon())
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// rebuildFlag is where --rebuild writes the corrected envelope; "-" prints
+// it with the progress output instead.
+var rebuildFlag string
+
+// validateRebuild rejects --rebuild in the modes that simulate no envelope
+// of the transaction given.
+func validateRebuild() error {
+	if rebuildFlag == "" {
+		return nil
+	}
+	if demoMode || wasmPath != "" || summaryFlag || keysOnlyFlag || compareTxFlag != "" || compareLedgerFlag != "" {
+		return errors.WrapValidationError("--rebuild cannot be combined with --demo, --wasm, --summary, --keys-only, --compare-tx or --compare-ledger")
+	}
+	return nil
+}
+
+// rebuiltEnvelope is the envelope that was simulated, made ready to sign and
+// submit again.
+type rebuiltEnvelope struct {
+	EnvelopeXdr string
+	// Notes describe what was changed beyond the overrides, and what still
+	// needs fixing before the envelope can be submitted.
+	Notes []string
+}
+
+// rebuildEnvelope turns the simulated envelope, with the --source-account
+// override already applied at the XDR level, into a corrected artifact. The
+// signatures, which no longer match and would be replaced anyway, are
+// dropped. When entries hold the source account, the sequence number is set
+// to the one the network expects next. Expired time bounds are reported but
+// left alone, since only the author knows the intended window.
+func rebuildEnvelope(envelopeXdr string, entries map[string]string, now time.Time) (*rebuiltEnvelope, error) {
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &env); err != nil {
+		return nil, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+
+	var source xdr.AccountId
+	var seq *xdr.SequenceNumber
+	var cond xdr.Preconditions
+	switch env.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
+		env.V0.Signatures = nil
+		source = xdr.AccountId{Type: xdr.PublicKeyTypePublicKeyTypeEd25519, Ed25519: &env.V0.Tx.SourceAccountEd25519}
+		seq = &env.V0.Tx.SeqNum
+		if env.V0.Tx.TimeBounds != nil {
+			cond = xdr.NewPreconditionsWithTimeBounds(env.V0.Tx.TimeBounds)
+		}
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		env.V1.Signatures = nil
+		source = env.V1.Tx.SourceAccount.ToAccountId()
+		seq = &env.V1.Tx.SeqNum
+		cond = env.V1.Tx.Cond
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		env.FeeBump.Signatures = nil
+		inner := env.FeeBump.Tx.InnerTx.V1
+		inner.Signatures = nil
+		source = inner.Tx.SourceAccount.ToAccountId()
+		seq = &inner.Tx.SeqNum
+		cond = inner.Tx.Cond
+	default:
+		return nil, fmt.Errorf("unsupported envelope type %s", env.Type)
+	}
+
+	rebuilt := &rebuiltEnvelope{}
+	if next, ok := nextSequence(source, entries); ok && next != *seq {
+		rebuilt.Notes = append(rebuilt.Notes, fmt.Sprintf("Sequence number set to %d, the source account's current sequence + 1 (was %d)", next, *seq))
+		*seq = next
+	}
+	if tb := cond.TimeBounds; tb != nil && tb.MaxTime != 0 && int64(tb.MaxTime) < now.Unix() {
+		rebuilt.Notes = append(rebuilt.Notes, fmt.Sprintf("The time bounds expired at %s; set new ones before signing",
+			time.Unix(int64(tb.MaxTime), 0).UTC().Format(time.RFC3339)))
+	}
+
+	var err error
+	if rebuilt.EnvelopeXdr, err = xdr.MarshalBase64(env); err != nil {
+		return nil, fmt.Errorf("failed to encode envelope: %w", err)
+	}
+	return rebuilt, nil
+}
+
+// nextSequence returns the sequence number the network expects next from
+// account, when its account entry is among entries.
+func nextSequence(account xdr.AccountId, entries map[string]string) (xdr.SequenceNumber, bool) {
+	key, err := xdr.MarshalBase64(xdr.LedgerKey{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: account},
+	})
+	if err != nil {
+		return 0, false
+	}
+	encoded, ok := entries[key]
+	if !ok {
+		return 0, false
+	}
+	var entry xdr.LedgerEntry
+	if err := xdr.SafeUnmarshalBase64(encoded, &entry); err != nil || entry.Data.Account == nil {
+		return 0, false
+	}
+	return entry.Data.Account.SeqNum + 1, true
+}
+
+// writeRebuild rebuilds the simulated envelope for --rebuild and writes it to
+// the file named by the flag, or to out for "-".
+func writeRebuild(out io.Writer, envelopeXdr string, entries map[string]string) error {
+	if rebuildFlag == "" {
+		return nil
+	}
+	rebuilt, err := rebuildEnvelope(envelopeXdr, entries, time.Now())
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("--rebuild: %v", err))
+	}
+
+	if rebuildFlag == "-" {
+		fmt.Fprintf(out, "\nRebuilt envelope:\n%s\n", rebuilt.EnvelopeXdr)
+	} else {
+		if err := os.WriteFile(rebuildFlag, []byte(rebuilt.EnvelopeXdr+"\n"), 0o644); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("failed to write rebuilt envelope: %v", err))
+		}
+		fmt.Fprintf(out, "\n%s Rebuilt envelope written to %s\n", visualizer.Success(), rebuildFlag)
+	}
+	for _, note := range rebuilt.Notes {
+		fmt.Fprintf(out, "  %s\n", note)
+	}
+	fmt.Fprintf(out, "  %s It carries no signatures: sign it again before submitting it\n", visualizer.Warning())
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedOverrideEnvelope is the test envelope with --source-account applied
+// and a stale signature, sequence number and time bounds.
+func signedOverrideEnvelope(t *testing.T) string {
+	t.Helper()
+	rewritten, _, err := newSourceAccountOverride(sourceTestEnvelope(t), overrideSource)
+	require.NoError(t, err)
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(rewritten, &env))
+	env.V1.Tx.SeqNum = 7
+	env.V1.Tx.Cond = xdr.NewPreconditionsWithTimeBounds(&xdr.TimeBounds{MaxTime: 1000})
+	env.V1.Signatures = []xdr.DecoratedSignature{{Signature: xdr.Signature("sig")}}
+	encoded, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return encoded
+}
+
+func accountEntryWithSeq(t *testing.T, address string, seq int64) string {
+	t.Helper()
+	var entry xdr.LedgerEntry
+	require.NoError(t, xdr.SafeUnmarshalBase64(accountLedgerEntry(t, address), &entry))
+	entry.Data.Account.SeqNum = xdr.SequenceNumber(seq)
+	encoded, err := xdr.MarshalBase64(entry)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestRebuildEnvelope(t *testing.T) {
+	entries := map[string]string{accountKey(t, overrideSource): accountEntryWithSeq(t, overrideSource, 41)}
+	rebuilt, err := rebuildEnvelope(signedOverrideEnvelope(t), entries, time.Unix(2000, 0))
+	require.NoError(t, err)
+
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(rebuilt.EnvelopeXdr, &env))
+	assert.Empty(t, env.V1.Signatures)
+	assert.Equal(t, overrideSource, env.V1.Tx.SourceAccount.Address())
+	assert.Equal(t, xdr.SequenceNumber(42), env.V1.Tx.SeqNum)
+
+	require.Len(t, rebuilt.Notes, 2)
+	assert.Contains(t, rebuilt.Notes[0], "Sequence number set to 42")
+	assert.Contains(t, rebuilt.Notes[1], "time bounds expired")
+}
+
+func TestRebuildEnvelope_WithoutSourceEntry(t *testing.T) {
+	rebuilt, err := rebuildEnvelope(signedOverrideEnvelope(t), nil, time.Unix(500, 0))
+	require.NoError(t, err)
+
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(rebuilt.EnvelopeXdr, &env))
+	assert.Equal(t, xdr.SequenceNumber(7), env.V1.Tx.SeqNum)
+	assert.Empty(t, rebuilt.Notes)
+
+	_, err = rebuildEnvelope("not-xdr", nil, time.Now())
+	assert.Error(t, err)
+}
+
+func TestWriteRebuild(t *testing.T) {
+	prev := rebuildFlag
+	t.Cleanup(func() { rebuildFlag = prev })
+
+	rebuildFlag = filepath.Join(t.TempDir(), "fixed.xdr")
+	var out bytes.Buffer
+	require.NoError(t, writeRebuild(&out, signedOverrideEnvelope(t), nil))
+	assert.Contains(t, out.String(), "Rebuilt envelope written to "+rebuildFlag)
+	assert.Contains(t, out.String(), "sign it again before submitting")
+
+	written, err := os.ReadFile(rebuildFlag)
+	require.NoError(t, err)
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(strings.TrimSpace(string(written)), &env))
+
+	rebuildFlag = "-"
+	out.Reset()
+	require.NoError(t, writeRebuild(&out, signedOverrideEnvelope(t), nil))
+	assert.Contains(t, out.String(), "Rebuilt envelope:\n"+strings.TrimSpace(string(written))+"\n")
+}
+
+func TestValidateRebuild(t *testing.T) {
+	prevRebuild, prevKeysOnly := rebuildFlag, keysOnlyFlag
+	t.Cleanup(func() { rebuildFlag, keysOnlyFlag = prevRebuild, prevKeysOnly })
+
+	rebuildFlag, keysOnlyFlag = "fixed.xdr", false
+	assert.NoError(t, validateRebuild())
+	keysOnlyFlag = true
+	assert.Error(t, validateRebuild())
+}
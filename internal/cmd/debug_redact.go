@@ -0,0 +1,57 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/bundle"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+var (
+	redactFlag          bool
+	redactContractsFlag bool
+)
+
+// validateRedact checks that --redact and --redact-contracts are only used
+// when a bundle is being saved.
+func validateRedact() error {
+	if (redactFlag || redactContractsFlag) && saveBundleFlag == "" {
+		return errors.WrapValidationError("--redact and --redact-contracts only apply to bundles written with --save")
+	}
+	return nil
+}
+
+// saveReplayBundle writes the --save bundle with the simulation result, when
+// there is one. A redacted bundle usually cannot be simulated again; an
+// unredacted one can reuse the result when its replay hash matches.
+func saveReplayBundle(out io.Writer, txHash string, tx *rpc.TransactionResponse, keys []string, entries map[string]string, res *simulator.SimulationResponse) error {
+	b := bundle.New(txHash, networkFlag, Version, tx, keys, entries)
+	b.Simulation = res
+	if redactFlag || redactContractsFlag {
+		if err := b.Redact(redactContractsFlag); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("failed to redact bundle: %v", err))
+		}
+	}
+	if err := bundle.Save(saveBundleFlag, b); err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to save bundle: %v", err))
+	}
+	fmt.Fprintf(out, "%s Saved replay bundle to %s\n", visualizer.Success(), saveBundleFlag)
+	if b.Manifest.Redacted {
+		fmt.Fprintf(out, "  Addresses are pseudonymised: compare it with 'erst diff'; it cannot be replayed faithfully\n")
+	}
+	return nil
+}
+
+// warnRedactedReplay explains why replaying a redacted bundle may fail.
+func warnRedactedReplay(out io.Writer, m bundle.Manifest) {
+	if m.Redacted {
+		fmt.Fprintf(out, "%s This bundle is redacted: its signatures were removed and addresses replaced, so the simulation may not match the original. Use 'erst diff' to compare its stored result.\n", visualizer.Warning())
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/bundle"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// Field kinds accepted by --redact-fields.
+const (
+	redactFieldAccount  = "account"
+	redactFieldContract = "contract"
+)
+
+var redactFieldsFlag []string
+
+// validateRedactFields checks --redact-fields. Only the JSON report is
+// redacted, so every other rendering of it is rejected rather than left to
+// leak the addresses; raw XDR and raw events are rejected because their
+// addresses are not rewritten.
+func validateRedactFields() error {
+	if len(redactFieldsFlag) == 0 {
+		return nil
+	}
+	for _, f := range redactFieldsFlag {
+		if f != redactFieldAccount && f != redactFieldContract {
+			return errors.WrapValidationError(fmt.Sprintf("unsupported --redact-fields value %q (expected account or contract)", f))
+		}
+	}
+	if summaryFlag || keysOnlyFlag || compareTxFlag != "" || compareLedgerFlag != "" {
+		return errors.WrapValidationError("--redact-fields is not supported with --summary, --keys-only, --compare-tx or --compare-ledger")
+	}
+	jsonReport := reportFileFlag != "" && reportFormatForPath(reportFileFlag) == outputFormatJSON
+	if templateFileFlag != "" || outputFormatFlag == outputFormatMarkdown || (reportFileFlag != "" && !jsonReport) {
+		return errors.WrapValidationError("--redact-fields only redacts JSON; it cannot be combined with --template, --output markdown or a Markdown --report")
+	}
+	if outputFormatFlag != outputFormatJSON && !jsonReport {
+		return errors.WrapValidationError("--redact-fields only applies to JSON reports; use --output json or --report <file>.json")
+	}
+	if includeRawFlag || eventsFormatFlag != eventsFormatDecoded {
+		return errors.WrapValidationError("--redact-fields cannot be combined with --include-raw or --events raw|both, whose XDR is not redacted")
+	}
+	return nil
+}
+
+// redactFieldKinds reports which address kinds --redact-fields selected.
+func redactFieldKinds() (accounts, contracts bool) {
+	for _, f := range redactFieldsFlag {
+		switch f {
+		case redactFieldAccount:
+			accounts = true
+		case redactFieldContract:
+			contracts = true
+		}
+	}
+	return accounts, contracts
+}
+
+// redactDebugReport returns a shallow copy of r whose base64 ledger keys, in
+// the footprint, TTL and warning lists, have the selected addresses pseudonymised.
+// Addresses written out as strkeys are redacted as the JSON is written.
+func redactDebugReport(r *report.DebugReport, accounts, contracts bool) (*report.DebugReport, error) {
+	out := *r
+	var err error
+	if out.Footprint, err = redactKeys(r.Footprint, accounts, contracts); err != nil {
+		return nil, err
+	}
+	if out.TTLs, err = redactTTLs(r.TTLs, accounts, contracts); err != nil {
+		return nil, err
+	}
+	if out.CompareTTLs, err = redactTTLs(r.CompareTTLs, accounts, contracts); err != nil {
+		return nil, err
+	}
+	if len(r.Warnings) > 0 {
+		out.Warnings = make([]simulator.Warning, len(r.Warnings))
+		for i, w := range r.Warnings {
+			if w.Keys, err = redactKeys(w.Keys, accounts, contracts); err != nil {
+				return nil, err
+			}
+			out.Warnings[i] = w
+		}
+	}
+	if len(r.Comparisons) > 0 {
+		out.Comparisons = make([]report.NetworkResult, len(r.Comparisons))
+		for i, c := range r.Comparisons {
+			if c.TTLs, err = redactTTLs(c.TTLs, accounts, contracts); err != nil {
+				return nil, err
+			}
+			out.Comparisons[i] = c
+		}
+	}
+	return &out, nil
+}
+
+func redactKeys(keys []string, accounts, contracts bool) ([]string, error) {
+	if keys == nil {
+		return nil, nil
+	}
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		redacted, err := bundle.RedactLedgerKey(k, accounts, contracts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to redact ledger key %s: %w", k, err)
+		}
+		out[i] = redacted
+	}
+	return out, nil
+}
+
+func redactTTLs(ttls []rpc.EntryTTL, accounts, contracts bool) ([]rpc.EntryTTL, error) {
+	if ttls == nil {
+		return nil, nil
+	}
+	out := make([]rpc.EntryTTL, len(ttls))
+	for i, ttl := range ttls {
+		key, err := bundle.RedactLedgerKey(ttl.Key, accounts, contracts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to redact ledger key %s: %w", ttl.Key, err)
+		}
+		ttl.Key = key
+		out[i] = ttl
+	}
+	return out, nil
+}
+
+// redactingWriter pseudonymises the strkeys in everything written through
+// it. It works a line at a time: indented JSON never splits a string value
+// across lines, so no address straddles a flush.
+type redactingWriter struct {
+	w                   io.Writer
+	accounts, contracts bool
+	pending             []byte
+}
+
+func (rw *redactingWriter) Write(p []byte) (int, error) {
+	rw.pending = append(rw.pending, p...)
+	i := bytes.LastIndexByte(rw.pending, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	if _, err := rw.w.Write(bundle.RedactAddresses(rw.pending[:i+1], rw.accounts, rw.contracts)); err != nil {
+		return 0, err
+	}
+	rw.pending = append(rw.pending[:0], rw.pending[i+1:]...)
+	return len(p), nil
+}
+
+// Flush writes out a trailing partial line.
+func (rw *redactingWriter) Flush() error {
+	if len(rw.pending) == 0 {
+		return nil
+	}
+	_, err := rw.w.Write(bundle.RedactAddresses(rw.pending, rw.accounts, rw.contracts))
+	rw.pending = nil
+	return err
+}
+
+// writeRedactedJSON writes r as JSON with the --redact-fields addresses
+// pseudonymised, the same way a redacted bundle is, so one address maps to
+// one pseudonym throughout the report.
+func writeRedactedJSON(w io.Writer, r *report.DebugReport) error {
+	accounts, contracts := redactFieldKinds()
+	redacted, err := redactDebugReport(r, accounts, contracts)
+	if err != nil {
+		return err
+	}
+	rw := &redactingWriter{w: w, accounts: accounts, contracts: contracts}
+	if err := report.WriteJSON(rw, redacted); err != nil {
+		return err
+	}
+	return rw.Flush()
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setRedactFieldsFlags(t *testing.T, fields []string, format, reportFile string) {
+	t.Helper()
+	prevFields, prevFormat, prevReport, prevEvents := redactFieldsFlag, outputFormatFlag, reportFileFlag, eventsFormatFlag
+	t.Cleanup(func() {
+		redactFieldsFlag, outputFormatFlag, reportFileFlag, eventsFormatFlag = prevFields, prevFormat, prevReport, prevEvents
+	})
+	redactFieldsFlag, outputFormatFlag, reportFileFlag, eventsFormatFlag = fields, format, reportFile, eventsFormatDecoded
+}
+
+func TestValidateRedactFields(t *testing.T) {
+	setRedactFieldsFlags(t, nil, outputFormatText, "")
+	assert.NoError(t, validateRedactFields())
+
+	setRedactFieldsFlags(t, []string{"account", "contract"}, outputFormatJSON, "")
+	assert.NoError(t, validateRedactFields())
+
+	setRedactFieldsFlags(t, []string{"account"}, outputFormatText, "report.json")
+	assert.NoError(t, validateRedactFields())
+
+	setRedactFieldsFlags(t, []string{"memo"}, outputFormatJSON, "")
+	assert.Error(t, validateRedactFields())
+
+	setRedactFieldsFlags(t, []string{"account"}, outputFormatText, "")
+	assert.Error(t, validateRedactFields(), "text output is not redacted")
+
+	setRedactFieldsFlags(t, []string{"account"}, outputFormatJSON, "report.md")
+	assert.Error(t, validateRedactFields(), "a Markdown report would leak the addresses")
+
+	setRedactFieldsFlags(t, []string{"account"}, outputFormatJSON, "")
+	eventsFormatFlag = eventsFormatBoth
+	assert.Error(t, validateRedactFields())
+}
+
+func TestWriteDebugReport_RedactFields(t *testing.T) {
+	contract := strkey.MustEncode(strkey.VersionByteContract, make([]byte, 32))
+	key := accountLedgerKey(t, originalSource)
+	r := report.NewDebugReport("abc", "testnet")
+	r.SourceAccount = &decoder.Account{Address: originalSource}
+	r.Footprint = []string{key}
+	r.TTLs = []rpc.EntryTTL{{Key: key}}
+	r.Result = &simulator.SimulationResponse{
+		Status:           "success",
+		DiagnosticEvents: []simulator.DiagnosticEvent{{ContractID: &contract, Topics: []string{"transfer", originalSource}}},
+	}
+
+	setRedactFieldsFlags(t, []string{"account"}, outputFormatJSON, "")
+	var out bytes.Buffer
+	require.NoError(t, writeDebugReport(&out, r, outputFormatJSON))
+	assert.NotContains(t, out.String(), originalSource)
+	assert.NotContains(t, out.String(), key)
+	assert.Contains(t, out.String(), contract, "contracts are kept unless requested")
+	assert.Equal(t, originalSource, r.SourceAccount.Address, "the report itself is left untouched")
+	assert.Equal(t, key, r.Footprint[0])
+
+	var again bytes.Buffer
+	require.NoError(t, writeDebugReport(&again, r, outputFormatJSON))
+	assert.Equal(t, out.String(), again.String(), "pseudonyms are deterministic")
+
+	setRedactFieldsFlags(t, []string{"account", "contract"}, outputFormatJSON, "")
+	out.Reset()
+	require.NoError(t, writeDebugReport(&out, r, outputFormatJSON))
+	assert.NotContains(t, out.String(), contract)
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/bundle"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/session"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setRedactFlags(t *testing.T, save string, redact, contracts bool) {
+	t.Helper()
+	prevSave, prevRedact, prevContracts := saveBundleFlag, redactFlag, redactContractsFlag
+	t.Cleanup(func() { saveBundleFlag, redactFlag, redactContractsFlag = prevSave, prevRedact, prevContracts })
+	saveBundleFlag, redactFlag, redactContractsFlag = save, redact, contracts
+}
+
+func TestValidateRedact(t *testing.T) {
+	setRedactFlags(t, "", true, false)
+	assert.Error(t, validateRedact())
+
+	setRedactFlags(t, "", false, true)
+	assert.Error(t, validateRedact())
+
+	setRedactFlags(t, "out.zip", true, false)
+	assert.NoError(t, validateRedact())
+
+	setRedactFlags(t, "", false, false)
+	assert.NoError(t, validateRedact())
+}
+
+func TestSaveReplayBundle_RedactedIsDiffable(t *testing.T) {
+	const account = originalSource
+	path := filepath.Join(t.TempDir(), "shared.erst.tar.gz")
+	setRedactFlags(t, path, true, false)
+
+	key := accountLedgerKey(t, account)
+	res := &simulator.SimulationResponse{Status: "success", Events: []string{"paid " + account}}
+	var out bytes.Buffer
+	require.NoError(t, saveReplayBundle(&out, "abc", &rpc.TransactionResponse{EnvelopeXdr: sourceTestEnvelope(t)}, []string{key},
+		map[string]string{key: accountLedgerEntry(t, account)}, res))
+	assert.Contains(t, out.String(), "Addresses are pseudonymised")
+
+	b, err := bundle.Load(path)
+	require.NoError(t, err)
+	assert.True(t, b.Manifest.Redacted)
+	assert.NotContains(t, b.Keys, key)
+
+	src, err := loadDiffSource(context.Background(), path, func() (*session.Store, error) {
+		t.Fatal("session store opened for a bundle path")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	got, err := src.result(false, func() (simulator.RunnerInterface, error) {
+		t.Fatal("redacted bundle was simulated again")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got.Events, 1)
+	assert.NotContains(t, got.Events[0], account)
+}
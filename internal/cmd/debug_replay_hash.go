@@ -0,0 +1,42 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/bundle"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+var printReplayHashFlag bool
+
+// reusableSimulation returns the simulation result stored in a --replay
+// bundle when simulating again would reproduce it: the bundle's replay hash
+// matches the inputs about to be simulated, it was saved by this erst
+// version, and nothing that changes the simulation but is not hashed
+// (timestamps, mock time, protocol version, fee mocks) was given. Redacted
+// bundles are never reused, since their stored result is of the unredacted
+// inputs.
+func reusableSimulation(replay *bundle.Bundle, replayHash string, timestamps int) *simulator.SimulationResponse {
+	if replay == nil || replay.Simulation == nil || replay.Manifest.Redacted {
+		return nil
+	}
+	m := replay.Manifest
+	if m.ReplayHash == "" || m.ReplayHash != replayHash || m.ErstVersion != Version {
+		return nil
+	}
+	if timestamps > 1 || TimestampFlag != 0 || mockTimeFlag != 0 || protocolVersionFlag != 0 || mockBaseFeeFlag > 0 || mockGasPriceFlag > 0 {
+		return nil
+	}
+	return replay.Simulation
+}
+
+// printReplayHash prints the run's replay hash when --print-replay-hash is set.
+func printReplayHash(out io.Writer, replayHash string) {
+	if printReplayHashFlag {
+		fmt.Fprintf(out, "Replay hash: %s\n", replayHash)
+	}
+}
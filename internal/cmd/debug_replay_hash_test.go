@@ -0,0 +1,62 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/bundle"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+)
+
+func replayHashBundle() *bundle.Bundle {
+	b := bundle.New("abc", "testnet", Version, &rpc.TransactionResponse{EnvelopeXdr: "AAAAenv=", ResultMetaXdr: "AAAAmeta"},
+		[]string{"AAAAAQ=="}, map[string]string{"AAAAAQ==": "AAAAAw=="})
+	b.Simulation = &simulator.SimulationResponse{Status: "success"}
+	return b
+}
+
+func TestReusableSimulation(t *testing.T) {
+	b := replayHashBundle()
+	hash := b.Manifest.ReplayHash
+
+	assert.Same(t, b.Simulation, reusableSimulation(b, hash, 1))
+	assert.Nil(t, reusableSimulation(nil, hash, 1))
+	assert.Nil(t, reusableSimulation(b, "other", 1), "inputs changed")
+	assert.Nil(t, reusableSimulation(b, hash, 5), "several timestamps")
+
+	prev := protocolVersionFlag
+	protocolVersionFlag = 22
+	assert.Nil(t, reusableSimulation(b, hash, 1), "protocol override")
+	protocolVersionFlag = prev
+
+	older := replayHashBundle()
+	older.Manifest.ErstVersion = "v0.0.1"
+	assert.Nil(t, reusableSimulation(older, older.Manifest.ReplayHash, 1), "another erst version")
+
+	redacted := replayHashBundle()
+	redacted.Manifest.Redacted = true
+	assert.Nil(t, reusableSimulation(redacted, redacted.Manifest.ReplayHash, 1))
+
+	noSim := replayHashBundle()
+	noSim.Simulation = nil
+	assert.Nil(t, reusableSimulation(noSim, noSim.Manifest.ReplayHash, 1))
+}
+
+func TestPrintReplayHash(t *testing.T) {
+	prev := printReplayHashFlag
+	t.Cleanup(func() { printReplayHashFlag = prev })
+
+	var out bytes.Buffer
+	printReplayHashFlag = false
+	printReplayHash(&out, "9f2c")
+	assert.Empty(t, out.String())
+
+	printReplayHashFlag = true
+	printReplayHash(&out, "9f2c")
+	assert.Equal(t, "Replay hash: 9f2c\n", out.String())
+}
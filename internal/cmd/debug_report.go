@@ -0,0 +1,339 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/spf13/cobra"
+)
+
+// Output formats accepted by the debug command's --output flag.
+const (
+	outputFormatText     = "text"
+	outputFormatJSON     = "json"
+	outputFormatMarkdown = "markdown"
+	// outputFormatLine condenses each run to one logfmt line.
+	outputFormatLine = "line"
+)
+
+func validateOutputFormat(format string) error {
+	switch format {
+	case outputFormatText, outputFormatJSON, outputFormatMarkdown, outputFormatLine:
+		return nil
+	default:
+		return errors.WrapValidationError(fmt.Sprintf("unsupported output format %q (expected text, json, markdown, or line)", format))
+	}
+}
+
+// Event representations accepted by the debug command's --events flag.
+const (
+	eventsFormatRaw     = "raw"
+	eventsFormatDecoded = "decoded"
+	eventsFormatBoth    = "both"
+)
+
+func validateEventsFormat(format string) error {
+	switch format {
+	case eventsFormatRaw, eventsFormatDecoded, eventsFormatBoth:
+		return nil
+	default:
+		return errors.WrapValidationError(fmt.Sprintf("unsupported events format %q (expected raw, decoded, or both)", format))
+	}
+}
+
+// validateReportModes rejects flags that consume a DebugReport (structured
+// output, --report and --check) for --wasm and --demo runs, which never
+// produce one.
+func validateReportModes() error {
+	if outputFormatFlag != outputFormatText || reportFileFlag != "" || templateFileFlag != "" || outputFileFlag != "" {
+		return errors.WrapValidationError("--output json|markdown|line, --template, --output-file and --report require a transaction hash; they are not supported with --wasm or --demo")
+	}
+	if len(checkRuleFiles) > 0 {
+		return errors.WrapValidationError("--check requires a transaction hash; it is not supported with --wasm or --demo")
+	}
+	return nil
+}
+
+// validateIncludeRaw checks that --include-raw has a JSON report to go into;
+// Markdown has nowhere to put base64 blobs.
+func validateIncludeRaw() error {
+	if !includeRawFlag {
+		return nil
+	}
+	if summaryFlag {
+		return errors.WrapValidationError("--include-raw is not supported with --summary")
+	}
+	if outputFormatFlag != outputFormatJSON && (reportFileFlag == "" || reportFormatForPath(reportFileFlag) != outputFormatJSON) {
+		return errors.WrapValidationError("--include-raw only applies to JSON reports; use --output json or --report <file>.json")
+	}
+	return nil
+}
+
+// validateTemplateOutput checks --template, which takes the place of
+// --output, and --output-file, which needs a rendering to redirect. The
+// template is parsed up front so a typo fails before any network work.
+func validateTemplateOutput() error {
+	if templateFileFlag != "" {
+		if outputFormatFlag != "" && outputFormatFlag != outputFormatText {
+			return errors.WrapValidationError("--template replaces --output; use one or the other")
+		}
+		if summaryFlag || compareTxFlag != "" || compareLedgerFlag != "" {
+			return errors.WrapValidationError("--template is not supported with --summary, --compare-tx or --compare-ledger")
+		}
+		if _, err := report.LoadTemplateRenderer(templateFileFlag); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("--template %s: %v", templateFileFlag, err))
+		}
+	}
+	if outputFileFlag != "" {
+		if !structuredOutput() {
+			return errors.WrapValidationError("--output-file requires --output json|markdown|line or --template")
+		}
+		if summaryFlag || compareTxFlag != "" || compareLedgerFlag != "" {
+			return errors.WrapValidationError("--output-file is not supported with --summary, --compare-tx or --compare-ledger")
+		}
+	}
+	return nil
+}
+
+// structuredOutput reports whether the run renders the report as JSON,
+// Markdown, a logfmt line or a template rather than printing the text output.
+func structuredOutput() bool {
+	return (outputFormatFlag != "" && outputFormatFlag != outputFormatText) || templateFileFlag != ""
+}
+
+// progressWriter returns where human-readable progress is printed: stdout in
+// text mode, stderr when --output or --template reserves stdout for the
+// structured report.
+func progressWriter(cmd *cobra.Command) io.Writer {
+	if structuredOutput() && outputFileFlag == "" {
+		return cmd.ErrOrStderr()
+	}
+	return cmd.OutOrStdout()
+}
+
+// attachComparisons records the compare-network results on r. A single
+// compare network fills the two-way Compare* fields; more also fill
+// Comparisons and group every network by outcome.
+func attachComparisons(r *report.DebugReport, networks []string, results []*simulator.SimulationResponse, ttls [][]rpc.EntryTTL) {
+	if len(results) == 0 || r.Result == nil {
+		return
+	}
+	r.CompareNetwork = networks[0]
+	r.CompareResult = results[0]
+	r.CompareTTLs = ttls[0]
+	r.CompareMode = compare.Mode(compareModeFlag)
+	r.Diff = compare.DiffWithMode(r.Result, results[0], r.CompareMode)
+	if len(results) == 1 {
+		return
+	}
+
+	for i, res := range results {
+		r.Comparisons = append(r.Comparisons, report.NetworkResult{Network: networks[i], Result: res, TTLs: ttls[i]})
+	}
+	r.OutcomeGroups = compare.GroupOutcomes(namedResults(r), r.CompareMode)
+}
+
+// comparisonDiverged reports whether any compare network's result differs
+// from the primary one.
+func comparisonDiverged(r *report.DebugReport) bool {
+	if len(r.OutcomeGroups) > 0 {
+		return len(r.OutcomeGroups) > 1
+	}
+	return r.Diff != nil && r.Diff.HasDivergence
+}
+
+// namedResults lists the primary and every N-way comparison result of r.
+func namedResults(r *report.DebugReport) []compare.NamedResult {
+	named := []compare.NamedResult{{Network: r.Network, Result: r.Result}}
+	for _, c := range r.Comparisons {
+		named = append(named, compare.NamedResult{Network: c.Network, Result: c.Result})
+	}
+	return named
+}
+
+// filterDebugReport returns a shallow copy of r whose simulation results only
+// contain events matching f, with the cross-network diff recomputed from the
+// filtered results. r itself is left untouched.
+func filterDebugReport(r *report.DebugReport, f simulator.EventFilter) *report.DebugReport {
+	if f.IsEmpty() {
+		return r
+	}
+	filtered := *r
+	filtered.Result = f.Apply(r.Result)
+	if r.CompareResult != nil {
+		filtered.CompareResult = f.Apply(r.CompareResult)
+		filtered.Diff = compare.DiffWithMode(filtered.Result, filtered.CompareResult, r.CompareMode)
+	}
+	if len(r.Comparisons) > 0 {
+		filtered.Comparisons = make([]report.NetworkResult, len(r.Comparisons))
+		for i, c := range r.Comparisons {
+			c.Result = f.Apply(c.Result)
+			filtered.Comparisons[i] = c
+		}
+		filtered.OutcomeGroups = compare.GroupOutcomes(namedResults(&filtered), r.CompareMode)
+	}
+	return &filtered
+}
+
+// applyEventsFormat returns a shallow copy of r that carries events only in
+// the requested representation: raw strips decoded events, decoded strips raw
+// events, and both keeps the results intact and adds raw/decoded pairs.
+func applyEventsFormat(r *report.DebugReport, format string) *report.DebugReport {
+	out := *r
+	if len(r.Comparisons) > 0 {
+		out.Comparisons = make([]report.NetworkResult, len(r.Comparisons))
+		copy(out.Comparisons, r.Comparisons)
+	}
+	switch format {
+	case eventsFormatBoth:
+		out.Events = report.PairEvents(r.Result)
+		out.CompareEvents = report.PairEvents(r.CompareResult)
+		for i := range out.Comparisons {
+			out.Comparisons[i].Events = report.PairEvents(out.Comparisons[i].Result)
+		}
+	case eventsFormatRaw:
+		out.Result = withoutDecodedEvents(r.Result)
+		out.CompareResult = withoutDecodedEvents(r.CompareResult)
+		for i := range out.Comparisons {
+			out.Comparisons[i].Result = withoutDecodedEvents(out.Comparisons[i].Result)
+		}
+	default:
+		out.Result = withoutRawEvents(r.Result)
+		out.CompareResult = withoutRawEvents(r.CompareResult)
+		for i := range out.Comparisons {
+			out.Comparisons[i].Result = withoutRawEvents(out.Comparisons[i].Result)
+		}
+	}
+	return &out
+}
+
+func withoutDecodedEvents(res *simulator.SimulationResponse) *simulator.SimulationResponse {
+	if res == nil {
+		return nil
+	}
+	stripped := *res
+	stripped.DiagnosticEvents = nil
+	stripped.CategorizedEvents = nil
+	return &stripped
+}
+
+func withoutRawEvents(res *simulator.SimulationResponse) *simulator.SimulationResponse {
+	if res == nil {
+		return nil
+	}
+	stripped := *res
+	stripped.Events = nil
+	return &stripped
+}
+
+// writeDebugReport serializes a DebugReport in the requested format. JSON is
+// streamed to w so large footprints and event lists are never buffered whole.
+func writeDebugReport(w io.Writer, r *report.DebugReport, format string) error {
+	switch format {
+	case outputFormatJSON:
+		if len(redactFieldsFlag) > 0 {
+			if err := writeRedactedJSON(w, r); err != nil {
+				return errors.WrapMarshalFailed(err)
+			}
+			return nil
+		}
+		if err := report.WriteJSON(w, r); err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+		return nil
+	case outputFormatMarkdown:
+		data, err := (&report.MarkdownRenderer{EventDataLimit: eventDataLimit()}).Render(r)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case outputFormatLine:
+		return report.WriteLine(w, r)
+	default:
+		return errors.WrapValidationError(fmt.Sprintf("cannot render report as %q", format))
+	}
+}
+
+// reportFormatForPath infers the report format from a file extension,
+// defaulting to Markdown.
+func reportFormatForPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return outputFormatJSON
+	}
+	return outputFormatMarkdown
+}
+
+// emitDebugReport writes the report to stdout, or the --output-file, for
+// --output json/markdown and --template, and to the --report file when one
+// was requested. Status lines go to progress.
+func emitDebugReport(stdout, progress io.Writer, r *report.DebugReport) error {
+	if structuredOutput() {
+		if outputFileFlag == "" {
+			if err := writeStructuredOutput(stdout, r); err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("failed to write report: %v", err))
+			}
+		} else {
+			if err := writeFile(outputFileFlag, func(w io.Writer) error { return writeStructuredOutput(w, r) }); err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("failed to write output file: %v", err))
+			}
+			fmt.Fprintf(progress, "%s Output written: %s\n", visualizer.Success(), outputFileFlag)
+		}
+	}
+
+	if reportFileFlag != "" {
+		err := writeFile(reportFileFlag, func(w io.Writer) error {
+			return writeDebugReport(w, r, reportFormatForPath(reportFileFlag))
+		})
+		if err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("failed to write report file: %v", err))
+		}
+		fmt.Fprintf(progress, "%s Report written: %s\n", visualizer.Success(), reportFileFlag)
+	}
+
+	return nil
+}
+
+// writeStructuredOutput renders r through the --template when one is set,
+// and as the --output format otherwise.
+func writeStructuredOutput(w io.Writer, r *report.DebugReport) error {
+	if templateFileFlag == "" {
+		return writeDebugReport(w, r, outputFormatFlag)
+	}
+	renderer, err := report.LoadTemplateRenderer(templateFileFlag)
+	if err != nil {
+		return err
+	}
+	data, err := renderer.Render(r)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// writeFile creates path and writes it with write, reporting the first of
+// the write and close errors.
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = write(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,343 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressWriter(t *testing.T) {
+	prev := outputFormatFlag
+	t.Cleanup(func() { outputFormatFlag = prev })
+
+	var stdout, stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+
+	outputFormatFlag = outputFormatText
+	assert.Same(t, &stdout, progressWriter(cmd))
+
+	outputFormatFlag = outputFormatJSON
+	assert.Same(t, &stderr, progressWriter(cmd))
+}
+
+func TestValidateReportModes(t *testing.T) {
+	prevFormat, prevReport := outputFormatFlag, reportFileFlag
+	t.Cleanup(func() {
+		outputFormatFlag = prevFormat
+		reportFileFlag = prevReport
+	})
+
+	outputFormatFlag, reportFileFlag = outputFormatText, ""
+	assert.NoError(t, validateReportModes())
+
+	outputFormatFlag = outputFormatMarkdown
+	assert.Error(t, validateReportModes())
+
+	outputFormatFlag, reportFileFlag = outputFormatText, "report.md"
+	assert.Error(t, validateReportModes())
+
+	prevChecks := checkRuleFiles
+	t.Cleanup(func() { checkRuleFiles = prevChecks })
+	outputFormatFlag, reportFileFlag, checkRuleFiles = outputFormatText, "", []string{"rules.yaml"}
+	assert.Error(t, validateReportModes())
+}
+
+func TestValidateIncludeRaw(t *testing.T) {
+	prevRaw, prevFormat, prevReport, prevSummary := includeRawFlag, outputFormatFlag, reportFileFlag, summaryFlag
+	t.Cleanup(func() {
+		includeRawFlag, outputFormatFlag, reportFileFlag, summaryFlag = prevRaw, prevFormat, prevReport, prevSummary
+	})
+
+	includeRawFlag, outputFormatFlag, reportFileFlag, summaryFlag = false, outputFormatText, "", false
+	assert.NoError(t, validateIncludeRaw())
+
+	includeRawFlag = true
+	assert.Error(t, validateIncludeRaw(), "text output has no JSON report")
+
+	reportFileFlag = "report.md"
+	assert.Error(t, validateIncludeRaw())
+
+	reportFileFlag = "report.json"
+	assert.NoError(t, validateIncludeRaw())
+
+	outputFormatFlag, reportFileFlag = outputFormatJSON, ""
+	assert.NoError(t, validateIncludeRaw())
+
+	summaryFlag = true
+	assert.Error(t, validateIncludeRaw())
+}
+
+func TestValidateTemplateOutput(t *testing.T) {
+	prevTemplate, prevOutputFile, prevFormat, prevSummary := templateFileFlag, outputFileFlag, outputFormatFlag, summaryFlag
+	t.Cleanup(func() {
+		templateFileFlag, outputFileFlag, outputFormatFlag, summaryFlag = prevTemplate, prevOutputFile, prevFormat, prevSummary
+	})
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.tmpl")
+	bad := filepath.Join(dir, "bad.tmpl")
+	assert.NoError(t, os.WriteFile(good, []byte("{{.TxHash}}"), 0o600))
+	assert.NoError(t, os.WriteFile(bad, []byte("{{.TxHash"), 0o600))
+
+	templateFileFlag, outputFileFlag, outputFormatFlag, summaryFlag = "", "", outputFormatText, false
+	assert.NoError(t, validateTemplateOutput())
+
+	templateFileFlag = good
+	assert.NoError(t, validateTemplateOutput())
+
+	templateFileFlag = bad
+	assert.Error(t, validateTemplateOutput(), "the template is parsed up front")
+
+	templateFileFlag, outputFormatFlag = good, outputFormatJSON
+	assert.Error(t, validateTemplateOutput(), "--template replaces --output")
+
+	templateFileFlag, outputFileFlag = "", "out.json"
+	assert.NoError(t, validateTemplateOutput())
+
+	outputFormatFlag = outputFormatText
+	assert.Error(t, validateTemplateOutput(), "--output-file needs a rendering")
+
+	templateFileFlag, summaryFlag = good, true
+	assert.Error(t, validateTemplateOutput())
+}
+
+func TestEmitDebugReport_TemplateToOutputFile(t *testing.T) {
+	prevTemplate, prevOutputFile, prevFormat, prevReport := templateFileFlag, outputFileFlag, outputFormatFlag, reportFileFlag
+	t.Cleanup(func() {
+		templateFileFlag, outputFileFlag, outputFormatFlag, reportFileFlag = prevTemplate, prevOutputFile, prevFormat, prevReport
+	})
+	dir := t.TempDir()
+	templateFileFlag = filepath.Join(dir, "summary.tmpl")
+	assert.NoError(t, os.WriteFile(templateFileFlag, []byte("{{.TxHash}} {{.Status}}\n"), 0o600))
+	outputFormatFlag, reportFileFlag = outputFormatText, ""
+
+	r := report.NewDebugReport("abc", "testnet")
+	r.Result = &simulator.SimulationResponse{Status: "success"}
+
+	var stdout, progress bytes.Buffer
+	outputFileFlag = ""
+	assert.NoError(t, emitDebugReport(&stdout, &progress, r))
+	assert.Equal(t, "abc success\n", stdout.String())
+
+	stdout.Reset()
+	outputFileFlag = filepath.Join(dir, "out.txt")
+	assert.NoError(t, emitDebugReport(&stdout, &progress, r))
+	assert.Empty(t, stdout.String())
+	written, err := os.ReadFile(outputFileFlag)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc success\n", string(written))
+	assert.Contains(t, progress.String(), "Output written: "+outputFileFlag)
+}
+
+func TestWriteDebugReport_Raw(t *testing.T) {
+	r := report.NewDebugReport("abc", "testnet")
+	var out bytes.Buffer
+	assert.NoError(t, writeDebugReport(&out, r, outputFormatJSON))
+	assert.NotContains(t, out.String(), `"raw"`, "raw XDR is left out by default")
+
+	r.Raw = &report.RawTransaction{
+		EnvelopeXdr:   "AAAAenv",
+		ResultMetaXdr: "AAAAmeta",
+		Keys:          []string{"AAAAkey"},
+		LedgerEntries: map[string]string{"AAAAkey": "AAAAentry"},
+	}
+	out.Reset()
+	assert.NoError(t, writeDebugReport(&out, r, outputFormatJSON))
+
+	var decoded struct {
+		Raw report.RawTransaction `json:"raw"`
+	}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, *r.Raw, decoded.Raw)
+}
+
+func TestFilterDebugReport_LeavesOriginalUnfiltered(t *testing.T) {
+	a, b := "CAAA", "CBBB"
+	r := report.NewDebugReport("abc", "testnet")
+	r.Result = &simulator.SimulationResponse{
+		DiagnosticEvents: []simulator.DiagnosticEvent{
+			{EventType: "contract", ContractID: &a},
+			{EventType: "contract", ContractID: &b},
+		},
+	}
+
+	filtered := filterDebugReport(r, simulator.EventFilter{ContractIDs: []string{"CAAA"}})
+
+	assert.Len(t, filtered.Result.DiagnosticEvents, 1)
+	assert.Len(t, r.Result.DiagnosticEvents, 2)
+	assert.Same(t, r, filterDebugReport(r, simulator.EventFilter{}))
+}
+
+func TestApplyEventsFormat(t *testing.T) {
+	r := report.NewDebugReport("abc", "testnet")
+	r.Result = &simulator.SimulationResponse{
+		Events:           []string{"raw-0", "raw-1"},
+		DiagnosticEvents: []simulator.DiagnosticEvent{{EventType: "contract"}, {EventType: "system"}},
+	}
+
+	decoded := applyEventsFormat(r, eventsFormatDecoded)
+	assert.Empty(t, decoded.Result.Events)
+	assert.Len(t, decoded.Result.DiagnosticEvents, 2)
+	assert.Empty(t, decoded.Events)
+
+	raw := applyEventsFormat(r, eventsFormatRaw)
+	assert.Len(t, raw.Result.Events, 2)
+	assert.Empty(t, raw.Result.DiagnosticEvents)
+
+	both := applyEventsFormat(r, eventsFormatBoth)
+	if assert.Len(t, both.Events, 2) {
+		assert.Equal(t, "raw-1", both.Events[1].Raw)
+		assert.Equal(t, "system", both.Events[1].Decoded.EventType)
+	}
+
+	// The original report is never modified.
+	assert.Len(t, r.Result.Events, 2)
+	assert.Len(t, r.Result.DiagnosticEvents, 2)
+}
+
+func TestValidateEventsFormat(t *testing.T) {
+	for _, f := range []string{eventsFormatRaw, eventsFormatDecoded, eventsFormatBoth} {
+		assert.NoError(t, validateEventsFormat(f))
+	}
+	assert.Error(t, validateEventsFormat("xdr"))
+}
+
+func TestAttachComparisons(t *testing.T) {
+	success := &simulator.SimulationResponse{Status: "success"}
+	failure := &simulator.SimulationResponse{Status: "error", Error: "trapped"}
+
+	twoWay := report.NewDebugReport("abc", "mainnet")
+	twoWay.Result = success
+	attachComparisons(twoWay, []string{"testnet"}, []*simulator.SimulationResponse{failure}, [][]rpc.EntryTTL{nil})
+	assert.Equal(t, "testnet", twoWay.CompareNetwork)
+	assert.NotNil(t, twoWay.Diff)
+	assert.Empty(t, twoWay.Comparisons)
+	assert.Empty(t, twoWay.OutcomeGroups)
+
+	nWay := report.NewDebugReport("abc", "mainnet")
+	nWay.Result = success
+	attachComparisons(nWay, []string{"testnet", "futurenet"},
+		[]*simulator.SimulationResponse{success, failure}, [][]rpc.EntryTTL{nil, nil})
+	assert.Len(t, nWay.Comparisons, 2)
+	if assert.Len(t, nWay.OutcomeGroups, 2) {
+		assert.Equal(t, []string{"mainnet", "testnet"}, nWay.OutcomeGroups[0].Networks)
+		assert.Equal(t, []string{"futurenet"}, nWay.OutcomeGroups[1].Networks)
+	}
+}
+
+func TestComparisonDiverged(t *testing.T) {
+	success := &simulator.SimulationResponse{Status: "success"}
+	failure := &simulator.SimulationResponse{Status: "error", Error: "trapped"}
+	attach := func(results ...*simulator.SimulationResponse) *report.DebugReport {
+		r := report.NewDebugReport("abc", "mainnet")
+		r.Result = success
+		networks := []string{"testnet", "futurenet"}[:len(results)]
+		attachComparisons(r, networks, results, make([][]rpc.EntryTTL, len(results)))
+		return r
+	}
+
+	assert.False(t, comparisonDiverged(report.NewDebugReport("abc", "mainnet")))
+	assert.False(t, comparisonDiverged(attach(success)))
+	assert.True(t, comparisonDiverged(attach(failure)))
+	assert.False(t, comparisonDiverged(attach(success, success)))
+	assert.True(t, comparisonDiverged(attach(success, failure)))
+}
+
+func TestDiffOutcomes_HighlightsDivergentNetwork(t *testing.T) {
+	success := &simulator.SimulationResponse{Status: "success"}
+	failure := &simulator.SimulationResponse{Status: "error"}
+
+	var buf bytes.Buffer
+	diffOutcomes(&buf, nil, []compare.NamedResult{
+		{Network: "mainnet", Result: success},
+		{Network: "testnet", Result: success},
+		{Network: "futurenet", Result: failure},
+	}, nil, nil)
+
+	out := buf.String()
+	assert.Contains(t, out, "mainnet vs testnet vs futurenet")
+	assert.Contains(t, out, "futurenet (error) ")
+	assert.Contains(t, out, "DIVERGENT")
+	assert.Contains(t, out, "Status Mismatch")
+}
+
+func TestDiffOutcomes_FlagsContractWasmDifference(t *testing.T) {
+	success := &simulator.SimulationResponse{Status: "success"}
+	wasms := networkWasms{
+		"mainnet": {"CA": {Contract: "CA", Hash: "aa", Size: 120}, "CB": {Contract: "CB", Hash: "bb"}},
+		"testnet": {"CA": {Contract: "CA", Hash: "cc", Size: 98}, "CB": {Contract: "CB", Hash: "bb"}},
+	}
+
+	var buf bytes.Buffer
+	diffOutcomes(&buf, nil, []compare.NamedResult{
+		{Network: "mainnet", Result: success},
+		{Network: "testnet", Result: success},
+	}, wasms, nil)
+
+	out := buf.String()
+	assert.Contains(t, out, "Contract WASM differs for CA")
+	assert.Contains(t, out, "mainnet: aa (120 bytes)")
+	assert.Contains(t, out, "testnet: cc (98 bytes)")
+	assert.NotContains(t, out, "differs for CB")
+}
+
+func TestDiffOutcomes_WarnsOnProtocolMismatch(t *testing.T) {
+	success := &simulator.SimulationResponse{Status: "success"}
+	results := []compare.NamedResult{
+		{Network: "testnet", Result: success},
+		{Network: "futurenet", Result: success},
+	}
+
+	var buf bytes.Buffer
+	diffOutcomes(&buf, nil, results, nil, networkProtocols{"testnet": 22, "futurenet": 23})
+	out := buf.String()
+	assert.Contains(t, out, "PROTOCOL MISMATCH")
+	assert.Contains(t, out, "=== Comparison: testnet (protocol 22) vs futurenet (protocol 23) ===")
+
+	buf.Reset()
+	warns := &debugWarnings{}
+	diffOutcomes(&buf, warns, results, nil, networkProtocols{"testnet": 22, "futurenet": 23})
+	assert.NotContains(t, buf.String(), "PROTOCOL MISMATCH", "a collected warning is printed in the Warnings section")
+	require.Len(t, warns.list, 1)
+	assert.Equal(t, simulator.WarningProtocolMismatch, warns.list[0].Code)
+
+	buf.Reset()
+	diffOutcomes(&buf, nil, results, nil, networkProtocols{"testnet": 22, "futurenet": 22})
+	assert.NotContains(t, buf.String(), "PROTOCOL MISMATCH")
+	assert.Contains(t, buf.String(), "testnet (protocol 22) vs futurenet (protocol 22)")
+
+	buf.Reset()
+	diffOutcomes(&buf, nil, results, nil, networkProtocols{"testnet": 22})
+	assert.NotContains(t, buf.String(), "PROTOCOL MISMATCH", "an unknown version is not a mismatch")
+}
+
+func TestPrintEventList_AbbreviatesLargeData(t *testing.T) {
+	big := strings.Repeat("ab", 300)
+	events := []simulator.DiagnosticEvent{{EventType: "contract", Topics: []string{"blob"}, Data: big}}
+
+	var buf bytes.Buffer
+	printEventList(&buf, "Contract Events", events)
+	assert.NotContains(t, buf.String(), big)
+	assert.Contains(t, buf.String(), "Data: <600 bytes, sha256:")
+
+	fullEventsFlag = true
+	t.Cleanup(func() { fullEventsFlag = false })
+	buf.Reset()
+	printEventList(&buf, "Contract Events", events)
+	assert.Contains(t, buf.String(), "Data: "+big)
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// networkRequestIDs tags the log records of each network's fetches and
+// simulation with its own request ID, so the interleaved records of
+// concurrent --compare-network runs can be told apart. Index 0 is the
+// primary network.
+type networkRequestIDs struct {
+	networks []string
+	ids      []string
+}
+
+func newNetworkRequestIDs(primary string, compare []string) *networkRequestIDs {
+	r := &networkRequestIDs{networks: append([]string{primary}, compare...)}
+	for range r.networks {
+		r.ids = append(r.ids, logger.NewRequestID())
+	}
+	return r
+}
+
+// primary returns ctx tagged with the primary network's request ID.
+func (r *networkRequestIDs) primary(ctx context.Context) context.Context {
+	return logger.WithRequestID(ctx, r.ids[0])
+}
+
+// compare returns ctx tagged with the request ID of the i-th compare
+// network.
+func (r *networkRequestIDs) compare(ctx context.Context, i int) context.Context {
+	return logger.WithRequestID(ctx, r.ids[i+1])
+}
+
+// printLegend maps each request ID to its network. Batched fetches log
+// under "<id>.<n>".
+func (r *networkRequestIDs) printLegend(out io.Writer) {
+	parts := make([]string, len(r.ids))
+	for i, id := range r.ids {
+		parts[i] = fmt.Sprintf("%s = %s", id, r.networks[i])
+		if i == 0 {
+			parts[i] += " (primary)"
+		}
+	}
+	fmt.Fprintf(out, "Log request IDs: %s\n", strings.Join(parts, ", "))
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkRequestIDs(t *testing.T) {
+	ids := newNetworkRequestIDs("testnet", []string{"mainnet", "futurenet"})
+
+	primary := logger.RequestID(ids.primary(context.Background()))
+	first := logger.RequestID(ids.compare(context.Background(), 0))
+	second := logger.RequestID(ids.compare(context.Background(), 1))
+	assert.NotEmpty(t, primary)
+	assert.NotEqual(t, primary, first)
+	assert.NotEqual(t, first, second)
+
+	var out bytes.Buffer
+	ids.printLegend(&out)
+	assert.Equal(t, "Log request IDs: "+primary+" = testnet (primary), "+first+" = mainnet, "+second+" = futurenet\n", out.String())
+}
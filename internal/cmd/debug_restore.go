@@ -0,0 +1,170 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var autoRestoreFlag bool
+
+// validateAutoRestore checks --auto-restore, which asks the primary
+// network's Soroban RPC about archived state, so it needs a transaction
+// fetched from the network and a single simulation to feed.
+func validateAutoRestore() error {
+	if !autoRestoreFlag {
+		return nil
+	}
+	if summaryFlag || demoMode || wasmPath != "" || replayBundleFlag != "" || snapshotFlag != "" || entriesFileFlag != "" ||
+		len(compareNetworksFlag) > 0 || compareTxFlag != "" || compareLedgerFlag != "" || keysOnlyFlag {
+		return errors.WrapValidationError("--auto-restore cannot be combined with --summary, --demo, --wasm, --replay, --snapshot, --entries-file, --compare-network, --compare-tx, --compare-ledger or --keys-only")
+	}
+	return nil
+}
+
+// simulateRestore asks Soroban RPC whether the transaction's footprint has
+// archived entries. When it does, the restore transaction the RPC's restore
+// preamble calls for is synthesized and simulated, and the state of the
+// restored entries is fetched so the invoke can run as it would after the
+// restore lands. It returns nil when nothing needs restoring or the RPC
+// could not say.
+func simulateRestore(ctx context.Context, out io.Writer, client *rpc.Client, envelopeXdr string) (*report.RestoreStep, map[string]string) {
+	preflight, err := client.SimulateTransaction(ctx, envelopeXdr)
+	if err != nil {
+		fmt.Fprintf(out, "%s --auto-restore: could not preflight the transaction: %v\n", visualizer.Warning(), err)
+		return nil, nil
+	}
+	preamble := preflight.Result.RestorePreamble
+	if preamble == nil {
+		fmt.Fprintf(out, "--auto-restore: no archived entries in the footprint; nothing to restore\n")
+		return nil, nil
+	}
+
+	fmt.Fprintf(out, "\n=== Restore (archived entries) ===\n")
+	step, restoreXdr, err := newRestoreStep(envelopeXdr, preamble.TransactionData)
+	if err != nil {
+		fmt.Fprintf(out, "%s Could not build the restore transaction: %v\n", visualizer.Warning(), err)
+		return nil, nil
+	}
+	if fee, err := strconv.ParseInt(preamble.MinResourceFee, 10, 64); err == nil {
+		step.ResourceFee = fee
+	}
+
+	// The RPC's own estimate of the restore, confirmed by simulating it
+	if sim, err := client.SimulateTransaction(ctx, restoreXdr); err != nil {
+		step.Error = err.Error()
+	} else if sim.Result.Error != "" {
+		step.Error = sim.Result.Error
+	} else {
+		if fee, err := strconv.ParseInt(sim.Result.MinResourceFee, 10, 64); err == nil {
+			step.ResourceFee = fee
+		}
+		step.CPUInstructions = max(sim.Result.Cost.CpuInsns, sim.Result.Cost.CpuInsns_)
+		step.MemoryBytes = max(sim.Result.Cost.MemBytes, sim.Result.Cost.MemBytes_)
+		if res, err := decoder.DecodeSorobanTransactionData(sim.Result.TransactionData); err == nil {
+			step.Resources = res
+		}
+	}
+
+	restored, err := client.GetLedgerEntries(ctx, step.Keys)
+	if err != nil {
+		restored = nil
+		fmt.Fprintf(out, "%s The RPC did not serve the archived entries' state (%v); the invoke runs without them\n", visualizer.Warning(), err)
+	}
+	step.Recovered = len(restored)
+	printRestoreStep(out, step)
+	return step, restored
+}
+
+// newRestoreStep synthesizes the RestoreFootprint transaction for the
+// archived entries in dataXdr, the restore preamble's transaction data, sent
+// by the source account of envelopeXdr. It returns the step, with the keys to
+// restore, and the restore envelope as base64 XDR.
+func newRestoreStep(envelopeXdr, dataXdr string) (*report.RestoreStep, string, error) {
+	var env xdr.TransactionEnvelope
+	if err := decoder.UnmarshalBase64RoundTrip(envelopeXdr, &env, "transaction envelope"); err != nil {
+		return nil, "", err
+	}
+	var data xdr.SorobanTransactionData
+	if err := decoder.UnmarshalBase64RoundTrip(dataXdr, &data, "restore preamble transaction data"); err != nil {
+		return nil, "", err
+	}
+
+	step := &report.RestoreStep{Keys: []string{}}
+	for _, k := range data.Resources.Footprint.ReadWrite {
+		b64, err := xdr.MarshalBase64(k)
+		if err != nil {
+			return nil, "", err
+		}
+		step.Keys = append(step.Keys, b64)
+	}
+
+	restore := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: env.SourceAccount(),
+				Fee:           xdr.Uint32(minInclusionFee + int64(data.ResourceFee)),
+				SeqNum:        xdr.SequenceNumber(env.SeqNum()),
+				Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+				Operations: []xdr.Operation{{
+					Body: xdr.OperationBody{
+						Type:               xdr.OperationTypeRestoreFootprint,
+						RestoreFootprintOp: &xdr.RestoreFootprintOp{},
+					},
+				}},
+				Ext: xdr.TransactionExt{V: 1, SorobanData: &data},
+			},
+		},
+	}
+	restoreXdr, err := xdr.MarshalBase64(restore)
+	if err != nil {
+		return nil, "", err
+	}
+	return step, restoreXdr, nil
+}
+
+func printRestoreStep(out io.Writer, step *report.RestoreStep) {
+	fmt.Fprintf(out, "Archived entries: %d\n", len(step.Keys))
+	for _, k := range step.Keys {
+		fmt.Fprintf(out, "  %s\n", k)
+	}
+	if step.Error != "" {
+		fmt.Fprintf(out, "%s The restore failed to simulate: %s\n", visualizer.Error(), step.Error)
+	} else {
+		fmt.Fprintf(out, "%s Restore simulated: resource fee %d stroops", visualizer.Success(), step.ResourceFee)
+		if step.CPUInstructions > 0 {
+			fmt.Fprintf(out, ", %d CPU instructions, %d memory bytes", step.CPUInstructions, step.MemoryBytes)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "Restored state recovered for %d of %d entries; the invoke below runs after the restore\n", step.Recovered, len(step.Keys))
+	fmt.Fprintf(out, "=== End of restore ===\n\n")
+}
+
+// withRestoredEntries returns entries with the restored ones added, leaving
+// entries itself untouched.
+func withRestoredEntries(entries, restored map[string]string) map[string]string {
+	if len(restored) == 0 {
+		return entries
+	}
+	merged := make(map[string]string, len(entries)+len(restored))
+	for k, v := range entries {
+		merged[k] = v
+	}
+	for k, v := range restored {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRestoreStep(t *testing.T) {
+	envXdr, env := feeTestEnvelope(t, 5000, 4000)
+	contract := xdr.ContractId{7}
+	archived := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contract},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	}
+	dataXdr, err := xdr.MarshalBase64(xdr.SorobanTransactionData{
+		Resources:   xdr.SorobanResources{Footprint: xdr.LedgerFootprint{ReadWrite: []xdr.LedgerKey{archived}}},
+		ResourceFee: 2500,
+	})
+	require.NoError(t, err)
+
+	step, restoreXdr, err := newRestoreStep(envXdr, dataXdr)
+	require.NoError(t, err)
+	archivedXdr, err := xdr.MarshalBase64(archived)
+	require.NoError(t, err)
+	assert.Equal(t, []string{archivedXdr}, step.Keys)
+
+	var restore xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(restoreXdr, &restore))
+	require.Len(t, restore.Operations(), 1)
+	assert.Equal(t, xdr.OperationTypeRestoreFootprint, restore.Operations()[0].Body.Type)
+	assert.Equal(t, env.SourceAccount(), restore.SourceAccount())
+	assert.Equal(t, uint32(2600), restore.Fee())
+	assert.Equal(t, archived, restore.V1.Tx.Ext.SorobanData.Resources.Footprint.ReadWrite[0])
+
+	_, _, err = newRestoreStep(envXdr, "not xdr")
+	assert.Error(t, err)
+}
+
+func TestWithRestoredEntries(t *testing.T) {
+	entries := map[string]string{"a": "1"}
+	assert.Equal(t, entries, withRestoredEntries(entries, nil))
+
+	merged := withRestoredEntries(entries, map[string]string{"b": "2"})
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, merged)
+	assert.Len(t, entries, 1, "the prefetched entries are shared and left untouched")
+}
+
+func TestValidateAutoRestore(t *testing.T) {
+	prevRestore, prevCompare := autoRestoreFlag, compareNetworksFlag
+	t.Cleanup(func() { autoRestoreFlag, compareNetworksFlag = prevRestore, prevCompare })
+
+	autoRestoreFlag, compareNetworksFlag = true, nil
+	assert.NoError(t, validateAutoRestore())
+
+	compareNetworksFlag = []string{"testnet"}
+	assert.Error(t, validateAutoRestore())
+}
+
+func TestPrintRestoreStep(t *testing.T) {
+	var buf bytes.Buffer
+	printRestoreStep(&buf, &report.RestoreStep{Keys: []string{"AAAA"}, ResourceFee: 2500, Recovered: 1})
+	out := buf.String()
+	assert.Contains(t, out, "Archived entries: 1")
+	assert.Contains(t, out, "resource fee 2500 stroops")
+	assert.Contains(t, out, "recovered for 1 of 1 entries")
+	assert.Contains(t, out, "=== End of restore ===")
+}
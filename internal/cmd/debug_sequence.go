@@ -0,0 +1,59 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+// detectSequenceFailure explains a transaction rejected for its sequence
+// number, looking up the source account's current sequence with client to
+// show the gap. client is nil when replaying a bundle offline.
+func detectSequenceFailure(ctx context.Context, client *rpc.Client, tx *rpc.TransactionResponse) *decoder.SequenceFailure {
+	f, err := decoder.DetectSequenceFailure(tx.EnvelopeXdr, tx.ResultXdr)
+	if err != nil {
+		logger.Logger.Warn("Failed to decode transaction result", "error", err)
+		return nil
+	}
+	if f == nil || client == nil {
+		return f
+	}
+	account, err := client.GetAccount(ctx, f.Account)
+	if err != nil {
+		logger.Logger.Warn("Failed to fetch the source account's sequence number", "account", f.Account, "error", err)
+		return f
+	}
+	f.SetCurrentSequence(account.Sequence)
+	return f
+}
+
+func printSequenceFailure(out io.Writer, f *decoder.SequenceFailure) {
+	if f == nil {
+		return
+	}
+	fmt.Fprintf(out, "\n=== Sequence Number Failure ===\n")
+	fmt.Fprintf(out, "%s %s: %s\n", visualizer.Error(), f.Code, f.Explanation)
+	fmt.Fprintf(out, "  Account: %s\n", f.Account)
+	fmt.Fprintf(out, "  Provided sequence: %d\n", f.ProvidedSequence)
+	if f.CurrentSequence != nil {
+		fmt.Fprintf(out, "  Current sequence:  %d (as of now; it may have advanced since the transaction was rejected)\n", *f.CurrentSequence)
+		fmt.Fprintf(out, "  Expected sequence: %d (provided is %+d)\n", *f.ExpectedSequence, *f.Gap)
+	}
+	if f.MinSeqNum != nil {
+		fmt.Fprintf(out, "  Min sequence number: %d\n", *f.MinSeqNum)
+	}
+	if f.MinSeqAge != nil {
+		fmt.Fprintf(out, "  Min sequence age: %ds\n", *f.MinSeqAge)
+	}
+	if f.MinSeqLedgerGap != nil {
+		fmt.Fprintf(out, "  Min sequence ledger gap: %d\n", *f.MinSeqLedgerGap)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// sourceAccountOverride replays a transaction as if it had been submitted by
+// a different source account. Only ledger entries owned by the account
+// itself (its account entry and trustlines) are swapped; contract storage
+// keyed by an address is left untouched, as are addresses in the arguments.
+// Either account may be muxed; its ledger entries are those of the
+// underlying account.
+type sourceAccountOverride struct {
+	from xdr.AccountId
+	to   xdr.AccountId
+	// fromAccount and toAccount name the sources as the envelopes do, for
+	// display.
+	fromAccount decoder.Account
+	toAccount   decoder.Account
+}
+
+// newSourceAccountOverride rewrites the source account of envelopeXdr (the
+// inner transaction's, for fee bumps) to account and returns the rewritten
+// envelope together with the override describing the swap.
+func newSourceAccountOverride(envelopeXdr, account string) (string, *sourceAccountOverride, error) {
+	toAccount, err := decoder.ParseAccount(account)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid source account %q: %w", account, err)
+	}
+	to, err := xdr.AddressToAccountId(toAccount.Base())
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid source account %q: %w", account, err)
+	}
+	muxed, err := xdr.AddressToMuxedAccount(account)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid source account %q: %w", account, err)
+	}
+
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &env); err != nil {
+		return "", nil, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+
+	override := &sourceAccountOverride{to: to, toAccount: toAccount}
+	var from xdr.MuxedAccount
+	switch env.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
+		if muxed.Type != xdr.CryptoKeyTypeKeyTypeEd25519 {
+			return "", nil, fmt.Errorf("a v0 transaction cannot have the muxed source account %s", account)
+		}
+		from = xdr.MuxedAccount{Type: xdr.CryptoKeyTypeKeyTypeEd25519, Ed25519: &env.V0.Tx.SourceAccountEd25519}
+		env.V0.Tx.SourceAccountEd25519 = *to.Ed25519
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		from = env.V1.Tx.SourceAccount
+		env.V1.Tx.SourceAccount = muxed
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		inner := &env.FeeBump.Tx.InnerTx.V1.Tx
+		from = inner.SourceAccount
+		inner.SourceAccount = muxed
+	default:
+		return "", nil, fmt.Errorf("unsupported envelope type %s", env.Type)
+	}
+	override.from = from.ToAccountId()
+	if override.fromAccount, err = decoder.NewAccount(from); err != nil {
+		return "", nil, fmt.Errorf("failed to decode source account: %w", err)
+	}
+
+	rewritten, err := xdr.MarshalBase64(env)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode envelope: %w", err)
+	}
+	return rewritten, override, nil
+}
+
+// rewriteKey maps an account or trustline key of the original source to the
+// same key for the override account. ok is false for any other key.
+func (o *sourceAccountOverride) rewriteKey(encoded string) (string, bool) {
+	var key xdr.LedgerKey
+	if err := xdr.SafeUnmarshalBase64(encoded, &key); err != nil {
+		return "", false
+	}
+
+	switch key.Type {
+	case xdr.LedgerEntryTypeAccount:
+		if !key.Account.AccountId.Equals(o.from) {
+			return "", false
+		}
+		key.Account.AccountId = o.to
+	case xdr.LedgerEntryTypeTrustline:
+		if !key.TrustLine.AccountId.Equals(o.from) {
+			return "", false
+		}
+		key.TrustLine.AccountId = o.to
+	default:
+		return "", false
+	}
+
+	rewritten, err := xdr.MarshalBase64(key)
+	if err != nil {
+		return "", false
+	}
+	return rewritten, true
+}
+
+// rewriteKeys returns keys with the original source's account-owned keys
+// replaced by the override account's.
+func (o *sourceAccountOverride) rewriteKeys(keys []string) []string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		if rewritten, ok := o.rewriteKey(k); ok {
+			out[i] = rewritten
+		} else {
+			out[i] = k
+		}
+	}
+	return out
+}
+
+// applyToEntries drops the original source's account-owned entries and
+// fetches the override account's equivalents through client. The override
+// account entry itself is always fetched, since every transaction loads its
+// source account.
+func (o *sourceAccountOverride) applyToEntries(ctx context.Context, client *rpc.Client, entries map[string]string) (map[string]string, error) {
+	accountKey, err := xdr.MarshalBase64(xdr.LedgerKey{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: o.to},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string, len(entries))
+	needed := map[string]struct{}{accountKey: {}}
+	for k, v := range entries {
+		if rewritten, ok := o.rewriteKey(k); ok {
+			needed[rewritten] = struct{}{}
+			continue
+		}
+		out[k] = v
+	}
+
+	keys := make([]string, 0, len(needed))
+	for k := range needed {
+		keys = append(keys, k)
+	}
+	fetched, err := client.GetLedgerEntries(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entries for source account %s: %w", o.to.Address(), err)
+	}
+	if _, ok := fetched[accountKey]; !ok {
+		return nil, fmt.Errorf("source account %s does not exist on this network", o.to.Address())
+	}
+	for k, v := range fetched {
+		out[k] = v
+	}
+	return out, nil
+}
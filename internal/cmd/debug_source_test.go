@@ -0,0 +1,91 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	originalSource = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	overrideSource = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+)
+
+func sourceTestEnvelope(t *testing.T) string {
+	t.Helper()
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: xdr.MustMuxedAddress(originalSource),
+			Operations: []xdr.Operation{{Body: xdr.OperationBody{
+				Type:           xdr.OperationTypeBumpSequence,
+				BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 1},
+			}}},
+		}},
+	}
+	encoded, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return encoded
+}
+
+func accountKey(t *testing.T, address string) string {
+	t.Helper()
+	key := xdr.LedgerKey{Type: xdr.LedgerEntryTypeAccount, Account: &xdr.LedgerKeyAccount{AccountId: xdr.MustAddress(address)}}
+	encoded, err := xdr.MarshalBase64(key)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestNewSourceAccountOverride_RewritesEnvelope(t *testing.T) {
+	rewritten, override, err := newSourceAccountOverride(sourceTestEnvelope(t), overrideSource)
+	require.NoError(t, err)
+
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(rewritten, &env))
+	assert.Equal(t, overrideSource, env.SourceAccount().ToAccountId().Address())
+	assert.Equal(t, originalSource, override.from.Address())
+	assert.Len(t, env.Operations(), 1, "operations are preserved")
+}
+
+func TestNewSourceAccountOverride_Muxed(t *testing.T) {
+	var m strkey.MuxedAccount
+	require.NoError(t, m.SetAccountID(overrideSource))
+	m.SetID(42)
+	mAddress, err := m.Address()
+	require.NoError(t, err)
+
+	rewritten, override, err := newSourceAccountOverride(sourceTestEnvelope(t), mAddress)
+	require.NoError(t, err)
+
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(rewritten, &env))
+	source := env.SourceAccount()
+	assert.Equal(t, mAddress, source.Address(), "the envelope keeps the muxed source")
+	assert.Equal(t, overrideSource, override.to.Address(), "ledger entries belong to the underlying account")
+	assert.Equal(t, mAddress+" ("+overrideSource+", memo ID 42)", override.toAccount.String())
+	assert.Equal(t, originalSource, override.fromAccount.String())
+
+	keys := override.rewriteKeys([]string{accountKey(t, originalSource)})
+	assert.Equal(t, []string{accountKey(t, overrideSource)}, keys)
+}
+
+func TestNewSourceAccountOverride_InvalidAccount(t *testing.T) {
+	_, _, err := newSourceAccountOverride(sourceTestEnvelope(t), "not-an-account")
+	assert.Error(t, err)
+}
+
+func TestSourceAccountOverride_RewriteKeys(t *testing.T) {
+	_, override, err := newSourceAccountOverride(sourceTestEnvelope(t), overrideSource)
+	require.NoError(t, err)
+
+	other := "GCEZWKCA5VLDNRLN3RPRJMRZOX3Z6G5CHCGSNFHEYVXM3XOJMDS674JZ"
+	keys := override.rewriteKeys([]string{accountKey(t, originalSource), accountKey(t, other)})
+
+	assert.Equal(t, []string{accountKey(t, overrideSource), accountKey(t, other)}, keys)
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc"
+)
+
+var statsFlag bool
+
+// debugStats accounts for where a debug run spent its time, per network: the
+// primary first, then every compare network. Each network's slot is only
+// written by the goroutine simulating it.
+type debugStats struct {
+	networks   []string
+	requests   []*rpc.RequestStats
+	simulation []time.Duration
+}
+
+func newDebugStats(primary string, compare []string) *debugStats {
+	s := &debugStats{networks: append([]string{primary}, compare...)}
+	s.requests = make([]*rpc.RequestStats, len(s.networks))
+	for i := range s.requests {
+		s.requests[i] = &rpc.RequestStats{}
+	}
+	s.simulation = make([]time.Duration, len(s.networks))
+	return s
+}
+
+// clientOption counts the requests of network i's client.
+func (s *debugStats) clientOption(i int) rpc.ClientOption {
+	return rpc.WithRequestStats(s.requests[i])
+}
+
+// simulated adds a simulation of network i that started at start.
+func (s *debugStats) simulated(i int, start time.Time) {
+	s.simulation[i] += time.Since(start)
+}
+
+// print writes the stats footer, shown with --stats or --verbose: the wall
+// time and, per network, the time spent in RPC requests and in simulation,
+// the number of requests and the bytes fetched.
+func (s *debugStats) print(out io.Writer, wall time.Duration) {
+	if !statsFlag && !verbose {
+		return
+	}
+	if len(s.networks) == 1 {
+		fmt.Fprintf(out, "\nStats: wall %s, %s\n", wall.Round(time.Millisecond), s.network(0))
+		return
+	}
+	fmt.Fprintf(out, "\nStats: wall %s\n", wall.Round(time.Millisecond))
+	for i, n := range s.networks {
+		fmt.Fprintf(out, "  %s: %s\n", n, s.network(i))
+	}
+}
+
+func (s *debugStats) network(i int) string {
+	r := s.requests[i]
+	return fmt.Sprintf("RPC %s in %d calls (%s fetched), simulation %s",
+		r.Duration().Round(time.Millisecond), r.Calls(), formatBytes(r.Bytes()), s.simulation[i].Round(time.Millisecond))
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setStatsFlags(t *testing.T, stats, verb bool) {
+	t.Helper()
+	prevStats, prevVerbose := statsFlag, verbose
+	t.Cleanup(func() { statsFlag, verbose = prevStats, prevVerbose })
+	statsFlag, verbose = stats, verb
+}
+
+func TestDebugStats_Print(t *testing.T) {
+	s := newDebugStats("testnet", nil)
+	s.simulated(0, time.Now().Add(-250*time.Millisecond))
+
+	var out bytes.Buffer
+	setStatsFlags(t, false, false)
+	s.print(&out, time.Second)
+	assert.Empty(t, out.String())
+
+	setStatsFlags(t, true, false)
+	s.print(&out, time.Second)
+	assert.Contains(t, out.String(), "Stats: wall 1s, RPC 0s in 0 calls (0 B fetched), simulation 25")
+
+	out.Reset()
+	setStatsFlags(t, false, true)
+	s.print(&out, time.Second)
+	assert.Contains(t, out.String(), "Stats: wall 1s")
+}
+
+func TestDebugStats_PrintPerNetwork(t *testing.T) {
+	setStatsFlags(t, true, false)
+	s := newDebugStats("testnet", []string{"mainnet"})
+	s.simulated(1, time.Now())
+
+	var out bytes.Buffer
+	s.print(&out, 2*time.Second)
+	assert.Contains(t, out.String(), "Stats: wall 2s\n")
+	assert.Contains(t, out.String(), "  testnet: RPC 0s in 0 calls")
+	assert.Contains(t, out.String(), "  mainnet: RPC 0s in 0 calls")
+}
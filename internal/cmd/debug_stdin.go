@@ -0,0 +1,103 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+// stdinHashArg is the argument that makes `erst debug -` read transaction
+// hashes from stdin instead of the command line.
+const stdinHashArg = "-"
+
+// stdinHashes holds the hashes read from stdin for the current invocation,
+// so PreRunE and RunE see the same list without reading stdin twice.
+var stdinHashes []string
+
+// isStdinHashArgs reports whether args asks for hashes to be read from stdin.
+func isStdinHashArgs(args []string) bool {
+	return len(args) == 1 && args[0] == stdinHashArg
+}
+
+// debugHashArgs returns the transaction hashes to debug: the stdin hashes
+// when args is "-", args itself otherwise.
+func debugHashArgs(args []string) []string {
+	if isStdinHashArgs(args) {
+		return stdinHashes
+	}
+	return args
+}
+
+// readHashes reads one transaction hash per line from in. Blank lines and
+// lines starting with # are skipped; surrounding whitespace is ignored.
+func readHashes(in io.Reader) ([]string, error) {
+	var hashes []string
+	scanner := bufio.NewScanner(in)
+	for line := 1; scanner.Scan(); line++ {
+		hash := strings.TrimSpace(scanner.Text())
+		if hash == "" || strings.HasPrefix(hash, "#") {
+			continue
+		}
+		if err := rpc.ValidateTransactionHash(hash); err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid transaction hash on stdin line %d: %v", line, err))
+		}
+		hashes = append(hashes, hash)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to read transaction hashes from stdin: %v", err))
+	}
+	if len(hashes) == 0 {
+		return nil, errors.WrapValidationError("no transaction hashes on stdin")
+	}
+	return hashes, nil
+}
+
+// loadStdinHashes reads stdin into stdinHashes when args is "-". Stdin is
+// never touched otherwise, so interactive runs do not block on it.
+func loadStdinHashes(cmd *cobra.Command, args []string) error {
+	stdinHashes = nil
+	if !isStdinHashArgs(args) {
+		return nil
+	}
+	hashes, err := readHashes(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+	stdinHashes = hashes
+	return nil
+}
+
+// validateMultiHash rejects flags that only make sense for a single
+// transaction when several hashes are debugged one after another.
+func validateMultiHash() error {
+	if saveBundleFlag != "" || reportFileFlag != "" || outputFileFlag != "" || traceOutputFile != "" {
+		return errors.WrapValidationError("--save, --report, --output-file and --trace-output write a single file and cannot be used with multiple transaction hashes (use --summary for aggregates)")
+	}
+	return nil
+}
+
+// runDebugEach debugs every hash with the single-transaction flow. A failing
+// transaction is reported and the rest still run; the command fails at the
+// end if any of them did.
+func runDebugEach(cmd *cobra.Command, hashes []string) error {
+	failed := 0
+	for i, hash := range hashes {
+		fmt.Fprintf(progressWriter(cmd), "\n=== [%d/%d] %s ===\n", i+1, len(hashes), hash)
+		if err := cmd.RunE(cmd, []string{hash}); err != nil {
+			failed++
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error debugging %s: %v\n", hash, err)
+		}
+	}
+	if failed > 0 {
+		return errors.WrapBatchFailed(failed, len(hashes))
+	}
+	return nil
+}
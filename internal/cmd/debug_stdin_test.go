@@ -0,0 +1,60 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingReader fails the test if anything tries to read from it.
+type failingReader struct{ t *testing.T }
+
+func (r failingReader) Read([]byte) (int, error) {
+	r.t.Fatal("stdin must not be read")
+	return 0, nil
+}
+
+func TestReadHashes(t *testing.T) {
+	a, b := strings.Repeat("a", 64), strings.Repeat("b", 64)
+	hashes, err := readHashes(strings.NewReader("# failed on mainnet\n" + a + "\n\n  " + b + "  \n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{a, b}, hashes)
+
+	_, err = readHashes(strings.NewReader(a + "\nnot-a-hash\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+
+	_, err = readHashes(strings.NewReader("\n# nothing here\n"))
+	assert.Error(t, err)
+}
+
+func TestLoadStdinHashes(t *testing.T) {
+	t.Cleanup(func() { stdinHashes = nil })
+	a := strings.Repeat("a", 64)
+
+	cmd := &cobra.Command{}
+	cmd.SetIn(failingReader{t})
+	require.NoError(t, loadStdinHashes(cmd, []string{a}))
+	assert.Equal(t, []string{a}, debugHashArgs([]string{a}))
+
+	cmd.SetIn(strings.NewReader(a + "\n"))
+	require.NoError(t, loadStdinHashes(cmd, []string{stdinHashArg}))
+	assert.Equal(t, []string{a}, debugHashArgs([]string{stdinHashArg}))
+}
+
+func TestValidateMultiHash(t *testing.T) {
+	orig := reportFileFlag
+	t.Cleanup(func() { reportFileFlag = orig })
+
+	reportFileFlag = ""
+	assert.NoError(t, validateMultiHash())
+
+	reportFileFlag = "report.md"
+	assert.Error(t, validateMultiHash())
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/heuristic"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/spf13/cobra"
+)
+
+// debugSummary aggregates the outcomes of a batch of transactions replayed
+// with --summary.
+type debugSummary struct {
+	Network            string                     `json:"network"`
+	Total              int                        `json:"total"`
+	Succeeded          int                        `json:"succeeded"`
+	Failed             int                        `json:"failed"`
+	FailuresByCategory map[heuristic.Category]int `json:"failures_by_category"`
+	TopFailingContract *failingContract           `json:"top_failing_contract,omitempty"`
+
+	// Errors lists transactions that could not be fetched or simulated;
+	// they count towards Total but not towards Succeeded or Failed.
+	Errors []summaryError `json:"errors,omitempty"`
+
+	contractFailures map[string]int
+}
+
+type failingContract struct {
+	ContractID string `json:"contract_id"`
+	Failures   int    `json:"failures"`
+}
+
+type summaryError struct {
+	TxHash string `json:"tx_hash"`
+	Error  string `json:"error"`
+}
+
+func newDebugSummary(network string) *debugSummary {
+	return &debugSummary{
+		Network:            network,
+		FailuresByCategory: make(map[heuristic.Category]int),
+		contractFailures:   make(map[string]int),
+	}
+}
+
+// add records one simulated transaction.
+func (s *debugSummary) add(txHash string, res *simulator.SimulationResponse) {
+	s.Total++
+	in := heuristic.Input{
+		TxHash:           txHash,
+		Network:          s.Network,
+		Status:           res.Status,
+		Error:            res.Error,
+		Events:           res.Events,
+		Logs:             res.Logs,
+		DiagnosticEvents: res.DiagnosticEvents,
+		BudgetUsage:      res.BudgetUsage,
+	}
+	category := heuristic.Classify(in)
+	if category == "" {
+		s.Succeeded++
+		return
+	}
+
+	s.Failed++
+	s.FailuresByCategory[category]++
+	if contract := heuristic.FailingContract(in); contract != "" {
+		s.contractFailures[contract]++
+		count := s.contractFailures[contract]
+		top := s.TopFailingContract
+		if top == nil || count > top.Failures || (count == top.Failures && contract < top.ContractID) {
+			s.TopFailingContract = &failingContract{ContractID: contract, Failures: count}
+		}
+	}
+}
+
+// addError records a transaction that could not be replayed.
+func (s *debugSummary) addError(txHash string, err error) {
+	s.Total++
+	s.Errors = append(s.Errors, summaryError{TxHash: txHash, Error: err.Error()})
+}
+
+// validateSummaryMode checks the arguments and flags of a --summary run,
+// which replays each hash on a single network and prints only aggregates.
+func validateSummaryMode(cmd *cobra.Command, args []string) error {
+	if demoMode || wasmPath != "" || replayBundleFlag != "" || saveBundleFlag != "" ||
+		snapshotFlag != "" || len(compareNetworksFlag) > 0 || watchFlag || len(checkRuleFiles) > 0 || sourceAccountFlag != "" {
+		return errors.WrapValidationError("--summary cannot be combined with --demo, --wasm, --replay, --save, --snapshot, --compare-network, --watch, --check or --source-account")
+	}
+	if outputFormatFlag == outputFormatMarkdown || outputFormatFlag == outputFormatLine {
+		return errors.WrapValidationError("--summary supports --output text or json")
+	}
+	if len(args) == 0 {
+		return errors.WrapValidationError("--summary requires at least one transaction hash")
+	}
+	for _, hash := range args {
+		if err := rpc.ValidateTransactionHash(hash); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("invalid transaction hash format %q: %v", hash, err))
+		}
+	}
+
+	return parsePrimaryNetwork()
+}
+
+// runDebugSummary replays every hash on --network and prints aggregate
+// outcome counts instead of per-transaction detail. Transactions that cannot
+// be fetched or simulated are reported but do not stop the run.
+func runDebugSummary(cmd *cobra.Command, hashes []string) error {
+	ctx := cmd.Context()
+	out := progressWriter(cmd)
+
+	opts, _ := primaryClientOptions(resolveRPCToken(), rpc.NewEntryMemo())
+	client, err := rpc.NewClient(opts...)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to create client: %v", err))
+	}
+	if noCacheFlag {
+		client.CacheEnabled = false
+	}
+
+	runner, err := simulator.NewRunnerWithMockTime("", tracingEnabled, mockTimeFlag)
+	if err != nil {
+		return errors.WrapSimulatorNotFound(err.Error())
+	}
+
+	summary := newDebugSummary(networkFlag)
+	for i, hash := range hashes {
+		fmt.Fprintf(out, "[%d/%d] %s\n", i+1, len(hashes), hash)
+		res, err := simulateForSummary(ctx, client, runner, hash)
+		if err != nil {
+			summary.addError(hash, err)
+			continue
+		}
+		summary.add(hash, res)
+	}
+
+	if outputFormatFlag == outputFormatJSON {
+		if err := report.WriteJSON(cmd.OutOrStdout(), summary); err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+		return nil
+	}
+	printDebugSummary(cmd.OutOrStdout(), summary)
+	return nil
+}
+
+func simulateForSummary(ctx context.Context, client *rpc.Client, runner simulator.RunnerInterface, txHash string) (*simulator.SimulationResponse, error) {
+	resp, err := client.GetTransaction(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	// Summary output is aggregates only, so footprint warnings are dropped
+	keys, err := transactionLedgerKeys(ctx, io.Discard, client, resp)
+	if err != nil {
+		return nil, err
+	}
+	protocol, _ := simulationProtocol(ctx, client, resp.Ledger, protocolVersionFlag)
+	return simulateFetched(ctx, client, runner, resp, keys, protocol)
+}
+
+// simulateFetched replays a fetched transaction under protocol against the
+// ledger state its result meta records, fetching the entries for keys from
+// client when the meta does not carry them.
+func simulateFetched(ctx context.Context, client *rpc.Client, runner simulator.RunnerInterface, resp *rpc.TransactionResponse, keys []string, protocol *uint32) (*simulator.SimulationResponse, error) {
+	entries, err := rpc.ExtractLedgerEntriesFromMeta(resp.ResultMetaXdr)
+	if err == nil {
+		entries = withUnchangedEntries(ctx, client, resp.ResultMetaXdr, keys, entries)
+	} else {
+		if err := checkKeyLimit(len(keys)); err != nil {
+			return nil, err
+		}
+		if entries, err = client.GetLedgerEntries(ctx, keys); err != nil {
+			return nil, err
+		}
+	}
+
+	req := &simulator.SimulationRequest{
+		EnvelopeXdr:     resp.EnvelopeXdr,
+		ResultMetaXdr:   resp.ResultMetaXdr,
+		LedgerEntries:   entries,
+		Timestamp:       TimestampFlag,
+		ProtocolVersion: protocol,
+	}
+	applySimulationFeeMocks(req)
+	return simulator.RunWithContext(ctx, runner, req)
+}
+
+func printDebugSummary(out io.Writer, s *debugSummary) {
+	fmt.Fprintf(out, "\nSummary of %d transactions on %s\n", s.Total, s.Network)
+	fmt.Fprintf(out, "  Succeeded: %d\n", s.Succeeded)
+	fmt.Fprintf(out, "  Failed:    %d\n", s.Failed)
+	if len(s.Errors) > 0 {
+		fmt.Fprintf(out, "  Errored:   %d (could not be fetched or simulated)\n", len(s.Errors))
+	}
+
+	if len(s.FailuresByCategory) > 0 {
+		categories := make([]heuristic.Category, 0, len(s.FailuresByCategory))
+		for c := range s.FailuresByCategory {
+			categories = append(categories, c)
+		}
+		sort.Slice(categories, func(i, j int) bool {
+			ci, cj := s.FailuresByCategory[categories[i]], s.FailuresByCategory[categories[j]]
+			if ci != cj {
+				return ci > cj
+			}
+			return categories[i] < categories[j]
+		})
+
+		fmt.Fprintf(out, "\nFailures by category:\n")
+		for _, c := range categories {
+			fmt.Fprintf(out, "  %-22s %d\n", c, s.FailuresByCategory[c])
+		}
+	}
+
+	if s.TopFailingContract != nil {
+		fmt.Fprintf(out, "\nMost common failing contract: %s (%d failures)\n", s.TopFailingContract.ContractID, s.TopFailingContract.Failures)
+	}
+
+	if len(s.Errors) > 0 {
+		fmt.Fprintf(out, "\nErrors:\n")
+		for _, e := range s.Errors {
+			fmt.Fprintf(out, "  %s: %s\n", e.TxHash, e.Error)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"io"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+)
+
+// summaryJSONFlag ends text output with a one-line JSON summary of the run,
+// for scripts that read the human-readable log with `tail -1 | jq`.
+var summaryJSONFlag bool
+
+// validateSummaryJSON rejects --summary-json where it would not be the last
+// line of stdout, or where the run produces no report to summarize.
+func validateSummaryJSON() error {
+	if !summaryJSONFlag {
+		return nil
+	}
+	if structuredOutput() && outputFileFlag == "" {
+		return errors.WrapValidationError("--summary-json follows text output; with --output json|markdown|line or --template, also pass --output-file")
+	}
+	if demoMode || wasmPath != "" || summaryFlag || keysOnlyFlag || compareTxFlag != "" || compareLedgerFlag != "" || envelopesFileFlag != "" {
+		return errors.WrapValidationError("--summary-json cannot be combined with --demo, --wasm, --summary, --keys-only, --compare-tx, --compare-ledger or --envelopes-file")
+	}
+	return nil
+}
+
+// writeSummaryJSON prints the --summary-json line for r. It must be the last
+// thing written to stdout.
+func writeSummaryJSON(stdout io.Writer, r *report.DebugReport, checkFailures int) error {
+	if !summaryJSONFlag {
+		return nil
+	}
+	if err := report.WriteSummaryJSON(stdout, r, checkFailures); err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSummaryJSON(t *testing.T) {
+	prevSummaryJSON, prevOutput, prevOutputFile, prevKeysOnly := summaryJSONFlag, outputFormatFlag, outputFileFlag, keysOnlyFlag
+	t.Cleanup(func() {
+		summaryJSONFlag, outputFormatFlag, outputFileFlag, keysOnlyFlag = prevSummaryJSON, prevOutput, prevOutputFile, prevKeysOnly
+	})
+
+	summaryJSONFlag, outputFormatFlag, outputFileFlag, keysOnlyFlag = true, outputFormatText, "", false
+	assert.NoError(t, validateSummaryJSON())
+
+	outputFormatFlag = outputFormatJSON
+	assert.Error(t, validateSummaryJSON(), "the JSON report would follow on stdout")
+	outputFileFlag = "report.json"
+	assert.NoError(t, validateSummaryJSON())
+
+	keysOnlyFlag = true
+	assert.Error(t, validateSummaryJSON())
+}
+
+func TestWriteSummaryJSON_OneLine(t *testing.T) {
+	prev := summaryJSONFlag
+	t.Cleanup(func() { summaryJSONFlag = prev })
+
+	r := report.NewDebugReport("abc123", "testnet")
+	r.Result = &simulator.SimulationResponse{Status: "success"}
+
+	var out bytes.Buffer
+	summaryJSONFlag = false
+	require.NoError(t, writeSummaryJSON(&out, r, 0))
+	assert.Empty(t, out.String())
+
+	summaryJSONFlag = true
+	require.NoError(t, writeSummaryJSON(&out, r, 0))
+	line := out.String()
+	assert.Equal(t, 1, strings.Count(line, "\n"))
+	assert.True(t, strings.HasSuffix(line, "\n"))
+
+	var summary report.SummaryJSON
+	require.NoError(t, json.Unmarshal([]byte(line), &summary))
+	assert.Equal(t, "success", summary.Status)
+	assert.Equal(t, "abc123", summary.TxHash)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/heuristic"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func failedSim(errMsg, contract string) *simulator.SimulationResponse {
+	return &simulator.SimulationResponse{
+		Status:           "error",
+		Error:            errMsg,
+		DiagnosticEvents: []simulator.DiagnosticEvent{{ContractID: &contract}},
+	}
+}
+
+func TestDebugSummary_Aggregates(t *testing.T) {
+	s := newDebugSummary("testnet")
+	s.add("a", &simulator.SimulationResponse{Status: "success"})
+	s.add("b", failedSim("Error(Auth, InvalidAction)", "CTOKEN"))
+	s.add("c", failedSim("Error(Auth, InvalidAction)", "CTOKEN"))
+	s.add("d", failedSim("insufficient balance", "CPOOL"))
+	s.addError("e", fmt.Errorf("transaction not found"))
+
+	assert.Equal(t, 5, s.Total)
+	assert.Equal(t, 1, s.Succeeded)
+	assert.Equal(t, 3, s.Failed)
+	assert.Equal(t, 2, s.FailuresByCategory[heuristic.CategoryAuth])
+	assert.Equal(t, 1, s.FailuresByCategory[heuristic.CategoryInsufficientBalance])
+	require.NotNil(t, s.TopFailingContract)
+	assert.Equal(t, "CTOKEN", s.TopFailingContract.ContractID)
+	assert.Equal(t, 2, s.TopFailingContract.Failures)
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"failures_by_category":{"auth":2,"insufficient_balance":1}`)
+
+	var buf bytes.Buffer
+	printDebugSummary(&buf, s)
+	text := buf.String()
+	assert.Contains(t, text, "Summary of 5 transactions on testnet")
+	assert.Contains(t, text, "Most common failing contract: CTOKEN (2 failures)")
+	assert.Less(t, strings.Index(text, "auth"), strings.Index(text, "insufficient_balance"), "categories sorted by count")
+}
+
+func TestValidateSummaryMode(t *testing.T) {
+	prevCompare, prevOutput, prevNetwork := compareNetworksFlag, outputFormatFlag, networkFlag
+	t.Cleanup(func() {
+		compareNetworksFlag, outputFormatFlag, networkFlag = prevCompare, prevOutput, prevNetwork
+	})
+	compareNetworksFlag, outputFormatFlag, networkFlag = nil, outputFormatJSON, "testnet"
+
+	hash := strings.Repeat("ab", 32)
+	assert.NoError(t, validateSummaryMode(debugCmd, []string{hash, hash}))
+	assert.Error(t, validateSummaryMode(debugCmd, nil))
+	assert.Error(t, validateSummaryMode(debugCmd, []string{hash, "not-a-hash"}))
+
+	outputFormatFlag = outputFormatMarkdown
+	assert.Error(t, validateSummaryMode(debugCmd, []string{hash}))
+
+	outputFormatFlag, compareNetworksFlag = outputFormatText, []string{"mainnet"}
+	assert.Error(t, validateSummaryMode(debugCmd, []string{hash}))
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/dotandev/hintents/internal/errors"
 	"github.com/dotandev/hintents/internal/simulator"
 	"github.com/stellar/go-stellar-sdk/xdr"
 	"github.com/stretchr/testify/assert"
@@ -334,4 +335,55 @@ func TestExtractLedgerKeys(t *testing.T) {
 		}
 	}
 	assert.True(t, found, "Key not found in extracted keys")
+
+	// Truncated or padded meta is reported as corrupt rather than half-decoded
+	truncated := base64.StdEncoding.EncodeToString(metaBytes[:len(metaBytes)-7])
+	_, err = extractLedgerKeys(truncated)
+	assert.ErrorIs(t, err, errors.ErrXDRCorrupt)
+
+	padded := base64.StdEncoding.EncodeToString(append(metaBytes, 0, 0, 0, 0))
+	_, err = extractLedgerKeys(padded)
+	assert.ErrorIs(t, err, errors.ErrXDRCorrupt)
+}
+
+func TestDebugPreRunE_ReplayFlagCombinations(t *testing.T) {
+	prevReplay, prevSave, prevCompare := replayBundleFlag, saveBundleFlag, compareNetworksFlag
+	t.Cleanup(func() {
+		replayBundleFlag, saveBundleFlag, compareNetworksFlag = prevReplay, prevSave, prevCompare
+	})
+
+	replayBundleFlag, saveBundleFlag, compareNetworksFlag = "bundle.erst.tar.gz", "", nil
+	assert.NoError(t, debugCmd.PreRunE(debugCmd, nil))
+
+	saveBundleFlag = "out.zip"
+	assert.Error(t, debugCmd.PreRunE(debugCmd, nil))
+
+	saveBundleFlag, compareNetworksFlag = "", []string{"testnet"}
+	assert.Error(t, debugCmd.PreRunE(debugCmd, nil))
+}
+
+func TestValidateCompareURLFlags(t *testing.T) {
+	prevCompare, prevRPC, prevHorizon, prevSoroban := compareNetworksFlag, compareRPCURLFlag, compareHorizonURL, compareSorobanURL
+	t.Cleanup(func() {
+		compareNetworksFlag, compareRPCURLFlag, compareHorizonURL, compareSorobanURL = prevCompare, prevRPC, prevHorizon, prevSoroban
+	})
+
+	compareNetworksFlag, compareRPCURLFlag, compareHorizonURL, compareSorobanURL = nil, "", "", ""
+	assert.NoError(t, validateCompareURLFlags())
+
+	compareRPCURLFlag = "https://rpc.example.com"
+	assert.Error(t, validateCompareURLFlags(), "requires a compare network")
+
+	compareNetworksFlag = []string{"testnet"}
+	assert.NoError(t, validateCompareURLFlags())
+
+	compareRPCURLFlag = "https://rpc.example.com, ftp://bad.example.com"
+	assert.Error(t, validateCompareURLFlags())
+
+	compareRPCURLFlag, compareSorobanURL = "", "https://soroban.example.com"
+	assert.NoError(t, validateCompareURLFlags())
+	assert.Len(t, compareURLOptions(), 1)
+
+	compareNetworksFlag = []string{"testnet", "futurenet"}
+	assert.Error(t, validateCompareURLFlags(), "ambiguous with several compare networks")
 }
@@ -0,0 +1,29 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/dotandev/hintents/internal/rpc"
+)
+
+// txSourceFlag is --tx-source: the API transactions are fetched from.
+var txSourceFlag string
+
+// validateTxSource checks --tx-source.
+func validateTxSource() error {
+	_, err := rpc.ParseTransactionSource(txSourceFlag)
+	return err
+}
+
+// txSourceOptions returns the client options for --tx-source. With
+// soroban, the --rpc-url endpoints are Soroban RPC endpoints, so a user
+// without Horizon access can point erst at their RPC alone.
+func txSourceOptions() []rpc.ClientOption {
+	src := rpc.TransactionSource(txSourceFlag)
+	opts := []rpc.ClientOption{rpc.WithTransactionSource(src)}
+	if src == rpc.TransactionSourceSoroban && rpcURLFlag != "" && customNetwork == nil {
+		opts = append(opts, rpc.WithSorobanURL(splitURLList(rpcURLFlag)[0]))
+	}
+	return opts
+}
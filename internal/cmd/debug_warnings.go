@@ -0,0 +1,71 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/visualizer"
+)
+
+// debugWarnings collects the warnings raised during a debug run, so they are
+// printed together in one Warnings section and attached to the report. A nil
+// collector prints each warning as it is raised instead, for callers that
+// build no report.
+type debugWarnings struct {
+	list []simulator.Warning
+}
+
+// add records w, or prints it on out when the collector is nil. A warning
+// already recorded, as when a step repeats for every --timestamp, is kept
+// once.
+func (c *debugWarnings) add(out io.Writer, w simulator.Warning) {
+	if c == nil {
+		printWarning(out, w)
+		return
+	}
+	for _, seen := range c.list {
+		if seen.Code == w.Code && seen.Message == w.Message {
+			return
+		}
+	}
+	c.list = append(c.list, w)
+}
+
+// all returns the run's warnings followed by those the primary simulation
+// itself reported.
+func (c *debugWarnings) all(res *simulator.SimulationResponse) []simulator.Warning {
+	var warnings []simulator.Warning
+	if c != nil {
+		warnings = append(warnings, c.list...)
+	}
+	if res != nil {
+		warnings = append(warnings, res.Warnings...)
+	}
+	return warnings
+}
+
+func printWarning(out io.Writer, w simulator.Warning) {
+	fmt.Fprintf(out, "%s %s\n", visualizer.Warning(), w.Message)
+	for _, k := range w.Keys {
+		fmt.Fprintf(out, "    %s\n", k)
+	}
+}
+
+// printWarnings prints the Warnings section: every soft problem of the run
+// with its code, so it is not lost among the progress output.
+func printWarnings(out io.Writer, warnings []simulator.Warning) {
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\nWarnings (%d):\n", len(warnings))
+	for _, w := range warnings {
+		fmt.Fprintf(out, "  %s [%s] %s\n", visualizer.Warning(), w.Code, w.Message)
+		for _, k := range w.Keys {
+			fmt.Fprintf(out, "      %s\n", k)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugWarnings(t *testing.T) {
+	w := simulator.Warning{Code: simulator.WarningTTLExpiring, Message: "1 footprint entries on testnet have expired", Keys: []string{"AAAA"}}
+
+	var out bytes.Buffer
+	var none *debugWarnings
+	none.add(&out, w)
+	assert.Contains(t, out.String(), "have expired")
+	assert.Contains(t, out.String(), "AAAA")
+
+	out.Reset()
+	warns := &debugWarnings{}
+	warns.add(&out, w)
+	warns.add(&out, w)
+	assert.Empty(t, out.String(), "a collector does not print as warnings are raised")
+
+	res := &simulator.SimulationResponse{Warnings: []simulator.Warning{{Code: "HOST", Message: "from the simulator"}}}
+	all := warns.all(res)
+	assert.Len(t, all, 2, "a repeated warning is kept once, followed by the simulation's own")
+	assert.Equal(t, simulator.WarningCode("HOST"), all[1].Code)
+
+	printWarnings(&out, all)
+	assert.Contains(t, out.String(), "Warnings (2):")
+	assert.Contains(t, out.String(), "[TTL_EXPIRING] 1 footprint entries on testnet have expired")
+
+	out.Reset()
+	printWarnings(&out, nil)
+	assert.Empty(t, out.String())
+}
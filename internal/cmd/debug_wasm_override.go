@@ -0,0 +1,235 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/abi"
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var overrideWasmFlags []string
+
+// wasmOverride makes one contract run different code: the WASM with hash,
+// read from a local file or, when code is nil, fetched from the network.
+type wasmOverride struct {
+	contract   string
+	contractID xdr.ContractId
+	hash       xdr.Hash
+	code       []byte
+	// from is the hex hash of the code the contract ran before, set once
+	// the override is applied.
+	from string
+}
+
+// parseWasmOverrides parses --override-wasm <contractID>=<wasmHashOrFile>
+// values. A 64-character hex value is a WASM hash; anything else is a WASM
+// file, which is read and checked to parse.
+func parseWasmOverrides(specs []string) ([]*wasmOverride, error) {
+	var overrides []*wasmOverride
+	seen := make(map[string]bool)
+	for _, spec := range specs {
+		contract, target, ok := strings.Cut(spec, "=")
+		if !ok || contract == "" || target == "" {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid --override-wasm %q: expected <contractID>=<wasmHashOrFile>", spec))
+		}
+		raw, err := strkey.Decode(strkey.VersionByteContract, contract)
+		if err != nil || len(raw) != len(xdr.ContractId{}) {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid --override-wasm contract %q: expected a C... contract address", contract))
+		}
+		if seen[contract] {
+			return nil, errors.WrapValidationError(fmt.Sprintf("--override-wasm names contract %s more than once", contract))
+		}
+		seen[contract] = true
+
+		o := &wasmOverride{contract: contract}
+		copy(o.contractID[:], raw)
+		if hash, err := hex.DecodeString(target); err == nil && len(hash) == len(xdr.Hash{}) {
+			copy(o.hash[:], hash)
+		} else {
+			code, err := os.ReadFile(target)
+			if err != nil {
+				return nil, errors.WrapValidationError(fmt.Sprintf("--override-wasm %s: %v", contract, err))
+			}
+			if err := checkOverrideWasm(code); err != nil {
+				return nil, errors.WrapValidationError(fmt.Sprintf("--override-wasm %s: %s: %v", contract, target, err))
+			}
+			o.code = code
+			o.hash = sha256.Sum256(code)
+		}
+		overrides = append(overrides, o)
+	}
+	return overrides, nil
+}
+
+// validateOverrideWasm checks --override-wasm, which rewrites the entries of
+// a single replay fetched from the network.
+func validateOverrideWasm() error {
+	if len(overrideWasmFlags) == 0 {
+		return nil
+	}
+	if summaryFlag || demoMode || wasmPath != "" || replayBundleFlag != "" || len(compareNetworksFlag) > 0 ||
+		compareTxFlag != "" || compareLedgerFlag != "" || keysOnlyFlag {
+		return errors.WrapValidationError("--override-wasm cannot be combined with --summary, --demo, --wasm, --replay, --compare-network, --compare-tx, --compare-ledger or --keys-only")
+	}
+	_, err := parseWasmOverrides(overrideWasmFlags)
+	return err
+}
+
+// checkOverrideWasm checks that code is a well-formed WASM module carrying a
+// Soroban contract spec.
+func checkOverrideWasm(code []byte) error {
+	spec, err := abi.ExtractCustomSection(code, "contractspecv0")
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		return errors.WrapSpecNotFound()
+	}
+	return nil
+}
+
+func (o *wasmOverride) hashHex() string {
+	return hex.EncodeToString(o.hash[:])
+}
+
+func (o *wasmOverride) codeKey() (string, error) {
+	return xdr.MarshalBase64(xdr.LedgerKey{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.LedgerKeyContractCode{Hash: o.hash},
+	})
+}
+
+// rewriteEnvelopeForWasms adds the override WASMs' code keys to the read-only
+// footprint of envelopeXdr, so the host may load them.
+func rewriteEnvelopeForWasms(envelopeXdr string, overrides []*wasmOverride) (string, error) {
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &env); err != nil {
+		return "", fmt.Errorf("failed to decode envelope: %w", err)
+	}
+	var ext *xdr.TransactionExt
+	switch env.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		ext = &env.V1.Tx.Ext
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		ext = &env.FeeBump.Tx.InnerTx.V1.Tx.Ext
+	}
+	if ext == nil || ext.SorobanData == nil {
+		return "", fmt.Errorf("the transaction has no Soroban footprint, so it runs no contract code")
+	}
+	footprint := &ext.SorobanData.Resources.Footprint
+	for _, o := range overrides {
+		present := false
+		for _, k := range append(footprint.ReadOnly, footprint.ReadWrite...) {
+			if k.Type == xdr.LedgerEntryTypeContractCode && k.ContractCode.Hash == o.hash {
+				present = true
+				break
+			}
+		}
+		if !present {
+			footprint.ReadOnly = append(footprint.ReadOnly, xdr.LedgerKey{
+				Type:         xdr.LedgerEntryTypeContractCode,
+				ContractCode: &xdr.LedgerKeyContractCode{Hash: o.hash},
+			})
+		}
+	}
+	return xdr.MarshalBase64(env)
+}
+
+// applyWasmOverrides returns entries with each overridden contract's
+// instance pointing at its override WASM and that WASM's code entry added.
+// The contract's instance must be in entries and run WASM. entries itself is
+// left untouched.
+func applyWasmOverrides(ctx context.Context, client *rpc.Client, overrides []*wasmOverride, entries map[string]string) (map[string]string, error) {
+	out := make(map[string]string, len(entries)+len(overrides))
+	for k, v := range entries {
+		out[k] = v
+	}
+	for _, o := range overrides {
+		instanceKey, err := xdr.MarshalBase64(xdr.LedgerKey{
+			Type: xdr.LedgerEntryTypeContractData,
+			ContractData: &xdr.LedgerKeyContractData{
+				Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &o.contractID},
+				Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+				Durability: xdr.ContractDataDurabilityPersistent,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		instanceXdr, ok := out[instanceKey]
+		if !ok {
+			return nil, fmt.Errorf("contract %s is not in the transaction's footprint", o.contract)
+		}
+		var instance xdr.LedgerEntry
+		if err := xdr.SafeUnmarshalBase64(instanceXdr, &instance); err != nil {
+			return nil, fmt.Errorf("failed to decode the instance of %s: %w", o.contract, err)
+		}
+		cd := instance.Data.ContractData
+		if cd == nil || cd.Val.Instance == nil {
+			return nil, fmt.Errorf("the ledger entry for %s is not a contract instance", o.contract)
+		}
+		exec := &cd.Val.Instance.Executable
+		if exec.Type != xdr.ContractExecutableTypeContractExecutableWasm || exec.WasmHash == nil {
+			return nil, fmt.Errorf("contract %s is a Stellar Asset Contract and runs no WASM", o.contract)
+		}
+		o.from = hex.EncodeToString(exec.WasmHash[:])
+		hash := o.hash
+		exec.WasmHash = &hash
+		if out[instanceKey], err = xdr.MarshalBase64(instance); err != nil {
+			return nil, err
+		}
+
+		codeKey, err := o.codeKey()
+		if err != nil {
+			return nil, err
+		}
+		if o.code == nil {
+			code, err := client.GetLedgerEntries(ctx, []string{codeKey})
+			if err != nil || code[codeKey] == "" {
+				return nil, fmt.Errorf("WASM %s for %s could not be fetched from the network: %v", o.hashHex(), o.contract, err)
+			}
+			if _, err := decoder.WasmSize(code[codeKey], o.hashHex()); err != nil {
+				return nil, fmt.Errorf("WASM %s for %s: %w", o.hashHex(), o.contract, err)
+			}
+			out[codeKey] = code[codeKey]
+			continue
+		}
+		codeEntry := xdr.LedgerEntry{
+			LastModifiedLedgerSeq: instance.LastModifiedLedgerSeq,
+			Data: xdr.LedgerEntryData{
+				Type:         xdr.LedgerEntryTypeContractCode,
+				ContractCode: &xdr.ContractCodeEntry{Hash: o.hash, Code: o.code},
+			},
+		}
+		if out[codeKey], err = xdr.MarshalBase64(codeEntry); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// printWasmOverrides says which contracts run substituted code, once the
+// overrides have been applied.
+func printWasmOverrides(out io.Writer, overrides []*wasmOverride) {
+	for _, o := range overrides {
+		if o.from == o.hashHex() {
+			fmt.Fprintf(out, "%s --override-wasm: %s already runs WASM %s\n", visualizer.Warning(), o.contract, o.from)
+			continue
+		}
+		fmt.Fprintf(out, "%s Contract %s runs WASM %s instead of the deployed %s\n", visualizer.Warning(), o.contract, o.hashHex(), o.from)
+	}
+}
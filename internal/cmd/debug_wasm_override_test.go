@@ -0,0 +1,139 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// overrideTestWasm is a minimal module holding only a contractspecv0 custom
+// section.
+func overrideTestWasm() []byte {
+	name := "contractspecv0"
+	payload := []byte{1, 2, 3}
+	section := append([]byte{byte(len(name))}, name...)
+	section = append(section, payload...)
+	return append([]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x00, byte(len(section))}, section...)
+}
+
+func overrideInstance(t *testing.T, id xdr.ContractId, exec xdr.ContractExecutable) (string, string) {
+	t.Helper()
+	addr := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &id}
+	key, err := xdr.MarshalBase64(xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   addr,
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	})
+	require.NoError(t, err)
+	entry, err := xdr.MarshalBase64(xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 9,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeContractData,
+			ContractData: &xdr.ContractDataEntry{
+				Contract:   addr,
+				Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+				Durability: xdr.ContractDataDurabilityPersistent,
+				Val:        xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{Executable: exec}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	return key, entry
+}
+
+func TestParseWasmOverrides(t *testing.T) {
+	contract := strkey.MustEncode(strkey.VersionByteContract, make([]byte, 32))
+	hash := hex.EncodeToString(bytes.Repeat([]byte{0xab}, 32))
+
+	overrides, err := parseWasmOverrides([]string{contract + "=" + hash})
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	assert.Equal(t, hash, overrides[0].hashHex())
+	assert.Nil(t, overrides[0].code, "a hash is fetched from the network")
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "old.wasm")
+	require.NoError(t, os.WriteFile(file, overrideTestWasm(), 0o600))
+	overrides, err = parseWasmOverrides([]string{contract + "=" + file})
+	require.NoError(t, err)
+	sum := sha256.Sum256(overrideTestWasm())
+	assert.Equal(t, hex.EncodeToString(sum[:]), overrides[0].hashHex())
+
+	notWasm := filepath.Join(dir, "bad.wasm")
+	require.NoError(t, os.WriteFile(notWasm, []byte("not wasm at all"), 0o600))
+	_, err = parseWasmOverrides([]string{contract + "=" + notWasm})
+	assert.Error(t, err)
+
+	_, err = parseWasmOverrides([]string{"GABC=" + hash})
+	assert.Error(t, err)
+	_, err = parseWasmOverrides([]string{contract})
+	assert.Error(t, err)
+	_, err = parseWasmOverrides([]string{contract + "=" + hash, contract + "=" + hash})
+	assert.Error(t, err)
+}
+
+func TestApplyWasmOverrides(t *testing.T) {
+	id := xdr.ContractId{5}
+	deployed := xdr.Hash{1}
+	key, entry := overrideInstance(t, id, xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableWasm, WasmHash: &deployed})
+	code := overrideTestWasm()
+	o := &wasmOverride{contract: strkey.MustEncode(strkey.VersionByteContract, id[:]), contractID: id, hash: sha256.Sum256(code), code: code}
+	entries := map[string]string{key: entry}
+
+	got, err := applyWasmOverrides(context.Background(), nil, []*wasmOverride{o}, entries)
+	require.NoError(t, err)
+	assert.Equal(t, entry, entries[key], "the original entries are left untouched")
+	assert.Equal(t, hex.EncodeToString(deployed[:]), o.from)
+
+	var instance xdr.LedgerEntry
+	require.NoError(t, xdr.SafeUnmarshalBase64(got[key], &instance))
+	assert.Equal(t, o.hash, *instance.Data.ContractData.Val.Instance.Executable.WasmHash)
+	codeKey, err := o.codeKey()
+	require.NoError(t, err)
+	var codeEntry xdr.LedgerEntry
+	require.NoError(t, xdr.SafeUnmarshalBase64(got[codeKey], &codeEntry))
+	assert.Equal(t, code, codeEntry.Data.ContractCode.Code)
+
+	var out bytes.Buffer
+	printWasmOverrides(&out, []*wasmOverride{o})
+	assert.Contains(t, out.String(), "instead of the deployed "+o.from)
+
+	_, err = applyWasmOverrides(context.Background(), nil, []*wasmOverride{o}, map[string]string{})
+	assert.ErrorContains(t, err, "not in the transaction's footprint")
+
+	sacKey, sac := overrideInstance(t, id, xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableStellarAsset})
+	_, err = applyWasmOverrides(context.Background(), nil, []*wasmOverride{o}, map[string]string{sacKey: sac})
+	assert.ErrorContains(t, err, "Stellar Asset Contract")
+}
+
+func TestRewriteEnvelopeForWasms(t *testing.T) {
+	envXdr, _ := feeTestEnvelope(t, 100, 0)
+	o := &wasmOverride{hash: xdr.Hash{9}}
+
+	rewritten, err := rewriteEnvelopeForWasms(envXdr, []*wasmOverride{o})
+	require.NoError(t, err)
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(rewritten, &env))
+	readOnly := env.V1.Tx.Ext.SorobanData.Resources.Footprint.ReadOnly
+	require.Len(t, readOnly, 1)
+	assert.Equal(t, o.hash, readOnly[0].ContractCode.Hash)
+
+	again, err := rewriteEnvelopeForWasms(rewritten, []*wasmOverride{o})
+	require.NoError(t, err)
+	assert.Equal(t, rewritten, again, "a key already in the footprint is not added twice")
+}
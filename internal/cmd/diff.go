@@ -0,0 +1,247 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dotandev/hintents/internal/bundle"
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/session"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffRerunFlag       bool
+	diffCompareModeFlag string
+	diffOutputFlag      string
+	diffFailFlag        bool
+)
+
+// diffSource is one side of erst diff: a saved session or a replay bundle.
+type diffSource struct {
+	Ref     string `json:"ref"`
+	Kind    string `json:"kind"` // "session" or "bundle"
+	TxHash  string `json:"tx_hash,omitempty"`
+	Network string `json:"network,omitempty"`
+
+	// request re-runs the simulation; response is the stored result, if any.
+	request  *simulator.SimulationRequest
+	response *simulator.SimulationResponse
+}
+
+// diffOutput is the JSON form of erst diff.
+type diffOutput struct {
+	A    *diffSource         `json:"a"`
+	B    *diffSource         `json:"b"`
+	Mode compare.Mode        `json:"compare_mode"`
+	Diff *compare.DiffResult `json:"diff"`
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <session-or-bundle-a> <session-or-bundle-b>",
+	Short: "Diff the simulation results of two saved sessions or bundles",
+	Long: `Compare two previously saved debug runs without touching the network.
+
+Each argument is either a session ID from 'erst session list' or the path of a
+replay bundle written by 'erst debug --save' (a directory, .tar.gz or .zip).
+Sessions, and bundles saved with --redact, are compared using their stored
+simulation result; other bundles, and sessions with --rerun, are simulated
+again from their saved inputs. Redacted bundles are structurally intact but
+have their signatures removed, so re-running them may not reproduce the
+original outcome.
+
+The diff is rendered with A in the "local" column and B in the "on-chain"
+column. Use --fail-on-divergence to make the command exit non-zero when the
+runs differ, for regression checks in CI.`,
+	Example: `  # Compare two saved sessions
+  erst diff abc123-1700000000 abc123-1700086400
+
+  # Compare bundles in CI, failing if execution diverged
+  erst diff baseline.erst.tar.gz candidate.erst.tar.gz --fail-on-divergence
+
+  # Ignore event order and emit JSON
+  erst diff before/ after/ --compare-mode set --output json`,
+	Args: cobra.ExactArgs(2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		switch diffOutputFlag {
+		case outputFormatText, outputFormatJSON:
+		default:
+			return errors.WrapValidationError(fmt.Sprintf("unsupported output format %q (expected text or json)", diffOutputFlag))
+		}
+		mode, err := compare.ParseMode(diffCompareModeFlag)
+		if err != nil {
+			return errors.WrapValidationError(err.Error())
+		}
+		diffCompareModeFlag = string(mode)
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		var store *session.Store
+		defer func() {
+			if store != nil {
+				store.Close()
+			}
+		}()
+		openStore := func() (*session.Store, error) {
+			if store == nil {
+				s, err := session.NewStore()
+				if err != nil {
+					return nil, errors.WrapValidationError(fmt.Sprintf("failed to open session store: %v", err))
+				}
+				store = s
+			}
+			return store, nil
+		}
+
+		var runner simulator.RunnerInterface
+		newRunner := func() (simulator.RunnerInterface, error) {
+			if runner == nil {
+				r, err := simulator.NewRunner("", false)
+				if err != nil {
+					return nil, errors.WrapSimulatorNotFound(err.Error())
+				}
+				runner = r
+			}
+			return runner, nil
+		}
+
+		sources := make([]*diffSource, 2)
+		results := make([]*simulator.SimulationResponse, 2)
+		for i, ref := range args {
+			src, err := loadDiffSource(ctx, ref, openStore)
+			if err != nil {
+				return err
+			}
+			res, err := src.result(diffRerunFlag, newRunner)
+			if err != nil {
+				return err
+			}
+			sources[i], results[i] = src, res
+		}
+
+		mode := compare.Mode(diffCompareModeFlag)
+		diff := compare.DiffWithMode(results[0], results[1], mode)
+
+		if diffOutputFlag == outputFormatJSON {
+			if err := report.WriteJSON(cmd.OutOrStdout(), diffOutput{A: sources[0], B: sources[1], Mode: mode, Diff: diff}); err != nil {
+				return errors.WrapMarshalFailed(err)
+			}
+		} else {
+			printDiffSources(cmd.OutOrStdout(), sources[0], sources[1])
+			compare.Render(diff)
+		}
+
+		if diffFailFlag && diff.HasDivergence {
+			return errors.WrapResultsMismatch("simulation results diverge")
+		}
+		return nil
+	},
+}
+
+// loadDiffSource resolves ref as a bundle path if one exists there, and as a
+// session ID otherwise. The session store is only opened when needed.
+func loadDiffSource(ctx context.Context, ref string, openStore func() (*session.Store, error)) (*diffSource, error) {
+	if _, err := os.Stat(ref); err == nil {
+		b, err := bundle.Load(ref)
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("failed to load bundle %s: %v", ref, err))
+		}
+		return &diffSource{
+			Ref:     ref,
+			Kind:    "bundle",
+			TxHash:  b.Manifest.TxHash,
+			Network: b.Manifest.Network,
+			request: &simulator.SimulationRequest{
+				EnvelopeXdr:   b.EnvelopeXdr,
+				ResultMetaXdr: b.ResultMetaXdr,
+				LedgerEntries: b.Entries,
+			},
+			response: b.Simulation,
+		}, nil
+	}
+
+	store, err := openStore()
+	if err != nil {
+		return nil, err
+	}
+	data, err := store.Load(ctx, ref)
+	if err != nil {
+		return nil, errors.WrapSessionNotFound(ref)
+	}
+	if data.SchemaVersion > session.SchemaVersion {
+		return nil, errors.WrapProtocolUnsupported(uint32(data.SchemaVersion))
+	}
+
+	src := &diffSource{Ref: ref, Kind: "session", TxHash: data.TxHash, Network: data.Network}
+	if data.SimRequestJSON != "" {
+		if src.request, err = data.ToSimulationRequest(); err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, "session "+ref)
+		}
+	}
+	if data.SimResponseJSON != "" {
+		if src.response, err = data.ToSimulationResponse(); err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, "session "+ref)
+		}
+	}
+	return src, nil
+}
+
+// result returns the stored simulation result, or re-runs the simulation
+// when there is none or rerun is set.
+func (s *diffSource) result(rerun bool, newRunner func() (simulator.RunnerInterface, error)) (*simulator.SimulationResponse, error) {
+	if s.response != nil && !rerun {
+		return s.response, nil
+	}
+	if s.request == nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("%s %s has no stored simulation result or request to re-run", s.Kind, s.Ref))
+	}
+
+	runner, err := newRunner()
+	if err != nil {
+		return nil, err
+	}
+	res, err := runner.Run(s.request)
+	if err != nil {
+		return nil, errors.WrapSimulationFailed(err, "")
+	}
+	return res, nil
+}
+
+func printDiffSources(out io.Writer, a, b *diffSource) {
+	for _, side := range []struct {
+		name string
+		src  *diffSource
+	}{{"A (local)", a}, {"B (on-chain)", b}} {
+		fmt.Fprintf(out, "%-13s %s %s", side.name, side.src.Kind, side.src.Ref)
+		if side.src.TxHash != "" {
+			fmt.Fprintf(out, " [tx %s", side.src.TxHash)
+			if side.src.Network != "" {
+				fmt.Fprintf(out, " on %s", side.src.Network)
+			}
+			fmt.Fprint(out, "]")
+		}
+		fmt.Fprintln(out)
+	}
+	if a.TxHash != "" && b.TxHash != "" && a.TxHash != b.TxHash {
+		fmt.Fprintln(out, "Note: the two runs are for different transactions")
+	}
+}
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffRerunFlag, "rerun", false, "Re-simulate sessions and redacted bundles from their saved inputs instead of using the stored result")
+	diffCmd.Flags().StringVar(&diffCompareModeFlag, "compare-mode", string(compare.ModeStrict), "How events are matched: strict (by position), set (ignore order), normalized (also ignore formatting and core_metrics resource counter events)")
+	diffCmd.Flags().StringVar(&diffOutputFlag, "output", outputFormatText, "Output format: text or json")
+	diffCmd.Flags().BoolVar(&diffFailFlag, "fail-on-divergence", false, "Exit non-zero when the two results differ")
+
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/bundle"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/session"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDiffSource_Bundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.erst.tar.gz")
+	tx := &rpc.TransactionResponse{EnvelopeXdr: "ENV", ResultMetaXdr: "META"}
+	require.NoError(t, bundle.Save(path, bundle.New("abc", "testnet", "v1", tx, []string{"k"}, map[string]string{"k": "v"})))
+
+	openStore := func() (*session.Store, error) {
+		t.Fatal("session store opened for a bundle path")
+		return nil, nil
+	}
+	src, err := loadDiffSource(context.Background(), path, openStore)
+	require.NoError(t, err)
+	assert.Equal(t, "bundle", src.Kind)
+	assert.Equal(t, "abc", src.TxHash)
+	assert.Equal(t, "testnet", src.Network)
+	assert.Equal(t, "ENV", src.request.EnvelopeXdr)
+	assert.Equal(t, map[string]string{"k": "v"}, src.request.LedgerEntries)
+}
+
+func TestDiffSourceResult(t *testing.T) {
+	stored := &simulator.SimulationResponse{Status: "success"}
+	rerun := &simulator.SimulationResponse{Status: "error"}
+	req := &simulator.SimulationRequest{EnvelopeXdr: "ENV"}
+
+	runner := new(MockRunner)
+	runner.On("Run", mock.Anything).Return(rerun, nil)
+	newRunner := func() (simulator.RunnerInterface, error) { return runner, nil }
+
+	src := &diffSource{Kind: "session", Ref: "s1", request: req, response: stored}
+
+	res, err := src.result(false, newRunner)
+	require.NoError(t, err)
+	assert.Same(t, stored, res)
+	runner.AssertNotCalled(t, "Run", mock.Anything)
+
+	res, err = src.result(true, newRunner)
+	require.NoError(t, err)
+	assert.Same(t, rerun, res)
+
+	empty := &diffSource{Kind: "session", Ref: "s2"}
+	_, err = empty.result(false, newRunner)
+	assert.Error(t, err)
+}
+
+func TestPrintDiffSources(t *testing.T) {
+	var buf bytes.Buffer
+	printDiffSources(&buf,
+		&diffSource{Kind: "session", Ref: "s1", TxHash: "aaa", Network: "testnet"},
+		&diffSource{Kind: "bundle", Ref: "b.zip", TxHash: "bbb"},
+	)
+
+	out := buf.String()
+	assert.Contains(t, out, "A (local)     session s1 [tx aaa on testnet]")
+	assert.Contains(t, out, "B (on-chain)  bundle b.zip [tx bbb]")
+	assert.Contains(t, out, "different transactions")
+}
@@ -4,10 +4,10 @@
 package cmd
 
 import (
-	"encoding/base64"
 	"fmt"
 	"os"
 
+	"github.com/dotandev/hintents/internal/decoder"
 	"github.com/dotandev/hintents/internal/errors"
 	"github.com/dotandev/hintents/internal/rpc"
 	"github.com/dotandev/hintents/internal/simulator"
@@ -33,7 +33,7 @@ var dryRunCmd = &cobra.Command{
 	Long: `Replay a local transaction envelope (not yet on chain) against current network state.
 
 This command:
-  1) Loads a base64-encoded TransactionEnvelope XDR from a local file
+  1) Loads a TransactionEnvelope XDR from a local file (base64, URL-safe base64 or hex)
   2) Fetches required ledger entries from the configured Soroban RPC
   3) Replays the transaction locally via the Rust simulator
   4) Prints an estimated required fee based on the observed resource usage
@@ -59,25 +59,34 @@ func init() {
 	rootCmd.AddCommand(dryRunCmd)
 }
 
-func runDryRun(cmd *cobra.Command, args []string) error {
-	path := args[0]
+// loadEnvelopeFile reads the TransactionEnvelope XDR in the file at path,
+// which may hold base64, URL-safe base64 or hex, and returns it re-encoded
+// as standard base64.
+func loadEnvelopeFile(path string) (string, *xdr.TransactionEnvelope, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
-		return errors.WrapValidationError(fmt.Sprintf("failed to read tx file: %v", err))
+		return "", nil, errors.WrapValidationError(fmt.Sprintf("failed to read tx file: %v", err))
 	}
 	envXdrB64 := string(bytesTrimSpace(b))
 	if envXdrB64 == "" {
-		return errors.WrapValidationError("tx file is empty")
+		return "", nil, errors.WrapValidationError("tx file is empty")
 	}
 
-	// Validate envelope is parseable
-	envBytes, err := base64.StdEncoding.DecodeString(envXdrB64)
-	if err != nil {
-		return errors.WrapUnmarshalFailed(err, "envelope base64")
-	}
+	// Validate envelope is parseable; the file may hold base64 or hex
 	var envelope xdr.TransactionEnvelope
-	if err := xdr.SafeUnmarshal(envBytes, &envelope); err != nil {
-		return errors.WrapUnmarshalFailed(err, "TransactionEnvelope")
+	if err := decoder.DecodeXDRText(envXdrB64, decoder.EncodingAuto, &envelope, "transaction envelope"); err != nil {
+		return "", nil, errors.WrapUnmarshalFailed(err, "TransactionEnvelope")
+	}
+	if envXdrB64, err = xdr.MarshalBase64(envelope); err != nil {
+		return "", nil, errors.WrapMarshalFailed(err)
+	}
+	return envXdrB64, &envelope, nil
+}
+
+func runDryRun(cmd *cobra.Command, args []string) error {
+	envXdrB64, _, err := loadEnvelopeFile(args[0])
+	if err != nil {
+		return err
 	}
 
 	// Create RPC client
@@ -116,7 +125,7 @@ func runDryRun(cmd *cobra.Command, args []string) error {
 	}
 
 	// Fallback: local simulator heuristic (best-effort)
-	keys, err := extractLedgerKeysFromEnvelope(&envelope)
+	keys, _, err := discoverLedgerKeys(ctx, client, envXdrB64)
 	if err != nil {
 		return errors.WrapSimulationLogicError(fmt.Sprintf("failed to extract ledger keys from envelope: %v", err))
 	}
@@ -183,13 +192,3 @@ func bytesTrimSpace(b []byte) []byte {
 	}
 	return b[start:end]
 }
-
-func extractLedgerKeysFromEnvelope(env *xdr.TransactionEnvelope) ([]string, error) {
-	// Best-effort extraction: for Soroban invoke operations, footprint lives in SorobanTransactionDataExt
-	// which is not always present / easy to reconstruct without full parsing.
-	// For now we return an empty list, letting simulation rely on internal defaults (may reduce accuracy).
-	//
-	// TODO: Implement full footprint extraction via soroban tx data when available.
-	_ = env
-	return []string{}, nil
-}
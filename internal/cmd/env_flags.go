@@ -0,0 +1,55 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// envFlagPrefix prefixes the environment variables that set flag values.
+const envFlagPrefix = "ERST_"
+
+// flagEnvName returns the environment variable for a flag:
+// --compare-network is ERST_COMPARE_NETWORK.
+func flagEnvName(name string) string {
+	return envFlagPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// applyEnvFlags sets every flag of the global flags, and of the debug
+// command when it is the one running, that was not given on the command line
+// from its ERST_ environment variable, for containerized and CI use. A flag
+// set this way counts as given, so the precedence is flag > env > config
+// file > default. List flags take comma-separated values, as a single
+// --flag a,b would.
+func applyEnvFlags(cmd *cobra.Command) error {
+	sets := []*pflag.FlagSet{cmd.Root().PersistentFlags()}
+	if cmd == debugCmd {
+		sets = append(sets, cmd.Flags())
+	}
+	for _, flags := range sets {
+		var err error
+		flags.VisitAll(func(f *pflag.Flag) {
+			if err != nil || f.Changed || f.Name == "help" {
+				return
+			}
+			value, ok := os.LookupEnv(flagEnvName(f.Name))
+			if !ok {
+				return
+			}
+			if setErr := flags.Set(f.Name, value); setErr != nil {
+				err = errors.WrapValidationError(fmt.Sprintf("invalid %s=%q for --%s: %v", flagEnvName(f.Name), value, f.Name, setErr))
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagEnvName(t *testing.T) {
+	assert.Equal(t, "ERST_COMPARE_NETWORK", flagEnvName("compare-network"))
+	assert.Equal(t, "ERST_OFFLINE", flagEnvName("offline"))
+}
+
+func TestApplyEnvFlags(t *testing.T) {
+	var (
+		offline  bool
+		limits   []string
+		agent    string
+		localOpt string
+	)
+	root := &cobra.Command{Use: "root"}
+	root.PersistentFlags().BoolVar(&offline, "offline", false, "")
+	root.PersistentFlags().StringSliceVar(&limits, "rate-limit", nil, "")
+	root.PersistentFlags().StringVar(&agent, "user-agent", "", "")
+	child := &cobra.Command{Use: "child"}
+	child.Flags().StringVar(&localOpt, "local-opt", "", "")
+	root.AddCommand(child)
+
+	t.Setenv("ERST_OFFLINE", "true")
+	t.Setenv("ERST_RATE_LIMIT", "mainnet=2,testnet=5")
+	t.Setenv("ERST_USER_AGENT", "from-env")
+	t.Setenv("ERST_LOCAL_OPT", "ignored")
+
+	// A flag given on the command line wins over its variable
+	require.NoError(t, root.PersistentFlags().Set("user-agent", "from-flag"))
+	require.NoError(t, applyEnvFlags(child))
+
+	assert.True(t, offline)
+	assert.Equal(t, []string{"mainnet=2", "testnet=5"}, limits)
+	assert.Equal(t, "from-flag", agent)
+	assert.Empty(t, localOpt, "only the debug command's own flags are read from the environment")
+	assert.True(t, root.PersistentFlags().Changed("offline"))
+}
+
+func TestApplyEnvFlags_Invalid(t *testing.T) {
+	var offline bool
+	root := &cobra.Command{Use: "root"}
+	root.PersistentFlags().BoolVar(&offline, "offline", false, "")
+
+	t.Setenv("ERST_OFFLINE", "maybe")
+	err := applyEnvFlags(root)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrValidationFailed))
+	assert.Contains(t, err.Error(), "ERST_OFFLINE")
+}
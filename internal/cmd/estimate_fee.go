@@ -0,0 +1,259 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/dotandev/hintents/internal/config"
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/report"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// minInclusionFee is the network's minimum base fee in stroops, the least
+// each operation can bid for inclusion.
+const minInclusionFee = 100
+
+var (
+	estimateFeeNetworkFlag string
+	estimateFeeRPCURLFlag  string
+	estimateFeeRPCToken    string
+	estimateFeeOutputFlag  string
+)
+
+// feeEstimate is the result of `erst estimate-fee`, and its JSON form. All
+// fees are in stroops.
+type feeEstimate struct {
+	Network      string `json:"network"`
+	LatestLedger uint32 `json:"latest_ledger,omitempty"`
+	ResourceFee  int64  `json:"resource_fee"`
+	// InclusionFee is the per-operation bid: the network minimum and, when
+	// getFeeStats answered, recent Soroban percentiles.
+	InclusionFee inclusionFeeEstimate `json:"inclusion_fee"`
+	// InclusionOps is how many operations the inclusion fee is paid for;
+	// a fee bump pays for one more than its inner transaction has.
+	InclusionOps   int64                     `json:"inclusion_ops"`
+	MinimumFee     int64                     `json:"minimum_fee"`
+	RecommendedFee int64                     `json:"recommended_fee,omitempty"`
+	Resources      *decoder.SorobanResources `json:"resources"`
+	Restore        *restoreEstimate          `json:"restore,omitempty"`
+
+	// What the envelope declares, to explain an insufficient fee.
+	DeclaredFee         int64 `json:"declared_fee"`
+	DeclaredResourceFee int64 `json:"declared_resource_fee,omitempty"`
+}
+
+type inclusionFeeEstimate struct {
+	Minimum int64 `json:"minimum"`
+	P50     int64 `json:"p50,omitempty"`
+	P90     int64 `json:"p90,omitempty"`
+}
+
+// restoreEstimate is the restore transaction that must land first when the
+// footprint includes archived entries.
+type restoreEstimate struct {
+	ResourceFee int64 `json:"resource_fee"`
+	Entries     int   `json:"entries"`
+}
+
+var estimateFeeCmd = &cobra.Command{
+	Use:   "estimate-fee <tx.xdr>",
+	Short: "Estimate the fee a Soroban transaction needs before submitting it",
+	Long: `Preflight a local transaction envelope with Soroban RPC simulateTransaction
+and break down the fee it needs: the resource fee for its instructions,
+ledger reads and writes (and rent), plus the inclusion fee, from the network
+minimum and recent getFeeStats percentiles.
+
+The envelope's own fee is compared against the estimate, which answers the
+common "why is my fee insufficient" question before the network rejects it.
+
+The file may hold base64, URL-safe base64 or hex XDR.
+
+Examples:
+  erst estimate-fee ./tx.xdr --network testnet
+  erst estimate-fee ./tx.xdr --output json`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if estimateFeeOutputFlag != outputFormatText && estimateFeeOutputFlag != outputFormatJSON {
+			return errors.WrapValidationError(fmt.Sprintf("unsupported output format %q (expected text or json)", estimateFeeOutputFlag))
+		}
+		return parseNetworkFlag(&estimateFeeNetworkFlag)
+	},
+	RunE: runEstimateFee,
+}
+
+func runEstimateFee(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	envXdrB64, envelope, err := loadEnvelopeFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	token := estimateFeeRPCToken
+	if token == "" {
+		token = os.Getenv("ERST_RPC_TOKEN")
+	}
+	if token == "" {
+		if cfg, err := config.LoadConfig(); err == nil && cfg.RPCToken != "" {
+			token = cfg.RPCToken
+		}
+	}
+	opts := []rpc.ClientOption{
+		rpc.WithNetwork(rpc.Network(estimateFeeNetworkFlag)),
+		rpc.WithToken(token),
+	}
+	if estimateFeeRPCURLFlag != "" {
+		opts = append(opts, rpc.WithSorobanURL(estimateFeeRPCURLFlag))
+	}
+	client, err := rpc.NewClient(opts...)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to create client: %v", err))
+	}
+
+	preflight, err := client.SimulateTransaction(ctx, envXdrB64)
+	if err != nil {
+		return err
+	}
+	stats, err := client.GetFeeStats(ctx)
+	if err != nil {
+		logger.Logger.Warn("Could not fetch fee stats; estimating with the minimum inclusion fee only", "error", err)
+	}
+
+	est, err := newFeeEstimate(estimateFeeNetworkFlag, envXdrB64, envelope, preflight, stats)
+	if err != nil {
+		return err
+	}
+	if estimateFeeOutputFlag == outputFormatJSON {
+		if err := report.WriteJSON(cmd.OutOrStdout(), est); err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+		return nil
+	}
+	printFeeEstimate(cmd.OutOrStdout(), est)
+	return nil
+}
+
+// newFeeEstimate breaks down the fee of the simulated envelope. stats may
+// be nil.
+func newFeeEstimate(network, envXdrB64 string, envelope *xdr.TransactionEnvelope, preflight *rpc.SimulateTransactionResponse, stats *rpc.FeeStats) (*feeEstimate, error) {
+	result := preflight.Result
+	if result.Error != "" {
+		return nil, errors.WrapSimulationReverted(fmt.Sprintf("the transaction fails to simulate, so its fee cannot be estimated: %s", result.Error))
+	}
+	if result.TransactionData == "" {
+		return nil, errors.WrapValidationError("simulateTransaction returned no resources; estimate-fee only prices Soroban (contract) transactions")
+	}
+
+	est := &feeEstimate{
+		Network:      network,
+		LatestLedger: result.LatestLedger,
+		InclusionFee: inclusionFeeEstimate{Minimum: minInclusionFee},
+		InclusionOps: int64(envelope.OperationsCount()),
+		DeclaredFee:  int64(envelope.Fee()),
+	}
+	if envelope.IsFeeBump() {
+		est.InclusionOps++
+		est.DeclaredFee = envelope.FeeBumpFee()
+	}
+
+	var err error
+	if est.ResourceFee, err = strconv.ParseInt(result.MinResourceFee, 10, 64); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "minResourceFee "+result.MinResourceFee)
+	}
+	if est.Resources, err = decoder.DecodeSorobanTransactionData(result.TransactionData); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "simulated transaction data")
+	}
+	if declared, err := decoder.DecodeSorobanResources(envXdrB64, ""); err == nil && declared != nil {
+		est.DeclaredResourceFee = declared.ResourceFee
+	}
+	if p := result.RestorePreamble; p != nil {
+		restore := &restoreEstimate{}
+		if restore.ResourceFee, err = strconv.ParseInt(p.MinResourceFee, 10, 64); err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, "restore preamble minResourceFee "+p.MinResourceFee)
+		}
+		if data, err := decoder.DecodeSorobanTransactionData(p.TransactionData); err == nil {
+			restore.Entries = len(data.ReadWrite)
+		}
+		est.Restore = restore
+	}
+
+	est.MinimumFee = est.ResourceFee + est.InclusionOps*minInclusionFee
+	if stats != nil {
+		// Unparseable percentiles are left out rather than guessed
+		est.InclusionFee.P50, _ = strconv.ParseInt(stats.SorobanInclusionFee.P50, 10, 64)
+		est.InclusionFee.P90, _ = strconv.ParseInt(stats.SorobanInclusionFee.P90, 10, 64)
+		if est.InclusionFee.P90 > 0 {
+			est.RecommendedFee = est.ResourceFee + est.InclusionOps*max(est.InclusionFee.P90, minInclusionFee)
+		}
+	}
+	return est, nil
+}
+
+func printFeeEstimate(out io.Writer, est *feeEstimate) {
+	fmt.Fprintf(out, "Fee estimate on %s", est.Network)
+	if est.LatestLedger > 0 {
+		fmt.Fprintf(out, " (ledger %d)", est.LatestLedger)
+	}
+	fmt.Fprintln(out)
+
+	res := est.Resources
+	fmt.Fprintf(out, "\nResources:\n")
+	fmt.Fprintf(out, "  Instructions:  %d\n", res.Instructions)
+	fmt.Fprintf(out, "  Disk read:     %d bytes\n", res.DiskReadBytes)
+	fmt.Fprintf(out, "  Write:         %d bytes\n", res.WriteBytes)
+	fmt.Fprintf(out, "  Footprint:     %d read-only, %d read-write entries\n", len(res.ReadOnly), len(res.ReadWrite))
+
+	fmt.Fprintf(out, "\nFees (stroops):\n")
+	fmt.Fprintf(out, "  Resource fee:  %d (includes rent for new entries and TTL extensions)\n", est.ResourceFee)
+	fmt.Fprintf(out, "  Inclusion fee: %d minimum", est.InclusionFee.Minimum)
+	if est.InclusionFee.P50 > 0 {
+		fmt.Fprintf(out, ", %d median, %d p90 of recent Soroban transactions", est.InclusionFee.P50, est.InclusionFee.P90)
+	}
+	if est.InclusionOps > 1 {
+		fmt.Fprintf(out, ", per operation (x%d)", est.InclusionOps)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "  Minimum fee:   %d\n", est.MinimumFee)
+	if est.RecommendedFee > 0 {
+		fmt.Fprintf(out, "  Recommended:   %d (p90 inclusion fee)\n", est.RecommendedFee)
+	}
+
+	if est.Restore != nil {
+		fmt.Fprintf(out, "\n%s %d archived entries must be restored first, by a separate transaction with a resource fee of %d\n",
+			visualizer.Warning(), est.Restore.Entries, est.Restore.ResourceFee)
+	}
+
+	fmt.Fprintf(out, "\nDeclared fee:    %d\n", est.DeclaredFee)
+	switch {
+	case est.DeclaredFee < est.MinimumFee:
+		fmt.Fprintf(out, "%s The declared fee is %d short of the minimum; the network will reject the transaction\n",
+			visualizer.Error(), est.MinimumFee-est.DeclaredFee)
+	case est.RecommendedFee > 0 && est.DeclaredFee < est.RecommendedFee:
+		fmt.Fprintf(out, "%s The declared fee covers the minimum but may not be included while the network is busy\n", visualizer.Warning())
+	default:
+		fmt.Fprintf(out, "%s The declared fee covers the estimate\n", visualizer.Success())
+	}
+	if est.DeclaredResourceFee > 0 && est.DeclaredResourceFee < est.ResourceFee {
+		fmt.Fprintf(out, "%s The envelope's Soroban data declares a resource fee of %d, below the %d needed; re-assemble it from the simulation\n",
+			visualizer.Warning(), est.DeclaredResourceFee, est.ResourceFee)
+	}
+}
+
+func init() {
+	estimateFeeCmd.Flags().StringVarP(&estimateFeeNetworkFlag, "network", "n", string(rpc.Mainnet), "Stellar network to use (testnet, mainnet, futurenet)")
+	estimateFeeCmd.Flags().StringVar(&estimateFeeRPCURLFlag, "rpc-url", "", "Custom Soroban RPC URL to use")
+	estimateFeeCmd.Flags().StringVar(&estimateFeeRPCToken, "rpc-token", "", "RPC authentication token (can also use ERST_RPC_TOKEN env var)")
+	estimateFeeCmd.Flags().StringVarP(&estimateFeeOutputFlag, "output", "o", outputFormatText, "Output format: text or json")
+
+	rootCmd.AddCommand(estimateFeeCmd)
+}
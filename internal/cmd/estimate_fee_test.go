@@ -0,0 +1,107 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// feeTestEnvelope is a one-operation Soroban transaction bidding fee, with
+// Soroban data declaring resourceFee.
+func feeTestEnvelope(t *testing.T, fee uint32, resourceFee int64) (string, *xdr.TransactionEnvelope) {
+	t.Helper()
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: xdr.MustMuxedAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"),
+			Fee:           xdr.Uint32(fee),
+			Operations: []xdr.Operation{{Body: xdr.OperationBody{
+				Type:                 xdr.OperationTypeInvokeHostFunction,
+				InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{HostFunction: xdr.HostFunction{Type: xdr.HostFunctionTypeHostFunctionTypeUploadContractWasm, Wasm: &[]byte{}}},
+			}}},
+			Ext: xdr.TransactionExt{V: 1, SorobanData: &xdr.SorobanTransactionData{ResourceFee: xdr.Int64(resourceFee)}},
+		}},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return b64, &env
+}
+
+func simulatedFee(t *testing.T, minResourceFee string) *rpc.SimulateTransactionResponse {
+	t.Helper()
+	data, err := xdr.MarshalBase64(xdr.SorobanTransactionData{
+		Resources: xdr.SorobanResources{
+			Footprint: xdr.LedgerFootprint{
+				ReadOnly: []xdr.LedgerKey{{
+					Type:    xdr.LedgerEntryTypeAccount,
+					Account: &xdr.LedgerKeyAccount{AccountId: xdr.MustAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")},
+				}},
+				ReadWrite: []xdr.LedgerKey{},
+			},
+			Instructions:  1_500_000,
+			DiskReadBytes: 2048,
+			WriteBytes:    512,
+		},
+		ResourceFee: 9000,
+	})
+	require.NoError(t, err)
+	resp := &rpc.SimulateTransactionResponse{}
+	resp.Result.MinResourceFee = minResourceFee
+	resp.Result.TransactionData = data
+	resp.Result.LatestLedger = 700
+	return resp
+}
+
+func TestNewFeeEstimate(t *testing.T) {
+	b64, env := feeTestEnvelope(t, 5000, 4000)
+	stats := &rpc.FeeStats{SorobanInclusionFee: rpc.FeeDistribution{P50: "150", P90: "800"}}
+
+	est, err := newFeeEstimate("testnet", b64, env, simulatedFee(t, "9000"), stats)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9000), est.ResourceFee)
+	assert.Equal(t, int64(1), est.InclusionOps)
+	assert.Equal(t, int64(9100), est.MinimumFee)
+	assert.Equal(t, int64(9800), est.RecommendedFee)
+	assert.Equal(t, int64(5000), est.DeclaredFee)
+	assert.Equal(t, int64(4000), est.DeclaredResourceFee)
+	assert.Equal(t, uint32(1_500_000), est.Resources.Instructions)
+	assert.Len(t, est.Resources.ReadOnly, 1)
+
+	var buf bytes.Buffer
+	printFeeEstimate(&buf, est)
+	out := buf.String()
+	assert.Contains(t, out, "Instructions:  1500000")
+	assert.Contains(t, out, "Minimum fee:   9100")
+	assert.Contains(t, out, "4100 short of the minimum")
+	assert.Contains(t, out, "declares a resource fee of 4000, below the 9000 needed")
+}
+
+func TestNewFeeEstimate_WithoutStats(t *testing.T) {
+	b64, env := feeTestEnvelope(t, 20000, 9000)
+
+	est, err := newFeeEstimate("testnet", b64, env, simulatedFee(t, "9000"), nil)
+	require.NoError(t, err)
+	assert.Zero(t, est.RecommendedFee)
+
+	var buf bytes.Buffer
+	printFeeEstimate(&buf, est)
+	assert.Contains(t, buf.String(), "The declared fee covers the estimate")
+	assert.NotContains(t, buf.String(), "Recommended")
+}
+
+func TestNewFeeEstimate_SimulationError(t *testing.T) {
+	b64, env := feeTestEnvelope(t, 100, 0)
+	resp := &rpc.SimulateTransactionResponse{}
+	resp.Result.Error = "HostError: Error(Contract, #3)"
+
+	_, err := newFeeEstimate("testnet", b64, env, resp, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Error(Contract, #3)")
+}
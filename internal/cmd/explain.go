@@ -42,12 +42,7 @@ Examples:
 		if err := rpc.ValidateTransactionHash(args[0]); err != nil {
 			return fmt.Errorf("invalid transaction hash: %w", err)
 		}
-		switch rpc.Network(explainNetworkFlag) {
-		case rpc.Testnet, rpc.Mainnet, rpc.Futurenet:
-		default:
-			return fmt.Errorf("invalid network: %s; must be testnet, mainnet, or futurenet", explainNetworkFlag)
-		}
-		return nil
+		return parseNetworkFlag(&explainNetworkFlag)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
@@ -113,7 +108,7 @@ func explainFromNetwork(cmd *cobra.Command, txHash string) error {
 		return fmt.Errorf("failed to fetch transaction: %w", err)
 	}
 
-	keys, err := extractLedgerKeys(resp.ResultMetaXdr)
+	keys, err := transactionLedgerKeys(cmd.Context(), cmd.ErrOrStderr(), client, resp)
 	if err != nil {
 		keys = nil
 	}
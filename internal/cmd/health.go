@@ -0,0 +1,137 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/spf13/cobra"
+)
+
+// healthProbeTimeout bounds each individual endpoint probe.
+const healthProbeTimeout = 10 * time.Second
+
+var (
+	healthNetworkFlag string
+	healthOutputFlag  string
+)
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check reachability and ledger height of RPC endpoints",
+	Long: `Probe the Horizon and Soroban RPC endpoints of each network and report
+whether they are reachable, how long they took to answer, and the latest
+ledger they know about.
+
+Horizon is checked through its root document; Soroban RPC through getHealth,
+falling back to getLatestLedger when the health response has no ledger.
+Use this to tell an endpoint problem apart from a transaction problem.`,
+	Example: `  # Check every network
+  erst health
+
+  # Check only testnet, as JSON
+  erst health --network testnet --output json`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		switch healthOutputFlag {
+		case outputFormatText, outputFormatJSON:
+		default:
+			return errors.WrapValidationError(fmt.Sprintf("unsupported output format %q (expected text or json)", healthOutputFlag))
+		}
+		if healthNetworkFlag != "" {
+			return parseNetworkFlag(&healthNetworkFlag)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		networks := []rpc.Network{rpc.Testnet, rpc.Mainnet, rpc.Futurenet}
+		if healthNetworkFlag != "" {
+			networks = []rpc.Network{rpc.Network(healthNetworkFlag)}
+		}
+
+		statuses, err := probeNetworks(cmd.Context(), networks)
+		if err != nil {
+			return err
+		}
+
+		if healthOutputFlag == outputFormatJSON {
+			data, err := json.MarshalIndent(statuses, "", "  ")
+			if err != nil {
+				return errors.WrapMarshalFailed(err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+
+		printEndpointStatuses(cmd.OutOrStdout(), statuses)
+		return nil
+	},
+}
+
+// probeNetworks checks the Horizon and Soroban endpoints of every network
+// concurrently. Results keep the network order, Horizon before Soroban.
+func probeNetworks(ctx context.Context, networks []rpc.Network) ([]rpc.EndpointStatus, error) {
+	statuses := make([]rpc.EndpointStatus, 2*len(networks))
+
+	var wg sync.WaitGroup
+	for i, n := range networks {
+		client, err := rpc.NewClient(rpc.WithNetwork(n), rpc.WithToken(os.Getenv("ERST_RPC_TOKEN")))
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("failed to create client for %s: %v", n, err))
+		}
+
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+			defer cancel()
+			statuses[2*i] = client.ProbeHorizon(probeCtx)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+			defer cancel()
+			statuses[2*i+1] = client.ProbeSoroban(probeCtx)
+		}(i)
+	}
+	wg.Wait()
+
+	return statuses, nil
+}
+
+func printEndpointStatuses(out io.Writer, statuses []rpc.EndpointStatus) {
+	network := ""
+	for _, s := range statuses {
+		if s.Network != network {
+			network = s.Network
+			fmt.Fprintf(out, "\n%s\n", network)
+		}
+
+		if !s.Reachable {
+			fmt.Fprintf(out, "  %s %-8s %s (%dms): %s\n", visualizer.Error(), s.Kind, s.URL, s.LatencyMs, s.Error)
+			continue
+		}
+		fmt.Fprintf(out, "  %s %-8s %s (%dms) ledger %d", visualizer.Success(), s.Kind, s.URL, s.LatencyMs, s.LatestLedger)
+		if s.Status != "" && s.Status != "healthy" {
+			fmt.Fprintf(out, " [%s]", s.Status)
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+func init() {
+	healthCmd.Flags().StringVarP(&healthNetworkFlag, "network", "n", "", "Only check this network (testnet, mainnet, futurenet)")
+	healthCmd.Flags().StringVar(&healthOutputFlag, "output", outputFormatText, "Output format: text or json")
+
+	rootCmd.AddCommand(healthCmd)
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintEndpointStatuses(t *testing.T) {
+	var buf bytes.Buffer
+	printEndpointStatuses(&buf, []rpc.EndpointStatus{
+		{Network: "testnet", Kind: rpc.EndpointHorizon, URL: "https://h", Reachable: true, LatencyMs: 12, LatestLedger: 100, Status: "healthy"},
+		{Network: "testnet", Kind: rpc.EndpointSoroban, URL: "https://s", LatencyMs: 30, Error: "connection refused"},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "testnet\n")
+	assert.Contains(t, out, "https://h (12ms) ledger 100")
+	assert.Contains(t, out, "https://s (30ms): connection refused")
+}
+
+func TestHealthPreRunE_Validation(t *testing.T) {
+	prevNet, prevOut := healthNetworkFlag, healthOutputFlag
+	t.Cleanup(func() { healthNetworkFlag, healthOutputFlag = prevNet, prevOut })
+
+	healthNetworkFlag, healthOutputFlag = "testnet", outputFormatJSON
+	assert.NoError(t, healthCmd.PreRunE(healthCmd, nil))
+
+	healthOutputFlag = "yaml"
+	assert.Error(t, healthCmd.PreRunE(healthCmd, nil))
+
+	healthNetworkFlag, healthOutputFlag = "devnet", outputFormatText
+	assert.Error(t, healthCmd.PreRunE(healthCmd, nil))
+}
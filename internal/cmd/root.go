@@ -4,7 +4,20 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
 	"github.com/dotandev/hintents/internal/localization"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/terminal"
 	"github.com/dotandev/hintents/internal/updater"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +27,22 @@ var (
 	TimestampFlag int64
 	WindowFlag    int64
 	ProfileFlag   bool
+	OfflineFlag   bool
+	RateLimitFlag []string
+	CAFileFlag    string
+	InsecureFlag  bool
+	UserAgentFlag string
+
+	PrintCurlFlag       bool
+	UnsafePrintCurlFlag bool
+
+	NoColorFlag bool
+
+	MaxResponseBytesFlag int64
+
+	MaxIdleConnsPerHostFlag int
+	MaxConnsPerHostFlag     int
+	IdleConnTimeoutFlag     time.Duration
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -38,15 +67,87 @@ Examples:
   erst session list                          View saved sessions
   erst cache status                          Check cache usage
 
+Exit codes:
+  0    success, or the compared results matched
+  1    any other error
+  2    the simulation ran and the transaction failed (e.g. a contract revert)
+  3    an RPC or network request failed
+  4    results differed (--fail-on-mismatch, --fail-on-divergence, --check)
+  5    invalid input (flags, arguments or files)
+  6    the transaction exceeded its declared resources
+  7    the transaction's resource fee was insufficient
+  8    the transaction failed an authorization check
+  9    the transaction needed a ledger entry that is missing or archived
+  130  interrupted
+
 Get started with 'erst debug --help' or visit the documentation.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Flags not given on the command line may come from ERST_ variables
+		if err := applyEnvFlags(cmd); err != nil {
+			return err
+		}
+
 		// Load localizations
 		if err := localization.LoadTranslations(); err != nil {
 			return err
 		}
 
+		// Refuse all network access from RPC clients created by any command
+		rpc.SetOffline(OfflineFlag)
+
+		// Throttle each network's RPC clients independently
+		limits, err := parseRateLimitFlag(RateLimitFlag)
+		if err != nil {
+			return err
+		}
+		rpc.SetRateLimits(limits)
+
+		// Trust a private CA, or skip verification for local setups
+		tlsConfig, err := rpc.LoadTLSConfig(CAFileFlag, InsecureFlag)
+		if err != nil {
+			return err
+		}
+		if InsecureFlag {
+			fmt.Fprintln(os.Stderr, "Warning: --insecure disables TLS certificate verification for RPC requests")
+		}
+		rpc.SetTLSConfig(tlsConfig)
+
+		// Bound every RPC response, whatever endpoint --rpc-url points at
+		if MaxResponseBytesFlag <= 0 {
+			return errors.WrapValidationError("--max-response-bytes must be positive")
+		}
+		rpc.SetMaxResponseBytes(MaxResponseBytesFlag)
+
+		// Share one tuned connection pool across every RPC client
+		if MaxIdleConnsPerHostFlag < 0 || MaxConnsPerHostFlag < 0 || IdleConnTimeoutFlag < 0 {
+			return errors.WrapValidationError("--max-idle-conns-per-host, --max-conns-per-host and --idle-conn-timeout must not be negative")
+		}
+		pool := rpc.DefaultPoolConfig()
+		pool.MaxIdleConnsPerHost = MaxIdleConnsPerHostFlag
+		pool.MaxConnsPerHost = MaxConnsPerHostFlag
+		pool.IdleConnTimeout = IdleConnTimeoutFlag
+		rpc.SetPoolConfig(pool)
+
+		// Identify erst to RPC providers that log or rate-limit by agent
+		rpc.SetUserAgent(userAgent())
+
+		// Echo every RPC request as a reproducible curl command
+		if PrintCurlFlag || UnsafePrintCurlFlag {
+			if UnsafePrintCurlFlag {
+				fmt.Fprintln(os.Stderr, "Warning: --unsafe-print-curl prints RPC credentials unredacted")
+			}
+			rpc.SetCurlOutput(os.Stderr, UnsafePrintCurlFlag)
+		} else {
+			rpc.SetCurlOutput(nil, false)
+		}
+
+		// Plain output for logs and terminals without color
+		terminal.SetColorEnabled(!NoColorFlag)
+
 		// Check for updates asynchronously (non-blocking)
-		checkForUpdatesAsync()
+		if !OfflineFlag {
+			checkForUpdatesAsync()
+		}
 
 		return nil
 	},
@@ -54,10 +155,125 @@ Get started with 'erst debug --help' or visit the documentation.`,
 	SilenceErrors: true,
 }
 
+// Exit codes returned by erst, so scripts can branch on the kind of failure
+// instead of parsing stderr. See ExitCode.
+const (
+	ExitCodeOK = 0
+	// ExitCodeError is any failure not covered by a more specific code.
+	ExitCodeError = 1
+	// ExitCodeSimulationFailed means the simulation ran and the transaction
+	// failed, e.g. a contract revert.
+	ExitCodeSimulationFailed = 2
+	// ExitCodeNetwork means an RPC or network request failed.
+	ExitCodeNetwork = 3
+	// ExitCodeMismatch means results differed where a match was required
+	// (--fail-on-mismatch, --fail-on-divergence or a failed --check rule).
+	ExitCodeMismatch = 4
+	// ExitCodeInvalidInput means a flag, argument or input file was invalid.
+	ExitCodeInvalidInput = 5
+	// ExitCodeResourceLimit, ExitCodeInsufficientFee, ExitCodeAuthFailed
+	// and ExitCodeStorageMissing refine ExitCodeSimulationFailed when the
+	// transaction failed for a reason other than its contract logic; see
+	// simulator.FailureCategory.
+	ExitCodeResourceLimit   = 6
+	ExitCodeInsufficientFee = 7
+	ExitCodeAuthFailed      = 8
+	ExitCodeStorageMissing  = 9
+	// ExitCodeInterrupted is the conventional exit status of a process
+	// stopped by SIGINT (128 + 2).
+	ExitCodeInterrupted = 130
+)
+
+// exitCodeClasses maps error sentinels, and the codes of classified
+// simulator errors, to exit codes. Classes are checked in order so that the
+// most specific outcome wins.
+var exitCodeClasses = []struct {
+	code     int
+	matching []error
+	erstCode []errors.ErstErrorCode
+}{
+	{ExitCodeInterrupted, []error{errors.ErrInterrupted}, nil},
+	{ExitCodeMismatch, []error{errors.ErrResultsMismatch, errors.ErrChecksFailed}, nil},
+	{ExitCodeResourceLimit, []error{errors.ErrResourceLimitExceeded}, nil},
+	{ExitCodeInsufficientFee, []error{errors.ErrInsufficientFee}, nil},
+	{ExitCodeAuthFailed, []error{errors.ErrAuthFailed}, nil},
+	{ExitCodeStorageMissing, []error{errors.ErrStorageMissing}, nil},
+	// The simulator reports a reverted transaction, including a Wasm trap,
+	// as an error that the runner classifies.
+	{ExitCodeSimulationFailed, []error{errors.ErrSimulationReverted},
+		[]errors.ErstErrorCode{errors.CodeSimExecFailed, errors.CodeSimLogicError, errors.CodeSimCrash}},
+	{ExitCodeNetwork, []error{
+		errors.ErrTransactionNotFound, errors.ErrRPCConnectionFailed, errors.ErrRPCTimeout,
+		errors.ErrAllRPCFailed, errors.ErrRPCError, errors.ErrRateLimitExceeded,
+		errors.ErrRPCResponseTooLarge, errors.ErrLedgerNotFound, errors.ErrLedgerArchived,
+		errors.ErrNetworkNotFound, errors.ErrUnauthorized, errors.ErrOffline,
+	}, nil},
+	{ExitCodeInvalidInput, []error{
+		errors.ErrValidationFailed, errors.ErrInvalidNetwork, errors.ErrArgumentRequired,
+		errors.ErrProtocolUnsupported, errors.ErrWasmInvalid, errors.ErrXDRCorrupt,
+		errors.ErrSessionNotFound,
+	}, nil},
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+//
+// The command runs with a context that is cancelled on SIGINT or SIGTERM, so
+// in-flight RPC calls and simulations stop. The run then returns
+// errors.ErrInterrupted; see ExitCode.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
+	if errors.Is(context.Cause(ctx), errors.ErrInterrupted) {
+		fmt.Fprintln(os.Stderr, "\nAborted.")
+		return errors.ErrInterrupted
+	}
+	return err
+}
+
+// ExitCode returns the process exit status for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+	for _, class := range exitCodeClasses {
+		for _, target := range class.matching {
+			if errors.Is(err, target) {
+				return class.code
+			}
+		}
+		for _, code := range class.erstCode {
+			if errors.IsErstCode(err, code) {
+				return class.code
+			}
+		}
+	}
+	return ExitCodeError
+}
+
+// interruptContext returns a context cancelled with errors.ErrInterrupted on
+// the first SIGINT or SIGTERM. The handler is removed after that signal, so a
+// second Ctrl-C kills the process if cleanup hangs.
+func interruptContext(parent context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(parent)
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sigCh:
+			signal.Stop(sigCh)
+			cancel(errors.ErrInterrupted)
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(sigCh)
+		cancel(nil)
+	}
 }
 
 // checkForUpdatesAsync runs the update check in a goroutine to not block CLI startup
@@ -70,6 +286,50 @@ func checkForUpdatesAsync() {
 	}()
 }
 
+// parseNetworkFlag validates a --network style flag and rewrites it to the
+// canonical network name, so aliases such as "public" work downstream.
+func parseNetworkFlag(flag *string) error {
+	n, err := rpc.ParseNetwork(*flag)
+	if err != nil {
+		return err
+	}
+	*flag = n.String()
+	return nil
+}
+
+// parseRateLimitFlag parses --rate-limit values. Each is either a rate in
+// requests per second, applied to every network, or network=rate to set one
+// network's rate; a later value for the same network wins.
+func parseRateLimitFlag(values []string) (map[rpc.Network]float64, error) {
+	limits := make(map[rpc.Network]float64, len(values))
+	for _, v := range values {
+		var network rpc.Network
+		rate := v
+		if name, r, ok := strings.Cut(v, "="); ok {
+			n, err := rpc.ParseNetwork(name)
+			if err != nil {
+				return nil, err
+			}
+			network, rate = n, r
+		}
+		rps, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		if err != nil || !(rps >= 0) || math.IsInf(rps, 0) {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid --rate-limit %q: expected requests per second, optionally as network=rate", v))
+		}
+		limits[network] = rps
+	}
+	return limits, nil
+}
+
+// userAgent is the User-Agent erst sends to RPC providers: --user-agent, or
+// erst/<version>.
+func userAgent() string {
+	if UserAgentFlag != "" {
+		return UserAgentFlag
+	}
+	return "erst/" + Version
+}
+
 func init() {
 	// Root command initialization
 	rootCmd.PersistentFlags().Int64Var(
@@ -93,6 +353,95 @@ func init() {
 		"Enable CPU/Memory profiling and generate a flamegraph SVG",
 	)
 
+	rootCmd.PersistentFlags().BoolVar(
+		&OfflineFlag,
+		"offline",
+		false,
+		"Refuse all network access; only --replay bundles and cached data are used",
+	)
+
+	rootCmd.PersistentFlags().StringSliceVar(
+		&RateLimitFlag,
+		"rate-limit",
+		nil,
+		"Max RPC requests per second, e.g. 5 for every network or mainnet=2 for one (repeatable)",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&CAFileFlag,
+		"ca-file",
+		"",
+		"PEM bundle of extra root CAs to trust for RPC and Horizon endpoints (e.g. a corporate CA)",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&InsecureFlag,
+		"insecure",
+		false,
+		"Skip TLS certificate verification for RPC and Horizon endpoints (local quickstart only)",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&UserAgentFlag,
+		"user-agent",
+		"",
+		"User-Agent header for RPC and Horizon requests (default erst/<version>)",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&PrintCurlFlag,
+		"print-curl",
+		false,
+		"Print an equivalent curl command for every RPC request to stderr, with credentials redacted",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&UnsafePrintCurlFlag,
+		"unsafe-print-curl",
+		false,
+		"Like --print-curl, but print auth headers and credentials unredacted",
+	)
+
+	rootCmd.PersistentFlags().Int64Var(
+		&MaxResponseBytesFlag,
+		"max-response-bytes",
+		rpc.DefaultMaxResponseBytes,
+		"Fail any RPC or Horizon response larger than this many bytes",
+	)
+
+	rootCmd.PersistentFlags().IntVar(
+		&MaxIdleConnsPerHostFlag,
+		"max-idle-conns-per-host",
+		rpc.DefaultPoolConfig().MaxIdleConnsPerHost,
+		"Idle RPC connections kept open per host for reuse across requests and clients",
+	)
+
+	rootCmd.PersistentFlags().IntVar(
+		&MaxConnsPerHostFlag,
+		"max-conns-per-host",
+		0,
+		"Maximum RPC connections open at once per host (0 = unlimited)",
+	)
+
+	rootCmd.PersistentFlags().DurationVar(
+		&IdleConnTimeoutFlag,
+		"idle-conn-timeout",
+		rpc.DefaultPoolConfig().IdleConnTimeout,
+		"How long an idle RPC connection is kept open for reuse",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&NoColorFlag,
+		"no-color",
+		false,
+		"Disable colored output, as NO_COLOR=1 or CLICOLOR=0 do",
+	)
+
+	// Bad flags are invalid input, for ExitCode
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return errors.WrapValidationError(err.Error())
+	})
+
 	// Register commands
 	rootCmd.AddCommand(statsCmd)
 }
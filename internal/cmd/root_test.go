@@ -0,0 +1,87 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimitFlag(t *testing.T) {
+	limits, err := parseRateLimitFlag([]string{"5", "mainnet=1.5", "public=2", "testnet=0"})
+	require.NoError(t, err)
+	assert.Equal(t, map[rpc.Network]float64{"": 5, rpc.Mainnet: 2, rpc.Testnet: 0}, limits)
+
+	for _, bad := range []string{"fast", "-1", "mainnet=", "devnet=3", "NaN", "Inf"} {
+		_, err := parseRateLimitFlag([]string{bad})
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, ExitCode(nil))
+	assert.Equal(t, 1, ExitCode(fmt.Errorf("boom")))
+	assert.Equal(t, 2, ExitCode(errors.WrapSimulationReverted("HostError: Error(Contract, #3)")))
+	assert.Equal(t, 2, ExitCode(fmt.Errorf("simulate: %w", errors.NewSimErrorMsg(errors.CodeSimExecFailed, "trapped"))))
+	assert.Equal(t, 1, ExitCode(errors.WrapSimCrash(fmt.Errorf("signal: killed"), "")), "a crashed simulator is not a revert")
+	assert.Equal(t, 3, ExitCode(errors.WrapRPCTimeout(fmt.Errorf("deadline exceeded"))))
+	assert.Equal(t, 3, ExitCode(errors.WrapTransactionNotFound(fmt.Errorf("404"))))
+	assert.Equal(t, 4, ExitCode(errors.WrapResultsMismatch("results differ")))
+	assert.Equal(t, 4, ExitCode(errors.WrapChecksFailed(2)))
+	assert.Equal(t, 5, ExitCode(errors.WrapValidationError("bad flag")))
+	assert.Equal(t, ExitCodeInterrupted, ExitCode(fmt.Errorf("run: %w", errors.ErrInterrupted)))
+	assert.Equal(t, 6, ExitCode(errors.WrapSimulationFailure(errors.ErrResourceLimitExceeded, "Error(Budget, ExceededLimit)")))
+	assert.Equal(t, 7, ExitCode(errors.WrapSimulationFailure(errors.ErrInsufficientFee, "")))
+	assert.Equal(t, 8, ExitCode(errors.WrapSimulationFailure(errors.ErrAuthFailed, "")))
+	assert.Equal(t, 9, ExitCode(errors.WrapRPCConnectionFailed(errors.WrapSimulationFailure(errors.ErrStorageMissing, "archived"))),
+		"a categorized failure wins over the wrapping it gets on the way out")
+
+	// A mismatch that also wraps a revert is reported as the mismatch.
+	both := fmt.Errorf("%w: %w", errors.ErrResultsMismatch, errors.ErrSimulationReverted)
+	assert.Equal(t, ExitCodeMismatch, ExitCode(both))
+}
+
+func TestExitCode_FlagErrorsAreInvalidInput(t *testing.T) {
+	err := rootCmd.FlagErrorFunc()(rootCmd, fmt.Errorf("unknown flag: --nope"))
+	assert.Equal(t, ExitCodeInvalidInput, ExitCode(err))
+	assert.Contains(t, err.Error(), "unknown flag: --nope")
+}
+
+func TestUserAgent(t *testing.T) {
+	prev := UserAgentFlag
+	t.Cleanup(func() { UserAgentFlag = prev })
+
+	UserAgentFlag = ""
+	assert.Equal(t, "erst/"+Version, userAgent())
+
+	UserAgentFlag = "acme-ci/1.0 (ops@example.com)"
+	assert.Equal(t, "acme-ci/1.0 (ops@example.com)", userAgent())
+}
+
+func TestInterruptContext(t *testing.T) {
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	p, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Skipf("cannot signal own process: %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		assert.ErrorIs(t, context.Cause(ctx), errors.ErrInterrupted)
+	case <-time.After(5 * time.Second):
+		t.Fatal("context was not cancelled by SIGINT")
+	}
+}
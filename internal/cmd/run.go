@@ -0,0 +1,364 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runConcurrencyFlag int
+	runReportDirFlag   string
+)
+
+// debugJob is one named `erst debug` run from a jobs file.
+type debugJob struct {
+	Name            string
+	Hash            string
+	Network         string
+	CompareNetworks []string
+	// Checks are rule files, resolved against the jobs file's directory.
+	Checks []string
+	// Args are further debug flags, passed through as they are.
+	Args []string
+}
+
+// jobResult is the outcome of one job. ExitCode is the job's erst exit
+// status; Err is set when the job could not be started at all.
+type jobResult struct {
+	Job      debugJob
+	ExitCode int
+	Err      error
+	Elapsed  time.Duration
+	Report   string
+	Log      string
+}
+
+func (r jobResult) passed() bool {
+	return r.Err == nil && r.ExitCode == ExitCodeOK
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run <jobs.toml>",
+	Short: "Run a file of named debug jobs and report an overall pass or fail",
+	Long: `Run every debug job defined in a jobs file, for scheduled regression
+monitoring. Each job replays one transaction, optionally against compare
+networks and post-simulation --check rule files, and writes a JSON report
+and a log to --report-dir. The command fails if any job does.
+
+Jobs are TOML tables named [job.<name>]:
+
+  [job.swap]
+  hash = "5c0a...ab"
+  network = "testnet"
+  compare_network = ["futurenet"]
+  checks = ["rules/swap.rules"]
+  args = ["--show-ttl"]
+
+Only hash is required; network defaults to mainnet. Check paths are relative
+to the jobs file. Each job runs as its own erst debug process, at most
+--concurrency at a time.
+
+Examples:
+  erst run jobs.toml
+  erst run jobs.toml --concurrency 8 --report-dir nightly`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if runConcurrencyFlag < 1 {
+			return errors.WrapValidationError("--concurrency must be at least 1")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jobs, err := loadJobsFile(args[0])
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(runReportDirFlag, 0o755); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("failed to create report directory: %v", err))
+		}
+		self, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to locate the erst binary: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Running %d jobs from %s (concurrency %d)\n", len(jobs), args[0], runConcurrencyFlag)
+		results := runJobs(cmd.Context(), jobs, runConcurrencyFlag, func(ctx context.Context, job debugJob) jobResult {
+			res := execJob(ctx, self, job, runReportDirFlag)
+			printJobResult(out, res)
+			return res
+		})
+		return summarizeJobs(out, results)
+	},
+}
+
+// jobNamePattern keeps job names usable as file names.
+var jobNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+func loadJobsFile(path string) ([]debugJob, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to open jobs file: %v", err))
+	}
+	defer f.Close()
+
+	jobs, err := parseJobs(f)
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("%s: %v", path, err))
+	}
+	dir := filepath.Dir(path)
+	for i := range jobs {
+		for j, c := range jobs[i].Checks {
+			if !filepath.IsAbs(c) {
+				jobs[i].Checks[j] = filepath.Join(dir, c)
+			}
+		}
+	}
+	return jobs, nil
+}
+
+// parseJobs reads the [job.<name>] tables of a jobs file. Values are quoted
+// strings or single-line arrays of them; blank lines and # comments are
+// skipped.
+func parseJobs(r io.Reader) ([]debugJob, error) {
+	var jobs []debugJob
+	var job *debugJob
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			name, ok := strings.CutPrefix(strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"), "job.")
+			if !ok || !strings.HasSuffix(line, "]") || !jobNamePattern.MatchString(name) {
+				return nil, fmt.Errorf("line %d: expected a [job.<name>] table, with letters, digits, '.', '_' or '-' in the name", lineNo)
+			}
+			for _, j := range jobs {
+				if j.Name == name {
+					return nil, fmt.Errorf("line %d: job %q is defined twice", lineNo, name)
+				}
+			}
+			jobs = append(jobs, debugJob{Name: name, Network: string(rpc.Mainnet)})
+			job = &jobs[len(jobs)-1]
+			continue
+		}
+		if job == nil {
+			return nil, fmt.Errorf("line %d: settings must follow a [job.<name>] table", lineNo)
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		raw = strings.TrimSpace(raw)
+		values, err := parseJobValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		switch key = strings.TrimSpace(key); key {
+		case "hash", "network":
+			if strings.HasPrefix(raw, "[") {
+				return nil, fmt.Errorf("line %d: %s takes a single string", lineNo, key)
+			}
+			if key == "hash" {
+				job.Hash = values[0]
+			} else {
+				job.Network = values[0]
+			}
+		case "compare_network":
+			job.CompareNetworks = values
+		case "checks":
+			job.Checks = values
+		case "args":
+			job.Args = values
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q (expected hash, network, compare_network, checks or args)", lineNo, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no [job.<name>] tables")
+	}
+	for _, j := range jobs {
+		if err := rpc.ValidateTransactionHash(j.Hash); err != nil {
+			return nil, fmt.Errorf("job %q: invalid hash: %v", j.Name, err)
+		}
+	}
+	return jobs, nil
+}
+
+// parseJobValue reads a quoted string, or an array of them, as a list.
+func parseJobValue(raw string) ([]string, error) {
+	if inner, ok := strings.CutPrefix(raw, "["); ok {
+		inner, ok = strings.CutSuffix(inner, "]")
+		if !ok {
+			return nil, fmt.Errorf("unterminated array %s", raw)
+		}
+		var values []string
+		for _, item := range strings.Split(inner, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			v, err := parseJobString(item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	}
+	v, err := parseJobString(raw)
+	if err != nil {
+		return nil, err
+	}
+	return []string{v}, nil
+}
+
+func parseJobString(raw string) (string, error) {
+	if len(raw) >= 2 && (raw[0] == '"' || raw[0] == '\'') && raw[len(raw)-1] == raw[0] {
+		return raw[1 : len(raw)-1], nil
+	}
+	return "", fmt.Errorf("expected a quoted string, got %s", raw)
+}
+
+// debugArgs builds the erst arguments that run job, writing its JSON report
+// to report.
+func (j debugJob) debugArgs(report string) []string {
+	args := []string{"debug", j.Hash, "--network", j.Network, "--report", report}
+	for _, n := range j.CompareNetworks {
+		args = append(args, "--compare-network", n)
+	}
+	for _, c := range j.Checks {
+		args = append(args, "--check", c)
+	}
+	return append(args, j.Args...)
+}
+
+// runJobs runs every job through run, at most concurrency at a time, and
+// returns the results in job order.
+func runJobs(ctx context.Context, jobs []debugJob, concurrency int, run func(context.Context, debugJob) jobResult) []jobResult {
+	results := make([]jobResult, len(jobs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job debugJob) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = run(ctx, job)
+		}(i, job)
+	}
+	wg.Wait()
+	return results
+}
+
+// execJob runs job as an erst debug child process of self, with its output
+// written to a log beside its report.
+func execJob(ctx context.Context, self string, job debugJob, dir string) jobResult {
+	res := jobResult{
+		Job:    job,
+		Report: filepath.Join(dir, job.Name+".json"),
+		Log:    filepath.Join(dir, job.Name+".log"),
+	}
+	log, err := os.Create(res.Log)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	defer log.Close()
+
+	start := time.Now()
+	c := exec.CommandContext(ctx, self, job.debugArgs(res.Report)...)
+	c.Stdout, c.Stderr = log, log
+	err = c.Run()
+	res.Elapsed = time.Since(start)
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		res.Err = err
+	}
+	return res
+}
+
+// exitCodeMeaning describes an erst exit status.
+func exitCodeMeaning(code int) string {
+	switch code {
+	case ExitCodeSimulationFailed:
+		return "simulation failed"
+	case ExitCodeNetwork:
+		return "network error"
+	case ExitCodeMismatch:
+		return "checks or comparison failed"
+	case ExitCodeInvalidInput:
+		return "invalid input"
+	case ExitCodeResourceLimit:
+		return "resource limit exceeded"
+	case ExitCodeInsufficientFee:
+		return "insufficient resource fee"
+	case ExitCodeAuthFailed:
+		return "authorization failed"
+	case ExitCodeStorageMissing:
+		return "ledger entry missing or archived"
+	case ExitCodeInterrupted:
+		return "interrupted"
+	default:
+		return "error"
+	}
+}
+
+func printJobResult(out io.Writer, r jobResult) {
+	switch {
+	case r.Err != nil:
+		fmt.Fprintf(out, "%s %s: could not run: %v\n", visualizer.Error(), r.Job.Name, r.Err)
+	case r.passed():
+		fmt.Fprintf(out, "%s %s passed (%s)\n", visualizer.Success(), r.Job.Name, r.Elapsed.Round(time.Millisecond))
+	default:
+		fmt.Fprintf(out, "%s %s failed: exit %d, %s (%s); see %s\n", visualizer.Error(), r.Job.Name, r.ExitCode,
+			exitCodeMeaning(r.ExitCode), r.Elapsed.Round(time.Millisecond), r.Log)
+	}
+}
+
+// summarizeJobs prints the overall outcome and fails if any job did.
+func summarizeJobs(out io.Writer, results []jobResult) error {
+	failed := 0
+	for _, r := range results {
+		if !r.passed() {
+			failed++
+		}
+	}
+	fmt.Fprintf(out, "\n%d of %d jobs passed\n", len(results)-failed, len(results))
+	if failed > 0 {
+		return errors.WrapBatchFailed(failed, len(results))
+	}
+	return nil
+}
+
+func init() {
+	runCmd.Flags().IntVar(&runConcurrencyFlag, "concurrency", 4, "Maximum number of jobs to run at once")
+	runCmd.Flags().StringVar(&runReportDirFlag, "report-dir", "erst-reports", "Directory for each job's JSON report and log")
+
+	rootCmd.AddCommand(runCmd)
+}
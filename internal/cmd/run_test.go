@@ -0,0 +1,136 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const runTestHash = "5c0a1234567890abcdef1234567890abcdef1234567890abcdef1234567890ab"
+
+func TestParseJobs(t *testing.T) {
+	src := `# nightly regressions
+[job.swap]
+hash = "` + runTestHash + `"
+network = "testnet"
+compare_network = ["futurenet", "mainnet"]
+checks = ["rules/swap.rules"]
+args = ["--show-ttl"]
+
+[job.mint]
+hash = '` + runTestHash + `'
+`
+	jobs, err := parseJobs(strings.NewReader(src))
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	assert.Equal(t, debugJob{
+		Name:            "swap",
+		Hash:            runTestHash,
+		Network:         "testnet",
+		CompareNetworks: []string{"futurenet", "mainnet"},
+		Checks:          []string{"rules/swap.rules"},
+		Args:            []string{"--show-ttl"},
+	}, jobs[0])
+	assert.Equal(t, "mint", jobs[1].Name)
+	assert.Equal(t, "mainnet", jobs[1].Network)
+}
+
+func TestParseJobs_Errors(t *testing.T) {
+	tests := map[string]string{
+		"no jobs":       "# empty\n",
+		"orphan key":    `hash = "` + runTestHash + `"`,
+		"bad table":     "[jobs.x]\n",
+		"bad name":      "[job.a/b]\n",
+		"duplicate":     "[job.a]\nhash = \"" + runTestHash + "\"\n[job.a]\n",
+		"unknown key":   "[job.a]\nhash = \"" + runTestHash + "\"\nfoo = \"x\"\n",
+		"unquoted":      "[job.a]\nhash = " + runTestHash + "\n",
+		"missing hash":  "[job.a]\nnetwork = \"testnet\"\n",
+		"hash as array": "[job.a]\nhash = [\"" + runTestHash + "\"]\n",
+	}
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseJobs(strings.NewReader(src))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadJobsFile_ResolvesChecksAgainstFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jobs.toml")
+	src := "[job.a]\nhash = \"" + runTestHash + "\"\nchecks = [\"a.rules\", \"/abs.rules\"]\n"
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+
+	jobs, err := loadJobsFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.rules"), "/abs.rules"}, jobs[0].Checks)
+}
+
+func TestDebugJobArgs(t *testing.T) {
+	job := debugJob{
+		Hash:            runTestHash,
+		Network:         "testnet",
+		CompareNetworks: []string{"futurenet"},
+		Checks:          []string{"a.rules"},
+		Args:            []string{"--show-ttl"},
+	}
+	assert.Equal(t, []string{
+		"debug", runTestHash, "--network", "testnet", "--report", "out/a.json",
+		"--compare-network", "futurenet", "--check", "a.rules", "--show-ttl",
+	}, job.debugArgs("out/a.json"))
+}
+
+func TestRunJobs_KeepsOrderAndLimitsConcurrency(t *testing.T) {
+	jobs := []debugJob{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}
+	var running, peak atomic.Int32
+	results := runJobs(context.Background(), jobs, 2, func(_ context.Context, job debugJob) jobResult {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return jobResult{Job: job}
+	})
+
+	require.Len(t, results, len(jobs))
+	for i, r := range results {
+		assert.Equal(t, jobs[i].Name, r.Job.Name)
+	}
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestSummarizeJobs(t *testing.T) {
+	var out bytes.Buffer
+	err := summarizeJobs(&out, []jobResult{
+		{Job: debugJob{Name: "a"}},
+		{Job: debugJob{Name: "b"}, ExitCode: ExitCodeMismatch},
+	})
+	require.Error(t, err)
+	assert.Contains(t, out.String(), "1 of 2 jobs passed")
+
+	out.Reset()
+	require.NoError(t, summarizeJobs(&out, []jobResult{{Job: debugJob{Name: "a"}}}))
+}
+
+func TestPrintJobResult_DescribesExitCode(t *testing.T) {
+	var out bytes.Buffer
+	printJobResult(&out, jobResult{Job: debugJob{Name: "swap"}, ExitCode: ExitCodeSimulationFailed, Log: "r/swap.log"})
+	assert.Contains(t, out.String(), "swap failed: exit 2, simulation failed")
+	assert.Contains(t, out.String(), "r/swap.log")
+}
@@ -6,7 +6,6 @@ package cmd
 import (
 	"bufio"
 	"context"
-	"encoding/base64"
 	"fmt"
 	"os"
 	"strings"
@@ -50,13 +49,7 @@ Shell Commands:
   exit                                          Exit the shell`,
 	Args: cobra.NoArgs,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		// Validate network flag
-		switch rpc.Network(shellNetworkFlag) {
-		case rpc.Testnet, rpc.Mainnet, rpc.Futurenet:
-			return nil
-		default:
-			return errors.WrapInvalidNetwork(shellNetworkFlag)
-		}
+		return parseNetworkFlag(&shellNetworkFlag)
 	},
 	RunE: runShell,
 }
@@ -74,11 +67,16 @@ func runShell(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
 	// Initialize RPC client
-	var rpcClient *rpc.Client
+	opts := []rpc.ClientOption{
+		rpc.WithNetwork(rpc.Network(shellNetworkFlag)),
+		rpc.WithToken(shellRPCToken),
+	}
 	if shellRPCURLFlag != "" {
-		rpcClient = rpc.NewClientWithURL(shellRPCURLFlag, rpc.Network(shellNetworkFlag))
-	} else {
-		rpcClient = rpc.NewClient(rpc.Network(shellNetworkFlag))
+		opts = append(opts, rpc.WithHorizonURL(shellRPCURLFlag))
+	}
+	rpcClient, err := rpc.NewClient(opts...)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to create client: %v", err))
 	}
 
 	// Initialize simulator runner
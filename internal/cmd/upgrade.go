@@ -105,7 +105,7 @@ Example:
 			return errors.WrapSimulationFailed(err, "")
 		}
 
-		printSimulationResult("Upgraded Contract", result)
+		printSimulationResult(cmd.OutOrStdout(), "Upgraded Contract", result)
 
 		return nil
 	},
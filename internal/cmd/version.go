@@ -4,58 +4,69 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"runtime/debug"
 	"time"
 
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/report"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Build information populated by ldflags
-	Version   = "dev"
-	CommitSHA = "unknown"
-	BuildDate = "unknown"
+	Version           = "dev"
+	CommitSHA         = "unknown"
+	BuildDate         = "unknown"
+	StellarSDKVersion = "unknown"
 )
 
+// stellarSDKModule is the module whose version is reported as the Stellar
+// SDK the binary was compiled against.
+const stellarSDKModule = "github.com/stellar/go-stellar-sdk"
+
 type VersionInfo struct {
-	Version   string `json:"version"`
-	CommitSHA string `json:"commit_sha"`
-	BuildDate string `json:"build_date"`
-	GoVersion string `json:"go_version"`
+	Version           string `json:"version"`
+	CommitSHA         string `json:"commit_sha"`
+	BuildDate         string `json:"build_date"`
+	GoVersion         string `json:"go_version"`
+	StellarSDKVersion string `json:"stellar_sdk_version"`
 }
 
 // versionCmd represents the version command
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
-	Long:  "Display detailed build information including version, commit hash, and build date",
+	Long:  "Display detailed build information including version, commit hash, build date and the Stellar SDK version. Include this output when filing bug reports.",
 	Args:  cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		out := cmd.OutOrStdout()
 
 		info := getVersionInfo()
 
 		if jsonOutput {
-			output, _ := json.MarshalIndent(info, "", "  ")
-			fmt.Println(string(output))
-		} else {
-			fmt.Printf("Erst Version: %s\n", info.Version)
-			fmt.Printf("Commit SHA:   %s\n", info.CommitSHA)
-			fmt.Printf("Build Date:   %s\n", info.BuildDate)
-			fmt.Printf("Go Version:   %s\n", info.GoVersion)
+			if err := report.WriteJSON(out, info); err != nil {
+				return errors.WrapMarshalFailed(err)
+			}
+			return nil
 		}
-		fmt.Printf("erst version %s\n", Version)
+		fmt.Fprintf(out, "Erst Version: %s\n", info.Version)
+		fmt.Fprintf(out, "Commit SHA:   %s\n", info.CommitSHA)
+		fmt.Fprintf(out, "Build Date:   %s\n", info.BuildDate)
+		fmt.Fprintf(out, "Go Version:   %s\n", info.GoVersion)
+		fmt.Fprintf(out, "Stellar SDK:  %s\n", info.StellarSDKVersion)
+		return nil
 	},
 }
 
 func getVersionInfo() VersionInfo {
 	info := VersionInfo{
-		Version:   Version,
-		CommitSHA: CommitSHA,
-		BuildDate: BuildDate,
-		GoVersion: "unknown",
+		Version:           Version,
+		CommitSHA:         CommitSHA,
+		BuildDate:         BuildDate,
+		GoVersion:         "unknown",
+		StellarSDKVersion: StellarSDKVersion,
 	}
 
 	// Use runtime/debug as fallback
@@ -77,11 +88,35 @@ func getVersionInfo() VersionInfo {
 				}
 			}
 		}
+
+		if info.StellarSDKVersion == "unknown" {
+			for _, dep := range buildInfo.Deps {
+				if dep.Path == stellarSDKModule {
+					info.StellarSDKVersion = dep.Version
+					if dep.Replace != nil && dep.Replace.Version != "" {
+						info.StellarSDKVersion = dep.Replace.Version
+					}
+					break
+				}
+			}
+		}
 	}
 
 	return info
 }
 
+// toolInfo describes this binary for the header of saved debug reports.
+func toolInfo() *report.ToolInfo {
+	info := getVersionInfo()
+	return &report.ToolInfo{
+		Version:           info.Version,
+		CommitSHA:         info.CommitSHA,
+		BuildDate:         info.BuildDate,
+		GoVersion:         info.GoVersion,
+		StellarSDKVersion: info.StellarSDKVersion,
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(versionCmd)
 	versionCmd.Flags().Bool("json", false, "Output version information in JSON format")
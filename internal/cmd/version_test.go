@@ -0,0 +1,45 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVersionInfo_PrefersLdflags(t *testing.T) {
+	origVersion, origCommit, origSDK := Version, CommitSHA, StellarSDKVersion
+	t.Cleanup(func() { Version, CommitSHA, StellarSDKVersion = origVersion, origCommit, origSDK })
+	Version, CommitSHA, StellarSDKVersion = "v1.2.3", "abc123", "v0.9.0"
+
+	info := getVersionInfo()
+	assert.Equal(t, "v1.2.3", info.Version)
+	assert.Equal(t, "abc123", info.CommitSHA)
+	assert.Equal(t, "v0.9.0", info.StellarSDKVersion)
+
+	tool := toolInfo()
+	assert.Equal(t, "v1.2.3", tool.Version)
+	assert.Equal(t, "v0.9.0", tool.StellarSDKVersion)
+}
+
+func TestVersionCmd_JSONIsValid(t *testing.T) {
+	var buf bytes.Buffer
+	versionCmd.SetOut(&buf)
+	t.Cleanup(func() {
+		versionCmd.SetOut(nil)
+		_ = versionCmd.Flags().Set("json", "false")
+	})
+	require.NoError(t, versionCmd.Flags().Set("json", "true"))
+
+	require.NoError(t, versionCmd.RunE(versionCmd, nil))
+
+	var info VersionInfo
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &info))
+	assert.Equal(t, Version, info.Version)
+	assert.NotEmpty(t, info.StellarSDKVersion)
+}
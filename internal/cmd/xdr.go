@@ -4,59 +4,76 @@
 package cmd
 
 import (
-	"encoding/base64"
+	"encoding/json"
 	"fmt"
 
 	"github.com/dotandev/hintents/internal/decoder"
 	"github.com/dotandev/hintents/internal/errors"
 	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/xdr"
 )
 
 var (
-	xdrFormat string
-	xdrData   string
-	xdrType   string
+	xdrFormat   string
+	xdrData     string
+	xdrType     string
+	xdrDepth    int
+	xdrOutput   string
+	xdrEncoding string
 )
 
 var xdrCmd = &cobra.Command{
 	Use:   "xdr",
 	Short: "Format and decode XDR data",
-	Long:  `Decode and format XDR structures to JSON or table format for easy inspection.`,
-	RunE:  xdrExec,
+	Long: `Decode and format XDR structures to JSON, table, or tree format for easy inspection.
+
+The tree format renders nested ScVal maps and vecs with indentation. Use --depth
+to cap how many nested levels are expanded, and --output json to emit the tree
+as JSON.
+
+The data may be standard or URL-safe base64, padded or not, or hex; the
+encoding is detected unless --encoding names it.
+
+Examples:
+  erst xdr --type ledger-entry --format tree --data <base64>
+  erst xdr --type ledger-entry --encoding hex --data <hex>
+  erst xdr --type scval --format tree --depth 2 --data <base64>
+  erst xdr --type scval --format tree --output json --data <base64>`,
+	RunE: xdrExec,
 }
 
 func xdrExec(cmd *cobra.Command, args []string) error {
 	if xdrData == "" {
 		return errors.WrapCliArgumentRequired("data")
 	}
+	if err := validateXDROutput(); err != nil {
+		return err
+	}
 
-	data, err := base64.StdEncoding.DecodeString(xdrData)
+	enc, err := decoder.ParseEncoding(xdrEncoding)
 	if err != nil {
-		return errors.WrapValidationError(fmt.Sprintf("invalid base64 input: %v", err))
+		return errors.WrapValidationError(err.Error())
+	}
+	output, err := decodeXDRInput(xdrData, enc, xdrType)
+	if err != nil {
+		return err
 	}
 
-	var output interface{}
+	formatter := decoder.NewXDRFormatter(decoder.FormatType(xdrFormat)).WithMaxDepth(xdrDepth)
 
-	switch xdrType {
-	case "ledger-entry":
-		le, err := decoder.DecodeXDRBase64AsLedgerEntry(string(data))
+	if xdrOutput == "json" {
+		node, err := formatter.TreeNode(output)
 		if err != nil {
-			return errors.WrapUnmarshalFailed(err, "ledger entry")
+			return errors.WrapValidationError(fmt.Sprintf("formatting failed: %v", err))
 		}
-		output = le
-
-	case "diagnostic-event":
-		event, err := decoder.DecodeXDRBase64AsDiagnosticEvent(string(data))
+		jsonBytes, err := json.MarshalIndent(node, "", "  ")
 		if err != nil {
-			return errors.WrapUnmarshalFailed(err, "diagnostic event")
+			return errors.WrapMarshalFailed(err)
 		}
-		output = event
-
-	default:
-		return errors.WrapValidationError(fmt.Sprintf("unsupported XDR type: %s (use: ledger-entry, diagnostic-event)", xdrType))
+		fmt.Println(string(jsonBytes))
+		return nil
 	}
 
-	formatter := decoder.NewXDRFormatter(decoder.FormatType(xdrFormat))
 	result, err := formatter.Format(output)
 	if err != nil {
 		return errors.WrapValidationError(fmt.Sprintf("formatting failed: %v", err))
@@ -66,12 +83,64 @@ func xdrExec(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// decodeXDRInput decodes data, written in enc, as the XDR type typ.
+func decodeXDRInput(data string, enc decoder.Encoding, typ string) (interface{}, error) {
+	switch typ {
+	case "ledger-entry":
+		var le xdr.LedgerEntry
+		if err := decoder.DecodeXDRText(data, enc, &le, "ledger entry"); err != nil {
+			return nil, err
+		}
+		return &le, nil
+
+	case "diagnostic-event":
+		var event xdr.DiagnosticEvent
+		if err := decoder.DecodeXDRText(data, enc, &event, "diagnostic event"); err != nil {
+			return nil, err
+		}
+		return &event, nil
+
+	case "scval":
+		var val xdr.ScVal
+		if err := decoder.DecodeXDRText(data, enc, &val, "scval"); err != nil {
+			return nil, err
+		}
+		return val, nil
+
+	default:
+		return nil, errors.WrapValidationError(fmt.Sprintf("unsupported XDR type: %s (use: ledger-entry, diagnostic-event, scval)", typ))
+	}
+}
+
+// validateXDROutput checks --output and --depth against --format. JSON
+// output is a serialisation of the tree view and --depth limits that view,
+// so both require --format tree.
+func validateXDROutput() error {
+	if xdrDepth != 0 && xdrFormat != string(decoder.FormatTree) {
+		return errors.WrapValidationError("--depth requires --format tree")
+	}
+	switch xdrOutput {
+	case "text":
+		return nil
+	case "json":
+		if xdrFormat != string(decoder.FormatTree) {
+			return errors.WrapValidationError("--output json requires --format tree")
+		}
+		return nil
+	default:
+		return errors.WrapValidationError(fmt.Sprintf("unsupported output mode: %s (use: text, json)", xdrOutput))
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(xdrCmd)
 
-	xdrCmd.Flags().StringVar(&xdrData, "data", "", "Base64-encoded XDR data to decode")
-	xdrCmd.Flags().StringVar(&xdrFormat, "format", "json", "Output format: json or table")
-	xdrCmd.Flags().StringVar(&xdrType, "type", "ledger-entry", "XDR type: ledger-entry, diagnostic-event")
+	xdrCmd.Flags().StringVar(&xdrData, "data", "", "XDR data to decode, as base64, URL-safe base64 or hex")
+	xdrCmd.Flags().StringVar(&xdrEncoding, "encoding", string(decoder.EncodingAuto), "Encoding of --data: auto, base64, base64url, or hex")
+	xdrCmd.Flags().StringVar(&xdrFormat, "format", "json", "Output format: json, table, or tree")
+	xdrCmd.Flags().StringVar(&xdrType, "type", "ledger-entry", "XDR type: ledger-entry, diagnostic-event, scval")
+	xdrCmd.Flags().IntVar(&xdrDepth, "depth", 0, "Maximum nesting depth to expand (0 = unlimited; requires --format tree)")
+	xdrCmd.Flags().StringVar(&xdrOutput, "output", "text", "Output mode: text, or json to emit the decoded tree as JSON (requires --format tree)")
 
 	_ = xdrCmd.MarkFlagRequired("data")
 }
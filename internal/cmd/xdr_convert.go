@@ -0,0 +1,110 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	xdrConvertFrom  string
+	xdrConvertTo    string
+	xdrConvertInput string
+	xdrConvertType  string
+)
+
+var xdrConvertCmd = &cobra.Command{
+	Use:   "xdr-convert",
+	Short: "Convert XDR between base64, URL-safe base64 and hex",
+	Long: `Read XDR from --input, or from stdin, and write it in the encoding --to names.
+
+The input encoding is detected unless --from names it. The bytes are checked in
+between: without --type they must be a whole number of 4-byte XDR units; with
+--type they must decode as that type and re-encode to exactly the same bytes,
+so non-canonical encodings, such as non-zero padding, are rejected.
+
+Output is padded standard base64, unpadded URL-safe base64, or lowercase hex.
+
+Examples:
+  erst xdr-convert --from base64 --to hex --input envelope.xdr
+  echo AAAADwAAAAh0cmFuc2Zlcg== | erst xdr-convert --to hex --type scval
+  erst xdr-convert --to base64url --type transaction-envelope < tx.hex`,
+	Args: cobra.NoArgs,
+	RunE: xdrConvertExec,
+}
+
+func xdrConvertExec(cmd *cobra.Command, args []string) error {
+	from, err := decoder.ParseEncoding(xdrConvertFrom)
+	if err != nil {
+		return errors.WrapValidationError(err.Error())
+	}
+	to, err := decoder.ParseEncoding(xdrConvertTo)
+	if err != nil || to == decoder.EncodingAuto {
+		return errors.WrapValidationError(fmt.Sprintf("unsupported --to encoding %q (use: base64, base64url, hex)", xdrConvertTo))
+	}
+	v, what, err := newXDRValue(xdrConvertType)
+	if err != nil {
+		return err
+	}
+
+	var input []byte
+	if xdrConvertInput != "" {
+		input, err = os.ReadFile(xdrConvertInput)
+	} else {
+		input, err = io.ReadAll(cmd.InOrStdin())
+	}
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to read input: %v", err))
+	}
+
+	out, err := decoder.ConvertXDRText(string(input), from, to, v, what)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), out)
+	return nil
+}
+
+// newXDRValue returns an empty value of the XDR type typ, named for error
+// messages, or nil for "" so that only the framing is checked.
+func newXDRValue(typ string) (decoder.XDRValue, string, error) {
+	switch typ {
+	case "":
+		return nil, "xdr", nil
+	case "ledger-entry":
+		return &xdr.LedgerEntry{}, "ledger entry", nil
+	case "ledger-key":
+		return &xdr.LedgerKey{}, "ledger key", nil
+	case "diagnostic-event":
+		return &xdr.DiagnosticEvent{}, "diagnostic event", nil
+	case "scval":
+		return &xdr.ScVal{}, "scval", nil
+	case "transaction-envelope":
+		return &xdr.TransactionEnvelope{}, "transaction envelope", nil
+	case "transaction-result":
+		return &xdr.TransactionResult{}, "transaction result", nil
+	case "transaction-meta":
+		return &xdr.TransactionMeta{}, "transaction meta", nil
+	default:
+		return nil, "", errors.WrapValidationError(fmt.Sprintf("unsupported XDR type: %s (use: ledger-entry, ledger-key, diagnostic-event, scval, transaction-envelope, transaction-result, transaction-meta)", typ))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(xdrConvertCmd)
+
+	xdrConvertCmd.Flags().StringVar(&xdrConvertFrom, "from", string(decoder.EncodingAuto), "Encoding of the input: auto, base64, base64url, or hex")
+	xdrConvertCmd.Flags().StringVar(&xdrConvertTo, "to", "", "Encoding to write: base64, base64url, or hex")
+	xdrConvertCmd.Flags().StringVar(&xdrConvertInput, "input", "", "File to read the XDR from (default stdin)")
+	xdrConvertCmd.Flags().StringVar(&xdrConvertType, "type", "", "XDR type to decode and check for a canonical encoding: ledger-entry, ledger-key, diagnostic-event, scval, transaction-envelope, transaction-result, transaction-meta")
+
+	_ = xdrConvertCmd.MarkFlagRequired("to")
+}
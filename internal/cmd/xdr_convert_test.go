@@ -0,0 +1,63 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runXDRConvert(t *testing.T, stdin string, flags map[string]string) (string, error) {
+	t.Helper()
+	prevFrom, prevTo, prevInput, prevType := xdrConvertFrom, xdrConvertTo, xdrConvertInput, xdrConvertType
+	t.Cleanup(func() {
+		xdrConvertFrom, xdrConvertTo, xdrConvertInput, xdrConvertType = prevFrom, prevTo, prevInput, prevType
+		xdrConvertCmd.SetIn(nil)
+		xdrConvertCmd.SetOut(nil)
+	})
+	xdrConvertFrom, xdrConvertTo, xdrConvertInput, xdrConvertType = "auto", "", "", ""
+	for name, value := range flags {
+		require.NoError(t, xdrConvertCmd.Flags().Set(name, value))
+	}
+
+	var out bytes.Buffer
+	xdrConvertCmd.SetIn(strings.NewReader(stdin))
+	xdrConvertCmd.SetOut(&out)
+	err := xdrConvertCmd.RunE(xdrConvertCmd, nil)
+	return out.String(), err
+}
+
+func TestXDRConvert_Stdin(t *testing.T) {
+	out, err := runXDRConvert(t, "AAAADwAAAAh0cmFuc2Zlcg==\n", map[string]string{"from": "base64", "to": "hex", "type": "scval"})
+	require.NoError(t, err)
+	assert.Equal(t, "0000000f000000087472616e73666572\n", out)
+}
+
+func TestXDRConvert_InputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scval.hex")
+	require.NoError(t, os.WriteFile(path, []byte("0x0000000F000000087472616E73666572\n"), 0o644))
+
+	out, err := runXDRConvert(t, "", map[string]string{"input": path, "to": "base64"})
+	require.NoError(t, err)
+	assert.Equal(t, "AAAADwAAAAh0cmFuc2Zlcg==\n", out)
+}
+
+func TestXDRConvert_Errors(t *testing.T) {
+	_, err := runXDRConvert(t, "AAAADw==", map[string]string{"to": "auto"})
+	assert.True(t, errors.Is(err, errors.ErrValidationFailed))
+
+	_, err = runXDRConvert(t, "AAAADw==", map[string]string{"to": "hex", "type": "contract-code"})
+	assert.True(t, errors.Is(err, errors.ErrValidationFailed))
+
+	// A symbol missing its length and bytes.
+	_, err = runXDRConvert(t, "AAAADw==", map[string]string{"to": "hex", "type": "scval"})
+	assert.True(t, errors.Is(err, errors.ErrXDRCorrupt))
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateXDROutput(t *testing.T) {
+	prevFormat, prevOutput, prevDepth := xdrFormat, xdrOutput, xdrDepth
+	t.Cleanup(func() {
+		xdrFormat = prevFormat
+		xdrOutput = prevOutput
+		xdrDepth = prevDepth
+	})
+
+	tests := []struct {
+		format, output string
+		depth          int
+		wantErr        bool
+	}{
+		{"json", "text", 0, false},
+		{"tree", "text", 0, false},
+		{"tree", "json", 0, false},
+		{"json", "json", 0, true},
+		{"table", "json", 0, true},
+		{"tree", "yaml", 0, true},
+		{"tree", "text", 2, false},
+		{"tree", "json", 2, false},
+		{"json", "text", 2, true},
+		{"table", "text", 2, true},
+	}
+	for _, tt := range tests {
+		xdrFormat, xdrOutput, xdrDepth = tt.format, tt.output, tt.depth
+		err := validateXDROutput()
+		if tt.wantErr {
+			assert.Error(t, err, "format=%s output=%s depth=%d", tt.format, tt.output, tt.depth)
+		} else {
+			assert.NoError(t, err, "format=%s output=%s depth=%d", tt.format, tt.output, tt.depth)
+		}
+	}
+}
+
+func TestDecodeXDRInput_Encodings(t *testing.T) {
+	sym := xdr.ScSymbol("transfer")
+	raw, err := xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}.MarshalBinary()
+	require.NoError(t, err)
+
+	for _, input := range []string{
+		base64.StdEncoding.EncodeToString(raw),
+		base64.RawURLEncoding.EncodeToString(raw),
+		hex.EncodeToString(raw),
+	} {
+		out, err := decodeXDRInput(input, decoder.EncodingAuto, "scval")
+		require.NoError(t, err, input)
+		val := out.(xdr.ScVal)
+		require.NotNil(t, val.Sym)
+		assert.Equal(t, sym, *val.Sym)
+	}
+
+	_, err = decodeXDRInput(hex.EncodeToString(raw), decoder.EncodingBase64URL, "scval")
+	assert.Error(t, err, "hex digits are not this value's URL-safe base64")
+
+	_, err = decodeXDRInput("AAAA", decoder.EncodingAuto, "operation")
+	assert.Error(t, err)
+}
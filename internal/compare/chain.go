@@ -0,0 +1,92 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// Simulated and on-chain statuses as ChainCheck reports them.
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// ChainCheck is the result of comparing a re-simulation with what the
+// network recorded for the same transaction. When they agree the failure
+// reproduces locally; when they disagree the reconstructed ledger state is
+// probably off, e.g. entries from the wrong ledger.
+type ChainCheck struct {
+	Reproduced bool `json:"reproduced"`
+
+	ChainStatus     string `json:"chain_status"`
+	ChainResultCode string `json:"chain_result_code"`
+	SimulatedStatus string `json:"simulated_status"`
+
+	// EventsCompared is the number of contract events compared, and
+	// EventMismatches the ones that differ. Events are only compared when
+	// the transaction succeeded on chain, since a failed transaction's
+	// events are rolled back.
+	EventsCompared  int              `json:"events_compared"`
+	EventMismatches []DiagnosticDiff `json:"event_mismatches,omitempty"`
+
+	// UnexpectedWrites are ledger keys the transaction changed on chain that
+	// are missing from the simulation's write footprint. They are only
+	// checked when the simulator reports a footprint.
+	UnexpectedWrites []string `json:"unexpected_writes,omitempty"`
+}
+
+// StatusMatches reports whether the simulation succeeded or failed as the
+// transaction did on chain.
+func (c *ChainCheck) StatusMatches() bool {
+	return c.ChainStatus == c.SimulatedStatus
+}
+
+// CheckAgainstChain compares a simulation with the recorded outcome. Events
+// are matched in normalized mode, as the two sides are rendered by different
+// encoders.
+func CheckAgainstChain(sim *simulator.SimulationResponse, chain *decoder.OnChainOutcome) *ChainCheck {
+	c := &ChainCheck{
+		ChainStatus:     StatusFailed,
+		ChainResultCode: chain.ResultCode,
+		SimulatedStatus: StatusFailed,
+	}
+	if chain.Successful {
+		c.ChainStatus = StatusSuccess
+	}
+	if sim.Status == StatusSuccess {
+		c.SimulatedStatus = StatusSuccess
+	}
+
+	if chain.Successful {
+		simulated, _ := simulator.SplitEvents(sim.DiagnosticEvents)
+		diff := DiffWithMode(
+			&simulator.SimulationResponse{DiagnosticEvents: simulated},
+			&simulator.SimulationResponse{DiagnosticEvents: chain.Events},
+			ModeNormalized,
+		)
+		c.EventsCompared = len(diff.DiagnosticDiffs)
+		for _, d := range diff.DiagnosticDiffs {
+			if d.Divergent {
+				c.EventMismatches = append(c.EventMismatches, d)
+			}
+		}
+
+		if len(sim.WriteKeys) > 0 {
+			writable := make(map[string]bool, len(sim.WriteKeys))
+			for _, k := range sim.WriteKeys {
+				writable[k] = true
+			}
+			for _, k := range chain.ChangedKeys {
+				if !writable[k] {
+					c.UnexpectedWrites = append(c.UnexpectedWrites, k)
+				}
+			}
+		}
+	}
+
+	c.Reproduced = c.StatusMatches() && len(c.EventMismatches) == 0 && len(c.UnexpectedWrites) == 0
+	return c
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"testing"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAgainstChain(t *testing.T) {
+	transfer := simulator.DiagnosticEvent{EventType: "contract", ContractID: ptr("CABC"), Topics: []string{"transfer"}, Data: "5"}
+	mint := simulator.DiagnosticEvent{EventType: "contract", ContractID: ptr("CABC"), Topics: []string{"mint"}, Data: "5"}
+	diag := simulator.DiagnosticEvent{EventType: "diagnostic", Topics: []string{"fn_call"}}
+
+	t.Run("reproduces", func(t *testing.T) {
+		sim := &simulator.SimulationResponse{Status: "success", DiagnosticEvents: []simulator.DiagnosticEvent{diag, transfer}, WriteKeys: []string{"k1"}}
+		chain := &decoder.OnChainOutcome{Successful: true, ResultCode: "tx_success", Events: []simulator.DiagnosticEvent{transfer}, ChangedKeys: []string{"k1"}}
+		c := CheckAgainstChain(sim, chain)
+		assert.True(t, c.Reproduced)
+		assert.Equal(t, 1, c.EventsCompared, "host diagnostics are not compared")
+	})
+
+	t.Run("status differs", func(t *testing.T) {
+		sim := &simulator.SimulationResponse{Status: "success"}
+		chain := &decoder.OnChainOutcome{Successful: false, ResultCode: "tx_failed"}
+		c := CheckAgainstChain(sim, chain)
+		assert.False(t, c.Reproduced)
+		assert.False(t, c.StatusMatches())
+		assert.Equal(t, StatusFailed, c.ChainStatus)
+		assert.Equal(t, StatusSuccess, c.SimulatedStatus)
+	})
+
+	t.Run("failure reproduces without comparing events", func(t *testing.T) {
+		sim := &simulator.SimulationResponse{Status: "error", DiagnosticEvents: []simulator.DiagnosticEvent{mint}}
+		chain := &decoder.OnChainOutcome{Successful: false, ResultCode: "tx_failed"}
+		c := CheckAgainstChain(sim, chain)
+		assert.True(t, c.Reproduced)
+		assert.Zero(t, c.EventsCompared)
+	})
+
+	t.Run("events and writes differ", func(t *testing.T) {
+		sim := &simulator.SimulationResponse{Status: "success", DiagnosticEvents: []simulator.DiagnosticEvent{mint}, WriteKeys: []string{"k1"}}
+		chain := &decoder.OnChainOutcome{Successful: true, ResultCode: "tx_success", Events: []simulator.DiagnosticEvent{transfer}, ChangedKeys: []string{"k1", "k2"}}
+		c := CheckAgainstChain(sim, chain)
+		assert.False(t, c.Reproduced)
+		assert.Len(t, c.EventMismatches, 1)
+		assert.Equal(t, []string{"k2"}, c.UnexpectedWrites)
+	})
+
+	t.Run("writes unchecked without a simulated footprint", func(t *testing.T) {
+		sim := &simulator.SimulationResponse{Status: "success"}
+		chain := &decoder.OnChainOutcome{Successful: true, ResultCode: "tx_success", ChangedKeys: []string{"k1"}}
+		assert.True(t, CheckAgainstChain(sim, chain).Reproduced)
+	})
+}
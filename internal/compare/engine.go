@@ -20,15 +20,18 @@ const (
 	SideOnChain = "on-chain"
 )
 
+// absentEvent stands in for the event of a side whose stream is shorter.
+const absentEvent = "<absent>"
+
 // EventDiff represents a single positional divergence between two event slices.
 type EventDiff struct {
 	// Index is the 0-based position in the event stream.
 	Index int
 
-	// LocalEvent is the event from the local-WASM run ("" if absent).
+	// LocalEvent is the event from the local-WASM run ("<absent>" if absent).
 	LocalEvent string
 
-	// OnChainEvent is the event from the on-chain run ("" if absent).
+	// OnChainEvent is the event from the on-chain run ("<absent>" if absent).
 	OnChainEvent string
 
 	// Divergent is true when the two events differ.
@@ -97,18 +100,40 @@ type DiffResult struct {
 }
 
 // Diff compares two SimulationResponse objects (local vs on-chain) and returns
-// a fully-populated DiffResult. Neither argument may be nil.
+// a fully-populated DiffResult. Neither argument may be nil. Events are
+// compared index by index; see DiffWithMode for order-insensitive modes.
 func Diff(local, onChain *simulator.SimulationResponse) *DiffResult {
+	return DiffWithMode(local, onChain, ModeStrict)
+}
+
+// DiffWithMode is Diff with a choice of event comparison. In ModeSet and
+// ModeNormalized the on-chain events are first reordered to line up with
+// their local counterparts, so event indexes in the result refer to the
+// aligned order rather than to emission order; ModeNormalized also drops
+// metrics events first.
+func DiffWithMode(local, onChain *simulator.SimulationResponse, mode Mode) *DiffResult {
 	result := &DiffResult{}
 
+	normalizeRaw := rawNormalizer(mode)
+	normalizeDiag := diagnosticNormalizer(mode)
+	localEvents, onChainEvents := local.Events, onChain.Events
+	localDiag, onChainDiag := local.DiagnosticEvents, onChain.DiagnosticEvents
+	if mode == ModeNormalized {
+		localDiag, onChainDiag = withoutMetrics(localDiag), withoutMetrics(onChainDiag)
+	}
+	if mode == ModeSet || mode == ModeNormalized {
+		localEvents, onChainEvents = alignEvents(localEvents, onChainEvents, normalizeRaw)
+		localDiag, onChainDiag = alignEvents(localDiag, onChainDiag, diagnosticKey(normalizeDiag))
+	}
+
 	// 1. Status comparison
 	result.StatusDiff = compareStatus(local, onChain)
 
 	// 2. Raw event diff (backward-compat events slice)
-	result.EventDiffs = compareRawEvents(local.Events, onChain.Events)
+	result.EventDiffs = compareRawEvents(localEvents, onChainEvents, normalizeRaw)
 
 	// 3. Diagnostic event diff (structured)
-	result.DiagnosticDiffs = compareDiagnosticEvents(local.DiagnosticEvents, onChain.DiagnosticEvents)
+	result.DiagnosticDiffs = compareDiagnosticEvents(localDiag, onChainDiag, normalizeDiag)
 
 	// 4. Budget diff
 	if local.BudgetUsage != nil || onChain.BudgetUsage != nil {
@@ -149,7 +174,7 @@ func compareStatus(local, onChain *simulator.SimulationResponse) StatusDiff {
 	return sd
 }
 
-func compareRawEvents(local, onChain []string) []EventDiff {
+func compareRawEvents(local, onChain []string, normalize func(string) string) []EventDiff {
 	maxLen := len(local)
 	if len(onChain) > maxLen {
 		maxLen = len(onChain)
@@ -169,12 +194,12 @@ func compareRawEvents(local, onChain []string) []EventDiff {
 		} else {
 			oeMissing = true
 		}
-		divergent := le != oe
+		divergent := leMissing != oeMissing || normalize(le) != normalize(oe)
 		if leMissing {
-			le = "<absent>"
+			le = absentEvent
 		}
 		if oeMissing {
-			oe = "<absent>"
+			oe = absentEvent
 		}
 		diffs[i] = EventDiff{
 			Index:        i,
@@ -186,7 +211,7 @@ func compareRawEvents(local, onChain []string) []EventDiff {
 	return diffs
 }
 
-func compareDiagnosticEvents(local, onChain []simulator.DiagnosticEvent) []DiagnosticDiff {
+func compareDiagnosticEvents(local, onChain []simulator.DiagnosticEvent, normalize func(simulator.DiagnosticEvent) simulator.DiagnosticEvent) []DiagnosticDiff {
 	maxLen := len(local)
 	if len(onChain) > maxLen {
 		maxLen = len(onChain)
@@ -214,9 +239,10 @@ func compareDiagnosticEvents(local, onChain []simulator.DiagnosticEvent) []Diagn
 			dd.Divergent = true
 			dd.DivergentPath = true
 		} else {
-			dd.Divergent = !diagnosticEventsEqual(*le, *oe)
-			dd.DivergentPath = le.EventType != oe.EventType ||
-				contractIDStr(le.ContractID) != contractIDStr(oe.ContractID)
+			ln, on := normalize(*le), normalize(*oe)
+			dd.Divergent = !diagnosticEventsEqual(ln, on)
+			dd.DivergentPath = ln.EventType != on.EventType ||
+				contractIDStr(ln.ContractID) != contractIDStr(on.ContractID)
 		}
 
 		diffs[i] = dd
@@ -0,0 +1,140 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// Mode selects how the event streams of two runs are matched up.
+type Mode string
+
+const (
+	// ModeStrict compares events index by index.
+	ModeStrict Mode = "strict"
+	// ModeSet compares events as unordered multisets, so identical events
+	// emitted in a different order do not count as divergences.
+	ModeSet Mode = "set"
+	// ModeNormalized is ModeSet over a canonical form of each event that
+	// ignores formatting differences: surrounding whitespace, the
+	// Symbol("...") wrapper on topics and the case of event types and
+	// contract IDs. The host's core_metrics diagnostic events are left out,
+	// since their resource counters differ between hosts and protocols.
+	ModeNormalized Mode = "normalized"
+)
+
+// ParseMode validates a --compare-mode value. The empty string means strict.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case "":
+		return ModeStrict, nil
+	case ModeStrict, ModeSet, ModeNormalized:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unsupported compare mode %q (expected strict, set, or normalized)", s)
+	}
+}
+
+// alignEvents reorders onChain so that events equal under key sit at the same
+// index as their counterpart in local. Matched pairs come first in local
+// order, followed by the unmatched events of both sides in their original
+// order, which the positional diff then reports as divergent.
+func alignEvents[T any](local, onChain []T, key func(T) string) ([]T, []T) {
+	pending := make(map[string][]int, len(onChain))
+	for i, ev := range onChain {
+		k := key(ev)
+		pending[k] = append(pending[k], i)
+	}
+
+	used := make([]bool, len(onChain))
+	alignedLocal := make([]T, 0, len(local))
+	alignedOnChain := make([]T, 0, len(onChain))
+	var unmatchedLocal []T
+	for _, ev := range local {
+		k := key(ev)
+		if idx := pending[k]; len(idx) > 0 {
+			pending[k] = idx[1:]
+			used[idx[0]] = true
+			alignedLocal = append(alignedLocal, ev)
+			alignedOnChain = append(alignedOnChain, onChain[idx[0]])
+			continue
+		}
+		unmatchedLocal = append(unmatchedLocal, ev)
+	}
+
+	alignedLocal = append(alignedLocal, unmatchedLocal...)
+	for i, ev := range onChain {
+		if !used[i] {
+			alignedOnChain = append(alignedOnChain, ev)
+		}
+	}
+	return alignedLocal, alignedOnChain
+}
+
+// rawNormalizer returns the canonical form raw events are compared in.
+func rawNormalizer(mode Mode) func(string) string {
+	if mode == ModeNormalized {
+		return normalizeText
+	}
+	return func(s string) string { return s }
+}
+
+// diagnosticNormalizer returns the canonical form diagnostic events are
+// compared in.
+func diagnosticNormalizer(mode Mode) func(simulator.DiagnosticEvent) simulator.DiagnosticEvent {
+	if mode == ModeNormalized {
+		return normalizeDiagnosticEvent
+	}
+	return func(e simulator.DiagnosticEvent) simulator.DiagnosticEvent { return e }
+}
+
+// diagnosticKey identifies a diagnostic event by the fields
+// diagnosticEventsEqual compares.
+func diagnosticKey(normalize func(simulator.DiagnosticEvent) simulator.DiagnosticEvent) func(simulator.DiagnosticEvent) string {
+	return func(e simulator.DiagnosticEvent) string {
+		e = normalize(e)
+		return strings.Join(append([]string{e.EventType, contractIDStr(e.ContractID), e.Data}, e.Topics...), "\x00")
+	}
+}
+
+func normalizeDiagnosticEvent(e simulator.DiagnosticEvent) simulator.DiagnosticEvent {
+	e.EventType = strings.ToLower(strings.TrimSpace(e.EventType))
+	if e.ContractID != nil {
+		id := strings.ToLower(strings.TrimSpace(*e.ContractID))
+		e.ContractID = &id
+	}
+	topics := make([]string, len(e.Topics))
+	for i, t := range e.Topics {
+		topics[i] = simulator.TopicSymbol(normalizeText(t))
+	}
+	e.Topics = topics
+	e.Data = normalizeText(e.Data)
+	return e
+}
+
+// normalizeText collapses runs of whitespace so formatting differences
+// between encoders do not register as divergences.
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// metricsTopic is the first topic of the diagnostic events in which the host
+// reports its resource counters: CPU instructions, memory, entries and bytes
+// read and written.
+const metricsTopic = "core_metrics"
+
+// withoutMetrics returns events without the host's core_metrics events.
+func withoutMetrics(events []simulator.DiagnosticEvent) []simulator.DiagnosticEvent {
+	kept := make([]simulator.DiagnosticEvent, 0, len(events))
+	for _, e := range events {
+		if len(e.Topics) > 0 && simulator.TopicSymbol(normalizeText(e.Topics[0])) == metricsTopic {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
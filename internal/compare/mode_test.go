@@ -0,0 +1,93 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMode(t *testing.T) {
+	for in, want := range map[string]Mode{"": ModeStrict, "strict": ModeStrict, "set": ModeSet, "normalized": ModeNormalized} {
+		got, err := ParseMode(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := ParseMode("fuzzy")
+	assert.Error(t, err)
+}
+
+func TestDiffWithMode_ReorderedEvents(t *testing.T) {
+	local := makeResp("success", []string{"a", "b", "c"}, nil, nil)
+	onChain := makeResp("success", []string{"c", "a", "b"}, nil, nil)
+
+	assert.True(t, DiffWithMode(local, onChain, ModeStrict).HasDivergence, "strict mode must flag reordering")
+
+	result := DiffWithMode(local, onChain, ModeSet)
+	assert.False(t, result.HasDivergence)
+	assert.Equal(t, 3, result.IdenticalEvents)
+}
+
+func TestDiffWithMode_SetCountsDuplicates(t *testing.T) {
+	local := makeResp("success", []string{"a", "a", "b"}, nil, nil)
+	onChain := makeResp("success", []string{"b", "a", "c"}, nil, nil)
+
+	result := DiffWithMode(local, onChain, ModeSet)
+	require.True(t, result.HasDivergence)
+	assert.Equal(t, 1, result.DivergentEvents)
+	last := result.EventDiffs[2]
+	assert.Equal(t, "a", last.LocalEvent)
+	assert.Equal(t, "c", last.OnChainEvent)
+}
+
+func TestDiffWithMode_SetKeepsFormatting(t *testing.T) {
+	local := makeResp("success", []string{"transfer  10"}, nil, nil)
+	onChain := makeResp("success", []string{"transfer 10"}, nil, nil)
+
+	assert.True(t, DiffWithMode(local, onChain, ModeSet).HasDivergence)
+	assert.False(t, DiffWithMode(local, onChain, ModeNormalized).HasDivergence)
+}
+
+func TestDiffWithMode_NormalizedDiagnosticEvents(t *testing.T) {
+	local := makeResp("success", nil, []simulator.DiagnosticEvent{
+		{EventType: "contract", ContractID: ptr("CABC"), Topics: []string{`Symbol("transfer")`}, Data: "10"},
+		{EventType: "diagnostic", Topics: []string{"fn_return"}, Data: "void"},
+	}, nil)
+	onChain := makeResp("success", nil, []simulator.DiagnosticEvent{
+		{EventType: "diagnostic", Topics: []string{"fn_return"}, Data: " void "},
+		{EventType: "Contract", ContractID: ptr("cabc"), Topics: []string{"transfer"}, Data: "10"},
+	}, nil)
+
+	assert.True(t, DiffWithMode(local, onChain, ModeSet).HasDivergence)
+
+	result := DiffWithMode(local, onChain, ModeNormalized)
+	assert.False(t, result.HasDivergence)
+	assert.Empty(t, result.CallPathDivergences)
+}
+
+func TestDiffWithMode_NormalizedIgnoresMetrics(t *testing.T) {
+	transfer := simulator.DiagnosticEvent{EventType: "contract", ContractID: ptr("CABC"), Topics: []string{`Symbol("transfer")`}, Data: "10"}
+	local := makeResp("success", nil, []simulator.DiagnosticEvent{
+		transfer,
+		{EventType: "diagnostic", Topics: []string{`Symbol("core_metrics")`, `Symbol("cpu_insn")`}, Data: "1200"},
+	}, nil)
+	onChain := makeResp("success", nil, []simulator.DiagnosticEvent{
+		{EventType: "diagnostic", Topics: []string{"core_metrics", "cpu_insn"}, Data: "1350"},
+		transfer,
+	}, nil)
+
+	set := DiffWithMode(local, onChain, ModeSet)
+	require.Len(t, set.DiagnosticDiffs, 2)
+	assert.True(t, set.DiagnosticDiffs[1].Divergent, "set mode compares counters")
+
+	result := DiffWithMode(local, onChain, ModeNormalized)
+	assert.False(t, result.HasDivergence)
+	require.Len(t, result.DiagnosticDiffs, 1)
+	assert.False(t, result.DiagnosticDiffs[0].Divergent)
+	assert.Equal(t, transfer.Topics, result.DiagnosticDiffs[0].Local.Topics)
+}
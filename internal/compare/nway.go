@@ -0,0 +1,69 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"sort"
+
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// NamedResult is a simulation result labelled with the network it ran on.
+type NamedResult struct {
+	Network string
+	Result  *simulator.SimulationResponse
+}
+
+// OutcomeGroup is a set of networks whose simulations produced the same
+// outcome, i.e. pairwise DiffWithMode reports no divergence.
+type OutcomeGroup struct {
+	Networks []string `json:"networks"`
+	Status   string   `json:"status"`
+	Error    string   `json:"error,omitempty"`
+
+	// representative is the first result of the group, used for diffing.
+	representative NamedResult
+}
+
+// Representative returns the result every member of the group matches.
+func (g OutcomeGroup) Representative() NamedResult {
+	return g.representative
+}
+
+// GroupOutcomes partitions results into groups of identical outcomes under
+// mode. Groups are ordered largest first (ties keep input order), so when
+// there is a majority outcome it is groups[0] and every later group is a
+// divergent one.
+func GroupOutcomes(results []NamedResult, mode Mode) []OutcomeGroup {
+	var groups []OutcomeGroup
+	for _, r := range results {
+		placed := false
+		for i := range groups {
+			if !DiffWithMode(groups[i].representative.Result, r.Result, mode).HasDivergence {
+				groups[i].Networks = append(groups[i].Networks, r.Network)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			groups = append(groups, OutcomeGroup{
+				Networks:       []string{r.Network},
+				Status:         r.Result.Status,
+				Error:          r.Result.Error,
+				representative: r,
+			})
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].Networks) > len(groups[j].Networks)
+	})
+	return groups
+}
+
+// HasMajority reports whether groups[0] is strictly larger than every other
+// group, making the remaining groups the odd ones out.
+func HasMajority(groups []OutcomeGroup) bool {
+	return len(groups) == 1 || (len(groups) > 1 && len(groups[0].Networks) > len(groups[1].Networks))
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupOutcomes_OddOneOut(t *testing.T) {
+	groups := GroupOutcomes([]NamedResult{
+		{Network: "mainnet", Result: makeResp("success", []string{"a"}, nil, nil)},
+		{Network: "futurenet", Result: makeResp("error", []string{"a", "b"}, nil, nil)},
+		{Network: "testnet", Result: makeResp("success", []string{"a"}, nil, nil)},
+	}, ModeStrict)
+
+	require.Len(t, groups, 2)
+	assert.True(t, HasMajority(groups))
+	assert.Equal(t, []string{"mainnet", "testnet"}, groups[0].Networks)
+	assert.Equal(t, "success", groups[0].Status)
+	assert.Equal(t, []string{"futurenet"}, groups[1].Networks)
+	assert.Equal(t, "futurenet", groups[1].Representative().Network)
+}
+
+func TestGroupOutcomes_AllIdentical(t *testing.T) {
+	groups := GroupOutcomes([]NamedResult{
+		{Network: "mainnet", Result: makeResp("success", nil, nil, nil)},
+		{Network: "testnet", Result: makeResp("success", nil, nil, nil)},
+	}, ModeStrict)
+
+	require.Len(t, groups, 1)
+	assert.True(t, HasMajority(groups))
+}
+
+func TestGroupOutcomes_NoMajority(t *testing.T) {
+	groups := GroupOutcomes([]NamedResult{
+		{Network: "mainnet", Result: makeResp("success", nil, nil, nil)},
+		{Network: "testnet", Result: makeResp("error", nil, nil, nil)},
+	}, ModeStrict)
+
+	require.Len(t, groups, 2)
+	assert.False(t, HasMajority(groups))
+	assert.Equal(t, []string{"mainnet"}, groups[0].Networks, "ties keep input order")
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// UnifiedContext is how many unchanged lines WriteUnified shows around each
+// change, as diff -u does.
+const UnifiedContext = 3
+
+// WriteUnified renders result in unified-diff syntax, with the local side
+// as the --- file labelled fromLabel and the on-chain side as the +++ file
+// labelled toLabel, so it can be piped into diffstat, review tools or
+// colorizers. Each side is listed one fact per line: status, error, budget
+// and then its events in order. Nothing is written when the listings match.
+func WriteUnified(w io.Writer, result *DiffResult, fromLabel, toLabel string) error {
+	if result == nil {
+		return nil
+	}
+	ops := diffLines(unifiedListing(result, true), unifiedListing(result, false))
+	hunks := unifiedHunks(ops, UnifiedContext)
+	if len(hunks) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromLabel, toLabel)
+	for _, h := range hunks {
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(h.fromStart, h.fromLen), hunkRange(h.toStart, h.toLen))
+		for _, op := range ops[h.first:h.last] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			b.WriteByte('\n')
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// unifiedListing lists one side of result, the local side when local is set.
+func unifiedListing(result *DiffResult, local bool) []string {
+	sd := result.StatusDiff
+	status, errMsg := sd.OnChainStatus, sd.OnChainError
+	if local {
+		status, errMsg = sd.LocalStatus, sd.LocalError
+	}
+	lines := []string{"status: " + status}
+	if errMsg != "" {
+		lines = append(lines, "error: "+errMsg)
+	}
+
+	if bd := result.BudgetDiff; bd != nil {
+		cpu, mem, ops := bd.OnChainCPU, bd.OnChainMem, bd.OnChainOps
+		if local {
+			cpu, mem, ops = bd.LocalCPU, bd.LocalMem, bd.LocalOps
+		}
+		lines = append(lines,
+			fmt.Sprintf("cpu_instructions: %d", cpu),
+			fmt.Sprintf("memory_bytes: %d", mem),
+			fmt.Sprintf("operations: %d", ops))
+	}
+
+	for _, d := range result.EventDiffs {
+		ev := d.OnChainEvent
+		if local {
+			ev = d.LocalEvent
+		}
+		if ev != absentEvent {
+			lines = append(lines, "event: "+ev)
+		}
+	}
+	return lines
+}
+
+// lineOp is one line of a diff: ' ' for context, '-' for a line only in
+// the from side and '+' for a line only in the to side.
+type lineOp struct {
+	kind byte
+	line string
+}
+
+// diffLines computes a shortest line diff of from and to from their
+// longest common subsequence.
+func diffLines(from, to []string) []lineOp {
+	// lcs[i][j] is the LCS length of from[i:] and to[j:]
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]lineOp, 0, max(len(from), len(to)))
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			ops = append(ops, lineOp{' ', from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, lineOp{'-', from[i]})
+			i++
+		default:
+			ops = append(ops, lineOp{'+', to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		ops = append(ops, lineOp{'-', from[i]})
+	}
+	for ; j < len(to); j++ {
+		ops = append(ops, lineOp{'+', to[j]})
+	}
+	return ops
+}
+
+// hunk is ops[first:last], covering fromLen lines of the from side from
+// line fromStart and toLen lines of the to side from line toStart (both
+// 1-based).
+type hunk struct {
+	first, last        int
+	fromStart, fromLen int
+	toStart, toLen     int
+}
+
+// unifiedHunks groups the changes in ops into hunks with context lines of
+// surrounding context, merging hunks whose context would overlap.
+func unifiedHunks(ops []lineOp, context int) []hunk {
+	var hunks []hunk
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		first := max(0, i-context)
+		if n := len(hunks); n > 0 && first <= hunks[n-1].last {
+			first = hunks[n-1].first
+			hunks = hunks[:n-1]
+		}
+		// Extend past this run of changes and its trailing context
+		last := i
+		for last < len(ops) && ops[last].kind != ' ' {
+			last++
+		}
+		i = last
+		last = min(len(ops), last+context)
+		hunks = append(hunks, hunk{first: first, last: last})
+	}
+
+	fromLine, toLine, pos := 1, 1, 0
+	for k := range hunks {
+		h := &hunks[k]
+		for ; pos < h.first; pos++ {
+			fromLine++
+			toLine++
+		}
+		h.fromStart, h.toStart = fromLine, toLine
+		for ; pos < h.last; pos++ {
+			if ops[pos].kind != '+' {
+				h.fromLen++
+				fromLine++
+			}
+			if ops[pos].kind != '-' {
+				h.toLen++
+				toLine++
+			}
+		}
+	}
+	return hunks
+}
+
+// hunkRange formats one side of a hunk header. An empty range is numbered
+// by the line before it, as diff -u does.
+func hunkRange(start, n int) string {
+	switch n {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	default:
+		return fmt.Sprintf("%d,%d", start, n)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteUnified(t *testing.T) {
+	local := &simulator.SimulationResponse{Status: "success", Events: []string{"a", "b", "c"}}
+	onChain := &simulator.SimulationResponse{Status: "error", Error: "trapped", Events: []string{"a", "x", "c", "d"}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteUnified(&buf, Diff(local, onChain), "testnet", "futurenet"))
+	assert.Equal(t, `--- testnet
++++ futurenet
+@@ -1,4 +1,6 @@
+-status: success
++status: error
++error: trapped
+ event: a
+-event: b
++event: x
+ event: c
++event: d
+`, buf.String())
+}
+
+func TestWriteUnified_Identical(t *testing.T) {
+	res := &simulator.SimulationResponse{Status: "success", Events: []string{"a"}}
+	var buf bytes.Buffer
+	require.NoError(t, WriteUnified(&buf, Diff(res, res), "a", "b"))
+	assert.Empty(t, buf.String())
+}
+
+func TestWriteUnified_SeparateHunks(t *testing.T) {
+	var events, changed []string
+	for i := 0; i < 20; i++ {
+		events = append(events, fmt.Sprintf("e%d", i))
+	}
+	changed = append(changed, events...)
+	changed[2], changed[17] = "x2", "x17"
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteUnified(&buf,
+		Diff(&simulator.SimulationResponse{Status: "success", Events: events},
+			&simulator.SimulationResponse{Status: "success", Events: changed}), "a", "b"))
+	assert.Contains(t, buf.String(), "@@ -1,7 +1,7 @@\n status: success\n event: e0\n event: e1\n-event: e2\n+event: x2\n")
+	assert.Contains(t, buf.String(), "@@ -16,6 +16,6 @@\n event: e14\n event: e15\n event: e16\n-event: e17\n+event: x17\n event: e18\n event: e19\n")
+}
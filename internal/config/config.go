@@ -40,6 +40,10 @@ type Config struct {
 	LogLevel          string   `json:"log_level,omitempty"`
 	CachePath         string   `json:"cache_path,omitempty"`
 	RPCToken          string   `json:"rpc_token,omitempty"`
+	// ArchivalRpcUrl is a full-history Soroban RPC for ledgers the regular
+	// endpoints no longer retain. Set via archival_rpc_url in config or
+	// ERST_ARCHIVAL_RPC_URL.
+	ArchivalRpcUrl string `json:"archival_rpc_url,omitempty"`
 	// CrashReporting enables opt-in anonymous crash reporting.
 	// Set via crash_reporting = true in config or ERST_CRASH_REPORTING=true.
 	CrashReporting bool `json:"crash_reporting,omitempty"`
@@ -109,6 +113,7 @@ func Load() (*Config, error) {
 		LogLevel:       getEnv("ERST_LOG_LEVEL", defaultConfig.LogLevel),
 		CachePath:      getEnv("ERST_CACHE_PATH", defaultConfig.CachePath),
 		RPCToken:       getEnv("ERST_RPC_TOKEN", ""),
+		ArchivalRpcUrl: getEnv("ERST_ARCHIVAL_RPC_URL", ""),
 		CrashEndpoint:  getEnv("ERST_CRASH_ENDPOINT", ""),
 		CrashSentryDSN: getEnv("ERST_SENTRY_DSN", ""),
 		RequestTimeout: defaultRequestTimeout,
@@ -231,6 +236,8 @@ func (c *Config) parseTOML(content string) error {
 			c.CachePath = value
 		case "rpc_token":
 			c.RPCToken = value
+		case "archival_rpc_url":
+			c.ArchivalRpcUrl = value
 		case "crash_reporting":
 			c.CrashReporting = value == "true" || value == "1" || value == "yes"
 		case "crash_endpoint":
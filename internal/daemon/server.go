@@ -23,7 +23,7 @@ import (
 // Server represents the JSON-RPC daemon server
 type Server struct {
 	rpcClient *stellarrpc.Client
-	simulator *simulator.Runner
+	simulator simulator.RunnerInterface
 	authToken string
 }
 
@@ -0,0 +1,95 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Account is an account address as a transaction names it. For a muxed
+// account (SEP-23), Address is the M-address and AccountID and MuxedID are
+// the underlying G-address and memo ID; ledger entries are keyed by the
+// G-address alone.
+type Account struct {
+	Address   string  `json:"address"`
+	AccountID string  `json:"account_id,omitempty"`
+	MuxedID   *uint64 `json:"muxed_id,omitempty"`
+}
+
+// NewAccount describes a MuxedAccount from the XDR.
+func NewAccount(a xdr.MuxedAccount) (Account, error) {
+	switch a.Type {
+	case xdr.CryptoKeyTypeKeyTypeEd25519:
+		address, err := a.GetAddress()
+		return Account{Address: address}, err
+	case xdr.CryptoKeyTypeKeyTypeMuxedEd25519:
+		base, err := strkey.Encode(strkey.VersionByteAccountID, a.Med25519.Ed25519[:])
+		if err != nil {
+			return Account{}, err
+		}
+		var muxed strkey.MuxedAccount
+		if err := muxed.SetAccountID(base); err != nil {
+			return Account{}, err
+		}
+		muxed.SetID(uint64(a.Med25519.Id))
+		return muxedAccount(&muxed)
+	default:
+		return Account{}, fmt.Errorf("unsupported account type %s", a.Type)
+	}
+}
+
+// ParseAccount parses a G- or M-address.
+func ParseAccount(address string) (Account, error) {
+	if muxed, err := strkey.DecodeMuxedAccount(address); err == nil {
+		return muxedAccount(muxed)
+	}
+	if !strkey.IsValidEd25519PublicKey(address) {
+		return Account{}, fmt.Errorf("%q is not a G or M account address", address)
+	}
+	return Account{Address: address}, nil
+}
+
+func muxedAccount(m *strkey.MuxedAccount) (Account, error) {
+	address, err := m.Address()
+	if err != nil {
+		return Account{}, err
+	}
+	base, err := m.AccountID()
+	if err != nil {
+		return Account{}, err
+	}
+	id := m.ID()
+	return Account{Address: address, AccountID: base, MuxedID: &id}, nil
+}
+
+// Base returns the G-address of the account, which for a muxed account is
+// the underlying account.
+func (a Account) Base() string {
+	if a.AccountID != "" {
+		return a.AccountID
+	}
+	return a.Address
+}
+
+// String renders a muxed account in both forms, for example
+// "MA… (GA…, memo ID 42)", and any other account as its address.
+func (a Account) String() string {
+	if a.MuxedID == nil {
+		return a.Address
+	}
+	return fmt.Sprintf("%s (%s, memo ID %d)", a.Address, a.AccountID, *a.MuxedID)
+}
+
+// describeMuxedAccount renders a as Account.String does, falling back to
+// its address when it cannot be decoded.
+func describeMuxedAccount(a xdr.MuxedAccount) string {
+	account, err := NewAccount(a)
+	if err != nil {
+		return a.Address()
+	}
+	return account.String()
+}
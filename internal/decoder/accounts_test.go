@@ -0,0 +1,76 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAccount = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+
+func testMuxedAddress(t *testing.T, id uint64) string {
+	t.Helper()
+	var m strkey.MuxedAccount
+	require.NoError(t, m.SetAccountID(testAccount))
+	m.SetID(id)
+	address, err := m.Address()
+	require.NoError(t, err)
+	return address
+}
+
+func TestNewAccount(t *testing.T) {
+	plain, err := NewAccount(xdr.MustMuxedAddress(testAccount))
+	require.NoError(t, err)
+	assert.Equal(t, Account{Address: testAccount}, plain)
+	assert.Equal(t, testAccount, plain.String())
+	assert.Equal(t, testAccount, plain.Base())
+
+	mAddress := testMuxedAddress(t, 42)
+	muxed, err := NewAccount(xdr.MustMuxedAddress(mAddress))
+	require.NoError(t, err)
+	assert.Equal(t, mAddress, muxed.Address)
+	assert.Equal(t, testAccount, muxed.AccountID)
+	require.NotNil(t, muxed.MuxedID)
+	assert.Equal(t, uint64(42), *muxed.MuxedID)
+	assert.Equal(t, testAccount, muxed.Base())
+	assert.Equal(t, mAddress+" ("+testAccount+", memo ID 42)", muxed.String())
+}
+
+func TestParseAccount(t *testing.T) {
+	mAddress := testMuxedAddress(t, 7)
+	muxed, err := ParseAccount(mAddress)
+	require.NoError(t, err)
+	assert.Equal(t, testAccount, muxed.Base())
+	assert.Equal(t, uint64(7), *muxed.MuxedID)
+
+	plain, err := ParseAccount(testAccount)
+	require.NoError(t, err)
+	assert.Nil(t, plain.MuxedID)
+
+	for _, bad := range []string{"", "not-an-account", "CAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAD2KM"} {
+		_, err := ParseAccount(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestAnalyzeEnvelope_MuxedSource(t *testing.T) {
+	mAddress := testMuxedAddress(t, 9)
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1:   &xdr.TransactionV1Envelope{Tx: xdr.Transaction{SourceAccount: xdr.MustMuxedAddress(mAddress)}},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	decoded, err := AnalyzeEnvelope(b64)
+	require.NoError(t, err)
+	assert.Equal(t, mAddress, decoded.Source)
+	assert.Equal(t, testAccount, decoded.SourceAccount.AccountID)
+	assert.Equal(t, uint64(9), *decoded.SourceAccount.MuxedID)
+}
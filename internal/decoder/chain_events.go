@@ -0,0 +1,66 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// DecodeChainEvent converts an event returned by getEvents into the shape the
+// simulator reports events in, rendering its base64 XDR topics and value, so
+// on-chain and simulated events can be printed and diffed alike.
+func DecodeChainEvent(e rpc.ChainEvent) (simulator.DiagnosticEvent, error) {
+	decoded := simulator.DiagnosticEvent{
+		EventType:                e.Type,
+		Topics:                   make([]string, 0, len(e.Topic)),
+		InSuccessfulContractCall: e.InSuccessfulContractCall,
+	}
+	if e.ContractID != "" {
+		id := e.ContractID
+		decoded.ContractID = &id
+	}
+
+	for i, topic := range e.Topic {
+		val, err := decodeScValBase64(topic)
+		if err != nil {
+			return decoded, fmt.Errorf("event %s topic %d: %w", e.ID, i, err)
+		}
+		decoded.Topics = append(decoded.Topics, val.String())
+	}
+	if e.Value != "" {
+		val, err := decodeScValBase64(e.Value)
+		if err != nil {
+			return decoded, fmt.Errorf("event %s value: %w", e.ID, err)
+		}
+		decoded.Data = val.String()
+	}
+	return decoded, nil
+}
+
+// DecodeChainEvents decodes the events emitted by transaction txHash, in
+// order, skipping events from other transactions in the same ledger.
+func DecodeChainEvents(events []rpc.ChainEvent, txHash string) ([]simulator.DiagnosticEvent, error) {
+	var decoded []simulator.DiagnosticEvent
+	for _, e := range events {
+		if txHash != "" && e.TxHash != txHash {
+			continue
+		}
+		d, err := DecodeChainEvent(e)
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, d)
+	}
+	return decoded, nil
+}
+
+func decodeScValBase64(b64 string) (xdr.ScVal, error) {
+	var val xdr.ScVal
+	err := UnmarshalBase64RoundTrip(b64, &val, "scval")
+	return val, err
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func symbolB64(t *testing.T, s string) string {
+	t.Helper()
+	sym := xdr.ScSymbol(s)
+	b64, err := xdr.MarshalBase64(xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym})
+	require.NoError(t, err)
+	return b64
+}
+
+func TestDecodeChainEvents(t *testing.T) {
+	events := []rpc.ChainEvent{
+		{Type: "contract", ContractID: "CABC", TxHash: "aa", Topic: []string{symbolB64(t, "transfer")}, Value: symbolB64(t, "done")},
+		{Type: "contract", ContractID: "CDEF", TxHash: "bb", Topic: []string{symbolB64(t, "mint")}},
+	}
+
+	decoded, err := DecodeChainEvents(events, "aa")
+	require.NoError(t, err)
+	require.Len(t, decoded, 1)
+	assert.Equal(t, "contract", decoded[0].EventType)
+	require.NotNil(t, decoded[0].ContractID)
+	assert.Equal(t, "CABC", *decoded[0].ContractID)
+	assert.Equal(t, []string{"transfer"}, decoded[0].Topics)
+	assert.Equal(t, "done", decoded[0].Data)
+
+	all, err := DecodeChainEvents(events, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestDecodeChainEvent_CorruptTopic(t *testing.T) {
+	_, err := DecodeChainEvent(rpc.ChainEvent{ID: "1", Topic: []string{"AAAA"}})
+	assert.Error(t, err)
+}
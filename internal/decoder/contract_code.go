@@ -0,0 +1,129 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// StellarAssetExecutable is ContractWasm.Hash for a Stellar Asset Contract,
+// which runs built-in code rather than WASM.
+const StellarAssetExecutable = "stellar-asset"
+
+// ContractWasm is the code a contract instance runs on one network.
+type ContractWasm struct {
+	Contract string `json:"contract"`
+	// Hash is the hex SHA-256 of the WASM, or StellarAssetExecutable. It is
+	// empty when the contract was not found on the network.
+	Hash string `json:"wasm_hash,omitempty"`
+	// Size is the WASM's length in bytes, when its code entry was fetched.
+	Size int `json:"wasm_size,omitempty"`
+}
+
+// Found reports whether the contract exists on the network.
+func (w ContractWasm) Found() bool {
+	return w.Hash != ""
+}
+
+func (w ContractWasm) String() string {
+	switch {
+	case !w.Found():
+		return "not deployed"
+	case w.Size > 0:
+		return fmt.Sprintf("%s (%d bytes)", w.Hash, w.Size)
+	default:
+		return w.Hash
+	}
+}
+
+// WasmDiff is a contract whose code differs between two networks.
+type WasmDiff struct {
+	Contract string       `json:"contract"`
+	A        ContractWasm `json:"a"`
+	B        ContractWasm `json:"b"`
+}
+
+// ContractInstanceKeys picks the footprint keys, base64 XDR LedgerKeys, that
+// hold a contract instance and maps each to its contract's address.
+func ContractInstanceKeys(keys []string) map[string]string {
+	instances := make(map[string]string)
+	for _, k := range keys {
+		var key xdr.LedgerKey
+		if err := xdr.SafeUnmarshalBase64(k, &key); err != nil {
+			continue
+		}
+		if cd := key.ContractData; key.Type == xdr.LedgerEntryTypeContractData && cd != nil &&
+			cd.Key.Type == xdr.ScValTypeScvLedgerKeyContractInstance {
+			instances[k] = contractAddress(cd.Contract)
+		}
+	}
+	return instances
+}
+
+// InstanceWasm reads the contract and executable of a base64 contract
+// instance LedgerEntry. codeKey is the base64 LedgerKey of the WASM to fetch
+// for its size, empty for a Stellar Asset Contract.
+func InstanceWasm(entryXdr string) (w ContractWasm, codeKey string, err error) {
+	var entry xdr.LedgerEntry
+	if err := xdr.SafeUnmarshalBase64(entryXdr, &entry); err != nil {
+		return ContractWasm{}, "", fmt.Errorf("invalid ledger entry: %w", err)
+	}
+	cd := entry.Data.ContractData
+	if entry.Data.Type != xdr.LedgerEntryTypeContractData || cd == nil || cd.Val.Type != xdr.ScValTypeScvContractInstance || cd.Val.Instance == nil {
+		return ContractWasm{}, "", fmt.Errorf("not a contract instance entry")
+	}
+
+	w.Contract = contractAddress(cd.Contract)
+	exec := cd.Val.Instance.Executable
+	if exec.Type != xdr.ContractExecutableTypeContractExecutableWasm || exec.WasmHash == nil {
+		w.Hash = StellarAssetExecutable
+		return w, "", nil
+	}
+	w.Hash = hex.EncodeToString(exec.WasmHash[:])
+	codeKey, err = xdr.MarshalBase64(xdr.LedgerKey{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.LedgerKeyContractCode{Hash: *exec.WasmHash},
+	})
+	return w, codeKey, err
+}
+
+// WasmSize returns the length of the WASM in a base64 contract code
+// LedgerEntry after checking it hashes to hash.
+func WasmSize(entryXdr, hash string) (int, error) {
+	var entry xdr.LedgerEntry
+	if err := xdr.SafeUnmarshalBase64(entryXdr, &entry); err != nil {
+		return 0, fmt.Errorf("invalid ledger entry: %w", err)
+	}
+	code := entry.Data.ContractCode
+	if entry.Data.Type != xdr.LedgerEntryTypeContractCode || code == nil {
+		return 0, fmt.Errorf("not a contract code entry")
+	}
+	sum := sha256.Sum256(code.Code)
+	if got := hex.EncodeToString(sum[:]); got != hash {
+		return 0, fmt.Errorf("WASM hashes to %s, not %s", got, hash)
+	}
+	return len(code.Code), nil
+}
+
+// DiffContractWasms lists, sorted by contract, the contracts whose code
+// differs between networks a and b, including those deployed on only one.
+// A contract missing from either map, whose code could not be looked up
+// there, is skipped.
+func DiffContractWasms(a, b map[string]ContractWasm) []WasmDiff {
+	var diffs []WasmDiff
+	for c, wa := range a {
+		wb, ok := b[c]
+		if !ok || wa.Hash == wb.Hash {
+			continue
+		}
+		diffs = append(diffs, WasmDiff{Contract: c, A: wa, B: wb})
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Contract < diffs[j].Contract })
+	return diffs
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func instanceVal(exec xdr.ContractExecutable) xdr.ScVal {
+	return xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{Executable: exec}}
+}
+
+func instanceKeyVal() xdr.ScVal {
+	return xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance}
+}
+
+func TestContractInstanceKeys(t *testing.T) {
+	entry := contractDataEntry(instanceKeyVal(), instanceVal(xdr.ContractExecutable{}))
+	instanceKey, err := entry.LedgerKey()
+	require.NoError(t, err)
+	dataKey, err := contractDataEntry(symVal("balance"), symVal("x")).LedgerKey()
+	require.NoError(t, err)
+
+	instanceB64 := marshalTestXDR(t, instanceKey)
+	got := ContractInstanceKeys([]string{instanceB64, marshalTestXDR(t, dataKey), "not-xdr"})
+
+	require.Len(t, got, 1)
+	assert.Equal(t, contractAddress(entry.Data.ContractData.Contract), got[instanceB64])
+}
+
+func TestInstanceWasm_WasmExecutable(t *testing.T) {
+	code := []byte("\x00asm\x01\x00\x00\x00")
+	hash := xdr.Hash(sha256.Sum256(code))
+	entry := contractDataEntry(instanceKeyVal(), instanceVal(xdr.ContractExecutable{
+		Type:     xdr.ContractExecutableTypeContractExecutableWasm,
+		WasmHash: &hash,
+	}))
+
+	w, codeKey, err := InstanceWasm(marshalTestXDR(t, entry))
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(hash[:]), w.Hash)
+	assert.True(t, w.Found())
+
+	var key xdr.LedgerKey
+	require.NoError(t, xdr.SafeUnmarshalBase64(codeKey, &key))
+	require.NotNil(t, key.ContractCode)
+	assert.Equal(t, hash, key.ContractCode.Hash)
+
+	codeEntry := xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.ContractCodeEntry{Hash: hash, Code: code},
+	}}
+	size, err := WasmSize(marshalTestXDR(t, codeEntry), w.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, len(code), size)
+
+	_, err = WasmSize(marshalTestXDR(t, codeEntry), "00")
+	assert.Error(t, err)
+}
+
+func TestInstanceWasm_StellarAsset(t *testing.T) {
+	entry := contractDataEntry(instanceKeyVal(), instanceVal(xdr.ContractExecutable{
+		Type: xdr.ContractExecutableTypeContractExecutableStellarAsset,
+	}))
+
+	w, codeKey, err := InstanceWasm(marshalTestXDR(t, entry))
+	require.NoError(t, err)
+	assert.Equal(t, StellarAssetExecutable, w.Hash)
+	assert.Empty(t, codeKey)
+}
+
+func TestInstanceWasm_NotAnInstance(t *testing.T) {
+	_, _, err := InstanceWasm(marshalTestXDR(t, contractDataEntry(symVal("k"), symVal("v"))))
+	assert.Error(t, err)
+}
+
+func TestDiffContractWasms(t *testing.T) {
+	a := map[string]ContractWasm{
+		"CB": {Contract: "CB", Hash: "11"},
+		"CA": {Contract: "CA", Hash: "22"},
+		"CC": {Contract: "CC", Hash: "33"},
+		"CD": {Contract: "CD", Hash: "44"},
+	}
+	b := map[string]ContractWasm{
+		"CB": {Contract: "CB", Hash: "99"},
+		"CA": {Contract: "CA"},
+		"CC": {Contract: "CC", Hash: "33"},
+	}
+
+	diffs := DiffContractWasms(a, b)
+	require.Len(t, diffs, 2)
+	assert.Equal(t, "CA", diffs[0].Contract)
+	assert.Equal(t, "not deployed", diffs[0].B.String())
+	assert.Equal(t, "CB", diffs[1].Contract)
+	assert.Equal(t, "99", diffs[1].B.Hash)
+}
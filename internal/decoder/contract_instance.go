@@ -0,0 +1,53 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// InstanceStorageEntry is one key and value of a contract's instance
+// storage.
+type InstanceStorageEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ContractInstance is a decoded contract instance entry: the code the
+// contract runs and the instance storage it keeps alongside it, often its
+// admin, configuration and other settings.
+type ContractInstance struct {
+	Contract string `json:"contract"`
+	// Executable is the hex SHA-256 of the contract's WASM, or
+	// StellarAssetExecutable for the built-in Stellar Asset Contract.
+	Executable string                 `json:"executable"`
+	Storage    []InstanceStorageEntry `json:"storage"`
+}
+
+// DecodeContractInstance decodes a base64 contract instance LedgerEntry,
+// listing its storage in the order the map holds it, which is sorted by key.
+func DecodeContractInstance(entryXdr string) (*ContractInstance, error) {
+	var entry xdr.LedgerEntry
+	if err := xdr.SafeUnmarshalBase64(entryXdr, &entry); err != nil {
+		return nil, fmt.Errorf("invalid ledger entry: %w", err)
+	}
+	cd := entry.Data.ContractData
+	if entry.Data.Type != xdr.LedgerEntryTypeContractData || cd == nil || cd.Val.Type != xdr.ScValTypeScvContractInstance || cd.Val.Instance == nil {
+		return nil, fmt.Errorf("not a contract instance entry")
+	}
+
+	inst := &ContractInstance{Contract: contractAddress(cd.Contract), Executable: StellarAssetExecutable, Storage: []InstanceStorageEntry{}}
+	if exec := cd.Val.Instance.Executable; exec.Type == xdr.ContractExecutableTypeContractExecutableWasm && exec.WasmHash != nil {
+		inst.Executable = hex.EncodeToString(exec.WasmHash[:])
+	}
+	if storage := cd.Val.Instance.Storage; storage != nil {
+		for _, e := range *storage {
+			inst.Storage = append(inst.Storage, InstanceStorageEntry{Key: e.Key.String(), Value: e.Val.String()})
+		}
+	}
+	return inst, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeContractInstance(t *testing.T) {
+	hash := xdr.Hash{0xab}
+	storage := xdr.ScMap{
+		{Key: symVal("admin"), Val: symVal("alice")},
+		{Key: symVal("paused"), Val: symVal("no")},
+	}
+	val := instanceVal(xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableWasm, WasmHash: &hash})
+	val.Instance.Storage = &storage
+	entry := contractDataEntry(instanceKeyVal(), val)
+
+	inst, err := DecodeContractInstance(marshalTestXDR(t, entry))
+	require.NoError(t, err)
+	assert.Equal(t, contractAddress(entry.Data.ContractData.Contract), inst.Contract)
+	assert.Equal(t, hex.EncodeToString(hash[:]), inst.Executable)
+	assert.Equal(t, []InstanceStorageEntry{
+		{Key: symVal("admin").String(), Value: symVal("alice").String()},
+		{Key: symVal("paused").String(), Value: symVal("no").String()},
+	}, inst.Storage)
+}
+
+func TestDecodeContractInstance_StellarAsset(t *testing.T) {
+	entry := contractDataEntry(instanceKeyVal(), instanceVal(xdr.ContractExecutable{
+		Type: xdr.ContractExecutableTypeContractExecutableStellarAsset,
+	}))
+
+	inst, err := DecodeContractInstance(marshalTestXDR(t, entry))
+	require.NoError(t, err)
+	assert.Equal(t, StellarAssetExecutable, inst.Executable)
+	assert.Empty(t, inst.Storage)
+
+	_, err = DecodeContractInstance(marshalTestXDR(t, contractDataEntry(symVal("k"), symVal("v"))))
+	assert.Error(t, err)
+}
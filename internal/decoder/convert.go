@@ -0,0 +1,92 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// EncodeText writes XDR bytes as text in enc: padded standard base64,
+// unpadded URL-safe base64, or lowercase hex without a 0x prefix.
+func EncodeText(data []byte, enc Encoding) (string, error) {
+	switch enc {
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString(data), nil
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(data), nil
+	case EncodingHex:
+		return hex.EncodeToString(data), nil
+	default:
+		return "", fmt.Errorf("unsupported output encoding %q (use: base64, base64url, hex)", enc)
+	}
+}
+
+// ConvertXDRText rewrites XDR text from one encoding to another, checking
+// the bytes in between. Without v the bytes only need to be a whole number
+// of 4-byte XDR units. With v they must decode as v, as UnmarshalRoundTrip
+// checks, and re-encode to exactly the same bytes: XDR has one encoding per
+// value, so any difference, such as non-zero padding, means the input was
+// not canonical. With EncodingAuto the candidates are tried as in
+// DecodeXDRText.
+func ConvertXDRText(text string, from, to Encoding, v XDRValue, what string) (string, error) {
+	text = strings.TrimSpace(text)
+	candidates := []Encoding{from}
+	if from == EncodingAuto || from == "" {
+		candidates = []Encoding{EncodingBase64, EncodingBase64URL}
+		if looksHex(text) {
+			candidates = append([]Encoding{EncodingHex}, candidates...)
+		}
+	}
+
+	var firstErr error
+	for _, e := range candidates {
+		data, err := decodeCanonical(text, e, v, what)
+		if err == nil {
+			return EncodeText(data, to)
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return "", firstErr
+}
+
+func decodeCanonical(text string, enc Encoding, v XDRValue, what string) ([]byte, error) {
+	if v != nil {
+		if err := decodeXDRTextAs(text, enc, v, what); err != nil {
+			return nil, err
+		}
+	}
+	data, err := decodeText(text, enc)
+	if err != nil {
+		return nil, errors.WrapXDRCorrupt(what, fmt.Sprintf("invalid %s: %v", enc, err))
+	}
+	if v == nil {
+		switch {
+		case len(data) == 0:
+			return nil, errors.WrapXDRCorrupt(what, "input is empty")
+		case len(data)%4 != 0:
+			return nil, errors.WrapXDRCorrupt(what, fmt.Sprintf("%d bytes is not a whole number of 4-byte XDR units", len(data)))
+		}
+		return data, nil
+	}
+
+	canonical, err := v.MarshalBinary()
+	if err != nil {
+		return nil, errors.WrapXDRCorrupt(what, fmt.Sprintf("decoded value does not re-encode: %v", err))
+	}
+	// UnmarshalRoundTrip has already checked the lengths match.
+	for i := range data {
+		if data[i] != canonical[i] {
+			return nil, errors.WrapXDRCorrupt(what, fmt.Sprintf("non-canonical encoding: byte %d is 0x%02x, the canonical encoding has 0x%02x",
+				i, data[i], canonical[i]))
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertXDRText(t *testing.T) {
+	raw, err := xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &xdr.ScBytes{0xfb, 0xff, 0xfe, 0x01}}.MarshalBinary()
+	require.NoError(t, err)
+	std := base64.StdEncoding.EncodeToString(raw)
+
+	got, err := ConvertXDRText(std, EncodingBase64, EncodingHex, nil, "scval")
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(raw), got)
+
+	got, err = ConvertXDRText(hex.EncodeToString(raw), EncodingAuto, EncodingBase64URL, &xdr.ScVal{}, "scval")
+	require.NoError(t, err)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(raw), got)
+
+	got, err = ConvertXDRText(base64.RawURLEncoding.EncodeToString(raw), EncodingAuto, EncodingBase64, &xdr.ScVal{}, "scval")
+	require.NoError(t, err)
+	assert.Equal(t, std, got)
+
+	_, err = ConvertXDRText(std, EncodingBase64, EncodingAuto, nil, "scval")
+	assert.Error(t, err)
+}
+
+func TestConvertXDRText_Invalid(t *testing.T) {
+	_, err := ConvertXDRText("zzzz", EncodingHex, EncodingBase64, nil, "xdr")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrXDRCorrupt))
+	assert.Contains(t, err.Error(), "invalid hex")
+
+	_, err = ConvertXDRText("0102", EncodingHex, EncodingBase64, nil, "xdr")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a whole number of 4-byte XDR units")
+
+	// The discriminant of a u32 with no value after it.
+	_, err = ConvertXDRText("00000003", EncodingHex, EncodingBase64, &xdr.ScVal{}, "scval")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrXDRCorrupt))
+}
+
+func TestConvertXDRText_NonCanonical(t *testing.T) {
+	raw, err := xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &xdr.ScBytes{0x01}}.MarshalBinary()
+	require.NoError(t, err)
+	// The single byte of opaque data is followed by three padding bytes,
+	// which must be zero.
+	raw[len(raw)-1] = 0x07
+	text := hex.EncodeToString(raw)
+
+	_, err = ConvertXDRText(text, EncodingHex, EncodingBase64, nil, "scval")
+	require.NoError(t, err, "without a type only the framing is checked")
+
+	_, err = ConvertXDRText(text, EncodingHex, EncodingBase64, &xdr.ScVal{}, "scval")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrXDRCorrupt))
+	assert.Contains(t, err.Error(), "non-zero padding")
+
+	_, err = ConvertXDRText("00000002", EncodingHex, EncodingBase64, new(lenientBool), "bool")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "non-canonical encoding: byte 3 is 0x02, the canonical encoding has 0x01")
+}
+
+// lenientBool decodes any non-zero word as true, as the SDK's decoder does
+// not, so that one value has more than one encoding.
+type lenientBool bool
+
+func (b *lenientBool) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return io.ErrUnexpectedEOF
+	}
+	*b = lenientBool(binary.BigEndian.Uint32(data) != 0)
+	return nil
+}
+
+func (b lenientBool) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 4)
+	if b {
+		out[3] = 1
+	}
+	return out, nil
+}
@@ -10,11 +10,14 @@ import (
 )
 
 type DecodedEnvelope struct {
-	Type       string
-	Source     string
-	Fee        int64
-	Operations []xdr.Operation
-	InnerTx    *DecodedEnvelope // for FeeBump
+	Type   string
+	Source string
+	// SourceAccount is Source with the underlying account and memo ID of a
+	// muxed source; for a fee bump it is the fee source.
+	SourceAccount Account
+	Fee           int64
+	Operations    []xdr.Operation
+	InnerTx       *DecodedEnvelope // for FeeBump
 }
 
 func AnalyzeEnvelope(b64 string) (*DecodedEnvelope, error) {
@@ -45,18 +48,24 @@ func decodeV0(tx xdr.TransactionV0) (*DecodedEnvelope, error) {
 		Ed25519: &tx.SourceAccountEd25519,
 	}
 	return &DecodedEnvelope{
-		Type:       "TransactionV0",
-		Source:     source.Address(),
-		Fee:        int64(tx.Fee),
-		Operations: tx.Operations,
+		Type:          "TransactionV0",
+		Source:        source.Address(),
+		SourceAccount: Account{Address: source.Address()},
+		Fee:           int64(tx.Fee),
+		Operations:    tx.Operations,
 	}, nil
 }
 func decodeV1(tx xdr.Transaction) (*DecodedEnvelope, error) {
+	account, err := NewAccount(tx.SourceAccount)
+	if err != nil {
+		return nil, err
+	}
 	return &DecodedEnvelope{
-		Type:       "TransactionV1",
-		Source:     tx.SourceAccount.Address(),
-		Fee:        int64(tx.Fee),
-		Operations: tx.Operations,
+		Type:          "TransactionV1",
+		Source:        account.Address,
+		SourceAccount: account,
+		Fee:           int64(tx.Fee),
+		Operations:    tx.Operations,
 	}, nil
 }
 
@@ -66,11 +75,16 @@ func decodeFeeBump(fb xdr.FeeBumpTransaction) (*DecodedEnvelope, error) {
 		return nil, err
 	}
 
+	account, err := NewAccount(fb.FeeSource)
+	if err != nil {
+		return nil, err
+	}
 	return &DecodedEnvelope{
-		Type:    "FeeBumpTransaction",
-		Source:  fb.FeeSource.Address(),
-		Fee:     int64(fb.Fee),
-		InnerTx: inner,
+		Type:          "FeeBumpTransaction",
+		Source:        account.Address,
+		SourceAccount: account,
+		Fee:           int64(fb.Fee),
+		InnerTx:       inner,
 	}, nil
 }
 
@@ -0,0 +1,102 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// FeeBumpResult splits a fee bump's result into the outer transaction, which
+// only pays the fee, and the inner transaction, whose operations carry the
+// logic that succeeded or failed.
+type FeeBumpResult struct {
+	FeeSource  string `json:"fee_source"`
+	MaxFee     int64  `json:"max_fee"`
+	FeeCharged int64  `json:"fee_charged"`
+	OuterCode  string `json:"outer_code,omitempty"`
+
+	// InnerHash is the hash of the inner transaction, under which Horizon
+	// also indexes the fee bump.
+	InnerHash   string `json:"inner_hash"`
+	InnerSource string `json:"inner_source"`
+
+	// The inner transaction's result. They are unset when the fee bump was
+	// rejected before the inner transaction ran, for example for an
+	// insufficient fee.
+	InnerCode        string `json:"inner_code,omitempty"`
+	InnerExplanation string `json:"inner_explanation,omitempty"`
+	InnerFeeCharged  int64  `json:"inner_fee_charged,omitempty"`
+	InnerSuccessful  bool   `json:"inner_successful"`
+}
+
+// InnerRan reports whether the result records the inner transaction's
+// outcome.
+func (r *FeeBumpResult) InnerRan() bool {
+	return r.InnerCode != ""
+}
+
+// DecodeFeeBumpResult describes a fee bump transaction's outer and inner
+// results. It returns nil for a transaction that is not a fee bump. The
+// inner hash is computed under passphrase; without one it is taken from the
+// result, which only records it once the inner transaction ran.
+func DecodeFeeBumpResult(envelopeXdr, resultXdr, passphrase string) (*FeeBumpResult, error) {
+	var env xdr.TransactionEnvelope
+	if err := UnmarshalBase64RoundTrip(envelopeXdr, &env, "transaction envelope"); err != nil {
+		return nil, err
+	}
+	if env.Type != xdr.EnvelopeTypeEnvelopeTypeTxFeeBump || env.FeeBump == nil {
+		return nil, nil
+	}
+	fb := env.FeeBump.Tx
+	if fb.InnerTx.V1 == nil {
+		return nil, fmt.Errorf("fee bump has no inner transaction")
+	}
+
+	feeSource, err := NewAccount(fb.FeeSource)
+	if err != nil {
+		return nil, err
+	}
+	innerSource, err := NewAccount(fb.InnerTx.V1.Tx.SourceAccount)
+	if err != nil {
+		return nil, err
+	}
+	r := &FeeBumpResult{
+		FeeSource:   feeSource.String(),
+		MaxFee:      int64(fb.Fee),
+		InnerSource: innerSource.String(),
+	}
+	if passphrase != "" {
+		inner := xdr.TransactionEnvelope{Type: xdr.EnvelopeTypeEnvelopeTypeTx, V1: fb.InnerTx.V1}
+		hash, err := network.HashTransactionInEnvelope(inner, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash the inner transaction: %w", err)
+		}
+		r.InnerHash = hex.EncodeToString(hash[:])
+	}
+
+	if resultXdr == "" {
+		return r, nil
+	}
+	var result xdr.TransactionResult
+	if err := UnmarshalBase64RoundTrip(resultXdr, &result, "transaction result"); err != nil {
+		return nil, err
+	}
+	r.FeeCharged = int64(result.FeeCharged)
+	r.OuterCode = DecodeTransactionResultCode(result.Result.Code).Code
+	if pair := result.Result.InnerResultPair; pair != nil {
+		if r.InnerHash == "" {
+			r.InnerHash = hex.EncodeToString(pair.TransactionHash[:])
+		}
+		info := DecodeTransactionResultCode(pair.Result.Result.Code)
+		r.InnerCode = info.Code
+		r.InnerExplanation = info.Explanation
+		r.InnerFeeCharged = int64(pair.Result.FeeCharged)
+		r.InnerSuccessful = pair.Result.Result.Code == xdr.TransactionResultCodeTxSuccess
+	}
+	return r, nil
+}
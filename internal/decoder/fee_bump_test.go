@@ -0,0 +1,101 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const feeBumpSource = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+func feeBumpTestEnvelope(t *testing.T) (xdr.TransactionEnvelope, xdr.TransactionEnvelope) {
+	t.Helper()
+	inner := sequenceTestEnvelope(t, 7, xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone})
+	return inner, xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{Tx: xdr.FeeBumpTransaction{
+			FeeSource: xdr.MustMuxedAddress(feeBumpSource),
+			Fee:       500,
+			InnerTx:   xdr.FeeBumpTransactionInnerTx{Type: xdr.EnvelopeTypeEnvelopeTypeTx, V1: inner.V1},
+		}},
+	}
+}
+
+func feeBumpTestResult(t *testing.T, outer, inner xdr.TransactionResultCode, innerHash xdr.Hash) string {
+	t.Helper()
+	return marshalTestXDR(t, xdr.TransactionResult{
+		FeeCharged: 300,
+		Result: xdr.TransactionResultResult{
+			Code: outer,
+			InnerResultPair: &xdr.InnerTransactionResultPair{
+				TransactionHash: innerHash,
+				Result: xdr.InnerTransactionResult{
+					FeeCharged: 200,
+					Result:     xdr.InnerTransactionResultResult{Code: inner, Results: &[]xdr.OperationResult{}},
+				},
+			},
+		},
+	})
+}
+
+func TestDecodeFeeBumpResult_InnerFailed(t *testing.T) {
+	inner, env := feeBumpTestEnvelope(t)
+	innerHash, err := network.HashTransactionInEnvelope(inner, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+
+	r, err := DecodeFeeBumpResult(marshalTestXDR(t, env),
+		feeBumpTestResult(t, xdr.TransactionResultCodeTxFeeBumpInnerFailed, xdr.TransactionResultCodeTxFailed, innerHash),
+		network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	assert.Equal(t, feeBumpSource, r.FeeSource)
+	assert.Equal(t, testAccount, r.InnerSource)
+	assert.Equal(t, int64(500), r.MaxFee)
+	assert.Equal(t, int64(300), r.FeeCharged)
+	assert.Equal(t, "tx_fee_bump_inner_failed", r.OuterCode)
+	assert.Equal(t, hex.EncodeToString(innerHash[:]), r.InnerHash)
+	assert.True(t, r.InnerRan())
+	assert.Equal(t, "tx_failed", r.InnerCode)
+	assert.Equal(t, int64(200), r.InnerFeeCharged)
+	assert.False(t, r.InnerSuccessful)
+}
+
+func TestDecodeFeeBumpResult_InnerHashFromResult(t *testing.T) {
+	_, env := feeBumpTestEnvelope(t)
+	recorded := xdr.Hash{1, 2, 3}
+
+	r, err := DecodeFeeBumpResult(marshalTestXDR(t, env),
+		feeBumpTestResult(t, xdr.TransactionResultCodeTxFeeBumpInnerSuccess, xdr.TransactionResultCodeTxSuccess, recorded), "")
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	assert.Equal(t, hex.EncodeToString(recorded[:]), r.InnerHash, "without a passphrase the result's hash is used")
+	assert.Equal(t, "tx_fee_bump_inner_success", r.OuterCode)
+	assert.True(t, r.InnerSuccessful)
+}
+
+func TestDecodeFeeBumpResult_OuterRejected(t *testing.T) {
+	_, env := feeBumpTestEnvelope(t)
+	r, err := DecodeFeeBumpResult(marshalTestXDR(t, env), txResult(t, xdr.TransactionResultCodeTxInsufficientFee), network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	assert.Equal(t, "tx_insufficient_fee", r.OuterCode)
+	assert.False(t, r.InnerRan())
+	assert.NotEmpty(t, r.InnerHash)
+}
+
+func TestDecodeFeeBumpResult_NotFeeBump(t *testing.T) {
+	env := sequenceTestEnvelope(t, 1, xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone})
+	r, err := DecodeFeeBumpResult(marshalTestXDR(t, env), txResult(t, xdr.TransactionResultCodeTxBadAuth), network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Nil(t, r)
+
+	_, err = DecodeFeeBumpResult("not-xdr", "", "")
+	assert.Error(t, err)
+}
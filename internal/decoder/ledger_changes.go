@@ -0,0 +1,228 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Kinds of LedgerChange.
+const (
+	ChangeCreated  = "created"
+	ChangeUpdated  = "updated"
+	ChangeRemoved  = "removed"
+	ChangeRestored = "restored"
+)
+
+// LedgerChange is one change a transaction's result meta records to a
+// ledger entry.
+type LedgerChange struct {
+	Kind string `json:"kind"`
+	// Key is the base64 XDR LedgerKey of the entry, KeyType its type and
+	// Description a readable rendering of it.
+	Key         string `json:"key"`
+	KeyType     string `json:"key_type"`
+	Description string `json:"description"`
+	// Before is the entry's value ahead of the change, when the meta records
+	// it, and After its value afterwards; a removed entry has no After.
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// ChangedLedgerKey returns the key of the entry a ledger entry change is
+// about.
+func ChangedLedgerKey(c xdr.LedgerEntryChange) (xdr.LedgerKey, bool) {
+	var entry *xdr.LedgerEntry
+	switch c.Type {
+	case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+		entry = c.Created
+	case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+		entry = c.Updated
+	case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+		if c.Removed == nil {
+			return xdr.LedgerKey{}, false
+		}
+		return *c.Removed, true
+	case xdr.LedgerEntryChangeTypeLedgerEntryState:
+		entry = c.State
+	case xdr.LedgerEntryChangeTypeLedgerEntryRestored:
+		entry = c.Restored
+	}
+	if entry == nil {
+		return xdr.LedgerKey{}, false
+	}
+	key, err := entry.LedgerKey()
+	return key, err == nil
+}
+
+// LedgerChanges decodes the base64 TransactionResultMeta and lists the
+// changes it records, in the order they were applied. The state entries
+// that precede a change are not listed themselves but give its Before.
+func LedgerChanges(resultMetaXdr string) ([]LedgerChange, error) {
+	var meta xdr.TransactionResultMeta
+	if err := UnmarshalBase64RoundTrip(resultMetaXdr, &meta, "transaction result meta"); err != nil {
+		return nil, err
+	}
+
+	changes := []LedgerChange{}
+	WalkLedgerEntryChanges(meta, func(entries xdr.LedgerEntryChanges) {
+		var state map[string]*xdr.LedgerEntry
+		for _, c := range entries {
+			key, ok := ChangedLedgerKey(c)
+			if !ok {
+				continue
+			}
+			b64, err := xdr.MarshalBase64(key)
+			if err != nil {
+				continue
+			}
+
+			change := LedgerChange{
+				Key:         b64,
+				KeyType:     strings.TrimPrefix(key.Type.String(), "LedgerEntryType"),
+				Description: DescribeLedgerKey(key),
+			}
+			switch c.Type {
+			case xdr.LedgerEntryChangeTypeLedgerEntryState:
+				if state == nil {
+					state = make(map[string]*xdr.LedgerEntry)
+				}
+				state[b64] = c.State
+				continue
+			case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+				change.Kind, change.After = ChangeCreated, DescribeLedgerEntry(*c.Created)
+			case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+				change.Kind, change.After = ChangeUpdated, DescribeLedgerEntry(*c.Updated)
+			case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+				change.Kind = ChangeRemoved
+			case xdr.LedgerEntryChangeTypeLedgerEntryRestored:
+				change.Kind, change.After = ChangeRestored, DescribeLedgerEntry(*c.Restored)
+			}
+			if before := state[b64]; before != nil {
+				change.Before = DescribeLedgerEntry(*before)
+			}
+			changes = append(changes, change)
+		}
+	})
+	return changes, nil
+}
+
+// PostStateEntries decodes the base64 TransactionResultMeta and returns the
+// state it leaves behind: every entry it creates, updates or restores, keyed
+// and valued as base64 XDR, with the last change to a key winning. Removed
+// entries map to "".
+func PostStateEntries(resultMetaXdr string) (map[string]string, error) {
+	var meta xdr.TransactionResultMeta
+	if err := UnmarshalBase64RoundTrip(resultMetaXdr, &meta, "transaction result meta"); err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]string)
+	var encodeErr error
+	WalkLedgerEntryChanges(meta, func(changes xdr.LedgerEntryChanges) {
+		for _, c := range changes {
+			var after *xdr.LedgerEntry
+			switch c.Type {
+			case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+				after = c.Created
+			case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+				after = c.Updated
+			case xdr.LedgerEntryChangeTypeLedgerEntryRestored:
+				after = c.Restored
+			case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+			default:
+				continue
+			}
+			key, ok := ChangedLedgerKey(c)
+			if !ok {
+				continue
+			}
+			b64, err := xdr.MarshalBase64(key)
+			if err != nil {
+				encodeErr = err
+				continue
+			}
+			if after == nil {
+				entries[b64] = ""
+				continue
+			}
+			if entries[b64], err = xdr.MarshalBase64(*after); err != nil {
+				encodeErr = err
+			}
+		}
+	})
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+	return entries, nil
+}
+
+// DescribeLedgerKey renders the identifying part of a ledger key, e.g. the
+// account of an account entry or the contract and key of contract data.
+func DescribeLedgerKey(key xdr.LedgerKey) string {
+	switch key.Type {
+	case xdr.LedgerEntryTypeAccount:
+		if key.Account != nil {
+			return "account " + key.Account.AccountId.Address()
+		}
+	case xdr.LedgerEntryTypeTrustline:
+		if t := key.TrustLine; t != nil {
+			return fmt.Sprintf("trustline %s / %s", t.AccountId.Address(), trustLineAssetString(t.Asset))
+		}
+	case xdr.LedgerEntryTypeContractData:
+		if cd := key.ContractData; cd != nil {
+			return fmt.Sprintf("%s %s %s",
+				contractAddress(cd.Contract), strings.ToLower(strings.TrimPrefix(cd.Durability.String(), "ContractDataDurability")), cd.Key.String())
+		}
+	case xdr.LedgerEntryTypeContractCode:
+		if key.ContractCode != nil {
+			return "wasm " + hex.EncodeToString(key.ContractCode.Hash[:])
+		}
+	case xdr.LedgerEntryTypeTtl:
+		if key.Ttl != nil {
+			return "ttl of " + hex.EncodeToString(key.Ttl.KeyHash[:])
+		}
+	}
+	return strings.ToLower(strings.TrimPrefix(key.Type.String(), "LedgerEntryType"))
+}
+
+// DescribeLedgerEntry renders the value of a ledger entry: the balance and
+// sequence of an account, the value of contract data, and so on.
+func DescribeLedgerEntry(entry xdr.LedgerEntry) string {
+	d := entry.Data
+	switch d.Type {
+	case xdr.LedgerEntryTypeAccount:
+		if d.Account != nil {
+			return fmt.Sprintf("balance %d, sequence %d", d.Account.Balance, d.Account.SeqNum)
+		}
+	case xdr.LedgerEntryTypeTrustline:
+		if d.TrustLine != nil {
+			return fmt.Sprintf("balance %d, limit %d", d.TrustLine.Balance, d.TrustLine.Limit)
+		}
+	case xdr.LedgerEntryTypeContractData:
+		if d.ContractData != nil {
+			return d.ContractData.Val.String()
+		}
+	case xdr.LedgerEntryTypeContractCode:
+		if d.ContractCode != nil {
+			return fmt.Sprintf("%d bytes of wasm", len(d.ContractCode.Code))
+		}
+	case xdr.LedgerEntryTypeTtl:
+		if d.Ttl != nil {
+			return fmt.Sprintf("live until ledger %d", d.Ttl.LiveUntilLedgerSeq)
+		}
+	}
+	return strings.ToLower(strings.TrimPrefix(d.Type.String(), "LedgerEntryType"))
+}
+
+func trustLineAssetString(a xdr.TrustLineAsset) string {
+	if a.Type == xdr.AssetTypeAssetTypePoolShare && a.LiquidityPoolId != nil {
+		return "pool " + hex.EncodeToString(a.LiquidityPoolId[:])
+	}
+	return a.ToAsset().StringCanonical()
+}
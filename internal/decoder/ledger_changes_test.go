@@ -0,0 +1,104 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func accountEntry(balance int64) *xdr.LedgerEntry {
+	return &xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.AccountEntry{AccountId: xdr.MustAddress(testAccount), Balance: xdr.Int64(balance), SeqNum: 7},
+	}}
+}
+
+func TestLedgerChanges(t *testing.T) {
+	removed, err := contractDataEntry(symVal("gone"), u32Val(1)).LedgerKey()
+	require.NoError(t, err)
+
+	meta := storageMeta(t,
+		xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: accountEntry(100)},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: accountEntry(60)},
+		},
+		xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryCreated, Created: contractDataEntry(symVal("counter"), u32Val(1))},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryRemoved, Removed: &removed},
+		},
+	)
+
+	changes, err := LedgerChanges(meta)
+	require.NoError(t, err)
+	require.Len(t, changes, 3, "state entries are not changes")
+
+	assert.Equal(t, ChangeUpdated, changes[0].Kind)
+	assert.Equal(t, "Account", changes[0].KeyType)
+	assert.Equal(t, "account "+testAccount, changes[0].Description)
+	assert.Equal(t, "balance 100, sequence 7", changes[0].Before)
+	assert.Equal(t, "balance 60, sequence 7", changes[0].After)
+
+	assert.Equal(t, ChangeCreated, changes[1].Kind)
+	assert.Equal(t, "ContractData", changes[1].KeyType)
+	assert.Contains(t, changes[1].Description, "persistent")
+	assert.Empty(t, changes[1].Before)
+	assert.NotEmpty(t, changes[1].After)
+
+	assert.Equal(t, ChangeRemoved, changes[2].Kind)
+	assert.Empty(t, changes[2].After)
+
+	_, err = LedgerChanges("not-xdr")
+	assert.Error(t, err)
+}
+
+func TestPostStateEntries(t *testing.T) {
+	removed, err := contractDataEntry(symVal("gone"), u32Val(1)).LedgerKey()
+	require.NoError(t, err)
+
+	meta := storageMeta(t,
+		xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: accountEntry(100)},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: accountEntry(60)},
+		},
+		xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: accountEntry(60)},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: accountEntry(40)},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryRemoved, Removed: &removed},
+		},
+	)
+
+	entries, err := PostStateEntries(meta)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	accountKey, err := accountEntry(0).LedgerKey()
+	require.NoError(t, err)
+	accountB64, err := xdr.MarshalBase64(accountKey)
+	require.NoError(t, err)
+	want, err := xdr.MarshalBase64(*accountEntry(40))
+	require.NoError(t, err)
+	assert.Equal(t, want, entries[accountB64], "the last change wins")
+
+	removedB64, err := xdr.MarshalBase64(removed)
+	require.NoError(t, err)
+	gone, ok := entries[removedB64]
+	assert.True(t, ok)
+	assert.Empty(t, gone, "removed entries map to the empty string")
+
+	_, err = PostStateEntries("not-xdr")
+	assert.Error(t, err)
+}
+
+func TestChangedLedgerKey(t *testing.T) {
+	key, ok := ChangedLedgerKey(xdr.LedgerEntryChange{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: accountEntry(1)})
+	require.True(t, ok)
+	assert.Equal(t, xdr.LedgerEntryTypeAccount, key.Type)
+
+	_, ok = ChangedLedgerKey(xdr.LedgerEntryChange{Type: xdr.LedgerEntryChangeTypeLedgerEntryRemoved})
+	assert.False(t, ok)
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"sort"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// OnChainOutcome is what the network recorded for a transaction: whether it
+// succeeded, the contract events its operations emitted and the ledger
+// entries they changed. Events are rendered like DecodeChainEvent's, so they
+// can be diffed against a simulation's.
+type OnChainOutcome struct {
+	Successful bool   `json:"successful"`
+	ResultCode string `json:"result_code"`
+
+	Events []simulator.DiagnosticEvent `json:"events,omitempty"`
+
+	// ChangedKeys are the base64 LedgerKeys the operations created, updated
+	// or removed, sorted. TTL entries are left out, as footprints do not list
+	// them.
+	ChangedKeys []string `json:"changed_keys,omitempty"`
+}
+
+// DecodeOnChainOutcome decodes a transaction's recorded result and result
+// meta. The result code comes from resultXdr, or from the meta when that is
+// empty. It returns nil when neither is available.
+func DecodeOnChainOutcome(resultXdr, resultMetaXdr string) (*OnChainOutcome, error) {
+	if resultXdr == "" && resultMetaXdr == "" {
+		return nil, nil
+	}
+
+	var meta xdr.TransactionResultMeta
+	if resultMetaXdr != "" {
+		if err := UnmarshalBase64RoundTrip(resultMetaXdr, &meta, "transaction result meta"); err != nil {
+			return nil, err
+		}
+	}
+	result := meta.Result.Result
+	if resultXdr != "" {
+		if err := UnmarshalBase64RoundTrip(resultXdr, &result, "transaction result"); err != nil {
+			return nil, err
+		}
+	}
+
+	outcome := &OnChainOutcome{
+		Successful: result.Successful(),
+		ResultCode: DecodeTransactionResultCode(result.Result.Code).Code,
+	}
+	if resultMetaXdr == "" {
+		return outcome, nil
+	}
+
+	for _, e := range metaContractEvents(meta.TxApplyProcessing) {
+		decoded, err := decodeContractEvent(e)
+		if err != nil {
+			return nil, err
+		}
+		outcome.Events = append(outcome.Events, decoded)
+	}
+
+	changed := make(map[string]bool)
+	for _, changes := range operationChanges(meta.TxApplyProcessing) {
+		for _, c := range changes {
+			if c.Type == xdr.LedgerEntryChangeTypeLedgerEntryState {
+				continue
+			}
+			key, ok := changedKey(c)
+			if !ok || key.Type == xdr.LedgerEntryTypeTtl {
+				continue
+			}
+			if b64, err := xdr.MarshalBase64(key); err == nil {
+				changed[b64] = true
+			}
+		}
+	}
+	for k := range changed {
+		outcome.ChangedKeys = append(outcome.ChangedKeys, k)
+	}
+	sort.Strings(outcome.ChangedKeys)
+	return outcome, nil
+}
+
+// metaContractEvents returns the contract events the transaction's
+// operations emitted. Transaction-level events, such as fee events, are left
+// out because a simulation does not produce them.
+func metaContractEvents(tm xdr.TransactionMeta) []xdr.ContractEvent {
+	switch {
+	case tm.V == 3 && tm.V3 != nil && tm.V3.SorobanMeta != nil:
+		return tm.V3.SorobanMeta.Events
+	case tm.V == 4 && tm.V4 != nil:
+		var events []xdr.ContractEvent
+		for _, op := range tm.V4.Operations {
+			events = append(events, op.Events...)
+		}
+		return events
+	}
+	return nil
+}
+
+func decodeContractEvent(e xdr.ContractEvent) (simulator.DiagnosticEvent, error) {
+	decoded := simulator.DiagnosticEvent{
+		EventType:                simulator.EventTypeContract,
+		Topics:                   []string{},
+		InSuccessfulContractCall: true,
+	}
+	switch e.Type {
+	case xdr.ContractEventTypeSystem:
+		decoded.EventType = simulator.EventTypeSystem
+	case xdr.ContractEventTypeDiagnostic:
+		decoded.EventType = simulator.EventTypeDiagnostic
+	}
+	if e.ContractId != nil {
+		id, err := strkey.Encode(strkey.VersionByteContract, e.ContractId[:])
+		if err != nil {
+			return decoded, err
+		}
+		decoded.ContractID = &id
+	}
+	if body, ok := e.Body.GetV0(); ok {
+		for _, topic := range body.Topics {
+			decoded.Topics = append(decoded.Topics, topic.String())
+		}
+		decoded.Data = body.Data.String()
+	}
+	return decoded, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeOnChainOutcome(t *testing.T) {
+	contract := xdr.ContractId{1}
+	entry := contractDataEntry(symVal("Balance"), u32Val(7))
+	ttlKey := xdr.LedgerKey{Type: xdr.LedgerEntryTypeTtl, Ttl: &xdr.LedgerKeyTtl{KeyHash: xdr.Hash{2}}}
+
+	meta := xdr.TransactionResultMeta{
+		TxApplyProcessing: xdr.TransactionMeta{V: 3, V3: &xdr.TransactionMetaV3{
+			Operations: []xdr.OperationMeta{{Changes: xdr.LedgerEntryChanges{
+				{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: entry},
+				{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: entry},
+				{Type: xdr.LedgerEntryChangeTypeLedgerEntryRemoved, Removed: &ttlKey},
+			}}},
+			SorobanMeta: &xdr.SorobanTransactionMeta{
+				Events: []xdr.ContractEvent{{
+					ContractId: &contract,
+					Type:       xdr.ContractEventTypeContract,
+					Body: xdr.ContractEventBody{V0: &xdr.ContractEventV0{
+						Topics: []xdr.ScVal{symVal("transfer")},
+						Data:   u32Val(5),
+					}},
+				}},
+				ReturnValue: xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+			},
+		}},
+		Result: xdr.TransactionResultPair{Result: xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &[]xdr.OperationResult{}},
+		}},
+	}
+	encoded, err := xdr.MarshalBase64(meta)
+	require.NoError(t, err)
+
+	outcome, err := DecodeOnChainOutcome("", encoded)
+	require.NoError(t, err)
+	require.NotNil(t, outcome)
+	assert.True(t, outcome.Successful, "the result comes from the meta when ResultXdr is empty")
+	assert.Equal(t, "tx_success", outcome.ResultCode)
+
+	require.Len(t, outcome.Events, 1)
+	assert.Equal(t, simulator.EventTypeContract, outcome.Events[0].EventType)
+	require.NotNil(t, outcome.Events[0].ContractID)
+	assert.Equal(t, "C", (*outcome.Events[0].ContractID)[:1])
+	assert.Equal(t, []string{symVal("transfer").String()}, outcome.Events[0].Topics)
+
+	key, err := entry.LedgerKey()
+	require.NoError(t, err)
+	encodedKey, err := xdr.MarshalBase64(key)
+	require.NoError(t, err)
+	assert.Equal(t, []string{encodedKey}, outcome.ChangedKeys, "TTL entries are left out")
+
+	failed := marshalTestXDR(t, xdr.TransactionResult{Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxBadAuth}})
+	outcome, err = DecodeOnChainOutcome(failed, encoded)
+	require.NoError(t, err)
+	assert.False(t, outcome.Successful, "ResultXdr takes precedence")
+	assert.Equal(t, "tx_bad_auth", outcome.ResultCode)
+}
+
+func TestDecodeOnChainOutcome_Empty(t *testing.T) {
+	outcome, err := DecodeOnChainOutcome("", "")
+	require.NoError(t, err)
+	assert.Nil(t, outcome)
+
+	_, err = DecodeOnChainOutcome("not-xdr", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// AllOperations returns the envelope's operations, taken from the inner
+// transaction of a fee bump.
+func (d *DecodedEnvelope) AllOperations() []xdr.Operation {
+	if d.InnerTx != nil {
+		return d.InnerTx.AllOperations()
+	}
+	return d.Operations
+}
+
+// OperationName returns the operation type without its XDR prefix, for
+// example "InvokeHostFunction".
+func OperationName(op xdr.Operation) string {
+	return strings.TrimPrefix(op.Body.Type.String(), "OperationType")
+}
+
+// SummarizeOperation describes op in one line: its type and, for
+// InvokeHostFunction, what it invokes.
+func SummarizeOperation(op xdr.Operation) string {
+	name := OperationName(op)
+	if detail := describeHostFunction(op); detail != "" {
+		return name + " " + detail
+	}
+	return name
+}
+
+// describeHostFunction returns the contract and function an
+// InvokeHostFunction operation calls, or what kind of host function it is.
+// It returns "" for other operations.
+func describeHostFunction(op xdr.Operation) string {
+	invoke, ok := op.Body.GetInvokeHostFunctionOp()
+	if !ok {
+		return ""
+	}
+	fn := invoke.HostFunction
+	switch fn.Type {
+	case xdr.HostFunctionTypeHostFunctionTypeInvokeContract:
+		args := fn.InvokeContract
+		contract, err := args.ContractAddress.String()
+		if err != nil {
+			contract = "<invalid contract address>"
+		}
+		return fmt.Sprintf("%s.%s (%d args)", contract, args.FunctionName, len(args.Args))
+	case xdr.HostFunctionTypeHostFunctionTypeCreateContract, xdr.HostFunctionTypeHostFunctionTypeCreateContractV2:
+		return "(create contract)"
+	case xdr.HostFunctionTypeHostFunctionTypeUploadContractWasm:
+		return "(upload contract Wasm)"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func invokeContractOp(contractID xdr.ContractId, fn string, args ...xdr.ScVal) xdr.Operation {
+	return xdr.Operation{Body: xdr.OperationBody{
+		Type: xdr.OperationTypeInvokeHostFunction,
+		InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{HostFunction: xdr.HostFunction{
+			Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+			InvokeContract: &xdr.InvokeContractArgs{
+				ContractAddress: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractID},
+				FunctionName:    xdr.ScSymbol(fn),
+				Args:            args,
+			},
+		}},
+	}}
+}
+
+func TestSummarizeOperation(t *testing.T) {
+	contractID := xdr.ContractId([32]byte{0x01})
+	addr := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractID}
+	contract, err := addr.String()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "InvokeHostFunction "+contract+".transfer (1 args)",
+		SummarizeOperation(invokeContractOp(contractID, "transfer", xdr.ScVal{Type: xdr.ScValTypeScvVoid})))
+
+	upload := xdr.Operation{Body: xdr.OperationBody{
+		Type: xdr.OperationTypeInvokeHostFunction,
+		InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{HostFunction: xdr.HostFunction{
+			Type: xdr.HostFunctionTypeHostFunctionTypeUploadContractWasm,
+			Wasm: &[]byte{0x00},
+		}},
+	}}
+	assert.Equal(t, "InvokeHostFunction (upload contract Wasm)", SummarizeOperation(upload))
+
+	assert.Equal(t, "BumpSequence", SummarizeOperation(xdr.Operation{Body: xdr.OperationBody{
+		Type:           xdr.OperationTypeBumpSequence,
+		BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 1},
+	}}))
+}
+
+func TestDecodedEnvelope_AllOperationsFeeBump(t *testing.T) {
+	op := invokeContractOp(xdr.ContractId{}, "hello")
+	env := &DecodedEnvelope{Type: "FeeBumpTransaction", InnerTx: &DecodedEnvelope{Operations: []xdr.Operation{op}}}
+	assert.Equal(t, []xdr.Operation{op}, env.AllOperations())
+}
@@ -33,7 +33,7 @@ func printOperation(i int, op xdr.Operation) {
 	switch op.Body.Type {
 
 	case xdr.OperationTypeInvokeHostFunction:
-		fmt.Println("      Soroban: Invoke Host Function")
+		fmt.Println("      Soroban: Invoke Host Function", describeHostFunction(op))
 
 	case xdr.OperationTypeExtendFootprintTtl:
 		fmt.Println("      Soroban: Extend Footprint TTL")
@@ -0,0 +1,53 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// XDRValue is implemented by every generated XDR type.
+type XDRValue interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// UnmarshalRoundTrip decodes data into v, then re-encodes v and checks that
+// the result is exactly as long as the input. A plain unmarshal can stop
+// early and succeed on input with trailing garbage, or fail deep inside a
+// truncated structure with an unhelpful EOF; both are reported here as
+// errors.ErrXDRCorrupt naming what was being decoded.
+func UnmarshalRoundTrip(data []byte, v XDRValue, what string) error {
+	if len(data) == 0 {
+		return errors.WrapXDRCorrupt(what, "input is empty")
+	}
+	if err := v.UnmarshalBinary(data); err != nil {
+		return errors.WrapXDRCorrupt(what, fmt.Sprintf("failed to decode %d bytes: %v", len(data), err))
+	}
+
+	encoded, err := v.MarshalBinary()
+	if err != nil {
+		return errors.WrapXDRCorrupt(what, fmt.Sprintf("decoded value does not re-encode: %v", err))
+	}
+	switch {
+	case len(encoded) < len(data):
+		return errors.WrapXDRCorrupt(what, fmt.Sprintf("%d trailing bytes after a %d-byte value", len(data)-len(encoded), len(encoded)))
+	case len(encoded) > len(data):
+		return errors.WrapXDRCorrupt(what, fmt.Sprintf("input is %d bytes but the decoded value needs %d", len(data), len(encoded)))
+	}
+	return nil
+}
+
+// UnmarshalBase64RoundTrip is UnmarshalRoundTrip for base64 input.
+func UnmarshalBase64RoundTrip(b64 string, v XDRValue, what string) error {
+	data, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return errors.WrapXDRCorrupt(what, fmt.Sprintf("invalid base64: %v", err))
+	}
+	return UnmarshalRoundTrip(data, v, what)
+}
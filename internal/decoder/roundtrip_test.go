@@ -0,0 +1,65 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+func ledgerEntryFixture(t *testing.T) []byte {
+	t.Helper()
+	entry := contractDataEntry(symVal("Balance"), u32Val(42))
+	entry.LastModifiedLedgerSeq = 100
+	data, err := entry.MarshalBinary()
+	if err != nil {
+		t.Fatalf("encode entry: %v", err)
+	}
+	return data
+}
+
+func TestUnmarshalRoundTrip_Valid(t *testing.T) {
+	var entry xdr.LedgerEntry
+	if err := UnmarshalRoundTrip(ledgerEntryFixture(t), &entry, "ledger entry"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.LastModifiedLedgerSeq != 100 {
+		t.Errorf("LastModifiedLedgerSeq = %d", entry.LastModifiedLedgerSeq)
+	}
+}
+
+func TestUnmarshalRoundTrip_Corrupt(t *testing.T) {
+	data := ledgerEntryFixture(t)
+	cases := map[string][]byte{
+		"empty":            {},
+		"truncated":        data[:len(data)-5],
+		"cut mid-header":   data[:3],
+		"trailing garbage": append(append([]byte{}, data...), 0xde, 0xad, 0xbe, 0xef),
+	}
+	for name, input := range cases {
+		var entry xdr.LedgerEntry
+		err := UnmarshalRoundTrip(input, &entry, "ledger entry")
+		if !errors.Is(err, errors.ErrXDRCorrupt) {
+			t.Errorf("%s: expected ErrXDRCorrupt, got %v", name, err)
+		}
+	}
+}
+
+func TestUnmarshalBase64RoundTrip(t *testing.T) {
+	data := ledgerEntryFixture(t)
+
+	var entry xdr.LedgerEntry
+	if err := UnmarshalBase64RoundTrip(base64.StdEncoding.EncodeToString(data), &entry, "ledger entry"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A base64 string cut short in the middle of a quantum
+	b64 := base64.StdEncoding.EncodeToString(data)
+	if err := UnmarshalBase64RoundTrip(b64[:len(b64)-3], &entry, "ledger entry"); !errors.Is(err, errors.ErrXDRCorrupt) {
+		t.Errorf("expected ErrXDRCorrupt, got %v", err)
+	}
+}
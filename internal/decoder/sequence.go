@@ -0,0 +1,104 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// SequenceFailure explains a transaction rejected for its sequence number:
+// tx_bad_seq, or tx_bad_min_seq_age_or_gap when its sequence preconditions
+// were not met. For a fee bump it describes the inner transaction.
+type SequenceFailure struct {
+	Code        string `json:"code"`
+	Explanation string `json:"explanation"`
+
+	// Account is the G-address whose sequence number the transaction used.
+	Account          string `json:"account"`
+	ProvidedSequence int64  `json:"provided_sequence"`
+
+	// CurrentSequence is the account's sequence number when erst looked it
+	// up, ExpectedSequence the one its next transaction must use, and Gap
+	// how far the provided sequence is ahead of (positive) or behind
+	// (negative) it. They are unset when the account could not be fetched.
+	CurrentSequence  *int64 `json:"current_sequence,omitempty"`
+	ExpectedSequence *int64 `json:"expected_sequence,omitempty"`
+	Gap              *int64 `json:"gap,omitempty"`
+
+	// The transaction's sequence preconditions, when it set any.
+	MinSeqNum       *int64  `json:"min_seq_num,omitempty"`
+	MinSeqAge       *uint64 `json:"min_seq_age,omitempty"`
+	MinSeqLedgerGap *uint32 `json:"min_seq_ledger_gap,omitempty"`
+}
+
+// DetectSequenceFailure returns the sequence failure a transaction's result
+// records, or nil when the transaction succeeded or failed for another
+// reason.
+func DetectSequenceFailure(envelopeXdr, resultXdr string) (*SequenceFailure, error) {
+	if resultXdr == "" {
+		return nil, nil
+	}
+	var result xdr.TransactionResult
+	if err := UnmarshalBase64RoundTrip(resultXdr, &result, "transaction result"); err != nil {
+		return nil, err
+	}
+	code := result.Result.Code
+	if code == xdr.TransactionResultCodeTxFeeBumpInnerFailed && result.Result.InnerResultPair != nil {
+		code = result.Result.InnerResultPair.Result.Result.Code
+	}
+	if code != xdr.TransactionResultCodeTxBadSeq && code != xdr.TransactionResultCodeTxBadMinSeqAgeOrGap {
+		return nil, nil
+	}
+
+	var env xdr.TransactionEnvelope
+	if err := UnmarshalBase64RoundTrip(envelopeXdr, &env, "transaction envelope"); err != nil {
+		return nil, err
+	}
+	account, err := NewAccount(env.SourceAccount())
+	if err != nil {
+		return nil, err
+	}
+	info := DecodeTransactionResultCode(code)
+	f := &SequenceFailure{
+		Code:             info.Code,
+		Explanation:      info.Explanation,
+		Account:          account.Base(),
+		ProvidedSequence: env.SeqNum(),
+		MinSeqNum:        env.MinSeqNum(),
+	}
+	if age := env.MinSeqAge(); age != nil && *age > 0 {
+		v := uint64(*age)
+		f.MinSeqAge = &v
+	}
+	if gap := env.MinSeqLedgerGap(); gap != nil && *gap > 0 {
+		v := uint32(*gap)
+		f.MinSeqLedgerGap = &v
+	}
+	return f, nil
+}
+
+// SetCurrentSequence records the account's current sequence number and
+// explains the gap between it and the provided one.
+func (f *SequenceFailure) SetCurrentSequence(current int64) {
+	expected := current + 1
+	gap := f.ProvidedSequence - expected
+	f.CurrentSequence, f.ExpectedSequence, f.Gap = &current, &expected, &gap
+
+	if f.Code != "tx_bad_seq" {
+		return
+	}
+	switch {
+	case gap < 0:
+		f.Explanation = fmt.Sprintf("Sequence number %d is stale: the account is already at %d. Another transaction from this account landed first; rebuild the transaction with sequence %d",
+			f.ProvidedSequence, current, expected)
+	case gap > 0:
+		f.Explanation = fmt.Sprintf("Sequence number %d is %d ahead of the %d the account expects. Earlier transactions from this account were never submitted or did not apply; submit them first or rebuild with sequence %d",
+			f.ProvidedSequence, gap, expected, expected)
+	default:
+		f.Explanation = fmt.Sprintf("The account now expects sequence number %d, which this transaction uses, so its sequence number has changed since it was rejected. Resubmitting it may succeed",
+			expected)
+	}
+}
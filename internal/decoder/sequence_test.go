@@ -0,0 +1,142 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sequenceTestEnvelope(t *testing.T, seq int64, cond xdr.Preconditions) xdr.TransactionEnvelope {
+	t.Helper()
+	return xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: xdr.MustMuxedAddress(testAccount),
+			Fee:           100,
+			SeqNum:        xdr.SequenceNumber(seq),
+			Cond:          cond,
+			Operations: []xdr.Operation{{Body: xdr.OperationBody{
+				Type:           xdr.OperationTypeBumpSequence,
+				BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 1},
+			}}},
+		}},
+	}
+}
+
+func marshalTestXDR(t *testing.T, v interface{}) string {
+	t.Helper()
+	encoded, err := xdr.MarshalBase64(v)
+	require.NoError(t, err)
+	return encoded
+}
+
+func txResult(t *testing.T, code xdr.TransactionResultCode) string {
+	t.Helper()
+	return marshalTestXDR(t, xdr.TransactionResult{FeeCharged: 100, Result: xdr.TransactionResultResult{Code: code}})
+}
+
+func TestDetectSequenceFailure_BadSeq(t *testing.T) {
+	env := sequenceTestEnvelope(t, 105, xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone})
+	f, err := DetectSequenceFailure(marshalTestXDR(t, env), txResult(t, xdr.TransactionResultCodeTxBadSeq))
+	require.NoError(t, err)
+	require.NotNil(t, f)
+	assert.Equal(t, "tx_bad_seq", f.Code)
+	assert.Equal(t, testAccount, f.Account)
+	assert.Equal(t, int64(105), f.ProvidedSequence)
+	assert.Nil(t, f.CurrentSequence)
+	assert.Nil(t, f.MinSeqNum)
+}
+
+func TestDetectSequenceFailure_MinSeqPreconditions(t *testing.T) {
+	minSeq := xdr.SequenceNumber(90)
+	env := sequenceTestEnvelope(t, 105, xdr.Preconditions{
+		Type: xdr.PreconditionTypePrecondV2,
+		V2:   &xdr.PreconditionsV2{MinSeqNum: &minSeq, MinSeqAge: 60, MinSeqLedgerGap: 5},
+	})
+	f, err := DetectSequenceFailure(marshalTestXDR(t, env), txResult(t, xdr.TransactionResultCodeTxBadMinSeqAgeOrGap))
+	require.NoError(t, err)
+	require.NotNil(t, f)
+	assert.Equal(t, "tx_bad_min_seq_age_or_gap", f.Code)
+	require.NotNil(t, f.MinSeqNum)
+	assert.Equal(t, int64(90), *f.MinSeqNum)
+	require.NotNil(t, f.MinSeqAge)
+	assert.Equal(t, uint64(60), *f.MinSeqAge)
+	require.NotNil(t, f.MinSeqLedgerGap)
+	assert.Equal(t, uint32(5), *f.MinSeqLedgerGap)
+}
+
+func TestDetectSequenceFailure_FeeBumpInner(t *testing.T) {
+	inner := sequenceTestEnvelope(t, 7, xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone})
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{Tx: xdr.FeeBumpTransaction{
+			FeeSource: xdr.MustMuxedAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"),
+			Fee:       200,
+			InnerTx:   xdr.FeeBumpTransactionInnerTx{Type: xdr.EnvelopeTypeEnvelopeTypeTx, V1: inner.V1},
+		}},
+	}
+	result := marshalTestXDR(t, xdr.TransactionResult{
+		FeeCharged: 200,
+		Result: xdr.TransactionResultResult{
+			Code: xdr.TransactionResultCodeTxFeeBumpInnerFailed,
+			InnerResultPair: &xdr.InnerTransactionResultPair{
+				Result: xdr.InnerTransactionResult{Result: xdr.InnerTransactionResultResult{Code: xdr.TransactionResultCodeTxBadSeq}},
+			},
+		},
+	})
+
+	f, err := DetectSequenceFailure(marshalTestXDR(t, env), result)
+	require.NoError(t, err)
+	require.NotNil(t, f)
+	assert.Equal(t, testAccount, f.Account, "the inner transaction's source owns the sequence number")
+	assert.Equal(t, int64(7), f.ProvidedSequence)
+}
+
+func TestDetectSequenceFailure_OtherResults(t *testing.T) {
+	env := marshalTestXDR(t, sequenceTestEnvelope(t, 1, xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone}))
+	for _, code := range []xdr.TransactionResultCode{xdr.TransactionResultCodeTxBadAuth, xdr.TransactionResultCodeTxInsufficientFee} {
+		f, err := DetectSequenceFailure(env, txResult(t, code))
+		require.NoError(t, err)
+		assert.Nil(t, f, code.String())
+	}
+
+	f, err := DetectSequenceFailure(env, "")
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	_, err = DetectSequenceFailure(env, "not-xdr")
+	assert.Error(t, err)
+}
+
+func TestSequenceFailure_SetCurrentSequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		provided int64
+		current  int64
+		gap      int64
+		contains string
+	}{
+		{"stale", 100, 120, -21, "is stale: the account is already at 120"},
+		{"ahead", 105, 100, 4, "4 ahead of the 101 the account expects"},
+		{"now valid", 101, 100, 0, "Resubmitting it may succeed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &SequenceFailure{Code: "tx_bad_seq", ProvidedSequence: tt.provided}
+			f.SetCurrentSequence(tt.current)
+			assert.Equal(t, tt.current, *f.CurrentSequence)
+			assert.Equal(t, tt.current+1, *f.ExpectedSequence)
+			assert.Equal(t, tt.gap, *f.Gap)
+			assert.Contains(t, f.Explanation, tt.contains)
+		})
+	}
+
+	f := &SequenceFailure{Code: "tx_bad_min_seq_age_or_gap", Explanation: "preconditions", ProvidedSequence: 5}
+	f.SetCurrentSequence(4)
+	assert.Equal(t, "preconditions", f.Explanation, "only tx_bad_seq is explained by the gap")
+}
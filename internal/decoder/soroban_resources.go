@@ -0,0 +1,222 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"sort"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// SorobanResources is the SorobanTransactionData a transaction declared,
+// together with the fees the ledger charged for it and any footprint
+// mismatch found in the result meta.
+type SorobanResources struct {
+	ReadOnly      []string `json:"read_only"`
+	ReadWrite     []string `json:"read_write"`
+	Instructions  uint32   `json:"instructions"`
+	DiskReadBytes uint32   `json:"disk_read_bytes"`
+	WriteBytes    uint32   `json:"write_bytes"`
+	ResourceFee   int64    `json:"resource_fee"`
+
+	// Charged fees, in stroops, when the result meta records them.
+	NonRefundableFeeCharged *int64 `json:"non_refundable_fee_charged,omitempty"`
+	RefundableFeeCharged    *int64 `json:"refundable_fee_charged,omitempty"`
+	RentFeeCharged          *int64 `json:"rent_fee_charged,omitempty"`
+
+	// Undeclared lists entries the operations changed that the footprint
+	// does not declare; the transaction under-declared its resources.
+	Undeclared []string `json:"undeclared,omitempty"`
+}
+
+// DecodeSorobanResources decodes the SorobanTransactionData of a base64
+// envelope, taking it from the inner transaction of a fee bump. It returns
+// nil for transactions without Soroban data. When resultMetaXdr is set, the
+// charged fees are read from it and the ledger entries changed by the
+// operations are checked against the declared footprint. Transaction-level
+// changes (fees and sequence numbers) and TTL entries are not part of a
+// footprint and are ignored.
+func DecodeSorobanResources(envelopeXdr, resultMetaXdr string) (*SorobanResources, error) {
+	var env xdr.TransactionEnvelope
+	if err := UnmarshalBase64RoundTrip(envelopeXdr, &env, "transaction envelope"); err != nil {
+		return nil, err
+	}
+	data, ok := sorobanData(env)
+	if !ok {
+		return nil, nil
+	}
+
+	res, err := newSorobanResources(data)
+	if err != nil {
+		return nil, err
+	}
+	declared := make(map[string]bool)
+	for _, k := range append(append([]string{}, res.ReadOnly...), res.ReadWrite...) {
+		declared[k] = true
+	}
+
+	if resultMetaXdr == "" {
+		return res, nil
+	}
+	var meta xdr.TransactionResultMeta
+	if err := UnmarshalBase64RoundTrip(resultMetaXdr, &meta, "transaction result meta"); err != nil {
+		return nil, err
+	}
+	if ext, ok := sorobanMetaExt(meta.TxApplyProcessing); ok {
+		nonRefundable := int64(ext.TotalNonRefundableResourceFeeCharged)
+		refundable := int64(ext.TotalRefundableResourceFeeCharged)
+		rent := int64(ext.RentFeeCharged)
+		res.NonRefundableFeeCharged, res.RefundableFeeCharged, res.RentFeeCharged = &nonRefundable, &refundable, &rent
+	}
+
+	undeclared := make(map[string]bool)
+	for _, changes := range operationChanges(meta.TxApplyProcessing) {
+		for _, c := range changes {
+			key, ok := changedKey(c)
+			if !ok || key.Type == xdr.LedgerEntryTypeTtl {
+				continue
+			}
+			if b64, err := xdr.MarshalBase64(key); err == nil && !declared[b64] {
+				undeclared[b64] = true
+			}
+		}
+	}
+	for k := range undeclared {
+		res.Undeclared = append(res.Undeclared, k)
+	}
+	sort.Strings(res.Undeclared)
+	return res, nil
+}
+
+// DecodeSorobanTransactionData decodes a base64 SorobanTransactionData, such
+// as the one Soroban RPC's simulateTransaction returns.
+func DecodeSorobanTransactionData(dataXdr string) (*SorobanResources, error) {
+	var data xdr.SorobanTransactionData
+	if err := UnmarshalBase64RoundTrip(dataXdr, &data, "soroban transaction data"); err != nil {
+		return nil, err
+	}
+	return newSorobanResources(data)
+}
+
+func newSorobanResources(data xdr.SorobanTransactionData) (*SorobanResources, error) {
+	res := &SorobanResources{
+		Instructions:  uint32(data.Resources.Instructions),
+		DiskReadBytes: uint32(data.Resources.DiskReadBytes),
+		WriteBytes:    uint32(data.Resources.WriteBytes),
+		ResourceFee:   int64(data.ResourceFee),
+	}
+	for _, set := range []struct {
+		keys []xdr.LedgerKey
+		into *[]string
+	}{
+		{data.Resources.Footprint.ReadOnly, &res.ReadOnly},
+		{data.Resources.Footprint.ReadWrite, &res.ReadWrite},
+	} {
+		*set.into = []string{}
+		for _, k := range set.keys {
+			b64, err := xdr.MarshalBase64(k)
+			if err != nil {
+				return nil, err
+			}
+			*set.into = append(*set.into, b64)
+		}
+	}
+	return res, nil
+}
+
+func sorobanData(env xdr.TransactionEnvelope) (xdr.SorobanTransactionData, bool) {
+	var ext xdr.TransactionExt
+	switch env.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		ext = env.V1.Tx.Ext
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		if env.FeeBump.Tx.InnerTx.V1 == nil {
+			return xdr.SorobanTransactionData{}, false
+		}
+		ext = env.FeeBump.Tx.InnerTx.V1.Tx.Ext
+	default:
+		return xdr.SorobanTransactionData{}, false
+	}
+	if ext.SorobanData == nil {
+		return xdr.SorobanTransactionData{}, false
+	}
+	return *ext.SorobanData, true
+}
+
+func sorobanMetaExt(tm xdr.TransactionMeta) (xdr.SorobanTransactionMetaExtV1, bool) {
+	var ext xdr.SorobanTransactionMetaExt
+	switch {
+	case tm.V == 3 && tm.V3 != nil && tm.V3.SorobanMeta != nil:
+		ext = tm.V3.SorobanMeta.Ext
+	case tm.V == 4 && tm.V4 != nil && tm.V4.SorobanMeta != nil:
+		ext = tm.V4.SorobanMeta.Ext
+	default:
+		return xdr.SorobanTransactionMetaExtV1{}, false
+	}
+	if ext.V1 == nil {
+		return xdr.SorobanTransactionMetaExtV1{}, false
+	}
+	return *ext.V1, true
+}
+
+// operationChanges returns the ledger entry changes made by each operation,
+// leaving out transaction-level changes.
+func operationChanges(tm xdr.TransactionMeta) []xdr.LedgerEntryChanges {
+	var out []xdr.LedgerEntryChanges
+	switch tm.V {
+	case 0:
+		if tm.Operations != nil {
+			for _, op := range *tm.Operations {
+				out = append(out, op.Changes)
+			}
+		}
+	case 1:
+		if tm.V1 != nil {
+			for _, op := range tm.V1.Operations {
+				out = append(out, op.Changes)
+			}
+		}
+	case 2:
+		if tm.V2 != nil {
+			for _, op := range tm.V2.Operations {
+				out = append(out, op.Changes)
+			}
+		}
+	case 3:
+		if tm.V3 != nil {
+			for _, op := range tm.V3.Operations {
+				out = append(out, op.Changes)
+			}
+		}
+	case 4:
+		if tm.V4 != nil {
+			for _, op := range tm.V4.Operations {
+				out = append(out, op.Changes)
+			}
+		}
+	}
+	return out
+}
+
+func changedKey(c xdr.LedgerEntryChange) (xdr.LedgerKey, bool) {
+	var entry *xdr.LedgerEntry
+	switch c.Type {
+	case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+		entry = c.Created
+	case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+		entry = c.Updated
+	case xdr.LedgerEntryChangeTypeLedgerEntryState:
+		entry = c.State
+	case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+		if c.Removed != nil {
+			return *c.Removed, true
+		}
+		return xdr.LedgerKey{}, false
+	}
+	if entry == nil {
+		return xdr.LedgerKey{}, false
+	}
+	key, err := entry.LedgerKey()
+	return key, err == nil
+}
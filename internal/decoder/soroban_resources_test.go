@@ -0,0 +1,103 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeSorobanResources(t *testing.T) {
+	void := xdr.ScVal{Type: xdr.ScValTypeScvVoid}
+	declaredEntry := contractDataEntry(symVal("Balance"), void)
+	undeclaredEntry := contractDataEntry(symVal("Admin"), void)
+	declaredKey, err := declaredEntry.LedgerKey()
+	require.NoError(t, err)
+	undeclaredKey, err := undeclaredEntry.LedgerKey()
+	require.NoError(t, err)
+
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: xdr.MustMuxedAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"),
+			Ext: xdr.TransactionExt{V: 1, SorobanData: &xdr.SorobanTransactionData{
+				Resources: xdr.SorobanResources{
+					Footprint:    xdr.LedgerFootprint{ReadWrite: []xdr.LedgerKey{declaredKey}},
+					Instructions: 5000,
+					WriteBytes:   128,
+				},
+				ResourceFee: 1234,
+			}},
+		}},
+	}
+	envB64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	ttlKey := xdr.LedgerKey{Type: xdr.LedgerEntryTypeTtl, Ttl: &xdr.LedgerKeyTtl{}}
+	meta := xdr.TransactionResultMeta{
+		Result: xdr.TransactionResultPair{Result: xdr.TransactionResult{Result: xdr.TransactionResultResult{
+			Code:    xdr.TransactionResultCodeTxSuccess,
+			Results: &[]xdr.OperationResult{},
+		}}},
+		TxApplyProcessing: xdr.TransactionMeta{
+			V: 3,
+			V3: &xdr.TransactionMetaV3{
+				Operations: []xdr.OperationMeta{{Changes: xdr.LedgerEntryChanges{
+					{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: declaredEntry},
+					{Type: xdr.LedgerEntryChangeTypeLedgerEntryCreated, Created: undeclaredEntry},
+					{Type: xdr.LedgerEntryChangeTypeLedgerEntryRemoved, Removed: &ttlKey},
+				}}},
+				SorobanMeta: &xdr.SorobanTransactionMeta{
+					Ext: xdr.SorobanTransactionMetaExt{V: 1, V1: &xdr.SorobanTransactionMetaExtV1{
+						TotalNonRefundableResourceFeeCharged: 700,
+						TotalRefundableResourceFeeCharged:    300,
+						RentFeeCharged:                       20,
+					}},
+					ReturnValue: void,
+				},
+			},
+		},
+	}
+	metaB64, err := xdr.MarshalBase64(meta)
+	require.NoError(t, err)
+
+	res, err := DecodeSorobanResources(envB64, metaB64)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	declaredB64, _ := xdr.MarshalBase64(declaredKey)
+	undeclaredB64, _ := xdr.MarshalBase64(undeclaredKey)
+	assert.Equal(t, []string{declaredB64}, res.ReadWrite)
+	assert.Empty(t, res.ReadOnly)
+	assert.Equal(t, uint32(5000), res.Instructions)
+	assert.Equal(t, int64(1234), res.ResourceFee)
+	require.NotNil(t, res.RefundableFeeCharged)
+	assert.Equal(t, int64(300), *res.RefundableFeeCharged)
+	assert.Equal(t, int64(700), *res.NonRefundableFeeCharged)
+	assert.Equal(t, []string{undeclaredB64}, res.Undeclared)
+
+	// Without meta only the declared data is reported
+	res, err = DecodeSorobanResources(envB64, "")
+	require.NoError(t, err)
+	assert.Nil(t, res.RefundableFeeCharged)
+	assert.Empty(t, res.Undeclared)
+}
+
+func TestDecodeSorobanResources_ClassicTransaction(t *testing.T) {
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: xdr.MustMuxedAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"),
+		}},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	res, err := DecodeSorobanResources(b64, "")
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// StorageChange is a contract-data entry a transaction updated, with its
+// value before and after the transaction.
+type StorageChange struct {
+	Contract   string `json:"contract"`
+	Durability string `json:"durability"`
+	Key        string `json:"key"`
+	Before     string `json:"before"`
+	After      string `json:"after"`
+
+	// Diff is a compact rendering of what changed between Before and After.
+	// Maps only list the entries that differ.
+	Diff string `json:"diff"`
+
+	before, after xdr.ScVal
+}
+
+// WalkLedgerEntryChanges calls fn with every set of ledger entry changes in
+// meta, in the order they were applied: fee processing, transaction-level
+// changes before the operations, each operation, then transaction-level
+// changes after them.
+func WalkLedgerEntryChanges(meta xdr.TransactionResultMeta, fn func(xdr.LedgerEntryChanges)) {
+	fn(meta.FeeProcessing)
+
+	switch meta.TxApplyProcessing.V {
+	case 0:
+		if meta.TxApplyProcessing.Operations != nil {
+			for _, op := range *meta.TxApplyProcessing.Operations {
+				fn(op.Changes)
+			}
+		}
+	case 1:
+		if v1 := meta.TxApplyProcessing.V1; v1 != nil {
+			fn(v1.TxChanges)
+			for _, op := range v1.Operations {
+				fn(op.Changes)
+			}
+		}
+	case 2:
+		if v2 := meta.TxApplyProcessing.V2; v2 != nil {
+			fn(v2.TxChangesBefore)
+			for _, op := range v2.Operations {
+				fn(op.Changes)
+			}
+			fn(v2.TxChangesAfter)
+		}
+	case 3:
+		if v3 := meta.TxApplyProcessing.V3; v3 != nil {
+			fn(v3.TxChangesBefore)
+			for _, op := range v3.Operations {
+				fn(op.Changes)
+			}
+			fn(v3.TxChangesAfter)
+		}
+	}
+}
+
+// StorageChanges decodes the base64 TransactionResultMeta and pairs every
+// updated contract-data entry with the state that preceded it. An entry
+// updated more than once is reported once, from its first prior state to its
+// final value; entries whose value ends up unchanged are skipped.
+func StorageChanges(resultMetaXdr string) ([]StorageChange, error) {
+	var meta xdr.TransactionResultMeta
+	if err := xdr.SafeUnmarshalBase64(resultMetaXdr, &meta); err != nil {
+		return nil, fmt.Errorf("failed to decode result meta: %w", err)
+	}
+
+	var changes []*StorageChange
+	byKey := make(map[string]*StorageChange)
+	prior := make(map[string]xdr.ContractDataEntry)
+
+	WalkLedgerEntryChanges(meta, func(entries xdr.LedgerEntryChanges) {
+		for _, c := range entries {
+			switch c.Type {
+			case xdr.LedgerEntryChangeTypeLedgerEntryState:
+				if cd, key, ok := contractData(c.State); ok {
+					if _, seen := prior[key]; !seen {
+						prior[key] = cd
+					}
+				}
+			case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+				cd, key, ok := contractData(c.Updated)
+				if !ok {
+					continue
+				}
+				if sc, exists := byKey[key]; exists {
+					sc.after = cd.Val
+					continue
+				}
+				before, ok := prior[key]
+				if !ok {
+					continue
+				}
+				sc := &StorageChange{
+					Contract:   contractAddress(cd.Contract),
+					Durability: strings.TrimPrefix(cd.Durability.String(), "ContractDataDurability"),
+					Key:        cd.Key.String(),
+					before:     before.Val,
+					after:      cd.Val,
+				}
+				byKey[key] = sc
+				changes = append(changes, sc)
+			}
+		}
+	})
+
+	out := make([]StorageChange, 0, len(changes))
+	for _, sc := range changes {
+		if scValEqual(sc.before, sc.after) {
+			continue
+		}
+		sc.Before = sc.before.String()
+		sc.After = sc.after.String()
+		sc.Diff = DiffScVal(sc.before, sc.after)
+		out = append(out, *sc)
+	}
+	return out, nil
+}
+
+// DiffScVal renders the difference between two values on one line. Maps are
+// diffed entry by entry (recursively), listing only changed keys as
+// `key: old → new`, added keys as `+key: new` and removed keys as
+// `-key: old`; any other value is shown as `old → new`.
+func DiffScVal(before, after xdr.ScVal) string {
+	if scValEqual(before, after) {
+		return before.String()
+	}
+
+	bm, bok := before.GetMap()
+	am, aok := after.GetMap()
+	if !bok || !aok || bm == nil || am == nil {
+		return before.String() + " → " + after.String()
+	}
+
+	var parts []string
+	remaining := make(map[int]bool, len(*am))
+	for i := range *am {
+		remaining[i] = true
+	}
+	for _, be := range *bm {
+		i := mapIndex(*am, be.Key)
+		if i < 0 {
+			parts = append(parts, fmt.Sprintf("-%s: %s", be.Key, be.Val))
+			continue
+		}
+		delete(remaining, i)
+		if ae := (*am)[i]; !scValEqual(be.Val, ae.Val) {
+			parts = append(parts, fmt.Sprintf("%s: %s", be.Key, DiffScVal(be.Val, ae.Val)))
+		}
+	}
+	for i, ae := range *am {
+		if remaining[i] {
+			parts = append(parts, fmt.Sprintf("+%s: %s", ae.Key, ae.Val))
+		}
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func contractData(entry *xdr.LedgerEntry) (xdr.ContractDataEntry, string, bool) {
+	if entry == nil || entry.Data.Type != xdr.LedgerEntryTypeContractData || entry.Data.ContractData == nil {
+		return xdr.ContractDataEntry{}, "", false
+	}
+	key, err := entry.LedgerKey()
+	if err != nil {
+		return xdr.ContractDataEntry{}, "", false
+	}
+	b, err := key.MarshalBinary()
+	if err != nil {
+		return xdr.ContractDataEntry{}, "", false
+	}
+	return *entry.Data.ContractData, base64.StdEncoding.EncodeToString(b), true
+}
+
+func contractAddress(addr xdr.ScAddress) string {
+	s, err := addr.String()
+	if err != nil {
+		return err.Error()
+	}
+	return s
+}
+
+func mapIndex(m xdr.ScMap, key xdr.ScVal) int {
+	for i, e := range m {
+		if scValEqual(e.Key, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+func scValEqual(a, b xdr.ScVal) bool {
+	ab, errA := a.MarshalBinary()
+	bb, errB := b.MarshalBinary()
+	return errA == nil && errB == nil && bytes.Equal(ab, bb)
+}
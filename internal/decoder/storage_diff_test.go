@@ -0,0 +1,115 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+func contractDataEntry(key, val xdr.ScVal) *xdr.LedgerEntry {
+	contract := xdr.ContractId{1}
+	return &xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeContractData,
+			ContractData: &xdr.ContractDataEntry{
+				Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contract},
+				Key:        key,
+				Durability: xdr.ContractDataDurabilityPersistent,
+				Val:        val,
+			},
+		},
+	}
+}
+
+func storageMeta(t *testing.T, ops ...xdr.LedgerEntryChanges) string {
+	t.Helper()
+	var opMetas []xdr.OperationMeta
+	for _, changes := range ops {
+		opMetas = append(opMetas, xdr.OperationMeta{Changes: changes})
+	}
+	meta := xdr.TransactionResultMeta{
+		TxApplyProcessing: xdr.TransactionMeta{V: 3, V3: &xdr.TransactionMetaV3{Operations: opMetas}},
+		Result: xdr.TransactionResultPair{
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{
+					Code:    xdr.TransactionResultCodeTxSuccess,
+					Results: &[]xdr.OperationResult{},
+				},
+			},
+		},
+	}
+	encoded, err := xdr.MarshalBase64(meta)
+	if err != nil {
+		t.Fatalf("encode meta: %v", err)
+	}
+	return encoded
+}
+
+func TestStorageChanges_PairsStateAndUpdated(t *testing.T) {
+	before := mapVal(
+		xdr.ScMapEntry{Key: symVal("balance"), Val: u32Val(100)},
+		xdr.ScMapEntry{Key: symVal("owner"), Val: symVal("alice")},
+	)
+	after := mapVal(
+		xdr.ScMapEntry{Key: symVal("balance"), Val: u32Val(40)},
+		xdr.ScMapEntry{Key: symVal("owner"), Val: symVal("alice")},
+		xdr.ScMapEntry{Key: symVal("frozen"), Val: u32Val(1)},
+	)
+	untouched := contractDataEntry(symVal("Admin"), symVal("alice"))
+
+	meta := storageMeta(t, xdr.LedgerEntryChanges{
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: contractDataEntry(symVal("State"), before)},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: contractDataEntry(symVal("State"), after)},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: untouched},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: untouched},
+	})
+
+	changes, err := StorageChanges(meta)
+	if err != nil {
+		t.Fatalf("StorageChanges: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d: %+v", len(changes), changes)
+	}
+	c := changes[0]
+	if c.Key != "State" || c.Durability != "Persistent" {
+		t.Errorf("unexpected key/durability: %q %q", c.Key, c.Durability)
+	}
+	if want := "{balance: 100 → 40, +frozen: 1}"; c.Diff != want {
+		t.Errorf("diff = %q, want %q", c.Diff, want)
+	}
+}
+
+func TestStorageChanges_MultipleUpdatesCollapse(t *testing.T) {
+	key := symVal("Counter")
+	meta := storageMeta(t,
+		xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: contractDataEntry(key, u32Val(1))},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: contractDataEntry(key, u32Val(2))},
+		},
+		xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: contractDataEntry(key, u32Val(2))},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: contractDataEntry(key, u32Val(3))},
+		},
+	)
+
+	changes, err := StorageChanges(meta)
+	if err != nil {
+		t.Fatalf("StorageChanges: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Diff != "1 → 3" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestDiffScVal_RemovedKey(t *testing.T) {
+	before := mapVal(xdr.ScMapEntry{Key: symVal("a"), Val: u32Val(1)}, xdr.ScMapEntry{Key: symVal("b"), Val: u32Val(2)})
+	after := mapVal(xdr.ScMapEntry{Key: symVal("a"), Val: u32Val(1)})
+
+	if got, want := DiffScVal(before, after), "{-b: 2}"; got != want {
+		t.Errorf("DiffScVal = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// Encoding is how XDR bytes are written as text.
+type Encoding string
+
+const (
+	// EncodingAuto detects the encoding from the input.
+	EncodingAuto Encoding = "auto"
+	// EncodingBase64 is standard base64, with or without padding.
+	EncodingBase64 Encoding = "base64"
+	// EncodingBase64URL is URL-safe base64, with or without padding.
+	EncodingBase64URL Encoding = "base64url"
+	// EncodingHex is hexadecimal, optionally prefixed with 0x.
+	EncodingHex Encoding = "hex"
+)
+
+// ParseEncoding validates an encoding name. The empty string means
+// EncodingAuto.
+func ParseEncoding(name string) (Encoding, error) {
+	switch e := Encoding(strings.ToLower(strings.TrimSpace(name))); e {
+	case "":
+		return EncodingAuto, nil
+	case EncodingAuto, EncodingBase64, EncodingBase64URL, EncodingHex:
+		return e, nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q (use: auto, base64, base64url, hex)", name)
+	}
+}
+
+// DecodeXDRText decodes XDR written as text in enc into v, checking it as
+// UnmarshalRoundTrip does. With EncodingAuto each encoding the text could be
+// in is tried, hex first when the text is only hex digits, and the first
+// that yields a valid v wins; the error, if none does, is that of the most
+// likely encoding.
+func DecodeXDRText(text string, enc Encoding, v XDRValue, what string) error {
+	text = strings.TrimSpace(text)
+	candidates := []Encoding{enc}
+	if enc == EncodingAuto || enc == "" {
+		candidates = []Encoding{EncodingBase64, EncodingBase64URL}
+		if looksHex(text) {
+			candidates = append([]Encoding{EncodingHex}, candidates...)
+		}
+	}
+
+	var firstErr error
+	for _, e := range candidates {
+		err := decodeXDRTextAs(text, e, v, what)
+		if err == nil {
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func decodeXDRTextAs(text string, enc Encoding, v XDRValue, what string) error {
+	data, err := decodeText(text, enc)
+	if err != nil {
+		return errors.WrapXDRCorrupt(what, fmt.Sprintf("invalid %s: %v", enc, err))
+	}
+	// A failed attempt can leave union arms set that the next one does not
+	// overwrite.
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+	}
+	return UnmarshalRoundTrip(data, v, what)
+}
+
+func decodeText(text string, enc Encoding) ([]byte, error) {
+	switch enc {
+	case EncodingHex:
+		return hex.DecodeString(trimHexPrefix(text))
+	case EncodingBase64:
+		if strings.HasSuffix(text, "=") {
+			return base64.StdEncoding.DecodeString(text)
+		}
+		return base64.RawStdEncoding.DecodeString(text)
+	case EncodingBase64URL:
+		if strings.HasSuffix(text, "=") {
+			return base64.URLEncoding.DecodeString(text)
+		}
+		return base64.RawURLEncoding.DecodeString(text)
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", enc)
+	}
+}
+
+// looksHex reports whether text could be hex: an even number of hex digits,
+// optionally prefixed with 0x.
+func looksHex(text string) bool {
+	text = trimHexPrefix(text)
+	if text == "" || len(text)%2 != 0 {
+		return false
+	}
+	for _, c := range text {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func trimHexPrefix(text string) string {
+	if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+		return text[2:]
+	}
+	return text
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeXDRText_Encodings(t *testing.T) {
+	// These bytes encode to base64 with '+' and '/', which URL-safe base64
+	// writes as '-' and '_'
+	want := xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &xdr.ScBytes{0xfb, 0xff, 0xfe, 0x01}}
+	raw, err := want.MarshalBinary()
+	require.NoError(t, err)
+
+	std := base64.StdEncoding.EncodeToString(raw)
+	require.True(t, strings.ContainsAny(std, "+/"))
+
+	inputs := map[string]string{
+		"base64":            std,
+		"base64 unpadded":   base64.RawStdEncoding.EncodeToString(raw),
+		"base64url":         base64.URLEncoding.EncodeToString(raw),
+		"base64url raw":     base64.RawURLEncoding.EncodeToString(raw),
+		"hex":               hex.EncodeToString(raw),
+		"hex with 0x":       "0x" + strings.ToUpper(hex.EncodeToString(raw)),
+		"surrounding space": "  " + std + "\n",
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			var got xdr.ScVal
+			require.NoError(t, DecodeXDRText(input, EncodingAuto, &got, "scval"))
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestDecodeXDRText_ExplicitEncoding(t *testing.T) {
+	key := xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: func() *xdr.Uint32 { v := xdr.Uint32(7); return &v }()}
+	raw, err := key.MarshalBinary()
+	require.NoError(t, err)
+
+	var got xdr.ScVal
+	require.NoError(t, DecodeXDRText(hex.EncodeToString(raw), EncodingHex, &got, "scval"))
+	assert.Equal(t, key, got)
+
+	err = DecodeXDRText(base64.StdEncoding.EncodeToString(raw), EncodingHex, &got, "scval")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrXDRCorrupt))
+	assert.Contains(t, err.Error(), "invalid hex")
+
+	err = DecodeXDRText("not xdr at all", EncodingAuto, &got, "scval")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "scval")
+}
+
+func TestParseEncoding(t *testing.T) {
+	for name, want := range map[string]Encoding{"": EncodingAuto, "auto": EncodingAuto, "HEX": EncodingHex, "base64url": EncodingBase64URL} {
+		got, err := ParseEncoding(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseEncoding("base32")
+	assert.Error(t, err)
+}
@@ -20,19 +20,33 @@ const (
 )
 
 type XDRFormatter struct {
-	format FormatType
+	format   FormatType
+	maxDepth int
 }
 
 func NewXDRFormatter(format FormatType) *XDRFormatter {
 	return &XDRFormatter{format: format}
 }
 
+// WithMaxDepth limits how many nested container levels the tree format
+// expands. Zero means unlimited.
+func (f *XDRFormatter) WithMaxDepth(depth int) *XDRFormatter {
+	f.maxDepth = depth
+	return f
+}
+
 func (f *XDRFormatter) Format(data interface{}) (string, error) {
 	switch f.format {
 	case FormatJSON:
 		return f.formatJSON(data)
 	case FormatTable:
 		return f.formatTable(data)
+	case FormatTree:
+		node, err := f.TreeNode(data)
+		if err != nil {
+			return "", err
+		}
+		return node.Render(), nil
 	default:
 		return "", fmt.Errorf("unsupported format: %s", f.format)
 	}
@@ -66,6 +80,23 @@ func (f *XDRFormatter) formatTable(data interface{}) (string, error) {
 	}
 }
 
+// TreeNode converts a decoded XDR value into an XDRNode tree, honouring the
+// formatter's depth limit.
+func (f *XDRFormatter) TreeNode(data interface{}) (*XDRNode, error) {
+	switch v := data.(type) {
+	case *xdr.LedgerEntry:
+		return LedgerEntryNode(v, f.maxDepth), nil
+	case *xdr.DiagnosticEvent:
+		return DiagnosticEventNode(v, f.maxDepth), nil
+	case xdr.ScVal:
+		return ScValNode("", v, f.maxDepth), nil
+	case *xdr.ScVal:
+		return ScValNode("", *v, f.maxDepth), nil
+	default:
+		return nil, fmt.Errorf("tree format not supported for %T", data)
+	}
+}
+
 func formatLedgerEntryTable(entry *xdr.LedgerEntry) (string, error) {
 	var buf bytes.Buffer
 	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
@@ -153,7 +184,7 @@ func formatTransactionEnvelopeTable(env *xdr.TransactionEnvelope) (string, error
 	case xdr.EnvelopeTypeEnvelopeTypeTx:
 		if env.V1 != nil {
 			tx := env.V1.Tx
-			_, _ = fmt.Fprintf(w, "Source Account:\t%s\n", tx.SourceAccount.Address())
+			_, _ = fmt.Fprintf(w, "Source Account:\t%s\n", describeMuxedAccount(tx.SourceAccount))
 			_, _ = fmt.Fprintf(w, "Fee:\t%d\n", tx.Fee)
 			_, _ = fmt.Fprintf(w, "Sequence Num:\t%d\n", tx.SeqNum)
 			_, _ = fmt.Fprintf(w, "Operations:\t%d\n", len(tx.Operations))
@@ -162,7 +193,7 @@ func formatTransactionEnvelopeTable(env *xdr.TransactionEnvelope) (string, error
 	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
 		if env.FeeBump != nil {
 			feeBump := env.FeeBump.Tx
-			_, _ = fmt.Fprintf(w, "Fee Source:\t%s\n", feeBump.FeeSource.Address())
+			_, _ = fmt.Fprintf(w, "Fee Source:\t%s\n", describeMuxedAccount(feeBump.FeeSource))
 			_, _ = fmt.Fprintf(w, "Fee:\t%d\n", feeBump.Fee)
 		}
 	}
@@ -205,16 +236,16 @@ func formatGenericTable(items []interface{}) (string, error) {
 
 func DecodeXDRBase64AsLedgerEntry(data string) (*xdr.LedgerEntry, error) {
 	var entry xdr.LedgerEntry
-	if err := entry.UnmarshalBinary([]byte(data)); err != nil {
-		return nil, fmt.Errorf("failed to decode ledger entry: %w", err)
+	if err := UnmarshalRoundTrip([]byte(data), &entry, "ledger entry"); err != nil {
+		return nil, err
 	}
 	return &entry, nil
 }
 
 func DecodeXDRBase64AsDiagnosticEvent(data string) (*xdr.DiagnosticEvent, error) {
 	var event xdr.DiagnosticEvent
-	if err := event.UnmarshalBinary([]byte(data)); err != nil {
-		return nil, fmt.Errorf("failed to decode diagnostic event: %w", err)
+	if err := UnmarshalRoundTrip([]byte(data), &event, "diagnostic event"); err != nil {
+		return nil, err
 	}
 	return &event, nil
 }
@@ -0,0 +1,243 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// FormatTree renders nested XDR structures as an indented tree.
+const FormatTree FormatType = "tree"
+
+// XDRNode is a tree view of a decoded XDR value. Containers (vecs, maps,
+// ledger entries) carry children; leaves carry a rendered Value. When a depth
+// limit cuts a container short, Truncated records how many children were
+// omitted.
+type XDRNode struct {
+	Label     string     `json:"label,omitempty"`
+	Type      string     `json:"type"`
+	Value     string     `json:"value,omitempty"`
+	Children  []*XDRNode `json:"children,omitempty"`
+	Truncated int        `json:"truncated,omitempty"`
+}
+
+// ScValNode builds a tree for an ScVal. maxDepth limits how many container
+// levels are expanded; zero or negative means unlimited.
+func ScValNode(label string, v xdr.ScVal, maxDepth int) *XDRNode {
+	return scValNode(label, v, 1, maxDepth)
+}
+
+func scValNode(label string, v xdr.ScVal, depth, maxDepth int) *XDRNode {
+	node := &XDRNode{Label: label, Type: scValTypeName(v.Type)}
+
+	switch v.Type {
+	case xdr.ScValTypeScvVec:
+		vec, ok := v.GetVec()
+		if !ok || vec == nil {
+			node.Value = "nil"
+			return node
+		}
+		if depthExceeded(depth, maxDepth) {
+			node.Value = fmt.Sprintf("[%d items]", len(*vec))
+			node.Truncated = len(*vec)
+			return node
+		}
+		for i, item := range *vec {
+			node.Children = append(node.Children, scValNode(fmt.Sprintf("[%d]", i), item, depth+1, maxDepth))
+		}
+
+	case xdr.ScValTypeScvMap:
+		m, ok := v.GetMap()
+		if !ok || m == nil {
+			node.Value = "nil"
+			return node
+		}
+		if depthExceeded(depth, maxDepth) {
+			node.Value = fmt.Sprintf("{%d entries}", len(*m))
+			node.Truncated = len(*m)
+			return node
+		}
+		for _, entry := range *m {
+			node.Children = append(node.Children, mapEntryNode(entry, depth+1, maxDepth))
+		}
+
+	case xdr.ScValTypeScvContractInstance:
+		// GetInstance dereferences the arm without a nil check
+		inst := v.Instance
+		if inst == nil {
+			node.Value = "nil"
+			return node
+		}
+		fields := 1
+		if inst.Storage != nil {
+			fields++
+		}
+		if depthExceeded(depth, maxDepth) {
+			node.Value = fmt.Sprintf("{%d fields}", fields)
+			node.Truncated = fields
+			return node
+		}
+		exec := &XDRNode{Label: "executable", Type: inst.Executable.Type.String()}
+		if inst.Executable.WasmHash != nil {
+			exec.Value = fmt.Sprintf("%x", inst.Executable.WasmHash[:])
+		}
+		node.Children = append(node.Children, exec)
+		if inst.Storage != nil {
+			storage := xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &inst.Storage}
+			node.Children = append(node.Children, scValNode("storage", storage, depth+1, maxDepth))
+		}
+
+	default:
+		node.Value = v.String()
+	}
+
+	return node
+}
+
+// mapEntryNode renders a map entry. Simple keys become the entry label so that
+// maps read like `balance: I128 = 100` instead of nested key/value pairs.
+func mapEntryNode(entry xdr.ScMapEntry, depth, maxDepth int) *XDRNode {
+	switch entry.Key.Type {
+	case xdr.ScValTypeScvSymbol, xdr.ScValTypeScvString, xdr.ScValTypeScvU32, xdr.ScValTypeScvI32,
+		xdr.ScValTypeScvU64, xdr.ScValTypeScvI64, xdr.ScValTypeScvAddress:
+		return scValNode(entry.Key.String(), entry.Val, depth, maxDepth)
+	}
+
+	node := &XDRNode{Label: "entry", Type: "MapEntry"}
+	node.Children = []*XDRNode{
+		scValNode("key", entry.Key, depth+1, maxDepth),
+		scValNode("val", entry.Val, depth+1, maxDepth),
+	}
+	return node
+}
+
+// LedgerEntryNode builds a tree for a ledger entry, expanding contract data
+// keys and values as ScVal trees.
+func LedgerEntryNode(entry *xdr.LedgerEntry, maxDepth int) *XDRNode {
+	node := &XDRNode{Type: "LedgerEntry", Label: entry.Data.Type.String()}
+	node.Children = append(node.Children, &XDRNode{
+		Label: "last_modified_ledger",
+		Type:  "Uint32",
+		Value: fmt.Sprintf("%d", entry.LastModifiedLedgerSeq),
+	})
+
+	switch entry.Data.Type {
+	case xdr.LedgerEntryTypeContractData:
+		if cd := entry.Data.ContractData; cd != nil {
+			contract, err := cd.Contract.String()
+			if err != nil {
+				contract = err.Error()
+			}
+			node.Children = append(node.Children,
+				&XDRNode{Label: "contract", Type: "Address", Value: contract},
+				&XDRNode{Label: "durability", Type: "ContractDataDurability", Value: cd.Durability.String()},
+				ScValNode("key", cd.Key, maxDepth),
+				ScValNode("val", cd.Val, maxDepth),
+			)
+		}
+	case xdr.LedgerEntryTypeContractCode:
+		if cc := entry.Data.ContractCode; cc != nil {
+			node.Children = append(node.Children,
+				&XDRNode{Label: "hash", Type: "Hash", Value: fmt.Sprintf("%x", cc.Hash)},
+				&XDRNode{Label: "size", Type: "Bytes", Value: fmt.Sprintf("%d bytes", len(cc.Code))},
+			)
+		}
+	case xdr.LedgerEntryTypeAccount:
+		if acc := entry.Data.Account; acc != nil {
+			node.Children = append(node.Children,
+				&XDRNode{Label: "account_id", Type: "AccountId", Value: acc.AccountId.Address()},
+				&XDRNode{Label: "balance", Type: "Int64", Value: fmt.Sprintf("%d", acc.Balance)},
+				&XDRNode{Label: "seq_num", Type: "SequenceNumber", Value: fmt.Sprintf("%d", acc.SeqNum)},
+			)
+		}
+	case xdr.LedgerEntryTypeTrustline:
+		if tl := entry.Data.TrustLine; tl != nil {
+			node.Children = append(node.Children,
+				&XDRNode{Label: "account_id", Type: "AccountId", Value: tl.AccountId.Address()},
+				&XDRNode{Label: "asset_type", Type: "AssetType", Value: tl.Asset.Type.String()},
+				&XDRNode{Label: "balance", Type: "Int64", Value: fmt.Sprintf("%d", tl.Balance)},
+			)
+		}
+	}
+
+	return node
+}
+
+// DiagnosticEventNode builds a tree for a diagnostic event, expanding topics
+// and data as ScVal trees.
+func DiagnosticEventNode(event *xdr.DiagnosticEvent, maxDepth int) *XDRNode {
+	node := &XDRNode{Type: "DiagnosticEvent", Label: event.Event.Type.String()}
+	node.Children = append(node.Children, &XDRNode{
+		Label: "in_successful_contract_call",
+		Type:  "Bool",
+		Value: fmt.Sprintf("%t", event.InSuccessfulContractCall),
+	})
+
+	if event.Event.ContractId != nil {
+		id, err := strkey.Encode(strkey.VersionByteContract, event.Event.ContractId[:])
+		if err != nil {
+			id = fmt.Sprintf("%x", event.Event.ContractId[:])
+		}
+		node.Children = append(node.Children, &XDRNode{Label: "contract_id", Type: "ContractId", Value: id})
+	}
+
+	if body := event.Event.Body.V0; body != nil {
+		topics := &XDRNode{Label: "topics", Type: "Vec"}
+		for i, t := range body.Topics {
+			topics.Children = append(topics.Children, ScValNode(fmt.Sprintf("[%d]", i), t, maxDepth))
+		}
+		node.Children = append(node.Children, topics, ScValNode("data", body.Data, maxDepth))
+	}
+
+	return node
+}
+
+// Render returns the node and its descendants as an indented tree.
+func (n *XDRNode) Render() string {
+	var sb strings.Builder
+	n.render(&sb, "", true, true)
+	return sb.String()
+}
+
+func (n *XDRNode) render(sb *strings.Builder, prefix string, last, root bool) {
+	line := n.Type
+	if n.Label != "" {
+		line = n.Label + ": " + n.Type
+	}
+	if n.Value != "" {
+		line += " = " + n.Value
+	}
+
+	childPrefix := prefix
+	if root {
+		sb.WriteString(line + "\n")
+	} else {
+		branch := "├─ "
+		if last {
+			branch = "└─ "
+		}
+		sb.WriteString(prefix + branch + line + "\n")
+		if last {
+			childPrefix += "   "
+		} else {
+			childPrefix += "│  "
+		}
+	}
+
+	for i, child := range n.Children {
+		child.render(sb, childPrefix, i == len(n.Children)-1, false)
+	}
+}
+
+func depthExceeded(depth, maxDepth int) bool {
+	return maxDepth > 0 && depth > maxDepth
+}
+
+func scValTypeName(t xdr.ScValType) string {
+	return strings.TrimPrefix(t.String(), "ScValTypeScv")
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+func symVal(s string) xdr.ScVal {
+	sym := xdr.ScSymbol(s)
+	return xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}
+}
+
+func u32Val(n uint32) xdr.ScVal {
+	v := xdr.Uint32(n)
+	return xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &v}
+}
+
+func vecVal(items ...xdr.ScVal) xdr.ScVal {
+	vec := xdr.ScVec(items)
+	p := &vec
+	return xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &p}
+}
+
+func mapVal(entries ...xdr.ScMapEntry) xdr.ScVal {
+	m := xdr.ScMap(entries)
+	p := &m
+	return xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &p}
+}
+
+func TestScValNode_Nested(t *testing.T) {
+	val := mapVal(
+		xdr.ScMapEntry{Key: symVal("owner"), Val: symVal("alice")},
+		xdr.ScMapEntry{Key: symVal("amounts"), Val: vecVal(u32Val(1), u32Val(2))},
+	)
+
+	node := ScValNode("", val, 0)
+	if node.Type != "Map" || len(node.Children) != 2 {
+		t.Fatalf("expected map node with 2 children, got %+v", node)
+	}
+
+	amounts := node.Children[1]
+	if amounts.Label != "amounts" || len(amounts.Children) != 2 {
+		t.Errorf("expected amounts vec with 2 children, got %+v", amounts)
+	}
+
+	out := node.Render()
+	for _, want := range []string{"owner: Symbol = alice", "amounts: Vec", "[1]: U32 = 2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected tree to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestScValNode_DepthLimit(t *testing.T) {
+	val := vecVal(vecVal(vecVal(u32Val(1))))
+
+	node := ScValNode("", val, 2)
+	inner := node.Children[0]
+	if len(inner.Children) != 1 {
+		t.Fatalf("expected second level to be expanded, got %+v", inner)
+	}
+
+	truncated := inner.Children[0]
+	if truncated.Truncated != 1 || len(truncated.Children) != 0 {
+		t.Errorf("expected third level to be truncated, got %+v", truncated)
+	}
+	if truncated.Value != "[1 items]" {
+		t.Errorf("expected item count placeholder, got %q", truncated.Value)
+	}
+}
+
+func TestScValNode_ContractInstance(t *testing.T) {
+	hash := xdr.Hash{0xab}
+	storage := xdr.ScMap{{Key: u32Val(1), Val: u32Val(2)}}
+	val := xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{
+		Executable: xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableWasm, WasmHash: &hash},
+		Storage:    &storage,
+	}}
+
+	node := ScValNode("", val, 0)
+	if len(node.Children) != 2 || node.Children[1].Label != "storage" {
+		t.Fatalf("expected executable and storage children, got %+v", node)
+	}
+
+	truncated := ScValNode("", vecVal(val), 1).Children[0]
+	if truncated.Truncated != 2 || len(truncated.Children) != 0 || truncated.Value != "{2 fields}" {
+		t.Errorf("expected the instance below the depth limit to be truncated, got %+v", truncated)
+	}
+
+	missing := ScValNode("", xdr.ScVal{Type: xdr.ScValTypeScvContractInstance}, 0)
+	if missing.Value != "nil" {
+		t.Errorf("expected nil for a missing instance, got %+v", missing)
+	}
+}
+
+func TestXDRFormatter_TreeFormat(t *testing.T) {
+	out, err := NewXDRFormatter(FormatTree).Format(vecVal(u32Val(7)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "└─ [0]: U32 = 7") {
+		t.Errorf("unexpected tree output:\n%s", out)
+	}
+}
+
+func TestXDRFormatter_TreeUnsupportedType(t *testing.T) {
+	if _, err := NewXDRFormatter(FormatTree).Format("plain string"); err == nil {
+		t.Error("expected error for unsupported tree input")
+	}
+}
@@ -23,12 +23,20 @@ func As(err error, target any) bool {
 	return errors.As(err, target)
 }
 
+// Broad classes of RPC failure. The more specific sentinels below belong to
+// one of them, so errors.Is(err, ErrNotFound) holds for a missing
+// transaction or ledger alike.
+var (
+	ErrNotFound = errors.New("not found")
+	ErrNetwork  = errors.New("network error")
+)
+
 // Sentinel errors for comparison with errors.Is
 var (
-	ErrTransactionNotFound  = errors.New("transaction not found")
-	ErrRPCConnectionFailed  = errors.New("RPC connection failed")
-	ErrRPCTimeout           = errors.New("RPC request timed out")
-	ErrAllRPCFailed         = errors.New("all RPC endpoints failed")
+	ErrTransactionNotFound  = &classError{"transaction not found", ErrNotFound}
+	ErrRPCConnectionFailed  = &classError{"RPC connection failed", ErrNetwork}
+	ErrRPCTimeout           = &classError{"RPC request timed out", ErrNetwork}
+	ErrAllRPCFailed         = &classError{"all RPC endpoints failed", ErrNetwork}
 	ErrSimulatorNotFound    = errors.New("simulator binary not found")
 	ErrSimulationFailed     = errors.New("simulation execution failed")
 	ErrSimCrash             = errors.New("simulator process crashed")
@@ -43,7 +51,7 @@ var (
 	ErrAuditLogInvalid      = errors.New("audit log verification failed")
 	ErrSessionNotFound      = errors.New("session not found")
 	ErrUnauthorized         = errors.New("unauthorized")
-	ErrLedgerNotFound       = errors.New("ledger not found")
+	ErrLedgerNotFound       = &classError{"ledger not found", ErrNotFound}
 	ErrLedgerArchived       = errors.New("ledger has been archived")
 	ErrRateLimitExceeded    = errors.New("rate limit exceeded")
 	ErrRPCResponseTooLarge  = errors.New("RPC response too large")
@@ -52,8 +60,38 @@ var (
 	ErrMissingLedgerKey     = errors.New("missing ledger key in footprint")
 	ErrWasmInvalid          = errors.New("invalid WASM file")
 	ErrSpecNotFound         = errors.New("contract spec not found")
+	ErrChecksFailed         = errors.New("post-simulation checks failed")
+	ErrBatchFailed          = errors.New("some transactions could not be debugged")
+	ErrOffline              = errors.New("network access disabled by --offline")
+	ErrXDRCorrupt           = errors.New("XDR appears truncated or corrupt")
+	ErrInterrupted          = errors.New("interrupted")
+	ErrSimulationReverted   = errors.New("simulation reverted")
+	ErrResultsMismatch      = errors.New("results do not match")
 )
 
+// Reasons a simulated transaction failed other than its contract logic.
+// Each is also an ErrSimulationReverted.
+var (
+	ErrResourceLimitExceeded = &classError{"resource limit exceeded", ErrSimulationReverted}
+	ErrInsufficientFee       = &classError{"insufficient resource fee", ErrSimulationReverted}
+	ErrAuthFailed            = &classError{"authorization failed", ErrSimulationReverted}
+	ErrStorageMissing        = &classError{"ledger entry missing or archived", ErrSimulationReverted}
+)
+
+// classError is a sentinel that also matches the broader class it belongs to.
+type classError struct {
+	msg   string
+	class error
+}
+
+func (e *classError) Error() string {
+	return e.msg
+}
+
+func (e *classError) Unwrap() error {
+	return e.class
+}
+
 type LedgerNotFoundError struct {
 	Sequence uint32
 	Message  string
@@ -67,6 +105,10 @@ func (e *LedgerNotFoundError) Is(target error) bool {
 	return target == ErrLedgerNotFound
 }
 
+func (e *LedgerNotFoundError) Unwrap() error {
+	return ErrLedgerNotFound
+}
+
 type LedgerArchivedError struct {
 	Sequence uint32
 	Message  string
@@ -80,6 +122,26 @@ func (e *LedgerArchivedError) Is(target error) bool {
 	return target == ErrLedgerArchived
 }
 
+// TransactionNotFoundError reports a transaction hash the endpoint does not
+// know. Its message lists the usual causes so the user has a next step.
+type TransactionNotFoundError struct {
+	Hash    string
+	Network string
+	Message string
+}
+
+func (e *TransactionNotFoundError) Error() string {
+	return e.Message
+}
+
+func (e *TransactionNotFoundError) Is(target error) bool {
+	return target == ErrTransactionNotFound
+}
+
+func (e *TransactionNotFoundError) Unwrap() error {
+	return ErrTransactionNotFound
+}
+
 type RateLimitError struct {
 	Message string
 }
@@ -92,6 +154,27 @@ func (e *RateLimitError) Is(target error) bool {
 	return target == ErrRateLimitExceeded
 }
 
+// RPCError is an error response from Horizon or Soroban RPC. StatusCode is
+// the response's HTTP status, or 0 when unknown; JSON-RPC errors usually
+// arrive with 200. Code is the JSON-RPC error code, or 0 for Horizon.
+type RPCError struct {
+	URL        string
+	StatusCode int
+	Code       int
+	Message    string
+}
+
+func (e *RPCError) Error() string {
+	if e.Code == 0 && e.StatusCode != 0 {
+		return fmt.Sprintf("%v from %s: %s (HTTP %d)", ErrRPCError, e.URL, e.Message, e.StatusCode)
+	}
+	return fmt.Sprintf("%v from %s: %s (code %d)", ErrRPCError, e.URL, e.Message, e.Code)
+}
+
+func (e *RPCError) Is(target error) bool {
+	return target == ErrRPCError
+}
+
 // ResponseTooLargeError indicates the Soroban RPC response exceeded server limits.
 type ResponseTooLargeError struct {
 	URL     string
@@ -162,7 +245,17 @@ func WrapAllRPCFailed() error {
 }
 
 func WrapRPCError(url string, msg string, code int) error {
-	return fmt.Errorf("%w from %s: %s (code %d)", ErrRPCError, url, msg, code)
+	return &RPCError{URL: url, Code: code, Message: msg}
+}
+
+// WrapRPCStatusError reports an HTTP error status from an RPC endpoint. A
+// 404 also matches ErrNotFound.
+func WrapRPCStatusError(url string, status int, msg string) error {
+	err := &RPCError{URL: url, StatusCode: status, Message: msg}
+	if status == 404 {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return err
 }
 
 func WrapSimCrash(err error, stderr string) error {
@@ -213,6 +306,34 @@ func WrapLedgerArchived(sequence uint32) error {
 	}
 }
 
+// WrapLedgerBeyondRetention reports a ledger older than the oldest one the
+// endpoint at url still retains. It is a LedgerArchivedError: the history
+// exists, but only an archival endpoint serves it.
+func WrapLedgerBeyondRetention(sequence, oldest uint32, url string) error {
+	return &LedgerArchivedError{
+		Sequence: sequence,
+		Message: fmt.Sprintf("%v: ledger %d is older than ledger %d, the oldest %s retains; an archival RPC endpoint is needed",
+			ErrLedgerArchived, sequence, oldest, url),
+	}
+}
+
+func WrapTransactionNotFoundOnNetwork(hash, network string) error {
+	return &TransactionNotFoundError{
+		Hash:    hash,
+		Network: network,
+		Message: fmt.Sprintf("%v: %s is not known to the %s endpoint. It may have been submitted to a different network "+
+			"(retry with --auto-network to search mainnet, testnet and futurenet), it may be older than the endpoint's "+
+			"history retention (try an archive endpoint with --rpc-url), or the hash may be mistyped",
+			ErrTransactionNotFound, hash, network),
+	}
+}
+
+// WrapNotFound reports a resource, such as "account G...", the endpoint
+// does not have.
+func WrapNotFound(what string) error {
+	return fmt.Errorf("%s %w", what, ErrNotFound)
+}
+
 func WrapRateLimitExceeded() error {
 	return &RateLimitError{
 		Message: fmt.Sprintf("%v, please try again later", ErrRateLimitExceeded),
@@ -251,10 +372,60 @@ func WrapRPCResponseTooLarge(url string) error {
 	}
 }
 
+// WrapResponseExceedsLimit reports a response body larger than the client
+// accepts from url.
+func WrapResponseExceedsLimit(url string, limit int64) error {
+	return &ResponseTooLargeError{
+		URL: url,
+		Message: fmt.Sprintf(
+			"%v: the response from %s is larger than the %d-byte limit; "+
+				"raise --max-response-bytes if the endpoint is trusted",
+			ErrRPCResponseTooLarge, url, limit),
+	}
+}
+
 func WrapMissingLedgerKey(key string) error {
 	return &MissingLedgerKeyError{Key: key}
 }
 
+func WrapChecksFailed(count int) error {
+	return fmt.Errorf("%w: %d finding(s)", ErrChecksFailed, count)
+}
+
+func WrapOffline(url string) error {
+	return fmt.Errorf("%w: refused request to %s (use --replay or cached data)", ErrOffline, url)
+}
+
+// WrapSimulationReverted reports a simulation that ran to completion but
+// whose transaction failed, e.g. a contract panic or error return.
+func WrapSimulationReverted(msg string) error {
+	if msg == "" {
+		return ErrSimulationReverted
+	}
+	return fmt.Errorf("%w: %s", ErrSimulationReverted, msg)
+}
+
+// WrapSimulationFailure reports a reverted simulation whose failure was
+// classified as one of the reasons above.
+func WrapSimulationFailure(reason error, msg string) error {
+	if msg == "" {
+		return reason
+	}
+	return fmt.Errorf("%w: %s", reason, msg)
+}
+
+func WrapResultsMismatch(msg string) error {
+	return fmt.Errorf("%w: %s", ErrResultsMismatch, msg)
+}
+
+func WrapBatchFailed(failed, total int) error {
+	return fmt.Errorf("%w: %d of %d", ErrBatchFailed, failed, total)
+}
+
+func WrapXDRCorrupt(what string, detail string) error {
+	return fmt.Errorf("%w: %s: %s", ErrXDRCorrupt, what, detail)
+}
+
 // ErstErrorCode is the canonical classification for all errors crossing
 // RPC and Simulator boundaries.
 type ErstErrorCode string
@@ -83,6 +83,16 @@ func TestErrorComparison(t *testing.T) {
 	assert.False(t, errors.Is(err2, ErrTransactionNotFound))
 }
 
+func TestWrapTransactionNotFoundOnNetwork(t *testing.T) {
+	err := WrapTransactionNotFoundOnNetwork("abc123", "testnet")
+
+	assert.True(t, errors.Is(err, ErrTransactionNotFound))
+	assert.False(t, errors.Is(err, ErrRPCConnectionFailed))
+	assert.Contains(t, err.Error(), "abc123 is not known to the testnet endpoint")
+	assert.Contains(t, err.Error(), "--auto-network")
+	assert.Contains(t, err.Error(), "retention")
+}
+
 func TestWrapRPCResponseTooLarge(t *testing.T) {
 	url := "https://soroban-testnet.stellar.org"
 	err := WrapRPCResponseTooLarge(url)
@@ -97,3 +107,26 @@ func TestWrapRPCResponseTooLarge(t *testing.T) {
 	assert.True(t, errors.As(err, &rte))
 	assert.Equal(t, url, rte.URL)
 }
+
+func TestErrorClasses(t *testing.T) {
+	assert.True(t, errors.Is(WrapTransactionNotFoundOnNetwork("abc", "testnet"), ErrNotFound))
+	assert.True(t, errors.Is(WrapLedgerNotFound(5), ErrNotFound))
+	assert.True(t, errors.Is(WrapNotFound("account GABC"), ErrNotFound))
+	assert.True(t, errors.Is(WrapRPCConnectionFailed(fmt.Errorf("refused")), ErrNetwork))
+	assert.True(t, errors.Is(WrapRPCTimeout(fmt.Errorf("deadline")), ErrNetwork))
+	assert.False(t, errors.Is(WrapRateLimitExceeded(), ErrNetwork))
+	assert.Equal(t, "transaction not found", ErrTransactionNotFound.Error())
+}
+
+func TestRPCError(t *testing.T) {
+	err := WrapRPCStatusError("https://horizon", 500, "boom")
+	var rpcErr *RPCError
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, 500, rpcErr.StatusCode)
+	assert.True(t, errors.Is(err, ErrRPCError))
+	assert.Equal(t, "RPC server returned an error from https://horizon: boom (HTTP 500)", err.Error())
+	assert.False(t, errors.Is(err, ErrNotFound))
+
+	assert.True(t, errors.Is(WrapRPCStatusError("https://horizon", 404, "missing"), ErrNotFound))
+	assert.Equal(t, "RPC server returned an error from https://rpc: bad (code -32600)", WrapRPCError("https://rpc", "bad", -32600).Error())
+}
@@ -63,6 +63,49 @@ func Summarize(in Input) string {
 	)
 }
 
+// Category is a coarse failure class used to aggregate many transactions.
+type Category string
+
+const (
+	CategoryAuth                Category = "auth"
+	CategoryBudgetExceeded      Category = "budget_exceeded"
+	CategoryInsufficientBalance Category = "insufficient_balance"
+	CategoryMissingEntry        Category = "missing_entry"
+	CategoryWasmTrap            Category = "wasm_trap"
+	CategoryOther               Category = "other"
+)
+
+// Classify returns the failure category Summarize would explain, using the
+// same rules in the same priority order. It returns "" for a success.
+func Classify(in Input) Category {
+	if in.Status == "success" {
+		return ""
+	}
+
+	combined := strings.Join(append(in.Events, in.Logs...), " ") + " " + in.Error
+
+	switch {
+	case checkAuthFailure(in, combined) != "":
+		return CategoryAuth
+	case checkBudgetExceeded(in, combined) != "":
+		return CategoryBudgetExceeded
+	case checkInsufficientBalance(in, combined) != "":
+		return CategoryInsufficientBalance
+	case checkMissingEntry(in, combined) != "":
+		return CategoryMissingEntry
+	case checkWasmTrap(in, combined) != "":
+		return CategoryWasmTrap
+	}
+	return CategoryOther
+}
+
+// FailingContract returns the contract that was executing when the
+// transaction failed, i.e. the last contract seen in its diagnostic events.
+func FailingContract(in Input) string {
+	_, callee := extractCallerCallee(in.DiagnosticEvents)
+	return callee
+}
+
 // checkAuthFailure detects authorization-related failures, including cross-contract
 // scenarios where one contract invoked another that lacked the required authorization.
 func checkAuthFailure(in Input, combined string) string {
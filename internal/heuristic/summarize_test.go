@@ -236,3 +236,33 @@ func TestSummarize_ShortHashPassthrough(t *testing.T) {
 		t.Fatalf("expected full short hash in output, got: %s", got)
 	}
 }
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		in   Input
+		want Category
+	}{
+		{Input{Status: "success"}, ""},
+		{Input{Status: "error", Error: "Error(Auth, InvalidAction)"}, CategoryAuth},
+		{Input{Status: "error", Error: "Error(Budget, CpuLimitExceeded)", Logs: []string{"cpu limit exceeded"}}, CategoryBudgetExceeded},
+		{Input{Status: "error", Error: "insufficient balance"}, CategoryInsufficientBalance},
+		{Input{Status: "error", Error: "Error(Storage, MissingValue)"}, CategoryMissingEntry},
+		{Input{Status: "error", Error: "wasm trap: unreachable"}, CategoryWasmTrap},
+		{Input{Status: "error", Error: "something odd"}, CategoryOther},
+	}
+	for _, c := range cases {
+		if got := Classify(c.in); got != c.want {
+			t.Errorf("Classify(%q) = %q, want %q", c.in.Error, got, c.want)
+		}
+	}
+}
+
+func TestFailingContract(t *testing.T) {
+	in := Input{DiagnosticEvents: []simulator.DiagnosticEvent{
+		{ContractID: strPtr("CALLER")},
+		{ContractID: strPtr("CALLEE")},
+	}}
+	if got := FailingContract(in); got != "CALLEE" {
+		t.Fatalf("expected CALLEE, got %q", got)
+	}
+}
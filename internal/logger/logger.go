@@ -59,7 +59,7 @@ func initLogger(lvl slog.Level, w io.Writer, useJSON bool) {
 		})
 	}
 
-	Logger = slog.New(handler)
+	Logger = slog.New(requestIDHandler{handler})
 }
 
 func SetLevel(lvl slog.Level) {
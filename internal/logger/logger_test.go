@@ -5,6 +5,7 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"log/slog"
 	"os"
 	"strings"
@@ -208,6 +209,42 @@ func TestErrorLogging(t *testing.T) {
 	}
 }
 
+func TestRequestIDLogging(t *testing.T) {
+	buf := &bytes.Buffer{}
+	SetOutput(buf, true)
+	SetLevel(slog.LevelDebug)
+	defer SetOutput(os.Stderr, false)
+
+	ctx := WithRequestID(context.Background(), "abc123")
+	Logger.InfoContext(ctx, "tagged")
+	Logger.InfoContext(WithSubRequestID(ctx, 2), "batch")
+	Logger.Info("untagged")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], `"request_id":"abc123"`) {
+		t.Errorf("expected the request ID in %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"request_id":"abc123.2"`) {
+		t.Errorf("expected the batch's request ID in %s", lines[1])
+	}
+	if strings.Contains(lines[2], "request_id") {
+		t.Errorf("expected no request ID in %s", lines[2])
+	}
+}
+
+func TestNewRequestID(t *testing.T) {
+	a, b := NewRequestID(), NewRequestID()
+	if len(a) != 8 || a == b {
+		t.Errorf("expected distinct 8-character IDs, got %q and %q", a, b)
+	}
+	if RequestID(context.Background()) != "" {
+		t.Error("a context without an ID should have none")
+	}
+}
+
 func BenchmarkLogging(b *testing.B) {
 	buf := &bytes.Buffer{}
 	SetOutput(buf, false)
@@ -0,0 +1,74 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+)
+
+// RequestIDKey is the structured field that carries a record's request ID.
+const RequestIDKey = "request_id"
+
+type requestIDContextKey struct{}
+
+// NewRequestID returns a short random ID for one logical operation, such
+// as the requests made for one network.
+func NewRequestID() string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "00000000"
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID returns a context whose log records, when logged with the
+// Context variants of the Logger methods, carry id as request_id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestID returns the request ID of ctx, or "" when it has none.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// WithSubRequestID tags a step of the operation in ctx, such as one batch
+// of a paged fetch, as "<parent>.<n>", so its records can be told apart and
+// still traced back to the operation. Without a parent ID it starts a new
+// one.
+func WithSubRequestID(ctx context.Context, n int) context.Context {
+	parent := RequestID(ctx)
+	if parent == "" {
+		parent = NewRequestID()
+	}
+	return WithRequestID(ctx, fmt.Sprintf("%s.%d", parent, n))
+}
+
+// requestIDHandler adds the request ID of a record's context as a field.
+type requestIDHandler struct {
+	slog.Handler
+}
+
+func (h requestIDHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{h.Handler.WithGroup(name)}
+}
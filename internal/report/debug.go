@@ -0,0 +1,225 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"time"
+
+	"github.com/dotandev/hintents/internal/authtrace"
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// DebugReport is the structured result of a single `erst debug` run. It is the
+// common source for every machine- or human-readable artifact the debug
+// command emits (JSON, Markdown), so all renderers show the same data.
+type DebugReport struct {
+	// Tool identifies the erst build that produced the report, so saved
+	// sessions can be traced back to a specific version.
+	Tool *ToolInfo `json:"tool,omitempty"`
+
+	TxHash         string    `json:"tx_hash"`
+	Network        string    `json:"network"`
+	CompareNetwork string    `json:"compare_network,omitempty"`
+	GeneratedAt    time.Time `json:"generated_at"`
+	EnvelopeSize   int       `json:"envelope_size"`
+
+	// ReplayHash is a stable hash of the simulated envelope, result meta,
+	// footprint keys and ledger entries; runs with the same hash simulated
+	// identical inputs. See bundle.ReplayHash.
+	ReplayHash string `json:"replay_hash,omitempty"`
+
+	// DurationMs is how long the run took, from fetching the transaction to
+	// building this report, in milliseconds.
+	DurationMs int64 `json:"duration_ms,omitempty"`
+
+	// SourceAccount is the transaction's source account, and FeeSource the
+	// fee source of a fee bump. A muxed account carries its underlying
+	// account and memo ID.
+	SourceAccount *decoder.Account `json:"source_account,omitempty"`
+	FeeSource     *decoder.Account `json:"fee_source,omitempty"`
+
+	// Footprint holds the base64-encoded ledger keys touched by the transaction.
+	Footprint []string `json:"footprint,omitempty"`
+	// FootprintTypes counts the footprint keys by LedgerKey type, for
+	// example {"ContractData": 3, "Account": 1}.
+	FootprintTypes map[string]int `json:"footprint_types,omitempty"`
+
+	// TTLs and CompareTTLs hold footprint entry expirations when --show-ttl is set.
+	TTLs        []rpc.EntryTTL `json:"ttls,omitempty"`
+	CompareTTLs []rpc.EntryTTL `json:"compare_ttls,omitempty"`
+
+	// StorageChanges lists the contract-data entries the on-chain
+	// transaction updated, when --show-storage-changes is set.
+	StorageChanges []decoder.StorageChange `json:"storage_changes,omitempty"`
+
+	// ContractInstances decodes the instance entry of every contract whose
+	// instance is in the footprint: its executable and instance storage.
+	ContractInstances []decoder.ContractInstance `json:"contract_instances,omitempty"`
+
+	// Resources is the declared SorobanTransactionData and the fees charged
+	// for it, when --show-resources is set.
+	Resources *decoder.SorobanResources `json:"resources,omitempty"`
+
+	// Failure is the decoded error diagnostic of a failed primary
+	// simulation: the contract error, its message and the call stack.
+	Failure *simulator.FailureDiagnostic `json:"failure,omitempty"`
+
+	// AuthFailure identifies the failing Soroban authorization entry when
+	// the primary simulation failed an auth check.
+	AuthFailure *authtrace.SorobanAuthFailure `json:"auth_failure,omitempty"`
+
+	// SequenceFailure explains a transaction rejected for its sequence
+	// number, with the source account's current sequence when known.
+	SequenceFailure *decoder.SequenceFailure `json:"sequence_failure,omitempty"`
+
+	// FeeBump separates a fee bump's outer (fee) and inner (logic) results,
+	// when --follow-fee-bump-inner is set.
+	FeeBump *decoder.FeeBumpResult `json:"fee_bump,omitempty"`
+
+	// Restore is the restore transaction --auto-restore simulated ahead of
+	// the invoke, when the footprint has archived entries. Result is the
+	// invoke alone, run with the restored state.
+	Restore *RestoreStep `json:"restore,omitempty"`
+
+	// ChainCheck compares the primary simulation with the result the
+	// network recorded, confirming whether the failure reproduces.
+	ChainCheck *compare.ChainCheck `json:"chain_check,omitempty"`
+
+	// Warnings are the soft problems found during the run, such as missing or
+	// expiring entries, which may make the result differ from the network's
+	// without failing it.
+	Warnings []simulator.Warning `json:"warnings,omitempty"`
+
+	Result        *simulator.SimulationResponse `json:"result"`
+	CompareResult *simulator.SimulationResponse `json:"compare_result,omitempty"`
+	Diff          *compare.DiffResult           `json:"diff,omitempty"`
+
+	// WasmDiffs lists the contracts whose code differs between the primary
+	// and compare network.
+	WasmDiffs []decoder.WasmDiff `json:"wasm_diffs,omitempty"`
+
+	// NetworkProtocols holds the protocol version each compared network
+	// currently runs, when it could be fetched. Differing versions mean a
+	// divergence may be protocol-driven rather than contract-driven.
+	NetworkProtocols map[string]uint32 `json:"network_protocols,omitempty"`
+
+	// CompareMode is the event comparison mode the diffs were computed with.
+	CompareMode compare.Mode `json:"compare_mode,omitempty"`
+
+	// Events and CompareEvents pair each raw event with its decoded form.
+	// They are only populated when both representations are requested.
+	Events        []EventRecord `json:"events,omitempty"`
+	CompareEvents []EventRecord `json:"compare_events,omitempty"`
+
+	// Comparisons holds every compare network's result when more than one
+	// compare network was requested; the Compare* fields above then describe
+	// the first of them. OutcomeGroups groups all networks, primary included,
+	// by identical outcome with the majority first.
+	Comparisons   []NetworkResult        `json:"comparisons,omitempty"`
+	OutcomeGroups []compare.OutcomeGroup `json:"outcome_groups,omitempty"`
+
+	// Raw embeds the transaction and ledger state the primary simulation
+	// ran on, when --include-raw is set, so the report is self-contained.
+	Raw *RawTransaction `json:"raw,omitempty"`
+}
+
+// RawTransaction is the base64 XDR a debug run started from: the same data
+// a replay bundle holds, in one JSON object.
+type RawTransaction struct {
+	EnvelopeXdr   string            `json:"envelope_xdr"`
+	ResultXdr     string            `json:"result_xdr,omitempty"`
+	ResultMetaXdr string            `json:"result_meta_xdr,omitempty"`
+	Keys          []string          `json:"keys"`
+	LedgerEntries map[string]string `json:"ledger_entries"`
+}
+
+// RestoreStep is a RestoreFootprint transaction synthesized from Soroban
+// RPC's restore preamble and simulated by the RPC. Fees are in stroops.
+type RestoreStep struct {
+	// Keys are the archived ledger keys the restore brings back.
+	Keys            []string                  `json:"keys"`
+	ResourceFee     int64                     `json:"resource_fee"`
+	CPUInstructions int64                     `json:"cpu_instructions,omitempty"`
+	MemoryBytes     int64                     `json:"memory_bytes,omitempty"`
+	Resources       *decoder.SorobanResources `json:"resources,omitempty"`
+	// Error is set when the restore itself failed to simulate.
+	Error string `json:"error,omitempty"`
+	// Recovered counts the restored entries whose state the RPC served, and
+	// which the invoke was simulated with.
+	Recovered int `json:"recovered"`
+}
+
+// ToolInfo is the build information of the erst binary that produced a
+// report.
+type ToolInfo struct {
+	Version           string `json:"version"`
+	CommitSHA         string `json:"commit_sha"`
+	BuildDate         string `json:"build_date"`
+	GoVersion         string `json:"go_version,omitempty"`
+	StellarSDKVersion string `json:"stellar_sdk_version"`
+}
+
+// NetworkResult is one compare network's contribution to an N-way comparison.
+type NetworkResult struct {
+	Network string                        `json:"network"`
+	Result  *simulator.SimulationResponse `json:"result"`
+	TTLs    []rpc.EntryTTL                `json:"ttls,omitempty"`
+	Events  []EventRecord                 `json:"events,omitempty"`
+}
+
+// EventRecord is a single event in both its raw simulator encoding and its
+// decoded structure.
+type EventRecord struct {
+	Raw     string                     `json:"raw,omitempty"`
+	Decoded *simulator.DiagnosticEvent `json:"decoded,omitempty"`
+}
+
+// PairEvents zips the raw and decoded events of a simulation response. The
+// simulator emits both lists from the same event stream, so they are
+// index-aligned; a shorter list simply leaves the other side empty.
+func PairEvents(res *simulator.SimulationResponse) []EventRecord {
+	if res == nil {
+		return nil
+	}
+	n := len(res.Events)
+	if len(res.DiagnosticEvents) > n {
+		n = len(res.DiagnosticEvents)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	records := make([]EventRecord, n)
+	for i := range records {
+		if i < len(res.Events) {
+			records[i].Raw = res.Events[i]
+		}
+		if i < len(res.DiagnosticEvents) {
+			ev := res.DiagnosticEvents[i]
+			records[i].Decoded = &ev
+		}
+	}
+	return records
+}
+
+// NewDebugReport creates a DebugReport for the given transaction and network.
+func NewDebugReport(txHash, network string) *DebugReport {
+	return &DebugReport{
+		TxHash:      txHash,
+		Network:     network,
+		GeneratedAt: time.Now(),
+	}
+}
+
+// Status returns the primary simulation status, or "unknown" when no
+// simulation result has been attached.
+func (r *DebugReport) Status() string {
+	if r.Result == nil || r.Result.Status == "" {
+		return "unknown"
+	}
+	return r.Result.Status
+}
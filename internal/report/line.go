@@ -0,0 +1,122 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// lineFields lists the key=value pairs WriteLine emits, in order.
+var lineFields = []string{"hash", "network", "status", "category", "events", "duration"}
+
+// WriteLine writes r as a single logfmt line:
+//
+//	hash=... network=... status=... category=... events=N duration=...
+//
+// Every field is always present, so lines from many runs can be grepped and
+// parsed alike; category is empty unless the simulation failed. Values with
+// spaces, quotes or '=' are quoted.
+func WriteLine(w io.Writer, r *DebugReport) error {
+	if r == nil {
+		return fmt.Errorf("nil debug report")
+	}
+	values := []string{
+		r.TxHash,
+		r.Network,
+		r.Status(),
+		failureCategory(r),
+		strconv.Itoa(eventCount(r)),
+		fmt.Sprintf("%dms", r.DurationMs),
+	}
+
+	var b strings.Builder
+	for i, key := range lineFields {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(values[i]))
+	}
+	b.WriteByte('\n')
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// SummaryJSON is the compact outcome of a run that --summary-json prints as
+// the last line of text output.
+type SummaryJSON struct {
+	TxHash   string `json:"tx_hash"`
+	Network  string `json:"network"`
+	Status   string `json:"status"`
+	Category string `json:"category"`
+	Events   int    `json:"events"`
+	// Mismatches counts the differences found: events and status that
+	// differ from the compare network, events that differ from those
+	// recorded on chain, and failed checks.
+	Mismatches int   `json:"mismatches"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+// WriteSummaryJSON writes the SummaryJSON of r, whose checks failed
+// checkFailures times, as one line of compact JSON.
+func WriteSummaryJSON(w io.Writer, r *DebugReport, checkFailures int) error {
+	if r == nil {
+		return fmt.Errorf("nil debug report")
+	}
+	mismatches := checkFailures
+	if r.Diff != nil {
+		mismatches += r.Diff.DivergentEvents
+		if !r.Diff.StatusDiff.Match {
+			mismatches++
+		}
+	}
+	if r.ChainCheck != nil {
+		mismatches += len(r.ChainCheck.EventMismatches)
+	}
+	data, err := json.Marshal(SummaryJSON{
+		TxHash:     r.TxHash,
+		Network:    r.Network,
+		Status:     r.Status(),
+		Category:   failureCategory(r),
+		Events:     eventCount(r),
+		Mismatches: mismatches,
+		DurationMs: r.DurationMs,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// failureCategory is the failure category of r's simulation, empty unless
+// it failed.
+func failureCategory(r *DebugReport) string {
+	if r.Result == nil {
+		return ""
+	}
+	return string(r.Result.FailureCategory)
+}
+
+// eventCount is the number of events r's simulation emitted, in whichever
+// of the decoded and raw forms holds more.
+func eventCount(r *DebugReport) int {
+	if r.Result == nil {
+		return 0
+	}
+	return max(len(r.Result.DiagnosticEvents), len(r.Result.Events))
+}
+
+// logfmtValue quotes v when it would otherwise not read back as one value.
+func logfmtValue(v string) string {
+	if strings.ContainsAny(v, " \t\n\"=") {
+		return strconv.Quote(v)
+	}
+	return v
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+func TestWriteLine(t *testing.T) {
+	r := sampleDebugReport()
+	r.DurationMs = 1234
+
+	var buf bytes.Buffer
+	if err := WriteLine(&buf, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "hash=abc123 network=testnet status=error category=CONTRACT_TRAP events=1 duration=1234ms\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteLine_EmptyAndQuotedValues(t *testing.T) {
+	r := NewDebugReport("abc123", "my net")
+	r.Result = &simulator.SimulationResponse{Status: "success", Events: []string{"a", "b"}}
+
+	var buf bytes.Buffer
+	if err := WriteLine(&buf, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `hash=abc123 network="my net" status=success category= events=2 duration=0ms` + "\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	if err := WriteLine(&buf, nil); err == nil {
+		t.Error("expected an error for a nil report")
+	}
+}
+
+func TestWriteSummaryJSON(t *testing.T) {
+	r := sampleDebugReport()
+	r.DurationMs = 1234
+	r.Diff = &compare.DiffResult{DivergentEvents: 2, StatusDiff: compare.StatusDiff{Match: false}}
+	r.ChainCheck = &compare.ChainCheck{EventMismatches: []compare.DiagnosticDiff{{}}}
+
+	var buf bytes.Buffer
+	if err := WriteSummaryJSON(&buf, r, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"tx_hash":"abc123","network":"testnet","status":"error","category":"CONTRACT_TRAP","events":1,"mismatches":5,"duration_ms":1234}` + "\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	if err := WriteSummaryJSON(&buf, nil, 0); err == nil {
+		t.Error("expected an error for a nil report")
+	}
+}
@@ -0,0 +1,525 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/authtrace"
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// MarkdownRenderer renders a DebugReport as a GitHub-flavoured Markdown
+// document suitable for attaching to issues and pull requests.
+type MarkdownRenderer struct {
+	// EventDataLimit abbreviates event data and topics longer than this
+	// many bytes to their length and hash; zero shows them in full.
+	EventDataLimit int
+}
+
+func NewMarkdownRenderer() *MarkdownRenderer {
+	return &MarkdownRenderer{}
+}
+
+func (r *MarkdownRenderer) Render(report *DebugReport) ([]byte, error) {
+	if report == nil {
+		return nil, fmt.Errorf("nil debug report")
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# Erst Debug Report\n\n")
+	fmt.Fprintf(&buf, "| Field | Value |\n|---|---|\n")
+	fmt.Fprintf(&buf, "| Transaction | `%s` |\n", report.TxHash)
+	fmt.Fprintf(&buf, "| Network | %s |\n", report.Network)
+	if report.SourceAccount != nil {
+		fmt.Fprintf(&buf, "| Source Account | `%s` |\n", report.SourceAccount)
+	}
+	if report.FeeSource != nil {
+		fmt.Fprintf(&buf, "| Fee Source | `%s` |\n", report.FeeSource)
+	}
+	if len(report.Comparisons) > 0 {
+		names := make([]string, len(report.Comparisons))
+		for i, c := range report.Comparisons {
+			names[i] = c.Network
+		}
+		fmt.Fprintf(&buf, "| Compare Networks | %s |\n", strings.Join(names, ", "))
+	} else if report.CompareNetwork != "" {
+		fmt.Fprintf(&buf, "| Compare Network | %s |\n", report.CompareNetwork)
+	}
+	fmt.Fprintf(&buf, "| Status | %s |\n", report.Status())
+	if report.Result != nil && report.Result.Error != "" {
+		fmt.Fprintf(&buf, "| Error | %s |\n", escapeMarkdownCell(report.Result.Error))
+	}
+	if report.Result != nil && report.Result.FailureCategory != "" {
+		fmt.Fprintf(&buf, "| Failure Category | %s |\n", report.Result.FailureCategory)
+	}
+	fmt.Fprintf(&buf, "| Envelope Size | %d bytes |\n", report.EnvelopeSize)
+	if report.ReplayHash != "" {
+		fmt.Fprintf(&buf, "| Replay Hash | `%s` |\n", report.ReplayHash)
+	}
+	if report.Tool != nil {
+		fmt.Fprintf(&buf, "| Erst Version | %s (%s) |\n", report.Tool.Version, report.Tool.CommitSHA)
+	}
+	fmt.Fprintf(&buf, "| Generated | %s |\n\n", formatTime(report.GeneratedAt))
+
+	if report.Result != nil {
+		writeMarkdownBudget(&buf, report.Result.BudgetUsage)
+	}
+
+	writeMarkdownWarnings(&buf, report.Warnings)
+	writeMarkdownFailure(&buf, report.Failure)
+	writeMarkdownAuthFailure(&buf, report.AuthFailure)
+	writeMarkdownSequenceFailure(&buf, report.SequenceFailure)
+	writeMarkdownFeeBump(&buf, report.FeeBump)
+	writeMarkdownRestore(&buf, report.Restore)
+	writeMarkdownChainCheck(&buf, report.ChainCheck)
+	writeMarkdownFootprint(&buf, report.Footprint)
+	writeMarkdownStorageChanges(&buf, report.StorageChanges)
+	writeMarkdownContractInstances(&buf, report.ContractInstances)
+	writeMarkdownResources(&buf, report.Resources)
+	writeMarkdownTTLs(&buf, report.Network, report.TTLs)
+	if len(report.Comparisons) > 0 {
+		for _, c := range report.Comparisons {
+			writeMarkdownTTLs(&buf, c.Network, c.TTLs)
+		}
+	} else {
+		writeMarkdownTTLs(&buf, report.CompareNetwork, report.CompareTTLs)
+	}
+
+	if report.Result != nil {
+		writeMarkdownEvents(&buf, report.Result, r.EventDataLimit)
+		writeMarkdownLogs(&buf, report.Result.Logs)
+	}
+
+	writeMarkdownOutcomeGroups(&buf, report.OutcomeGroups)
+
+	if len(report.Comparisons) > 0 && report.Result != nil {
+		for _, c := range report.Comparisons {
+			if c.Result != nil {
+				writeMarkdownDiff(&buf, compare.DiffWithMode(report.Result, c.Result, report.CompareMode), report.Network, c.Network)
+			}
+		}
+	} else if report.Diff != nil {
+		writeMarkdownDiff(&buf, report.Diff, report.Network, report.CompareNetwork)
+	}
+	writeMarkdownWasmDiffs(&buf, report.WasmDiffs, report.Network, report.CompareNetwork)
+
+	return buf.Bytes(), nil
+}
+
+func writeMarkdownBudget(buf *bytes.Buffer, usage *simulator.BudgetUsage) {
+	if usage == nil {
+		return
+	}
+	fmt.Fprintf(buf, "## Resource Usage\n\n")
+	fmt.Fprintf(buf, "| Resource | Used | Limit | Usage |\n|---|---:|---:|---:|\n")
+	fmt.Fprintf(buf, "| CPU Instructions | %d | %d | %.2f%% |\n", usage.CPUInstructions, usage.CPULimit, usage.CPUUsagePercent)
+	fmt.Fprintf(buf, "| Memory Bytes | %d | %d | %.2f%% |\n", usage.MemoryBytes, usage.MemoryLimit, usage.MemoryUsagePercent)
+	fmt.Fprintf(buf, "| Operations | %d | - | - |\n\n", usage.OperationsCount)
+}
+
+func writeMarkdownWarnings(buf *bytes.Buffer, warnings []simulator.Warning) {
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "## Warnings\n\n")
+	for _, w := range warnings {
+		fmt.Fprintf(buf, "- `%s` %s\n", w.Code, w.Message)
+		for _, k := range w.Keys {
+			fmt.Fprintf(buf, "  - `%s`\n", k)
+		}
+	}
+	fmt.Fprintf(buf, "\n")
+}
+
+func writeMarkdownAuthFailure(buf *bytes.Buffer, f *authtrace.SorobanAuthFailure) {
+	if f == nil {
+		return
+	}
+	fmt.Fprintf(buf, "## Authorization Failure\n\n")
+	fmt.Fprintf(buf, "| Field | Value |\n|---|---|\n")
+	fmt.Fprintf(buf, "| Reason | %s |\n", f.Reason)
+	if f.InvokingContract != "" {
+		fmt.Fprintf(buf, "| Invoking Contract | `%s` |\n", f.InvokingContract)
+	}
+	if f.Function != "" {
+		fmt.Fprintf(buf, "| Function | %s |\n", escapeMarkdownCell(f.Function))
+	}
+	if f.EntryIndex >= 0 {
+		fmt.Fprintf(buf, "| Auth Entry | operation %d, entry %d |\n", f.OperationIndex, f.EntryIndex)
+		fmt.Fprintf(buf, "| Credentials | %s |\n", f.CredentialsType)
+	} else {
+		fmt.Fprintf(buf, "| Auth Entry | none matched |\n")
+	}
+	if f.Address != "" {
+		fmt.Fprintf(buf, "| Address | `%s` |\n", f.Address)
+	}
+	if f.ProvidedNonce != nil {
+		fmt.Fprintf(buf, "| Provided Nonce | %d |\n", *f.ProvidedNonce)
+	}
+	if f.ExpectedNonce != "" {
+		fmt.Fprintf(buf, "| Expected Nonce | %s |\n", escapeMarkdownCell(f.ExpectedNonce))
+	}
+	if f.SignatureExpirationLedger > 0 {
+		fmt.Fprintf(buf, "| Signature Expiration Ledger | %d |\n", f.SignatureExpirationLedger)
+	}
+	if f.HostMessage != "" {
+		fmt.Fprintf(buf, "| Host Message | %s |\n", escapeMarkdownCell(f.HostMessage))
+	}
+	fmt.Fprintf(buf, "\n%s\n\n", f.Hint())
+}
+
+func writeMarkdownSequenceFailure(buf *bytes.Buffer, f *decoder.SequenceFailure) {
+	if f == nil {
+		return
+	}
+	fmt.Fprintf(buf, "## Sequence Number Failure\n\n")
+	fmt.Fprintf(buf, "| Field | Value |\n|---|---|\n")
+	fmt.Fprintf(buf, "| Code | `%s` |\n", f.Code)
+	fmt.Fprintf(buf, "| Account | `%s` |\n", f.Account)
+	fmt.Fprintf(buf, "| Provided Sequence | %d |\n", f.ProvidedSequence)
+	if f.CurrentSequence != nil {
+		fmt.Fprintf(buf, "| Current Sequence | %d |\n", *f.CurrentSequence)
+		fmt.Fprintf(buf, "| Expected Sequence | %d |\n", *f.ExpectedSequence)
+		fmt.Fprintf(buf, "| Gap | %+d |\n", *f.Gap)
+	}
+	if f.MinSeqNum != nil {
+		fmt.Fprintf(buf, "| Min Sequence Number | %d |\n", *f.MinSeqNum)
+	}
+	if f.MinSeqAge != nil {
+		fmt.Fprintf(buf, "| Min Sequence Age | %ds |\n", *f.MinSeqAge)
+	}
+	if f.MinSeqLedgerGap != nil {
+		fmt.Fprintf(buf, "| Min Sequence Ledger Gap | %d |\n", *f.MinSeqLedgerGap)
+	}
+	fmt.Fprintf(buf, "\n%s\n\n", f.Explanation)
+}
+
+func writeMarkdownFeeBump(buf *bytes.Buffer, f *decoder.FeeBumpResult) {
+	if f == nil {
+		return
+	}
+	fmt.Fprintf(buf, "## Fee Bump\n\n")
+	fmt.Fprintf(buf, "| Field | Value |\n|---|---|\n")
+	fmt.Fprintf(buf, "| Fee Source | `%s` |\n", f.FeeSource)
+	fmt.Fprintf(buf, "| Max Fee | %d stroops |\n", f.MaxFee)
+	if f.OuterCode != "" {
+		fmt.Fprintf(buf, "| Outer Result | `%s` (fee charged: %d stroops) |\n", f.OuterCode, f.FeeCharged)
+	}
+	fmt.Fprintf(buf, "| Inner Transaction | `%s` |\n", f.InnerHash)
+	fmt.Fprintf(buf, "| Inner Source | `%s` |\n", f.InnerSource)
+	if f.InnerRan() {
+		fmt.Fprintf(buf, "| Inner Result | `%s` |\n", f.InnerCode)
+		fmt.Fprintf(buf, "\n%s\n\n", f.InnerExplanation)
+	} else {
+		fmt.Fprintf(buf, "\nThe fee bump was rejected before its inner transaction ran.\n\n")
+	}
+}
+
+func writeMarkdownRestore(buf *bytes.Buffer, r *RestoreStep) {
+	if r == nil {
+		return
+	}
+	fmt.Fprintf(buf, "## Restore\n\n")
+	fmt.Fprintf(buf, "The footprint has archived entries; this restore runs before the invoke, whose results follow separately.\n\n")
+	fmt.Fprintf(buf, "| Field | Value |\n|---|---|\n")
+	fmt.Fprintf(buf, "| Archived Entries | %d |\n", len(r.Keys))
+	if r.Error != "" {
+		fmt.Fprintf(buf, "| Error | %s |\n", escapeMarkdownCell(r.Error))
+	} else {
+		fmt.Fprintf(buf, "| Resource Fee | %d stroops |\n", r.ResourceFee)
+		if r.CPUInstructions > 0 {
+			fmt.Fprintf(buf, "| CPU Instructions | %d |\n", r.CPUInstructions)
+			fmt.Fprintf(buf, "| Memory Bytes | %d |\n", r.MemoryBytes)
+		}
+	}
+	fmt.Fprintf(buf, "| State Recovered | %d of %d entries |\n\n", r.Recovered, len(r.Keys))
+}
+
+func writeMarkdownChainCheck(buf *bytes.Buffer, c *compare.ChainCheck) {
+	if c == nil {
+		return
+	}
+	fmt.Fprintf(buf, "## On-Chain Comparison\n\n")
+	if c.Reproduced {
+		fmt.Fprintf(buf, "The re-simulation reproduces the on-chain result.\n\n")
+	} else {
+		fmt.Fprintf(buf, "The re-simulation does **not** match the on-chain result; the reconstructed ledger state is probably off.\n\n")
+	}
+	fmt.Fprintf(buf, "| Field | Value |\n|---|---|\n")
+	fmt.Fprintf(buf, "| On-chain | %s (`%s`) |\n", c.ChainStatus, c.ChainResultCode)
+	fmt.Fprintf(buf, "| Simulated | %s |\n", c.SimulatedStatus)
+	if c.EventsCompared > 0 {
+		fmt.Fprintf(buf, "| Differing Events | %d of %d |\n", len(c.EventMismatches), c.EventsCompared)
+	}
+	if len(c.UnexpectedWrites) > 0 {
+		fmt.Fprintf(buf, "| Unexpected Writes | %d |\n", len(c.UnexpectedWrites))
+	}
+	fmt.Fprintf(buf, "\n")
+	for _, k := range c.UnexpectedWrites {
+		fmt.Fprintf(buf, "- `%s`\n", k)
+	}
+	if len(c.UnexpectedWrites) > 0 {
+		fmt.Fprintf(buf, "\n")
+	}
+}
+
+func writeMarkdownFailure(buf *bytes.Buffer, f *simulator.FailureDiagnostic) {
+	if f == nil {
+		return
+	}
+	fmt.Fprintf(buf, "## Contract Failure\n\n")
+	fmt.Fprintf(buf, "| Field | Value |\n|---|---|\n")
+	fmt.Fprintf(buf, "| Error | `%s` |\n", f.Error)
+	if f.ErrorName != "" {
+		fmt.Fprintf(buf, "| Error name | `%s` |\n", f.ErrorName)
+	}
+	if f.ErrorDoc != "" {
+		fmt.Fprintf(buf, "| Error doc | %s |\n", escapeMarkdownCell(f.ErrorDoc))
+	}
+	if f.ContractID != "" {
+		fmt.Fprintf(buf, "| Contract | `%s` |\n", f.ContractID)
+	}
+	if f.Message != "" {
+		fmt.Fprintf(buf, "| Message | %s |\n", escapeMarkdownCell(f.Message))
+	}
+	fmt.Fprintln(buf)
+	if len(f.CallStack) > 0 {
+		fmt.Fprintf(buf, "Call stack (innermost last):\n\n")
+		for i, frame := range f.CallStack {
+			fmt.Fprintf(buf, "%d. `%s`", i+1, frame.Function)
+			if frame.ContractID != "" {
+				fmt.Fprintf(buf, " on `%s`", frame.ContractID)
+			}
+			fmt.Fprintln(buf)
+		}
+		fmt.Fprintln(buf)
+	}
+}
+
+func writeMarkdownFootprint(buf *bytes.Buffer, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "## Footprint\n\n")
+	openDetails(buf, fmt.Sprintf("%d ledger keys", len(keys)))
+	fmt.Fprintf(buf, "| # | Ledger Key (base64) |\n|---:|---|\n")
+	for i, k := range keys {
+		fmt.Fprintf(buf, "| %d | `%s` |\n", i+1, k)
+	}
+	closeDetails(buf)
+}
+
+func writeMarkdownStorageChanges(buf *bytes.Buffer, changes []decoder.StorageChange) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "## Storage Changes\n\n")
+	fmt.Fprintf(buf, "| Contract | Durability | Key | Change |\n|---|---|---|---|\n")
+	for _, c := range changes {
+		fmt.Fprintf(buf, "| `%s` | %s | %s | %s |\n", c.Contract, c.Durability, escapeMarkdownCell(c.Key), escapeMarkdownCell(c.Diff))
+	}
+	fmt.Fprintln(buf)
+}
+
+func writeMarkdownContractInstances(buf *bytes.Buffer, instances []decoder.ContractInstance) {
+	if len(instances) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "## Contract Instances\n\n")
+	for _, inst := range instances {
+		fmt.Fprintf(buf, "### `%s`\n\n", inst.Contract)
+		fmt.Fprintf(buf, "Executable: `%s`\n\n", inst.Executable)
+		if len(inst.Storage) == 0 {
+			fmt.Fprintf(buf, "No instance storage.\n\n")
+			continue
+		}
+		fmt.Fprintf(buf, "| Key | Value |\n|---|---|\n")
+		for _, e := range inst.Storage {
+			fmt.Fprintf(buf, "| %s | %s |\n", escapeMarkdownCell(e.Key), escapeMarkdownCell(e.Value))
+		}
+		fmt.Fprintln(buf)
+	}
+}
+
+func writeMarkdownResources(buf *bytes.Buffer, res *decoder.SorobanResources) {
+	if res == nil {
+		return
+	}
+	fmt.Fprintf(buf, "## Declared Resources\n\n")
+	fmt.Fprintf(buf, "| Resource | Declared |\n|---|---:|\n")
+	fmt.Fprintf(buf, "| Instructions | %d |\n", res.Instructions)
+	fmt.Fprintf(buf, "| Disk Read Bytes | %d |\n", res.DiskReadBytes)
+	fmt.Fprintf(buf, "| Write Bytes | %d |\n", res.WriteBytes)
+	fmt.Fprintf(buf, "| Resource Fee | %d stroops |\n", res.ResourceFee)
+	if res.RefundableFeeCharged != nil {
+		fmt.Fprintf(buf, "| Non-refundable Fee Charged | %d stroops |\n", *res.NonRefundableFeeCharged)
+		fmt.Fprintf(buf, "| Refundable Fee Charged | %d stroops |\n", *res.RefundableFeeCharged)
+		fmt.Fprintf(buf, "| Rent Fee Charged | %d stroops |\n", *res.RentFeeCharged)
+	}
+	fmt.Fprintf(buf, "| Read-only Keys | %d |\n", len(res.ReadOnly))
+	fmt.Fprintf(buf, "| Read-write Keys | %d |\n\n", len(res.ReadWrite))
+
+	if len(res.Undeclared) > 0 {
+		fmt.Fprintf(buf, "**Under-declared footprint:** the transaction changed %d entries it did not declare.\n\n", len(res.Undeclared))
+		fmt.Fprintf(buf, "| # | Ledger Key (base64) |\n|---:|---|\n")
+		for i, k := range res.Undeclared {
+			fmt.Fprintf(buf, "| %d | `%s` |\n", i+1, k)
+		}
+		fmt.Fprintln(buf)
+	}
+}
+
+func writeMarkdownTTLs(buf *bytes.Buffer, network string, ttls []rpc.EntryTTL) {
+	if len(ttls) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "## Entry TTLs (%s, latest ledger %d)\n\n", network, ttls[0].LatestLedger)
+	fmt.Fprintf(buf, "| Type | Durability | Live Until | Remaining | Ledger Key (base64) |\n|---|---|---:|---:|---|\n")
+	for _, t := range ttls {
+		liveUntil, remaining := fmt.Sprintf("%d", t.LiveUntilLedger), fmt.Sprintf("%d", t.LedgersRemaining())
+		if t.Missing {
+			liveUntil, remaining = "-", "missing"
+		}
+		fmt.Fprintf(buf, "| %s | %s | %s | %s | `%s` |\n", t.Type, t.Durability, liveUntil, remaining, t.Key)
+	}
+	fmt.Fprintln(buf)
+}
+
+func writeMarkdownEvents(buf *bytes.Buffer, res *simulator.SimulationResponse, dataLimit int) {
+	if len(res.DiagnosticEvents) == 0 && len(res.Events) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "## Events\n\n")
+
+	if len(res.DiagnosticEvents) > 0 {
+		openDetails(buf, fmt.Sprintf("%d diagnostic events", len(res.DiagnosticEvents)))
+		fmt.Fprintf(buf, "| # | Type | Contract | Topics | Data |\n|---:|---|---|---|---|\n")
+		for i, ev := range res.DiagnosticEvents {
+			ev = simulator.AbbreviateEvent(ev, dataLimit)
+			contractID := ""
+			if ev.ContractID != nil {
+				contractID = *ev.ContractID
+			}
+			fmt.Fprintf(buf, "| %d | %s | %s | %s | %s |\n",
+				i+1,
+				ev.EventType,
+				escapeMarkdownCell(contractID),
+				escapeMarkdownCell(strings.Join(ev.Topics, ", ")),
+				escapeMarkdownCell(ev.Data))
+		}
+		closeDetails(buf)
+		return
+	}
+
+	openDetails(buf, fmt.Sprintf("%d events", len(res.Events)))
+	for i, ev := range res.Events {
+		fmt.Fprintf(buf, "%d. `%s`\n", i+1, simulator.AbbreviateEventData(ev, dataLimit))
+	}
+	closeDetails(buf)
+}
+
+func writeMarkdownLogs(buf *bytes.Buffer, logs []string) {
+	if len(logs) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "## Logs\n\n")
+	openDetails(buf, fmt.Sprintf("%d log lines", len(logs)))
+	fmt.Fprintf(buf, "```\n%s\n```\n", strings.Join(logs, "\n"))
+	closeDetails(buf)
+}
+
+func writeMarkdownOutcomeGroups(buf *bytes.Buffer, groups []compare.OutcomeGroup) {
+	if len(groups) == 0 {
+		return
+	}
+	majority := compare.HasMajority(groups)
+	fmt.Fprintf(buf, "## Outcome Groups\n\n")
+	fmt.Fprintf(buf, "| # | Networks | Status | Error | |\n|---:|---|---|---|---|\n")
+	for i, g := range groups {
+		marker := ""
+		if majority && i > 0 {
+			marker = "divergent"
+		}
+		fmt.Fprintf(buf, "| %d | %s | %s | %s | %s |\n",
+			i+1, strings.Join(g.Networks, ", "), g.Status, escapeMarkdownCell(g.Error), marker)
+	}
+	fmt.Fprintln(buf)
+}
+
+func writeMarkdownWasmDiffs(buf *bytes.Buffer, diffs []decoder.WasmDiff, primary, other string) {
+	if len(diffs) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "## Contract WASM Differences\n\n")
+	fmt.Fprintf(buf, "| Contract | %s | %s |\n|---|---|---|\n", primary, other)
+	for _, d := range diffs {
+		fmt.Fprintf(buf, "| `%s` | %s | %s |\n", d.Contract, d.A, d.B)
+	}
+	fmt.Fprintln(buf)
+}
+
+func writeMarkdownDiff(buf *bytes.Buffer, diff *compare.DiffResult, primary, other string) {
+	fmt.Fprintf(buf, "## Cross-Network Diff (%s vs %s)\n\n", primary, other)
+
+	sd := diff.StatusDiff
+	if sd.Match {
+		fmt.Fprintf(buf, "Status match: **%s**\n\n", sd.LocalStatus)
+	} else {
+		fmt.Fprintf(buf, "Status mismatch: **%s** (%s) vs **%s** (%s)\n\n", sd.LocalStatus, primary, sd.OnChainStatus, other)
+	}
+
+	if bd := diff.BudgetDiff; bd != nil {
+		fmt.Fprintf(buf, "| Resource | %s | %s | Delta |\n|---|---:|---:|---:|\n", primary, other)
+		fmt.Fprintf(buf, "| CPU Instructions | %d | %d | %+d |\n", bd.LocalCPU, bd.OnChainCPU, bd.CPUDelta)
+		fmt.Fprintf(buf, "| Memory Bytes | %d | %d | %+d |\n", bd.LocalMem, bd.OnChainMem, bd.MemoryDelta)
+		fmt.Fprintf(buf, "| Operations | %d | %d | %+d |\n\n", bd.LocalOps, bd.OnChainOps, bd.OpsDelta)
+	}
+
+	var divergent []compare.EventDiff
+	for _, d := range diff.EventDiffs {
+		if d.Divergent {
+			divergent = append(divergent, d)
+		}
+	}
+	if len(divergent) == 0 {
+		fmt.Fprintf(buf, "No event divergences.\n\n")
+	} else {
+		openDetails(buf, fmt.Sprintf("%d divergent events", len(divergent)))
+		fmt.Fprintf(buf, "| # | %s | %s |\n|---:|---|---|\n", primary, other)
+		for _, d := range divergent {
+			fmt.Fprintf(buf, "| %d | %s | %s |\n", d.Index, escapeMarkdownCell(d.LocalEvent), escapeMarkdownCell(d.OnChainEvent))
+		}
+		closeDetails(buf)
+	}
+
+	if len(diff.CallPathDivergences) > 0 {
+		fmt.Fprintf(buf, "### Divergent Call Paths\n\n")
+		for _, cp := range diff.CallPathDivergences {
+			fmt.Fprintf(buf, "- Event %d: %s\n", cp.EventIndex, cp.Reason)
+		}
+		fmt.Fprintf(buf, "\n")
+	}
+}
+
+func openDetails(buf *bytes.Buffer, summary string) {
+	fmt.Fprintf(buf, "<details>\n<summary>%s</summary>\n\n", summary)
+}
+
+func closeDetails(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "\n</details>\n\n")
+}
+
+// escapeMarkdownCell makes a value safe to embed inside a Markdown table cell.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	return strings.ReplaceAll(s, "\n", " ")
+}
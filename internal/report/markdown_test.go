@@ -0,0 +1,413 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/authtrace"
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+func sampleDebugReport() *DebugReport {
+	contractID := "CABC"
+	r := NewDebugReport("abc123", "testnet")
+	r.EnvelopeSize = 256
+	r.Footprint = []string{"AAAAAQ==", "AAAAAg=="}
+	r.Result = &simulator.SimulationResponse{
+		Status:          "error",
+		Error:           "HostError: contract | trapped",
+		FailureCategory: simulator.FailureContractTrap,
+		BudgetUsage: &simulator.BudgetUsage{
+			CPUInstructions: 1000,
+			CPULimit:        10000,
+			CPUUsagePercent: 10,
+		},
+		DiagnosticEvents: []simulator.DiagnosticEvent{
+			{EventType: "contract", ContractID: &contractID, Topics: []string{"transfer"}, Data: "42"},
+		},
+		Logs: []string{"log line"},
+	}
+	return r
+}
+
+func TestMarkdownRender_Sections(t *testing.T) {
+	out, err := NewMarkdownRenderer().Render(sampleDebugReport())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	for _, want := range []string{
+		"# Erst Debug Report",
+		"| Transaction | `abc123` |",
+		"| Failure Category | CONTRACT_TRAP |",
+		"## Resource Usage",
+		"## Footprint",
+		"<summary>2 ledger keys</summary>",
+		"## Events",
+		"| 1 | contract | CABC | transfer | 42 |",
+		"## Logs",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+
+	if !strings.Contains(md, `contract \| trapped`) {
+		t.Error("expected pipe characters in table cells to be escaped")
+	}
+	if strings.Contains(md, "Cross-Network Diff") {
+		t.Error("did not expect diff section without a compare result")
+	}
+}
+
+func TestMarkdownRender_Diff(t *testing.T) {
+	r := sampleDebugReport()
+	r.CompareNetwork = "mainnet"
+	r.CompareResult = &simulator.SimulationResponse{Status: "success", Events: []string{"ev"}}
+	r.Diff = compare.Diff(r.Result, r.CompareResult)
+
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	if !strings.Contains(md, "## Cross-Network Diff (testnet vs mainnet)") {
+		t.Error("expected diff section header")
+	}
+	if !strings.Contains(md, "Status mismatch") {
+		t.Error("expected status mismatch line")
+	}
+	if !strings.Contains(md, "divergent events") {
+		t.Error("expected collapsible divergent event section")
+	}
+}
+
+func TestMarkdownRender_Nil(t *testing.T) {
+	if _, err := NewMarkdownRenderer().Render(nil); err == nil {
+		t.Error("expected error for nil report")
+	}
+}
+
+func TestMarkdownRender_TTLs(t *testing.T) {
+	r := sampleDebugReport()
+	r.TTLs = []rpc.EntryTTL{
+		{Key: "AAAAAQ==", Type: "contract_data", Durability: "persistent", LatestLedger: 100, Missing: true},
+		{Key: "AAAAAg==", Type: "contract_code", Durability: "persistent", LiveUntilLedger: 150, LatestLedger: 100},
+	}
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	for _, want := range []string{
+		"## Entry TTLs (testnet, latest ledger 100)",
+		"| contract_data | persistent | - | missing | `AAAAAQ==` |",
+		"| contract_code | persistent | 150 | 50 | `AAAAAg==` |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdownRender_Resources(t *testing.T) {
+	refundable, nonRefundable, rent := int64(300), int64(200), int64(50)
+	r := sampleDebugReport()
+	r.Resources = &decoder.SorobanResources{
+		ReadOnly:                []string{"AAAAAQ=="},
+		ReadWrite:               []string{},
+		Instructions:            1000,
+		ResourceFee:             900,
+		RefundableFeeCharged:    &refundable,
+		NonRefundableFeeCharged: &nonRefundable,
+		RentFeeCharged:          &rent,
+		Undeclared:              []string{"AAAAAg=="},
+	}
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	for _, want := range []string{
+		"## Declared Resources",
+		"| Resource Fee | 900 stroops |",
+		"| Refundable Fee Charged | 300 stroops |",
+		"| Read-only Keys | 1 |",
+		"changed 1 entries it did not declare",
+		"| 1 | `AAAAAg==` |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdownRender_AuthFailure(t *testing.T) {
+	nonce := int64(42)
+	r := sampleDebugReport()
+	r.AuthFailure = &authtrace.SorobanAuthFailure{
+		Reason:           authtrace.ReasonNonceReused,
+		InvokingContract: "CABC",
+		Function:         "transfer",
+		EntryIndex:       1,
+		CredentialsType:  authtrace.CredentialsAddress,
+		Address:          "GABC",
+		ProvidedNonce:    &nonce,
+	}
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	for _, want := range []string{
+		"## Authorization Failure",
+		"| Reason | nonce_reused |",
+		"| Invoking Contract | `CABC` |",
+		"| Auth Entry | operation 0, entry 1 |",
+		"| Provided Nonce | 42 |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdownRender_SequenceFailure(t *testing.T) {
+	r := sampleDebugReport()
+	r.SequenceFailure = &decoder.SequenceFailure{
+		Code:             "tx_bad_seq",
+		Account:          "GABC",
+		ProvidedSequence: 105,
+	}
+	r.SequenceFailure.SetCurrentSequence(100)
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	for _, want := range []string{
+		"## Sequence Number Failure",
+		"| Code | `tx_bad_seq` |",
+		"| Provided Sequence | 105 |",
+		"| Expected Sequence | 101 |",
+		"| Gap | +4 |",
+		"4 ahead of the 101 the account expects",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdownRender_Restore(t *testing.T) {
+	r := sampleDebugReport()
+	r.Restore = &RestoreStep{Keys: []string{"AAAA", "BBBB"}, ResourceFee: 2500, Recovered: 2}
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	for _, want := range []string{
+		"## Restore",
+		"| Archived Entries | 2 |",
+		"| Resource Fee | 2500 stroops |",
+		"| State Recovered | 2 of 2 entries |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdownRender_FeeBump(t *testing.T) {
+	r := sampleDebugReport()
+	r.FeeBump = &decoder.FeeBumpResult{
+		FeeSource:        "GFEE",
+		MaxFee:           500,
+		FeeCharged:       300,
+		OuterCode:        "tx_fee_bump_inner_failed",
+		InnerHash:        "abc123",
+		InnerSource:      "GINNER",
+		InnerCode:        "tx_failed",
+		InnerExplanation: "One or more operations failed",
+	}
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	for _, want := range []string{
+		"## Fee Bump",
+		"| Outer Result | `tx_fee_bump_inner_failed` (fee charged: 300 stroops) |",
+		"| Inner Transaction | `abc123` |",
+		"| Inner Result | `tx_failed` |",
+		"One or more operations failed",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdownRender_ChainCheck(t *testing.T) {
+	r := sampleDebugReport()
+	r.ChainCheck = &compare.ChainCheck{
+		ChainStatus:      "success",
+		ChainResultCode:  "tx_success",
+		SimulatedStatus:  "failed",
+		UnexpectedWrites: []string{"AAAABg=="},
+	}
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	for _, want := range []string{
+		"## On-Chain Comparison",
+		"does **not** match the on-chain result",
+		"| On-chain | success (`tx_success`) |",
+		"| Simulated | failed |",
+		"- `AAAABg==`",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdownRender_OutcomeGroups(t *testing.T) {
+	r := sampleDebugReport()
+	other := &simulator.SimulationResponse{Status: "success"}
+	r.Comparisons = []NetworkResult{
+		{Network: "mainnet", Result: r.Result},
+		{Network: "futurenet", Result: other},
+	}
+	r.OutcomeGroups = compare.GroupOutcomes([]compare.NamedResult{
+		{Network: "testnet", Result: r.Result},
+		{Network: "mainnet", Result: r.Result},
+		{Network: "futurenet", Result: other},
+	}, compare.ModeStrict)
+
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	for _, want := range []string{
+		"| Compare Networks | mainnet, futurenet |",
+		"## Outcome Groups",
+		"| 2 | futurenet | success |  | divergent |",
+		"## Cross-Network Diff (testnet vs futurenet)",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdownRender_AbbreviatesLargeEventData(t *testing.T) {
+	r := sampleDebugReport()
+	big := strings.Repeat("ff", 200)
+	r.Result.DiagnosticEvents[0].Data = big
+
+	out, err := (&MarkdownRenderer{EventDataLimit: simulator.DefaultEventDataLimit}).Render(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), big) || !strings.Contains(string(out), "400 bytes, sha256:") {
+		t.Errorf("large event data not abbreviated:\n%s", out)
+	}
+
+	out, err = NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), big) {
+		t.Error("the default renderer should show event data in full")
+	}
+}
+
+func TestMarkdownRender_Warnings(t *testing.T) {
+	r := sampleDebugReport()
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), "## Warnings") {
+		t.Error("did not expect a warnings section without warnings")
+	}
+
+	r.Warnings = []simulator.Warning{{
+		Code:    simulator.WarningMissingEntries,
+		Message: "The snapshot is missing 1 of 2 footprint entries",
+		Keys:    []string{"AAAAAQ=="},
+	}}
+	out, err = NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+	for _, want := range []string{
+		"## Warnings",
+		"- `MISSING_ENTRIES` The snapshot is missing 1 of 2 footprint entries",
+		"  - `AAAAAQ==`",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdownRender_ContractInstances(t *testing.T) {
+	r := sampleDebugReport()
+	r.ContractInstances = []decoder.ContractInstance{
+		{Contract: "CABC", Executable: "ab12", Storage: []decoder.InstanceStorageEntry{{Key: "Admin", Value: "GADMIN"}}},
+		{Contract: "CSAC", Executable: decoder.StellarAssetExecutable, Storage: []decoder.InstanceStorageEntry{}},
+	}
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := string(out)
+
+	for _, want := range []string{
+		"## Contract Instances",
+		"### `CABC`",
+		"Executable: `ab12`",
+		"| Admin | GADMIN |",
+		"Executable: `stellar-asset`",
+		"No instance storage.",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdownRender_ReplayHash(t *testing.T) {
+	r := sampleDebugReport()
+	r.ReplayHash = "9f2c"
+	out, err := NewMarkdownRenderer().Render(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), "| Replay Hash | `9f2c` |") {
+		t.Errorf("expected markdown to contain the replay hash")
+	}
+}
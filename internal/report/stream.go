@@ -0,0 +1,197 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const jsonIndent = "  "
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// WriteJSON encodes v to w as indented JSON without materializing the whole
+// document first. Structs and slices are written member by member, so only
+// the largest single leaf value is ever held in memory; everything else goes
+// straight to w. The output is byte-for-byte what json.MarshalIndent(v, "",
+// "  ") would produce, followed by a newline.
+func WriteJSON(w io.Writer, v interface{}) error {
+	bw := bufio.NewWriter(w)
+	s := &jsonStreamer{w: bw}
+	if err := s.value(reflect.ValueOf(v), 0); err != nil {
+		return err
+	}
+	if err := bw.WriteByte('\n'); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+type jsonStreamer struct {
+	w *bufio.Writer
+}
+
+func (s *jsonStreamer) value(v reflect.Value, depth int) error {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			_, err := s.w.WriteString("null")
+			return err
+		}
+		if v.Type().Implements(jsonMarshalerType) {
+			return s.leaf(v, depth)
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case !v.IsValid():
+		_, err := s.w.WriteString("null")
+		return err
+	case v.Kind() == reflect.Struct && streamableStruct(v.Type()):
+		return s.object(v, depth)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && !v.Type().Implements(jsonMarshalerType):
+		return s.array(v, depth)
+	default:
+		return s.leaf(v, depth)
+	}
+}
+
+// leaf encodes v in one piece, indented to match its position.
+func (s *jsonStreamer) leaf(v reflect.Value, depth int) error {
+	data, err := json.MarshalIndent(v.Interface(), strings.Repeat(jsonIndent, depth), jsonIndent)
+	if err != nil {
+		return err
+	}
+	_, err = s.w.Write(data)
+	return err
+}
+
+func (s *jsonStreamer) array(v reflect.Value, depth int) error {
+	if v.IsNil() {
+		_, err := s.w.WriteString("null")
+		return err
+	}
+	if v.Len() == 0 {
+		_, err := s.w.WriteString("[]")
+		return err
+	}
+
+	s.w.WriteByte('[')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			s.w.WriteByte(',')
+		}
+		s.newline(depth + 1)
+		if err := s.value(v.Index(i), depth+1); err != nil {
+			return err
+		}
+	}
+	s.newline(depth)
+	_, err := s.w.WriteString("]")
+	return err
+}
+
+func (s *jsonStreamer) object(v reflect.Value, depth int) error {
+	t := v.Type()
+	written := 0
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		if omitEmpty && isEmptyJSONValue(fv) {
+			continue
+		}
+
+		if written == 0 {
+			s.w.WriteByte('{')
+		} else {
+			s.w.WriteByte(',')
+		}
+		written++
+		s.newline(depth + 1)
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		s.w.Write(key)
+		s.w.WriteString(": ")
+		if err := s.value(fv, depth+1); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	if written == 0 {
+		_, err := s.w.WriteString("{}")
+		return err
+	}
+	s.newline(depth)
+	_, err := s.w.WriteString("}")
+	return err
+}
+
+func (s *jsonStreamer) newline(depth int) {
+	s.w.WriteByte('\n')
+	for i := 0; i < depth; i++ {
+		s.w.WriteString(jsonIndent)
+	}
+}
+
+// streamableStruct reports whether t can be written field by field with the
+// same result as encoding/json. Structs with custom marshalers, embedded
+// fields or ",string" options are encoded as leaves instead.
+func streamableStruct(t reflect.Type) bool {
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous || strings.Contains(field.Tag.Get("json"), ",string") {
+			return false
+		}
+	}
+	return true
+}
+
+func jsonFieldName(field reflect.StructField) (name string, omitEmpty, ok bool) {
+	if !field.IsExported() {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(","+opts+",", ",omitempty,"), true
+}
+
+// isEmptyJSONValue mirrors encoding/json's definition of empty for omitempty.
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
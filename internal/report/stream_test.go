@@ -0,0 +1,83 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/compare"
+	"github.com/dotandev/hintents/internal/rpc"
+)
+
+type streamEmbedded struct {
+	Inner string `json:"inner"`
+}
+
+type streamSample struct {
+	streamEmbedded
+	Name      string            `json:"name"`
+	Skipped   string            `json:"-"`
+	Omitted   []string          `json:"omitted,omitempty"`
+	Nil       []string          `json:"nil"`
+	Empty     []int             `json:"empty"`
+	Bytes     []byte            `json:"bytes"`
+	When      time.Time         `json:"when"`
+	Labels    map[string]string `json:"labels"`
+	Ptr       *streamEmbedded   `json:"ptr"`
+	NilPtr    *streamEmbedded   `json:"nil_ptr"`
+	Any       interface{}       `json:"any"`
+	Nested    [][]string        `json:"nested"`
+	Untagged  int
+	Quoted    int64      `json:"quoted,string"`
+	EmptyObjs []struct{} `json:"empty_objs"`
+}
+
+func assertStreamsLikeMarshalIndent(t *testing.T, v interface{}) {
+	t.Helper()
+	want, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("MarshalIndent: %v", err)
+	}
+	want = append(want, '\n')
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, v); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("streamed JSON differs\n--- got ---\n%s\n--- want ---\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteJSON_MatchesMarshalIndent(t *testing.T) {
+	assertStreamsLikeMarshalIndent(t, streamSample{
+		streamEmbedded: streamEmbedded{Inner: "x"},
+		Name:           "<tx & co>",
+		Empty:          []int{},
+		Bytes:          []byte("raw"),
+		When:           time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Labels:         map[string]string{"b": "2", "a": "1"},
+		Ptr:            &streamEmbedded{Inner: "y"},
+		Any:            map[string]interface{}{"k": []int{1, 2}},
+		Nested:         [][]string{{"a"}, {}, nil},
+		Quoted:         7,
+		EmptyObjs:      []struct{}{{}},
+	})
+}
+
+func TestWriteJSON_DebugReport(t *testing.T) {
+	r := sampleDebugReport()
+	r.TTLs = []rpc.EntryTTL{{Key: "AAAAAQ==", LatestLedger: 100, Missing: true}}
+	r.OutcomeGroups = []compare.OutcomeGroup{{Networks: []string{"testnet"}, Status: "error"}}
+	r.Events = PairEvents(r.Result)
+	assertStreamsLikeMarshalIndent(t, r)
+}
+
+func TestWriteJSON_TopLevelArray(t *testing.T) {
+	assertStreamsLikeMarshalIndent(t, []*DebugReport{sampleDebugReport(), nil, NewDebugReport("def", "mainnet")})
+	assertStreamsLikeMarshalIndent(t, []string{})
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// TemplateRenderer renders a DebugReport through a user-supplied Go
+// text/template. The template sees the DebugReport itself, so every field
+// is available (.TxHash, .Network, .Footprint, .Diff, .Result, ...) along
+// with the .Status method. .Events always pairs the primary result's raw
+// and decoded events, even when the run did not ask for both.
+type TemplateRenderer struct {
+	tmpl *template.Template
+}
+
+// templateFuncs are the helpers available to report templates besides the
+// text/template builtins.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"truncate": func(n int, s string) string {
+		if n < 0 || len(s) <= n {
+			return s
+		}
+		return s[:n] + "..."
+	},
+	"default": func(def, v interface{}) interface{} {
+		if v == nil || v == "" {
+			return def
+		}
+		return v
+	},
+}
+
+// NewTemplateRenderer parses text as a report template called name.
+func NewTemplateRenderer(name, text string) (*TemplateRenderer, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return &TemplateRenderer{tmpl: tmpl}, nil
+}
+
+// LoadTemplateRenderer reads and parses the report template at path.
+func LoadTemplateRenderer(path string) (*TemplateRenderer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	return NewTemplateRenderer(filepath.Base(path), string(data))
+}
+
+func (r *TemplateRenderer) Render(report *DebugReport) ([]byte, error) {
+	if report == nil {
+		return nil, fmt.Errorf("nil debug report")
+	}
+	data := *report
+	if len(data.Events) == 0 {
+		data.Events = PairEvents(report.Result)
+	}
+
+	var buf bytes.Buffer
+	if err := r.tmpl.Execute(&buf, &data); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
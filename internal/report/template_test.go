@@ -0,0 +1,66 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTemplateRender_Fields(t *testing.T) {
+	r, err := NewTemplateRenderer("t", `{{.TxHash}}|{{.Status}}|{{len .Footprint}}|{{len .Events}}|{{range .Events}}{{.Decoded.Data}}{{end}}|{{truncate 9 .Result.Error}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := r.Render(sampleDebugReport())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "abc123|error|2|1|42|HostError..."; string(out) != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
+
+func TestTemplateRender_Errors(t *testing.T) {
+	if _, err := NewTemplateRenderer("bad", "{{.TxHash"); err == nil {
+		t.Error("expected a parse error")
+	}
+
+	r, err := NewTemplateRenderer("missing", "{{.NoSuchField}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.Render(sampleDebugReport()); err == nil || !strings.Contains(err.Error(), "NoSuchField") {
+		t.Errorf("expected an error naming the unknown field, got %v", err)
+	}
+	if _, err := r.Render(nil); err == nil {
+		t.Error("expected an error for a nil report")
+	}
+	if _, err := LoadTemplateRenderer(filepath.Join(t.TempDir(), "absent.tmpl")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestTemplateRender_Examples(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "examples", "templates", "*.tmpl"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no example templates found: %v", err)
+	}
+	for _, path := range paths {
+		r, err := LoadTemplateRenderer(path)
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		out, err := r.Render(sampleDebugReport())
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		if !strings.Contains(string(out), "abc123") {
+			t.Errorf("%s: expected the transaction hash in %q", path, out)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// retentionRangeRe matches the oldest ledger Soroban RPC names when a
+// request falls outside its retention window, e.g. "startLedger must be
+// between the oldest ledger: 1000 and the latest ledger: 2000 for this rpc
+// instance."
+var retentionRangeRe = regexp.MustCompile(`oldest ledger:?\s*(\d+)`)
+
+// retentionError turns a Soroban RPC error saying ledger is older than the
+// endpoint at url retains into a LedgerArchivedError. Any other error is
+// returned unchanged.
+func retentionError(err error, ledger uint32, url string) error {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		return err
+	}
+	m := retentionRangeRe.FindStringSubmatch(rpcErr.Message)
+	if m == nil {
+		return err
+	}
+	oldest, perr := strconv.ParseUint(m[1], 10, 32)
+	if perr != nil || ledger >= uint32(oldest) {
+		return err
+	}
+	return errors.WrapLedgerBeyondRetention(ledger, uint32(oldest), url)
+}
+
+// archivalClient returns a client that sends Soroban RPC requests to the
+// archival endpoint alone, sharing c's transport, or nil when c has none.
+func (c *Client) archivalClient() *Client {
+	if c.ArchivalURL == "" || c.ArchivalURL == c.SorobanURL {
+		return nil
+	}
+	return &Client{
+		Horizon:     c.Horizon,
+		HorizonURL:  c.HorizonURL,
+		Network:     c.Network,
+		SorobanURL:  c.ArchivalURL,
+		AltURLs:     []string{c.ArchivalURL},
+		httpClient:  c.httpClient,
+		token:       c.token,
+		Config:      c.Config,
+		failures:    make(map[string]int),
+		lastFailure: make(map[string]time.Time),
+		offline:     c.offline,
+		limiter:     c.limiter,
+		tlsConfig:   c.tlsConfig,
+		userAgent:   c.userAgent,
+	}
+}
+
+// withArchivalFallback runs fetch against c and, when it fails because the
+// ledger is beyond the retention window of c's endpoints (errors.Is
+// ErrLedgerArchived), retries it against the archival endpoint. Without
+// one, it warns that older ledgers need one and returns the error.
+func (c *Client) withArchivalFallback(ctx context.Context, what string, fetch func(*Client) error) error {
+	err := fetch(c)
+	if err == nil || !errors.Is(err, errors.ErrLedgerArchived) {
+		return err
+	}
+	archival := c.archivalClient()
+	if archival == nil {
+		logger.Logger.WarnContext(ctx, "The ledger is beyond the RPC's retention window and no archival RPC is configured; set --archival-rpc-url or the network's archivalRPCURL",
+			"request", what, "url", c.SorobanURL)
+		return err
+	}
+	logger.Logger.WarnContext(ctx, "The ledger is beyond the RPC's retention window; retrying against the archival RPC",
+		"request", what, "url", archival.SorobanURL)
+	return fetch(archival)
+}
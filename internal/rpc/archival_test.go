@@ -0,0 +1,70 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func staticRPCServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+const beyondRetention = `{"jsonrpc":"2.0","id":1,"error":{"code":-32600,` +
+	`"message":"startLedger must be between the oldest ledger: 1000 and the latest ledger: 2000 for this rpc instance."}}`
+
+func TestGetEvents_ArchivalFallback(t *testing.T) {
+	primary := staticRPCServer(t, beyondRetention)
+	archival := staticRPCServer(t, `{"jsonrpc":"2.0","id":1,"result":{"latestLedger":2000,"events":[
+		{"type":"contract","ledger":100,"contractId":"C1","id":"1","topic":["AAAADwAAAAR0ZXN0"],"value":"AAAAAQ=="}]}}`)
+
+	client := &Client{SorobanURL: primary.URL, AltURLs: []string{primary.URL}, ArchivalURL: archival.URL}
+	resp, err := client.GetEvents(context.Background(), 100, nil)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.Equal(t, "C1", resp[0].ContractID)
+}
+
+func TestGetEvents_BeyondRetentionWithoutArchival(t *testing.T) {
+	primary := staticRPCServer(t, beyondRetention)
+
+	client := &Client{SorobanURL: primary.URL, AltURLs: []string{primary.URL}}
+	_, err := client.GetEvents(context.Background(), 100, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrLedgerArchived))
+	assert.Contains(t, err.Error(), "older than ledger 1000")
+}
+
+func TestGetTransactionViaSoroban_ArchivalFallback(t *testing.T) {
+	primary := sorobanTxServer(t, `{"status":"NOT_FOUND","latestLedger":2000,"oldestLedger":1000}`)
+	archival := sorobanTxServer(t, `{"status":"SUCCESS","latestLedger":2000,"oldestLedger":1,"ledger":150,"envelopeXdr":"ENV"}`)
+
+	client := &Client{SorobanURL: primary.URL, AltURLs: []string{primary.URL}, ArchivalURL: archival.URL, Network: Testnet}
+	tx, err := client.GetTransactionViaSoroban(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(150), tx.Ledger)
+	assert.Equal(t, "ENV", tx.EnvelopeXdr)
+}
+
+func TestArchivalClient(t *testing.T) {
+	assert.Nil(t, (&Client{SorobanURL: "https://rpc.example"}).archivalClient())
+	assert.Nil(t, (&Client{SorobanURL: "https://rpc.example", ArchivalURL: "https://rpc.example"}).archivalClient())
+
+	archival := (&Client{SorobanURL: "https://rpc.example", ArchivalURL: "https://archive.example"}).archivalClient()
+	require.NotNil(t, archival)
+	assert.Equal(t, "https://archive.example", archival.SorobanURL)
+	assert.Equal(t, []string{"https://archive.example"}, archival.AltURLs)
+}
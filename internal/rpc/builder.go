@@ -4,7 +4,9 @@
 package rpc
 
 import (
+	"crypto/tls"
 	"fmt"
+	"math"
 	"net/http"
 	"os"
 	"time"
@@ -22,9 +24,21 @@ type clientBuilder struct {
 	sorobanURL     string
 	altURLs        []string
 	cacheEnabled   bool
+	cacheStore     CacheStore
+	entryMemo      *EntryMemo
 	config         *NetworkConfig
 	httpClient     *http.Client
 	requestTimeout time.Duration
+	offline        bool
+	rateLimit      *float64
+	tlsConfig      *tls.Config
+	userAgent      string
+	txSource       TransactionSource
+	archivalURL    string
+	rawHorizon     bool
+	stats          *RequestStats
+
+	maxResponseBytes int64
 }
 
 const defaultHTTPTimeout = 15 * time.Second
@@ -34,6 +48,7 @@ func newBuilder() *clientBuilder {
 		network:        Mainnet,
 		cacheEnabled:   true,
 		requestTimeout: defaultHTTPTimeout,
+		offline:        IsOffline(),
 	}
 }
 
@@ -107,6 +122,31 @@ func WithNetworkConfig(cfg NetworkConfig) ClientOption {
 	}
 }
 
+// WithArchivalRPCURL sets a full-history Soroban RPC endpoint that
+// ledger-pinned requests fall back to when the regular endpoints no longer
+// retain the ledger. It overrides NetworkConfig.ArchivalRPCURL.
+func WithArchivalRPCURL(url string) ClientOption {
+	return func(b *clientBuilder) error {
+		if url != "" {
+			if err := isValidURL(url); err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("invalid ArchivalRPCURL: %v", err))
+			}
+		}
+		b.archivalURL = url
+		return nil
+	}
+}
+
+// WithRawHorizon makes GetTransaction keep Horizon's transaction JSON, with
+// the memo, signatures, fee account and other fields TransactionResponse
+// does not parse, in TransactionResponse.RawHorizon.
+func WithRawHorizon(enabled bool) ClientOption {
+	return func(b *clientBuilder) error {
+		b.rawHorizon = enabled
+		return nil
+	}
+}
+
 func WithCacheEnabled(enabled bool) ClientOption {
 	return func(b *clientBuilder) error {
 		b.cacheEnabled = enabled
@@ -114,6 +154,25 @@ func WithCacheEnabled(enabled bool) ClientOption {
 	}
 }
 
+// WithCacheStore sets where the client caches ledger entries when caching is
+// enabled, in place of the SQLite database in ~/.erst. The store may be
+// shared with other clients.
+func WithCacheStore(store CacheStore) ClientOption {
+	return func(b *clientBuilder) error {
+		b.cacheStore = store
+		return nil
+	}
+}
+
+// WithEntryMemo shares a run-scoped ledger-entry memo with the client so keys
+// already fetched by another client in the same run are not requested again.
+func WithEntryMemo(memo *EntryMemo) ClientOption {
+	return func(b *clientBuilder) error {
+		b.entryMemo = memo
+		return nil
+	}
+}
+
 // WithRequestTimeout sets a custom HTTP request timeout for all RPC calls.
 // Use this to override the default 15-second timeout, for example on slow connections.
 // A value of 0 disables the timeout (not recommended for production use).
@@ -131,6 +190,30 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
+// WithOffline makes the client refuse every outbound request with
+// errors.ErrOffline, so only cached and memoized data can be served. It
+// overrides WithHTTPClient. Clients default to the process-wide SetOffline
+// setting.
+func WithOffline(enabled bool) ClientOption {
+	return func(b *clientBuilder) error {
+		b.offline = enabled
+		return nil
+	}
+}
+
+// WithRateLimit caps the client at rps requests per second, counting every
+// HTTP request including retries; 0 removes any limit. It overrides both
+// SetRateLimits and NetworkConfig.RateLimit.
+func WithRateLimit(rps float64) ClientOption {
+	return func(b *clientBuilder) error {
+		if rps < 0 || math.IsNaN(rps) || math.IsInf(rps, 0) {
+			return errors.WrapValidationError(fmt.Sprintf("invalid rate limit %v: must be a non-negative number of requests per second", rps))
+		}
+		b.rateLimit = &rps
+		return nil
+	}
+}
+
 func NewClient(opts ...ClientOption) (*Client, error) {
 	builder := newBuilder()
 
@@ -157,58 +240,40 @@ func (b *clientBuilder) validate() error {
 	}
 
 	if b.horizonURL == "" && b.sorobanURL == "" {
-		b.horizonURL = b.getDefaultHorizonURL(b.network)
+		b.horizonURL = b.network.Config().HorizonURL
 	}
 
 	return nil
 }
 
-func (b *clientBuilder) getDefaultHorizonURL(net Network) string {
-	switch net {
-	case Testnet:
-		return TestnetHorizonURL
-	case Futurenet:
-		return FuturenetHorizonURL
-	default:
-		return MainnetHorizonURL
-	}
-}
-
-func (b *clientBuilder) getDefaultSorobanURL(net Network) string {
-	switch net {
-	case Testnet:
-		return TestnetSorobanURL
-	case Futurenet:
-		return FuturenetSorobanURL
-	default:
-		return MainnetSorobanURL
-	}
-}
-
-func (b *clientBuilder) getConfig(net Network) NetworkConfig {
-	switch net {
-	case Testnet:
-		return TestnetConfig
-	case Futurenet:
-		return FuturenetConfig
-	default:
-		return MainnetConfig
-	}
-}
-
 func (b *clientBuilder) build() (*Client, error) {
 	if b.sorobanURL == "" {
-		b.sorobanURL = b.getDefaultSorobanURL(b.network)
+		b.sorobanURL = b.network.Config().SorobanRPCURL
 	}
 
 	if b.config == nil {
-		cfg := b.getConfig(b.network)
+		cfg := b.network.Config()
 		b.config = &cfg
 	}
+	if b.archivalURL == "" {
+		b.archivalURL = b.config.ArchivalRPCURL
+	}
 
-	if b.httpClient == nil {
-		b.httpClient = createHTTPClient(b.token, b.requestTimeout)
+	limiter := NewRateLimiter(b.resolveRateLimit())
+	tlsConfig := b.resolveTLSConfig()
+	userAgent := b.resolveUserAgent()
+	if b.offline {
+		b.httpClient = offlineHTTPClient()
+	} else if b.httpClient == nil {
+		b.httpClient = createHTTPClient(b.token, userAgent, b.requestTimeout, limiter, tlsConfig, b.resolveMaxResponseBytes())
+	} else {
+		b.httpClient = withRateLimit(b.httpClient, limiter)
+		// A caller's own client is only bounded when it asks to be
+		if b.maxResponseBytes > 0 {
+			b.httpClient = withResponseLimit(b.httpClient, b.maxResponseBytes)
+		}
 	}
+	b.httpClient = withRequestStats(b.httpClient, b.stats)
 
 	if len(b.altURLs) == 0 && b.horizonURL != "" {
 		b.altURLs = []string{b.horizonURL}
@@ -235,7 +300,29 @@ func (b *clientBuilder) build() (*Client, error) {
 		token:        b.token,
 		Config:       *b.config,
 		CacheEnabled: b.cacheEnabled,
+		Cache:        b.cacheStore,
+		EntryMemo:    b.entryMemo,
 		failures:     make(map[string]int),
 		lastFailure:  make(map[string]time.Time),
+		offline:      b.offline,
+		limiter:      limiter,
+		tlsConfig:    tlsConfig,
+		userAgent:    userAgent,
+		txSource:     b.txSource,
+		ArchivalURL:  b.archivalURL,
+		rawHorizon:   b.rawHorizon,
 	}, nil
-}
\ No newline at end of file
+}
+
+// resolveRateLimit picks the request rate for the client: WithRateLimit, then
+// the process-wide SetRateLimits entry for the network, then the network
+// config. Zero means unlimited.
+func (b *clientBuilder) resolveRateLimit() float64 {
+	if b.rateLimit != nil {
+		return *b.rateLimit
+	}
+	if rps, ok := defaultRateLimit(b.network); ok {
+		return rps
+	}
+	return b.config.RateLimit
+}
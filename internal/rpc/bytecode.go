@@ -152,7 +152,7 @@ func FetchContractBytecode(ctx context.Context, c *Client, contractIDStr string)
 	for k, v := range codeEntries {
 		entries[k] = v
 	}
-	logger.Logger.Debug("Fetched contract bytecode on demand", "contract_id", contractIDStr, "cached", true)
+	logger.Logger.DebugContext(ctx, "Fetched contract bytecode on demand", "contract_id", contractIDStr, "cached", true)
 	return entries, nil
 }
 
@@ -174,7 +174,7 @@ func FetchBytecodeForTraceContractCalls(ctx context.Context, c *Client, contract
 		// require parsing; simpler to always call FetchContractBytecode which uses the client cache.
 		fetched, err := FetchContractBytecode(ctx, c, id)
 		if err != nil {
-			logger.Logger.Warn("Failed to fetch contract bytecode for trace", "contract_id", id, "error", err)
+			logger.Logger.WarnContext(ctx, "Failed to fetch contract bytecode for trace", "contract_id", id, "error", err)
 			continue
 		}
 		if existingMap == nil {
@@ -0,0 +1,153 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// CacheStore is where a Client caches ledger entries between runs. Keys are
+// base64 XDR LedgerKeys and values the entries' XDR. Implementations must be
+// safe for concurrent use: the compare networks of one debug run fetch in
+// parallel and may share a store. A ttl of zero or less means
+// DefaultCacheTTL.
+type CacheStore interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+// SQLiteCacheStore is the default CacheStore, the ~/.erst/cache.db database
+// behind the package-level Get and Set.
+type SQLiteCacheStore struct{}
+
+var _ CacheStore = SQLiteCacheStore{}
+
+// Get returns the cached value for key. A failed read is logged and
+// treated as a miss.
+func (SQLiteCacheStore) Get(key string) ([]byte, bool) {
+	val, hit, err := Get(key)
+	if err != nil {
+		logger.Logger.Warn("Cache read failed", "error", err)
+	}
+	if err != nil || !hit {
+		return nil, false
+	}
+	return []byte(val), true
+}
+
+func (SQLiteCacheStore) Set(key string, value []byte, ttl time.Duration) error {
+	return SetWithTTL(key, string(value), ttl)
+}
+
+// MemoryCacheStore is a CacheStore that lives as long as the process, for
+// tests and for embedding erst where nothing should touch the disk.
+type MemoryCacheStore struct {
+	mu      sync.RWMutex
+	entries map[string]memoryCacheEntry
+	now     func() time.Time
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+var _ CacheStore = (*MemoryCacheStore)(nil)
+
+// NewMemoryCacheStore creates an empty in-memory store.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{entries: make(map[string]memoryCacheEntry), now: time.Now}
+}
+
+func (s *MemoryCacheStore) Get(key string) ([]byte, bool) {
+	s.mu.RLock()
+	e, ok := s.entries[key]
+	s.mu.RUnlock()
+	if !ok || !s.now().Before(e.expiresAt) {
+		return nil, false
+	}
+	return append([]byte(nil), e.value...), true
+}
+
+func (s *MemoryCacheStore) Set(key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = memoryCacheEntry{value: append([]byte(nil), value...), expiresAt: s.now().Add(ttl)}
+	return nil
+}
+
+// FileCacheStore is a CacheStore keeping one file per key in a directory.
+// Each file holds the expiry time followed by the value and is replaced
+// atomically, so concurrent readers never see a partial write, even from
+// another erst process sharing the directory.
+type FileCacheStore struct {
+	dir string
+	now func() time.Time
+}
+
+var _ CacheStore = (*FileCacheStore)(nil)
+
+// NewFileCacheStore creates a store in dir, creating the directory if
+// needed.
+func NewFileCacheStore(dir string) (*FileCacheStore, error) {
+	if err := os.MkdirAll(dir, DirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &FileCacheStore{dir: dir, now: time.Now}, nil
+}
+
+func (s *FileCacheStore) path(key string) string {
+	return filepath.Join(s.dir, getCacheKey(key))
+}
+
+func (s *FileCacheStore) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+	expiresAt := time.Unix(0, int64(binary.BigEndian.Uint64(data[:8])))
+	if !s.now().Before(expiresAt) {
+		return nil, false
+	}
+	return data[8:], true
+}
+
+func (s *FileCacheStore) Set(key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	data := make([]byte, 8, 8+len(value))
+	binary.BigEndian.PutUint64(data, uint64(s.now().Add(ttl).UnixNano()))
+	data = append(data, value...)
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("cache write failed: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), FilePerm)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("cache write failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCacheStores(t *testing.T) map[string]CacheStore {
+	t.Helper()
+	files, err := NewFileCacheStore(t.TempDir())
+	require.NoError(t, err)
+	return map[string]CacheStore{"memory": NewMemoryCacheStore(), "file": files}
+}
+
+func TestCacheStore_SetAndGet(t *testing.T) {
+	for name, store := range testCacheStores(t) {
+		t.Run(name, func(t *testing.T) {
+			_, hit := store.Get("missing")
+			assert.False(t, hit)
+
+			require.NoError(t, store.Set("key", []byte("value"), time.Hour))
+			val, hit := store.Get("key")
+			assert.True(t, hit)
+			assert.Equal(t, []byte("value"), val)
+
+			require.NoError(t, store.Set("key", []byte("newer"), 0))
+			val, _ = store.Get("key")
+			assert.Equal(t, []byte("newer"), val, "a second Set replaces the value")
+		})
+	}
+}
+
+func TestCacheStore_Expiry(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	clock := func() time.Time { return now }
+
+	memory := NewMemoryCacheStore()
+	memory.now = clock
+	files, err := NewFileCacheStore(t.TempDir())
+	require.NoError(t, err)
+	files.now = clock
+
+	for name, store := range map[string]CacheStore{"memory": memory, "file": files} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, store.Set("key", []byte("value"), time.Minute))
+			_, hit := store.Get("key")
+			assert.True(t, hit)
+		})
+	}
+	now = now.Add(time.Minute)
+	for name, store := range map[string]CacheStore{"memory": memory, "file": files} {
+		_, hit := store.Get("key")
+		assert.False(t, hit, "%s entry should have expired", name)
+	}
+}
+
+func TestCacheStore_Concurrent(t *testing.T) {
+	for name, store := range testCacheStores(t) {
+		t.Run(name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					for j := 0; j < 20; j++ {
+						key := fmt.Sprintf("key-%d", j%4)
+						assert.NoError(t, store.Set(key, []byte(fmt.Sprintf("value-%d", i)), time.Hour))
+						if val, hit := store.Get(key); hit {
+							assert.Regexp(t, `^value-\d$`, string(val), "a read never sees a partial write")
+						}
+					}
+				}(i)
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestClientGetLedgerEntries_UsesInjectedCacheStore(t *testing.T) {
+	key := contractDataKeyB64(t, "BALANCE", xdr.ContractDataDurabilityPersistent)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"latestLedger":10,"entries":[{"key":"` + key + `","xdr":"AAAA"}]}}`))
+	}))
+	defer server.Close()
+
+	store := NewMemoryCacheStore()
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}, CacheEnabled: true, Cache: store}
+
+	entries, err := client.GetLedgerEntries(context.Background(), []string{key})
+	require.NoError(t, err)
+	assert.Equal(t, "AAAA", entries[key])
+	cached, hit := store.Get(key)
+	assert.True(t, hit, "the fetched entry is written to the injected store")
+	assert.Equal(t, []byte("AAAA"), cached)
+
+	entries, err = client.GetLedgerEntries(context.Background(), []string{key})
+	require.NoError(t, err)
+	assert.Equal(t, "AAAA", entries[key])
+	assert.Equal(t, 1, calls, "the second fetch is served from the store")
+}
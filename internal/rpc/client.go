@@ -6,6 +6,7 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,15 +26,6 @@ import (
 	"github.com/dotandev/hintents/internal/errors"
 )
 
-// Network types for Stellar
-type Network string
-
-const (
-	Testnet   Network = "testnet"
-	Mainnet   Network = "mainnet"
-	Futurenet Network = "futurenet"
-)
-
 // Horizon URLs for each network
 const (
 	TestnetHorizonURL   = "https://horizon-testnet.stellar.org/"
@@ -48,9 +40,11 @@ const (
 	FuturenetSorobanURL = "https://rpc-futurenet.stellar.org"
 )
 
-// authTransport is a custom HTTP RoundTripper that adds authentication headers
+// authTransport is a custom HTTP RoundTripper that adds authentication and
+// User-Agent headers
 type authTransport struct {
 	token     string
+	userAgent string
 	transport http.RoundTripper
 }
 
@@ -60,6 +54,9 @@ func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		// Add Bearer token to Authorization header
 		req.Header.Set("Authorization", "Bearer "+t.token)
 	}
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
 	return t.transport.RoundTrip(req)
 }
 
@@ -69,6 +66,23 @@ type NetworkConfig struct {
 	HorizonURL        string
 	NetworkPassphrase string
 	SorobanRPCURL     string
+
+	// RateLimit caps requests to this network's endpoints, in requests per
+	// second. Zero means unlimited.
+	RateLimit float64
+
+	// TLSConfig is used for HTTPS connections to this network's endpoints,
+	// for example to trust a private CA. nil uses the process-wide setting.
+	TLSConfig *tls.Config
+
+	// UserAgent is sent as the User-Agent header of every request to this
+	// network's endpoints. Empty uses the process-wide setting.
+	UserAgent string
+
+	// ArchivalRPCURL is a Soroban RPC endpoint that keeps the network's full
+	// history. Ledger-pinned requests that fall outside the retention window
+	// of SorobanRPCURL are retried against it.
+	ArchivalRPCURL string
 }
 
 // Predefined network configurations
@@ -108,8 +122,17 @@ type Client struct {
 	token        string // stored for reference, not logged
 	Config       NetworkConfig
 	CacheEnabled bool
+	Cache        CacheStore // where CacheEnabled caches entries; nil means SQLiteCacheStore
+	EntryMemo    *EntryMemo // optional run-scoped store shared with other clients
 	failures     map[string]int
 	lastFailure  map[string]time.Time
+	offline      bool         // every request is refused; see WithOffline
+	limiter      *RateLimiter // throttles every HTTP request; nil if unlimited
+	tlsConfig    *tls.Config  // nil uses the system defaults
+	userAgent    string
+	txSource     TransactionSource // API GetTransaction uses; "" is auto
+	ArchivalURL  string            // full-history Soroban RPC; see NetworkConfig.ArchivalRPCURL
+	rawHorizon   bool              // keep Horizon's transaction JSON; see WithRawHorizon
 }
 
 // NodeFailure records a failure for a specific RPC URL
@@ -123,6 +146,15 @@ type AllNodesFailedError struct {
 	Failures []NodeFailure
 }
 
+// Unwrap exposes the per-node errors so errors.Is can match the underlying cause.
+func (e *AllNodesFailedError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Reason)
+	}
+	return errs
+}
+
 func (e *AllNodesFailedError) Error() string {
 	var reasons []string
 	for _, f := range e.Failures {
@@ -211,6 +243,16 @@ func NewClientWithURLsOption(urls []string, net Network, token string) *Client {
 	return client
 }
 
+// endpointCount returns how many endpoints a failover loop should try. A client
+// without alternates still gets a single attempt against its primary URL, and
+// an offline client never fails over since every endpoint refuses alike.
+func (c *Client) endpointCount() int {
+	if len(c.AltURLs) == 0 || c.offline {
+		return 1
+	}
+	return len(c.AltURLs)
+}
+
 // rotateURL switches to the next available provider URL, skipping unhealthy ones if possible
 func (c *Client) rotateURL() bool {
 	c.mu.Lock()
@@ -234,9 +276,14 @@ func (c *Client) rotateURL() bool {
 	}
 
 	c.HorizonURL = c.AltURLs[c.currIndex]
+	// When the Soroban endpoint is one of the alternates (single-URL RPC
+	// providers serve both APIs), fail it over together with Horizon.
+	if containsURL(c.AltURLs, c.SorobanURL) {
+		c.SorobanURL = c.HorizonURL
+	}
 	httpClient := c.httpClient
 	if httpClient == nil {
-		httpClient = createHTTPClient(c.token, defaultHTTPTimeout)
+		httpClient = createHTTPClient(c.token, c.userAgent, defaultHTTPTimeout, c.limiter, c.tlsConfig, processMaxResponseBytes())
 	}
 	c.Horizon = &horizonclient.Client{
 		HorizonURL: c.HorizonURL,
@@ -247,6 +294,24 @@ func (c *Client) rotateURL() bool {
 	return true
 }
 
+// failoverError reports a failover loop that ran out of endpoints. An offline
+// client's refusal is returned as is so the cause stays obvious.
+func (c *Client) failoverError(failures []NodeFailure) error {
+	if c.offline && len(failures) == 1 {
+		return failures[0].Reason
+	}
+	return &AllNodesFailedError{Failures: failures}
+}
+
+func containsURL(urls []string, url string) bool {
+	for _, u := range urls {
+		if u == url {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Client) getHTTPClient() *http.Client {
 	if c.httpClient != nil {
 		return c.httpClient
@@ -254,26 +319,39 @@ func (c *Client) getHTTPClient() *http.Client {
 	return http.DefaultClient
 }
 
-// createHTTPClient creates an HTTP client with optional authentication and a configurable timeout.
-func createHTTPClient(token string, timeout time.Duration) *http.Client {
+// createHTTPClient creates an HTTP client with optional authentication, User-Agent,
+// TLS configuration and a configurable timeout, whose response bodies are
+// bounded by maxResponseBytes.
+func createHTTPClient(token, userAgent string, timeout time.Duration, limiter *RateLimiter, tlsConfig *tls.Config, maxResponseBytes int64) *http.Client {
 	cfg := DefaultRetryConfig()
+	// Every call the client makes is a read; keep it that way should a
+	// submitting method be added.
+	cfg.IdempotentOnly = true
+
+	// Every client shares the pooled connections for its TLS configuration
+	var baseTransport http.RoundTripper = sharedTransport(tlsConfig)
+	if limiter != nil {
+		baseTransport = &rateLimitedTransport{limiter: limiter, transport: baseTransport}
+	}
 
-	var baseTransport http.RoundTripper = http.DefaultTransport
+	// Print each attempt, with the headers authTransport adds
+	baseTransport = withCurl(baseTransport)
 
 	var transport http.RoundTripper = baseTransport
-	if token != "" {
+	if token != "" || userAgent != "" {
 		transport = &authTransport{
 			token:     token,
+			userAgent: userAgent,
 			transport: baseTransport,
 		}
 	}
 
 	transport = NewRetryTransport(cfg, transport)
 
-	return &http.Client{
+	return withResponseLimit(&http.Client{
 		Transport: transport,
 		Timeout:   timeout,
-	}
+	}, maxResponseBytes)
 }
 
 // NewCustomClient creates a new RPC client for a custom/private network
@@ -283,7 +361,19 @@ func NewCustomClient(config NetworkConfig) (*Client, error) {
 		return nil, err
 	}
 
-	httpClient := createHTTPClient("", defaultHTTPTimeout)
+	limiter := NewRateLimiter(config.RateLimit)
+	tlsConfig := config.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = defaultTLS.Load()
+	}
+	userAgent := config.UserAgent
+	if userAgent == "" {
+		userAgent = processUserAgent()
+	}
+	httpClient := createHTTPClient("", userAgent, defaultHTTPTimeout, limiter, tlsConfig, processMaxResponseBytes())
+	if IsOffline() {
+		httpClient = offlineHTTPClient()
+	}
 	horizonClient := &horizonclient.Client{
 		HorizonURL: config.HorizonURL,
 		HTTP:       httpClient,
@@ -301,6 +391,10 @@ func NewCustomClient(config NetworkConfig) (*Client, error) {
 		Config:       config,
 		CacheEnabled: true,
 		httpClient:   httpClient,
+		offline:      IsOffline(),
+		limiter:      limiter,
+		tlsConfig:    tlsConfig,
+		userAgent:    userAgent,
 	}, nil
 }
 
@@ -325,32 +419,85 @@ type GetHealthResponse struct {
 	} `json:"error,omitempty"`
 }
 
-// GetTransaction fetches the transaction details and full XDR data
+// GetTransaction fetches the transaction details and full XDR data, from
+// Horizon or, depending on the client's TransactionSource, Soroban RPC. When
+// the preferred API is rate limited or unreachable, the other one is tried;
+// see transactionProviders.
 func (c *Client) GetTransaction(ctx context.Context, hash string) (*TransactionResponse, error) {
-	if len(c.AltURLs) == 0 {
-		return nil, &AllNodesFailedError{}
+	providers := c.transactionProviders()
+	var failed []error
+	for i, p := range providers {
+		resp, err := p.get(ctx, hash)
+		if err == nil {
+			logger.Logger.InfoContext(ctx, "Transaction fetched", "hash", hash, "provider", p.name, "url", p.url())
+			return resp, nil
+		}
+		if ctx.Err() != nil || !shouldFallBack(err) {
+			return nil, err
+		}
+		failed = append(failed, err)
+		if i < len(providers)-1 {
+			logger.Logger.WarnContext(ctx, "Transaction provider failed; falling back",
+				"hash", hash, "provider", p.name, "fallback", providers[i+1].name, "error", err)
+		}
 	}
+	return nil, providersFailedError(providers, failed)
+}
+
+// getTransactionViaHorizon fetches a transaction from Horizon, failing over
+// across the client's endpoints.
+func (c *Client) getTransactionViaHorizon(ctx context.Context, hash string) (*TransactionResponse, error) {
+	attempts := c.endpointCount()
 	var failures []NodeFailure
-	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		resp, err := c.getTransactionAttempt(ctx, hash)
 		if err == nil {
 			c.markSuccess(c.HorizonURL)
 			return resp, nil
 		}
+		// Cancellation is not the endpoint's fault; stop without failing over
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 
-		c.markFailure(c.HorizonURL)
+		// A missing transaction says nothing about the endpoint's health,
+		// but another endpoint may retain more history, so keep rotating.
+		if !errors.Is(err, errors.ErrTransactionNotFound) {
+			c.markFailure(c.HorizonURL)
+		}
 
 		failures = append(failures, NodeFailure{URL: c.HorizonURL, Reason: err})
 
 		// Only rotate if this isn't the last possible URL
-		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying with fallback RPC...", "error", err)
+		if attempt < attempts-1 {
+			logger.Logger.WarnContext(ctx, "Retrying with fallback RPC...", "error", err)
 			if !c.rotateURL() {
 				break
 			}
 		}
 	}
-	return nil, &AllNodesFailedError{Failures: failures}
+	if notFound := allNotFound(failures); notFound != nil {
+		return nil, notFound
+	}
+	return nil, c.failoverError(failures)
+}
+
+// allNotFound returns the first failure's error when every endpoint reported
+// the transaction as not found, so the user sees the not-found guidance
+// rather than a list of endpoint failures.
+func allNotFound(failures []NodeFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	for _, f := range failures {
+		if !errors.Is(f.Reason, errors.ErrTransactionNotFound) {
+			return nil
+		}
+	}
+	return failures[0].Reason
 }
 
 func (c *Client) getTransactionAttempt(ctx context.Context, hash string) (*TransactionResponse, error) {
@@ -363,7 +510,7 @@ func (c *Client) getTransactionAttempt(ctx context.Context, hash string) (*Trans
 	)
 	defer span.End()
 
-	logger.Logger.Debug("Fetching transaction details", "hash", hash, "url", c.HorizonURL)
+	logger.Logger.DebugContext(ctx, "Fetching transaction details", "hash", hash, "url", c.HorizonURL)
 
 	// Fail fast if circuit breaker is open for this Horizon endpoint.
 	if !c.isHealthy(c.HorizonURL) {
@@ -372,11 +519,19 @@ func (c *Client) getTransactionAttempt(ctx context.Context, hash string) (*Trans
 		return nil, errors.WrapRPCConnectionFailed(err)
 	}
 
-	tx, err := c.Horizon.TransactionDetail(hash)
+	var (
+		tx  hProtocol.Transaction
+		raw json.RawMessage
+		err error
+	)
+	if c.rawHorizon {
+		tx, raw, err = c.horizonTransactionJSON(ctx, hash)
+	} else {
+		tx, err = transactionDetail(ctx, c.Horizon, hash)
+	}
 	if err != nil {
 		span.RecordError(err)
-		logger.Logger.Error("Failed to fetch transaction", "hash", hash, "error", err, "url", c.HorizonURL)
-		return nil, errors.WrapRPCConnectionFailed(err)
+		return nil, c.handleTransactionError(err, hash)
 	}
 
 	span.SetAttributes(
@@ -385,9 +540,35 @@ func (c *Client) getTransactionAttempt(ctx context.Context, hash string) (*Trans
 		attribute.Int("result_meta.size_bytes", len(tx.ResultMetaXdr)),
 	)
 
-	logger.Logger.Info("Transaction fetched", "hash", hash, "envelope_size", len(tx.EnvelopeXdr), "url", c.HorizonURL)
+	logger.Logger.InfoContext(ctx, "Transaction fetched", "hash", hash, "envelope_size", len(tx.EnvelopeXdr), "url", c.HorizonURL)
+
+	resp := ParseTransactionResponse(tx)
+	resp.RawHorizon = raw
+	return resp, nil
+}
+
+// LatestTransactionHash returns the hash of the most recent transaction
+// Horizon knows about, for callers that need any real transaction to work
+// with.
+func (c *Client) LatestTransactionHash(ctx context.Context) (string, error) {
+	if !c.isHealthy(c.HorizonURL) {
+		return "", errors.WrapRPCConnectionFailed(fmt.Errorf("circuit breaker open for %s", c.HorizonURL))
+	}
+
+	page, err := c.Horizon.Transactions(horizonclient.TransactionRequest{
+		Order: horizonclient.OrderDesc,
+		Limit: 1,
+	})
+	if err != nil {
+		c.markFailure(c.HorizonURL)
+		return "", errors.WrapRPCConnectionFailed(err)
+	}
+	c.markSuccess(c.HorizonURL)
 
-	return ParseTransactionResponse(tx), nil
+	if len(page.Embedded.Records) == 0 {
+		return "", errors.WrapTransactionNotFound(fmt.Errorf("no transactions returned by %s", c.HorizonURL))
+	}
+	return page.Embedded.Records[0].Hash, nil
 }
 
 // GetNetworkPassphrase returns the network passphrase for this client
@@ -410,17 +591,20 @@ type GetLedgerEntriesRequest struct {
 	Params  []interface{} `json:"params"`
 }
 
+// LedgerEntryResult is a single entry returned by getLedgerEntries.
+type LedgerEntryResult struct {
+	Key                string `json:"key"`
+	Xdr                string `json:"xdr"`
+	LastModifiedLedger int    `json:"lastModifiedLedgerSeq"`
+	LiveUntilLedger    int    `json:"liveUntilLedgerSeq"`
+}
+
 type GetLedgerEntriesResponse struct {
 	Jsonrpc string `json:"jsonrpc"`
 	ID      int    `json:"id"`
 	Result  struct {
-		Entries []struct {
-			Key                string `json:"key"`
-			Xdr                string `json:"xdr"`
-			LastModifiedLedger int    `json:"lastModifiedLedgerSeq"`
-			LiveUntilLedger    int    `json:"liveUntilLedgerSeq"`
-		} `json:"entries"`
-		LatestLedger int `json:"latestLedger"`
+		Entries      []LedgerEntryResult `json:"entries"`
+		LatestLedger int                 `json:"latestLedger"`
 	} `json:"result"`
 	Error *struct {
 		Code    int    `json:"code"`
@@ -452,11 +636,9 @@ type GetLedgerEntriesResponse struct {
 //
 // GetLedgerHeader fetches ledger header details for a specific sequence with automatic fallback.
 func (c *Client) GetLedgerHeader(ctx context.Context, sequence uint32) (*LedgerHeaderResponse, error) {
-	if len(c.AltURLs) == 0 {
-		return nil, &AllNodesFailedError{}
-	}
+	attempts := c.endpointCount()
 	var failures []NodeFailure
-	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
+	for attempt := 0; attempt < attempts; attempt++ {
 		resp, err := c.getLedgerHeaderAttempt(ctx, sequence)
 		if err == nil {
 			c.markSuccess(c.HorizonURL)
@@ -467,14 +649,19 @@ func (c *Client) GetLedgerHeader(ctx context.Context, sequence uint32) (*LedgerH
 
 		failures = append(failures, NodeFailure{URL: c.HorizonURL, Reason: err})
 
-		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying ledger header fetch with fallback RPC...", "error", err)
+		if attempt < attempts-1 {
+			logger.Logger.WarnContext(ctx, "Retrying ledger header fetch with fallback RPC...", "error", err)
 			if !c.rotateURL() {
 				break
 			}
 		}
 	}
-	return nil, &AllNodesFailedError{Failures: failures}
+	// With a single endpoint there was no failover; surface the typed error
+	// (not found, archived, rate limited) directly.
+	if len(failures) == 1 {
+		return nil, failures[0].Reason
+	}
+	return nil, c.failoverError(failures)
 }
 
 func (c *Client) getLedgerHeaderAttempt(ctx context.Context, sequence uint32) (*LedgerHeaderResponse, error) {
@@ -487,7 +674,7 @@ func (c *Client) getLedgerHeaderAttempt(ctx context.Context, sequence uint32) (*
 	)
 	defer span.End()
 
-	logger.Logger.Debug("Fetching ledger header", "sequence", sequence, "network", c.Network, "url", c.HorizonURL)
+	logger.Logger.DebugContext(ctx, "Fetching ledger header", "sequence", sequence, "network", c.Network, "url", c.HorizonURL)
 
 	// Fail fast if circuit breaker is open for this Horizon endpoint.
 	if !c.isHealthy(c.HorizonURL) {
@@ -511,7 +698,7 @@ func (c *Client) getLedgerHeaderAttempt(ctx context.Context, sequence uint32) (*
 		attribute.Int("ledger.tx_count", int(response.SuccessfulTxCount+response.FailedTxCount)),
 	)
 
-	logger.Logger.Info("Ledger header fetched successfully",
+	logger.Logger.InfoContext(ctx, "Ledger header fetched successfully",
 		"sequence", sequence,
 		"hash", response.Hash,
 		"url", c.HorizonURL,
@@ -520,6 +707,52 @@ func (c *Client) getLedgerHeaderAttempt(ctx context.Context, sequence uint32) (*
 	return response, nil
 }
 
+// transactionDetail fetches hash from Horizon, returning as soon as ctx is
+// done. The Horizon client takes no context, so an abandoned request runs on
+// in the background until its own timeout.
+func transactionDetail(ctx context.Context, horizon horizonclient.ClientInterface, hash string) (hProtocol.Transaction, error) {
+	type result struct {
+		tx  hProtocol.Transaction
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		tx, err := horizon.TransactionDetail(hash)
+		done <- result{tx, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.tx, r.err
+	case <-ctx.Done():
+		return hProtocol.Transaction{}, ctx.Err()
+	}
+}
+
+// handleTransactionError tells a missing transaction apart from rate limiting
+// and server failures, which call for different next steps.
+func (c *Client) handleTransactionError(err error, hash string) error {
+	if hErr, ok := err.(*horizonclient.Error); ok {
+		switch hErr.Problem.Status {
+		case 404:
+			logger.Logger.Warn("Transaction not found", "hash", hash, "status", 404, "url", c.HorizonURL)
+			return errors.WrapTransactionNotFoundOnNetwork(hash, string(c.Network))
+		case 413:
+			logger.Logger.Warn("Response too large", "hash", hash, "status", 413)
+			return errors.WrapRPCResponseTooLarge(c.HorizonURL)
+		case 429:
+			logger.Logger.Warn("Rate limit exceeded", "hash", hash, "status", 429)
+			return errors.WrapRateLimitExceeded()
+		default:
+			logger.Logger.Error("Horizon error", "hash", hash, "status", hErr.Problem.Status, "detail", hErr.Problem.Detail)
+			return errors.WrapRPCStatusError(c.HorizonURL, hErr.Problem.Status, hErr.Problem.Detail)
+		}
+	}
+
+	logger.Logger.Error("Failed to fetch transaction", "hash", hash, "error", err, "url", c.HorizonURL)
+	return requestError(err)
+}
+
 // handleLedgerError provides detailed error messages for ledger fetch failures
 func (c *Client) handleLedgerError(err error, sequence uint32) error {
 	// Check if it's a Horizon error
@@ -539,13 +772,13 @@ func (c *Client) handleLedgerError(err error, sequence uint32) error {
 			return errors.WrapRateLimitExceeded()
 		default:
 			logger.Logger.Error("Horizon error", "sequence", sequence, "status", hErr.Problem.Status, "detail", hErr.Problem.Detail)
-			return errors.WrapRPCError(c.HorizonURL, hErr.Problem.Detail, hErr.Problem.Status)
+			return errors.WrapRPCStatusError(c.HorizonURL, hErr.Problem.Status, hErr.Problem.Detail)
 		}
 	}
 
 	// Generic error
 	logger.Logger.Error("Failed to fetch ledger", "sequence", sequence, "error", err)
-	return errors.WrapRPCConnectionFailed(err)
+	return requestError(err)
 }
 
 // IsLedgerNotFound checks if error is a "ledger not found" error
@@ -578,16 +811,27 @@ func (c *Client) GetLedgerEntries(ctx context.Context, keys []string) (map[strin
 	entries := make(map[string]string)
 	var keysToFetch []string
 
+	// Reuse entries already fetched earlier in this run, on any client
+	// sharing the memo
+	if c.EntryMemo != nil {
+		var memoHits map[string]string
+		memoHits, keys = c.EntryMemo.lookup(c.Network, keys)
+		for k, v := range memoHits {
+			entries[k] = v
+		}
+		if len(keys) == 0 {
+			logger.Logger.DebugContext(ctx, "All ledger entries reused from this run", "count", len(entries))
+			return entries, nil
+		}
+	}
+
 	// Check cache if enabled
 	if c.CacheEnabled {
+		store := c.cacheStore()
 		for _, key := range keys {
-			val, hit, err := Get(key)
-			if err != nil {
-				logger.Logger.Warn("Cache read failed", "error", err)
-			}
-			if hit {
-				entries[key] = val
-				logger.Logger.Debug("Cache hit", "key", key)
+			if val, hit := store.Get(key); hit {
+				entries[key] = string(val)
+				logger.Logger.DebugContext(ctx, "Cache hit", "key", key)
 			} else {
 				keysToFetch = append(keysToFetch, key)
 			}
@@ -598,57 +842,147 @@ func (c *Client) GetLedgerEntries(ctx context.Context, keys []string) (map[strin
 
 	// If all keys found in cache, return immediately
 	if len(keysToFetch) == 0 {
-		logger.Logger.Info("All ledger entries found in cache", "count", len(keys))
+		logger.Logger.InfoContext(ctx, "All ledger entries found in cache", "count", len(keys))
 		return entries, nil
 	}
 
-	if len(c.AltURLs) == 0 {
-		return nil, &AllNodesFailedError{}
+	logger.Logger.DebugContext(ctx, "Fetching ledger entries from RPC", "count", len(keysToFetch), "url", c.SorobanURL)
+	var res map[string]string
+	err := c.withSorobanFailover(ctx, func() error {
+		var err error
+		res, err = c.getLedgerEntriesAttempt(ctx, keysToFetch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.EntryMemo != nil {
+		c.EntryMemo.Seed(c.Network, res)
 	}
 
-	logger.Logger.Debug("Fetching ledger entries from RPC", "count", len(keysToFetch), "url", c.SorobanURL)
+	// Merge with cached results
+	for k, v := range res {
+		entries[k] = v
+	}
+	return entries, nil
+}
+
+// cacheStore returns the store CacheEnabled reads and writes.
+func (c *Client) cacheStore() CacheStore {
+	if c.Cache != nil {
+		return c.Cache
+	}
+	return SQLiteCacheStore{}
+}
+
+// withSorobanFailover runs attempt against the active Soroban RPC endpoint,
+// rotating through AltURLs until one succeeds or ctx is done. Endpoint health
+// is recorded for the circuit breaker after every attempt.
+func (c *Client) withSorobanFailover(ctx context.Context, attempt func() error) error {
+	attempts := c.endpointCount()
+
 	var failures []NodeFailure
-	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
-		res, err := c.getLedgerEntriesAttempt(ctx, keysToFetch)
+	for i := 0; i < attempts; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := attempt()
 		if err == nil {
 			c.markSuccess(c.SorobanURL)
-			// Merge with cached results
-			for k, v := range res {
-				entries[k] = v
-			}
-			return entries, nil
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
 		}
 
 		c.markFailure(c.SorobanURL)
 		failures = append(failures, NodeFailure{URL: c.SorobanURL, Reason: err})
 
-		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying with fallback Soroban RPC...", "error", err)
+		if i < attempts-1 {
+			logger.Logger.WarnContext(ctx, "Retrying with fallback Soroban RPC...", "error", err)
 			if !c.rotateURL() {
 				break
 			}
-			continue
 		}
 	}
-	return nil, &AllNodesFailedError{Failures: failures}
+	return c.failoverError(failures)
 }
 
 func (c *Client) getLedgerEntriesAttempt(ctx context.Context, keysToFetch []string) (map[string]string, error) {
+	rpcResp, err := c.getLedgerEntriesRaw(ctx, keysToFetch)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]string)
+	fetchedCount := 0
+	for _, entry := range rpcResp.Result.Entries {
+		entries[entry.Key] = entry.Xdr
+		fetchedCount++
+
+		// Cache the new entry
+		if c.CacheEnabled {
+			if err := c.cacheStore().Set(entry.Key, []byte(entry.Xdr), DefaultCacheTTL); err != nil {
+				logger.Logger.WarnContext(ctx, "Failed to cache entry", "key", entry.Key, "error", err)
+			}
+		}
+	}
+
+	// Cryptographically verify all returned ledger entries
+	if err := VerifyLedgerEntries(keysToFetch, entries); err != nil {
+		return nil, fmt.Errorf("ledger entry verification failed: %w", err)
+	}
+
+	logger.Logger.InfoContext(ctx, "Ledger entries fetched",
+		"total_requested", len(keysToFetch),
+		"from_cache", len(keysToFetch)-fetchedCount,
+		"from_rpc", fetchedCount,
+		"url", c.SorobanURL,
+	)
+
+	return entries, nil
+}
+
+// MaxLedgerKeysPerRequest is the most keys sent in one getLedgerEntries
+// call; Soroban RPC rejects larger requests.
+const MaxLedgerKeysPerRequest = 200
+
+// getLedgerEntriesRaw fetches keysToFetch from the active Soroban RPC
+// endpoint, in pages of at most MaxLedgerKeysPerRequest keys, and merges the
+// pages into one response. LatestLedger is the newest any page reported.
+func (c *Client) getLedgerEntriesRaw(ctx context.Context, keysToFetch []string) (*GetLedgerEntriesResponse, error) {
+	if len(keysToFetch) <= MaxLedgerKeysPerRequest {
+		return c.getLedgerEntriesPage(ctx, keysToFetch)
+	}
+
+	var merged GetLedgerEntriesResponse
+	for start := 0; start < len(keysToFetch); start += MaxLedgerKeysPerRequest {
+		end := min(start+MaxLedgerKeysPerRequest, len(keysToFetch))
+		pageCtx := logger.WithSubRequestID(ctx, start/MaxLedgerKeysPerRequest+1)
+		logger.Logger.DebugContext(pageCtx, "Fetching ledger entry page", "from", start, "to", end, "total", len(keysToFetch))
+		page, err := c.getLedgerEntriesPage(pageCtx, keysToFetch[start:end])
+		if err != nil {
+			return nil, err
+		}
+		merged.Jsonrpc, merged.ID = page.Jsonrpc, page.ID
+		merged.Result.Entries = append(merged.Result.Entries, page.Result.Entries...)
+		merged.Result.LatestLedger = max(merged.Result.LatestLedger, page.Result.LatestLedger)
+	}
+	return &merged, nil
+}
+
+// getLedgerEntriesPage performs a single getLedgerEntries call against the
+// active Soroban RPC endpoint and returns the decoded response as-is.
+func (c *Client) getLedgerEntriesPage(ctx context.Context, keysToFetch []string) (*GetLedgerEntriesResponse, error) {
 	// Always use the dedicated Soroban RPC URL for getLedgerEntries; this is a
 	// Soroban JSON-RPC method and is not served by the Horizon REST API.
 	targetURL := c.SorobanURL
 	if targetURL == "" {
-		switch c.Network {
-		case Testnet:
-			targetURL = TestnetSorobanURL
-		case Mainnet:
-			targetURL = MainnetSorobanURL
-		case Futurenet:
-			targetURL = FuturenetSorobanURL
-		}
+		targetURL = c.Network.Config().SorobanRPCURL
 	}
 
-	logger.Logger.Debug("Fetching ledger entries", "count", len(keysToFetch), "url", targetURL)
+	logger.Logger.DebugContext(ctx, "Fetching ledger entries", "count", len(keysToFetch), "url", targetURL)
 
 	// Fail fast if circuit breaker is open for this Soroban endpoint.
 	if !c.isHealthy(targetURL) {
@@ -677,7 +1011,7 @@ func (c *Client) getLedgerEntriesAttempt(ctx context.Context, keysToFetch []stri
 
 	resp, err := c.getHTTPClient().Do(req)
 	if err != nil {
-		return nil, errors.WrapRPCConnectionFailed(err)
+		return nil, requestError(err)
 	}
 	defer resp.Body.Close()
 
@@ -691,41 +1025,15 @@ func (c *Client) getLedgerEntriesAttempt(ctx context.Context, keysToFetch []stri
 	}
 
 	var rpcResp GetLedgerEntriesResponse
-	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
-		return nil, errors.WrapUnmarshalFailed(err, string(respBytes))
+	if err := decodeRPCResponse(targetURL, resp.StatusCode, respBytes, &rpcResp); err != nil {
+		return nil, err
 	}
 
 	if rpcResp.Error != nil {
-		return nil, errors.WrapRPCError(targetURL, rpcResp.Error.Message, rpcResp.Error.Code)
+		return nil, jsonRPCError(targetURL, resp.StatusCode, rpcResp.Error.Code, rpcResp.Error.Message)
 	}
 
-	entries := make(map[string]string)
-	fetchedCount := 0
-	for _, entry := range rpcResp.Result.Entries {
-		entries[entry.Key] = entry.Xdr
-		fetchedCount++
-
-		// Cache the new entry
-		if c.CacheEnabled {
-			if err := Set(entry.Key, entry.Xdr); err != nil {
-				logger.Logger.Warn("Failed to cache entry", "key", entry.Key, "error", err)
-			}
-		}
-	}
-
-	// Cryptographically verify all returned ledger entries
-	if err := VerifyLedgerEntries(keysToFetch, entries); err != nil {
-		return nil, fmt.Errorf("ledger entry verification failed: %w", err)
-	}
-
-	logger.Logger.Info("Ledger entries fetched",
-		"total_requested", len(keysToFetch),
-		"from_cache", len(keysToFetch)-fetchedCount,
-		"from_rpc", fetchedCount,
-		"url", targetURL,
-	)
-
-	return entries, nil
+	return &rpcResp, nil
 }
 
 type TransactionSummary struct {
@@ -746,7 +1054,7 @@ type EventSummary struct {
 }
 
 func (c *Client) GetAccountTransactions(ctx context.Context, account string, limit int) ([]TransactionSummary, error) {
-	logger.Logger.Debug("Fetching account transactions", "account", account)
+	logger.Logger.DebugContext(ctx, "Fetching account transactions", "account", account)
 
 	pageSize := normalizePageSize(limit)
 	req := horizonclient.TransactionRequest{
@@ -768,8 +1076,8 @@ func (c *Client) GetAccountTransactions(ctx context.Context, account string, lim
 		max: limit,
 	}.collect()
 	if err != nil {
-		logger.Logger.Error("Failed to fetch account transactions", "account", account, "error", err)
-		return nil, errors.WrapRPCConnectionFailed(err)
+		logger.Logger.ErrorContext(ctx, "Failed to fetch account transactions", "account", account, "error", err)
+		return nil, horizonError(err, c.HorizonURL, "account "+account)
 	}
 
 	summaries := make([]TransactionSummary, 0, len(transactions))
@@ -781,13 +1089,13 @@ func (c *Client) GetAccountTransactions(ctx context.Context, account string, lim
 		})
 	}
 
-	logger.Logger.Debug("Account transactions retrieved", "count", len(summaries))
+	logger.Logger.DebugContext(ctx, "Account transactions retrieved", "count", len(summaries))
 	return summaries, nil
 }
 
 // GetEventsForAccount fetches effects (treated as events) for an account using shared page iteration.
 func (c *Client) GetEventsForAccount(ctx context.Context, account string, limit int) ([]EventSummary, error) {
-	logger.Logger.Debug("Fetching account events", "account", account)
+	logger.Logger.DebugContext(ctx, "Fetching account events", "account", account)
 
 	pageSize := normalizePageSize(limit)
 	req := horizonclient.EffectRequest{
@@ -809,7 +1117,7 @@ func (c *Client) GetEventsForAccount(ctx context.Context, account string, limit
 		max: limit,
 	}.collect()
 	if err != nil {
-		logger.Logger.Error("Failed to fetch account events", "account", account, "error", err)
+		logger.Logger.ErrorContext(ctx, "Failed to fetch account events", "account", account, "error", err)
 		return nil, errors.WrapRPCConnectionFailed(err)
 	}
 
@@ -821,13 +1129,13 @@ func (c *Client) GetEventsForAccount(ctx context.Context, account string, limit
 		})
 	}
 
-	logger.Logger.Debug("Account events retrieved", "count", len(out))
+	logger.Logger.DebugContext(ctx, "Account events retrieved", "count", len(out))
 	return out, nil
 }
 
 // GetAccounts fetches account records using shared page iteration.
 func (c *Client) GetAccounts(ctx context.Context, limit int) ([]AccountSummary, error) {
-	logger.Logger.Debug("Fetching accounts")
+	logger.Logger.DebugContext(ctx, "Fetching accounts")
 
 	pageSize := normalizePageSize(limit)
 	req := horizonclient.AccountsRequest{
@@ -848,8 +1156,8 @@ func (c *Client) GetAccounts(ctx context.Context, limit int) ([]AccountSummary,
 		max: limit,
 	}.collect()
 	if err != nil {
-		logger.Logger.Error("Failed to fetch accounts", "error", err)
-		return nil, errors.WrapRPCConnectionFailed(err)
+		logger.Logger.ErrorContext(ctx, "Failed to fetch accounts", "error", err)
+		return nil, horizonError(err, c.HorizonURL, "accounts")
 	}
 
 	out := make([]AccountSummary, 0, len(accountRecords))
@@ -861,10 +1169,31 @@ func (c *Client) GetAccounts(ctx context.Context, limit int) ([]AccountSummary,
 		})
 	}
 
-	logger.Logger.Debug("Accounts retrieved", "count", len(out))
+	logger.Logger.DebugContext(ctx, "Accounts retrieved", "count", len(out))
 	return out, nil
 }
 
+// GetAccount fetches an account's current state from Horizon.
+func (c *Client) GetAccount(ctx context.Context, address string) (*AccountSummary, error) {
+	logger.Logger.DebugContext(ctx, "Fetching account", "account", address)
+
+	if !c.isHealthy(c.HorizonURL) {
+		return nil, errors.WrapRPCConnectionFailed(fmt.Errorf("circuit breaker open for %s", c.HorizonURL))
+	}
+	acc, err := c.Horizon.AccountDetail(horizonclient.AccountRequest{AccountID: address})
+	if err != nil {
+		c.markFailure(c.HorizonURL)
+		return nil, horizonError(err, c.HorizonURL, "account "+address)
+	}
+	c.markSuccess(c.HorizonURL)
+
+	return &AccountSummary{
+		ID:            acc.AccountID,
+		Sequence:      acc.Sequence,
+		SubentryCount: acc.SubentryCount,
+	}, nil
+}
+
 func getTransactionStatus(tx hProtocol.Transaction) string {
 	if tx.Successful {
 		return "success"
@@ -893,6 +1222,15 @@ type SimulateTransactionResponse struct {
 			CpuInsns_ int64 `json:"cpu_insns,omitempty"`
 			MemBytes_ int64 `json:"mem_bytes,omitempty"`
 		} `json:"cost,omitempty"`
+		// Error is set when the transaction itself failed to simulate.
+		Error        string `json:"error,omitempty"`
+		LatestLedger uint32 `json:"latestLedger,omitempty"`
+		// RestorePreamble is set when archived footprint entries must be
+		// restored, by a separate transaction, before this one can run.
+		RestorePreamble *struct {
+			MinResourceFee  string `json:"minResourceFee"`
+			TransactionData string `json:"transactionData"`
+		} `json:"restorePreamble,omitempty"`
 	} `json:"result"`
 	Error *struct {
 		Code    int    `json:"code"`
@@ -902,11 +1240,9 @@ type SimulateTransactionResponse struct {
 
 // SimulateTransaction calls Soroban RPC simulateTransaction using a base64 TransactionEnvelope XDR.
 func (c *Client) SimulateTransaction(ctx context.Context, envelopeXdr string) (*SimulateTransactionResponse, error) {
-	if len(c.AltURLs) == 0 {
-		return nil, &AllNodesFailedError{}
-	}
+	attempts := c.endpointCount()
 	var failures []NodeFailure
-	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
+	for attempt := 0; attempt < attempts; attempt++ {
 		resp, err := c.simulateTransactionAttempt(ctx, envelopeXdr)
 		if err == nil {
 			c.markSuccess(c.SorobanURL)
@@ -917,14 +1253,14 @@ func (c *Client) SimulateTransaction(ctx context.Context, envelopeXdr string) (*
 
 		failures = append(failures, NodeFailure{URL: c.SorobanURL, Reason: err})
 
-		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying transaction simulation with fallback RPC...", "error", err)
+		if attempt < attempts-1 {
+			logger.Logger.WarnContext(ctx, "Retrying transaction simulation with fallback RPC...", "error", err)
 			if !c.rotateURL() {
 				break
 			}
 		}
 	}
-	return nil, &AllNodesFailedError{Failures: failures}
+	return nil, c.failoverError(failures)
 }
 
 func (c *Client) simulateTransactionAttempt(ctx context.Context, envelopeXdr string) (*SimulateTransactionResponse, error) {
@@ -932,17 +1268,10 @@ func (c *Client) simulateTransactionAttempt(ctx context.Context, envelopeXdr str
 	// Soroban JSON-RPC method and is not served by the Horizon REST API.
 	targetURL := c.SorobanURL
 	if targetURL == "" {
-		switch c.Network {
-		case Testnet:
-			targetURL = TestnetSorobanURL
-		case Mainnet:
-			targetURL = MainnetSorobanURL
-		case Futurenet:
-			targetURL = FuturenetSorobanURL
-		}
+		targetURL = c.Network.Config().SorobanRPCURL
 	}
 
-	logger.Logger.Debug("Simulating transaction (preflight)", "url", targetURL)
+	logger.Logger.DebugContext(ctx, "Simulating transaction (preflight)", "url", targetURL)
 
 	// Fail fast if circuit breaker is open for this Soroban endpoint.
 	if !c.isHealthy(targetURL) {
@@ -971,7 +1300,7 @@ func (c *Client) simulateTransactionAttempt(ctx context.Context, envelopeXdr str
 
 	resp, err := c.getHTTPClient().Do(req)
 	if err != nil {
-		return nil, errors.WrapRPCConnectionFailed(err)
+		return nil, requestError(err)
 	}
 	defer resp.Body.Close()
 
@@ -985,12 +1314,12 @@ func (c *Client) simulateTransactionAttempt(ctx context.Context, envelopeXdr str
 	}
 
 	var rpcResp SimulateTransactionResponse
-	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
-		return nil, errors.WrapUnmarshalFailed(err, string(respBytes))
+	if err := decodeRPCResponse(targetURL, resp.StatusCode, respBytes, &rpcResp); err != nil {
+		return nil, err
 	}
 
 	if rpcResp.Error != nil {
-		return nil, errors.WrapRPCError(targetURL, rpcResp.Error.Message, rpcResp.Error.Code)
+		return nil, jsonRPCError(targetURL, resp.StatusCode, rpcResp.Error.Code, rpcResp.Error.Message)
 	}
 
 	return &rpcResp, nil
@@ -998,11 +1327,9 @@ func (c *Client) simulateTransactionAttempt(ctx context.Context, envelopeXdr str
 
 // GetHealth checks the health of the Soroban RPC endpoint.
 func (c *Client) GetHealth(ctx context.Context) (*GetHealthResponse, error) {
-	if len(c.AltURLs) == 0 {
-		return nil, &AllNodesFailedError{}
-	}
+	attempts := c.endpointCount()
 	var failures []NodeFailure
-	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
+	for attempt := 0; attempt < attempts; attempt++ {
 		resp, err := c.getHealthAttempt(ctx)
 		if err == nil {
 			c.markSuccess(c.SorobanURL)
@@ -1012,20 +1339,20 @@ func (c *Client) GetHealth(ctx context.Context) (*GetHealthResponse, error) {
 		c.markFailure(c.SorobanURL)
 		failures = append(failures, NodeFailure{URL: c.SorobanURL, Reason: err})
 
-		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying GetHealth with fallback RPC...", "error", err)
+		if attempt < attempts-1 {
+			logger.Logger.WarnContext(ctx, "Retrying GetHealth with fallback RPC...", "error", err)
 			if !c.rotateURL() {
 				break
 			}
 			continue
 		}
 	}
-	return nil, &AllNodesFailedError{Failures: failures}
+	return nil, c.failoverError(failures)
 }
 
 func (c *Client) getHealthAttempt(ctx context.Context) (*GetHealthResponse, error) {
 	targetURL := c.SorobanURL
-	logger.Logger.Debug("Checking Soroban RPC health", "url", targetURL)
+	logger.Logger.DebugContext(ctx, "Checking Soroban RPC health", "url", targetURL)
 
 	// Fail fast if circuit breaker is open for this Soroban endpoint.
 	if !c.isHealthy(targetURL) {
@@ -1071,6 +1398,6 @@ func (c *Client) getHealthAttempt(ctx context.Context) (*GetHealthResponse, erro
 		return nil, errors.NewRPCError(errors.CodeRPCError, fmt.Errorf("rpc error from %s: %s (code %d)", targetURL, rpcResp.Error.Message, rpcResp.Error.Code))
 	}
 
-	logger.Logger.Info("Soroban RPC health check successful", "url", targetURL, "status", rpcResp.Result.Status)
+	logger.Logger.InfoContext(ctx, "Soroban RPC health check successful", "url", targetURL, "status", rpcResp.Result.Status)
 	return &rpcResp, nil
-}
\ No newline at end of file
+}
@@ -11,6 +11,8 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
 )
 
 func newRetryHTTPClient() *http.Client {
@@ -50,6 +52,7 @@ func TestSimulateTransactionRetriesOnRateLimit(t *testing.T) {
 		WithHorizonURL(server.URL),
 		WithSorobanURL(server.URL),
 		WithHTTPClient(newRetryHTTPClient()),
+		WithCacheEnabled(false),
 	)
 	if err != nil {
 		t.Fatalf("failed to build client: %v", err)
@@ -71,6 +74,7 @@ func TestSimulateTransactionRetriesOnRateLimit(t *testing.T) {
 
 func TestGetLedgerEntriesRetriesOnRateLimit(t *testing.T) {
 	var calls int32
+	key := contractDataKeyB64(t, "COUNTER", xdr.ContractDataDurabilityPersistent)
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if atomic.AddInt32(&calls, 1) == 1 {
@@ -84,7 +88,7 @@ func TestGetLedgerEntriesRetriesOnRateLimit(t *testing.T) {
 			ID:      1,
 		}
 		resp.Result.Entries = []LedgerEntryResult{{
-			Key: "AAA",
+			Key: key,
 			Xdr: "BBB",
 		}}
 		_ = json.NewEncoder(w).Encode(resp)
@@ -96,17 +100,18 @@ func TestGetLedgerEntriesRetriesOnRateLimit(t *testing.T) {
 		WithHorizonURL(server.URL),
 		WithSorobanURL(server.URL),
 		WithHTTPClient(newRetryHTTPClient()),
+		WithCacheEnabled(false),
 	)
 	if err != nil {
 		t.Fatalf("failed to build client: %v", err)
 	}
 
-	entries, err := client.GetLedgerEntries(context.Background(), []string{"AAA"})
+	entries, err := client.GetLedgerEntries(context.Background(), []string{key})
 	if err != nil {
 		t.Fatalf("expected retry to succeed, got error: %v", err)
 	}
 
-	if entries["AAA"] != "BBB" {
+	if entries[key] != "BBB" {
 		t.Fatalf("unexpected ledger entry: %v", entries)
 	}
 
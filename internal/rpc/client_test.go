@@ -5,7 +5,9 @@ package rpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -17,13 +19,16 @@ import (
 	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
 	effects "github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
 	operations "github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
 	"github.com/stellar/go-stellar-sdk/txnbuild"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockHorizonClient struct {
 	TransactionDetailFunc func(hash string) (hProtocol.Transaction, error)
 	LedgerDetailFunc      func(sequence uint32) (hProtocol.Ledger, error)
+	AccountDetailFunc     func(request horizonclient.AccountRequest) (hProtocol.Account, error)
 }
 
 func (m *mockHorizonClient) TransactionDetail(hash string) (hProtocol.Transaction, error) {
@@ -33,6 +38,9 @@ func (m *mockHorizonClient) AccountData(request horizonclient.AccountRequest) (h
 	return hProtocol.AccountData{}, nil
 }
 func (m *mockHorizonClient) AccountDetail(request horizonclient.AccountRequest) (hProtocol.Account, error) {
+	if m.AccountDetailFunc != nil {
+		return m.AccountDetailFunc(request)
+	}
 	return hProtocol.Account{}, nil
 }
 func (m *mockHorizonClient) Accounts(request horizonclient.AccountsRequest) (hProtocol.AccountsPage, error) {
@@ -277,6 +285,78 @@ func TestGetTransaction(t *testing.T) {
 	}
 }
 
+func TestGetAccount(t *testing.T) {
+	mock := &mockHorizonClient{AccountDetailFunc: func(request horizonclient.AccountRequest) (hProtocol.Account, error) {
+		if request.AccountID != "GABC" {
+			return hProtocol.Account{}, &horizonclient.Error{Problem: problem.P{Status: 404, Detail: "not found"}}
+		}
+		return hProtocol.Account{AccountID: "GABC", Sequence: 42, SubentryCount: 3}, nil
+	}}
+	c := newTestClient(mock)
+
+	acc, err := c.GetAccount(context.Background(), "GABC")
+	require.NoError(t, err)
+	assert.Equal(t, &AccountSummary{ID: "GABC", Sequence: 42, SubentryCount: 3}, acc)
+
+	_, err = c.GetAccount(context.Background(), "GMISSING")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "GMISSING")
+}
+
+func TestGetTransaction_HorizonErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		target error
+		substr string
+	}{
+		{"not found", 404, errs.ErrTransactionNotFound, "--auto-network"},
+		{"rate limited", 429, errs.ErrRateLimitExceeded, "try again later"},
+		{"server error", 500, errs.ErrRPCError, "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockHorizonClient{TransactionDetailFunc: func(hash string) (hProtocol.Transaction, error) {
+				return hProtocol.Transaction{}, &horizonclient.Error{Problem: problem.P{Status: tt.status, Detail: "boom"}}
+			}}
+			c := newTestClient(mock)
+			c.Network = Testnet
+
+			_, err := c.GetTransaction(context.Background(), "abc123")
+			assert.ErrorIs(t, err, tt.target)
+			assert.Contains(t, err.Error(), tt.substr)
+		})
+	}
+}
+
+func TestGetTransaction_NotFoundOnEveryEndpoint(t *testing.T) {
+	var hits int
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":404,"title":"Resource Missing"}`))
+	})
+	a := httptest.NewServer(notFound)
+	defer a.Close()
+	b := httptest.NewServer(notFound)
+	defer b.Close()
+
+	c, err := NewClient(WithNetwork(Testnet), WithAltURLs([]string{a.URL, b.URL}), WithCacheEnabled(false))
+	assert.NoError(t, err)
+
+	_, err = c.GetTransaction(context.Background(), "abc123")
+	var nf *errs.TransactionNotFoundError
+	assert.True(t, errors.As(err, &nf), "got %T: %v", err, err)
+	assert.Equal(t, "testnet", nf.Network)
+	assert.Equal(t, 2, hits, "every endpoint is asked, as retention differs between them")
+
+	// A missing transaction must not trip the circuit breaker
+	assert.Zero(t, c.failures[a.URL])
+	assert.Zero(t, c.failures[b.URL])
+}
+
 func TestGetTransaction_Timeout(t *testing.T) {
 	var testCtx context.Context
 	mock := &mockHorizonClient{
@@ -297,6 +377,25 @@ func TestGetTransaction_Timeout(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetTransaction_CancelledStopsFailover(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	mock := &mockHorizonClient{TransactionDetailFunc: func(hash string) (hProtocol.Transaction, error) {
+		<-release
+		return hProtocol.Transaction{}, nil
+	}}
+	c := newTestClient(mock)
+	c.AltURLs = []string{c.HorizonURL, "https://b.example"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetTransaction(ctx, "abc123")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "https://horizon-testnet.stellar.org", c.HorizonURL, "a cancelled call must not fail over")
+	assert.Zero(t, c.failures["https://horizon-testnet.stellar.org"])
+}
+
 func TestGetLedgerEntries_WithVerification(t *testing.T) {
 	// This test verifies that GetLedgerEntries properly validates returned entries
 	// Note: This is a unit test that would require a mock RPC server to fully test
@@ -329,6 +428,39 @@ func TestGetLedgerEntries_ResponseTooLarge(t *testing.T) {
 	assert.True(t, IsResponseTooLarge(err) || containsStr(err.Error(), "exceeded the server"))
 }
 
+func TestGetLedgerEntriesRaw_Pages(t *testing.T) {
+	var pageSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params [][]string `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		keys := req.Params[0]
+		pageSizes = append(pageSizes, len(keys))
+
+		var resp GetLedgerEntriesResponse
+		for _, k := range keys {
+			resp.Result.Entries = append(resp.Result.Entries, LedgerEntryResult{Key: k, Xdr: "entry-" + k})
+		}
+		resp.Result.LatestLedger = 100 + len(pageSizes)
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer server.Close()
+
+	c := &Client{SorobanURL: server.URL, Network: "custom", AltURLs: []string{server.URL}}
+	keys := make([]string, 2*MaxLedgerKeysPerRequest+50)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key%d", i)
+	}
+
+	resp, err := c.getLedgerEntriesRaw(context.Background(), keys)
+	require.NoError(t, err)
+	assert.Equal(t, []int{MaxLedgerKeysPerRequest, MaxLedgerKeysPerRequest, 50}, pageSizes)
+	require.Len(t, resp.Result.Entries, len(keys))
+	assert.Equal(t, "key449", resp.Result.Entries[449].Key)
+	assert.Equal(t, 103, resp.Result.LatestLedger)
+}
+
 func TestSimulateTransaction_ResponseTooLarge(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusRequestEntityTooLarge)
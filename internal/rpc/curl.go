@@ -0,0 +1,146 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// curlPrinter is where SetCurlOutput sends the curl equivalent of each
+// request. mu keeps the commands of concurrent clients from interleaving.
+type curlPrinter struct {
+	mu     sync.Mutex
+	out    io.Writer
+	unsafe bool
+}
+
+var defaultCurl atomic.Pointer[curlPrinter]
+
+// SetCurlOutput makes clients built afterwards write an equivalent curl
+// command to out for every HTTP request they send, retries included, so a
+// misbehaving call can be reproduced by hand. Credentials are redacted
+// unless unsafe is set. A nil out turns printing off. Clients given their
+// own HTTP client through WithHTTPClient are not affected.
+func SetCurlOutput(out io.Writer, unsafe bool) {
+	if out == nil {
+		defaultCurl.Store(nil)
+		return
+	}
+	defaultCurl.Store(&curlPrinter{out: out, unsafe: unsafe})
+}
+
+// curlTransport prints each request as it is sent. It sits below the
+// transport that adds the auth and User-Agent headers, so the command
+// carries exactly what goes on the wire.
+type curlTransport struct {
+	printer   *curlPrinter
+	transport http.RoundTripper
+}
+
+func (t *curlTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, err := bufferBody(req)
+	if err != nil {
+		return nil, err
+	}
+	cmd := FormatCurl(req, !t.printer.unsafe)
+	t.printer.mu.Lock()
+	fmt.Fprintln(t.printer.out, cmd)
+	t.printer.mu.Unlock()
+	return t.transport.RoundTrip(req)
+}
+
+// withCurl wraps transport in a curlTransport when SetCurlOutput is on.
+func withCurl(transport http.RoundTripper) http.RoundTripper {
+	if p := defaultCurl.Load(); p != nil {
+		return &curlTransport{printer: p, transport: transport}
+	}
+	return transport
+}
+
+const redacted = "REDACTED"
+
+// sensitiveNames are substrings of header and query parameter names whose
+// values FormatCurl redacts.
+var sensitiveNames = []string{"auth", "cookie", "token", "key", "secret", "password", "signature"}
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatCurl renders req as a curl command line, with every argument quoted
+// for a POSIX shell. With redact set, credentials in the URL and the values
+// of auth-like headers and query parameters are replaced with REDACTED. The
+// body is included when the request can re-read it through GetBody; the
+// request itself is never modified.
+func FormatCurl(req *http.Request, redact bool) string {
+	var b strings.Builder
+	b.WriteString("curl")
+	if req.Method != "" && req.Method != http.MethodGet {
+		b.WriteString(" -X " + req.Method)
+	}
+	b.WriteString(" " + shellQuote(curlURL(req.URL, redact)))
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range req.Header[name] {
+			if redact && isSensitive(name) {
+				v = redacted
+			}
+			b.WriteString(" -H " + shellQuote(name+": "+v))
+		}
+	}
+
+	if body, ok := peekBody(req); ok && len(body) > 0 {
+		b.WriteString(" --data-raw " + shellQuote(string(body)))
+	}
+	return b.String()
+}
+
+func curlURL(u *url.URL, redact bool) string {
+	if u == nil {
+		return ""
+	}
+	if !redact {
+		return u.String()
+	}
+	clean := *u
+	if clean.User != nil {
+		clean.User = url.User(redacted)
+	}
+	if query := clean.Query(); len(query) > 0 {
+		changed := false
+		for name := range query {
+			if isSensitive(name) {
+				query.Set(name, redacted)
+				changed = true
+			}
+		}
+		if changed {
+			clean.RawQuery = query.Encode()
+		}
+	}
+	return clean.String()
+}
+
+// shellQuote single-quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatCurl(t *testing.T) {
+	body := `{"jsonrpc":"2.0","method":"getHealth","params":{"note":"it's"}}`
+	req, err := http.NewRequest(http.MethodPost, "https://user:pw@rpc.example/soroban?apikey=abc&x=1", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-Api-Key", "k")
+
+	got := FormatCurl(req, true)
+	assert.Equal(t, `curl -X POST 'https://REDACTED@rpc.example/soroban?apikey=REDACTED&x=1'`+
+		` -H 'Authorization: REDACTED' -H 'Content-Type: application/json' -H 'X-Api-Key: REDACTED'`+
+		` --data-raw '{"jsonrpc":"2.0","method":"getHealth","params":{"note":"it'\''s"}}'`, got)
+	assert.NotContains(t, got, "secret")
+
+	unsafe := FormatCurl(req, false)
+	assert.Contains(t, unsafe, "user:pw@")
+	assert.Contains(t, unsafe, "'Authorization: Bearer secret'")
+
+	// The body is still there for the transport to send
+	sent, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(sent))
+}
+
+func TestFormatCurl_Get(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://horizon.example/ledgers/5", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "curl 'https://horizon.example/ledgers/5'", FormatCurl(req, true))
+}
+
+func TestClient_PrintCurl(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	SetCurlOutput(&out, false)
+	t.Cleanup(func() { SetCurlOutput(nil, false) })
+
+	c, err := NewClient(WithHorizonURL(srv.URL), WithOffline(false), WithToken("secret"), WithUserAgent("ua"))
+	require.NoError(t, err)
+	resp, err := c.getHTTPClient().Get(srv.URL + "/health")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "curl '"+srv.URL+"/health' -H 'Authorization: REDACTED' -H 'User-Agent: ua'\n", out.String())
+
+	SetCurlOutput(nil, false)
+	out.Reset()
+	c, err = NewClient(WithHorizonURL(srv.URL), WithOffline(false))
+	require.NoError(t, err)
+	resp, err = c.getHTTPClient().Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, out.String())
+}
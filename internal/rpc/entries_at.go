@@ -0,0 +1,65 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// EntriesAtLedger is the state of ledger entries as of a past ledger, as far
+// as Soroban RPC can tell. getLedgerEntries only serves the latest state, so
+// an entry modified after Ledger has no older value to return, and an entry
+// removed since cannot be told apart from one that never existed.
+type EntriesAtLedger struct {
+	Ledger uint32
+	// Entries maps base64 XDR LedgerKeys to the entries valid at Ledger.
+	Entries map[string]string
+	// Newer lists, sorted, the keys whose entry was last modified after
+	// Ledger. Their latest value stands in for the older one in Entries.
+	Newer []string
+	// LatestLedger is the ledger the RPC served the state from.
+	LatestLedger uint32
+}
+
+// GetLedgerEntriesAt fetches keys and pins them to ledger using each entry's
+// last-modified ledger. Entries are fetched directly from Soroban RPC,
+// bypassing the local cache, which does not record when an entry changed.
+func (c *Client) GetLedgerEntriesAt(ctx context.Context, keys []string, ledger uint32) (*EntriesAtLedger, error) {
+	at := &EntriesAtLedger{Ledger: ledger, Entries: make(map[string]string)}
+	if len(keys) == 0 {
+		return at, nil
+	}
+
+	logger.Logger.DebugContext(ctx, "Fetching ledger entries as of a ledger", "count", len(keys), "ledger", ledger, "url", c.SorobanURL)
+
+	var rpcResp *GetLedgerEntriesResponse
+	err := c.withSorobanFailover(ctx, func() error {
+		var err error
+		rpcResp, err = c.getLedgerEntriesRaw(ctx, keys)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	at.LatestLedger = uint32(rpcResp.Result.LatestLedger)
+	if at.LatestLedger > 0 && ledger > at.LatestLedger {
+		return nil, fmt.Errorf("ledger %d is newer than the RPC's latest ledger %d", ledger, at.LatestLedger)
+	}
+	for _, entry := range rpcResp.Result.Entries {
+		at.Entries[entry.Key] = entry.Xdr
+		if uint32(entry.LastModifiedLedger) > ledger {
+			at.Newer = append(at.Newer, entry.Key)
+		}
+	}
+	if err := VerifyLedgerEntries(keys, at.Entries); err != nil {
+		return nil, fmt.Errorf("ledger entry verification failed: %w", err)
+	}
+	sort.Strings(at.Newer)
+	return at, nil
+}
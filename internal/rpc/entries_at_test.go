@@ -0,0 +1,42 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLedgerEntriesAt(t *testing.T) {
+	stable := contractDataKeyB64(t, "ADMIN", xdr.ContractDataDurabilityPersistent)
+	changed := contractDataKeyB64(t, "BALANCE", xdr.ContractDataDurabilityPersistent)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"latestLedger":2000,"entries":[` +
+			`{"key":"` + stable + `","xdr":"AAAA","lastModifiedLedgerSeq":500},` +
+			`{"key":"` + changed + `","xdr":"BBBB","lastModifiedLedgerSeq":1500}]}}`))
+	}))
+	defer server.Close()
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+
+	at, err := client.GetLedgerEntriesAt(context.Background(), []string{stable, changed}, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1000), at.Ledger)
+	assert.Equal(t, uint32(2000), at.LatestLedger)
+	assert.Equal(t, map[string]string{stable: "AAAA", changed: "BBBB"}, at.Entries)
+	assert.Equal(t, []string{changed}, at.Newer)
+
+	at, err = client.GetLedgerEntriesAt(context.Background(), []string{stable, changed}, 1500)
+	require.NoError(t, err)
+	assert.Empty(t, at.Newer, "an entry modified at the ledger itself is current")
+
+	_, err = client.GetLedgerEntriesAt(context.Background(), []string{stable, changed}, 3000)
+	assert.ErrorContains(t, err, "newer than the RPC's latest ledger 2000")
+}
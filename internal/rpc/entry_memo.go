@@ -0,0 +1,81 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/base64"
+	"sync"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// EntryMemo is a run-scoped, in-memory store of ledger entries that can be
+// shared by several clients (e.g. the primary and compare networks of a
+// single debug run) so the same key is never requested twice. Entries are
+// keyed by network and ledger key; contract code is keyed by its hash alone
+// because identical code hashes have identical contents on every network.
+type EntryMemo struct {
+	mu      sync.Mutex
+	entries map[string]string
+	saved   int
+}
+
+// NewEntryMemo creates an empty memo.
+func NewEntryMemo() *EntryMemo {
+	return &EntryMemo{entries: make(map[string]string)}
+}
+
+// Seed records entries obtained without an RPC call (snapshots, transaction
+// metadata) so later fetches for the same network can reuse them.
+func (m *EntryMemo) Seed(network Network, entries map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k, v := range entries {
+		m.entries[memoKey(network, k)] = v
+	}
+}
+
+// Saved returns how many key requests were answered from the memo.
+func (m *EntryMemo) Saved() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.saved
+}
+
+// lookup splits keys into those already memoised and those still to fetch.
+func (m *EntryMemo) lookup(network Network, keys []string) (map[string]string, []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	found := make(map[string]string)
+	var missing []string
+	for _, k := range keys {
+		if v, ok := m.entries[memoKey(network, k)]; ok {
+			found[k] = v
+			m.saved++
+			continue
+		}
+		missing = append(missing, k)
+	}
+	return found, missing
+}
+
+func memoKey(network Network, keyB64 string) string {
+	if isContractCodeKey(keyB64) {
+		return "*|" + keyB64
+	}
+	return string(network) + "|" + keyB64
+}
+
+func isContractCodeKey(keyB64 string) bool {
+	raw, err := base64.StdEncoding.DecodeString(keyB64)
+	if err != nil {
+		return false
+	}
+	var key xdr.LedgerKey
+	if err := xdr.SafeUnmarshal(raw, &key); err != nil {
+		return false
+	}
+	return key.Type == xdr.LedgerEntryTypeContractCode
+}
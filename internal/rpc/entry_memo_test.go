@@ -0,0 +1,92 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoEntriesServer answers getLedgerEntries with a dummy entry for every
+// requested key and counts how many keys were requested in total.
+func echoEntriesServer(t *testing.T, requested *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params [][]string `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		resp := GetLedgerEntriesResponse{Jsonrpc: "2.0", ID: 1}
+		for _, k := range req.Params[0] {
+			atomic.AddInt32(requested, 1)
+			resp.Result.Entries = append(resp.Result.Entries, LedgerEntryResult{Key: k, Xdr: "ENTRY"})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func contractCodeKeyB64(t *testing.T) string {
+	t.Helper()
+	encoded, err := EncodeLedgerKey(xdr.LedgerKey{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.LedgerKeyContractCode{Hash: xdr.Hash{0xaa}},
+	})
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestEntryMemo_SharesAcrossClients(t *testing.T) {
+	var requested int32
+	server := echoEntriesServer(t, &requested)
+	defer server.Close()
+
+	dataKey := contractDataKeyB64(t, "BALANCE", xdr.ContractDataDurabilityPersistent)
+	codeKey := contractCodeKeyB64(t)
+	memo := NewEntryMemo()
+
+	newClient := func(net Network) *Client {
+		c, err := NewClient(WithNetwork(net), WithSorobanURL(server.URL), WithCacheEnabled(false), WithEntryMemo(memo))
+		require.NoError(t, err)
+		return c
+	}
+	testnet, futurenet := newClient(Testnet), newClient(Futurenet)
+
+	_, err := testnet.GetLedgerEntries(context.Background(), []string{dataKey, codeKey})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&requested))
+
+	// Same network: nothing is refetched.
+	entries, err := testnet.GetLedgerEntries(context.Background(), []string{dataKey, codeKey})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&requested))
+
+	// Other network: contract code is reused, contract data is fetched.
+	_, err = futurenet.GetLedgerEntries(context.Background(), []string{dataKey, codeKey})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&requested))
+
+	assert.Equal(t, 3, memo.Saved())
+}
+
+func TestEntryMemo_Seed(t *testing.T) {
+	memo := NewEntryMemo()
+	memo.Seed(Testnet, map[string]string{"k": "v"})
+
+	found, missing := memo.lookup(Testnet, []string{"k", "other"})
+	assert.Equal(t, map[string]string{"k": "v"}, found)
+	assert.Equal(t, []string{"other"}, missing)
+
+	_, missing = memo.lookup(Mainnet, []string{"k"})
+	assert.Equal(t, []string{"k"}, missing)
+}
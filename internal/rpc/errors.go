@@ -0,0 +1,83 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+)
+
+// Errors the client returns, for callers to test with errors.Is:
+//
+//   - ErrNotFound: the transaction, ledger or account does not exist on the
+//     endpoint.
+//   - ErrRateLimited: the endpoint kept rejecting requests with HTTP 429.
+//   - ErrNetwork: the endpoint could not be reached or did not answer in
+//     time.
+//   - ErrDecode: the endpoint answered with something that is not a valid
+//     response.
+//
+// An error response from the endpoint is an *RPCError, which carries the
+// HTTP status; use errors.As to inspect it. These are the internal/errors
+// sentinels, so wrapped errors keep matching them.
+var (
+	ErrNotFound    = errors.ErrNotFound
+	ErrRateLimited = errors.ErrRateLimitExceeded
+	ErrNetwork     = errors.ErrNetwork
+	ErrDecode      = errors.ErrUnmarshalFailed
+)
+
+// RPCError is an error response from Horizon or Soroban RPC.
+type RPCError = errors.RPCError
+
+// requestError reports a request that got no response to decode. The retry
+// transport has already classified rate limiting, error statuses and
+// timeouts; anything else is a connection failure.
+func requestError(err error) error {
+	if errors.Is(err, ErrRateLimited) || errors.Is(err, errors.ErrRPCError) || errors.Is(err, ErrNetwork) ||
+		errors.Is(err, errors.ErrRPCResponseTooLarge) {
+		return err
+	}
+	return errors.WrapRPCConnectionFailed(err)
+}
+
+// horizonError maps a failed Horizon request for what onto the errors above.
+func horizonError(err error, url, what string) error {
+	var hErr *horizonclient.Error
+	if !errors.As(err, &hErr) {
+		return requestError(err)
+	}
+	switch hErr.Problem.Status {
+	case http.StatusNotFound:
+		return errors.WrapNotFound(what)
+	case http.StatusRequestEntityTooLarge:
+		return errors.WrapRPCResponseTooLarge(url)
+	case http.StatusTooManyRequests:
+		return errors.WrapRateLimitExceeded()
+	default:
+		return errors.WrapRPCStatusError(url, hErr.Problem.Status, hErr.Problem.Detail)
+	}
+}
+
+// decodeRPCResponse decodes a Soroban RPC response body into out. A body
+// that is not JSON-RPC is reported by the HTTP status when that is an
+// error, which tells more than the unparseable body does.
+func decodeRPCResponse(url string, status int, body []byte, out interface{}) error {
+	if err := json.Unmarshal(body, out); err != nil {
+		if status >= http.StatusBadRequest {
+			return errors.WrapRPCStatusError(url, status, http.StatusText(status))
+		}
+		return errors.WrapUnmarshalFailed(err, string(body))
+	}
+	return nil
+}
+
+// jsonRPCError reports a JSON-RPC error object returned with the given HTTP
+// status.
+func jsonRPCError(url string, status, code int, message string) error {
+	return &RPCError{URL: url, StatusCode: status, Code: code, Message: message}
+}
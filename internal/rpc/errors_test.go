@@ -0,0 +1,128 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllNodesFailedError_MatchesEachCause(t *testing.T) {
+	err := &AllNodesFailedError{Failures: []NodeFailure{
+		{URL: "https://a", Reason: horizonError(&horizonclient.Error{Problem: problem.P{Status: 429}}, "https://a", "x")},
+		{URL: "https://b", Reason: horizonError(errors.New("connection refused"), "https://b", "x")},
+	}}
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.ErrorIs(t, err, ErrNetwork)
+	assert.NotErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, &AllNodesFailedError{}, ErrNetwork)
+}
+
+func TestHorizonError(t *testing.T) {
+	problemErr := func(status int) error {
+		return &horizonclient.Error{Problem: problem.P{Status: status, Detail: "detail"}}
+	}
+
+	err := horizonError(problemErr(404), "https://horizon", "account GABC")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "account GABC not found")
+
+	assert.ErrorIs(t, horizonError(problemErr(429), "https://horizon", "x"), ErrRateLimited)
+
+	err = horizonError(problemErr(500), "https://horizon", "x")
+	var rpcErr *RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, 500, rpcErr.StatusCode)
+	assert.Equal(t, "https://horizon", rpcErr.URL)
+	assert.NotErrorIs(t, err, ErrNetwork)
+
+	err = horizonError(errors.New("connection refused"), "https://horizon", "x")
+	assert.ErrorIs(t, err, ErrNetwork)
+	assert.False(t, errors.As(err, &rpcErr))
+}
+
+func TestSorobanErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		check  func(t *testing.T, err error)
+	}{
+		{"json-rpc error", http.StatusOK, `{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"bad range"}}`, func(t *testing.T, err error) {
+			var rpcErr *RPCError
+			require.ErrorAs(t, err, &rpcErr)
+			assert.Equal(t, -32600, rpcErr.Code)
+			assert.Equal(t, http.StatusOK, rpcErr.StatusCode)
+			assert.Equal(t, "bad range", rpcErr.Message)
+		}},
+		{"error status without json", http.StatusBadGateway, `<html>bad gateway</html>`, func(t *testing.T, err error) {
+			var rpcErr *RPCError
+			require.ErrorAs(t, err, &rpcErr)
+			assert.Equal(t, http.StatusBadGateway, rpcErr.StatusCode)
+			assert.NotErrorIs(t, err, ErrDecode)
+		}},
+		{"undecodable body", http.StatusOK, `not json`, func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, ErrDecode)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+			_, err := client.GetEvents(context.Background(), 5, nil)
+			require.Error(t, err)
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestRetryTransport_ExhaustedStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		check  func(t *testing.T, err error)
+	}{
+		{http.StatusTooManyRequests, func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, ErrRateLimited)
+			assert.NotErrorIs(t, err, ErrNetwork)
+		}},
+		{http.StatusServiceUnavailable, func(t *testing.T, err error) {
+			var rpcErr *RPCError
+			require.ErrorAs(t, err, &rpcErr)
+			assert.Equal(t, http.StatusServiceUnavailable, rpcErr.StatusCode)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			cfg := DefaultRetryConfig()
+			cfg.MaxRetries = 1
+			cfg.InitialBackoff = time.Millisecond
+			client := &http.Client{Transport: NewRetryTransport(cfg, http.DefaultTransport)}
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+			_, err = client.Do(req)
+			require.Error(t, err)
+			tt.check(t, requestError(err))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// getEventsPageLimit is the page size requested from getEvents; pages are
+// followed until one comes back short.
+const getEventsPageLimit = 1000
+
+// EventFilter narrows getEvents results, mirroring the Soroban RPC filter
+// object. Empty fields match everything.
+type EventFilter struct {
+	Type        string     `json:"type,omitempty"` // "contract", "system" or "diagnostic"
+	ContractIDs []string   `json:"contractIds,omitempty"`
+	Topics      [][]string `json:"topics,omitempty"` // base64 ScVal segments, "*" as a wildcard
+}
+
+// ChainEvent is an event as recorded on the ledger and returned by getEvents.
+// Topics and Value are base64-encoded XDR ScVals.
+type ChainEvent struct {
+	Type                     string   `json:"type"`
+	Ledger                   uint32   `json:"ledger"`
+	LedgerClosedAt           string   `json:"ledgerClosedAt"`
+	ContractID               string   `json:"contractId"`
+	ID                       string   `json:"id"`
+	Topic                    []string `json:"topic"`
+	Value                    string   `json:"value"`
+	InSuccessfulContractCall bool     `json:"inSuccessfulContractCall"`
+	TxHash                   string   `json:"txHash"`
+}
+
+type getEventsPagination struct {
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit"`
+}
+
+type getEventsParams struct {
+	StartLedger uint32              `json:"startLedger,omitempty"`
+	EndLedger   uint32              `json:"endLedger,omitempty"`
+	Filters     []EventFilter       `json:"filters"`
+	Pagination  getEventsPagination `json:"pagination"`
+}
+
+type GetEventsRequest struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Method  string          `json:"method"`
+	Params  getEventsParams `json:"params"`
+}
+
+type GetEventsResponse struct {
+	Jsonrpc string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Result  struct {
+		Events       []ChainEvent `json:"events"`
+		LatestLedger uint32       `json:"latestLedger"`
+		Cursor       string       `json:"cursor"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// GetEvents fetches the events emitted in ledger that match filters, using
+// Soroban RPC getEvents and following pagination to the end of the ledger.
+// A ledger outside the endpoint's retention window is fetched from the
+// archival endpoint, when one is configured.
+func (c *Client) GetEvents(ctx context.Context, ledger uint32, filters []EventFilter) ([]ChainEvent, error) {
+	if ledger == 0 {
+		return nil, errors.WrapValidationError("getEvents needs the ledger the transaction was included in")
+	}
+	if filters == nil {
+		filters = []EventFilter{}
+	}
+
+	var events []ChainEvent
+	err := c.withArchivalFallback(ctx, "getEvents", func(cl *Client) error {
+		var err error
+		events, err = cl.getEvents(ctx, ledger, filters)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (c *Client) getEvents(ctx context.Context, ledger uint32, filters []EventFilter) ([]ChainEvent, error) {
+	var events []ChainEvent
+	err := c.withSorobanFailover(ctx, func() error {
+		events = nil
+		params := getEventsParams{
+			StartLedger: ledger,
+			EndLedger:   ledger + 1,
+			Filters:     filters,
+			Pagination:  getEventsPagination{Limit: getEventsPageLimit},
+		}
+		for {
+			resp, err := c.getEventsAttempt(ctx, params)
+			if err != nil {
+				return err
+			}
+			for _, e := range resp.Result.Events {
+				if e.Ledger == ledger {
+					events = append(events, e)
+				}
+			}
+			if len(resp.Result.Events) < getEventsPageLimit || resp.Result.Cursor == "" {
+				return nil
+			}
+			// A cursor replaces the ledger range on follow-up pages
+			params.StartLedger, params.EndLedger = 0, 0
+			params.Pagination.Cursor = resp.Result.Cursor
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Logger.InfoContext(ctx, "Events fetched", "ledger", ledger, "count", len(events), "url", c.SorobanURL)
+	return events, nil
+}
+
+func (c *Client) getEventsAttempt(ctx context.Context, params getEventsParams) (*GetEventsResponse, error) {
+	targetURL := c.SorobanURL
+	logger.Logger.DebugContext(ctx, "Fetching events", "ledger", params.StartLedger, "cursor", params.Pagination.Cursor, "url", targetURL)
+
+	// Fail fast if circuit breaker is open for this Soroban endpoint.
+	if !c.isHealthy(targetURL) {
+		return nil, errors.WrapRPCConnectionFailed(fmt.Errorf("circuit breaker open for %s", targetURL))
+	}
+
+	bodyBytes, err := json.Marshal(GetEventsRequest{
+		Jsonrpc: "2.0",
+		ID:      1,
+		Method:  "getEvents",
+		Params:  params,
+	})
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		return nil, errors.WrapRPCResponseTooLarge(targetURL)
+	}
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "body read error")
+	}
+
+	var rpcResp GetEventsResponse
+	if err := decodeRPCResponse(targetURL, resp.StatusCode, respBytes, &rpcResp); err != nil {
+		return nil, err
+	}
+	if rpcResp.Error != nil {
+		err := jsonRPCError(targetURL, resp.StatusCode, rpcResp.Error.Code, rpcResp.Error.Message)
+		return nil, retentionError(err, params.StartLedger, targetURL)
+	}
+	return &rpcResp, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEvents_FollowsCursorAndKeepsLedger(t *testing.T) {
+	var requests []getEventsParams
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GetEventsRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getEvents", req.Method)
+		requests = append(requests, req.Params)
+
+		var resp GetEventsResponse
+		if req.Params.Pagination.Cursor == "" {
+			// A full page, so the client must ask for the next one
+			resp.Result.Events = make([]ChainEvent, getEventsPageLimit)
+			for i := range resp.Result.Events {
+				resp.Result.Events[i] = ChainEvent{Type: "contract", Ledger: 100, TxHash: "aa"}
+			}
+			resp.Result.Cursor = "page-2"
+		} else {
+			resp.Result.Events = []ChainEvent{
+				{Type: "contract", Ledger: 100, TxHash: "bb"},
+				{Type: "contract", Ledger: 101, TxHash: "cc"},
+			}
+			resp.Result.Cursor = "page-3"
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer server.Close()
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+
+	events, err := client.GetEvents(context.Background(), 100, nil)
+	require.NoError(t, err)
+	assert.Len(t, events, getEventsPageLimit+1)
+	assert.Equal(t, "bb", events[len(events)-1].TxHash)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, uint32(100), requests[0].StartLedger)
+	assert.Equal(t, uint32(101), requests[0].EndLedger)
+	assert.NotNil(t, requests[0].Filters)
+	assert.Zero(t, requests[1].StartLedger)
+	assert.Equal(t, "page-2", requests[1].Pagination.Cursor)
+}
+
+func TestGetEvents_RPCError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"startLedger must be within the ledger range"}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+
+	_, err := client.GetEvents(context.Background(), 5, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ledger range")
+}
+
+func TestGetEvents_RequiresLedger(t *testing.T) {
+	_, err := (&Client{}).GetEvents(context.Background(), 0, nil)
+	assert.Error(t, err)
+}
+
+func TestGetEvents_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request should be sent once the context is cancelled")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+	_, err := client.GetEvents(ctx, 100, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -5,8 +5,12 @@ package rpc
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -48,3 +52,32 @@ func TestClient_GetTransaction_Failover_Logic(t *testing.T) {
 	assert.Equal(t, 2, len(fallbackErr.Failures), "Should have recorded 2 failures")
 	assert.Contains(t, err.Error(), "all RPC endpoints failed")
 }
+
+func TestClient_WithoutAlternatesAttemptsOnce(t *testing.T) {
+	calls := 0
+	client := newTestClient(&mockHorizonClient{TransactionDetailFunc: func(hash string) (hProtocol.Transaction, error) {
+		calls++
+		return hProtocol.Transaction{}, &horizonclient.Error{Problem: problem.P{Status: http.StatusNotFound}}
+	}})
+	client.AltURLs = nil
+	assert.Equal(t, 1, client.endpointCount())
+
+	_, err := client.GetTransaction(context.Background(), "abc")
+	assert.ErrorIs(t, err, ErrNotFound, "the endpoint's own error, not an empty failover list")
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_RotationMovesSharedSorobanURL(t *testing.T) {
+	// One URL per provider serving both APIs: Soroban fails over with Horizon
+	client := NewClientWithURLsOption([]string{"http://rpc1.com", "http://rpc2.com"}, Testnet, "")
+	client.SorobanURL = "http://rpc1.com"
+	assert.True(t, client.rotateURL())
+	assert.Equal(t, "http://rpc2.com", client.SorobanURL)
+
+	// A separate Soroban endpoint is not one of the alternates
+	client = NewClientWithURLsOption([]string{"http://rpc1.com", "http://rpc2.com"}, Testnet, "")
+	client.SorobanURL = "http://soroban.com"
+	assert.True(t, client.rotateURL())
+	assert.Equal(t, "http://rpc2.com", client.HorizonURL)
+	assert.Equal(t, "http://soroban.com", client.SorobanURL)
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// FeeDistribution summarises the inclusion fees, in stroops, bid by the
+// transactions of the last LedgerCount ledgers. Soroban RPC sends the
+// amounts as decimal strings.
+type FeeDistribution struct {
+	Max              string `json:"max"`
+	Min              string `json:"min"`
+	Mode             string `json:"mode"`
+	P10              string `json:"p10"`
+	P50              string `json:"p50"`
+	P90              string `json:"p90"`
+	P99              string `json:"p99"`
+	TransactionCount string `json:"transactionCount"`
+	LedgerCount      uint32 `json:"ledgerCount"`
+}
+
+// FeeStats is the result of Soroban RPC getFeeStats.
+type FeeStats struct {
+	SorobanInclusionFee FeeDistribution `json:"sorobanInclusionFee"`
+	InclusionFee        FeeDistribution `json:"inclusionFee"`
+	LatestLedger        uint32          `json:"latestLedger"`
+}
+
+type GetFeeStatsResponse struct {
+	Jsonrpc string   `json:"jsonrpc"`
+	ID      int      `json:"id"`
+	Result  FeeStats `json:"result"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// GetFeeStats fetches the recent inclusion fee distributions from Soroban
+// RPC getFeeStats.
+func (c *Client) GetFeeStats(ctx context.Context) (*FeeStats, error) {
+	var stats *FeeStats
+	err := c.withSorobanFailover(ctx, func() error {
+		resp, err := c.getFeeStatsAttempt(ctx)
+		if err != nil {
+			return err
+		}
+		stats = &resp.Result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (c *Client) getFeeStatsAttempt(ctx context.Context) (*GetFeeStatsResponse, error) {
+	targetURL := c.SorobanURL
+	logger.Logger.DebugContext(ctx, "Fetching fee stats", "url", targetURL)
+
+	// Fail fast if circuit breaker is open for this Soroban endpoint.
+	if !c.isHealthy(targetURL) {
+		return nil, errors.WrapRPCConnectionFailed(fmt.Errorf("circuit breaker open for %s", targetURL))
+	}
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getFeeStats",
+	})
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "body read error")
+	}
+
+	var rpcResp GetFeeStatsResponse
+	if err := decodeRPCResponse(targetURL, resp.StatusCode, respBytes, &rpcResp); err != nil {
+		return nil, err
+	}
+	if rpcResp.Error != nil {
+		return nil, jsonRPCError(targetURL, resp.StatusCode, rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+	return &rpcResp, nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFeeStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getFeeStats", req["method"])
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{
+			"sorobanInclusionFee":{"max":"5000","min":"100","mode":"100","p10":"100","p50":"120","p90":"900","p99":"4000","transactionCount":"42","ledgerCount":50},
+			"inclusionFee":{"max":"200","min":"100","mode":"100","p50":"100","p90":"150","transactionCount":"900","ledgerCount":10},
+			"latestLedger":4242}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+	stats, err := client.GetFeeStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "900", stats.SorobanInclusionFee.P90)
+	assert.Equal(t, uint32(50), stats.SorobanInclusionFee.LedgerCount)
+	assert.Equal(t, "150", stats.InclusionFee.P90)
+	assert.Equal(t, uint32(4242), stats.LatestLedger)
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Footprint is the set of ledger keys a transaction reads and writes, as
+// base64 XDR LedgerKeys.
+type Footprint struct {
+	ReadOnly  []string `json:"readOnly"`
+	ReadWrite []string `json:"readWrite"`
+	// Restore lists the archived entries that must be restored, by a
+	// separate transaction, before this one can run. It is empty when
+	// nothing in the footprint is archived.
+	Restore []string `json:"restore,omitempty"`
+}
+
+// Keys returns every key of the footprint, read-only first.
+func (f *Footprint) Keys() []string {
+	keys := make([]string, 0, len(f.ReadOnly)+len(f.ReadWrite))
+	keys = append(keys, f.ReadOnly...)
+	return append(keys, f.ReadWrite...)
+}
+
+// DiscoverFootprint finds the footprint of a transaction that has no result
+// meta, such as one built locally and never submitted, by preflighting
+// envelopeXdr with Soroban RPC simulateTransaction. The keys are those the
+// transaction would touch against the current ledger.
+func (c *Client) DiscoverFootprint(ctx context.Context, envelopeXdr string) (*Footprint, error) {
+	sim, err := c.SimulateTransaction(ctx, envelopeXdr)
+	if err != nil {
+		return nil, err
+	}
+	if sim.Result.TransactionData == "" {
+		if sim.Result.Error != "" {
+			return nil, fmt.Errorf("%w: simulateTransaction found no footprint: %s", errors.ErrSimulationFailed, sim.Result.Error)
+		}
+		return &Footprint{}, nil
+	}
+
+	var data xdr.SorobanTransactionData
+	if err := xdr.SafeUnmarshalBase64(sim.Result.TransactionData, &data); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "simulateTransaction transactionData")
+	}
+	fp := &Footprint{}
+	if fp.ReadOnly, err = marshalLedgerKeys(data.Resources.Footprint.ReadOnly); err != nil {
+		return nil, err
+	}
+	if fp.ReadWrite, err = marshalLedgerKeys(data.Resources.Footprint.ReadWrite); err != nil {
+		return nil, err
+	}
+
+	if preamble := sim.Result.RestorePreamble; preamble != nil && preamble.TransactionData != "" {
+		var restore xdr.SorobanTransactionData
+		if err := xdr.SafeUnmarshalBase64(preamble.TransactionData, &restore); err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, "simulateTransaction restorePreamble")
+		}
+		if fp.Restore, err = marshalLedgerKeys(restore.Resources.Footprint.ReadWrite); err != nil {
+			return nil, err
+		}
+	}
+	return fp, nil
+}
+
+func marshalLedgerKeys(keys []xdr.LedgerKey) ([]string, error) {
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		b64, err := xdr.MarshalBase64(k)
+		if err != nil {
+			return nil, errors.WrapMarshalFailed(err)
+		}
+		out = append(out, b64)
+	}
+	return out, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func footprintTestKey(b byte) xdr.LedgerKey {
+	return xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractCode, ContractCode: &xdr.LedgerKeyContractCode{Hash: xdr.Hash{b}}}
+}
+
+func footprintTestData(t *testing.T, readOnly, readWrite []xdr.LedgerKey) string {
+	t.Helper()
+	b64, err := xdr.MarshalBase64(xdr.SorobanTransactionData{
+		Resources: xdr.SorobanResources{Footprint: xdr.LedgerFootprint{ReadOnly: readOnly, ReadWrite: readWrite}},
+	})
+	require.NoError(t, err)
+	return b64
+}
+
+func simulateServer(t *testing.T, result map[string]interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "simulateTransaction", req.Method)
+		assert.Equal(t, []interface{}{"ENV"}, req.Params)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDiscoverFootprint(t *testing.T) {
+	ro, rw, archived := footprintTestKey(1), footprintTestKey(2), footprintTestKey(3)
+	server := simulateServer(t, map[string]interface{}{
+		"transactionData": footprintTestData(t, []xdr.LedgerKey{ro}, []xdr.LedgerKey{rw}),
+		"restorePreamble": map[string]string{
+			"minResourceFee":  "100",
+			"transactionData": footprintTestData(t, nil, []xdr.LedgerKey{archived}),
+		},
+	})
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+	fp, err := client.DiscoverFootprint(context.Background(), "ENV")
+	require.NoError(t, err)
+
+	b64 := func(k xdr.LedgerKey) string {
+		s, err := xdr.MarshalBase64(k)
+		require.NoError(t, err)
+		return s
+	}
+	assert.Equal(t, []string{b64(ro)}, fp.ReadOnly)
+	assert.Equal(t, []string{b64(rw)}, fp.ReadWrite)
+	assert.Equal(t, []string{b64(archived)}, fp.Restore)
+	assert.Equal(t, []string{b64(ro), b64(rw)}, fp.Keys())
+}
+
+func TestDiscoverFootprint_SimulationError(t *testing.T) {
+	server := simulateServer(t, map[string]interface{}{"error": "HostError: Error(Contract, #1)"})
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+	_, err := client.DiscoverFootprint(context.Background(), "ENV")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrSimulationFailed))
+	assert.Contains(t, err.Error(), "Error(Contract, #1)")
+}
+
+func TestDiscoverFootprint_Empty(t *testing.T) {
+	server := simulateServer(t, map[string]interface{}{"latestLedger": 10})
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+	fp, err := client.DiscoverFootprint(context.Background(), "ENV")
+	require.NoError(t, err)
+	assert.Empty(t, fp.Keys())
+	assert.Empty(t, fp.Restore)
+}
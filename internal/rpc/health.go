@@ -0,0 +1,144 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Endpoint kinds reported by the probes.
+const (
+	EndpointHorizon = "horizon"
+	EndpointSoroban = "soroban"
+)
+
+// EndpointStatus is the outcome of probing a single RPC endpoint.
+type EndpointStatus struct {
+	Network      string `json:"network"`
+	Kind         string `json:"kind"`
+	URL          string `json:"url"`
+	Reachable    bool   `json:"reachable"`
+	LatencyMs    int64  `json:"latency_ms"`
+	LatestLedger uint32 `json:"latest_ledger,omitempty"`
+	Status       string `json:"status,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// ProbeHorizon requests the Horizon root document and reports its latest
+// ingested ledger. Unlike the regular client calls it never fails over, so
+// the result describes exactly the configured Horizon URL.
+func (c *Client) ProbeHorizon(ctx context.Context) EndpointStatus {
+	status := EndpointStatus{Network: string(c.Network), Kind: EndpointHorizon, URL: c.HorizonURL}
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.HorizonURL, nil)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	status.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		status.Error = fmt.Sprintf("unexpected HTTP status %d", resp.StatusCode)
+		return status
+	}
+
+	var root struct {
+		HistoryLatestLedger uint32 `json:"history_latest_ledger"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&root); err != nil {
+		status.Error = fmt.Sprintf("invalid Horizon root response: %v", err)
+		return status
+	}
+
+	status.Reachable = true
+	status.Status = "healthy"
+	status.LatestLedger = root.HistoryLatestLedger
+	return status
+}
+
+// ProbeSoroban calls getHealth on the configured Soroban RPC URL, falling
+// back to getLatestLedger for servers whose health response omits the
+// ledger sequence. Like ProbeHorizon it does not fail over.
+func (c *Client) ProbeSoroban(ctx context.Context) EndpointStatus {
+	status := EndpointStatus{Network: string(c.Network), Kind: EndpointSoroban, URL: c.SorobanURL}
+
+	start := time.Now()
+	health, err := c.getHealthAttempt(ctx)
+	status.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Reachable = true
+	status.Status = health.Result.Status
+	status.LatestLedger = health.Result.LatestLedger
+	if status.LatestLedger == 0 {
+		if seq, err := c.getLatestLedgerSequence(ctx); err == nil {
+			status.LatestLedger = seq
+		}
+	}
+	return status
+}
+
+// getLatestLedgerSequence calls the Soroban getLatestLedger method.
+func (c *Client) getLatestLedgerSequence(ctx context.Context) (uint32, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getLatestLedger",
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.SorobanURL, bytes.NewBuffer(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	var rpcResp struct {
+		Result struct {
+			Sequence uint32 `json:"sequence"`
+		} `json:"result"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error,omitempty"`
+	}
+	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
+		return 0, err
+	}
+	if rpcResp.Error != nil {
+		return 0, fmt.Errorf("rpc error: %s (code %d)", rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	return rpcResp.Result.Sequence, nil
+}
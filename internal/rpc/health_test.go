@@ -0,0 +1,64 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeHorizon(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"history_latest_ledger": 4242, "core_latest_ledger": 4243}`))
+	}))
+	defer server.Close()
+
+	client := &Client{Network: Testnet, HorizonURL: server.URL}
+	status := client.ProbeHorizon(context.Background())
+
+	assert.True(t, status.Reachable)
+	assert.Equal(t, EndpointHorizon, status.Kind)
+	assert.Equal(t, uint32(4242), status.LatestLedger)
+	assert.Empty(t, status.Error)
+}
+
+func TestProbeHorizon_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	status := (&Client{HorizonURL: server.URL}).ProbeHorizon(context.Background())
+
+	assert.False(t, status.Reachable)
+	assert.Contains(t, status.Error, "502")
+}
+
+func TestProbeSoroban_FallsBackToLatestLedger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req.Method {
+		case "getHealth":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"healthy"}}`))
+		case "getLatestLedger":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"sequence":777}}`))
+		}
+	}))
+	defer server.Close()
+
+	status := (&Client{Network: Testnet, SorobanURL: server.URL}).ProbeSoroban(context.Background())
+
+	assert.True(t, status.Reachable)
+	assert.Equal(t, "healthy", status.Status)
+	assert.Equal(t, uint32(777), status.LatestLedger)
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// idempotentMethods are the Soroban JSON-RPC methods that only read state.
+// Repeating one of them cannot change the ledger, so they are safe to retry.
+// simulateTransaction is among them: it runs a transaction without
+// submitting it. sendTransaction is deliberately absent.
+var idempotentMethods = map[string]bool{
+	"getEvents":           true,
+	"getFeeStats":         true,
+	"getHealth":           true,
+	"getLatestLedger":     true,
+	"getLedgerEntries":    true,
+	"getLedgers":          true,
+	"getNetwork":          true,
+	"getTransaction":      true,
+	"getTransactions":     true,
+	"getVersionInfo":      true,
+	"simulateTransaction": true,
+}
+
+// IsIdempotentMethod reports whether the JSON-RPC method only reads state
+// and may therefore be retried. Unknown methods are assumed to have side
+// effects.
+func IsIdempotentMethod(method string) bool {
+	return idempotentMethods[method]
+}
+
+// isIdempotentRequest classifies an HTTP request for RetryConfig's
+// IdempotentOnly. GET and HEAD requests, which Horizon reads use, are
+// idempotent; a POST is when its body is a JSON-RPC call, or a batch of
+// calls, to idempotent methods only. The body is only peeked, never
+// consumed.
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	if req.Method != http.MethodPost {
+		return false
+	}
+
+	body, ok := peekBody(req)
+	if !ok {
+		return false
+	}
+	var calls []struct {
+		Method string `json:"method"`
+	}
+	trimmed := bytes.TrimSpace(body)
+	if !bytes.HasPrefix(trimmed, []byte("[")) {
+		trimmed = append(append([]byte("["), trimmed...), ']')
+	}
+	if err := json.Unmarshal(trimmed, &calls); err != nil || len(calls) == 0 {
+		return false
+	}
+	for _, call := range calls {
+		if !IsIdempotentMethod(strings.TrimSpace(call.Method)) {
+			return false
+		}
+	}
+	return true
+}
+
+// peekBody returns a copy of the request's body without consuming it. Only
+// a body that can be re-read through GetBody is peeked; bufferBody gives
+// any other request one.
+func peekBody(req *http.Request) ([]byte, bool) {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return nil, false
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	defer rc.Close()
+	body, err := io.ReadAll(rc)
+	return body, err == nil
+}
+
+// bufferBody returns req unchanged when its body is absent or can already
+// be re-read. Otherwise it reads the body into memory and returns a clone
+// that carries the buffered copy, leaving req itself untouched as a
+// RoundTripper must. A body that cannot be read fails the request rather
+// than sending whatever bytes arrived before the error.
+func bufferBody(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return req, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return clone, nil
+}
+
+// retriesFor returns how many times req may be retried under config.
+func retriesFor(config RetryConfig, req *http.Request) int {
+	if config.IdempotentOnly && !isIdempotentRequest(req) {
+		return 0
+	}
+	return config.MaxRetries
+}
+
+// rewind gives a cloned request a fresh copy of its body, so that a retry
+// sends the same payload rather than an already drained reader.
+func rewind(req *http.Request) *http.Request {
+	if req.GetBody == nil || req.Body == nil || req.Body == http.NoBody {
+		return req
+	}
+	if body, err := req.GetBody(); err == nil {
+		req.Body = body
+	}
+	return req
+}
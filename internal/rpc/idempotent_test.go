@@ -0,0 +1,196 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsIdempotentMethod(t *testing.T) {
+	for _, m := range []string{"getTransaction", "getLedgerEntries", "getEvents", "simulateTransaction"} {
+		if !IsIdempotentMethod(m) {
+			t.Errorf("expected %s to be idempotent", m)
+		}
+	}
+	for _, m := range []string{"sendTransaction", "unknownMethod", ""} {
+		if IsIdempotentMethod(m) {
+			t.Errorf("expected %s not to be idempotent", m)
+		}
+	}
+}
+
+func TestIsIdempotentRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   bool
+	}{
+		{"get", http.MethodGet, "", true},
+		{"read call", http.MethodPost, `{"jsonrpc":"2.0","id":1,"method":"getLedgerEntries"}`, true},
+		{"read batch", http.MethodPost, `[{"method":"getEvents"},{"method":"getTransaction"}]`, true},
+		{"submit", http.MethodPost, `{"jsonrpc":"2.0","id":1,"method":"sendTransaction"}`, false},
+		{"mixed batch", http.MethodPost, `[{"method":"getEvents"},{"method":"sendTransaction"}]`, false},
+		{"form post", http.MethodPost, "tx=AAAA", false},
+		{"empty post", http.MethodPost, "", false},
+		{"delete", http.MethodDelete, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequest(tt.method, "http://rpc.local", body)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if got := isIdempotentRequest(req); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+			if tt.body != "" {
+				sent, _ := io.ReadAll(req.Body)
+				if string(sent) != tt.body {
+					t.Errorf("classifying consumed the body: %q", sent)
+				}
+			}
+		})
+	}
+}
+
+func retryCountingServer(t *testing.T, attempts *int, bodies *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*attempts++
+		body, _ := io.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRetryTransportIdempotentOnly(t *testing.T) {
+	cfg := DefaultRetryConfig()
+	cfg.MaxRetries = 2
+	cfg.InitialBackoff = time.Millisecond
+	cfg.IdempotentOnly = true
+	client := &http.Client{Transport: NewRetryTransport(cfg, http.DefaultTransport)}
+
+	t.Run("read is retried with its body", func(t *testing.T) {
+		attempts, bodies := 0, []string{}
+		server := retryCountingServer(t, &attempts, &bodies)
+		payload := `{"jsonrpc":"2.0","id":1,"method":"getTransaction"}`
+		resp, err := client.Post(server.URL, "application/json", strings.NewReader(payload))
+		if err == nil {
+			resp.Body.Close()
+		}
+		if attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", attempts)
+		}
+		for i, b := range bodies {
+			if b != payload {
+				t.Errorf("attempt %d sent %q", i+1, b)
+			}
+		}
+	})
+
+	t.Run("submit is sent once", func(t *testing.T) {
+		attempts, bodies := 0, []string{}
+		server := retryCountingServer(t, &attempts, &bodies)
+		resp, err := client.Post(server.URL, "application/json",
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"sendTransaction"}`))
+		if err == nil {
+			resp.Body.Close()
+			t.Errorf("expected the 503 to be reported")
+		}
+		if attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", attempts)
+		}
+	})
+}
+
+// streamBody hides the reader's type so http.NewRequest cannot set GetBody,
+// as with a body streamed from a pipe.
+type streamBody struct{ io.Reader }
+
+func (streamBody) Close() error { return nil }
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("disk gone") }
+
+func TestIsIdempotentRequest_LeavesRequestUntouched(t *testing.T) {
+	body := streamBody{strings.NewReader(`{"method":"getTransaction"}`)}
+	req, err := http.NewRequest(http.MethodPost, "http://rpc.local", body)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	if isIdempotentRequest(req) {
+		t.Errorf("expected a body without GetBody not to be classified")
+	}
+	if req.Body != body || req.GetBody != nil {
+		t.Errorf("classifying modified the request")
+	}
+}
+
+func TestRetryTransport_BuffersStreamedBody(t *testing.T) {
+	cfg := DefaultRetryConfig()
+	cfg.MaxRetries = 2
+	cfg.InitialBackoff = time.Millisecond
+	cfg.IdempotentOnly = true
+	client := &http.Client{Transport: NewRetryTransport(cfg, http.DefaultTransport)}
+
+	attempts, bodies := 0, []string{}
+	server := retryCountingServer(t, &attempts, &bodies)
+	payload := `{"jsonrpc":"2.0","id":1,"method":"getTransaction"}`
+	body := streamBody{strings.NewReader(payload)}
+	req, err := http.NewRequest(http.MethodPost, server.URL, body)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	for i, b := range bodies {
+		if b != payload {
+			t.Errorf("attempt %d sent %q", i+1, b)
+		}
+	}
+	if req.Body != body || req.GetBody != nil {
+		t.Errorf("the transport modified the caller's request")
+	}
+}
+
+func TestRetryTransport_UnreadableBodyFails(t *testing.T) {
+	attempts, bodies := 0, []string{}
+	server := retryCountingServer(t, &attempts, &bodies)
+	client := &http.Client{Transport: NewRetryTransport(DefaultRetryConfig(), http.DefaultTransport)}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, streamBody{failingReader{}})
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected the read error to fail the request")
+	}
+	if !strings.Contains(err.Error(), "disk gone") {
+		t.Errorf("expected the read error, got %v", err)
+	}
+	if attempts != 0 {
+		t.Errorf("expected nothing to be sent, got %d attempts", attempts)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// LatestLedger is the result of Soroban RPC getLatestLedger: the newest
+// ledger the server has and the protocol version the network runs.
+type LatestLedger struct {
+	ID              string `json:"id"`
+	ProtocolVersion uint32 `json:"protocolVersion"`
+	Sequence        uint32 `json:"sequence"`
+}
+
+type GetLatestLedgerResponse struct {
+	Jsonrpc string       `json:"jsonrpc"`
+	ID      int          `json:"id"`
+	Result  LatestLedger `json:"result"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// GetLatestLedger fetches the latest ledger, and with it the network's
+// current protocol version, from Soroban RPC getLatestLedger.
+func (c *Client) GetLatestLedger(ctx context.Context) (*LatestLedger, error) {
+	var latest *LatestLedger
+	err := c.withSorobanFailover(ctx, func() error {
+		resp, err := c.getLatestLedgerAttempt(ctx)
+		if err != nil {
+			return err
+		}
+		latest = &resp.Result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return latest, nil
+}
+
+func (c *Client) getLatestLedgerAttempt(ctx context.Context) (*GetLatestLedgerResponse, error) {
+	targetURL := c.SorobanURL
+	logger.Logger.DebugContext(ctx, "Fetching latest ledger", "url", targetURL)
+
+	// Fail fast if circuit breaker is open for this Soroban endpoint.
+	if !c.isHealthy(targetURL) {
+		return nil, errors.WrapRPCConnectionFailed(fmt.Errorf("circuit breaker open for %s", targetURL))
+	}
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getLatestLedger",
+	})
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "body read error")
+	}
+
+	var rpcResp GetLatestLedgerResponse
+	if err := decodeRPCResponse(targetURL, resp.StatusCode, respBytes, &rpcResp); err != nil {
+		return nil, err
+	}
+	if rpcResp.Error != nil {
+		return nil, jsonRPCError(targetURL, resp.StatusCode, rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+	return &rpcResp, nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLatestLedger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getLatestLedger", req["method"])
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"id":"abc","protocolVersion":22,"sequence":4242}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+	latest, err := client.GetLatestLedger(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(22), latest.ProtocolVersion)
+	assert.Equal(t, uint32(4242), latest.Sequence)
+}
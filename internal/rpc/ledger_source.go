@@ -0,0 +1,16 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import "context"
+
+// LedgerSource supplies the ledger entries a simulation reads. Keys and
+// entries are base64 XDR LedgerKeys and LedgerEntries; keys the source does
+// not hold are absent from the result rather than an error. Client is the
+// network-backed source; snapshot.Source serves a locally captured snapshot.
+type LedgerSource interface {
+	GetLedgerEntries(ctx context.Context, keys []string) (map[string]string, error)
+}
+
+var _ LedgerSource = (*Client)(nil)
@@ -0,0 +1,97 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// Network identifies a Stellar network
+type Network string
+
+const (
+	Testnet   Network = "testnet"
+	Mainnet   Network = "mainnet"
+	Futurenet Network = "futurenet"
+)
+
+// networkAliases maps accepted alternative spellings to their network.
+var networkAliases = map[string]Network{
+	"public": Mainnet,
+	"pubnet": Mainnet,
+}
+
+// ParseNetwork converts a user-supplied network name into a Network. Names
+// are case-insensitive and "public" is accepted as an alias for mainnet.
+func ParseNetwork(name string) (Network, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	switch n := Network(normalized); n {
+	case Testnet, Mainnet, Futurenet:
+		return n, nil
+	}
+	if n, ok := networkAliases[normalized]; ok {
+		return n, nil
+	}
+	return "", errors.WrapInvalidNetwork(name)
+}
+
+// String returns the canonical network name.
+func (n Network) String() string {
+	return string(n)
+}
+
+// Config returns the predefined configuration of the network. Unknown
+// networks fall back to mainnet, matching the client's default.
+func (n Network) Config() NetworkConfig {
+	switch n {
+	case Testnet:
+		return TestnetConfig
+	case Futurenet:
+		return FuturenetConfig
+	default:
+		return MainnetConfig
+	}
+}
+
+// wellKnownHosts maps the hostnames of public Horizon and RPC endpoints to
+// the network they serve.
+var wellKnownHosts = map[string]Network{
+	"horizon.stellar.org":           Mainnet,
+	"horizon-testnet.stellar.org":   Testnet,
+	"soroban-testnet.stellar.org":   Testnet,
+	"horizon-futurenet.stellar.org": Futurenet,
+	"rpc-futurenet.stellar.org":     Futurenet,
+}
+
+// InferNetworkFromURL guesses which network an RPC or Horizon URL serves,
+// from well-known hostnames and then from a network name in the hostname
+// (e.g. "testnet" in soroban-testnet.example.com). It reports false when the
+// URL gives no hint, as for a local or private endpoint.
+func InferNetworkFromURL(rawURL string) (Network, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Hostname() == "" {
+		return "", false
+	}
+	host := strings.ToLower(u.Hostname())
+	if n, ok := wellKnownHosts[host]; ok {
+		return n, true
+	}
+	for _, hint := range []struct {
+		word    string
+		network Network
+	}{
+		{"futurenet", Futurenet},
+		{"testnet", Testnet},
+		{"mainnet", Mainnet},
+		{"pubnet", Mainnet},
+	} {
+		if strings.Contains(host, hint.word) {
+			return hint.network, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetwork_Valid(t *testing.T) {
+	for input, want := range map[string]Network{
+		"testnet":   Testnet,
+		"mainnet":   Mainnet,
+		"futurenet": Futurenet,
+		" TestNet ": Testnet,
+	} {
+		got, err := ParseNetwork(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+}
+
+func TestParseNetwork_Aliases(t *testing.T) {
+	for _, input := range []string{"public", "PUBLIC", "pubnet"} {
+		got, err := ParseNetwork(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, Mainnet, got, input)
+	}
+}
+
+func TestParseNetwork_Invalid(t *testing.T) {
+	for _, input := range []string{"", "devnet", "main net"} {
+		_, err := ParseNetwork(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestNetwork_Config(t *testing.T) {
+	assert.Equal(t, TestnetConfig, Testnet.Config())
+	assert.Equal(t, FuturenetConfig, Futurenet.Config())
+	assert.Equal(t, MainnetConfig, Mainnet.Config())
+	assert.Equal(t, "futurenet", Futurenet.String())
+}
+
+func TestInferNetworkFromURL(t *testing.T) {
+	for input, want := range map[string]Network{
+		TestnetSorobanURL:                         Testnet,
+		TestnetHorizonURL:                         Testnet,
+		MainnetHorizonURL:                         Mainnet,
+		MainnetSorobanURL:                         Mainnet,
+		FuturenetSorobanURL:                       Futurenet,
+		"https://SOROBAN-TESTNET.stellar.org:443": Testnet,
+		"https://rpc.testnet.example.com/v1":      Testnet,
+	} {
+		got, ok := InferNetworkFromURL(input)
+		require.True(t, ok, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"http://localhost:8000", "https://rpc.example.com", "not a url", ""} {
+		_, ok := InferNetworkFromURL(input)
+		assert.False(t, ok, input)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+var offlineMode atomic.Bool
+
+// SetOffline sets the process-wide default for WithOffline. Clients created
+// afterwards refuse all network access unless built with WithOffline(false).
+func SetOffline(enabled bool) {
+	offlineMode.Store(enabled)
+}
+
+// IsOffline reports whether new clients default to offline mode.
+func IsOffline() bool {
+	return offlineMode.Load()
+}
+
+// offlineTransport fails every request without touching the network. It sits
+// in place of the retrying transport, so nothing is retried either.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, errors.WrapOffline(req.URL.Redacted())
+}
+
+func offlineHTTPClient() *http.Client {
+	return &http.Client{Transport: offlineTransport{}}
+}
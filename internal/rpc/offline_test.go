@@ -0,0 +1,87 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+func TestOfflineClientRefusesRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithAltURLs([]string{server.URL, server.URL + "/alt"}),
+		WithSorobanURL(server.URL),
+		WithHTTPClient(server.Client()),
+		WithCacheEnabled(false),
+		WithOffline(true),
+	)
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.GetTransaction(ctx, "abc"); !errors.Is(err, errors.ErrOffline) {
+		t.Errorf("GetTransaction: expected ErrOffline, got %v", err)
+	}
+	if _, err := client.GetLedgerEntries(ctx, []string{"key"}); !errors.Is(err, errors.ErrOffline) {
+		t.Errorf("GetLedgerEntries: expected ErrOffline, got %v", err)
+	}
+	if _, err := client.SimulateTransaction(ctx, "AAAA"); !errors.Is(err, errors.ErrOffline) {
+		t.Errorf("SimulateTransaction: expected ErrOffline, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("expected no requests to reach the server, got %d", n)
+	}
+}
+
+func TestOfflineClientServesMemoizedEntries(t *testing.T) {
+	memo := NewEntryMemo()
+	memo.Seed(Testnet, map[string]string{"key": "entry"})
+
+	client, err := NewClient(WithNetwork(Testnet), WithEntryMemo(memo), WithCacheEnabled(false), WithOffline(true))
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+
+	entries, err := client.GetLedgerEntries(context.Background(), []string{"key"})
+	if err != nil {
+		t.Fatalf("GetLedgerEntries: %v", err)
+	}
+	if entries["key"] != "entry" {
+		t.Errorf("expected memoized entry, got %v", entries)
+	}
+}
+
+func TestSetOfflineIsClientDefault(t *testing.T) {
+	t.Cleanup(func() { SetOffline(false) })
+	SetOffline(true)
+
+	client, err := NewClient(WithNetwork(Testnet))
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+	if !client.offline {
+		t.Error("expected client to inherit offline mode")
+	}
+
+	online, err := NewClient(WithNetwork(Testnet), WithOffline(false))
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+	if online.offline {
+		t.Error("expected WithOffline(false) to override the default")
+	}
+}
@@ -3,13 +3,26 @@
 
 package rpc
 
-import hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+import (
+	"encoding/json"
+
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+)
 
 // TransactionResponse holds the XDR data for a transaction
 type TransactionResponse struct {
 	EnvelopeXdr   string
 	ResultXdr     string
 	ResultMetaXdr string
+
+	// Ledger is the sequence of the ledger that included the transaction, or
+	// 0 when unknown, as for transactions loaded from a replay bundle.
+	Ledger uint32
+
+	// RawHorizon is Horizon's transaction JSON exactly as received, for a
+	// client built WithRawHorizon that fetched the transaction from Horizon;
+	// nil otherwise.
+	RawHorizon json.RawMessage
 }
 
 // ParseTransactionResponse converts a Horizon transaction into a TransactionResponse
@@ -18,6 +31,7 @@ func ParseTransactionResponse(tx hProtocol.Transaction) *TransactionResponse {
 		EnvelopeXdr:   tx.EnvelopeXdr,
 		ResultXdr:     tx.ResultXdr,
 		ResultMetaXdr: tx.ResultMetaXdr,
+		Ledger:        uint32(tx.Ledger),
 	}
 }
 
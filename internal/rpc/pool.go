@@ -0,0 +1,89 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// PoolConfig tunes the connection pool that RPC clients share. Connections
+// are kept alive between requests and reused by every client of the
+// process, so concurrent compare-network and multi-hash runs against one
+// host do not each open their own.
+type PoolConfig struct {
+	// MaxIdleConns bounds the idle connections kept across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost bounds the idle connections kept per host; more
+	// concurrent requests than this to one host close connections instead
+	// of reusing them.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost bounds the connections, idle or in use, per host.
+	// Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept.
+	IdleConnTimeout time.Duration
+}
+
+// DefaultPoolConfig is the pool used unless SetPoolConfig changes it. Go's
+// default keeps only two idle connections per host, which concurrent
+// clients exhaust at once.
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 32,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+// pool holds the transports shared by RPC clients, one per TLS
+// configuration so that connections verified against one set of roots are
+// never handed to a client expecting another.
+var pool = struct {
+	sync.Mutex
+	config     PoolConfig
+	transports map[*tls.Config]*http.Transport
+}{config: DefaultPoolConfig()}
+
+// SetPoolConfig sets the process-wide connection pool settings. Clients
+// built afterwards share a new pool with them; existing clients keep the
+// old one, whose idle connections are closed.
+func SetPoolConfig(cfg PoolConfig) {
+	pool.Lock()
+	defer pool.Unlock()
+	for _, t := range pool.transports {
+		t.CloseIdleConnections()
+	}
+	pool.config = cfg
+	pool.transports = nil
+}
+
+// sharedTransport returns the pooled transport for clients using tlsConfig,
+// nil meaning the system defaults.
+func sharedTransport(tlsConfig *tls.Config) *http.Transport {
+	pool.Lock()
+	defer pool.Unlock()
+	if t, ok := pool.transports[tlsConfig]; ok {
+		return t
+	}
+
+	cfg := pool.config
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DisableKeepAlives = false
+	t.MaxIdleConns = max(cfg.MaxIdleConns, cfg.MaxIdleConnsPerHost)
+	t.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	t.MaxConnsPerHost = cfg.MaxConnsPerHost
+	t.IdleConnTimeout = cfg.IdleConnTimeout
+	if tlsConfig != nil {
+		t.TLSClientConfig = tlsConfig.Clone()
+	}
+
+	if pool.transports == nil {
+		pool.transports = make(map[*tls.Config]*http.Transport)
+	}
+	pool.transports[tlsConfig] = t
+	return t
+}
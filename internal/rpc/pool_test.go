@@ -0,0 +1,88 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedTransport_PerTLSConfig(t *testing.T) {
+	SetPoolConfig(DefaultPoolConfig())
+	t.Cleanup(func() { SetPoolConfig(DefaultPoolConfig()) })
+
+	system := sharedTransport(nil)
+	assert.Same(t, system, sharedTransport(nil))
+	assert.Equal(t, 32, system.MaxIdleConnsPerHost)
+	assert.False(t, system.DisableKeepAlives)
+
+	custom := &tls.Config{MinVersion: tls.VersionTLS12}
+	withCustom := sharedTransport(custom)
+	assert.NotSame(t, system, withCustom, "clients with other roots get their own pool")
+	assert.Same(t, withCustom, sharedTransport(custom))
+	assert.Equal(t, uint16(tls.VersionTLS12), withCustom.TLSClientConfig.MinVersion)
+}
+
+func TestSetPoolConfig(t *testing.T) {
+	t.Cleanup(func() { SetPoolConfig(DefaultPoolConfig()) })
+	before := sharedTransport(nil)
+
+	SetPoolConfig(PoolConfig{MaxIdleConns: 10, MaxIdleConnsPerHost: 64, MaxConnsPerHost: 8, IdleConnTimeout: time.Minute})
+	after := sharedTransport(nil)
+	assert.NotSame(t, before, after)
+	assert.Equal(t, 64, after.MaxIdleConns, "never fewer overall than per host")
+	assert.Equal(t, 64, after.MaxIdleConnsPerHost)
+	assert.Equal(t, 8, after.MaxConnsPerHost)
+	assert.Equal(t, time.Minute, after.IdleConnTimeout)
+}
+
+func TestClients_ShareConnections(t *testing.T) {
+	for _, useTLS := range []bool{false, true} {
+		t.Run(map[bool]string{false: "http", true: "tls"}[useTLS], func(t *testing.T) {
+			SetPoolConfig(DefaultPoolConfig())
+			t.Cleanup(func() {
+				SetPoolConfig(DefaultPoolConfig())
+				SetTLSConfig(nil)
+			})
+
+			var opened atomic.Int32
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"NOT_FOUND"}}`))
+			}))
+			server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					opened.Add(1)
+				}
+			}
+			if useTLS {
+				// As --ca-file does: one config shared by every client
+				server.StartTLS()
+				roots := x509.NewCertPool()
+				roots.AddCert(server.Certificate())
+				SetTLSConfig(&tls.Config{RootCAs: roots})
+			} else {
+				server.Start()
+			}
+			t.Cleanup(server.Close)
+
+			for i := 0; i < 3; i++ {
+				client, err := NewClient(WithSorobanURL(server.URL), WithTransactionSource(TransactionSourceSoroban))
+				require.NoError(t, err)
+				_, err = client.GetTransactionViaSoroban(context.Background(), "abc")
+				require.False(t, isCertificateError(err), "%v", err)
+			}
+			assert.Equal(t, int32(1), opened.Load(), "every client should reuse the pooled connection")
+		})
+	}
+}
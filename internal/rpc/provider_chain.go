@@ -0,0 +1,70 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// Provider names, as logged for each request a provider serves.
+const (
+	providerHorizon = "horizon"
+	providerSoroban = "soroban-rpc"
+)
+
+// transactionProvider is an API GetTransaction can fetch transactions from.
+type transactionProvider struct {
+	name string
+	// url returns the provider's current endpoint, which failover may have
+	// rotated since the provider was listed.
+	url func() string
+	get func(context.Context, string) (*TransactionResponse, error)
+}
+
+// transactionProviders lists the APIs GetTransaction tries, in order: the
+// one the client's TransactionSource selects, then the other one when the
+// client has a separate endpoint for it. Raw Horizon mode keeps Horizon's
+// JSON, which Soroban RPC cannot provide, so it has no fallback; nor does a
+// client with failover alternates, which fails over across those instead.
+func (c *Client) transactionProviders() []transactionProvider {
+	horizon := transactionProvider{name: providerHorizon, url: func() string { return c.HorizonURL }, get: c.getTransactionViaHorizon}
+	soroban := transactionProvider{name: providerSoroban, url: func() string { return c.SorobanURL }, get: c.GetTransactionViaSoroban}
+
+	preferred, other := horizon, soroban
+	if c.usesSorobanForTransactions() {
+		preferred, other = soroban, horizon
+	}
+	if c.rawHorizon || c.endpointCount() > 1 || c.HorizonURL == "" || c.SorobanURL == "" || c.HorizonURL == c.SorobanURL {
+		return []transactionProvider{preferred}
+	}
+	return []transactionProvider{preferred, other}
+}
+
+// shouldFallBack reports whether a provider's failure is its own, so the
+// other provider may still serve the request: it is rate limiting the
+// client or cannot be reached. A transaction that is not found, or an error
+// the endpoint reports, would most likely be the same on the other API.
+func shouldFallBack(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrNetwork)
+}
+
+// providersFailedError reports every provider failing, with the endpoint
+// failures of each; a single failure is returned as is.
+func providersFailedError(providers []transactionProvider, failed []error) error {
+	if len(failed) == 1 {
+		return failed[0]
+	}
+	var all AllNodesFailedError
+	for i, err := range failed {
+		var nodes *AllNodesFailedError
+		if errors.As(err, &nodes) {
+			all.Failures = append(all.Failures, nodes.Failures...)
+		} else {
+			all.Failures = append(all.Failures, NodeFailure{URL: providers[i].url(), Reason: err})
+		}
+	}
+	return &all
+}
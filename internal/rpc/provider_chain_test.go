@@ -0,0 +1,120 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func horizonStatusClient(status int) *testClient {
+	return newTestClient(&mockHorizonClient{TransactionDetailFunc: func(hash string) (hProtocol.Transaction, error) {
+		return hProtocol.Transaction{}, &horizonclient.Error{Problem: problem.P{Status: status}}
+	}})
+}
+
+func TestGetTransaction_FallsBackToSorobanWhenRateLimited(t *testing.T) {
+	c := horizonStatusClient(http.StatusTooManyRequests)
+	c.SorobanURL = sorobanTxServer(t, `{"status":"SUCCESS","latestLedger":200,"oldestLedger":100,"ledger":150,"envelopeXdr":"ENV"}`).URL
+
+	tx, err := c.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "ENV", tx.EnvelopeXdr)
+}
+
+func TestGetTransaction_FallsBackToHorizonWhenSorobanUnreachable(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	c := newTestClient(&mockHorizonClient{TransactionDetailFunc: func(hash string) (hProtocol.Transaction, error) {
+		return hProtocol.Transaction{Hash: hash, EnvelopeXdr: "HENV"}, nil
+	}})
+	c.SorobanURL = down.URL
+	c.txSource = TransactionSourceSoroban
+
+	tx, err := c.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "HENV", tx.EnvelopeXdr)
+}
+
+func TestGetTransaction_NoFallback(t *testing.T) {
+	calls := 0
+	soroban := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { calls++ }))
+	t.Cleanup(soroban.Close)
+
+	// A missing transaction is not the provider's fault
+	c := horizonStatusClient(http.StatusNotFound)
+	c.SorobanURL = soroban.URL
+	_, err := c.GetTransaction(context.Background(), "abc")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Raw Horizon mode needs Horizon's JSON
+	c = horizonStatusClient(http.StatusTooManyRequests)
+	c.SorobanURL = soroban.URL
+	c.rawHorizon = true
+	assert.Len(t, c.transactionProviders(), 1)
+
+	assert.Zero(t, calls)
+}
+
+func TestGetTransaction_EveryProviderFails(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	c := horizonStatusClient(http.StatusTooManyRequests)
+	c.SorobanURL = down.URL
+
+	_, err := c.GetTransaction(context.Background(), "abc")
+	var all *AllNodesFailedError
+	require.ErrorAs(t, err, &all)
+	require.Len(t, all.Failures, 2)
+	assert.Equal(t, c.HorizonURL, all.Failures[0].URL)
+	assert.Equal(t, down.URL, all.Failures[1].URL)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.ErrorIs(t, err, ErrNetwork)
+}
+
+func TestGetTransaction_AlternatesSkipSorobanFallback(t *testing.T) {
+	var sorobanCalls atomic.Int32
+	soroban := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { sorobanCalls.Add(1) }))
+	t.Cleanup(soroban.Close)
+	// The first endpoint lacks the transaction, so Horizon rotates to the next
+	missing := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(missing.Close)
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"hash":"abc","successful":true,"envelope_xdr":"ENV"}`))
+	}))
+	t.Cleanup(ok.Close)
+
+	var logs bytes.Buffer
+	logger.SetOutput(&logs, false)
+	logger.SetLevel(slog.LevelInfo)
+	t.Cleanup(func() {
+		logger.SetOutput(os.Stderr, false)
+		logger.SetLevel(slog.LevelWarn)
+	})
+
+	c := NewClientWithURLsOption([]string{missing.URL, ok.URL}, Testnet, "")
+	c.SorobanURL = soroban.URL
+	require.Len(t, c.transactionProviders(), 1, "alternates fail over across each other")
+
+	tx, err := c.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "ENV", tx.EnvelopeXdr)
+	assert.Zero(t, sorobanCalls.Load())
+	assert.Contains(t, logs.String(), "url="+ok.URL, "the endpoint that served the transaction is logged")
+}
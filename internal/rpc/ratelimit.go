@@ -0,0 +1,136 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that spaces out requests to at most Rate per
+// second, allowing bursts of up to the whole-number part of the rate. A nil
+// limiter never waits.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter for rps requests per second, or nil when
+// rps is not positive.
+func NewRateLimiter(rps float64) *RateLimiter {
+	if rps <= 0 {
+		return nil
+	}
+	burst := math.Max(1, math.Floor(rps))
+	return &RateLimiter{rate: rps, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// Rate returns the configured requests per second, or 0 for a nil limiter.
+func (l *RateLimiter) Rate() float64 {
+	if l == nil {
+		return 0
+	}
+	return l.rate
+}
+
+// Wait blocks until a request may be sent or ctx is done. Callers are served
+// in the order they arrive: each one reserves a token up front, going into
+// debt if necessary, and sleeps until that debt is repaid.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	deficit := -l.tokens
+	l.mu.Unlock()
+
+	if deficit <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(deficit / l.rate * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Hand the reservation back so later callers are not delayed by it
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// RateLimit returns the client's request rate in requests per second, or 0
+// when it is not throttled.
+func (c *Client) RateLimit() float64 {
+	return c.limiter.Rate()
+}
+
+// rateLimitedTransport waits on the limiter before every request it sends.
+// It sits below the retrying transport so retries are throttled too.
+type rateLimitedTransport struct {
+	limiter   *RateLimiter
+	transport http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// withRateLimit returns a copy of client whose requests wait on limiter. The
+// client is returned unchanged when limiter is nil.
+func withRateLimit(client *http.Client, limiter *RateLimiter) *http.Client {
+	if limiter == nil {
+		return client
+	}
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	limited := *client
+	limited.Transport = &rateLimitedTransport{limiter: limiter, transport: transport}
+	return &limited
+}
+
+var (
+	rateLimitsMu sync.RWMutex
+	rateLimits   map[Network]float64
+)
+
+// SetRateLimits sets the process-wide request rate, in requests per second,
+// for clients of each network. The empty network applies to every network
+// without its own entry. Clients built afterwards use these unless
+// WithRateLimit is given; they take precedence over NetworkConfig.RateLimit.
+func SetRateLimits(limits map[Network]float64) {
+	rateLimitsMu.Lock()
+	defer rateLimitsMu.Unlock()
+	rateLimits = limits
+}
+
+// defaultRateLimit returns the process-wide rate for network, if one is set.
+func defaultRateLimit(network Network) (float64, bool) {
+	rateLimitsMu.RLock()
+	defer rateLimitsMu.RUnlock()
+	if rps, ok := rateLimits[network]; ok {
+		return rps, true
+	}
+	rps, ok := rateLimits[""]
+	return rps, ok
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	l := NewRateLimiter(20)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	// The first 20 are a burst; the other 5 need a quarter of a second
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("25 requests at 20/s took only %v", elapsed)
+	}
+}
+
+func TestRateLimiterWaitHonoursContext(t *testing.T) {
+	l := NewRateLimiter(1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestNilRateLimiterNeverWaits(t *testing.T) {
+	var l *RateLimiter
+	if NewRateLimiter(0) != nil {
+		t.Error("expected no limiter for a zero rate")
+	}
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Wait: %v", err)
+	}
+}
+
+func TestClientRateLimitPrecedence(t *testing.T) {
+	t.Cleanup(func() { SetRateLimits(nil) })
+	SetRateLimits(map[Network]float64{"": 5, Testnet: 2})
+
+	cases := []struct {
+		name string
+		opts []ClientOption
+		want float64
+	}{
+		{"network override", []ClientOption{WithNetwork(Testnet)}, 2},
+		{"all networks", []ClientOption{WithNetwork(Mainnet)}, 5},
+		{"explicit option", []ClientOption{WithNetwork(Testnet), WithRateLimit(0)}, 0},
+	}
+	for _, tc := range cases {
+		client, err := NewClient(tc.opts...)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got := client.RateLimit(); got != tc.want {
+			t.Errorf("%s: RateLimit() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	SetRateLimits(nil)
+	cfg := TestnetConfig
+	cfg.RateLimit = 3
+	client, err := NewClient(WithNetworkConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := client.RateLimit(); got != 3 {
+		t.Errorf("config RateLimit() = %v, want 3", got)
+	}
+
+	if _, err := NewClient(WithRateLimit(-1)); err == nil {
+		t.Error("expected a negative rate to be rejected")
+	}
+}
+
+func TestRateLimitIsPerClient(t *testing.T) {
+	var key xdr.LedgerKey
+	if err := key.SetAccount(xdr.MustAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")); err != nil {
+		t.Fatal(err)
+	}
+	keyB64, err := xdr.MarshalBase64(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"entries":[{"key":"` + keyB64 + `","xdr":"AAAA"}]}}`))
+	}))
+	defer server.Close()
+
+	newClient := func(rps float64) *Client {
+		client, err := NewClient(
+			WithNetwork(Testnet),
+			WithSorobanURL(server.URL),
+			WithHTTPClient(server.Client()),
+			WithCacheEnabled(false),
+			WithRateLimit(rps),
+		)
+		if err != nil {
+			t.Fatalf("failed to build client: %v", err)
+		}
+		return client
+	}
+	slow, fast := newClient(10), newClient(0)
+	ctx := context.Background()
+
+	timeCalls := func(c *Client) time.Duration {
+		start := time.Now()
+		for i := 0; i < 13; i++ {
+			if _, err := c.GetLedgerEntries(ctx, []string{keyB64}); err != nil {
+				t.Fatalf("GetLedgerEntries: %v", err)
+			}
+		}
+		return time.Since(start)
+	}
+
+	if d := timeCalls(slow); d < 250*time.Millisecond {
+		t.Errorf("13 requests at 10/s took only %v", d)
+	}
+	if d := timeCalls(fast); d > 250*time.Millisecond {
+		t.Errorf("unthrottled client was slowed down: %v", d)
+	}
+	if n := atomic.LoadInt32(&calls); n != 26 {
+		t.Errorf("expected 26 requests, got %d", n)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+)
+
+// horizonTransactionJSON fetches a transaction from Horizon as
+// TransactionDetail does, but keeps the response body so the fields
+// hProtocol.Transaction leaves out survive. Horizon errors come back as
+// *horizonclient.Error, as from horizonclient, for handleTransactionError.
+func (c *Client) horizonTransactionJSON(ctx context.Context, hash string) (hProtocol.Transaction, json.RawMessage, error) {
+	var tx hProtocol.Transaction
+	target := strings.TrimSuffix(c.HorizonURL, "/") + "/transactions/" + url.PathEscape(hash)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return tx, nil, errors.WrapRPCConnectionFailed(err)
+	}
+	req.Header.Set("Accept", "application/hal+json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	if err != nil {
+		return tx, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return tx, nil, errors.WrapUnmarshalFailed(err, "body read error")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		hErr := &horizonclient.Error{Response: resp}
+		if json.Unmarshal(body, &hErr.Problem) != nil || hErr.Problem.Status == 0 {
+			hErr.Problem = problem.P{Status: resp.StatusCode, Detail: strings.TrimSpace(string(body))}
+		}
+		return tx, nil, hErr
+	}
+	if err := json.Unmarshal(body, &tx); err != nil {
+		return tx, nil, errors.WrapUnmarshalFailed(err, string(body))
+	}
+	return tx, json.RawMessage(body), nil
+}
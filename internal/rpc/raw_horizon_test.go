@@ -0,0 +1,52 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransaction_RawHorizon(t *testing.T) {
+	body := `{"hash":"abc","ledger":150,"envelope_xdr":"ENV","result_xdr":"RES","result_meta_xdr":"META",` +
+		`"memo_type":"text","memo":"hello","fee_account":"GFEE","signatures":["c2ln"],"not_in_the_struct":true}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transactions/abc" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"https://stellar.org/horizon-errors/not_found","title":"Resource Missing","status":404}`))
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(
+		WithNetworkConfig(NetworkConfig{Name: "local", NetworkPassphrase: "p", HorizonURL: server.URL + "/"}),
+		WithTransactionSource(TransactionSourceHorizon),
+		WithRawHorizon(true),
+	)
+	require.NoError(t, err)
+
+	tx, err := client.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "ENV", tx.EnvelopeXdr)
+	assert.Equal(t, "META", tx.ResultMetaXdr)
+	assert.Equal(t, uint32(150), tx.Ledger)
+	assert.JSONEq(t, body, string(tx.RawHorizon))
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(tx.RawHorizon, &fields))
+	assert.Equal(t, true, fields["not_in_the_struct"], "fields Horizon sends but erst does not parse are kept")
+
+	_, err = client.GetTransaction(context.Background(), "missing")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrTransactionNotFound))
+}
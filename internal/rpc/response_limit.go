@@ -0,0 +1,113 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// DefaultMaxResponseBytes bounds the body of any single RPC response unless
+// another limit is set: far above what a Horizon or Soroban RPC call
+// returns, but low enough that a misbehaving endpoint cannot exhaust memory.
+const DefaultMaxResponseBytes int64 = 256 << 20
+
+var defaultMaxResponseBytes atomic.Int64
+
+// SetMaxResponseBytes sets the process-wide response body limit for RPC
+// clients. Clients built afterwards use it unless WithMaxResponseBytes is
+// given; a client supplied through WithHTTPClient is left unbounded. A
+// value of 0 or less restores DefaultMaxResponseBytes.
+func SetMaxResponseBytes(n int64) {
+	defaultMaxResponseBytes.Store(n)
+}
+
+// WithMaxResponseBytes fails every response whose body is larger than n
+// bytes with ErrRPCResponseTooLarge. It overrides SetMaxResponseBytes, and
+// also bounds a client supplied through WithHTTPClient.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return func(b *clientBuilder) error {
+		if n <= 0 {
+			return errors.WrapValidationError("the maximum response size must be positive")
+		}
+		b.maxResponseBytes = n
+		return nil
+	}
+}
+
+// resolveMaxResponseBytes picks the response limit for the client:
+// WithMaxResponseBytes, then SetMaxResponseBytes, then the default.
+func (b *clientBuilder) resolveMaxResponseBytes() int64 {
+	if b.maxResponseBytes > 0 {
+		return b.maxResponseBytes
+	}
+	return processMaxResponseBytes()
+}
+
+// processMaxResponseBytes is the limit SetMaxResponseBytes set, or the
+// default.
+func processMaxResponseBytes() int64 {
+	if n := defaultMaxResponseBytes.Load(); n > 0 {
+		return n
+	}
+	return DefaultMaxResponseBytes
+}
+
+func withResponseLimit(client *http.Client, limit int64) *http.Client {
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	limited := *client
+	limited.Transport = &responseLimitTransport{limit: limit, transport: transport}
+	return &limited
+}
+
+// responseLimitTransport fails responses larger than limit: at once when
+// their Content-Length says so, otherwise once reading passes the limit.
+type responseLimitTransport struct {
+	limit     int64
+	transport http.RoundTripper
+}
+
+func (t *responseLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+	url := req.URL.Redacted()
+	if resp.ContentLength > t.limit {
+		resp.Body.Close()
+		return nil, errors.WrapResponseExceedsLimit(url, t.limit)
+	}
+	resp.Body = &limitedBody{
+		Reader: io.LimitReader(resp.Body, t.limit+1),
+		Closer: resp.Body,
+		limit:  t.limit,
+		url:    url,
+	}
+	return resp, nil
+}
+
+// limitedBody reads at most limit bytes of a response and reports a body
+// that goes on past them instead of silently truncating it.
+type limitedBody struct {
+	io.Reader
+	io.Closer
+	limit int64
+	read  int64
+	url   string
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n - int(b.read-b.limit), errors.WrapResponseExceedsLimit(b.url, b.limit)
+	}
+	return n, err
+}
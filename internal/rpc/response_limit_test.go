@@ -0,0 +1,108 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamingServer answers every request with a JSON-RPC response padded to
+// size bytes, streamed in chunks without a Content-Length.
+func streamingServer(t *testing.T, size int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := `{"jsonrpc":"2.0","id":1,"result":{"status":"NOT_FOUND","pad":"`
+		_, _ = w.Write([]byte(prefix))
+		chunk := strings.Repeat("x", 512)
+		for written := len(prefix); written < size; written += len(chunk) {
+			_, _ = w.Write([]byte(chunk))
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write([]byte(`"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMaxResponseBytes_StreamedPastLimit(t *testing.T) {
+	server := streamingServer(t, 64<<10)
+	client, err := NewClient(WithSorobanURL(server.URL), WithTransactionSource(TransactionSourceSoroban), WithMaxResponseBytes(4<<10))
+	require.NoError(t, err)
+
+	_, err = client.GetTransactionViaSoroban(context.Background(), "abc")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrRPCResponseTooLarge), err.Error())
+	assert.Contains(t, err.Error(), "4096-byte limit")
+	assert.Contains(t, err.Error(), "--max-response-bytes")
+}
+
+func TestMaxResponseBytes_ContentLengthPastLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := strings.Repeat("x", 2048)
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithSorobanURL(server.URL), WithMaxResponseBytes(1024))
+	require.NoError(t, err)
+	_, err = client.GetTransactionViaSoroban(context.Background(), "abc")
+	assert.True(t, errors.Is(err, errors.ErrRPCResponseTooLarge), "%v", err)
+}
+
+func TestMaxResponseBytes_CustomAndFailoverClients(t *testing.T) {
+	t.Cleanup(func() { SetMaxResponseBytes(0) })
+	SetMaxResponseBytes(1024)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 2048)))
+	}))
+	t.Cleanup(server.Close)
+
+	custom, err := NewCustomClient(NetworkConfig{Name: "local", NetworkPassphrase: "Local Network", SorobanRPCURL: server.URL})
+	require.NoError(t, err)
+	_, err = custom.GetTransactionViaSoroban(context.Background(), "abc")
+	assert.True(t, errors.Is(err, errors.ErrRPCResponseTooLarge), "%v", err)
+
+	// A client without its own HTTP client gets a bounded one on failover
+	rotated := &Client{AltURLs: []string{"https://a.example", server.URL}}
+	require.True(t, rotated.rotateURL())
+	horizon, ok := rotated.Horizon.(*horizonclient.Client)
+	require.True(t, ok)
+	_, err = horizon.HTTP.(*http.Client).Get(server.URL)
+	assert.True(t, errors.Is(err, errors.ErrRPCResponseTooLarge), "%v", err)
+}
+
+func TestMaxResponseBytes_WithinLimit(t *testing.T) {
+	server := sorobanTxServer(t, `{"status":"SUCCESS","latestLedger":200,"oldestLedger":100,"ledger":150,"envelopeXdr":"ENV"}`)
+	client, err := NewClient(WithSorobanURL(server.URL), WithMaxResponseBytes(1024))
+	require.NoError(t, err)
+
+	tx, err := client.GetTransactionViaSoroban(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "ENV", tx.EnvelopeXdr)
+}
+
+func TestMaxResponseBytes_Resolve(t *testing.T) {
+	t.Cleanup(func() { SetMaxResponseBytes(0) })
+
+	assert.Equal(t, DefaultMaxResponseBytes, newBuilder().resolveMaxResponseBytes())
+	SetMaxResponseBytes(10)
+	assert.Equal(t, int64(10), newBuilder().resolveMaxResponseBytes())
+
+	b := newBuilder()
+	require.NoError(t, WithMaxResponseBytes(20)(b))
+	assert.Equal(t, int64(20), b.resolveMaxResponseBytes())
+	assert.Error(t, WithMaxResponseBytes(0)(newBuilder()))
+}
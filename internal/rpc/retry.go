@@ -23,6 +23,11 @@ type RetryConfig struct {
 	MaxBackoff         time.Duration
 	JitterFraction     float64
 	StatusCodesToRetry []int
+	// IdempotentOnly restricts retries to requests that are safe to repeat:
+	// HTTP GETs and JSON-RPC calls to read-only methods (see
+	// IsIdempotentMethod). Any other request, such as sendTransaction, is
+	// sent exactly once, so a retry can never repeat a side effect.
+	IdempotentOnly bool
 }
 
 // DefaultRetryConfig returns a sensible default retry configuration
@@ -57,19 +62,24 @@ func NewRetrier(config RetryConfig, client *http.Client) *Retrier {
 func (r *Retrier) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
 	var lastErr error
 	backoff := r.config.InitialBackoff
+	req, err := bufferBody(req)
+	if err != nil {
+		return nil, err
+	}
+	maxRetries := retriesFor(r.config, req)
 
-	for attempt := 0; attempt <= r.config.MaxRetries; attempt++ {
+	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
 			if err := r.waitWithContext(ctx, backoff); err != nil {
 				return nil, errors.WrapRPCTimeout(err)
 			}
 		}
 
-		resp, err := r.client.Do(req.Clone(ctx))
+		resp, err := r.client.Do(rewind(req.Clone(ctx)))
 		if err != nil {
 			lastErr = err
-			if attempt < r.config.MaxRetries {
-				logger.Logger.Debug("Request failed, will retry", "attempt", attempt+1, "error", err)
+			if attempt < maxRetries {
+				logger.Logger.DebugContext(ctx, "Request failed, will retry", "attempt", attempt+1, "error", err)
 			}
 			backoff = r.nextBackoff(backoff)
 			continue
@@ -86,7 +96,7 @@ func (r *Retrier) Do(ctx context.Context, req *http.Request) (*http.Response, er
 			lastErr = fmt.Errorf("status code %d", resp.StatusCode)
 			retryAfter := r.getRetryAfter(resp)
 
-			logger.Logger.Warn("Rate limited or temporary failure, will retry",
+			logger.Logger.WarnContext(ctx, "Rate limited or temporary failure, will retry",
 				"attempt", attempt+1,
 				"status_code", resp.StatusCode,
 				"retry_after", retryAfter,
@@ -100,11 +110,11 @@ func (r *Retrier) Do(ctx context.Context, req *http.Request) (*http.Response, er
 				backoff = r.nextBackoff(backoff)
 			}
 
-			if attempt < r.config.MaxRetries {
+			if attempt < maxRetries {
 				continue
 			}
 			// If we've exhausted retries on a retryable error, return error
-			return nil, errors.WrapRPCConnectionFailed(lastErr)
+			return nil, statusError(req, resp.StatusCode)
 		}
 
 		// Success or non-retryable error
@@ -114,6 +124,15 @@ func (r *Retrier) Do(ctx context.Context, req *http.Request) (*http.Response, er
 	return nil, errors.WrapRPCConnectionFailed(lastErr)
 }
 
+// statusError reports a retryable status that persisted through every
+// retry: rate limiting, or an endpoint that stayed unavailable.
+func statusError(req *http.Request, status int) error {
+	if status == http.StatusTooManyRequests {
+		return errors.WrapRateLimitExceeded()
+	}
+	return errors.WrapRPCStatusError(req.URL.Redacted(), status, http.StatusText(status))
+}
+
 // shouldRetry determines if the response status code warrants a retry
 func (r *Retrier) shouldRetry(statusCode int) bool {
 	for _, code := range r.config.StatusCodesToRetry {
@@ -201,19 +220,29 @@ func NewRetryTransport(config RetryConfig, transport http.RoundTripper) *RetryTr
 func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	var lastErr error
 	backoff := rt.config.InitialBackoff
+	req, err := bufferBody(req)
+	if err != nil {
+		return nil, err
+	}
+	maxRetries := retriesFor(rt.config, req)
 
-	for attempt := 0; attempt <= rt.config.MaxRetries; attempt++ {
+	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
 			if err := rt.waitWithContext(req.Context(), backoff); err != nil {
 				return nil, errors.WrapRPCTimeout(err)
 			}
+			req = rewind(req.Clone(req.Context()))
 		}
 
 		resp, err := rt.transport.RoundTrip(req)
 		if err != nil {
+			// An untrusted certificate will not become trusted on retry
+			if isCertificateError(err) {
+				return nil, fmt.Errorf("%w (use --ca-file to trust a private CA, or --insecure for a local setup)", err)
+			}
 			lastErr = err
-			if attempt < rt.config.MaxRetries {
-				logger.Logger.Debug("RoundTrip failed, will retry", "attempt", attempt+1, "error", err)
+			if attempt < maxRetries {
+				logger.Logger.DebugContext(req.Context(), "RoundTrip failed, will retry", "attempt", attempt+1, "error", err)
 			}
 			backoff = rt.nextBackoff(backoff)
 			continue
@@ -230,7 +259,7 @@ func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			lastErr = fmt.Errorf("status code %d", resp.StatusCode)
 			retryAfter := rt.getRetryAfter(resp)
 
-			logger.Logger.Warn("Rate limited or temporary failure, will retry",
+			logger.Logger.WarnContext(req.Context(), "Rate limited or temporary failure, will retry",
 				"attempt", attempt+1,
 				"status_code", resp.StatusCode,
 				"retry_after", retryAfter,
@@ -244,11 +273,11 @@ func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 				backoff = rt.nextBackoff(backoff)
 			}
 
-			if attempt < rt.config.MaxRetries {
+			if attempt < maxRetries {
 				continue
 			}
 			// If we've exhausted retries on a retryable error, return error
-			return nil, errors.WrapRPCConnectionFailed(lastErr)
+			return nil, statusError(req, resp.StatusCode)
 		}
 
 		// Success or non-retryable error
@@ -0,0 +1,233 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TransactionSource selects the API GetTransaction fetches transactions
+// from.
+type TransactionSource string
+
+const (
+	// TransactionSourceAuto uses Horizon, unless the client was configured
+	// with a Soroban RPC endpoint only.
+	TransactionSourceAuto    TransactionSource = "auto"
+	TransactionSourceHorizon TransactionSource = "horizon"
+	TransactionSourceSoroban TransactionSource = "soroban"
+)
+
+// ParseTransactionSource validates a transaction source name. The empty
+// string is TransactionSourceAuto.
+func ParseTransactionSource(s string) (TransactionSource, error) {
+	switch src := TransactionSource(s); src {
+	case "":
+		return TransactionSourceAuto, nil
+	case TransactionSourceAuto, TransactionSourceHorizon, TransactionSourceSoroban:
+		return src, nil
+	default:
+		return "", errors.WrapValidationError(fmt.Sprintf("unsupported transaction source %q (expected auto, horizon or soroban)", s))
+	}
+}
+
+// WithTransactionSource sets the API GetTransaction uses.
+func WithTransactionSource(src TransactionSource) ClientOption {
+	return func(b *clientBuilder) error {
+		parsed, err := ParseTransactionSource(string(src))
+		if err != nil {
+			return err
+		}
+		b.txSource = parsed
+		return nil
+	}
+}
+
+// usesSorobanForTransactions reports whether GetTransaction goes to Soroban
+// RPC: when asked to, or when there is no Horizon endpoint to ask.
+func (c *Client) usesSorobanForTransactions() bool {
+	switch c.txSource {
+	case TransactionSourceSoroban:
+		return true
+	case TransactionSourceHorizon:
+		return false
+	default:
+		return c.HorizonURL == "" && c.SorobanURL != ""
+	}
+}
+
+// Soroban RPC getTransaction statuses.
+const (
+	sorobanTxSuccess  = "SUCCESS"
+	sorobanTxFailed   = "FAILED"
+	sorobanTxNotFound = "NOT_FOUND"
+)
+
+type GetTransactionResponse struct {
+	Jsonrpc string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Result  struct {
+		Status        string `json:"status"`
+		LatestLedger  uint32 `json:"latestLedger"`
+		OldestLedger  uint32 `json:"oldestLedger"`
+		Ledger        uint32 `json:"ledger,omitempty"`
+		EnvelopeXdr   string `json:"envelopeXdr,omitempty"`
+		ResultXdr     string `json:"resultXdr,omitempty"`
+		ResultMetaXdr string `json:"resultMetaXdr,omitempty"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// GetTransactionViaSoroban fetches a transaction's envelope, result and
+// meta XDR from Soroban RPC getTransaction. Soroban RPC only retains recent
+// ledgers, but is often ahead of Horizon for Soroban transactions. A
+// transaction no endpoint finds may be older than they retain, so it is
+// looked up on the archival endpoint, when one is configured.
+func (c *Client) GetTransactionViaSoroban(ctx context.Context, hash string) (*TransactionResponse, error) {
+	resp, err := c.getTransactionViaSoroban(ctx, hash)
+	if err == nil || !errors.Is(err, errors.ErrTransactionNotFound) {
+		return resp, err
+	}
+	archival := c.archivalClient()
+	if archival == nil {
+		return nil, err
+	}
+	logger.Logger.WarnContext(ctx, "Transaction not found within the RPC's retention window; trying the archival RPC",
+		"hash", hash, "url", archival.SorobanURL)
+	return archival.getTransactionViaSoroban(ctx, hash)
+}
+
+func (c *Client) getTransactionViaSoroban(ctx context.Context, hash string) (*TransactionResponse, error) {
+	attempts := c.endpointCount()
+	var failures []NodeFailure
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		resp, err := c.getTransactionViaSorobanAttempt(ctx, hash)
+		if err == nil {
+			c.markSuccess(c.SorobanURL)
+			return resp, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		// As with Horizon, another endpoint may retain more history.
+		if !errors.Is(err, errors.ErrTransactionNotFound) {
+			c.markFailure(c.SorobanURL)
+		}
+		failures = append(failures, NodeFailure{URL: c.SorobanURL, Reason: err})
+
+		if attempt < attempts-1 {
+			logger.Logger.WarnContext(ctx, "Retrying with fallback Soroban RPC...", "error", err)
+			if !c.rotateURL() {
+				break
+			}
+		}
+	}
+	if notFound := allNotFound(failures); notFound != nil {
+		return nil, notFound
+	}
+	return nil, c.failoverError(failures)
+}
+
+func (c *Client) getTransactionViaSorobanAttempt(ctx context.Context, hash string) (*TransactionResponse, error) {
+	targetURL := c.SorobanURL
+	tracer := telemetry.GetTracer()
+	_, span := tracer.Start(ctx, "rpc_get_transaction_soroban")
+	span.SetAttributes(
+		attribute.String("transaction.hash", hash),
+		attribute.String("network", string(c.Network)),
+		attribute.String("rpc.url", targetURL),
+	)
+	defer span.End()
+
+	logger.Logger.DebugContext(ctx, "Fetching transaction from Soroban RPC", "hash", hash, "url", targetURL)
+
+	// Fail fast if circuit breaker is open for this Soroban endpoint.
+	if !c.isHealthy(targetURL) {
+		err := errors.WrapRPCConnectionFailed(fmt.Errorf("circuit breaker open for %s", targetURL))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getTransaction",
+		"params":  map[string]string{"hash": hash},
+	})
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, requestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		return nil, errors.WrapRPCResponseTooLarge(targetURL)
+	}
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "body read error")
+	}
+
+	var rpcResp GetTransactionResponse
+	if err := decodeRPCResponse(targetURL, resp.StatusCode, respBytes, &rpcResp); err != nil {
+		return nil, err
+	}
+	if rpcResp.Error != nil {
+		return nil, jsonRPCError(targetURL, resp.StatusCode, rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+
+	result := rpcResp.Result
+	switch result.Status {
+	case sorobanTxSuccess, sorobanTxFailed:
+	case sorobanTxNotFound:
+		logger.Logger.WarnContext(ctx, "Transaction not found", "hash", hash, "url", targetURL,
+			"oldest_ledger", result.OldestLedger, "latest_ledger", result.LatestLedger)
+		return nil, errors.WrapTransactionNotFoundOnNetwork(hash, string(c.Network))
+	default:
+		return nil, errors.WrapUnmarshalFailed(fmt.Errorf("unexpected status %q", result.Status), "getTransaction response")
+	}
+
+	span.SetAttributes(
+		attribute.Int("envelope.size_bytes", len(result.EnvelopeXdr)),
+		attribute.Int("result.size_bytes", len(result.ResultXdr)),
+		attribute.Int("result_meta.size_bytes", len(result.ResultMetaXdr)),
+	)
+	logger.Logger.InfoContext(ctx, "Transaction fetched", "hash", hash, "envelope_size", len(result.EnvelopeXdr), "url", targetURL)
+
+	return &TransactionResponse{
+		EnvelopeXdr:   result.EnvelopeXdr,
+		ResultXdr:     result.ResultXdr,
+		ResultMetaXdr: result.ResultMetaXdr,
+		Ledger:        result.Ledger,
+	}, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sorobanTxServer(t *testing.T, result string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params map[string]string `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getTransaction", req.Method)
+		assert.Equal(t, "abc", req.Params["hash"])
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetTransactionViaSoroban(t *testing.T) {
+	server := sorobanTxServer(t, `{"status":"FAILED","latestLedger":200,"oldestLedger":100,"ledger":150,
+		"envelopeXdr":"ENV","resultXdr":"RES","resultMetaXdr":"META"}`)
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+	tx, err := client.GetTransactionViaSoroban(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, &TransactionResponse{EnvelopeXdr: "ENV", ResultXdr: "RES", ResultMetaXdr: "META", Ledger: 150}, tx)
+}
+
+func TestGetTransactionViaSoroban_NotFound(t *testing.T) {
+	server := sorobanTxServer(t, `{"status":"NOT_FOUND","latestLedger":200,"oldestLedger":100}`)
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}, Network: Testnet}
+	_, err := client.GetTransactionViaSoroban(context.Background(), "abc")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrTransactionNotFound))
+}
+
+func TestGetTransaction_Source(t *testing.T) {
+	server := sorobanTxServer(t, `{"status":"SUCCESS","latestLedger":200,"oldestLedger":100,"ledger":150,"envelopeXdr":"ENV"}`)
+
+	// No Horizon endpoint: auto falls back to Soroban RPC
+	client, err := NewClient(WithNetworkConfig(NetworkConfig{Name: "local", NetworkPassphrase: "p", SorobanRPCURL: server.URL}))
+	require.NoError(t, err)
+	assert.True(t, client.usesSorobanForTransactions())
+	tx, err := client.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "ENV", tx.EnvelopeXdr)
+
+	client, err = NewClient(WithSorobanURL(server.URL))
+	require.NoError(t, err)
+	assert.False(t, client.usesSorobanForTransactions())
+
+	client, err = NewClient(WithSorobanURL(server.URL), WithTransactionSource(TransactionSourceSoroban))
+	require.NoError(t, err)
+	assert.True(t, client.usesSorobanForTransactions())
+
+	_, err = NewClient(WithTransactionSource("ftp"))
+	assert.Error(t, err)
+}
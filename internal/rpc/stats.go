@@ -0,0 +1,101 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// RequestStats counts the HTTP requests of the clients given it through
+// WithRequestStats: how many were sent, how many response bytes came back
+// and how long they took, from sending the request to reading the last byte
+// of the response. A request retried by the client counts once. Requests
+// of concurrent clients overlap, so Duration may exceed the wall time.
+type RequestStats struct {
+	calls    atomic.Int64
+	bytes    atomic.Int64
+	duration atomic.Int64
+}
+
+// Calls returns the number of requests sent.
+func (s *RequestStats) Calls() int64 { return s.calls.Load() }
+
+// Bytes returns the number of response body bytes read.
+func (s *RequestStats) Bytes() int64 { return s.bytes.Load() }
+
+// Duration returns the summed time spent in requests.
+func (s *RequestStats) Duration() time.Duration { return time.Duration(s.duration.Load()) }
+
+// WithRequestStats records every request the client sends in stats, which
+// may be shared with other clients.
+func WithRequestStats(stats *RequestStats) ClientOption {
+	return func(b *clientBuilder) error {
+		b.stats = stats
+		return nil
+	}
+}
+
+func withRequestStats(client *http.Client, stats *RequestStats) *http.Client {
+	if stats == nil {
+		return client
+	}
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	counted := *client
+	counted.Transport = &statsTransport{stats: stats, transport: transport}
+	return &counted
+}
+
+// statsTransport wraps the whole transport stack, retries included, and
+// times each request until its response body is read or closed.
+type statsTransport struct {
+	stats     *RequestStats
+	transport http.RoundTripper
+}
+
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	t.stats.calls.Add(1)
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		t.stats.duration.Add(int64(time.Since(start)))
+		return resp, err
+	}
+	resp.Body = &statsBody{ReadCloser: resp.Body, stats: t.stats, start: start}
+	return resp, nil
+}
+
+// statsBody counts the bytes read from a response and adds the request's
+// time once the body is exhausted or closed, whichever comes first.
+type statsBody struct {
+	io.ReadCloser
+	stats *RequestStats
+	start time.Time
+	done  atomic.Bool
+}
+
+func (b *statsBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.stats.bytes.Add(int64(n))
+	if err == io.EOF {
+		b.finish()
+	}
+	return n, err
+}
+
+func (b *statsBody) Close() error {
+	b.finish()
+	return b.ReadCloser.Close()
+}
+
+func (b *statsBody) finish() {
+	if b.done.CompareAndSwap(false, true) {
+		b.stats.duration.Add(int64(time.Since(b.start)))
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestStats(t *testing.T) {
+	const body = `{"jsonrpc":"2.0","id":1,"result":{"latestLedger":2000,"events":[]}}`
+	server := staticRPCServer(t, body)
+
+	stats := &RequestStats{}
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL), WithHorizonURL(server.URL),
+		WithRequestStats(stats), WithOffline(false))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = client.GetEvents(context.Background(), 100, nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(2), stats.Calls())
+	assert.Equal(t, int64(2*len(body)), stats.Bytes())
+	assert.Positive(t, stats.Duration())
+}
+
+func TestWithRequestStats_Nil(t *testing.T) {
+	client, err := NewClient(WithNetwork(Testnet))
+	require.NoError(t, err)
+	_, counted := client.getHTTPClient().Transport.(*statsTransport)
+	assert.False(t, counted)
+}
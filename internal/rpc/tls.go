@@ -0,0 +1,87 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+var defaultTLS atomic.Pointer[tls.Config]
+
+// SetTLSConfig sets the process-wide TLS configuration for RPC clients.
+// Clients built afterwards use it unless WithTLSConfig is given or their
+// NetworkConfig carries its own. nil restores the system defaults.
+func SetTLSConfig(cfg *tls.Config) {
+	defaultTLS.Store(cfg)
+}
+
+// LoadTLSConfig builds the TLS configuration for a private RPC endpoint.
+// caFile names a PEM bundle of root certificates trusted in addition to the
+// system roots, for endpoints behind an internal CA or a TLS-intercepting
+// proxy. insecure skips certificate verification entirely and is only meant
+// for local quickstart setups with self-signed certificates. It returns nil
+// when neither is set.
+func LoadTLSConfig(caFile string, insecure bool) (*tls.Config, error) {
+	if caFile == "" && !insecure {
+		return nil, nil
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if insecure {
+		cfg.InsecureSkipVerify = true
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("failed to read CA file: %v", err))
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.WrapValidationError(fmt.Sprintf("CA file %s contains no PEM certificates", caFile))
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
+
+// WithTLSConfig sets the TLS configuration used for both Horizon and Soroban
+// RPC requests. It overrides NetworkConfig.TLSConfig and SetTLSConfig, and is
+// ignored when WithHTTPClient supplies the client.
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return func(b *clientBuilder) error {
+		b.tlsConfig = cfg
+		return nil
+	}
+}
+
+// resolveTLSConfig picks the TLS configuration for the client: WithTLSConfig,
+// then the network config, then the process-wide SetTLSConfig value.
+func (b *clientBuilder) resolveTLSConfig() *tls.Config {
+	if b.tlsConfig != nil {
+		return b.tlsConfig
+	}
+	if b.config != nil && b.config.TLSConfig != nil {
+		return b.config.TLSConfig
+	}
+	return defaultTLS.Load()
+}
+
+// isCertificateError reports whether err is a failure to verify the server's
+// certificate.
+func isCertificateError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	return errors.As(err, &verifyErr) || errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostname) || errors.As(err, &invalid)
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCAFile(t *testing.T, srv *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return path
+}
+
+func TestLoadTLSConfig(t *testing.T) {
+	cfg, err := LoadTLSConfig("", false)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	cfg, err = LoadTLSConfig("", true)
+	require.NoError(t, err)
+	assert.True(t, cfg.InsecureSkipVerify)
+
+	_, err = LoadTLSConfig(filepath.Join(t.TempDir(), "missing.pem"), false)
+	assert.Error(t, err)
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o644))
+	_, err = LoadTLSConfig(notPEM, false)
+	assert.ErrorContains(t, err, "contains no PEM certificates")
+}
+
+func TestClient_TLSConfig(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	get := func(opts ...ClientOption) error {
+		c, err := NewClient(append([]ClientOption{WithHorizonURL(srv.URL), WithOffline(false)}, opts...)...)
+		require.NoError(t, err)
+		resp, err := c.getHTTPClient().Get(srv.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	err := get()
+	require.Error(t, err, "the test server's certificate is not trusted by default")
+	assert.Contains(t, err.Error(), "--ca-file")
+
+	trusted, err := LoadTLSConfig(writeCAFile(t, srv), false)
+	require.NoError(t, err)
+	assert.NoError(t, get(WithTLSConfig(trusted)))
+
+	insecure, err := LoadTLSConfig("", true)
+	require.NoError(t, err)
+	assert.NoError(t, get(WithTLSConfig(insecure)))
+
+	SetTLSConfig(trusted)
+	t.Cleanup(func() { SetTLSConfig(nil) })
+	assert.NoError(t, get(), "the process-wide config applies to new clients")
+}
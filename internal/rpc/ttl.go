@@ -0,0 +1,148 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// EntryTTL describes the expiration state of a Soroban ledger entry.
+type EntryTTL struct {
+	Key             string `json:"key"`        // base64-encoded LedgerKey
+	Type            string `json:"type"`       // "contract_data" or "contract_code"
+	Durability      string `json:"durability"` // "persistent" or "temporary"
+	LiveUntilLedger uint32 `json:"live_until_ledger"`
+	LatestLedger    uint32 `json:"latest_ledger"`
+	Missing         bool   `json:"missing,omitempty"` // requested but not returned: archived or never created
+}
+
+// LedgersRemaining returns how many ledgers remain before the entry expires.
+// The value is negative once the entry has already expired.
+func (t EntryTTL) LedgersRemaining() int64 {
+	return int64(t.LiveUntilLedger) - int64(t.LatestLedger)
+}
+
+// Expired reports whether the entry is no longer live at the latest ledger.
+func (t EntryTTL) Expired() bool {
+	return t.LedgersRemaining() < 0
+}
+
+// ExpiresWithin reports whether the entry expires within the given number of ledgers.
+func (t EntryTTL) ExpiresWithin(ledgers uint32) bool {
+	return t.LedgersRemaining() <= int64(ledgers)
+}
+
+// GetEntryTTLs fetches the live-until ledger for every contract-data and
+// contract-code key in keys. Other ledger key types have no TTL and are
+// skipped. Keys the RPC does not return are reported with Missing set, ahead
+// of the live entries. Entries are fetched directly from Soroban RPC,
+// bypassing the local cache, since TTLs change independently of entry contents.
+func (c *Client) GetEntryTTLs(ctx context.Context, keys []string) ([]EntryTTL, error) {
+	meta := make(map[string]EntryTTL)
+	var ttlKeys []string
+	for _, k := range keys {
+		info, ok := ttlKeyInfo(k)
+		if !ok {
+			continue
+		}
+		meta[k] = info
+		ttlKeys = append(ttlKeys, k)
+	}
+	if len(ttlKeys) == 0 {
+		return nil, nil
+	}
+
+	logger.Logger.DebugContext(ctx, "Fetching ledger entry TTLs", "count", len(ttlKeys), "url", c.SorobanURL)
+
+	var rpcResp *GetLedgerEntriesResponse
+	err := c.withSorobanFailover(ctx, func() error {
+		var err error
+		rpcResp, err = c.getLedgerEntriesRaw(ctx, ttlKeys)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	latest := uint32(rpcResp.Result.LatestLedger)
+	ttls := make([]EntryTTL, 0, len(ttlKeys))
+	for _, entry := range rpcResp.Result.Entries {
+		info, ok := meta[entry.Key]
+		if !ok {
+			continue
+		}
+		info.LiveUntilLedger = uint32(entry.LiveUntilLedger)
+		info.LatestLedger = latest
+		ttls = append(ttls, info)
+		delete(meta, entry.Key)
+	}
+
+	// Anything left in meta was requested but not returned: the entry has
+	// been archived or was never created.
+	for _, k := range ttlKeys {
+		info, ok := meta[k]
+		if !ok {
+			continue
+		}
+		logger.Logger.WarnContext(ctx, "Ledger entry not found; it may be archived", "key", k)
+		info.Missing = true
+		info.LatestLedger = latest
+		ttls = append(ttls, info)
+	}
+
+	sort.SliceStable(ttls, func(i, j int) bool {
+		if ttls[i].Missing != ttls[j].Missing {
+			return ttls[i].Missing
+		}
+		return ttls[i].LedgersRemaining() < ttls[j].LedgersRemaining()
+	})
+
+	return ttls, nil
+}
+
+// ttlKeyInfo reports whether a base64 ledger key refers to an entry that
+// carries a TTL, and describes it.
+func ttlKeyInfo(keyB64 string) (EntryTTL, bool) {
+	raw, err := base64.StdEncoding.DecodeString(keyB64)
+	if err != nil {
+		return EntryTTL{}, false
+	}
+	var key xdr.LedgerKey
+	if err := xdr.SafeUnmarshal(raw, &key); err != nil {
+		return EntryTTL{}, false
+	}
+
+	switch key.Type {
+	case xdr.LedgerEntryTypeContractData:
+		durability := "persistent"
+		if key.ContractData != nil && key.ContractData.Durability == xdr.ContractDataDurabilityTemporary {
+			durability = "temporary"
+		}
+		return EntryTTL{Key: keyB64, Type: "contract_data", Durability: durability}, true
+	case xdr.LedgerEntryTypeContractCode:
+		return EntryTTL{Key: keyB64, Type: "contract_code", Durability: "persistent"}, true
+	default:
+		return EntryTTL{}, false
+	}
+}
+
+// String renders a one-line description of the TTL state.
+func (t EntryTTL) String() string {
+	if t.Missing {
+		return fmt.Sprintf("%s (%s) not found at ledger %d (archived or never created)",
+			t.Type, t.Durability, t.LatestLedger)
+	}
+	if t.Expired() {
+		return fmt.Sprintf("%s (%s) expired %d ledgers ago (live until %d, latest %d)",
+			t.Type, t.Durability, -t.LedgersRemaining(), t.LiveUntilLedger, t.LatestLedger)
+	}
+	return fmt.Sprintf("%s (%s) expires in %d ledgers (live until %d, latest %d)",
+		t.Type, t.Durability, t.LedgersRemaining(), t.LiveUntilLedger, t.LatestLedger)
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func contractDataKeyB64(t *testing.T, symbol string, durability xdr.ContractDataDurability) string {
+	t.Helper()
+	contractID := xdr.ContractId([32]byte{0x01, 0x02, 0x03})
+	sym := xdr.ScSymbol(symbol)
+	key := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractID},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+			Durability: durability,
+		},
+	}
+	encoded, err := EncodeLedgerKey(key)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestEntryTTL_LedgersRemaining(t *testing.T) {
+	live := EntryTTL{LiveUntilLedger: 150, LatestLedger: 100}
+	assert.Equal(t, int64(50), live.LedgersRemaining())
+	assert.False(t, live.Expired())
+	assert.True(t, live.ExpiresWithin(50))
+	assert.False(t, live.ExpiresWithin(49))
+
+	expired := EntryTTL{LiveUntilLedger: 90, LatestLedger: 100}
+	assert.True(t, expired.Expired())
+	assert.Contains(t, expired.String(), "expired 10 ledgers ago")
+}
+
+func TestGetEntryTTLs(t *testing.T) {
+	persistentKey := contractDataKeyB64(t, "BALANCE", xdr.ContractDataDurabilityPersistent)
+	tempKey := contractDataKeyB64(t, "NONCE", xdr.ContractDataDurabilityTemporary)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GetLedgerEntriesRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getLedgerEntries", req.Method)
+
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"latestLedger":1000,"entries":[` +
+			`{"key":"` + persistentKey + `","xdr":"","liveUntilLedgerSeq":5000},` +
+			`{"key":"` + tempKey + `","xdr":"","liveUntilLedgerSeq":1010}]}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{SorobanURL: server.URL, AltURLs: []string{server.URL}}
+
+	ttls, err := client.GetEntryTTLs(context.Background(), []string{persistentKey, tempKey, "not-a-key"})
+	require.NoError(t, err)
+	require.Len(t, ttls, 2)
+
+	// Sorted by soonest expiration first.
+	assert.Equal(t, tempKey, ttls[0].Key)
+	assert.Equal(t, "temporary", ttls[0].Durability)
+	assert.Equal(t, int64(10), ttls[0].LedgersRemaining())
+	assert.Equal(t, "persistent", ttls[1].Durability)
+	assert.Equal(t, int64(4000), ttls[1].LedgersRemaining())
+}
+
+func TestGetEntryTTLs_NoContractKeys(t *testing.T) {
+	client := &Client{}
+	ttls, err := client.GetEntryTTLs(context.Background(), []string{"AAAA"})
+	assert.NoError(t, err)
+	assert.Empty(t, ttls)
+}
+
+func TestGetEntryTTLs_ReportsMissingKeys(t *testing.T) {
+	liveKey := contractDataKeyB64(t, "BALANCE", xdr.ContractDataDurabilityPersistent)
+	archivedKey := contractDataKeyB64(t, "OLD", xdr.ContractDataDurabilityPersistent)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"latestLedger":1000,"entries":[` +
+			`{"key":"` + liveKey + `","xdr":"","liveUntilLedgerSeq":5000}]}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{SorobanURL: server.URL}
+
+	ttls, err := client.GetEntryTTLs(context.Background(), []string{liveKey, archivedKey})
+	require.NoError(t, err)
+	require.Len(t, ttls, 2)
+
+	// Missing entries sort ahead of live ones.
+	assert.Equal(t, archivedKey, ttls[0].Key)
+	assert.True(t, ttls[0].Missing)
+	assert.Contains(t, ttls[0].String(), "archived")
+	assert.False(t, ttls[1].Missing)
+}
+
+func TestGetEntryTTLs_FailsOver(t *testing.T) {
+	key := contractDataKeyB64(t, "BALANCE", xdr.ContractDataDurabilityPersistent)
+
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	}))
+	defer bad.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"latestLedger":10,"entries":[` +
+			`{"key":"` + key + `","xdr":"","liveUntilLedgerSeq":20}]}}`))
+	}))
+	defer good.Close()
+
+	client := &Client{SorobanURL: bad.URL, AltURLs: []string{bad.URL, good.URL}}
+
+	ttls, err := client.GetEntryTTLs(context.Background(), []string{key})
+	require.NoError(t, err)
+	require.Len(t, ttls, 1)
+	assert.Equal(t, int64(10), ttls[0].LedgersRemaining())
+	assert.Equal(t, good.URL, client.SorobanURL)
+}
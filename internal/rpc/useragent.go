@@ -0,0 +1,54 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import "sync/atomic"
+
+// DefaultUserAgent is the User-Agent sent when nothing more specific is set.
+// The CLI replaces it with erst/<version> through SetUserAgent.
+const DefaultUserAgent = "erst"
+
+var defaultUserAgent atomic.Pointer[string]
+
+// SetUserAgent sets the process-wide User-Agent header for RPC clients, so
+// providers that log or rate-limit by agent can identify erst. Clients built
+// afterwards use it unless WithUserAgent is given or their NetworkConfig
+// carries its own. "" restores DefaultUserAgent.
+func SetUserAgent(ua string) {
+	if ua == "" {
+		defaultUserAgent.Store(nil)
+		return
+	}
+	defaultUserAgent.Store(&ua)
+}
+
+// processUserAgent returns the SetUserAgent value, or DefaultUserAgent.
+func processUserAgent() string {
+	if ua := defaultUserAgent.Load(); ua != nil {
+		return *ua
+	}
+	return DefaultUserAgent
+}
+
+// WithUserAgent sets the User-Agent header of both Horizon and Soroban RPC
+// requests. It overrides NetworkConfig.UserAgent and SetUserAgent, and is
+// ignored when WithHTTPClient supplies the client.
+func WithUserAgent(ua string) ClientOption {
+	return func(b *clientBuilder) error {
+		b.userAgent = ua
+		return nil
+	}
+}
+
+// resolveUserAgent picks the User-Agent for the client: WithUserAgent, then
+// the network config, then the process-wide SetUserAgent value.
+func (b *clientBuilder) resolveUserAgent() string {
+	if b.userAgent != "" {
+		return b.userAgent
+	}
+	if b.config != nil && b.config.UserAgent != "" {
+		return b.config.UserAgent
+	}
+	return processUserAgent()
+}
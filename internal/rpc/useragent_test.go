@@ -0,0 +1,64 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UserAgent(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	agent := func(opts ...ClientOption) string {
+		c, err := NewClient(append([]ClientOption{WithHorizonURL(srv.URL), WithOffline(false)}, opts...)...)
+		require.NoError(t, err)
+		resp, err := c.getHTTPClient().Get(srv.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return got
+	}
+
+	assert.Equal(t, DefaultUserAgent, agent())
+	assert.Equal(t, "my-app/2.0", agent(WithUserAgent("my-app/2.0")))
+
+	cfg := TestnetConfig
+	cfg.UserAgent = "from-config"
+	assert.Equal(t, "from-config", agent(WithNetworkConfig(cfg)))
+	assert.Equal(t, "option", agent(WithNetworkConfig(cfg), WithUserAgent("option")), "the option overrides the network config")
+
+	SetUserAgent("erst/v1.2.3")
+	t.Cleanup(func() { SetUserAgent("") })
+	assert.Equal(t, "erst/v1.2.3", agent(), "the process-wide agent applies to new clients")
+	assert.Equal(t, "from-config", agent(WithNetworkConfig(cfg)))
+
+	SetUserAgent("")
+	assert.Equal(t, DefaultUserAgent, agent())
+}
+
+func TestClient_UserAgentWithToken(t *testing.T) {
+	var agent, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agent, auth = r.Header.Get("User-Agent"), r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(WithHorizonURL(srv.URL), WithOffline(false), WithToken("secret"), WithUserAgent("ua"))
+	require.NoError(t, err)
+	resp, err := c.getHTTPClient().Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "ua", agent)
+	assert.Equal(t, "Bearer secret", auth)
+}
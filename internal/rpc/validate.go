@@ -35,6 +35,11 @@ func isValidURL(urlStr string) error {
 	return nil
 }
 
+// ValidateURL checks that urlStr is an absolute http(s) URL with a host.
+func ValidateURL(urlStr string) error {
+	return isValidURL(urlStr)
+}
+
 func ValidateNetworkConfig(config NetworkConfig) error {
 	if config.Name == "" {
 		return errors.WrapValidationError("network name is required")
@@ -60,6 +65,16 @@ func ValidateNetworkConfig(config NetworkConfig) error {
 		}
 	}
 
+	if config.ArchivalRPCURL != "" {
+		if err := isValidURL(config.ArchivalRPCURL); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("invalid ArchivalRPCURL: %v", err))
+		}
+	}
+
+	if config.RateLimit < 0 {
+		return errors.WrapValidationError("RateLimit must not be negative")
+	}
+
 	if config.HorizonURL == "" && config.SorobanRPCURL == "" {
 		return errors.WrapValidationError("at least one of HorizonURL or SorobanRPCURL must be provided")
 	}
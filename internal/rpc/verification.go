@@ -5,7 +5,6 @@ package rpc
 
 import (
 	"crypto/sha256"
-	"bytes"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
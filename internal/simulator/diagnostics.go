@@ -0,0 +1,179 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Event types reported in DiagnosticEvent.EventType.
+const (
+	EventTypeContract   = "contract"
+	EventTypeSystem     = "system"
+	EventTypeDiagnostic = "diagnostic"
+)
+
+// IsDiagnostic reports whether e is a host diagnostic (call traces, errors,
+// budget notes) rather than an event emitted by a contract or the system.
+func (e DiagnosticEvent) IsDiagnostic() bool {
+	return strings.EqualFold(e.EventType, EventTypeDiagnostic)
+}
+
+// SplitEvents separates contract and system events from host diagnostics,
+// preserving order within each group.
+func SplitEvents(events []DiagnosticEvent) (contractEvents, diagnostics []DiagnosticEvent) {
+	for _, e := range events {
+		if e.IsDiagnostic() {
+			diagnostics = append(diagnostics, e)
+		} else {
+			contractEvents = append(contractEvents, e)
+		}
+	}
+	return contractEvents, diagnostics
+}
+
+// FailureDiagnostic is the host diagnostic that explains why a contract call
+// reverted: the error it raised, the message that came with it and the chain
+// of calls that led there.
+type FailureDiagnostic struct {
+	ContractID string `json:"contract_id,omitempty"`
+	Error      string `json:"error"`
+	// ErrorName and ErrorDoc name a contract error code after the case of
+	// the contract's error enum that defines it, when its spec is known.
+	ErrorName string `json:"error_name,omitempty"`
+	ErrorDoc  string `json:"error_doc,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Data      string `json:"data,omitempty"`
+
+	// CallStack lists the frames active when the error was raised, outermost
+	// first.
+	CallStack []CallFrame `json:"call_stack,omitempty"`
+}
+
+// ContractErrorCode returns the code of a contract-defined error, 5 for
+// Error(Contract, #5).
+func (f *FailureDiagnostic) ContractErrorCode() (uint32, bool) {
+	code, ok := strings.CutPrefix(f.Error, "Error(Contract, #")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(strings.TrimSuffix(code, ")"), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(n), true
+}
+
+// CallFrame is one contract function invocation on the call stack.
+type CallFrame struct {
+	ContractID string `json:"contract_id,omitempty"`
+	Function   string `json:"function"`
+}
+
+var (
+	// scErrorPattern matches the debug form of an ScVal error, e.g.
+	// Error(Contract(3)) or Error(Auth(InvalidAction)).
+	scErrorPattern = regexp.MustCompile(`^Error\((\w+)\((\w+)\)\)$`)
+	// stringPattern captures the first string literal in a debug-formatted
+	// value, e.g. String(ScString(StringM(balance too low))).
+	stringPattern = regexp.MustCompile(`StringM\(([^)]*)\)`)
+)
+
+// DecodeFailure finds the first error diagnostic in events, which is where
+// the failure originated before the host escalated it up the call stack, and
+// decodes it together with the call stack at that point. It returns nil when
+// no error diagnostic was emitted.
+func DecodeFailure(events []DiagnosticEvent) *FailureDiagnostic {
+	var stack []CallFrame
+	for _, e := range events {
+		if !e.IsDiagnostic() || len(e.Topics) == 0 {
+			continue
+		}
+
+		switch unwrapDebugValue(e.Topics[0]) {
+		case "fn_call":
+			frame := CallFrame{}
+			if len(e.Topics) > 1 {
+				frame.ContractID = unwrapDebugValue(e.Topics[1])
+			}
+			if len(e.Topics) > 2 {
+				frame.Function = unwrapDebugValue(e.Topics[2])
+			}
+			stack = append(stack, frame)
+
+		case "fn_return":
+			if len(stack) == 0 {
+				continue
+			}
+			name := ""
+			if len(e.Topics) > 1 {
+				name = unwrapDebugValue(e.Topics[1])
+			}
+			// Unwind to the matching frame; calls that trapped never return.
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].Function == name {
+					stack = stack[:i]
+					break
+				}
+			}
+
+		case "error":
+			f := &FailureDiagnostic{
+				Data:      e.Data,
+				CallStack: append([]CallFrame(nil), stack...),
+			}
+			if e.ContractID != nil {
+				f.ContractID = *e.ContractID
+			}
+			if len(e.Topics) > 1 {
+				f.Error = formatScError(e.Topics[1])
+			}
+			if m := stringPattern.FindStringSubmatch(e.Data); m != nil {
+				f.Message = m[1]
+			} else if !strings.ContainsAny(e.Data, "()") {
+				f.Message = e.Data
+			}
+			return f
+		}
+	}
+	return nil
+}
+
+// formatScError renders Error(Contract(3)) as Error(Contract, #3) and
+// Error(Auth(InvalidAction)) as Error(Auth, InvalidAction), the form used by
+// the Stellar CLI. Anything else is returned unchanged.
+func formatScError(topic string) string {
+	m := scErrorPattern.FindStringSubmatch(strings.TrimSpace(topic))
+	if m == nil {
+		return topic
+	}
+	code := m[2]
+	if strings.Trim(code, "0123456789") == "" {
+		code = "#" + code
+	}
+	return "Error(" + m[1] + ", " + code + ")"
+}
+
+// unwrapDebugValue strips the type wrappers the simulator's debug formatting
+// puts around symbols and strings, so Symbol(ScSymbol(StringM(fn_call)))
+// and Symbol("fn_call") both become fn_call. Other values are returned
+// trimmed but otherwise unchanged.
+func unwrapDebugValue(s string) string {
+	s = strings.TrimSpace(s)
+	for {
+		open := strings.IndexByte(s, '(')
+		if open <= 0 || !strings.HasSuffix(s, ")") {
+			break
+		}
+		switch s[:open] {
+		case "Symbol", "ScSymbol", "String", "ScString", "StringM":
+			s = s[open+1 : len(s)-1]
+			continue
+		}
+		break
+	}
+	return strings.Trim(s, `"`)
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import "testing"
+
+func failureFixture() []DiagnosticEvent {
+	token := "CTOKEN"
+	return []DiagnosticEvent{
+		{EventType: "diagnostic", Topics: []string{"Symbol(ScSymbol(StringM(fn_call)))", "Bytes(CROUTER)", "Symbol(ScSymbol(StringM(swap)))"}},
+		{EventType: "diagnostic", Topics: []string{"Symbol(ScSymbol(StringM(fn_call)))", "Bytes(CTOKEN)", "Symbol(ScSymbol(StringM(balance)))"}},
+		{EventType: "diagnostic", Topics: []string{"Symbol(ScSymbol(StringM(fn_return)))", "Symbol(ScSymbol(StringM(balance)))"}},
+		{EventType: "contract", ContractID: &token, Topics: []string{`Symbol("approve")`}},
+		{EventType: "diagnostic", Topics: []string{"Symbol(ScSymbol(StringM(fn_call)))", "Bytes(CTOKEN)", "Symbol(ScSymbol(StringM(transfer)))"}},
+		{
+			EventType:  "diagnostic",
+			ContractID: &token,
+			Topics:     []string{"Symbol(ScSymbol(StringM(error)))", "Error(Contract(3))"},
+			Data:       "Vec(Some(ScVec(VecM([String(ScString(StringM(balance is not sufficient))), I128(5)]))))",
+		},
+		{EventType: "diagnostic", Topics: []string{"Symbol(ScSymbol(StringM(error)))", "Error(WasmVm(InvalidAction))"}},
+	}
+}
+
+func TestSplitEvents(t *testing.T) {
+	contractEvents, diagnostics := SplitEvents(failureFixture())
+	if len(contractEvents) != 1 || contractEvents[0].EventType != "contract" {
+		t.Errorf("expected one contract event, got %+v", contractEvents)
+	}
+	if len(diagnostics) != 6 {
+		t.Errorf("expected 6 diagnostics, got %d", len(diagnostics))
+	}
+}
+
+func TestDecodeFailure(t *testing.T) {
+	f := DecodeFailure(failureFixture())
+	if f == nil {
+		t.Fatal("expected a failure diagnostic")
+	}
+	if f.Error != "Error(Contract, #3)" {
+		t.Errorf("Error = %q", f.Error)
+	}
+	if f.ContractID != "CTOKEN" {
+		t.Errorf("ContractID = %q", f.ContractID)
+	}
+	if f.Message != "balance is not sufficient" {
+		t.Errorf("Message = %q", f.Message)
+	}
+
+	want := []CallFrame{{ContractID: "Bytes(CROUTER)", Function: "swap"}, {ContractID: "Bytes(CTOKEN)", Function: "transfer"}}
+	if len(f.CallStack) != len(want) {
+		t.Fatalf("CallStack = %+v, want %+v", f.CallStack, want)
+	}
+	for i := range want {
+		if f.CallStack[i] != want[i] {
+			t.Errorf("CallStack[%d] = %+v, want %+v", i, f.CallStack[i], want[i])
+		}
+	}
+}
+
+func TestDecodeFailure_NoErrorDiagnostic(t *testing.T) {
+	events := failureFixture()[:4]
+	if f := DecodeFailure(events); f != nil {
+		t.Errorf("expected nil, got %+v", f)
+	}
+}
+
+func TestFormatScError(t *testing.T) {
+	cases := map[string]string{
+		"Error(Contract(3))":           "Error(Contract, #3)",
+		"Error(Auth(InvalidAction))":   "Error(Auth, InvalidAction)",
+		"Error(Budget, ExceededLimit)": "Error(Budget, ExceededLimit)",
+	}
+	for in, want := range cases {
+		if got := formatScError(in); got != want {
+			t.Errorf("formatScError(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFailureDiagnostic_ContractErrorCode(t *testing.T) {
+	cases := map[string]struct {
+		code uint32
+		ok   bool
+	}{
+		"Error(Contract, #5)":          {5, true},
+		"Error(Contract, #4294967295)": {4294967295, true},
+		"Error(Auth, InvalidAction)":   {0, false},
+		"Error(Contract, #x)":          {0, false},
+	}
+	for in, want := range cases {
+		code, ok := (&FailureDiagnostic{Error: in}).ContractErrorCode()
+		if code != want.code || ok != want.ok {
+			t.Errorf("ContractErrorCode(%q) = %d, %v, want %d, %v", in, code, ok, want.code, want.ok)
+		}
+	}
+}
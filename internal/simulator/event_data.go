@@ -0,0 +1,41 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// DefaultEventDataLimit is the length, in bytes, beyond which event data is
+// abbreviated for display.
+const DefaultEventDataLimit = 256
+
+// AbbreviateEventData returns data unchanged when it is at most limit bytes
+// long, and otherwise its length and SHA-256 in its place. Equal payloads
+// abbreviate identically, so large payloads can still be compared by eye.
+// A limit of zero or less disables abbreviation.
+func AbbreviateEventData(data string, limit int) string {
+	if limit <= 0 || len(data) <= limit {
+		return data
+	}
+	sum := sha256.Sum256([]byte(data))
+	return fmt.Sprintf("<%d bytes, sha256:%s>", len(data), hex.EncodeToString(sum[:]))
+}
+
+// AbbreviateEvent returns a copy of e with its data and topics abbreviated
+// by AbbreviateEventData.
+func AbbreviateEvent(e DiagnosticEvent, limit int) DiagnosticEvent {
+	if limit <= 0 {
+		return e
+	}
+	e.Data = AbbreviateEventData(e.Data, limit)
+	topics := make([]string, len(e.Topics))
+	for i, t := range e.Topics {
+		topics[i] = AbbreviateEventData(t, limit)
+	}
+	e.Topics = topics
+	return e
+}
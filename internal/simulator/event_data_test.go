@@ -0,0 +1,35 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAbbreviateEventData(t *testing.T) {
+	assert.Equal(t, "short", AbbreviateEventData("short", 10))
+	assert.Equal(t, "0123456789", AbbreviateEventData("0123456789", 10))
+
+	big := strings.Repeat("ab", 600)
+	got := AbbreviateEventData(big, DefaultEventDataLimit)
+	assert.True(t, strings.HasPrefix(got, "<1200 bytes, sha256:"), got)
+	assert.Equal(t, got, AbbreviateEventData(strings.Repeat("ab", 600), DefaultEventDataLimit), "equal payloads abbreviate identically")
+	assert.NotEqual(t, got, AbbreviateEventData(big[:1199]+"c", DefaultEventDataLimit))
+
+	assert.Equal(t, big, AbbreviateEventData(big, 0), "a zero limit keeps the full data")
+}
+
+func TestAbbreviateEvent(t *testing.T) {
+	big := strings.Repeat("x", 50)
+	e := DiagnosticEvent{EventType: EventTypeContract, Topics: []string{"transfer", big}, Data: big}
+
+	got := AbbreviateEvent(e, 20)
+	assert.Equal(t, "transfer", got.Topics[0])
+	assert.Contains(t, got.Topics[1], "50 bytes")
+	assert.Contains(t, got.Data, "50 bytes")
+	assert.Equal(t, big, e.Topics[1], "the original event is left alone")
+}
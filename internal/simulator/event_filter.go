@@ -0,0 +1,144 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"strings"
+	"unicode"
+)
+
+// EventFilter restricts which events of a SimulationResponse are reported.
+// An event is kept when it matches at least one contract ID (if any are set)
+// and at least one topic (if any are set), and is at least MinSeverity (if
+// set). A zero-value filter keeps everything.
+type EventFilter struct {
+	ContractIDs []string
+	Topics      []string
+
+	// MinSeverity drops events less severe than it, as classified by
+	// Classifier. Empty or info keeps every event.
+	MinSeverity EventSeverity
+	Classifier  *EventClassifier
+}
+
+// IsEmpty reports whether the filter has no criteria.
+func (f EventFilter) IsEmpty() bool {
+	return len(f.ContractIDs) == 0 && len(f.Topics) == 0 && !f.filtersSeverity()
+}
+
+func (f EventFilter) filtersSeverity() bool {
+	return f.MinSeverity != "" && f.MinSeverity != SeverityInfo
+}
+
+// MatchDiagnostic reports whether a structured diagnostic event passes the filter.
+func (f EventFilter) MatchDiagnostic(ev DiagnosticEvent) bool {
+	if len(f.ContractIDs) > 0 {
+		if ev.ContractID == nil || !containsString(f.ContractIDs, *ev.ContractID) {
+			return false
+		}
+	}
+	if len(f.Topics) > 0 && !topicsMatch(f.Topics, ev.Topics) {
+		return false
+	}
+	if f.filtersSeverity() && !f.Classifier.Classify(ev).AtLeast(f.MinSeverity) {
+		return false
+	}
+	return true
+}
+
+// MatchRaw reports whether a raw (string-encoded) event passes the filter.
+// Raw events carry no structure, so criteria are matched against whole
+// tokens; "mint" does not match "minted" or "admin_mint".
+func (f EventFilter) MatchRaw(ev string) bool {
+	tokens := rawTokens(ev)
+	if len(f.ContractIDs) > 0 && !containsAny(tokens, f.ContractIDs) {
+		return false
+	}
+	if len(f.Topics) > 0 && !containsAny(tokens, f.Topics) {
+		return false
+	}
+	if f.filtersSeverity() && !f.Classifier.ClassifyRaw(ev).AtLeast(f.MinSeverity) {
+		return false
+	}
+	return true
+}
+
+// Apply returns a shallow copy of resp whose event slices only contain events
+// matching the filter. The original response is never modified, and nil is
+// returned unchanged.
+func (f EventFilter) Apply(resp *SimulationResponse) *SimulationResponse {
+	if resp == nil || f.IsEmpty() {
+		return resp
+	}
+
+	filtered := *resp
+	filtered.Events = nil
+	filtered.DiagnosticEvents = nil
+	filtered.CategorizedEvents = nil
+
+	for _, ev := range resp.Events {
+		if f.MatchRaw(ev) {
+			filtered.Events = append(filtered.Events, ev)
+		}
+	}
+	for _, ev := range resp.DiagnosticEvents {
+		if f.MatchDiagnostic(ev) {
+			filtered.DiagnosticEvents = append(filtered.DiagnosticEvents, ev)
+		}
+	}
+	for _, ev := range resp.CategorizedEvents {
+		diag := DiagnosticEvent{EventType: ev.EventType, ContractID: ev.ContractID, Topics: ev.Topics, Data: ev.Data}
+		if f.MatchDiagnostic(diag) {
+			filtered.CategorizedEvents = append(filtered.CategorizedEvents, ev)
+		}
+	}
+	return &filtered
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(list, wanted []string) bool {
+	for _, w := range wanted {
+		if w != "" && containsString(list, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// rawTokens splits a raw event string into identifier-like tokens.
+func rawTokens(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+}
+
+// topicsMatch reports whether any event topic is exactly one of the wanted
+// symbols. Topics are rendered ScVals, so a symbol may appear either bare or
+// wrapped (e.g. `Symbol("transfer")`); the wrapper is stripped before comparing.
+func topicsMatch(wanted, topics []string) bool {
+	for _, topic := range topics {
+		if containsString(wanted, TopicSymbol(topic)) {
+			return true
+		}
+	}
+	return false
+}
+
+// TopicSymbol returns the symbol inside a `Symbol("<x>")` topic, or the
+// topic unchanged when it is not in that form.
+func TopicSymbol(topic string) string {
+	const prefix, suffix = `Symbol("`, `")`
+	if strings.HasPrefix(topic, prefix) && strings.HasSuffix(topic, suffix) && len(topic) >= len(prefix)+len(suffix) {
+		return topic[len(prefix) : len(topic)-len(suffix)]
+	}
+	return topic
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import "testing"
+
+func filterFixture() *SimulationResponse {
+	a, b := "CAAA", "CBBB"
+	return &SimulationResponse{
+		Status: "success",
+		Events: []string{"CAAA transfer 10", "CBBB mint 5"},
+		DiagnosticEvents: []DiagnosticEvent{
+			{EventType: "contract", ContractID: &a, Topics: []string{`Symbol("transfer")`}},
+			{EventType: "contract", ContractID: &b, Topics: []string{`Symbol("mint")`}},
+			{EventType: "diagnostic", Topics: []string{"fn_call"}},
+		},
+	}
+}
+
+func TestEventFilter_EmptyKeepsEverything(t *testing.T) {
+	resp := filterFixture()
+	if got := (EventFilter{}).Apply(resp); got != resp {
+		t.Error("expected empty filter to return the original response")
+	}
+}
+
+func TestEventFilter_ByContract(t *testing.T) {
+	resp := filterFixture()
+	got := EventFilter{ContractIDs: []string{"CAAA"}}.Apply(resp)
+
+	if len(got.DiagnosticEvents) != 1 || *got.DiagnosticEvents[0].ContractID != "CAAA" {
+		t.Errorf("expected only CAAA diagnostic event, got %+v", got.DiagnosticEvents)
+	}
+	if len(got.Events) != 1 || got.Events[0] != "CAAA transfer 10" {
+		t.Errorf("expected only CAAA raw event, got %v", got.Events)
+	}
+	if len(resp.DiagnosticEvents) != 3 {
+		t.Error("original response must not be modified")
+	}
+}
+
+func TestEventFilter_ByTopic(t *testing.T) {
+	got := EventFilter{Topics: []string{"mint"}}.Apply(filterFixture())
+
+	if len(got.DiagnosticEvents) != 1 || *got.DiagnosticEvents[0].ContractID != "CBBB" {
+		t.Errorf("expected only mint event, got %+v", got.DiagnosticEvents)
+	}
+}
+
+func TestEventFilter_ContractAndTopic(t *testing.T) {
+	got := EventFilter{ContractIDs: []string{"CAAA"}, Topics: []string{"mint"}}.Apply(filterFixture())
+
+	if len(got.DiagnosticEvents) != 0 || len(got.Events) != 0 {
+		t.Errorf("expected no events to match both criteria, got %+v / %v", got.DiagnosticEvents, got.Events)
+	}
+}
+
+func TestEventFilter_TopicIsExactNotPrefix(t *testing.T) {
+	a, b := "CAAA", "CBBB"
+	resp := &SimulationResponse{
+		Events: []string{"CAAA minted 10", "CBBB admin_mint 5", "CAAA mint 1"},
+		DiagnosticEvents: []DiagnosticEvent{
+			{EventType: "contract", ContractID: &a, Topics: []string{`Symbol("minted")`}},
+			{EventType: "contract", ContractID: &b, Topics: []string{`Symbol("admin_mint")`}},
+			{EventType: "contract", ContractID: &b, Topics: []string{"mint"}},
+		},
+	}
+	got := EventFilter{Topics: []string{"mint"}}.Apply(resp)
+
+	if len(got.DiagnosticEvents) != 1 || got.DiagnosticEvents[0].Topics[0] != "mint" {
+		t.Errorf("expected only the exact mint topic, got %+v", got.DiagnosticEvents)
+	}
+	if len(got.Events) != 1 || got.Events[0] != "CAAA mint 1" {
+		t.Errorf("expected only the exact mint raw event, got %v", got.Events)
+	}
+}
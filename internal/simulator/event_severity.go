@@ -0,0 +1,200 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// EventSeverity says how much an event matters to someone debugging a
+// transaction.
+type EventSeverity string
+
+const (
+	SeverityInfo    EventSeverity = "info"
+	SeverityWarning EventSeverity = "warning"
+	SeverityError   EventSeverity = "error"
+)
+
+// Severities lists every severity, least severe first.
+var Severities = []EventSeverity{SeverityInfo, SeverityWarning, SeverityError}
+
+// ParseEventSeverity parses info, warning or error.
+func ParseEventSeverity(s string) (EventSeverity, error) {
+	for _, sev := range Severities {
+		if strings.EqualFold(s, string(sev)) {
+			return sev, nil
+		}
+	}
+	return "", fmt.Errorf("unknown severity %q (expected info, warning or error)", s)
+}
+
+// AtLeast reports whether s is as severe as min. An empty severity counts as
+// info.
+func (s EventSeverity) AtLeast(min EventSeverity) bool {
+	return s.rank() >= min.rank()
+}
+
+func (s EventSeverity) rank() int {
+	for i, sev := range Severities {
+		if s == sev {
+			return i
+		}
+	}
+	return 0
+}
+
+// Zero addresses, the conventional destination of a burn by transfer.
+const (
+	zeroAccount  = "GAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAWHF"
+	zeroContract = "CAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABSC4"
+)
+
+// SeverityRule assigns Severity to events whose Field matches Value: topic
+// (one of the topics is the symbol), contract (the emitting contract ID) or
+// data (the data contains the text).
+type SeverityRule struct {
+	Severity EventSeverity
+	Field    string
+	Value    string
+}
+
+// Severity rule fields.
+const (
+	SeverityFieldTopic    = "topic"
+	SeverityFieldContract = "contract"
+	SeverityFieldData     = "data"
+)
+
+func (r SeverityRule) match(ev DiagnosticEvent) bool {
+	switch r.Field {
+	case SeverityFieldTopic:
+		return topicsMatch([]string{r.Value}, ev.Topics)
+	case SeverityFieldContract:
+		return ev.ContractID != nil && *ev.ContractID == r.Value
+	case SeverityFieldData:
+		return strings.Contains(ev.Data, r.Value)
+	}
+	return false
+}
+
+func (r SeverityRule) matchRaw(ev string, tokens []string) bool {
+	if r.Field == SeverityFieldData {
+		return strings.Contains(ev, r.Value)
+	}
+	return containsString(tokens, r.Value)
+}
+
+// EventClassifier assigns a severity to events. Rules are tried in order and
+// the first match wins; events no rule matches get the built-in severity:
+// error diagnostics are errors, burns, clawbacks and transfers to a zero
+// address are warnings, and everything else is info.
+type EventClassifier struct {
+	Rules []SeverityRule
+}
+
+// Classify returns the severity of a structured event.
+func (c *EventClassifier) Classify(ev DiagnosticEvent) EventSeverity {
+	if c != nil {
+		for _, r := range c.Rules {
+			if r.match(ev) {
+				return r.Severity
+			}
+		}
+	}
+	if len(ev.Topics) == 0 {
+		return SeverityInfo
+	}
+	switch unwrapDebugValue(ev.Topics[0]) {
+	case "error":
+		return SeverityError
+	case "burn", "clawback":
+		return SeverityWarning
+	case "transfer":
+		for _, topic := range ev.Topics[1:] {
+			if strings.Contains(topic, zeroAccount) || strings.Contains(topic, zeroContract) {
+				return SeverityWarning
+			}
+		}
+	}
+	return SeverityInfo
+}
+
+// ClassifyRaw returns the severity of a raw (string-encoded) event. Raw
+// events carry no structure, so rules and the built-in patterns are matched
+// against its whole tokens, as EventFilter.MatchRaw does.
+func (c *EventClassifier) ClassifyRaw(ev string) EventSeverity {
+	tokens := rawTokens(ev)
+	if c != nil {
+		for _, r := range c.Rules {
+			if r.matchRaw(ev, tokens) {
+				return r.Severity
+			}
+		}
+	}
+	switch {
+	case containsString(tokens, "error"):
+		return SeverityError
+	case containsAny(tokens, []string{"burn", "clawback", zeroAccount, zeroContract}):
+		return SeverityWarning
+	}
+	return SeverityInfo
+}
+
+// Annotate returns a shallow copy of resp with the Severity of every
+// structured event set. The original response is never modified, and nil is
+// returned unchanged.
+func (c *EventClassifier) Annotate(resp *SimulationResponse) *SimulationResponse {
+	if resp == nil || len(resp.DiagnosticEvents) == 0 {
+		return resp
+	}
+	annotated := *resp
+	annotated.DiagnosticEvents = make([]DiagnosticEvent, len(resp.DiagnosticEvents))
+	for i, ev := range resp.DiagnosticEvents {
+		ev.Severity = c.Classify(ev)
+		annotated.DiagnosticEvents[i] = ev
+	}
+	return &annotated
+}
+
+// ParseSeverityRules parses a severity rule file: one rule per line of the
+// form
+//
+//	<severity> <field> <value>
+//
+// for example "error topic liquidated" or "warning contract CABC...".
+// Blank lines and lines starting with '#' are ignored.
+func ParseSeverityRules(r io.Reader) ([]SeverityRule, error) {
+	var rules []SeverityRule
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("line %d: usage: <severity> topic|contract|data <value>", lineNo)
+		}
+		sev, err := ParseEventSeverity(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		switch fields[1] {
+		case SeverityFieldTopic, SeverityFieldContract, SeverityFieldData:
+		default:
+			return nil, fmt.Errorf("line %d: unknown field %q (expected topic, contract or data)", lineNo, fields[1])
+		}
+		rules = append(rules, SeverityRule{Severity: sev, Field: fields[1], Value: strings.Join(fields[2:], " ")})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventClassifier_BuiltIn(t *testing.T) {
+	tests := []struct {
+		name  string
+		event DiagnosticEvent
+		want  EventSeverity
+	}{
+		{"error diagnostic", DiagnosticEvent{EventType: "diagnostic", Topics: []string{`Symbol("error")`, "Error(Contract(3))"}}, SeverityError},
+		{"burn", DiagnosticEvent{EventType: "contract", Topics: []string{`Symbol("burn")`, "GABC"}}, SeverityWarning},
+		{"clawback", DiagnosticEvent{EventType: "contract", Topics: []string{"clawback"}}, SeverityWarning},
+		{"transfer to zero", DiagnosticEvent{EventType: "contract", Topics: []string{`Symbol("transfer")`, "GABC", "Address(" + zeroAccount + ")"}}, SeverityWarning},
+		{"transfer", DiagnosticEvent{EventType: "contract", Topics: []string{`Symbol("transfer")`, "GABC", "GDEF"}}, SeverityInfo},
+		{"call trace", DiagnosticEvent{EventType: "diagnostic", Topics: []string{"fn_call"}}, SeverityInfo},
+		{"no topics", DiagnosticEvent{EventType: "contract"}, SeverityInfo},
+	}
+	var c *EventClassifier
+	for _, tt := range tests {
+		if got := c.Classify(tt.event); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEventClassifier_RulesComeFirst(t *testing.T) {
+	id := "CAAA"
+	c := &EventClassifier{Rules: []SeverityRule{
+		{Severity: SeverityError, Field: SeverityFieldTopic, Value: "liquidated"},
+		{Severity: SeverityInfo, Field: SeverityFieldContract, Value: "CAAA"},
+		{Severity: SeverityWarning, Field: SeverityFieldData, Value: "paused"},
+	}}
+
+	if got := c.Classify(DiagnosticEvent{Topics: []string{`Symbol("liquidated")`}}); got != SeverityError {
+		t.Errorf("topic rule: got %q", got)
+	}
+	if got := c.Classify(DiagnosticEvent{ContractID: &id, Topics: []string{"burn"}}); got != SeverityInfo {
+		t.Errorf("contract rule should override the burn pattern: got %q", got)
+	}
+	if got := c.Classify(DiagnosticEvent{Data: `String("contract paused")`}); got != SeverityWarning {
+		t.Errorf("data rule: got %q", got)
+	}
+	if got := c.ClassifyRaw("CBBB liquidated 10"); got != SeverityError {
+		t.Errorf("raw topic rule: got %q", got)
+	}
+}
+
+func TestEventClassifier_ClassifyRaw(t *testing.T) {
+	var c *EventClassifier
+	if got := c.ClassifyRaw("error Contract 3"); got != SeverityError {
+		t.Errorf("got %q", got)
+	}
+	if got := c.ClassifyRaw("CAAA burn 10"); got != SeverityWarning {
+		t.Errorf("got %q", got)
+	}
+	if got := c.ClassifyRaw("CAAA burned 10"); got != SeverityInfo {
+		t.Errorf("partial tokens must not match: got %q", got)
+	}
+}
+
+func TestEventClassifier_Annotate(t *testing.T) {
+	resp := &SimulationResponse{DiagnosticEvents: []DiagnosticEvent{
+		{EventType: "diagnostic", Topics: []string{"error"}},
+		{EventType: "contract", Topics: []string{"mint"}},
+	}}
+	var c *EventClassifier
+	got := c.Annotate(resp)
+	if got.DiagnosticEvents[0].Severity != SeverityError || got.DiagnosticEvents[1].Severity != SeverityInfo {
+		t.Errorf("unexpected severities: %+v", got.DiagnosticEvents)
+	}
+	if resp.DiagnosticEvents[0].Severity != "" {
+		t.Error("Annotate modified the original response")
+	}
+	if c.Annotate(nil) != nil {
+		t.Error("expected nil for a nil response")
+	}
+}
+
+func TestEventFilter_MinSeverity(t *testing.T) {
+	resp := &SimulationResponse{
+		Events: []string{"CAAA transfer 10", "CAAA burn 5", "error Contract 3"},
+		DiagnosticEvents: []DiagnosticEvent{
+			{EventType: "contract", Topics: []string{"transfer"}},
+			{EventType: "contract", Topics: []string{"burn"}},
+			{EventType: "diagnostic", Topics: []string{"error"}},
+		},
+	}
+
+	if !(EventFilter{MinSeverity: SeverityInfo}).IsEmpty() {
+		t.Error("a minimum of info should keep everything")
+	}
+
+	got := EventFilter{MinSeverity: SeverityWarning}.Apply(resp)
+	if len(got.DiagnosticEvents) != 2 || len(got.Events) != 2 {
+		t.Errorf("warning: got %d structured and %d raw events", len(got.DiagnosticEvents), len(got.Events))
+	}
+	got = EventFilter{MinSeverity: SeverityError}.Apply(resp)
+	if len(got.DiagnosticEvents) != 1 || got.Events[0] != "error Contract 3" {
+		t.Errorf("error: got %+v", got)
+	}
+}
+
+func TestParseSeverityRules(t *testing.T) {
+	rules, err := ParseSeverityRules(strings.NewReader(`
+# protocol events
+error topic liquidated
+warning  data contract paused
+info contract CAAA
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []SeverityRule{
+		{Severity: SeverityError, Field: SeverityFieldTopic, Value: "liquidated"},
+		{Severity: SeverityWarning, Field: SeverityFieldData, Value: "contract paused"},
+		{Severity: SeverityInfo, Field: SeverityFieldContract, Value: "CAAA"},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("got %d rules, want %d", len(rules), len(want))
+	}
+	for i := range want {
+		if rules[i] != want[i] {
+			t.Errorf("rule %d: got %+v, want %+v", i, rules[i], want[i])
+		}
+	}
+
+	for _, bad := range []string{"error topic", "fatal topic x", "error name x"} {
+		if _, err := ParseSeverityRules(strings.NewReader(bad)); err == nil || !strings.Contains(err.Error(), "line 1") {
+			t.Errorf("%q: expected a line 1 error, got %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// FailureCategory says why a simulated transaction failed, separating a
+// contract that reverted from one that ran out of resources, fee,
+// authorization or state.
+type FailureCategory string
+
+const (
+	FailureResourceLimitExceeded FailureCategory = "RESOURCE_LIMIT_EXCEEDED"
+	FailureInsufficientFee       FailureCategory = "INSUFFICIENT_FEE"
+	FailureContractTrap          FailureCategory = "CONTRACT_TRAP"
+	FailureAuthFailed            FailureCategory = "AUTH_FAILED"
+	FailureStorageMissing        FailureCategory = "STORAGE_MISSING"
+)
+
+// Description explains the category in a sentence, with the usual next
+// step.
+func (c FailureCategory) Description() string {
+	switch c {
+	case FailureResourceLimitExceeded:
+		return "the transaction ran out of its declared resources (CPU, memory or ledger I/O); re-simulate it and raise its limits"
+	case FailureInsufficientFee:
+		return "the declared resource fee does not cover the resources and rent the transaction used"
+	case FailureContractTrap:
+		return "the contract reverted: it returned an error or trapped"
+	case FailureAuthFailed:
+		return "a required authorization was missing or invalid"
+	case FailureStorageMissing:
+		return "a ledger entry the transaction needs is missing or archived; restore it or check the footprint"
+	default:
+		return "the failure could not be classified"
+	}
+}
+
+// Reason is the error sentinel for the category, for exit-code mapping. A
+// contract trap, like an unclassified failure, is a plain revert.
+func (c FailureCategory) Reason() error {
+	switch c {
+	case FailureResourceLimitExceeded:
+		return errors.ErrResourceLimitExceeded
+	case FailureInsufficientFee:
+		return errors.ErrInsufficientFee
+	case FailureAuthFailed:
+		return errors.ErrAuthFailed
+	case FailureStorageMissing:
+		return errors.ErrStorageMissing
+	default:
+		return errors.ErrSimulationReverted
+	}
+}
+
+// failurePatterns maps fragments of a normalized error (see
+// normalizeFailure) to categories. They are tried in order, so fee and
+// resource exhaustion win over the generic trap a host raises for them.
+var failurePatterns = []struct {
+	category  FailureCategory
+	fragments []string
+}{
+	{FailureInsufficientFee, []string{"insufficientrefundablefee", "insufficientfee", "resourcefeeexceeded"}},
+	{FailureResourceLimitExceeded, []string{"error(budget", "exceededlimit", "limitexceeded", "budgetexceeded"}},
+	{FailureStorageMissing, []string{"entryarchived", "archived", "error(storage", "missingledgerkey"}},
+	{FailureAuthFailed, []string{"error(auth", "authfailed", "authorizationfailed", "notauthorized"}},
+	{FailureContractTrap, []string{"error(contract", "error(wasmvm", "wasmtrap", "trapped", "unreachable", "panic"}},
+}
+
+// ClassifyFailure categorizes a failed simulation from the error diagnostic
+// the host emitted, which names the failure most precisely, and then from
+// the simulator's error message. It returns "" for a successful simulation
+// or a failure no pattern matches.
+func ClassifyFailure(res *SimulationResponse) FailureCategory {
+	if res == nil || (res.Status != "error" && res.Error == "") {
+		return ""
+	}
+	if f := DecodeFailure(res.DiagnosticEvents); f != nil {
+		if c := classifyFailureText(f.Error); c != "" {
+			return c
+		}
+	}
+	return classifyFailureText(res.Error)
+}
+
+func classifyFailureText(text string) FailureCategory {
+	norm := normalizeFailure(text)
+	if norm == "" {
+		return ""
+	}
+	for _, p := range failurePatterns {
+		for _, f := range p.fragments {
+			if strings.Contains(norm, f) {
+				return p.category
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeFailure lowercases text and drops spaces and underscores, so
+// InsufficientRefundableFee, INSUFFICIENT_REFUNDABLE_FEE and "insufficient
+// refundable fee" read alike, as do Error(Budget, ExceededLimit) and
+// Error(Budget(ExceededLimit)).
+func normalizeFailure(text string) string {
+	return strings.NewReplacer(" ", "", "_", "").Replace(strings.ToLower(text))
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func errorDiagnostic(scError string) []DiagnosticEvent {
+	return []DiagnosticEvent{{
+		EventType: EventTypeDiagnostic,
+		Topics:    []string{`Symbol("error")`, scError},
+		Data:      `String("failed")`,
+	}}
+}
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *SimulationResponse
+		want FailureCategory
+	}{
+		{"success", &SimulationResponse{Status: "success"}, ""},
+		{"nil", nil, ""},
+		{"budget diagnostic", &SimulationResponse{Status: "error", DiagnosticEvents: errorDiagnostic("Error(Budget(ExceededLimit))")}, FailureResourceLimitExceeded},
+		{"footprint limit", &SimulationResponse{Status: "error", DiagnosticEvents: errorDiagnostic("Error(Storage(ExceededLimit))")}, FailureResourceLimitExceeded},
+		{"missing entry", &SimulationResponse{Status: "error", DiagnosticEvents: errorDiagnostic("Error(Storage(MissingValue))")}, FailureStorageMissing},
+		{"auth", &SimulationResponse{Status: "error", DiagnosticEvents: errorDiagnostic("Error(Auth(InvalidAction))")}, FailureAuthFailed},
+		{"contract error", &SimulationResponse{Status: "error", DiagnosticEvents: errorDiagnostic("Error(Contract(3))")}, FailureContractTrap},
+		{"refundable fee", &SimulationResponse{Status: "error", Error: "transaction failed: INSUFFICIENT_REFUNDABLE_FEE"}, FailureInsufficientFee},
+		{"archived", &SimulationResponse{Status: "error", Error: "HostError: entry archived"}, FailureStorageMissing},
+		{"wasm trap", &SimulationResponse{Status: "error", Error: "Wasm Trap: unreachable"}, FailureContractTrap},
+		{"error without status", &SimulationResponse{Error: "Error(Budget, ExceededLimit)"}, FailureResourceLimitExceeded},
+		{"unclassified", &SimulationResponse{Status: "error", Error: "something odd"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyFailure(tt.res))
+		})
+	}
+}
+
+func TestFailureCategory_Reason(t *testing.T) {
+	assert.ErrorIs(t, FailureResourceLimitExceeded.Reason(), errors.ErrResourceLimitExceeded)
+	assert.ErrorIs(t, FailureStorageMissing.Reason(), errors.ErrSimulationReverted, "every category is a revert")
+	assert.Equal(t, errors.ErrSimulationReverted, FailureContractTrap.Reason())
+	assert.Equal(t, errors.ErrSimulationReverted, FailureCategory("").Reason())
+}
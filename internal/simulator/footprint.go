@@ -0,0 +1,58 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// normalizeFootprint re-encodes ReadKeys and WriteKeys canonically, sorted
+// and without duplicates, so they compare equal to keys decoded elsewhere.
+// A key reported as both read and written is only kept in WriteKeys.
+func (r *SimulationResponse) normalizeFootprint() error {
+	write, err := canonicalKeys(r.WriteKeys, nil)
+	if err != nil {
+		return fmt.Errorf("write key: %w", err)
+	}
+	read, err := canonicalKeys(r.ReadKeys, write)
+	if err != nil {
+		return fmt.Errorf("read key: %w", err)
+	}
+	r.ReadKeys, r.WriteKeys = keysOf(read), keysOf(write)
+	return nil
+}
+
+// canonicalKeys decodes and re-encodes keys, leaving out any in skip.
+func canonicalKeys(keys []string, skip map[string]bool) (map[string]bool, error) {
+	out := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		var key xdr.LedgerKey
+		if err := xdr.SafeUnmarshalBase64(k, &key); err != nil {
+			return nil, fmt.Errorf("%q is not a base64 XDR LedgerKey: %w", k, err)
+		}
+		encoded, err := xdr.MarshalBase64(key)
+		if err != nil {
+			return nil, err
+		}
+		if !skip[encoded] {
+			out[encoded] = true
+		}
+	}
+	return out, nil
+}
+
+func keysOf(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
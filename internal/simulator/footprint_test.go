@@ -0,0 +1,56 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func footprintKey(t *testing.T, address string) string {
+	t.Helper()
+	key := xdr.LedgerKey{Type: xdr.LedgerEntryTypeAccount, Account: &xdr.LedgerKeyAccount{AccountId: xdr.MustAddress(address)}}
+	encoded, err := xdr.MarshalBase64(key)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestNormalizeFootprint(t *testing.T) {
+	a := footprintKey(t, "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	b := footprintKey(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+
+	resp := &SimulationResponse{ReadKeys: []string{b, a, a}, WriteKeys: []string{b}}
+	require.NoError(t, resp.normalizeFootprint())
+	assert.Equal(t, []string{a}, resp.ReadKeys, "written keys are not also listed as read")
+	assert.Equal(t, []string{b}, resp.WriteKeys)
+
+	empty := &SimulationResponse{}
+	require.NoError(t, empty.normalizeFootprint())
+	assert.Nil(t, empty.ReadKeys)
+	assert.Nil(t, empty.WriteKeys)
+
+	assert.Error(t, (&SimulationResponse{ReadKeys: []string{"not-xdr"}}).normalizeFootprint())
+}
+
+func TestRunner_ReturnsFootprint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the simulator")
+	}
+	key := footprintKey(t, "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	bin := filepath.Join(t.TempDir(), "erst-sim")
+	script := fmt.Sprintf("#!/bin/sh\ncat >/dev/null\necho '{\"status\":\"success\",\"read_keys\":[\"%s\"]}'\n", key)
+	require.NoError(t, os.WriteFile(bin, []byte(script), 0o755))
+
+	resp, err := (&Runner{BinaryPath: bin}).Run(&SimulationRequest{EnvelopeXdr: "AAAA"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{key}, resp.ReadKeys)
+	assert.Empty(t, resp.WriteKeys)
+}
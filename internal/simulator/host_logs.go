@@ -0,0 +1,51 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+var hostLogs atomic.Bool
+
+// SetHostLogs makes every Runner pass on what the simulator host writes to
+// stderr: its tracing output and diagnostics, which often name the exact
+// panic location inside the contract. Each line is logged through
+// logger.Logger at debug level once the run finishes. Unless RUST_LOG is
+// already set, the host is also asked for its debug-level tracing.
+func SetHostLogs(enabled bool) {
+	hostLogs.Store(enabled)
+}
+
+// hostLogEnv returns the environment for the simulator process, or nil to
+// inherit ours unchanged.
+func hostLogEnv() []string {
+	if !hostLogs.Load() {
+		return nil
+	}
+	if _, ok := os.LookupEnv("RUST_LOG"); ok {
+		return nil
+	}
+	return append(os.Environ(), "RUST_LOG=debug")
+}
+
+// logHostOutput logs the simulator's stderr line by line when SetHostLogs
+// is on.
+func logHostOutput(ctx context.Context, stderr string) {
+	if !hostLogs.Load() {
+		return
+	}
+	for _, line := range strings.Split(stderr, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		logger.Logger.DebugContext(ctx, "Simulator host", "line", line)
+	}
+}
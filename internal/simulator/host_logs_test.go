@@ -0,0 +1,49 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogHostOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger.SetOutput(&buf, false)
+	logger.SetLevel(slog.LevelDebug)
+	t.Cleanup(func() {
+		SetHostLogs(false)
+		logger.SetOutput(os.Stderr, false)
+		logger.SetLevel(slog.LevelInfo)
+	})
+
+	stderr := "INFO host: loading 2 entries\n\nERROR panicked at src/lib.rs:42:9: overflow\r\n"
+	logHostOutput(context.Background(), stderr)
+	assert.Empty(t, buf.String())
+
+	SetHostLogs(true)
+	logHostOutput(context.Background(), stderr)
+	assert.Equal(t, 2, bytes.Count(buf.Bytes(), []byte("Simulator host")))
+	assert.Contains(t, buf.String(), "loading 2 entries")
+	assert.Contains(t, buf.String(), "src/lib.rs:42:9")
+}
+
+func TestHostLogEnv(t *testing.T) {
+	t.Cleanup(func() { SetHostLogs(false) })
+
+	assert.Nil(t, hostLogEnv())
+
+	SetHostLogs(true)
+	t.Setenv("RUST_LOG", "warn")
+	assert.Nil(t, hostLogEnv())
+
+	os.Unsetenv("RUST_LOG")
+	assert.Contains(t, hostLogEnv(), "RUST_LOG=debug")
+}
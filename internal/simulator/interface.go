@@ -3,11 +3,32 @@
 
 package simulator
 
+import "context"
+
 // RunnerInterface defines the contract for simulator execution
 type RunnerInterface interface {
 	Run(req *SimulationRequest) (*SimulationResponse, error)
 }
 
+// ContextRunner is implemented by runners that can abandon a simulation when
+// a context is cancelled.
+type ContextRunner interface {
+	RunContext(ctx context.Context, req *SimulationRequest) (*SimulationResponse, error)
+}
+
+// RunWithContext runs req on runner, stopping the simulation when ctx is done
+// if the runner supports it. Other runners are only checked for
+// cancellation before they start.
+func RunWithContext(ctx context.Context, runner RunnerInterface, req *SimulationRequest) (*SimulationResponse, error) {
+	if cr, ok := runner.(ContextRunner); ok {
+		return cr.RunContext(ctx, req)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return runner.Run(req)
+}
+
 // NewRunnerInterface creates a RunnerInterface implementation
 // This allows for easy swapping between real and mock implementations
 func NewRunnerInterface() (RunnerInterface, error) {
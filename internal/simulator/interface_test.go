@@ -4,7 +4,12 @@
 package simulator
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -58,3 +63,34 @@ func (m *mockRunnerForTest) Run(req *SimulationRequest) (*SimulationResponse, er
 		Events: []string{"mock-event"},
 	}, nil
 }
+
+func TestRunWithContext_CancelledBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	resp, err := RunWithContext(ctx, &mockRunnerForTest{}, &SimulationRequest{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, resp)
+
+	resp, err = RunWithContext(context.Background(), &mockRunnerForTest{}, &SimulationRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "success", resp.Status)
+}
+
+func TestRunner_RunContextKillsSimulator(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the simulator")
+	}
+	bin := filepath.Join(t.TempDir(), "slow-sim")
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\nexec sleep 30\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := (&Runner{BinaryPath: bin}).RunContext(ctx, &SimulationRequest{EnvelopeXdr: "AAAA", ResultMetaXdr: "AAAA"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
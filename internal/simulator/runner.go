@@ -5,7 +5,9 @@ package simulator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -21,6 +23,7 @@ type Runner struct {
 	BinaryPath string
 	Debug      bool
 	MockTime   int64 // non-zero overrides Timestamp in every SimulationRequest
+	Validator  *Validator
 }
 
 // Compile-time check to ensure Runner implements RunnerInterface
@@ -33,7 +36,18 @@ var _ RunnerInterface = (*Runner)(nil)
 // 3. Local directory
 // 4. Dev target
 // 5. Global PATH
-func NewRunner(simPathOverride string, debug bool) (*Runner, error) {
+//
+// It returns a RunnerInterface so callers can be handed a fake in tests; see
+// the simtest package.
+func NewRunner(simPathOverride string, debug bool) (RunnerInterface, error) {
+	r, err := newRunner(simPathOverride, debug)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func newRunner(simPathOverride string, debug bool) (*Runner, error) {
 	path, source, err := findSimBinary(simPathOverride)
 	if err != nil {
 		return nil, err
@@ -56,8 +70,8 @@ func NewRunner(simPathOverride string, debug bool) (*Runner, error) {
 
 // NewRunnerWithMockTime creates a Runner that overrides the ledger timestamp on
 // every request with the provided Unix epoch value. Pass 0 to disable the override.
-func NewRunnerWithMockTime(simPathOverride string, debug bool, mockTime int64) (*Runner, error) {
-	r, err := NewRunner(simPathOverride, debug)
+func NewRunnerWithMockTime(simPathOverride string, debug bool, mockTime int64) (RunnerInterface, error) {
+	r, err := newRunner(simPathOverride, debug)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +79,6 @@ func NewRunnerWithMockTime(simPathOverride string, debug bool, mockTime int64) (
 	return r, nil
 }
 
-
 // -------------------- Binary Discovery --------------------
 
 func findSimBinary(simPathOverride string) (string, string, error) {
@@ -143,10 +156,15 @@ func abs(path string) string {
 // -------------------- Execution --------------------
 
 func (r *Runner) Run(req *SimulationRequest) (*SimulationResponse, error) {
+	return r.RunContext(context.Background(), req)
+}
+
+// RunContext is Run, killing the simulator process if ctx is done first.
+func (r *Runner) RunContext(ctx context.Context, req *SimulationRequest) (*SimulationResponse, error) {
 	// Validate request before processing
 	if r.Validator != nil {
 		if err := r.Validator.ValidateRequest(req); err != nil {
-			logger.Logger.Error("Request validation failed", "error", err)
+			logger.Logger.ErrorContext(ctx, "Request validation failed", "error", err)
 			return nil, err
 		}
 	}
@@ -169,36 +187,54 @@ func (r *Runner) Run(req *SimulationRequest) (*SimulationResponse, error) {
 
 	inputBytes, err := json.Marshal(req)
 	if err != nil {
-		logger.Logger.Error("Failed to marshal simulation request", "error", err)
+		logger.Logger.ErrorContext(ctx, "Failed to marshal simulation request", "error", err)
 		return nil, errors.WrapMarshalFailed(err)
 	}
 
-	cmd := exec.Command(r.BinaryPath)
+	cmd := exec.CommandContext(ctx, r.BinaryPath)
 	cmd.Stdin = bytes.NewReader(inputBytes)
+	cmd.Env = hostLogEnv()
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		logger.Logger.Error("Simulator execution failed", "error", err, "stderr", stderr.String())
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		logger.Logger.ErrorContext(ctx, "Simulator execution failed", "error", err, "stderr", stderr.String())
 		return nil, errors.WrapSimCrash(err, stderr.String())
 	}
+	// A crash already carries stderr; a clean exit, including a contract
+	// that panicked, would otherwise drop it
+	logHostOutput(ctx, stderr.String())
 
 	var resp SimulationResponse
 	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
-		logger.Logger.Error("Failed to unmarshal response", "error", err)
+		logger.Logger.ErrorContext(ctx, "Failed to unmarshal response", "error", err)
 		return nil, errors.WrapUnmarshalFailed(err, stdout.String())
 	}
+	if err := resp.normalizeFootprint(); err != nil {
+		logger.Logger.ErrorContext(ctx, "Simulator returned an invalid footprint", "error", err)
+		return nil, errors.WrapUnmarshalFailed(err, "simulation footprint")
+	}
+
+	resp.FailureCategory = ClassifyFailure(&resp)
 
 	// If the simulator returned a logical error inside the response payload,
 	// classify it into a unified ErstError before returning to the caller.
 	if resp.Error != "" {
-		classified := ipc.Error{Message: resp.Error}.ToErstError()
-		logger.Logger.Error("Simulator returned error",
+		classified := (&ipc.Error{Message: resp.Error}).ToErstError()
+		logger.Logger.ErrorContext(ctx, "Simulator returned error",
 			"code", classified.Code,
+			"category", resp.FailureCategory,
 			"original", classified.OriginalError,
 		)
+		// A failure that is not the contract's own carries its reason too
+		if c := resp.FailureCategory; c != "" && c != FailureContractTrap {
+			return nil, fmt.Errorf("%w: %w", c.Reason(), classified)
+		}
 		return nil, classified
 	}
 
@@ -233,4 +269,4 @@ func (r *Runner) applyProtocolConfig(req *SimulationRequest, proto *Protocol) er
 	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -55,6 +55,9 @@ type DiagnosticEvent struct {
 	Data                     string   `json:"data"`
 	InSuccessfulContractCall bool     `json:"in_successful_contract_call"`
 	WasmInstruction          *string  `json:"wasm_instruction,omitempty"`
+
+	// Severity is set by erst, not the simulator; see EventClassifier.
+	Severity EventSeverity `json:"severity,omitempty"`
 }
 
 // BudgetUsage represents resource consumption during simulation
@@ -71,6 +74,8 @@ type BudgetUsage struct {
 type SimulationResponse struct {
 	Status            string               `json:"status"` // "success" or "error"
 	Error             string               `json:"error,omitempty"`
+	FailureCategory   FailureCategory      `json:"failure_category,omitempty"`  // Why a failed simulation failed, see ClassifyFailure; empty on success
+	Warnings          []Warning            `json:"warnings,omitempty"`          // Soft problems that do not fail the simulation, see Warning
 	Events            []string             `json:"events,omitempty"`            // Raw event strings (backward compatibility)
 	DiagnosticEvents  []DiagnosticEvent    `json:"diagnostic_events,omitempty"` // Structured diagnostic events
 	Logs              []string             `json:"logs,omitempty"`              // Host debug logs
@@ -82,6 +87,14 @@ type SimulationResponse struct {
 	StackTrace        *WasmStackTrace      `json:"stack_trace,omitempty"`      // Enhanced WASM stack trace on traps
 	SourceLocation    string               `json:"source_location,omitempty"`
 	WasmOffset        *uint64              `json:"wasm_offset,omitempty"`
+
+	// ReadKeys and WriteKeys are the footprint the simulation actually used:
+	// the ledger keys it read only and those it could write, as base64 XDR
+	// LedgerKeys in the same encoding as keys extracted from result meta.
+	// Runner.Run sorts them; they are empty when the simulator does not
+	// report a footprint.
+	ReadKeys  []string `json:"read_keys,omitempty"`
+	WriteKeys []string `json:"write_keys,omitempty"`
 }
 
 type CategorizedEvent struct {
@@ -101,10 +114,10 @@ type SecurityViolation struct {
 
 // SourceLocation represents a precise position in Rust/WASM source code.
 type SourceLocation struct {
-	File      string  `json:"file"`
-	Line      uint    `json:"line"`
-	Column    uint    `json:"column"`
-	ColumnEnd *uint   `json:"column_end,omitempty"`
+	File      string `json:"file"`
+	Line      uint   `json:"line"`
+	Column    uint   `json:"column"`
+	ColumnEnd *uint  `json:"column_end,omitempty"`
 }
 
 // Session represents a stored simulation result
@@ -0,0 +1,75 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package simtest provides fake simulators for testing code that takes a
+// simulator.RunnerInterface, so orchestration such as debug, compare and diff
+// can be tested without the erst-sim binary.
+package simtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// RunFunc computes the response to one simulation request.
+type RunFunc func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error)
+
+// MockRunner is a simulator.RunnerInterface that answers every request with
+// its RunFunc and records the requests it was given. It is safe for
+// concurrent use, as the runners passed to compare and multi-network debug
+// runs must be.
+type MockRunner struct {
+	fn RunFunc
+
+	mu       sync.Mutex
+	requests []*simulator.SimulationRequest
+}
+
+var (
+	_ simulator.RunnerInterface = (*MockRunner)(nil)
+	_ simulator.ContextRunner   = (*MockRunner)(nil)
+)
+
+// NewMockRunner returns a MockRunner that answers with fn. A nil fn answers
+// every request with a successful, empty response.
+func NewMockRunner(fn RunFunc) *MockRunner {
+	if fn == nil {
+		fn = func(*simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+			return &simulator.SimulationResponse{Status: "success"}, nil
+		}
+	}
+	return &MockRunner{fn: fn}
+}
+
+// Returning returns a MockRunner that answers every request with res and err.
+func Returning(res *simulator.SimulationResponse, err error) *MockRunner {
+	return NewMockRunner(func(*simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		return res, err
+	})
+}
+
+// Run records req and answers it.
+func (m *MockRunner) Run(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+	m.mu.Lock()
+	m.requests = append(m.requests, req)
+	m.mu.Unlock()
+	return m.fn(req)
+}
+
+// RunContext is Run, except that a request made after ctx is done fails with
+// the context's error without being recorded.
+func (m *MockRunner) RunContext(ctx context.Context, req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.Run(req)
+}
+
+// Requests returns the requests run so far, in the order they arrived.
+func (m *MockRunner) Requests() []*simulator.SimulationRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*simulator.SimulationRequest(nil), m.requests...)
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockRunner_Default(t *testing.T) {
+	m := NewMockRunner(nil)
+	req := &simulator.SimulationRequest{EnvelopeXdr: "AAAA"}
+
+	res, err := m.Run(req)
+	require.NoError(t, err)
+	assert.Equal(t, "success", res.Status)
+	assert.Equal(t, []*simulator.SimulationRequest{req}, m.Requests())
+}
+
+func TestReturning(t *testing.T) {
+	boom := errors.New("boom")
+	_, err := Returning(nil, boom).Run(&simulator.SimulationRequest{})
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestMockRunner_RunWithContext(t *testing.T) {
+	m := NewMockRunner(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err := simulator.RunWithContext(ctx, m, &simulator.SimulationRequest{})
+	require.NoError(t, err)
+
+	cancel()
+	_, err = simulator.RunWithContext(ctx, m, &simulator.SimulationRequest{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, m.Requests(), 1)
+}
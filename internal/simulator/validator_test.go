@@ -0,0 +1,52 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func TestValidateRequest_Valid(t *testing.T) {
+	validXDR := base64.StdEncoding.EncodeToString([]byte("valid xdr data"))
+	req := &SimulationRequest{
+		EnvelopeXdr:   validXDR,
+		ResultMetaXdr: validXDR,
+	}
+
+	if err := NewValidator(false).ValidateRequest(req); err != nil {
+		t.Fatalf("expected valid request, got %v", err)
+	}
+}
+
+func TestValidateRequest_Invalid(t *testing.T) {
+	validXDR := base64.StdEncoding.EncodeToString([]byte("valid xdr data"))
+	tests := []struct {
+		name string
+		req  *SimulationRequest
+		code string
+	}{
+		{"nil request", nil, "ERR_NULL_REQUEST"},
+		{"empty envelope", &SimulationRequest{ResultMetaXdr: validXDR}, "ERR_EMPTY_FIELD"},
+		{"invalid base64", &SimulationRequest{EnvelopeXdr: "not base64!", ResultMetaXdr: validXDR}, "ERR_INVALID_BASE64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewValidator(false).ValidateRequest(tt.req)
+			var vErr *ValidationError
+			if !errors.As(err, &vErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if vErr.Code != tt.code {
+				t.Errorf("expected code %s, got %s", tt.code, vErr.Code)
+			}
+		})
+	}
+}
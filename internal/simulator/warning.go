@@ -0,0 +1,36 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+// Warning is a soft problem found while preparing or running a simulation:
+// something that may make the result differ from the network's without
+// failing the run. Code identifies the kind of problem for programmatic
+// consumers; Message explains it for people.
+type Warning struct {
+	Code    WarningCode `json:"code"`
+	Message string      `json:"message"`
+	// Keys lists the base64 ledger keys the warning is about, when it
+	// concerns particular entries.
+	Keys []string `json:"keys,omitempty"`
+}
+
+// WarningCode identifies the kind of a Warning.
+type WarningCode string
+
+const (
+	// WarningMissingEntries: footprint entries the ledger state source does
+	// not hold are simulated as absent.
+	WarningMissingEntries WarningCode = "MISSING_ENTRIES"
+	// WarningArchivedEntries: footprint entries the network did not return,
+	// because they are archived or were never created.
+	WarningArchivedEntries WarningCode = "ARCHIVED_ENTRIES"
+	// WarningTTLExpiring: footprint entries that have expired or expire soon.
+	WarningTTLExpiring WarningCode = "TTL_EXPIRING"
+	// WarningProtocolMismatch: the compared networks run different protocol
+	// versions.
+	WarningProtocolMismatch WarningCode = "PROTOCOL_MISMATCH"
+	// WarningUndeclaredFootprint: the transaction changed entries its
+	// declared footprint does not list.
+	WarningUndeclaredFootprint WarningCode = "UNDECLARED_FOOTPRINT"
+)
@@ -0,0 +1,76 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Source looks up ledger entries in a snapshot by ledger key, standing in for
+// an RPC server's getLedgerEntries so a simulation can run entirely from
+// locally captured state.
+type Source struct {
+	entries map[string]string
+}
+
+// NewSource indexes the snapshot's entries by ledger key. Keys are
+// normalised, so lookups match however the snapshot's writer encoded them.
+func NewSource(snap *Snapshot) (*Source, error) {
+	entries := make(map[string]string, len(snap.LedgerEntries))
+	for i, t := range snap.LedgerEntries {
+		if len(t) != 2 {
+			return nil, fmt.Errorf("ledgerEntries[%d] must be a [key, entry] pair", i)
+		}
+		key, err := normalizeKey(t[0])
+		if err != nil {
+			return nil, fmt.Errorf("ledgerEntries[%d]: invalid key: %w", i, err)
+		}
+		entries[key] = t[1]
+	}
+	return &Source{entries: entries}, nil
+}
+
+// LoadSource reads a snapshot file and indexes it.
+func LoadSource(path string) (*Source, error) {
+	snap, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSource(snap)
+}
+
+// Len returns the number of entries the snapshot holds.
+func (s *Source) Len() int {
+	return len(s.entries)
+}
+
+// GetLedgerEntries returns the snapshot's entries for keys, under the keys as
+// given. Like getLedgerEntries, keys the snapshot does not hold are left out.
+func (s *Source) GetLedgerEntries(ctx context.Context, keys []string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	found := make(map[string]string, len(keys))
+	for _, k := range keys {
+		key, err := normalizeKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ledger key %q: %w", k, err)
+		}
+		if entry, ok := s.entries[key]; ok {
+			found[k] = entry
+		}
+	}
+	return found, nil
+}
+
+func normalizeKey(raw string) (string, error) {
+	var key xdr.LedgerKey
+	if err := xdr.SafeUnmarshalBase64(raw, &key); err != nil {
+		return "", err
+	}
+	return xdr.MarshalBase64(key)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func accountKey(t *testing.T, address string) string {
+	t.Helper()
+	key, err := xdr.LedgerKey{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: xdr.MustAddress(address)},
+	}.MarshalBinaryBase64()
+	require.NoError(t, err)
+	return key
+}
+
+func TestSource_GetLedgerEntries(t *testing.T) {
+	keyA := accountKey(t, "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	keyB := accountKey(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+
+	source, err := NewSource(FromMap(map[string]string{keyA: "entry-a"}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, source.Len())
+
+	entries, err := source.GetLedgerEntries(context.Background(), []string{keyA, keyB})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{keyA: "entry-a"}, entries, "keys the snapshot lacks are left out")
+
+	_, err = source.GetLedgerEntries(context.Background(), []string{"not-a-key"})
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = source.GetLedgerEntries(ctx, []string{keyA})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewSource_Invalid(t *testing.T) {
+	_, err := NewSource(&Snapshot{LedgerEntries: []LedgerEntryTuple{{"only-a-key"}}})
+	assert.ErrorContains(t, err, "[key, entry] pair")
+
+	_, err = NewSource(&Snapshot{LedgerEntries: []LedgerEntryTuple{{"not-a-key", "entry"}}})
+	assert.ErrorContains(t, err, "invalid key")
+}
+
+func TestLoadSource(t *testing.T) {
+	key := accountKey(t, "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, Save(path, FromMap(map[string]string{key: "entry"})))
+
+	source, err := LoadSource(path)
+	require.NoError(t, err)
+	entries, err := source.GetLedgerEntries(context.Background(), []string{key})
+	require.NoError(t, err)
+	assert.Equal(t, "entry", entries[key])
+
+	_, err = LoadSource(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -5,8 +5,9 @@ package terminal
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"sync"
+	"sync/atomic"
 
 	"github.com/mattn/go-isatty"
 )
@@ -24,49 +25,82 @@ const (
 	sgrBold    = "\033[1m"
 )
 
+// colorDisabled is set by SetColorEnabled(false) and overrides the
+// environment for every renderer.
+var colorDisabled atomic.Bool
+
+// SetColorEnabled turns color off for all renderers when enabled is false,
+// ahead of anything the environment says, as --no-color does. With enabled
+// true, color is again decided from the environment and the terminal.
+func SetColorEnabled(enabled bool) {
+	colorDisabled.Store(!enabled)
+}
+
 type ANSIRenderer struct {
-	isTTY   bool
-	ttyOnce sync.Once
+	out io.Writer
 }
 
 func NewANSIRenderer() *ANSIRenderer {
 	return &ANSIRenderer{}
 }
 
-func (r *ANSIRenderer) IsTTY() bool {
-	r.ttyOnce.Do(func() {
-		r.isTTY = r.checkTTY()
-	})
-	return r.isTTY
+// NewANSIRendererTo creates a renderer that prints to w instead of stdout.
+func NewANSIRendererTo(w io.Writer) *ANSIRenderer {
+	return &ANSIRenderer{out: w}
 }
 
-func (r *ANSIRenderer) checkTTY() bool {
+func (r *ANSIRenderer) writer() io.Writer {
+	if r.out != nil {
+		return r.out
+	}
+	return os.Stdout
+}
+
+// IsTTY reports whether color output should be used. SetColorEnabled(false)
+// disables it outright. Otherwise the environment is re-read on every call
+// so changes take effect immediately, and the usual conventions apply, in
+// this order: a non-empty NO_COLOR disables color,
+// FORCE_COLOR or CLICOLOR_FORCE (other than "0") enables it even off a
+// terminal, and CLICOLOR=0 or TERM=dumb disables it on one.
+func (r *ANSIRenderer) IsTTY() bool {
+	if colorDisabled.Load() {
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
 	if os.Getenv("FORCE_COLOR") != "" {
 		return true
 	}
-	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+	if v := os.Getenv("CLICOLOR_FORCE"); v != "" && v != "0" {
+		return true
+	}
+	if os.Getenv("CLICOLOR") == "0" {
 		return false
 	}
 	if os.Getenv("TERM") == "dumb" {
 		return false
 	}
-	return isatty.IsTerminal(os.Stdout.Fd())
+	if f, ok := r.writer().(*os.File); ok {
+		return isatty.IsTerminal(f.Fd())
+	}
+	return false
 }
 
 func (r *ANSIRenderer) Print(a ...any) {
-	fmt.Print(a...)
+	fmt.Fprint(r.writer(), a...)
 }
 
 func (r *ANSIRenderer) Printf(format string, a ...any) {
-	fmt.Printf(format, a...)
+	fmt.Fprintf(r.writer(), format, a...)
 }
 
 func (r *ANSIRenderer) Println(a ...any) {
-	fmt.Println(a...)
+	fmt.Fprintln(r.writer(), a...)
 }
 
 func (r *ANSIRenderer) ClearLine() {
-	fmt.Print("\r\033[K")
+	fmt.Fprint(r.writer(), "\r\033[K")
 }
 
 func (r *ANSIRenderer) Scanln(a ...any) (int, error) {
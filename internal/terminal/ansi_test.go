@@ -34,6 +34,75 @@ func TestANSIRenderer_IsTTY(t *testing.T) {
 	os.Unsetenv("TERM")
 }
 
+func TestANSIRenderer_IsTTYColorConventions(t *testing.T) {
+	r := NewANSIRenderer()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"empty NO_COLOR is ignored", map[string]string{"NO_COLOR": "", "FORCE_COLOR": "1"}, true},
+		{"NO_COLOR wins over FORCE_COLOR", map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, false},
+		{"CLICOLOR=0 disables", map[string]string{"CLICOLOR": "0", "TERM": "xterm"}, false},
+		{"FORCE_COLOR wins over CLICOLOR=0", map[string]string{"CLICOLOR": "0", "FORCE_COLOR": "1"}, true},
+		{"CLICOLOR_FORCE enables", map[string]string{"CLICOLOR_FORCE": "1"}, true},
+		{"CLICOLOR_FORCE=0 is ignored", map[string]string{"CLICOLOR_FORCE": "0"}, false},
+		{"NO_COLOR wins over CLICOLOR_FORCE", map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"NO_COLOR", "FORCE_COLOR", "CLICOLOR", "CLICOLOR_FORCE", "TERM"} {
+				t.Setenv(k, "")
+				os.Unsetenv(k)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if got := r.IsTTY(); got != tt.want {
+				t.Errorf("IsTTY() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestANSIRenderer_IsTTYRereadsEnvironment(t *testing.T) {
+	r := NewANSIRenderer()
+	t.Setenv("FORCE_COLOR", "1")
+	if !r.IsTTY() {
+		t.Fatal("IsTTY() should be true when FORCE_COLOR is set")
+	}
+
+	// The same renderer sees NO_COLOR set after its first answer
+	t.Setenv("NO_COLOR", "1")
+	if r.IsTTY() {
+		t.Error("IsTTY() should be false once NO_COLOR is set")
+	}
+	os.Unsetenv("NO_COLOR")
+	if !r.IsTTY() {
+		t.Error("IsTTY() should be true again once NO_COLOR is unset")
+	}
+}
+
+func TestSetColorEnabled_OverridesEnvironment(t *testing.T) {
+	t.Cleanup(func() { SetColorEnabled(true) })
+	t.Setenv("FORCE_COLOR", "1")
+	r := NewANSIRenderer()
+
+	SetColorEnabled(false)
+	if r.IsTTY() {
+		t.Error("IsTTY() should be false when color is disabled, even with FORCE_COLOR")
+	}
+	if got := r.Colorize("hello", "red"); got != "hello" {
+		t.Errorf("Expected plain text with color disabled, got %q", got)
+	}
+
+	SetColorEnabled(true)
+	if !r.IsTTY() {
+		t.Error("IsTTY() should follow FORCE_COLOR once color is enabled again")
+	}
+}
+
 func TestANSIRenderer_Colorize(t *testing.T) {
 	r := NewANSIRenderer()
 	os.Setenv("FORCE_COLOR", "1")
@@ -11,31 +11,20 @@ import (
 
 // ExecutionState represents the state at a specific point in execution
 type ExecutionState struct {
-	Step           int                    `json:"step"`
-	Timestamp      time.Time              `json:"timestamp"`
-	Operation      string                 `json:"operation"`
-	ContractID     string                 `json:"contract_id,omitempty"`
-	Function       string                 `json:"function,omitempty"`
-	Arguments      []interface{}          `json:"arguments,omitempty"`
-	RawArguments   []string               `json:"raw_arguments,omitempty"`
-	ReturnValue    interface{}            `json:"return_value,omitempty"`
-	RawReturnValue string                 `json:"raw_return_value,omitempty"`
-	Error          string                 `json:"error,omitempty"`
-	HostState      map[string]interface{} `json:"host_state,omitempty"`
+	Step            int                    `json:"step"`
+	Timestamp       time.Time              `json:"timestamp"`
+	Operation       string                 `json:"operation"`
+	EventType       string                 `json:"event_type,omitempty"` // trap, contract_call, host_function, auth, or empty for inferred
+	ContractID      string                 `json:"contract_id,omitempty"`
+	Function        string                 `json:"function,omitempty"`
+	Arguments       []interface{}          `json:"arguments,omitempty"`
+	RawArguments    []string               `json:"raw_arguments,omitempty"`
+	ReturnValue     interface{}            `json:"return_value,omitempty"`
+	RawReturnValue  string                 `json:"raw_return_value,omitempty"`
+	Error           string                 `json:"error,omitempty"`
+	HostState       map[string]interface{} `json:"host_state,omitempty"`
 	Memory          map[string]interface{} `json:"memory,omitempty"`
 	WasmInstruction string                 `json:"wasm_instruction,omitempty"`
-	Memory         map[string]interface{} `json:"memory,omitempty"`
-	Step        int                    `json:"step"`
-	Timestamp   time.Time              `json:"timestamp"`
-	Operation   string                 `json:"operation"`
-	EventType   string                 `json:"event_type,omitempty"` // trap, contract_call, host_function, auth, or empty for inferred
-	ContractID  string                 `json:"contract_id,omitempty"`
-	Function    string                 `json:"function,omitempty"`
-	Arguments   []interface{}          `json:"arguments,omitempty"`
-	ReturnValue interface{}            `json:"return_value,omitempty"`
-	Error       string                 `json:"error,omitempty"`
-	HostState   map[string]interface{} `json:"host_state,omitempty"`
-	Memory      map[string]interface{} `json:"memory,omitempty"`
 }
 
 // DefaultSnapshotInterval is the number of steps between state snapshots.
@@ -7,6 +7,19 @@ import (
 	"github.com/dotandev/hintents/internal/terminal"
 )
 
+// ANSI SGR codes used by the theme palettes
+const (
+	sgrReset   = "\033[0m"
+	sgrRed     = "\033[31m"
+	sgrGreen   = "\033[32m"
+	sgrYellow  = "\033[33m"
+	sgrBlue    = "\033[34m"
+	sgrMagenta = "\033[35m"
+	sgrCyan    = "\033[36m"
+	sgrDim     = "\033[2m"
+	sgrBold    = "\033[1m"
+)
+
 var defaultRenderer terminal.Renderer = terminal.NewANSIRenderer()
 
 // ColorEnabled reports whether ANSI color output should be used.
@@ -19,9 +32,6 @@ func Colorize(text string, color string) string {
 	return defaultRenderer.Colorize(text, color)
 }
 
-// Success returns a success indicator.
-func Success() string {
-	return defaultRenderer.Success()
 // ContractBoundary returns a visual separator for cross-contract call transitions.
 func ContractBoundary(fromContract, toContract string) string {
 	if ColorEnabled() {
@@ -40,7 +50,6 @@ func Success() string {
 
 // Warning returns a warning indicator.
 func Warning() string {
-	return defaultRenderer.Warning()
 	if ColorEnabled() {
 		return themeColors("warning") + "[!]" + sgrReset
 	}
@@ -49,10 +58,6 @@ func Warning() string {
 
 // Error returns an error indicator.
 func Error() string {
-	return defaultRenderer.Error()
-}
-
-// Symbol returns a symbol that may be styled.
 	if ColorEnabled() {
 		return themeColors("error") + "[X]" + sgrReset
 	}
@@ -68,8 +73,6 @@ func Info() string {
 }
 
 // Symbol returns a symbol that may be styled; when colors disabled, returns plain ASCII equivalent.
-//
-//nolint:gocyclo
 func Symbol(name string) string {
 	return defaultRenderer.Symbol(name)
 }
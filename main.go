@@ -60,12 +60,19 @@ func main() {
 	defer reporter.HandlePanic(ctx, "erst")
 
 	if execErr := cmd.Execute(); execErr != nil {
+		code := cmd.ExitCode(execErr)
+		// Ctrl-C is not a failure worth reporting; Execute already said so
+		if code == cmd.ExitCodeInterrupted {
+			os.Exit(code)
+		}
 		// Report fatal command errors that were not recovered as panics.
-		if reporter.IsEnabled() {
+		// Reverts, mismatches, bad input and network failures are expected
+		// outcomes, not crashes.
+		if code == cmd.ExitCodeError && reporter.IsEnabled() {
 			stack := debug.Stack()
 			_ = reporter.Send(ctx, execErr, stack, "erst")
 		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", execErr)
-		os.Exit(1)
+		os.Exit(code)
 	}
 }
\ No newline at end of file
@@ -65,6 +65,8 @@ fn send_error(msg: String) {
         flamegraph: None,
         optimization_report: None,
         budget_usage: None,
+        read_keys: vec![],
+        write_keys: vec![],
         source_location: None,
         stack_trace: Some(trace),
         wasm_offset: None,
@@ -225,6 +227,8 @@ fn main() {
             flamegraph: None,
             optimization_report: None,
             budget_usage: None,
+            read_keys: vec![],
+            write_keys: vec![],
             source_location: None,
             stack_trace: None,
         };
@@ -253,6 +257,8 @@ fn main() {
                 flamegraph: None,
                 optimization_report: None,
                 budget_usage: None,
+                read_keys: vec![],
+                write_keys: vec![],
                 source_location: None,
                 stack_trace: None,
                 wasm_offset: None,
@@ -343,6 +349,16 @@ fn main() {
     // Initialize Host
     let sim_host = runner::SimHost::new(None, request.resource_calibration.clone());
     let host = sim_host.inner;
+    if let Some(version) = request.protocol_version {
+        let ledger_info = soroban_env_host::LedgerInfo {
+            protocol_version: version,
+            ..Default::default()
+        };
+        if let Err(e) = host.set_ledger_info(ledger_info) {
+            send_error(format!("Host does not support protocol {}: {:?}", version, e));
+            return;
+        }
+    }
 
     // --- START: Local WASM Loading Integration (Issue #70) ---
     if let Some(path) = &request.wasm_path {
@@ -440,6 +456,8 @@ fn main() {
         memory_usage_percent,
     };
 
+    let (read_keys, write_keys) = storage_footprint(&host);
+
     let optimization_report = if request.enable_optimization_advisor {
         let advisor = GasOptimizationAdvisor::new();
         let metrics = BudgetMetrics {
@@ -567,6 +585,8 @@ fn main() {
                         flamegraph: flamegraph_svg,
                         optimization_report,
                         budget_usage: Some(budget_usage),
+                        read_keys: read_keys.clone(),
+                        write_keys: write_keys.clone(),
                         source_location: None,
                     };
 
@@ -590,6 +610,8 @@ fn main() {
                 flamegraph: flamegraph_svg,
                 optimization_report,
                 budget_usage: Some(budget_usage),
+                read_keys,
+                write_keys,
                 source_location: None,
                 stack_trace: None,
                 // If a WASM with debug symbols was provided, expose the first
@@ -769,6 +791,8 @@ fn main() {
                 flamegraph: None,
                 optimization_report: None,
                 budget_usage: None,
+                read_keys,
+                write_keys,
                 source_location: None,
                 stack_trace: Some(wasm_trace),
                 wasm_offset,
@@ -801,6 +825,8 @@ fn main() {
                 flamegraph: None,
                 optimization_report: None,
                 budget_usage: None,
+                read_keys: vec![],
+                write_keys: vec![],
                 source_location: None,
                 stack_trace: Some(wasm_trace),
                 wasm_offset: None,
@@ -815,6 +841,42 @@ fn main() {
     }
 }
 
+/// Returns the ledger keys the host's storage footprint recorded as
+/// read-only and read-write, base64 XDR encoded and sorted, so callers can
+/// compare the footprint the simulation used against the declared one.
+fn storage_footprint(host: &Host) -> (Vec<String>, Vec<String>) {
+    use soroban_env_host::storage::AccessType;
+    use soroban_env_host::xdr::{Limits, WriteXdr};
+
+    let budget = host.budget_cloned();
+    let footprint = host.with_mut_storage(|storage| {
+        let mut read = Vec::new();
+        let mut write = Vec::new();
+        for (key, access) in storage.footprint.0.iter(&budget)? {
+            match key.to_xdr_base64(Limits::none()) {
+                Ok(encoded) => match access {
+                    AccessType::ReadOnly => read.push(encoded),
+                    AccessType::ReadWrite => write.push(encoded),
+                },
+                Err(e) => eprintln!("Failed to encode footprint key: {e}"),
+            }
+        }
+        Ok((read, write))
+    });
+
+    match footprint {
+        Ok((mut read, mut write)) => {
+            read.sort();
+            write.sort();
+            (read, write)
+        }
+        Err(e) => {
+            eprintln!("Failed to read storage footprint: {e:?}");
+            (vec![], vec![])
+        }
+    }
+}
+
 fn extract_wasm_offset(error_msg: &str) -> Option<u64> {
     // Look for patterns like "@ 0x[HEX]" in the error message
     // Soroban/Wasmi errors often contain stack traces like:
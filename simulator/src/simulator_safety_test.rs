@@ -53,6 +53,8 @@ fn send_error(msg: String) {
         flamegraph: None,
         optimization_report: None,
         budget_usage: None,
+        read_keys: vec![],
+        write_keys: vec![],
         source_location: None,
     };
     println!("{}", serde_json::to_string(&res).unwrap());
@@ -152,6 +154,8 @@ fn main() {
             flamegraph: None,
             optimization_report: None,
             budget_usage: None,
+            read_keys: vec![],
+            write_keys: vec![],
             source_location: None,
         };
         println!("{}", serde_json::to_string(&res).unwrap());
@@ -173,6 +177,8 @@ fn main() {
                 flamegraph: None,
                 optimization_report: None,
                 budget_usage: None,
+                read_keys: vec![],
+                write_keys: vec![],
                 source_location: None,
             };
             println!("{}", serde_json::to_string(&res).unwrap());
@@ -418,6 +424,8 @@ fn main() {
                 flamegraph: flamegraph_svg,
                 optimization_report,
                 budget_usage: Some(budget_usage),
+                read_keys: vec![],
+                write_keys: vec![],
                 source_location: None,
             };
 
@@ -444,6 +452,8 @@ fn main() {
                 flamegraph: None,
                 optimization_report: None,
                 budget_usage: None,
+                read_keys: vec![],
+                write_keys: vec![],
                 source_location: None,
             };
             println!("{}", serde_json::to_string(&response).unwrap());
@@ -467,6 +477,8 @@ fn main() {
                 flamegraph: None,
                 optimization_report: None,
                 budget_usage: None,
+                read_keys: vec![],
+                write_keys: vec![],
                 source_location: None,
             };
             println!("{}", serde_json::to_string(&response).unwrap());
@@ -26,6 +26,9 @@ pub struct SimulationRequest {
     /// Optional hard memory limit in bytes. If set, the simulator will panic
     /// when memory consumption exceeds this limit, simulating live network constraints.
     pub memory_limit: Option<u64>,
+    /// Protocol version to run the host under. Unset keeps the host's own
+    /// default.
+    pub protocol_version: Option<u32>,
 }
 
 #[derive(Debug, Deserialize, Serialize, Clone)]
@@ -55,6 +58,12 @@ pub struct SimulationResponse {
     #[serde(skip_serializing_if = "Option::is_none")]
     pub stack_trace: Option<WasmStackTrace>,
     pub wasm_offset: Option<u64>,
+    /// Ledger keys the host's footprint recorded as read-only, base64 XDR.
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub read_keys: Vec<String>,
+    /// Ledger keys the host's footprint recorded as read-write, base64 XDR.
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub write_keys: Vec<String>,
 }
 
 #[derive(Debug, Serialize)]